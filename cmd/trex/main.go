@@ -1,17 +1,21 @@
 package main
 
 import (
-	"github.com/golang/glog"
-
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
 	pkgcmd "github.com/openshift-online/rh-trex-ai/pkg/cmd"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 
 	_ "github.com/openshift-online/rh-trex-ai/cmd/trex/environments"
+	_ "github.com/openshift-online/rh-trex-ai/plugins/auditevents"
 	_ "github.com/openshift-online/rh-trex-ai/plugins/dinosaurs"
 	_ "github.com/openshift-online/rh-trex-ai/plugins/events"
 	_ "github.com/openshift-online/rh-trex-ai/plugins/fossils"
 	_ "github.com/openshift-online/rh-trex-ai/plugins/generic"
+	_ "github.com/openshift-online/rh-trex-ai/plugins/idempotencykeys"
+	_ "github.com/openshift-online/rh-trex-ai/plugins/ratelimits"
+	_ "github.com/openshift-online/rh-trex-ai/plugins/rolebindings"
 	_ "github.com/openshift-online/rh-trex-ai/plugins/scientists"
+	_ "github.com/openshift-online/rh-trex-ai/plugins/webhooks"
 )
 
 // nolint
@@ -26,6 +30,6 @@ func main() {
 	)
 
 	if err := rootCmd.Execute(); err != nil {
-		glog.Fatalf("error running command: %v", err)
+		logger.Fatalf("error running command: %v", err)
 	}
 }
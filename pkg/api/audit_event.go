@@ -0,0 +1,45 @@
+package api
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AuditAction string
+
+const (
+	AuditCreateAction  AuditAction = "Create"
+	AuditUpdateAction  AuditAction = "Update"
+	AuditDeleteAction  AuditAction = "Delete"
+	AuditRestoreAction AuditAction = "Restore"
+	AuditPurgeAction   AuditAction = "Purge"
+	AuditLinkAction    AuditAction = "Link"
+	AuditUnlinkAction  AuditAction = "Unlink"
+)
+
+// AuditEvent records who changed what. Audit events are append-only: unlike events they are never
+// reconciled or updated, and are only deleted once they are older than the audit retention.
+type AuditEvent struct {
+	ID        string `gorm:"primaryKey"`
+	CreatedAt time.Time
+	Source    string      // the Kind, e.g. Dinosaurs
+	SourceID  string      // primary key of the changed row
+	Action    AuditAction // Create|Update|Delete|Restore|Purge|Link|Unlink
+	// Username and OperationID identify the request that made the change, they are empty for changes
+	// made by controllers
+	Username       string
+	OperationID    string
+	OrganizationID string
+	// Before and After hold the JSON of the row before and after the change, Before is empty for
+	// creates and After for deletes
+	Before string
+	After  string
+}
+
+type AuditEventList []*AuditEvent
+
+func (d *AuditEvent) BeforeCreate(tx *gorm.DB) error {
+	d.ID = NewID()
+	return nil
+}
@@ -6,8 +6,8 @@ import (
 	"net/http"
 	"sync"
 
-	"github.com/golang/glog"
 	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 )
 
 // SendNotFound sends a 404 response with some details about the non existing resource.
@@ -39,7 +39,7 @@ func SendNotFound(w http.ResponseWriter, r *http.Request) {
 	_, err = w.Write(data)
 	if err != nil {
 		err = fmt.Errorf("can't send response body for request '%s'", r.URL.Path)
-		glog.Error(err)
+		logger.Errorf("%v", err)
 
 		return
 	}
@@ -61,7 +61,7 @@ func SendUnauthorized(w http.ResponseWriter, r *http.Request, message string) {
 	_, err = w.Write(data)
 	if err != nil {
 		err = fmt.Errorf("can't send response body for request '%s'", r.URL.Path)
-		glog.Error(err)
+		logger.Errorf("%v", err)
 
 		return
 	}
@@ -77,7 +77,7 @@ func SendPanic(w http.ResponseWriter, r *http.Request) {
 			r.URL.Path,
 			err.Error(),
 		)
-		glog.Error(err)
+		logger.Errorf("%v", err)
 
 	}
 }
@@ -105,7 +105,7 @@ func getPanicBody() []byte {
 				"can't create the panic error body: %s",
 				err.Error(),
 			)
-			glog.Error(err)
+			logger.Errorf("%v", err)
 
 			panic(err)
 		}
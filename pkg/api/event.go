@@ -1,6 +1,7 @@
 package api
 
 import (
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -20,6 +21,63 @@ type Event struct {
 	SourceID       string     // primary key of MyTable
 	EventType      EventType  // Add|Update|Delete
 	ReconciledDate *time.Time `json:"gorm:null"`
+	// Seq numbers the events in the order they were recorded. The database assigns it on insert and it
+	// never changes, so watches replay and resume by it.
+	Seq int64 `gorm:"autoIncrement;<-:create"`
+	// ChangedFields is the comma separated field mask of an update, the fields it changed by their name in
+	// the API. It is empty when the update isn't known field by field.
+	ChangedFields string
+	// TraceParent and TraceState are the W3C trace context of the request that produced the event, so the
+	// span of the controller handling it links back to that request
+	TraceParent string
+	TraceState  string
+
+	// Attempts counts failed handler runs. NextAttemptAt holds back retries until the backoff has
+	// elapsed and DeadLetteredAt is set once the controller gives up on the event.
+	Attempts       int
+	LastError      string
+	NextAttemptAt  *time.Time
+	DeadLetteredAt *time.Time
+	// ReprocessedBy and ReprocessedAt record the user who last handed the event back to the controllers
+	// with a reprocess, and when
+	ReprocessedBy string
+	ReprocessedAt *time.Time
+}
+
+// DeadLettered returns true when the event exhausted its retries and will not be processed again
+// until it is re-driven
+func (d *Event) DeadLettered() bool {
+	return d.DeadLetteredAt != nil
+}
+
+// FieldMask returns the fields an update changed, nil when they aren't known
+func (d *Event) FieldMask() []string {
+	if d.ChangedFields == "" {
+		return nil
+	}
+	return strings.Split(d.ChangedFields, ",")
+}
+
+// EventPause pauses the kind controllers for the events of Source. The events are still recorded while the
+// controllers are paused and processed once they are resumed.
+type EventPause struct {
+	Source    string `gorm:"primaryKey"`
+	PausedBy  string
+	CreatedAt time.Time
+}
+
+// EventStats is the backlog of the kind controllers for the events of Source
+type EventStats struct {
+	Source string
+	Total  int64
+	// Pending counts the events waiting to be processed, Retrying those of them that failed before
+	Pending      int64
+	Retrying     int64
+	DeadLettered int64
+	// OldestPendingAt is the creation time of the oldest pending event, nil without pending events
+	OldestPendingAt *time.Time
+	// Pause is set while the controllers of Source are paused
+	Pause *EventPause `gorm:"-"`
 }
 
 type EventList []*Event
@@ -35,5 +93,6 @@ func (l EventList) Index() EventIndex {
 
 func (d *Event) BeforeCreate(tx *gorm.DB) error {
 	d.ID = NewID()
+	d.ResourceVersion = 1
 	return nil
 }
@@ -29,6 +29,8 @@ const (
 	EventType_EVENT_TYPE_CREATED     EventType = 1
 	EventType_EVENT_TYPE_UPDATED     EventType = 2
 	EventType_EVENT_TYPE_DELETED     EventType = 3
+	// ends a watch that fell behind or can't be resumed, see the event_id of the watch event
+	EventType_EVENT_TYPE_RESYNC_REQUIRED EventType = 4
 )
 
 // Enum value maps for EventType.
@@ -38,12 +40,14 @@ var (
 		1: "EVENT_TYPE_CREATED",
 		2: "EVENT_TYPE_UPDATED",
 		3: "EVENT_TYPE_DELETED",
+		4: "EVENT_TYPE_RESYNC_REQUIRED",
 	}
 	EventType_value = map[string]int32{
-		"EVENT_TYPE_UNSPECIFIED": 0,
-		"EVENT_TYPE_CREATED":     1,
-		"EVENT_TYPE_UPDATED":     2,
-		"EVENT_TYPE_DELETED":     3,
+		"EVENT_TYPE_UNSPECIFIED":     0,
+		"EVENT_TYPE_CREATED":         1,
+		"EVENT_TYPE_UPDATED":         2,
+		"EVENT_TYPE_DELETED":         3,
+		"EVENT_TYPE_RESYNC_REQUIRED": 4,
 	}
 )
 
@@ -75,12 +79,15 @@ func (EventType) EnumDescriptor() ([]byte, []int) {
 }
 
 type ObjectReference struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Kind          string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
-	Href          string                 `protobuf:"bytes,5,opt,name=href,proto3" json:"href,omitempty"`
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Kind            string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	Href            string                 `protobuf:"bytes,5,opt,name=href,proto3" json:"href,omitempty"`
+	ResourceVersion int64                  `protobuf:"varint,6,opt,name=resource_version,json=resourceVersion,proto3" json:"resource_version,omitempty"`
+	// set when the record is soft-deleted and can still be restored
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -150,11 +157,27 @@ func (x *ObjectReference) GetHref() string {
 	return ""
 }
 
+func (x *ObjectReference) GetResourceVersion() int64 {
+	if x != nil {
+		return x.ResourceVersion
+	}
+	return 0
+}
+
+func (x *ObjectReference) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
 type ListMeta struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	Size          int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
-	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Page  int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Size  int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Total int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	// token to request the next page with, empty on the last page
+	ContinueToken string `protobuf:"bytes,4,opt,name=continue_token,json=continueToken,proto3" json:"continue_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -210,6 +233,13 @@ func (x *ListMeta) GetTotal() int32 {
 	return 0
 }
 
+func (x *ListMeta) GetContinueToken() string {
+	if x != nil {
+		return x.ContinueToken
+	}
+	return ""
+}
+
 type Error struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -294,12 +324,138 @@ func (x *Error) GetOperationId() string {
 	return ""
 }
 
+// BatchItemError is the error of one item of a batch RPC
+type BatchItemError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// google.rpc.Code of the item, as the RPC would have returned on its own
+	Code    int32  `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// The fields of the item that failed validation, as the google.rpc.BadRequest details of the RPC would list them
+	FieldViolations []*FieldViolation `protobuf:"bytes,3,rep,name=field_violations,json=fieldViolations,proto3" json:"field_violations,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BatchItemError) Reset() {
+	*x = BatchItemError{}
+	mi := &file_rh_trex_v1_common_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchItemError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchItemError) ProtoMessage() {}
+
+func (x *BatchItemError) ProtoReflect() protoreflect.Message {
+	mi := &file_rh_trex_v1_common_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchItemError.ProtoReflect.Descriptor instead.
+func (*BatchItemError) Descriptor() ([]byte, []int) {
+	return file_rh_trex_v1_common_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchItemError) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *BatchItemError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BatchItemError) GetFieldViolations() []*FieldViolation {
+	if x != nil {
+		return x.FieldViolations
+	}
+	return nil
+}
+
+// FieldViolation is a field of a request that failed validation
+type FieldViolation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// field is the name of the field, e.g. discovery_location or items[1].estimated_age
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// code is the constraint the field violates, e.g. required, max_length or immutable
+	Code          string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldViolation) Reset() {
+	*x = FieldViolation{}
+	mi := &file_rh_trex_v1_common_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldViolation) ProtoMessage() {}
+
+func (x *FieldViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_rh_trex_v1_common_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldViolation.ProtoReflect.Descriptor instead.
+func (*FieldViolation) Descriptor() ([]byte, []int) {
+	return file_rh_trex_v1_common_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FieldViolation) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldViolation) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *FieldViolation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 var File_rh_trex_v1_common_proto protoreflect.FileDescriptor
 
 const file_rh_trex_v1_common_proto_rawDesc = "" +
 	"\n" +
 	"\x17rh_trex/v1/common.proto\x12\n" +
-	"rh_trex.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xbf\x01\n" +
+	"rh_trex.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa5\x02\n" +
 	"\x0fObjectReference\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
 	"\n" +
@@ -307,23 +463,36 @@ const file_rh_trex_v1_common_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x12\n" +
 	"\x04kind\x18\x04 \x01(\tR\x04kind\x12\x12\n" +
-	"\x04href\x18\x05 \x01(\tR\x04href\"H\n" +
+	"\x04href\x18\x05 \x01(\tR\x04href\x12)\n" +
+	"\x10resource_version\x18\x06 \x01(\x03R\x0fresourceVersion\x129\n" +
+	"\n" +
+	"deleted_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"o\n" +
 	"\bListMeta\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x12\n" +
 	"\x04size\x18\x02 \x01(\x05R\x04size\x12\x14\n" +
-	"\x05total\x18\x03 \x01(\x05R\x05total\"\x8e\x01\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x12%\n" +
+	"\x0econtinue_token\x18\x04 \x01(\tR\rcontinueToken\"\x8e\x01\n" +
 	"\x05Error\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x12\n" +
 	"\x04href\x18\x03 \x01(\tR\x04href\x12\x12\n" +
 	"\x04code\x18\x04 \x01(\x05R\x04code\x12\x16\n" +
 	"\x06reason\x18\x05 \x01(\tR\x06reason\x12!\n" +
-	"\foperation_id\x18\x06 \x01(\tR\voperationId*o\n" +
+	"\foperation_id\x18\x06 \x01(\tR\voperationId\"\x85\x01\n" +
+	"\x0eBatchItemError\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\x05R\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12E\n" +
+	"\x10field_violations\x18\x03 \x03(\v2\x1a.rh_trex.v1.FieldViolationR\x0ffieldViolations\"T\n" +
+	"\x0eFieldViolation\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage*\x8f\x01\n" +
 	"\tEventType\x12\x1a\n" +
 	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x16\n" +
 	"\x12EVENT_TYPE_CREATED\x10\x01\x12\x16\n" +
 	"\x12EVENT_TYPE_UPDATED\x10\x02\x12\x16\n" +
-	"\x12EVENT_TYPE_DELETED\x10\x03BKZIgithub.com/openshift-online/rh-trex-ai/pkg/api/grpc/rh_trex/v1;rh_trex_v1b\x06proto3"
+	"\x12EVENT_TYPE_DELETED\x10\x03\x12\x1e\n" +
+	"\x1aEVENT_TYPE_RESYNC_REQUIRED\x10\x04BKZIgithub.com/openshift-online/rh-trex-ai/pkg/api/grpc/rh_trex/v1;rh_trex_v1b\x06proto3"
 
 var (
 	file_rh_trex_v1_common_proto_rawDescOnce sync.Once
@@ -338,22 +507,26 @@ func file_rh_trex_v1_common_proto_rawDescGZIP() []byte {
 }
 
 var file_rh_trex_v1_common_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_rh_trex_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_rh_trex_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_rh_trex_v1_common_proto_goTypes = []any{
 	(EventType)(0),                // 0: rh_trex.v1.EventType
 	(*ObjectReference)(nil),       // 1: rh_trex.v1.ObjectReference
 	(*ListMeta)(nil),              // 2: rh_trex.v1.ListMeta
 	(*Error)(nil),                 // 3: rh_trex.v1.Error
-	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+	(*BatchItemError)(nil),        // 4: rh_trex.v1.BatchItemError
+	(*FieldViolation)(nil),        // 5: rh_trex.v1.FieldViolation
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
 }
 var file_rh_trex_v1_common_proto_depIdxs = []int32{
-	4, // 0: rh_trex.v1.ObjectReference.created_at:type_name -> google.protobuf.Timestamp
-	4, // 1: rh_trex.v1.ObjectReference.updated_at:type_name -> google.protobuf.Timestamp
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	6, // 0: rh_trex.v1.ObjectReference.created_at:type_name -> google.protobuf.Timestamp
+	6, // 1: rh_trex.v1.ObjectReference.updated_at:type_name -> google.protobuf.Timestamp
+	6, // 2: rh_trex.v1.ObjectReference.deleted_at:type_name -> google.protobuf.Timestamp
+	5, // 3: rh_trex.v1.BatchItemError.field_violations:type_name -> rh_trex.v1.FieldViolation
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_rh_trex_v1_common_proto_init() }
@@ -367,7 +540,7 @@ func file_rh_trex_v1_common_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rh_trex_v1_common_proto_rawDesc), len(file_rh_trex_v1_common_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   3,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
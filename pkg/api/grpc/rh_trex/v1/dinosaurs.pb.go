@@ -9,6 +9,7 @@ package rh_trex_v1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -118,8 +119,10 @@ func (x *CreateDinosaurRequest) GetSpecies() string {
 }
 
 type GetDinosaurRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// the fields of the dinosaur to return, e.g. species or metadata.id. Every field is returned without it.
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -161,10 +164,22 @@ func (x *GetDinosaurRequest) GetId() string {
 	return ""
 }
 
+func (x *GetDinosaurRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
 type UpdateDinosaurRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Species       *string                `protobuf:"bytes,2,opt,name=species,proto3,oneof" json:"species,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Species *string                `protobuf:"bytes,2,opt,name=species,proto3,oneof" json:"species,omitempty"`
+	// When set, the update only succeeds if the stored resource version still matches.
+	ResourceVersion *int64 `protobuf:"varint,3,opt,name=resource_version,json=resourceVersion,proto3,oneof" json:"resource_version,omitempty"`
+	// the fields to update, by their proto name. Fields it names that the request leaves unset are cleared.
+	// Without it the fields the request sets are updated.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -213,6 +228,20 @@ func (x *UpdateDinosaurRequest) GetSpecies() string {
 	return ""
 }
 
+func (x *UpdateDinosaurRequest) GetResourceVersion() int64 {
+	if x != nil && x.ResourceVersion != nil {
+		return *x.ResourceVersion
+	}
+	return 0
+}
+
+func (x *UpdateDinosaurRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
 type DeleteDinosaurRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -258,9 +287,21 @@ func (x *DeleteDinosaurRequest) GetId() string {
 }
 
 type ListDinosaursRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	Size          int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Page  int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Size  int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// continue_token of the previous page; resumes right after it and ignores page
+	ContinueToken string `protobuf:"bytes,3,opt,name=continue_token,json=continueToken,proto3" json:"continue_token,omitempty"`
+	// omit the total count, which is expensive on large tables
+	SkipCount bool `protobuf:"varint,4,opt,name=skip_count,json=skipCount,proto3" json:"skip_count,omitempty"`
+	// include soft-deleted records, which carry metadata.deleted_at
+	IncludeDeleted bool `protobuf:"varint,5,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// TSL search the dinosaurs have to match, on the same fields as the REST search
+	Search string `protobuf:"bytes,6,opt,name=search,proto3" json:"search,omitempty"`
+	// the order of the dinosaurs, like the orderBy of REST, e.g. "created_at desc"
+	OrderBy []string `protobuf:"bytes,7,rep,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// the fields of the dinosaurs to return, see GetDinosaurRequest
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,8,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -309,6 +350,48 @@ func (x *ListDinosaursRequest) GetSize() int32 {
 	return 0
 }
 
+func (x *ListDinosaursRequest) GetContinueToken() string {
+	if x != nil {
+		return x.ContinueToken
+	}
+	return ""
+}
+
+func (x *ListDinosaursRequest) GetSkipCount() bool {
+	if x != nil {
+		return x.SkipCount
+	}
+	return false
+}
+
+func (x *ListDinosaursRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *ListDinosaursRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListDinosaursRequest) GetOrderBy() []string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return nil
+}
+
+func (x *ListDinosaursRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
 type ListDinosaursResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Items         []*Dinosaur            `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
@@ -398,7 +481,15 @@ func (*DeleteDinosaurResponse) Descriptor() ([]byte, []int) {
 }
 
 type WatchDinosaursRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// event_id of the last event the client received. The events since are replayed before the live
+	// events; if the event is no longer kept the stream ends with EVENT_TYPE_RESYNC_REQUIRED right away.
+	ResumeFrom string `protobuf:"bytes,1,opt,name=resume_from,json=resumeFrom,proto3" json:"resume_from,omitempty"`
+	// TSL search the dinosaurs have to match to be sent, on the same fields as the REST search
+	Filter string `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// event_seq of the last event the client received, to resume from instead of resume_from. It numbers
+	// the events of all Kinds and is not the resource_version of a resource.
+	EventSeq      int64 `protobuf:"varint,3,opt,name=event_seq,json=eventSeq,proto3" json:"event_seq,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -433,11 +524,39 @@ func (*WatchDinosaursRequest) Descriptor() ([]byte, []int) {
 	return file_rh_trex_v1_dinosaurs_proto_rawDescGZIP(), []int{8}
 }
 
+func (x *WatchDinosaursRequest) GetResumeFrom() string {
+	if x != nil {
+		return x.ResumeFrom
+	}
+	return ""
+}
+
+func (x *WatchDinosaursRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *WatchDinosaursRequest) GetEventSeq() int64 {
+	if x != nil {
+		return x.EventSeq
+	}
+	return 0
+}
+
 type DinosaurWatchEvent struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=rh_trex.v1.EventType" json:"type,omitempty"`
-	Dinosaur      *Dinosaur              `protobuf:"bytes,2,opt,name=dinosaur,proto3" json:"dinosaur,omitempty"`
-	ResourceId    string                 `protobuf:"bytes,3,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Type       EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=rh_trex.v1.EventType" json:"type,omitempty"`
+	Dinosaur   *Dinosaur              `protobuf:"bytes,2,opt,name=dinosaur,proto3" json:"dinosaur,omitempty"`
+	ResourceId string                 `protobuf:"bytes,3,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	// id of the event, to resume the watch from. For EVENT_TYPE_RESYNC_REQUIRED it is the last event
+	// sent, or empty if the watch can't be resumed and the client has to list the dinosaurs again.
+	EventId string `protobuf:"bytes,4,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// the fields an update changed, by their proto name. Empty when the update isn't known field by field.
+	ChangedFields []string `protobuf:"bytes,5,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"`
+	// sequence number of the event, to resume the watch from like event_id
+	EventSeq      int64 `protobuf:"varint,6,opt,name=event_seq,json=eventSeq,proto3" json:"event_seq,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -493,46 +612,368 @@ func (x *DinosaurWatchEvent) GetResourceId() string {
 	return ""
 }
 
+func (x *DinosaurWatchEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *DinosaurWatchEvent) GetChangedFields() []string {
+	if x != nil {
+		return x.ChangedFields
+	}
+	return nil
+}
+
+func (x *DinosaurWatchEvent) GetEventSeq() int64 {
+	if x != nil {
+		return x.EventSeq
+	}
+	return 0
+}
+
+type BatchCreateDinosaursRequest struct {
+	state    protoimpl.MessageState   `protogen:"open.v1"`
+	Requests []*CreateDinosaurRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	// abort the whole batch on the first failed item instead of reporting per item results
+	AllOrNothing  bool `protobuf:"varint,2,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateDinosaursRequest) Reset() {
+	*x = BatchCreateDinosaursRequest{}
+	mi := &file_rh_trex_v1_dinosaurs_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateDinosaursRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateDinosaursRequest) ProtoMessage() {}
+
+func (x *BatchCreateDinosaursRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rh_trex_v1_dinosaurs_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateDinosaursRequest.ProtoReflect.Descriptor instead.
+func (*BatchCreateDinosaursRequest) Descriptor() ([]byte, []int) {
+	return file_rh_trex_v1_dinosaurs_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BatchCreateDinosaursRequest) GetRequests() []*CreateDinosaurRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *BatchCreateDinosaursRequest) GetAllOrNothing() bool {
+	if x != nil {
+		return x.AllOrNothing
+	}
+	return false
+}
+
+type BatchUpdateDinosaursRequest struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Requests      []*UpdateDinosaurRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	AllOrNothing  bool                     `protobuf:"varint,2,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateDinosaursRequest) Reset() {
+	*x = BatchUpdateDinosaursRequest{}
+	mi := &file_rh_trex_v1_dinosaurs_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateDinosaursRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateDinosaursRequest) ProtoMessage() {}
+
+func (x *BatchUpdateDinosaursRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rh_trex_v1_dinosaurs_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateDinosaursRequest.ProtoReflect.Descriptor instead.
+func (*BatchUpdateDinosaursRequest) Descriptor() ([]byte, []int) {
+	return file_rh_trex_v1_dinosaurs_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BatchUpdateDinosaursRequest) GetRequests() []*UpdateDinosaurRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *BatchUpdateDinosaursRequest) GetAllOrNothing() bool {
+	if x != nil {
+		return x.AllOrNothing
+	}
+	return false
+}
+
+type BatchDeleteDinosaursRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	AllOrNothing  bool                   `protobuf:"varint,2,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeleteDinosaursRequest) Reset() {
+	*x = BatchDeleteDinosaursRequest{}
+	mi := &file_rh_trex_v1_dinosaurs_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteDinosaursRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteDinosaursRequest) ProtoMessage() {}
+
+func (x *BatchDeleteDinosaursRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rh_trex_v1_dinosaurs_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteDinosaursRequest.ProtoReflect.Descriptor instead.
+func (*BatchDeleteDinosaursRequest) Descriptor() ([]byte, []int) {
+	return file_rh_trex_v1_dinosaurs_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BatchDeleteDinosaursRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *BatchDeleteDinosaursRequest) GetAllOrNothing() bool {
+	if x != nil {
+		return x.AllOrNothing
+	}
+	return false
+}
+
+type DinosaurBatchResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// set for successful creates and updates
+	Dinosaur *Dinosaur `protobuf:"bytes,2,opt,name=dinosaur,proto3" json:"dinosaur,omitempty"`
+	// set for failed items
+	Error         *BatchItemError `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DinosaurBatchResult) Reset() {
+	*x = DinosaurBatchResult{}
+	mi := &file_rh_trex_v1_dinosaurs_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DinosaurBatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DinosaurBatchResult) ProtoMessage() {}
+
+func (x *DinosaurBatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_rh_trex_v1_dinosaurs_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DinosaurBatchResult.ProtoReflect.Descriptor instead.
+func (*DinosaurBatchResult) Descriptor() ([]byte, []int) {
+	return file_rh_trex_v1_dinosaurs_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DinosaurBatchResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DinosaurBatchResult) GetDinosaur() *Dinosaur {
+	if x != nil {
+		return x.Dinosaur
+	}
+	return nil
+}
+
+func (x *DinosaurBatchResult) GetError() *BatchItemError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type DinosaurBatchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// one result per item, in request order
+	Results       []*DinosaurBatchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DinosaurBatchResponse) Reset() {
+	*x = DinosaurBatchResponse{}
+	mi := &file_rh_trex_v1_dinosaurs_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DinosaurBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DinosaurBatchResponse) ProtoMessage() {}
+
+func (x *DinosaurBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rh_trex_v1_dinosaurs_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DinosaurBatchResponse.ProtoReflect.Descriptor instead.
+func (*DinosaurBatchResponse) Descriptor() ([]byte, []int) {
+	return file_rh_trex_v1_dinosaurs_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DinosaurBatchResponse) GetResults() []*DinosaurBatchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
 var File_rh_trex_v1_dinosaurs_proto protoreflect.FileDescriptor
 
 const file_rh_trex_v1_dinosaurs_proto_rawDesc = "" +
 	"\n" +
 	"\x1arh_trex/v1/dinosaurs.proto\x12\n" +
-	"rh_trex.v1\x1a\x17rh_trex/v1/common.proto\"]\n" +
+	"rh_trex.v1\x1a google/protobuf/field_mask.proto\x1a\x17rh_trex/v1/common.proto\"]\n" +
 	"\bDinosaur\x127\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x1b.rh_trex.v1.ObjectReferenceR\bmetadata\x12\x18\n" +
 	"\aspecies\x18\x02 \x01(\tR\aspecies\"1\n" +
 	"\x15CreateDinosaurRequest\x12\x18\n" +
-	"\aspecies\x18\x01 \x01(\tR\aspecies\"$\n" +
+	"\aspecies\x18\x01 \x01(\tR\aspecies\"]\n" +
 	"\x12GetDinosaurRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"R\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x127\n" +
+	"\tread_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\"\xd4\x01\n" +
 	"\x15UpdateDinosaurRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
-	"\aspecies\x18\x02 \x01(\tH\x00R\aspecies\x88\x01\x01B\n" +
+	"\aspecies\x18\x02 \x01(\tH\x00R\aspecies\x88\x01\x01\x12.\n" +
+	"\x10resource_version\x18\x03 \x01(\x03H\x01R\x0fresourceVersion\x88\x01\x01\x12;\n" +
+	"\vupdate_mask\x18\x04 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMaskB\n" +
 	"\n" +
-	"\b_species\"'\n" +
+	"\b_speciesB\x13\n" +
+	"\x11_resource_version\"'\n" +
 	"\x15DeleteDinosaurRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\">\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x99\x02\n" +
 	"\x14ListDinosaursRequest\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x12\n" +
-	"\x04size\x18\x02 \x01(\x05R\x04size\"u\n" +
+	"\x04size\x18\x02 \x01(\x05R\x04size\x12%\n" +
+	"\x0econtinue_token\x18\x03 \x01(\tR\rcontinueToken\x12\x1d\n" +
+	"\n" +
+	"skip_count\x18\x04 \x01(\bR\tskipCount\x12'\n" +
+	"\x0finclude_deleted\x18\x05 \x01(\bR\x0eincludeDeleted\x12\x16\n" +
+	"\x06search\x18\x06 \x01(\tR\x06search\x12\x19\n" +
+	"\border_by\x18\a \x03(\tR\aorderBy\x127\n" +
+	"\tread_mask\x18\b \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\"u\n" +
 	"\x15ListDinosaursResponse\x12*\n" +
 	"\x05items\x18\x01 \x03(\v2\x14.rh_trex.v1.DinosaurR\x05items\x120\n" +
 	"\bmetadata\x18\x02 \x01(\v2\x14.rh_trex.v1.ListMetaR\bmetadata\"\x18\n" +
-	"\x16DeleteDinosaurResponse\"\x17\n" +
-	"\x15WatchDinosaursRequest\"\x92\x01\n" +
+	"\x16DeleteDinosaurResponse\"m\n" +
+	"\x15WatchDinosaursRequest\x12\x1f\n" +
+	"\vresume_from\x18\x01 \x01(\tR\n" +
+	"resumeFrom\x12\x16\n" +
+	"\x06filter\x18\x02 \x01(\tR\x06filter\x12\x1b\n" +
+	"\tevent_seq\x18\x03 \x01(\x03R\beventSeq\"\xf1\x01\n" +
 	"\x12DinosaurWatchEvent\x12)\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x15.rh_trex.v1.EventTypeR\x04type\x120\n" +
 	"\bdinosaur\x18\x02 \x01(\v2\x14.rh_trex.v1.DinosaurR\bdinosaur\x12\x1f\n" +
 	"\vresource_id\x18\x03 \x01(\tR\n" +
-	"resourceId2\xf2\x03\n" +
+	"resourceId\x12\x19\n" +
+	"\bevent_id\x18\x04 \x01(\tR\aeventId\x12%\n" +
+	"\x0echanged_fields\x18\x05 \x03(\tR\rchangedFields\x12\x1b\n" +
+	"\tevent_seq\x18\x06 \x01(\x03R\beventSeq\"\x82\x01\n" +
+	"\x1bBatchCreateDinosaursRequest\x12=\n" +
+	"\brequests\x18\x01 \x03(\v2!.rh_trex.v1.CreateDinosaurRequestR\brequests\x12$\n" +
+	"\x0eall_or_nothing\x18\x02 \x01(\bR\fallOrNothing\"\x82\x01\n" +
+	"\x1bBatchUpdateDinosaursRequest\x12=\n" +
+	"\brequests\x18\x01 \x03(\v2!.rh_trex.v1.UpdateDinosaurRequestR\brequests\x12$\n" +
+	"\x0eall_or_nothing\x18\x02 \x01(\bR\fallOrNothing\"U\n" +
+	"\x1bBatchDeleteDinosaursRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12$\n" +
+	"\x0eall_or_nothing\x18\x02 \x01(\bR\fallOrNothing\"\x89\x01\n" +
+	"\x13DinosaurBatchResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x120\n" +
+	"\bdinosaur\x18\x02 \x01(\v2\x14.rh_trex.v1.DinosaurR\bdinosaur\x120\n" +
+	"\x05error\x18\x03 \x01(\v2\x1a.rh_trex.v1.BatchItemErrorR\x05error\"R\n" +
+	"\x15DinosaurBatchResponse\x129\n" +
+	"\aresults\x18\x01 \x03(\v2\x1f.rh_trex.v1.DinosaurBatchResultR\aresults2\x9e\x06\n" +
 	"\x0fDinosaurService\x12C\n" +
 	"\vGetDinosaur\x12\x1e.rh_trex.v1.GetDinosaurRequest\x1a\x14.rh_trex.v1.Dinosaur\x12I\n" +
 	"\x0eCreateDinosaur\x12!.rh_trex.v1.CreateDinosaurRequest\x1a\x14.rh_trex.v1.Dinosaur\x12I\n" +
 	"\x0eUpdateDinosaur\x12!.rh_trex.v1.UpdateDinosaurRequest\x1a\x14.rh_trex.v1.Dinosaur\x12W\n" +
 	"\x0eDeleteDinosaur\x12!.rh_trex.v1.DeleteDinosaurRequest\x1a\".rh_trex.v1.DeleteDinosaurResponse\x12T\n" +
 	"\rListDinosaurs\x12 .rh_trex.v1.ListDinosaursRequest\x1a!.rh_trex.v1.ListDinosaursResponse\x12U\n" +
-	"\x0eWatchDinosaurs\x12!.rh_trex.v1.WatchDinosaursRequest\x1a\x1e.rh_trex.v1.DinosaurWatchEvent0\x01BKZIgithub.com/openshift-online/rh-trex-ai/pkg/api/grpc/rh_trex/v1;rh_trex_v1b\x06proto3"
+	"\x0eWatchDinosaurs\x12!.rh_trex.v1.WatchDinosaursRequest\x1a\x1e.rh_trex.v1.DinosaurWatchEvent0\x01\x12b\n" +
+	"\x14BatchCreateDinosaurs\x12'.rh_trex.v1.BatchCreateDinosaursRequest\x1a!.rh_trex.v1.DinosaurBatchResponse\x12b\n" +
+	"\x14BatchUpdateDinosaurs\x12'.rh_trex.v1.BatchUpdateDinosaursRequest\x1a!.rh_trex.v1.DinosaurBatchResponse\x12b\n" +
+	"\x14BatchDeleteDinosaurs\x12'.rh_trex.v1.BatchDeleteDinosaursRequest\x1a!.rh_trex.v1.DinosaurBatchResponseBKZIgithub.com/openshift-online/rh-trex-ai/pkg/api/grpc/rh_trex/v1;rh_trex_v1b\x06proto3"
 
 var (
 	file_rh_trex_v1_dinosaurs_proto_rawDescOnce sync.Once
@@ -546,45 +987,66 @@ func file_rh_trex_v1_dinosaurs_proto_rawDescGZIP() []byte {
 	return file_rh_trex_v1_dinosaurs_proto_rawDescData
 }
 
-var file_rh_trex_v1_dinosaurs_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_rh_trex_v1_dinosaurs_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_rh_trex_v1_dinosaurs_proto_goTypes = []any{
-	(*Dinosaur)(nil),               // 0: rh_trex.v1.Dinosaur
-	(*CreateDinosaurRequest)(nil),  // 1: rh_trex.v1.CreateDinosaurRequest
-	(*GetDinosaurRequest)(nil),     // 2: rh_trex.v1.GetDinosaurRequest
-	(*UpdateDinosaurRequest)(nil),  // 3: rh_trex.v1.UpdateDinosaurRequest
-	(*DeleteDinosaurRequest)(nil),  // 4: rh_trex.v1.DeleteDinosaurRequest
-	(*ListDinosaursRequest)(nil),   // 5: rh_trex.v1.ListDinosaursRequest
-	(*ListDinosaursResponse)(nil),  // 6: rh_trex.v1.ListDinosaursResponse
-	(*DeleteDinosaurResponse)(nil), // 7: rh_trex.v1.DeleteDinosaurResponse
-	(*WatchDinosaursRequest)(nil),  // 8: rh_trex.v1.WatchDinosaursRequest
-	(*DinosaurWatchEvent)(nil),     // 9: rh_trex.v1.DinosaurWatchEvent
-	(*ObjectReference)(nil),        // 10: rh_trex.v1.ObjectReference
-	(*ListMeta)(nil),               // 11: rh_trex.v1.ListMeta
-	(EventType)(0),                 // 12: rh_trex.v1.EventType
+	(*Dinosaur)(nil),                    // 0: rh_trex.v1.Dinosaur
+	(*CreateDinosaurRequest)(nil),       // 1: rh_trex.v1.CreateDinosaurRequest
+	(*GetDinosaurRequest)(nil),          // 2: rh_trex.v1.GetDinosaurRequest
+	(*UpdateDinosaurRequest)(nil),       // 3: rh_trex.v1.UpdateDinosaurRequest
+	(*DeleteDinosaurRequest)(nil),       // 4: rh_trex.v1.DeleteDinosaurRequest
+	(*ListDinosaursRequest)(nil),        // 5: rh_trex.v1.ListDinosaursRequest
+	(*ListDinosaursResponse)(nil),       // 6: rh_trex.v1.ListDinosaursResponse
+	(*DeleteDinosaurResponse)(nil),      // 7: rh_trex.v1.DeleteDinosaurResponse
+	(*WatchDinosaursRequest)(nil),       // 8: rh_trex.v1.WatchDinosaursRequest
+	(*DinosaurWatchEvent)(nil),          // 9: rh_trex.v1.DinosaurWatchEvent
+	(*BatchCreateDinosaursRequest)(nil), // 10: rh_trex.v1.BatchCreateDinosaursRequest
+	(*BatchUpdateDinosaursRequest)(nil), // 11: rh_trex.v1.BatchUpdateDinosaursRequest
+	(*BatchDeleteDinosaursRequest)(nil), // 12: rh_trex.v1.BatchDeleteDinosaursRequest
+	(*DinosaurBatchResult)(nil),         // 13: rh_trex.v1.DinosaurBatchResult
+	(*DinosaurBatchResponse)(nil),       // 14: rh_trex.v1.DinosaurBatchResponse
+	(*ObjectReference)(nil),             // 15: rh_trex.v1.ObjectReference
+	(*fieldmaskpb.FieldMask)(nil),       // 16: google.protobuf.FieldMask
+	(*ListMeta)(nil),                    // 17: rh_trex.v1.ListMeta
+	(EventType)(0),                      // 18: rh_trex.v1.EventType
+	(*BatchItemError)(nil),              // 19: rh_trex.v1.BatchItemError
 }
 var file_rh_trex_v1_dinosaurs_proto_depIdxs = []int32{
-	10, // 0: rh_trex.v1.Dinosaur.metadata:type_name -> rh_trex.v1.ObjectReference
-	0,  // 1: rh_trex.v1.ListDinosaursResponse.items:type_name -> rh_trex.v1.Dinosaur
-	11, // 2: rh_trex.v1.ListDinosaursResponse.metadata:type_name -> rh_trex.v1.ListMeta
-	12, // 3: rh_trex.v1.DinosaurWatchEvent.type:type_name -> rh_trex.v1.EventType
-	0,  // 4: rh_trex.v1.DinosaurWatchEvent.dinosaur:type_name -> rh_trex.v1.Dinosaur
-	2,  // 5: rh_trex.v1.DinosaurService.GetDinosaur:input_type -> rh_trex.v1.GetDinosaurRequest
-	1,  // 6: rh_trex.v1.DinosaurService.CreateDinosaur:input_type -> rh_trex.v1.CreateDinosaurRequest
-	3,  // 7: rh_trex.v1.DinosaurService.UpdateDinosaur:input_type -> rh_trex.v1.UpdateDinosaurRequest
-	4,  // 8: rh_trex.v1.DinosaurService.DeleteDinosaur:input_type -> rh_trex.v1.DeleteDinosaurRequest
-	5,  // 9: rh_trex.v1.DinosaurService.ListDinosaurs:input_type -> rh_trex.v1.ListDinosaursRequest
-	8,  // 10: rh_trex.v1.DinosaurService.WatchDinosaurs:input_type -> rh_trex.v1.WatchDinosaursRequest
-	0,  // 11: rh_trex.v1.DinosaurService.GetDinosaur:output_type -> rh_trex.v1.Dinosaur
-	0,  // 12: rh_trex.v1.DinosaurService.CreateDinosaur:output_type -> rh_trex.v1.Dinosaur
-	0,  // 13: rh_trex.v1.DinosaurService.UpdateDinosaur:output_type -> rh_trex.v1.Dinosaur
-	7,  // 14: rh_trex.v1.DinosaurService.DeleteDinosaur:output_type -> rh_trex.v1.DeleteDinosaurResponse
-	6,  // 15: rh_trex.v1.DinosaurService.ListDinosaurs:output_type -> rh_trex.v1.ListDinosaursResponse
-	9,  // 16: rh_trex.v1.DinosaurService.WatchDinosaurs:output_type -> rh_trex.v1.DinosaurWatchEvent
-	11, // [11:17] is the sub-list for method output_type
-	5,  // [5:11] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	15, // 0: rh_trex.v1.Dinosaur.metadata:type_name -> rh_trex.v1.ObjectReference
+	16, // 1: rh_trex.v1.GetDinosaurRequest.read_mask:type_name -> google.protobuf.FieldMask
+	16, // 2: rh_trex.v1.UpdateDinosaurRequest.update_mask:type_name -> google.protobuf.FieldMask
+	16, // 3: rh_trex.v1.ListDinosaursRequest.read_mask:type_name -> google.protobuf.FieldMask
+	0,  // 4: rh_trex.v1.ListDinosaursResponse.items:type_name -> rh_trex.v1.Dinosaur
+	17, // 5: rh_trex.v1.ListDinosaursResponse.metadata:type_name -> rh_trex.v1.ListMeta
+	18, // 6: rh_trex.v1.DinosaurWatchEvent.type:type_name -> rh_trex.v1.EventType
+	0,  // 7: rh_trex.v1.DinosaurWatchEvent.dinosaur:type_name -> rh_trex.v1.Dinosaur
+	1,  // 8: rh_trex.v1.BatchCreateDinosaursRequest.requests:type_name -> rh_trex.v1.CreateDinosaurRequest
+	3,  // 9: rh_trex.v1.BatchUpdateDinosaursRequest.requests:type_name -> rh_trex.v1.UpdateDinosaurRequest
+	0,  // 10: rh_trex.v1.DinosaurBatchResult.dinosaur:type_name -> rh_trex.v1.Dinosaur
+	19, // 11: rh_trex.v1.DinosaurBatchResult.error:type_name -> rh_trex.v1.BatchItemError
+	13, // 12: rh_trex.v1.DinosaurBatchResponse.results:type_name -> rh_trex.v1.DinosaurBatchResult
+	2,  // 13: rh_trex.v1.DinosaurService.GetDinosaur:input_type -> rh_trex.v1.GetDinosaurRequest
+	1,  // 14: rh_trex.v1.DinosaurService.CreateDinosaur:input_type -> rh_trex.v1.CreateDinosaurRequest
+	3,  // 15: rh_trex.v1.DinosaurService.UpdateDinosaur:input_type -> rh_trex.v1.UpdateDinosaurRequest
+	4,  // 16: rh_trex.v1.DinosaurService.DeleteDinosaur:input_type -> rh_trex.v1.DeleteDinosaurRequest
+	5,  // 17: rh_trex.v1.DinosaurService.ListDinosaurs:input_type -> rh_trex.v1.ListDinosaursRequest
+	8,  // 18: rh_trex.v1.DinosaurService.WatchDinosaurs:input_type -> rh_trex.v1.WatchDinosaursRequest
+	10, // 19: rh_trex.v1.DinosaurService.BatchCreateDinosaurs:input_type -> rh_trex.v1.BatchCreateDinosaursRequest
+	11, // 20: rh_trex.v1.DinosaurService.BatchUpdateDinosaurs:input_type -> rh_trex.v1.BatchUpdateDinosaursRequest
+	12, // 21: rh_trex.v1.DinosaurService.BatchDeleteDinosaurs:input_type -> rh_trex.v1.BatchDeleteDinosaursRequest
+	0,  // 22: rh_trex.v1.DinosaurService.GetDinosaur:output_type -> rh_trex.v1.Dinosaur
+	0,  // 23: rh_trex.v1.DinosaurService.CreateDinosaur:output_type -> rh_trex.v1.Dinosaur
+	0,  // 24: rh_trex.v1.DinosaurService.UpdateDinosaur:output_type -> rh_trex.v1.Dinosaur
+	7,  // 25: rh_trex.v1.DinosaurService.DeleteDinosaur:output_type -> rh_trex.v1.DeleteDinosaurResponse
+	6,  // 26: rh_trex.v1.DinosaurService.ListDinosaurs:output_type -> rh_trex.v1.ListDinosaursResponse
+	9,  // 27: rh_trex.v1.DinosaurService.WatchDinosaurs:output_type -> rh_trex.v1.DinosaurWatchEvent
+	14, // 28: rh_trex.v1.DinosaurService.BatchCreateDinosaurs:output_type -> rh_trex.v1.DinosaurBatchResponse
+	14, // 29: rh_trex.v1.DinosaurService.BatchUpdateDinosaurs:output_type -> rh_trex.v1.DinosaurBatchResponse
+	14, // 30: rh_trex.v1.DinosaurService.BatchDeleteDinosaurs:output_type -> rh_trex.v1.DinosaurBatchResponse
+	22, // [22:31] is the sub-list for method output_type
+	13, // [13:22] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_rh_trex_v1_dinosaurs_proto_init() }
@@ -600,7 +1062,7 @@ func file_rh_trex_v1_dinosaurs_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rh_trex_v1_dinosaurs_proto_rawDesc), len(file_rh_trex_v1_dinosaurs_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,12 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DinosaurService_GetDinosaur_FullMethodName    = "/rh_trex.v1.DinosaurService/GetDinosaur"
-	DinosaurService_CreateDinosaur_FullMethodName = "/rh_trex.v1.DinosaurService/CreateDinosaur"
-	DinosaurService_UpdateDinosaur_FullMethodName = "/rh_trex.v1.DinosaurService/UpdateDinosaur"
-	DinosaurService_DeleteDinosaur_FullMethodName = "/rh_trex.v1.DinosaurService/DeleteDinosaur"
-	DinosaurService_ListDinosaurs_FullMethodName  = "/rh_trex.v1.DinosaurService/ListDinosaurs"
-	DinosaurService_WatchDinosaurs_FullMethodName = "/rh_trex.v1.DinosaurService/WatchDinosaurs"
+	DinosaurService_GetDinosaur_FullMethodName          = "/rh_trex.v1.DinosaurService/GetDinosaur"
+	DinosaurService_CreateDinosaur_FullMethodName       = "/rh_trex.v1.DinosaurService/CreateDinosaur"
+	DinosaurService_UpdateDinosaur_FullMethodName       = "/rh_trex.v1.DinosaurService/UpdateDinosaur"
+	DinosaurService_DeleteDinosaur_FullMethodName       = "/rh_trex.v1.DinosaurService/DeleteDinosaur"
+	DinosaurService_ListDinosaurs_FullMethodName        = "/rh_trex.v1.DinosaurService/ListDinosaurs"
+	DinosaurService_WatchDinosaurs_FullMethodName       = "/rh_trex.v1.DinosaurService/WatchDinosaurs"
+	DinosaurService_BatchCreateDinosaurs_FullMethodName = "/rh_trex.v1.DinosaurService/BatchCreateDinosaurs"
+	DinosaurService_BatchUpdateDinosaurs_FullMethodName = "/rh_trex.v1.DinosaurService/BatchUpdateDinosaurs"
+	DinosaurService_BatchDeleteDinosaurs_FullMethodName = "/rh_trex.v1.DinosaurService/BatchDeleteDinosaurs"
 )
 
 // DinosaurServiceClient is the client API for DinosaurService service.
@@ -37,6 +40,9 @@ type DinosaurServiceClient interface {
 	DeleteDinosaur(ctx context.Context, in *DeleteDinosaurRequest, opts ...grpc.CallOption) (*DeleteDinosaurResponse, error)
 	ListDinosaurs(ctx context.Context, in *ListDinosaursRequest, opts ...grpc.CallOption) (*ListDinosaursResponse, error)
 	WatchDinosaurs(ctx context.Context, in *WatchDinosaursRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DinosaurWatchEvent], error)
+	BatchCreateDinosaurs(ctx context.Context, in *BatchCreateDinosaursRequest, opts ...grpc.CallOption) (*DinosaurBatchResponse, error)
+	BatchUpdateDinosaurs(ctx context.Context, in *BatchUpdateDinosaursRequest, opts ...grpc.CallOption) (*DinosaurBatchResponse, error)
+	BatchDeleteDinosaurs(ctx context.Context, in *BatchDeleteDinosaursRequest, opts ...grpc.CallOption) (*DinosaurBatchResponse, error)
 }
 
 type dinosaurServiceClient struct {
@@ -116,6 +122,36 @@ func (c *dinosaurServiceClient) WatchDinosaurs(ctx context.Context, in *WatchDin
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type DinosaurService_WatchDinosaursClient = grpc.ServerStreamingClient[DinosaurWatchEvent]
 
+func (c *dinosaurServiceClient) BatchCreateDinosaurs(ctx context.Context, in *BatchCreateDinosaursRequest, opts ...grpc.CallOption) (*DinosaurBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DinosaurBatchResponse)
+	err := c.cc.Invoke(ctx, DinosaurService_BatchCreateDinosaurs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dinosaurServiceClient) BatchUpdateDinosaurs(ctx context.Context, in *BatchUpdateDinosaursRequest, opts ...grpc.CallOption) (*DinosaurBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DinosaurBatchResponse)
+	err := c.cc.Invoke(ctx, DinosaurService_BatchUpdateDinosaurs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dinosaurServiceClient) BatchDeleteDinosaurs(ctx context.Context, in *BatchDeleteDinosaursRequest, opts ...grpc.CallOption) (*DinosaurBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DinosaurBatchResponse)
+	err := c.cc.Invoke(ctx, DinosaurService_BatchDeleteDinosaurs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DinosaurServiceServer is the server API for DinosaurService service.
 // All implementations must embed UnimplementedDinosaurServiceServer
 // for forward compatibility.
@@ -126,6 +162,9 @@ type DinosaurServiceServer interface {
 	DeleteDinosaur(context.Context, *DeleteDinosaurRequest) (*DeleteDinosaurResponse, error)
 	ListDinosaurs(context.Context, *ListDinosaursRequest) (*ListDinosaursResponse, error)
 	WatchDinosaurs(*WatchDinosaursRequest, grpc.ServerStreamingServer[DinosaurWatchEvent]) error
+	BatchCreateDinosaurs(context.Context, *BatchCreateDinosaursRequest) (*DinosaurBatchResponse, error)
+	BatchUpdateDinosaurs(context.Context, *BatchUpdateDinosaursRequest) (*DinosaurBatchResponse, error)
+	BatchDeleteDinosaurs(context.Context, *BatchDeleteDinosaursRequest) (*DinosaurBatchResponse, error)
 	mustEmbedUnimplementedDinosaurServiceServer()
 }
 
@@ -154,6 +193,15 @@ func (UnimplementedDinosaurServiceServer) ListDinosaurs(context.Context, *ListDi
 func (UnimplementedDinosaurServiceServer) WatchDinosaurs(*WatchDinosaursRequest, grpc.ServerStreamingServer[DinosaurWatchEvent]) error {
 	return status.Error(codes.Unimplemented, "method WatchDinosaurs not implemented")
 }
+func (UnimplementedDinosaurServiceServer) BatchCreateDinosaurs(context.Context, *BatchCreateDinosaursRequest) (*DinosaurBatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchCreateDinosaurs not implemented")
+}
+func (UnimplementedDinosaurServiceServer) BatchUpdateDinosaurs(context.Context, *BatchUpdateDinosaursRequest) (*DinosaurBatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchUpdateDinosaurs not implemented")
+}
+func (UnimplementedDinosaurServiceServer) BatchDeleteDinosaurs(context.Context, *BatchDeleteDinosaursRequest) (*DinosaurBatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchDeleteDinosaurs not implemented")
+}
 func (UnimplementedDinosaurServiceServer) mustEmbedUnimplementedDinosaurServiceServer() {}
 func (UnimplementedDinosaurServiceServer) testEmbeddedByValue()                         {}
 
@@ -276,6 +324,60 @@ func _DinosaurService_WatchDinosaurs_Handler(srv interface{}, stream grpc.Server
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type DinosaurService_WatchDinosaursServer = grpc.ServerStreamingServer[DinosaurWatchEvent]
 
+func _DinosaurService_BatchCreateDinosaurs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateDinosaursRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DinosaurServiceServer).BatchCreateDinosaurs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DinosaurService_BatchCreateDinosaurs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DinosaurServiceServer).BatchCreateDinosaurs(ctx, req.(*BatchCreateDinosaursRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DinosaurService_BatchUpdateDinosaurs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateDinosaursRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DinosaurServiceServer).BatchUpdateDinosaurs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DinosaurService_BatchUpdateDinosaurs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DinosaurServiceServer).BatchUpdateDinosaurs(ctx, req.(*BatchUpdateDinosaursRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DinosaurService_BatchDeleteDinosaurs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteDinosaursRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DinosaurServiceServer).BatchDeleteDinosaurs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DinosaurService_BatchDeleteDinosaurs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DinosaurServiceServer).BatchDeleteDinosaurs(ctx, req.(*BatchDeleteDinosaursRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DinosaurService_ServiceDesc is the grpc.ServiceDesc for DinosaurService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -303,6 +405,18 @@ var DinosaurService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListDinosaurs",
 			Handler:    _DinosaurService_ListDinosaurs_Handler,
 		},
+		{
+			MethodName: "BatchCreateDinosaurs",
+			Handler:    _DinosaurService_BatchCreateDinosaurs_Handler,
+		},
+		{
+			MethodName: "BatchUpdateDinosaurs",
+			Handler:    _DinosaurService_BatchUpdateDinosaurs_Handler,
+		},
+		{
+			MethodName: "BatchDeleteDinosaurs",
+			Handler:    _DinosaurService_BatchDeleteDinosaurs_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
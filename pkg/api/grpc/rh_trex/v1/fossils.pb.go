@@ -9,6 +9,7 @@ package rh_trex_v1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -28,6 +29,8 @@ type Fossil struct {
 	EstimatedAge      *int32                 `protobuf:"varint,3,opt,name=estimated_age,json=estimatedAge,proto3,oneof" json:"estimated_age,omitempty"`
 	FossilType        *string                `protobuf:"bytes,4,opt,name=fossil_type,json=fossilType,proto3,oneof" json:"fossil_type,omitempty"`
 	ExcavatorName     *string                `protobuf:"bytes,5,opt,name=excavator_name,json=excavatorName,proto3,oneof" json:"excavator_name,omitempty"`
+	DinosaurId        *string                `protobuf:"bytes,6,opt,name=dinosaur_id,json=dinosaurId,proto3,oneof" json:"dinosaur_id,omitempty"`
+	ScientistId       *string                `protobuf:"bytes,7,opt,name=scientist_id,json=scientistId,proto3,oneof" json:"scientist_id,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -97,12 +100,28 @@ func (x *Fossil) GetExcavatorName() string {
 	return ""
 }
 
+func (x *Fossil) GetDinosaurId() string {
+	if x != nil && x.DinosaurId != nil {
+		return *x.DinosaurId
+	}
+	return ""
+}
+
+func (x *Fossil) GetScientistId() string {
+	if x != nil && x.ScientistId != nil {
+		return *x.ScientistId
+	}
+	return ""
+}
+
 type CreateFossilRequest struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	DiscoveryLocation string                 `protobuf:"bytes,1,opt,name=discovery_location,json=discoveryLocation,proto3" json:"discovery_location,omitempty"`
 	EstimatedAge      *int32                 `protobuf:"varint,2,opt,name=estimated_age,json=estimatedAge,proto3,oneof" json:"estimated_age,omitempty"`
 	FossilType        *string                `protobuf:"bytes,3,opt,name=fossil_type,json=fossilType,proto3,oneof" json:"fossil_type,omitempty"`
 	ExcavatorName     *string                `protobuf:"bytes,4,opt,name=excavator_name,json=excavatorName,proto3,oneof" json:"excavator_name,omitempty"`
+	DinosaurId        *string                `protobuf:"bytes,5,opt,name=dinosaur_id,json=dinosaurId,proto3,oneof" json:"dinosaur_id,omitempty"`
+	ScientistId       *string                `protobuf:"bytes,6,opt,name=scientist_id,json=scientistId,proto3,oneof" json:"scientist_id,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -165,9 +184,25 @@ func (x *CreateFossilRequest) GetExcavatorName() string {
 	return ""
 }
 
+func (x *CreateFossilRequest) GetDinosaurId() string {
+	if x != nil && x.DinosaurId != nil {
+		return *x.DinosaurId
+	}
+	return ""
+}
+
+func (x *CreateFossilRequest) GetScientistId() string {
+	if x != nil && x.ScientistId != nil {
+		return *x.ScientistId
+	}
+	return ""
+}
+
 type GetFossilRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// the fields of the fossil to return, e.g. discovery_location or metadata.id. Every field is returned without it.
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -209,6 +244,13 @@ func (x *GetFossilRequest) GetId() string {
 	return ""
 }
 
+func (x *GetFossilRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
 type UpdateFossilRequest struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -216,8 +258,15 @@ type UpdateFossilRequest struct {
 	EstimatedAge      *int32                 `protobuf:"varint,3,opt,name=estimated_age,json=estimatedAge,proto3,oneof" json:"estimated_age,omitempty"`
 	FossilType        *string                `protobuf:"bytes,4,opt,name=fossil_type,json=fossilType,proto3,oneof" json:"fossil_type,omitempty"`
 	ExcavatorName     *string                `protobuf:"bytes,5,opt,name=excavator_name,json=excavatorName,proto3,oneof" json:"excavator_name,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	// When set, the update only succeeds if the stored resource version still matches.
+	ResourceVersion *int64  `protobuf:"varint,6,opt,name=resource_version,json=resourceVersion,proto3,oneof" json:"resource_version,omitempty"`
+	DinosaurId      *string `protobuf:"bytes,7,opt,name=dinosaur_id,json=dinosaurId,proto3,oneof" json:"dinosaur_id,omitempty"`
+	ScientistId     *string `protobuf:"bytes,8,opt,name=scientist_id,json=scientistId,proto3,oneof" json:"scientist_id,omitempty"`
+	// the fields to update, by their proto name. Fields it names that the request leaves unset are cleared.
+	// Without it the fields the request sets are updated.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,9,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateFossilRequest) Reset() {
@@ -285,6 +334,34 @@ func (x *UpdateFossilRequest) GetExcavatorName() string {
 	return ""
 }
 
+func (x *UpdateFossilRequest) GetResourceVersion() int64 {
+	if x != nil && x.ResourceVersion != nil {
+		return *x.ResourceVersion
+	}
+	return 0
+}
+
+func (x *UpdateFossilRequest) GetDinosaurId() string {
+	if x != nil && x.DinosaurId != nil {
+		return *x.DinosaurId
+	}
+	return ""
+}
+
+func (x *UpdateFossilRequest) GetScientistId() string {
+	if x != nil && x.ScientistId != nil {
+		return *x.ScientistId
+	}
+	return ""
+}
+
+func (x *UpdateFossilRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
 type DeleteFossilRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -330,9 +407,21 @@ func (x *DeleteFossilRequest) GetId() string {
 }
 
 type ListFossilsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	Size          int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Page  int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Size  int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// continue_token of the previous page; resumes right after it and ignores page
+	ContinueToken string `protobuf:"bytes,3,opt,name=continue_token,json=continueToken,proto3" json:"continue_token,omitempty"`
+	// omit the total count, which is expensive on large tables
+	SkipCount bool `protobuf:"varint,4,opt,name=skip_count,json=skipCount,proto3" json:"skip_count,omitempty"`
+	// include soft-deleted records, which carry metadata.deleted_at
+	IncludeDeleted bool `protobuf:"varint,5,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// TSL search the fossils have to match, on the same fields as the REST search
+	Search string `protobuf:"bytes,6,opt,name=search,proto3" json:"search,omitempty"`
+	// the order of the fossils, like the orderBy of REST, e.g. "created_at desc"
+	OrderBy []string `protobuf:"bytes,7,rep,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// the fields of the fossils to return, see GetFossilRequest
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,8,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -381,6 +470,48 @@ func (x *ListFossilsRequest) GetSize() int32 {
 	return 0
 }
 
+func (x *ListFossilsRequest) GetContinueToken() string {
+	if x != nil {
+		return x.ContinueToken
+	}
+	return ""
+}
+
+func (x *ListFossilsRequest) GetSkipCount() bool {
+	if x != nil {
+		return x.SkipCount
+	}
+	return false
+}
+
+func (x *ListFossilsRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *ListFossilsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListFossilsRequest) GetOrderBy() []string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return nil
+}
+
+func (x *ListFossilsRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
 type ListFossilsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Items         []*Fossil              `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
@@ -470,7 +601,15 @@ func (*DeleteFossilResponse) Descriptor() ([]byte, []int) {
 }
 
 type WatchFossilsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// event_id of the last event the client received. The events since are replayed before the live
+	// events; if the event is no longer kept the stream ends with EVENT_TYPE_RESYNC_REQUIRED right away.
+	ResumeFrom string `protobuf:"bytes,1,opt,name=resume_from,json=resumeFrom,proto3" json:"resume_from,omitempty"`
+	// TSL search the fossils have to match to be sent, on the same fields as the REST search
+	Filter string `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// event_seq of the last event the client received, to resume from instead of resume_from. It numbers
+	// the events of all Kinds and is not the resource_version of a resource.
+	EventSeq      int64 `protobuf:"varint,3,opt,name=event_seq,json=eventSeq,proto3" json:"event_seq,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -505,11 +644,39 @@ func (*WatchFossilsRequest) Descriptor() ([]byte, []int) {
 	return file_rh_trex_v1_fossils_proto_rawDescGZIP(), []int{8}
 }
 
+func (x *WatchFossilsRequest) GetResumeFrom() string {
+	if x != nil {
+		return x.ResumeFrom
+	}
+	return ""
+}
+
+func (x *WatchFossilsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *WatchFossilsRequest) GetEventSeq() int64 {
+	if x != nil {
+		return x.EventSeq
+	}
+	return 0
+}
+
 type FossilWatchEvent struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=rh_trex.v1.EventType" json:"type,omitempty"`
-	Fossil        *Fossil                `protobuf:"bytes,2,opt,name=fossil,proto3" json:"fossil,omitempty"`
-	ResourceId    string                 `protobuf:"bytes,3,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Type       EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=rh_trex.v1.EventType" json:"type,omitempty"`
+	Fossil     *Fossil                `protobuf:"bytes,2,opt,name=fossil,proto3" json:"fossil,omitempty"`
+	ResourceId string                 `protobuf:"bytes,3,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	// id of the event, to resume the watch from. For EVENT_TYPE_RESYNC_REQUIRED it is the last event
+	// sent, or empty if the watch can't be resumed and the client has to list the fossils again.
+	EventId string `protobuf:"bytes,4,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// the fields an update changed, by their proto name. Empty when the update isn't known field by field.
+	ChangedFields []string `protobuf:"bytes,5,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"`
+	// sequence number of the event, to resume the watch from like event_id
+	EventSeq      int64 `protobuf:"varint,6,opt,name=event_seq,json=eventSeq,proto3" json:"event_seq,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -565,66 +732,403 @@ func (x *FossilWatchEvent) GetResourceId() string {
 	return ""
 }
 
+func (x *FossilWatchEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *FossilWatchEvent) GetChangedFields() []string {
+	if x != nil {
+		return x.ChangedFields
+	}
+	return nil
+}
+
+func (x *FossilWatchEvent) GetEventSeq() int64 {
+	if x != nil {
+		return x.EventSeq
+	}
+	return 0
+}
+
+type BatchCreateFossilsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Requests []*CreateFossilRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	// abort the whole batch on the first failed item instead of reporting per item results
+	AllOrNothing  bool `protobuf:"varint,2,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateFossilsRequest) Reset() {
+	*x = BatchCreateFossilsRequest{}
+	mi := &file_rh_trex_v1_fossils_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateFossilsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateFossilsRequest) ProtoMessage() {}
+
+func (x *BatchCreateFossilsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rh_trex_v1_fossils_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateFossilsRequest.ProtoReflect.Descriptor instead.
+func (*BatchCreateFossilsRequest) Descriptor() ([]byte, []int) {
+	return file_rh_trex_v1_fossils_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BatchCreateFossilsRequest) GetRequests() []*CreateFossilRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *BatchCreateFossilsRequest) GetAllOrNothing() bool {
+	if x != nil {
+		return x.AllOrNothing
+	}
+	return false
+}
+
+type BatchUpdateFossilsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requests      []*UpdateFossilRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	AllOrNothing  bool                   `protobuf:"varint,2,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateFossilsRequest) Reset() {
+	*x = BatchUpdateFossilsRequest{}
+	mi := &file_rh_trex_v1_fossils_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateFossilsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateFossilsRequest) ProtoMessage() {}
+
+func (x *BatchUpdateFossilsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rh_trex_v1_fossils_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateFossilsRequest.ProtoReflect.Descriptor instead.
+func (*BatchUpdateFossilsRequest) Descriptor() ([]byte, []int) {
+	return file_rh_trex_v1_fossils_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BatchUpdateFossilsRequest) GetRequests() []*UpdateFossilRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *BatchUpdateFossilsRequest) GetAllOrNothing() bool {
+	if x != nil {
+		return x.AllOrNothing
+	}
+	return false
+}
+
+type BatchDeleteFossilsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	AllOrNothing  bool                   `protobuf:"varint,2,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeleteFossilsRequest) Reset() {
+	*x = BatchDeleteFossilsRequest{}
+	mi := &file_rh_trex_v1_fossils_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteFossilsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteFossilsRequest) ProtoMessage() {}
+
+func (x *BatchDeleteFossilsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rh_trex_v1_fossils_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteFossilsRequest.ProtoReflect.Descriptor instead.
+func (*BatchDeleteFossilsRequest) Descriptor() ([]byte, []int) {
+	return file_rh_trex_v1_fossils_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BatchDeleteFossilsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *BatchDeleteFossilsRequest) GetAllOrNothing() bool {
+	if x != nil {
+		return x.AllOrNothing
+	}
+	return false
+}
+
+type FossilBatchResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// set for successful creates and updates
+	Fossil *Fossil `protobuf:"bytes,2,opt,name=fossil,proto3" json:"fossil,omitempty"`
+	// set for failed items
+	Error         *BatchItemError `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FossilBatchResult) Reset() {
+	*x = FossilBatchResult{}
+	mi := &file_rh_trex_v1_fossils_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FossilBatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FossilBatchResult) ProtoMessage() {}
+
+func (x *FossilBatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_rh_trex_v1_fossils_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FossilBatchResult.ProtoReflect.Descriptor instead.
+func (*FossilBatchResult) Descriptor() ([]byte, []int) {
+	return file_rh_trex_v1_fossils_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *FossilBatchResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FossilBatchResult) GetFossil() *Fossil {
+	if x != nil {
+		return x.Fossil
+	}
+	return nil
+}
+
+func (x *FossilBatchResult) GetError() *BatchItemError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type FossilBatchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// one result per item, in request order
+	Results       []*FossilBatchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FossilBatchResponse) Reset() {
+	*x = FossilBatchResponse{}
+	mi := &file_rh_trex_v1_fossils_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FossilBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FossilBatchResponse) ProtoMessage() {}
+
+func (x *FossilBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rh_trex_v1_fossils_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FossilBatchResponse.ProtoReflect.Descriptor instead.
+func (*FossilBatchResponse) Descriptor() ([]byte, []int) {
+	return file_rh_trex_v1_fossils_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *FossilBatchResponse) GetResults() []*FossilBatchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
 var File_rh_trex_v1_fossils_proto protoreflect.FileDescriptor
 
 const file_rh_trex_v1_fossils_proto_rawDesc = "" +
 	"\n" +
 	"\x18rh_trex/v1/fossils.proto\x12\n" +
-	"rh_trex.v1\x1a\x17rh_trex/v1/common.proto\"\xa1\x02\n" +
+	"rh_trex.v1\x1a google/protobuf/field_mask.proto\x1a\x17rh_trex/v1/common.proto\"\x90\x03\n" +
 	"\x06Fossil\x127\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x1b.rh_trex.v1.ObjectReferenceR\bmetadata\x12-\n" +
 	"\x12discovery_location\x18\x02 \x01(\tR\x11discoveryLocation\x12(\n" +
 	"\restimated_age\x18\x03 \x01(\x05H\x00R\festimatedAge\x88\x01\x01\x12$\n" +
 	"\vfossil_type\x18\x04 \x01(\tH\x01R\n" +
 	"fossilType\x88\x01\x01\x12*\n" +
-	"\x0eexcavator_name\x18\x05 \x01(\tH\x02R\rexcavatorName\x88\x01\x01B\x10\n" +
+	"\x0eexcavator_name\x18\x05 \x01(\tH\x02R\rexcavatorName\x88\x01\x01\x12$\n" +
+	"\vdinosaur_id\x18\x06 \x01(\tH\x03R\n" +
+	"dinosaurId\x88\x01\x01\x12&\n" +
+	"\fscientist_id\x18\a \x01(\tH\x04R\vscientistId\x88\x01\x01B\x10\n" +
 	"\x0e_estimated_ageB\x0e\n" +
 	"\f_fossil_typeB\x11\n" +
-	"\x0f_excavator_name\"\xf5\x01\n" +
+	"\x0f_excavator_nameB\x0e\n" +
+	"\f_dinosaur_idB\x0f\n" +
+	"\r_scientist_id\"\xe4\x02\n" +
 	"\x13CreateFossilRequest\x12-\n" +
 	"\x12discovery_location\x18\x01 \x01(\tR\x11discoveryLocation\x12(\n" +
 	"\restimated_age\x18\x02 \x01(\x05H\x00R\festimatedAge\x88\x01\x01\x12$\n" +
 	"\vfossil_type\x18\x03 \x01(\tH\x01R\n" +
 	"fossilType\x88\x01\x01\x12*\n" +
-	"\x0eexcavator_name\x18\x04 \x01(\tH\x02R\rexcavatorName\x88\x01\x01B\x10\n" +
+	"\x0eexcavator_name\x18\x04 \x01(\tH\x02R\rexcavatorName\x88\x01\x01\x12$\n" +
+	"\vdinosaur_id\x18\x05 \x01(\tH\x03R\n" +
+	"dinosaurId\x88\x01\x01\x12&\n" +
+	"\fscientist_id\x18\x06 \x01(\tH\x04R\vscientistId\x88\x01\x01B\x10\n" +
 	"\x0e_estimated_ageB\x0e\n" +
 	"\f_fossil_typeB\x11\n" +
-	"\x0f_excavator_name\"\"\n" +
+	"\x0f_excavator_nameB\x0e\n" +
+	"\f_dinosaur_idB\x0f\n" +
+	"\r_scientist_id\"[\n" +
 	"\x10GetFossilRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xa1\x02\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x127\n" +
+	"\tread_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\"\x92\x04\n" +
 	"\x13UpdateFossilRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x122\n" +
 	"\x12discovery_location\x18\x02 \x01(\tH\x00R\x11discoveryLocation\x88\x01\x01\x12(\n" +
 	"\restimated_age\x18\x03 \x01(\x05H\x01R\festimatedAge\x88\x01\x01\x12$\n" +
 	"\vfossil_type\x18\x04 \x01(\tH\x02R\n" +
 	"fossilType\x88\x01\x01\x12*\n" +
-	"\x0eexcavator_name\x18\x05 \x01(\tH\x03R\rexcavatorName\x88\x01\x01B\x15\n" +
+	"\x0eexcavator_name\x18\x05 \x01(\tH\x03R\rexcavatorName\x88\x01\x01\x12.\n" +
+	"\x10resource_version\x18\x06 \x01(\x03H\x04R\x0fresourceVersion\x88\x01\x01\x12$\n" +
+	"\vdinosaur_id\x18\a \x01(\tH\x05R\n" +
+	"dinosaurId\x88\x01\x01\x12&\n" +
+	"\fscientist_id\x18\b \x01(\tH\x06R\vscientistId\x88\x01\x01\x12;\n" +
+	"\vupdate_mask\x18\t \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMaskB\x15\n" +
 	"\x13_discovery_locationB\x10\n" +
 	"\x0e_estimated_ageB\x0e\n" +
 	"\f_fossil_typeB\x11\n" +
-	"\x0f_excavator_name\"%\n" +
+	"\x0f_excavator_nameB\x13\n" +
+	"\x11_resource_versionB\x0e\n" +
+	"\f_dinosaur_idB\x0f\n" +
+	"\r_scientist_id\"%\n" +
 	"\x13DeleteFossilRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"<\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x97\x02\n" +
 	"\x12ListFossilsRequest\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x12\n" +
-	"\x04size\x18\x02 \x01(\x05R\x04size\"q\n" +
+	"\x04size\x18\x02 \x01(\x05R\x04size\x12%\n" +
+	"\x0econtinue_token\x18\x03 \x01(\tR\rcontinueToken\x12\x1d\n" +
+	"\n" +
+	"skip_count\x18\x04 \x01(\bR\tskipCount\x12'\n" +
+	"\x0finclude_deleted\x18\x05 \x01(\bR\x0eincludeDeleted\x12\x16\n" +
+	"\x06search\x18\x06 \x01(\tR\x06search\x12\x19\n" +
+	"\border_by\x18\a \x03(\tR\aorderBy\x127\n" +
+	"\tread_mask\x18\b \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\"q\n" +
 	"\x13ListFossilsResponse\x12(\n" +
 	"\x05items\x18\x01 \x03(\v2\x12.rh_trex.v1.FossilR\x05items\x120\n" +
 	"\bmetadata\x18\x02 \x01(\v2\x14.rh_trex.v1.ListMetaR\bmetadata\"\x16\n" +
-	"\x14DeleteFossilResponse\"\x15\n" +
-	"\x13WatchFossilsRequest\"\x8a\x01\n" +
+	"\x14DeleteFossilResponse\"k\n" +
+	"\x13WatchFossilsRequest\x12\x1f\n" +
+	"\vresume_from\x18\x01 \x01(\tR\n" +
+	"resumeFrom\x12\x16\n" +
+	"\x06filter\x18\x02 \x01(\tR\x06filter\x12\x1b\n" +
+	"\tevent_seq\x18\x03 \x01(\x03R\beventSeq\"\xe9\x01\n" +
 	"\x10FossilWatchEvent\x12)\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x15.rh_trex.v1.EventTypeR\x04type\x12*\n" +
 	"\x06fossil\x18\x02 \x01(\v2\x12.rh_trex.v1.FossilR\x06fossil\x12\x1f\n" +
 	"\vresource_id\x18\x03 \x01(\tR\n" +
-	"resourceId2\xcc\x03\n" +
+	"resourceId\x12\x19\n" +
+	"\bevent_id\x18\x04 \x01(\tR\aeventId\x12%\n" +
+	"\x0echanged_fields\x18\x05 \x03(\tR\rchangedFields\x12\x1b\n" +
+	"\tevent_seq\x18\x06 \x01(\x03R\beventSeq\"~\n" +
+	"\x19BatchCreateFossilsRequest\x12;\n" +
+	"\brequests\x18\x01 \x03(\v2\x1f.rh_trex.v1.CreateFossilRequestR\brequests\x12$\n" +
+	"\x0eall_or_nothing\x18\x02 \x01(\bR\fallOrNothing\"~\n" +
+	"\x19BatchUpdateFossilsRequest\x12;\n" +
+	"\brequests\x18\x01 \x03(\v2\x1f.rh_trex.v1.UpdateFossilRequestR\brequests\x12$\n" +
+	"\x0eall_or_nothing\x18\x02 \x01(\bR\fallOrNothing\"S\n" +
+	"\x19BatchDeleteFossilsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12$\n" +
+	"\x0eall_or_nothing\x18\x02 \x01(\bR\fallOrNothing\"\x81\x01\n" +
+	"\x11FossilBatchResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12*\n" +
+	"\x06fossil\x18\x02 \x01(\v2\x12.rh_trex.v1.FossilR\x06fossil\x120\n" +
+	"\x05error\x18\x03 \x01(\v2\x1a.rh_trex.v1.BatchItemErrorR\x05error\"N\n" +
+	"\x13FossilBatchResponse\x127\n" +
+	"\aresults\x18\x01 \x03(\v2\x1d.rh_trex.v1.FossilBatchResultR\aresults2\xe6\x05\n" +
 	"\rFossilService\x12=\n" +
 	"\tGetFossil\x12\x1c.rh_trex.v1.GetFossilRequest\x1a\x12.rh_trex.v1.Fossil\x12C\n" +
 	"\fCreateFossil\x12\x1f.rh_trex.v1.CreateFossilRequest\x1a\x12.rh_trex.v1.Fossil\x12C\n" +
 	"\fUpdateFossil\x12\x1f.rh_trex.v1.UpdateFossilRequest\x1a\x12.rh_trex.v1.Fossil\x12Q\n" +
 	"\fDeleteFossil\x12\x1f.rh_trex.v1.DeleteFossilRequest\x1a .rh_trex.v1.DeleteFossilResponse\x12N\n" +
 	"\vListFossils\x12\x1e.rh_trex.v1.ListFossilsRequest\x1a\x1f.rh_trex.v1.ListFossilsResponse\x12O\n" +
-	"\fWatchFossils\x12\x1f.rh_trex.v1.WatchFossilsRequest\x1a\x1c.rh_trex.v1.FossilWatchEvent0\x01BKZIgithub.com/openshift-online/rh-trex-ai/pkg/api/grpc/rh_trex/v1;rh_trex_v1b\x06proto3"
+	"\fWatchFossils\x12\x1f.rh_trex.v1.WatchFossilsRequest\x1a\x1c.rh_trex.v1.FossilWatchEvent0\x01\x12\\\n" +
+	"\x12BatchCreateFossils\x12%.rh_trex.v1.BatchCreateFossilsRequest\x1a\x1f.rh_trex.v1.FossilBatchResponse\x12\\\n" +
+	"\x12BatchUpdateFossils\x12%.rh_trex.v1.BatchUpdateFossilsRequest\x1a\x1f.rh_trex.v1.FossilBatchResponse\x12\\\n" +
+	"\x12BatchDeleteFossils\x12%.rh_trex.v1.BatchDeleteFossilsRequest\x1a\x1f.rh_trex.v1.FossilBatchResponseBKZIgithub.com/openshift-online/rh-trex-ai/pkg/api/grpc/rh_trex/v1;rh_trex_v1b\x06proto3"
 
 var (
 	file_rh_trex_v1_fossils_proto_rawDescOnce sync.Once
@@ -638,45 +1142,66 @@ func file_rh_trex_v1_fossils_proto_rawDescGZIP() []byte {
 	return file_rh_trex_v1_fossils_proto_rawDescData
 }
 
-var file_rh_trex_v1_fossils_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_rh_trex_v1_fossils_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_rh_trex_v1_fossils_proto_goTypes = []any{
-	(*Fossil)(nil),               // 0: rh_trex.v1.Fossil
-	(*CreateFossilRequest)(nil),  // 1: rh_trex.v1.CreateFossilRequest
-	(*GetFossilRequest)(nil),     // 2: rh_trex.v1.GetFossilRequest
-	(*UpdateFossilRequest)(nil),  // 3: rh_trex.v1.UpdateFossilRequest
-	(*DeleteFossilRequest)(nil),  // 4: rh_trex.v1.DeleteFossilRequest
-	(*ListFossilsRequest)(nil),   // 5: rh_trex.v1.ListFossilsRequest
-	(*ListFossilsResponse)(nil),  // 6: rh_trex.v1.ListFossilsResponse
-	(*DeleteFossilResponse)(nil), // 7: rh_trex.v1.DeleteFossilResponse
-	(*WatchFossilsRequest)(nil),  // 8: rh_trex.v1.WatchFossilsRequest
-	(*FossilWatchEvent)(nil),     // 9: rh_trex.v1.FossilWatchEvent
-	(*ObjectReference)(nil),      // 10: rh_trex.v1.ObjectReference
-	(*ListMeta)(nil),             // 11: rh_trex.v1.ListMeta
-	(EventType)(0),               // 12: rh_trex.v1.EventType
+	(*Fossil)(nil),                    // 0: rh_trex.v1.Fossil
+	(*CreateFossilRequest)(nil),       // 1: rh_trex.v1.CreateFossilRequest
+	(*GetFossilRequest)(nil),          // 2: rh_trex.v1.GetFossilRequest
+	(*UpdateFossilRequest)(nil),       // 3: rh_trex.v1.UpdateFossilRequest
+	(*DeleteFossilRequest)(nil),       // 4: rh_trex.v1.DeleteFossilRequest
+	(*ListFossilsRequest)(nil),        // 5: rh_trex.v1.ListFossilsRequest
+	(*ListFossilsResponse)(nil),       // 6: rh_trex.v1.ListFossilsResponse
+	(*DeleteFossilResponse)(nil),      // 7: rh_trex.v1.DeleteFossilResponse
+	(*WatchFossilsRequest)(nil),       // 8: rh_trex.v1.WatchFossilsRequest
+	(*FossilWatchEvent)(nil),          // 9: rh_trex.v1.FossilWatchEvent
+	(*BatchCreateFossilsRequest)(nil), // 10: rh_trex.v1.BatchCreateFossilsRequest
+	(*BatchUpdateFossilsRequest)(nil), // 11: rh_trex.v1.BatchUpdateFossilsRequest
+	(*BatchDeleteFossilsRequest)(nil), // 12: rh_trex.v1.BatchDeleteFossilsRequest
+	(*FossilBatchResult)(nil),         // 13: rh_trex.v1.FossilBatchResult
+	(*FossilBatchResponse)(nil),       // 14: rh_trex.v1.FossilBatchResponse
+	(*ObjectReference)(nil),           // 15: rh_trex.v1.ObjectReference
+	(*fieldmaskpb.FieldMask)(nil),     // 16: google.protobuf.FieldMask
+	(*ListMeta)(nil),                  // 17: rh_trex.v1.ListMeta
+	(EventType)(0),                    // 18: rh_trex.v1.EventType
+	(*BatchItemError)(nil),            // 19: rh_trex.v1.BatchItemError
 }
 var file_rh_trex_v1_fossils_proto_depIdxs = []int32{
-	10, // 0: rh_trex.v1.Fossil.metadata:type_name -> rh_trex.v1.ObjectReference
-	0,  // 1: rh_trex.v1.ListFossilsResponse.items:type_name -> rh_trex.v1.Fossil
-	11, // 2: rh_trex.v1.ListFossilsResponse.metadata:type_name -> rh_trex.v1.ListMeta
-	12, // 3: rh_trex.v1.FossilWatchEvent.type:type_name -> rh_trex.v1.EventType
-	0,  // 4: rh_trex.v1.FossilWatchEvent.fossil:type_name -> rh_trex.v1.Fossil
-	2,  // 5: rh_trex.v1.FossilService.GetFossil:input_type -> rh_trex.v1.GetFossilRequest
-	1,  // 6: rh_trex.v1.FossilService.CreateFossil:input_type -> rh_trex.v1.CreateFossilRequest
-	3,  // 7: rh_trex.v1.FossilService.UpdateFossil:input_type -> rh_trex.v1.UpdateFossilRequest
-	4,  // 8: rh_trex.v1.FossilService.DeleteFossil:input_type -> rh_trex.v1.DeleteFossilRequest
-	5,  // 9: rh_trex.v1.FossilService.ListFossils:input_type -> rh_trex.v1.ListFossilsRequest
-	8,  // 10: rh_trex.v1.FossilService.WatchFossils:input_type -> rh_trex.v1.WatchFossilsRequest
-	0,  // 11: rh_trex.v1.FossilService.GetFossil:output_type -> rh_trex.v1.Fossil
-	0,  // 12: rh_trex.v1.FossilService.CreateFossil:output_type -> rh_trex.v1.Fossil
-	0,  // 13: rh_trex.v1.FossilService.UpdateFossil:output_type -> rh_trex.v1.Fossil
-	7,  // 14: rh_trex.v1.FossilService.DeleteFossil:output_type -> rh_trex.v1.DeleteFossilResponse
-	6,  // 15: rh_trex.v1.FossilService.ListFossils:output_type -> rh_trex.v1.ListFossilsResponse
-	9,  // 16: rh_trex.v1.FossilService.WatchFossils:output_type -> rh_trex.v1.FossilWatchEvent
-	11, // [11:17] is the sub-list for method output_type
-	5,  // [5:11] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	15, // 0: rh_trex.v1.Fossil.metadata:type_name -> rh_trex.v1.ObjectReference
+	16, // 1: rh_trex.v1.GetFossilRequest.read_mask:type_name -> google.protobuf.FieldMask
+	16, // 2: rh_trex.v1.UpdateFossilRequest.update_mask:type_name -> google.protobuf.FieldMask
+	16, // 3: rh_trex.v1.ListFossilsRequest.read_mask:type_name -> google.protobuf.FieldMask
+	0,  // 4: rh_trex.v1.ListFossilsResponse.items:type_name -> rh_trex.v1.Fossil
+	17, // 5: rh_trex.v1.ListFossilsResponse.metadata:type_name -> rh_trex.v1.ListMeta
+	18, // 6: rh_trex.v1.FossilWatchEvent.type:type_name -> rh_trex.v1.EventType
+	0,  // 7: rh_trex.v1.FossilWatchEvent.fossil:type_name -> rh_trex.v1.Fossil
+	1,  // 8: rh_trex.v1.BatchCreateFossilsRequest.requests:type_name -> rh_trex.v1.CreateFossilRequest
+	3,  // 9: rh_trex.v1.BatchUpdateFossilsRequest.requests:type_name -> rh_trex.v1.UpdateFossilRequest
+	0,  // 10: rh_trex.v1.FossilBatchResult.fossil:type_name -> rh_trex.v1.Fossil
+	19, // 11: rh_trex.v1.FossilBatchResult.error:type_name -> rh_trex.v1.BatchItemError
+	13, // 12: rh_trex.v1.FossilBatchResponse.results:type_name -> rh_trex.v1.FossilBatchResult
+	2,  // 13: rh_trex.v1.FossilService.GetFossil:input_type -> rh_trex.v1.GetFossilRequest
+	1,  // 14: rh_trex.v1.FossilService.CreateFossil:input_type -> rh_trex.v1.CreateFossilRequest
+	3,  // 15: rh_trex.v1.FossilService.UpdateFossil:input_type -> rh_trex.v1.UpdateFossilRequest
+	4,  // 16: rh_trex.v1.FossilService.DeleteFossil:input_type -> rh_trex.v1.DeleteFossilRequest
+	5,  // 17: rh_trex.v1.FossilService.ListFossils:input_type -> rh_trex.v1.ListFossilsRequest
+	8,  // 18: rh_trex.v1.FossilService.WatchFossils:input_type -> rh_trex.v1.WatchFossilsRequest
+	10, // 19: rh_trex.v1.FossilService.BatchCreateFossils:input_type -> rh_trex.v1.BatchCreateFossilsRequest
+	11, // 20: rh_trex.v1.FossilService.BatchUpdateFossils:input_type -> rh_trex.v1.BatchUpdateFossilsRequest
+	12, // 21: rh_trex.v1.FossilService.BatchDeleteFossils:input_type -> rh_trex.v1.BatchDeleteFossilsRequest
+	0,  // 22: rh_trex.v1.FossilService.GetFossil:output_type -> rh_trex.v1.Fossil
+	0,  // 23: rh_trex.v1.FossilService.CreateFossil:output_type -> rh_trex.v1.Fossil
+	0,  // 24: rh_trex.v1.FossilService.UpdateFossil:output_type -> rh_trex.v1.Fossil
+	7,  // 25: rh_trex.v1.FossilService.DeleteFossil:output_type -> rh_trex.v1.DeleteFossilResponse
+	6,  // 26: rh_trex.v1.FossilService.ListFossils:output_type -> rh_trex.v1.ListFossilsResponse
+	9,  // 27: rh_trex.v1.FossilService.WatchFossils:output_type -> rh_trex.v1.FossilWatchEvent
+	14, // 28: rh_trex.v1.FossilService.BatchCreateFossils:output_type -> rh_trex.v1.FossilBatchResponse
+	14, // 29: rh_trex.v1.FossilService.BatchUpdateFossils:output_type -> rh_trex.v1.FossilBatchResponse
+	14, // 30: rh_trex.v1.FossilService.BatchDeleteFossils:output_type -> rh_trex.v1.FossilBatchResponse
+	22, // [22:31] is the sub-list for method output_type
+	13, // [13:22] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_rh_trex_v1_fossils_proto_init() }
@@ -694,7 +1219,7 @@ func file_rh_trex_v1_fossils_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rh_trex_v1_fossils_proto_rawDesc), len(file_rh_trex_v1_fossils_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,12 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	FossilService_GetFossil_FullMethodName    = "/rh_trex.v1.FossilService/GetFossil"
-	FossilService_CreateFossil_FullMethodName = "/rh_trex.v1.FossilService/CreateFossil"
-	FossilService_UpdateFossil_FullMethodName = "/rh_trex.v1.FossilService/UpdateFossil"
-	FossilService_DeleteFossil_FullMethodName = "/rh_trex.v1.FossilService/DeleteFossil"
-	FossilService_ListFossils_FullMethodName  = "/rh_trex.v1.FossilService/ListFossils"
-	FossilService_WatchFossils_FullMethodName = "/rh_trex.v1.FossilService/WatchFossils"
+	FossilService_GetFossil_FullMethodName          = "/rh_trex.v1.FossilService/GetFossil"
+	FossilService_CreateFossil_FullMethodName       = "/rh_trex.v1.FossilService/CreateFossil"
+	FossilService_UpdateFossil_FullMethodName       = "/rh_trex.v1.FossilService/UpdateFossil"
+	FossilService_DeleteFossil_FullMethodName       = "/rh_trex.v1.FossilService/DeleteFossil"
+	FossilService_ListFossils_FullMethodName        = "/rh_trex.v1.FossilService/ListFossils"
+	FossilService_WatchFossils_FullMethodName       = "/rh_trex.v1.FossilService/WatchFossils"
+	FossilService_BatchCreateFossils_FullMethodName = "/rh_trex.v1.FossilService/BatchCreateFossils"
+	FossilService_BatchUpdateFossils_FullMethodName = "/rh_trex.v1.FossilService/BatchUpdateFossils"
+	FossilService_BatchDeleteFossils_FullMethodName = "/rh_trex.v1.FossilService/BatchDeleteFossils"
 )
 
 // FossilServiceClient is the client API for FossilService service.
@@ -37,6 +40,9 @@ type FossilServiceClient interface {
 	DeleteFossil(ctx context.Context, in *DeleteFossilRequest, opts ...grpc.CallOption) (*DeleteFossilResponse, error)
 	ListFossils(ctx context.Context, in *ListFossilsRequest, opts ...grpc.CallOption) (*ListFossilsResponse, error)
 	WatchFossils(ctx context.Context, in *WatchFossilsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FossilWatchEvent], error)
+	BatchCreateFossils(ctx context.Context, in *BatchCreateFossilsRequest, opts ...grpc.CallOption) (*FossilBatchResponse, error)
+	BatchUpdateFossils(ctx context.Context, in *BatchUpdateFossilsRequest, opts ...grpc.CallOption) (*FossilBatchResponse, error)
+	BatchDeleteFossils(ctx context.Context, in *BatchDeleteFossilsRequest, opts ...grpc.CallOption) (*FossilBatchResponse, error)
 }
 
 type fossilServiceClient struct {
@@ -116,6 +122,36 @@ func (c *fossilServiceClient) WatchFossils(ctx context.Context, in *WatchFossils
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type FossilService_WatchFossilsClient = grpc.ServerStreamingClient[FossilWatchEvent]
 
+func (c *fossilServiceClient) BatchCreateFossils(ctx context.Context, in *BatchCreateFossilsRequest, opts ...grpc.CallOption) (*FossilBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FossilBatchResponse)
+	err := c.cc.Invoke(ctx, FossilService_BatchCreateFossils_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fossilServiceClient) BatchUpdateFossils(ctx context.Context, in *BatchUpdateFossilsRequest, opts ...grpc.CallOption) (*FossilBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FossilBatchResponse)
+	err := c.cc.Invoke(ctx, FossilService_BatchUpdateFossils_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fossilServiceClient) BatchDeleteFossils(ctx context.Context, in *BatchDeleteFossilsRequest, opts ...grpc.CallOption) (*FossilBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FossilBatchResponse)
+	err := c.cc.Invoke(ctx, FossilService_BatchDeleteFossils_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FossilServiceServer is the server API for FossilService service.
 // All implementations must embed UnimplementedFossilServiceServer
 // for forward compatibility.
@@ -126,6 +162,9 @@ type FossilServiceServer interface {
 	DeleteFossil(context.Context, *DeleteFossilRequest) (*DeleteFossilResponse, error)
 	ListFossils(context.Context, *ListFossilsRequest) (*ListFossilsResponse, error)
 	WatchFossils(*WatchFossilsRequest, grpc.ServerStreamingServer[FossilWatchEvent]) error
+	BatchCreateFossils(context.Context, *BatchCreateFossilsRequest) (*FossilBatchResponse, error)
+	BatchUpdateFossils(context.Context, *BatchUpdateFossilsRequest) (*FossilBatchResponse, error)
+	BatchDeleteFossils(context.Context, *BatchDeleteFossilsRequest) (*FossilBatchResponse, error)
 	mustEmbedUnimplementedFossilServiceServer()
 }
 
@@ -154,6 +193,15 @@ func (UnimplementedFossilServiceServer) ListFossils(context.Context, *ListFossil
 func (UnimplementedFossilServiceServer) WatchFossils(*WatchFossilsRequest, grpc.ServerStreamingServer[FossilWatchEvent]) error {
 	return status.Error(codes.Unimplemented, "method WatchFossils not implemented")
 }
+func (UnimplementedFossilServiceServer) BatchCreateFossils(context.Context, *BatchCreateFossilsRequest) (*FossilBatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchCreateFossils not implemented")
+}
+func (UnimplementedFossilServiceServer) BatchUpdateFossils(context.Context, *BatchUpdateFossilsRequest) (*FossilBatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchUpdateFossils not implemented")
+}
+func (UnimplementedFossilServiceServer) BatchDeleteFossils(context.Context, *BatchDeleteFossilsRequest) (*FossilBatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchDeleteFossils not implemented")
+}
 func (UnimplementedFossilServiceServer) mustEmbedUnimplementedFossilServiceServer() {}
 func (UnimplementedFossilServiceServer) testEmbeddedByValue()                       {}
 
@@ -276,6 +324,60 @@ func _FossilService_WatchFossils_Handler(srv interface{}, stream grpc.ServerStre
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type FossilService_WatchFossilsServer = grpc.ServerStreamingServer[FossilWatchEvent]
 
+func _FossilService_BatchCreateFossils_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateFossilsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FossilServiceServer).BatchCreateFossils(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FossilService_BatchCreateFossils_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FossilServiceServer).BatchCreateFossils(ctx, req.(*BatchCreateFossilsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FossilService_BatchUpdateFossils_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateFossilsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FossilServiceServer).BatchUpdateFossils(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FossilService_BatchUpdateFossils_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FossilServiceServer).BatchUpdateFossils(ctx, req.(*BatchUpdateFossilsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FossilService_BatchDeleteFossils_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteFossilsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FossilServiceServer).BatchDeleteFossils(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FossilService_BatchDeleteFossils_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FossilServiceServer).BatchDeleteFossils(ctx, req.(*BatchDeleteFossilsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FossilService_ServiceDesc is the grpc.ServiceDesc for FossilService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -303,6 +405,18 @@ var FossilService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListFossils",
 			Handler:    _FossilService_ListFossils_Handler,
 		},
+		{
+			MethodName: "BatchCreateFossils",
+			Handler:    _FossilService_BatchCreateFossils_Handler,
+		},
+		{
+			MethodName: "BatchUpdateFossils",
+			Handler:    _FossilService_BatchUpdateFossils_Handler,
+		},
+		{
+			MethodName: "BatchDeleteFossils",
+			Handler:    _FossilService_BatchDeleteFossils_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
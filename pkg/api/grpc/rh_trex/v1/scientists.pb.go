@@ -9,6 +9,7 @@ package rh_trex_v1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -134,8 +135,10 @@ func (x *CreateScientistRequest) GetField() string {
 }
 
 type GetScientistRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// the fields of the scientist to return, e.g. name or metadata.id. Every field is returned without it.
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -177,11 +180,23 @@ func (x *GetScientistRequest) GetId() string {
 	return ""
 }
 
+func (x *GetScientistRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
 type UpdateScientistRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
-	Field         *string                `protobuf:"bytes,3,opt,name=field,proto3,oneof" json:"field,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Field *string                `protobuf:"bytes,3,opt,name=field,proto3,oneof" json:"field,omitempty"`
+	// When set, the update only succeeds if the stored resource version still matches.
+	ResourceVersion *int64 `protobuf:"varint,4,opt,name=resource_version,json=resourceVersion,proto3,oneof" json:"resource_version,omitempty"`
+	// the fields to update, by their proto name. Fields it names that the request leaves unset are cleared.
+	// Without it the fields the request sets are updated.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,5,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -237,6 +252,20 @@ func (x *UpdateScientistRequest) GetField() string {
 	return ""
 }
 
+func (x *UpdateScientistRequest) GetResourceVersion() int64 {
+	if x != nil && x.ResourceVersion != nil {
+		return *x.ResourceVersion
+	}
+	return 0
+}
+
+func (x *UpdateScientistRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
 type DeleteScientistRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -282,9 +311,21 @@ func (x *DeleteScientistRequest) GetId() string {
 }
 
 type ListScientistsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	Size          int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Page  int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Size  int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// continue_token of the previous page; resumes right after it and ignores page
+	ContinueToken string `protobuf:"bytes,3,opt,name=continue_token,json=continueToken,proto3" json:"continue_token,omitempty"`
+	// omit the total count, which is expensive on large tables
+	SkipCount bool `protobuf:"varint,4,opt,name=skip_count,json=skipCount,proto3" json:"skip_count,omitempty"`
+	// include soft-deleted records, which carry metadata.deleted_at
+	IncludeDeleted bool `protobuf:"varint,5,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// TSL search the scientists have to match, on the same fields as the REST search
+	Search string `protobuf:"bytes,6,opt,name=search,proto3" json:"search,omitempty"`
+	// the order of the scientists, like the orderBy of REST, e.g. "created_at desc"
+	OrderBy []string `protobuf:"bytes,7,rep,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// the fields of the scientists to return, see GetScientistRequest
+	ReadMask      *fieldmaskpb.FieldMask `protobuf:"bytes,8,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -333,6 +374,48 @@ func (x *ListScientistsRequest) GetSize() int32 {
 	return 0
 }
 
+func (x *ListScientistsRequest) GetContinueToken() string {
+	if x != nil {
+		return x.ContinueToken
+	}
+	return ""
+}
+
+func (x *ListScientistsRequest) GetSkipCount() bool {
+	if x != nil {
+		return x.SkipCount
+	}
+	return false
+}
+
+func (x *ListScientistsRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *ListScientistsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListScientistsRequest) GetOrderBy() []string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return nil
+}
+
+func (x *ListScientistsRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
 type ListScientistsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Items         []*Scientist           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
@@ -422,7 +505,15 @@ func (*DeleteScientistResponse) Descriptor() ([]byte, []int) {
 }
 
 type WatchScientistsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// event_id of the last event the client received. The events since are replayed before the live
+	// events; if the event is no longer kept the stream ends with EVENT_TYPE_RESYNC_REQUIRED right away.
+	ResumeFrom string `protobuf:"bytes,1,opt,name=resume_from,json=resumeFrom,proto3" json:"resume_from,omitempty"`
+	// TSL search the scientists have to match to be sent, on the same fields as the REST search
+	Filter string `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// event_seq of the last event the client received, to resume from instead of resume_from. It numbers
+	// the events of all Kinds and is not the resource_version of a resource.
+	EventSeq      int64 `protobuf:"varint,3,opt,name=event_seq,json=eventSeq,proto3" json:"event_seq,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -457,11 +548,39 @@ func (*WatchScientistsRequest) Descriptor() ([]byte, []int) {
 	return file_rh_trex_v1_scientists_proto_rawDescGZIP(), []int{8}
 }
 
+func (x *WatchScientistsRequest) GetResumeFrom() string {
+	if x != nil {
+		return x.ResumeFrom
+	}
+	return ""
+}
+
+func (x *WatchScientistsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *WatchScientistsRequest) GetEventSeq() int64 {
+	if x != nil {
+		return x.EventSeq
+	}
+	return 0
+}
+
 type ScientistWatchEvent struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=rh_trex.v1.EventType" json:"type,omitempty"`
-	Scientist     *Scientist             `protobuf:"bytes,2,opt,name=scientist,proto3" json:"scientist,omitempty"`
-	ResourceId    string                 `protobuf:"bytes,3,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Type       EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=rh_trex.v1.EventType" json:"type,omitempty"`
+	Scientist  *Scientist             `protobuf:"bytes,2,opt,name=scientist,proto3" json:"scientist,omitempty"`
+	ResourceId string                 `protobuf:"bytes,3,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	// id of the event, to resume the watch from. For EVENT_TYPE_RESYNC_REQUIRED it is the last event
+	// sent, or empty if the watch can't be resumed and the client has to list the scientists again.
+	EventId string `protobuf:"bytes,4,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// the fields an update changed, by their proto name. Empty when the update isn't known field by field.
+	ChangedFields []string `protobuf:"bytes,5,rep,name=changed_fields,json=changedFields,proto3" json:"changed_fields,omitempty"`
+	// sequence number of the event, to resume the watch from like event_id
+	EventSeq      int64 `protobuf:"varint,6,opt,name=event_seq,json=eventSeq,proto3" json:"event_seq,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -517,49 +636,680 @@ func (x *ScientistWatchEvent) GetResourceId() string {
 	return ""
 }
 
+func (x *ScientistWatchEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *ScientistWatchEvent) GetChangedFields() []string {
+	if x != nil {
+		return x.ChangedFields
+	}
+	return nil
+}
+
+func (x *ScientistWatchEvent) GetEventSeq() int64 {
+	if x != nil {
+		return x.EventSeq
+	}
+	return 0
+}
+
+type BatchCreateScientistsRequest struct {
+	state    protoimpl.MessageState    `protogen:"open.v1"`
+	Requests []*CreateScientistRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	// abort the whole batch on the first failed item instead of reporting per item results
+	AllOrNothing  bool `protobuf:"varint,2,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCreateScientistsRequest) Reset() {
+	*x = BatchCreateScientistsRequest{}
+	mi := &file_rh_trex_v1_scientists_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateScientistsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateScientistsRequest) ProtoMessage() {}
+
+func (x *BatchCreateScientistsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rh_trex_v1_scientists_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateScientistsRequest.ProtoReflect.Descriptor instead.
+func (*BatchCreateScientistsRequest) Descriptor() ([]byte, []int) {
+	return file_rh_trex_v1_scientists_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BatchCreateScientistsRequest) GetRequests() []*CreateScientistRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *BatchCreateScientistsRequest) GetAllOrNothing() bool {
+	if x != nil {
+		return x.AllOrNothing
+	}
+	return false
+}
+
+type BatchUpdateScientistsRequest struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Requests      []*UpdateScientistRequest `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	AllOrNothing  bool                      `protobuf:"varint,2,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateScientistsRequest) Reset() {
+	*x = BatchUpdateScientistsRequest{}
+	mi := &file_rh_trex_v1_scientists_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateScientistsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateScientistsRequest) ProtoMessage() {}
+
+func (x *BatchUpdateScientistsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rh_trex_v1_scientists_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateScientistsRequest.ProtoReflect.Descriptor instead.
+func (*BatchUpdateScientistsRequest) Descriptor() ([]byte, []int) {
+	return file_rh_trex_v1_scientists_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BatchUpdateScientistsRequest) GetRequests() []*UpdateScientistRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *BatchUpdateScientistsRequest) GetAllOrNothing() bool {
+	if x != nil {
+		return x.AllOrNothing
+	}
+	return false
+}
+
+type BatchDeleteScientistsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	AllOrNothing  bool                   `protobuf:"varint,2,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeleteScientistsRequest) Reset() {
+	*x = BatchDeleteScientistsRequest{}
+	mi := &file_rh_trex_v1_scientists_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteScientistsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteScientistsRequest) ProtoMessage() {}
+
+func (x *BatchDeleteScientistsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rh_trex_v1_scientists_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteScientistsRequest.ProtoReflect.Descriptor instead.
+func (*BatchDeleteScientistsRequest) Descriptor() ([]byte, []int) {
+	return file_rh_trex_v1_scientists_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BatchDeleteScientistsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *BatchDeleteScientistsRequest) GetAllOrNothing() bool {
+	if x != nil {
+		return x.AllOrNothing
+	}
+	return false
+}
+
+type ScientistBatchResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// set for successful creates and updates
+	Scientist *Scientist `protobuf:"bytes,2,opt,name=scientist,proto3" json:"scientist,omitempty"`
+	// set for failed items
+	Error         *BatchItemError `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScientistBatchResult) Reset() {
+	*x = ScientistBatchResult{}
+	mi := &file_rh_trex_v1_scientists_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScientistBatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScientistBatchResult) ProtoMessage() {}
+
+func (x *ScientistBatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_rh_trex_v1_scientists_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScientistBatchResult.ProtoReflect.Descriptor instead.
+func (*ScientistBatchResult) Descriptor() ([]byte, []int) {
+	return file_rh_trex_v1_scientists_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ScientistBatchResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScientistBatchResult) GetScientist() *Scientist {
+	if x != nil {
+		return x.Scientist
+	}
+	return nil
+}
+
+func (x *ScientistBatchResult) GetError() *BatchItemError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+type ScientistBatchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// one result per item, in request order
+	Results       []*ScientistBatchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScientistBatchResponse) Reset() {
+	*x = ScientistBatchResponse{}
+	mi := &file_rh_trex_v1_scientists_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScientistBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScientistBatchResponse) ProtoMessage() {}
+
+func (x *ScientistBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rh_trex_v1_scientists_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScientistBatchResponse.ProtoReflect.Descriptor instead.
+func (*ScientistBatchResponse) Descriptor() ([]byte, []int) {
+	return file_rh_trex_v1_scientists_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ScientistBatchResponse) GetResults() []*ScientistBatchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type LinkScientistDinosaurRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DinosaurId    string                 `protobuf:"bytes,2,opt,name=dinosaur_id,json=dinosaurId,proto3" json:"dinosaur_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkScientistDinosaurRequest) Reset() {
+	*x = LinkScientistDinosaurRequest{}
+	mi := &file_rh_trex_v1_scientists_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkScientistDinosaurRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkScientistDinosaurRequest) ProtoMessage() {}
+
+func (x *LinkScientistDinosaurRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rh_trex_v1_scientists_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkScientistDinosaurRequest.ProtoReflect.Descriptor instead.
+func (*LinkScientistDinosaurRequest) Descriptor() ([]byte, []int) {
+	return file_rh_trex_v1_scientists_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *LinkScientistDinosaurRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LinkScientistDinosaurRequest) GetDinosaurId() string {
+	if x != nil {
+		return x.DinosaurId
+	}
+	return ""
+}
+
+type LinkScientistDinosaurResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkScientistDinosaurResponse) Reset() {
+	*x = LinkScientistDinosaurResponse{}
+	mi := &file_rh_trex_v1_scientists_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkScientistDinosaurResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkScientistDinosaurResponse) ProtoMessage() {}
+
+func (x *LinkScientistDinosaurResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rh_trex_v1_scientists_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkScientistDinosaurResponse.ProtoReflect.Descriptor instead.
+func (*LinkScientistDinosaurResponse) Descriptor() ([]byte, []int) {
+	return file_rh_trex_v1_scientists_proto_rawDescGZIP(), []int{16}
+}
+
+type UnlinkScientistDinosaurRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DinosaurId    string                 `protobuf:"bytes,2,opt,name=dinosaur_id,json=dinosaurId,proto3" json:"dinosaur_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkScientistDinosaurRequest) Reset() {
+	*x = UnlinkScientistDinosaurRequest{}
+	mi := &file_rh_trex_v1_scientists_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkScientistDinosaurRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkScientistDinosaurRequest) ProtoMessage() {}
+
+func (x *UnlinkScientistDinosaurRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rh_trex_v1_scientists_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkScientistDinosaurRequest.ProtoReflect.Descriptor instead.
+func (*UnlinkScientistDinosaurRequest) Descriptor() ([]byte, []int) {
+	return file_rh_trex_v1_scientists_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UnlinkScientistDinosaurRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UnlinkScientistDinosaurRequest) GetDinosaurId() string {
+	if x != nil {
+		return x.DinosaurId
+	}
+	return ""
+}
+
+type UnlinkScientistDinosaurResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlinkScientistDinosaurResponse) Reset() {
+	*x = UnlinkScientistDinosaurResponse{}
+	mi := &file_rh_trex_v1_scientists_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlinkScientistDinosaurResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlinkScientistDinosaurResponse) ProtoMessage() {}
+
+func (x *UnlinkScientistDinosaurResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rh_trex_v1_scientists_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlinkScientistDinosaurResponse.ProtoReflect.Descriptor instead.
+func (*UnlinkScientistDinosaurResponse) Descriptor() ([]byte, []int) {
+	return file_rh_trex_v1_scientists_proto_rawDescGZIP(), []int{18}
+}
+
+type ListScientistDinosaursRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id of the scientist whose linked dinosaurs are listed
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Page           int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Size           int32                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	ContinueToken  string                 `protobuf:"bytes,4,opt,name=continue_token,json=continueToken,proto3" json:"continue_token,omitempty"`
+	SkipCount      bool                   `protobuf:"varint,5,opt,name=skip_count,json=skipCount,proto3" json:"skip_count,omitempty"`
+	IncludeDeleted bool                   `protobuf:"varint,6,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	Search         string                 `protobuf:"bytes,7,opt,name=search,proto3" json:"search,omitempty"`
+	OrderBy        []string               `protobuf:"bytes,8,rep,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	ReadMask       *fieldmaskpb.FieldMask `protobuf:"bytes,9,opt,name=read_mask,json=readMask,proto3" json:"read_mask,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListScientistDinosaursRequest) Reset() {
+	*x = ListScientistDinosaursRequest{}
+	mi := &file_rh_trex_v1_scientists_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScientistDinosaursRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScientistDinosaursRequest) ProtoMessage() {}
+
+func (x *ListScientistDinosaursRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rh_trex_v1_scientists_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScientistDinosaursRequest.ProtoReflect.Descriptor instead.
+func (*ListScientistDinosaursRequest) Descriptor() ([]byte, []int) {
+	return file_rh_trex_v1_scientists_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListScientistDinosaursRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ListScientistDinosaursRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListScientistDinosaursRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ListScientistDinosaursRequest) GetContinueToken() string {
+	if x != nil {
+		return x.ContinueToken
+	}
+	return ""
+}
+
+func (x *ListScientistDinosaursRequest) GetSkipCount() bool {
+	if x != nil {
+		return x.SkipCount
+	}
+	return false
+}
+
+func (x *ListScientistDinosaursRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *ListScientistDinosaursRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListScientistDinosaursRequest) GetOrderBy() []string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return nil
+}
+
+func (x *ListScientistDinosaursRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
 var File_rh_trex_v1_scientists_proto protoreflect.FileDescriptor
 
 const file_rh_trex_v1_scientists_proto_rawDesc = "" +
 	"\n" +
 	"\x1brh_trex/v1/scientists.proto\x12\n" +
-	"rh_trex.v1\x1a\x17rh_trex/v1/common.proto\"n\n" +
+	"rh_trex.v1\x1a google/protobuf/field_mask.proto\x1a\x17rh_trex/v1/common.proto\x1a\x1arh_trex/v1/dinosaurs.proto\"n\n" +
 	"\tScientist\x127\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x1b.rh_trex.v1.ObjectReferenceR\bmetadata\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
 	"\x05field\x18\x03 \x01(\tR\x05field\"B\n" +
 	"\x16CreateScientistRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
-	"\x05field\x18\x02 \x01(\tR\x05field\"%\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\"^\n" +
 	"\x13GetScientistRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"o\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x127\n" +
+	"\tread_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\"\xf1\x01\n" +
 	"\x16UpdateScientistRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x19\n" +
-	"\x05field\x18\x03 \x01(\tH\x01R\x05field\x88\x01\x01B\a\n" +
+	"\x05field\x18\x03 \x01(\tH\x01R\x05field\x88\x01\x01\x12.\n" +
+	"\x10resource_version\x18\x04 \x01(\x03H\x02R\x0fresourceVersion\x88\x01\x01\x12;\n" +
+	"\vupdate_mask\x18\x05 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMaskB\a\n" +
 	"\x05_nameB\b\n" +
-	"\x06_field\"(\n" +
+	"\x06_fieldB\x13\n" +
+	"\x11_resource_version\"(\n" +
 	"\x16DeleteScientistRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"?\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x9a\x02\n" +
 	"\x15ListScientistsRequest\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x12\n" +
-	"\x04size\x18\x02 \x01(\x05R\x04size\"w\n" +
+	"\x04size\x18\x02 \x01(\x05R\x04size\x12%\n" +
+	"\x0econtinue_token\x18\x03 \x01(\tR\rcontinueToken\x12\x1d\n" +
+	"\n" +
+	"skip_count\x18\x04 \x01(\bR\tskipCount\x12'\n" +
+	"\x0finclude_deleted\x18\x05 \x01(\bR\x0eincludeDeleted\x12\x16\n" +
+	"\x06search\x18\x06 \x01(\tR\x06search\x12\x19\n" +
+	"\border_by\x18\a \x03(\tR\aorderBy\x127\n" +
+	"\tread_mask\x18\b \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask\"w\n" +
 	"\x16ListScientistsResponse\x12+\n" +
 	"\x05items\x18\x01 \x03(\v2\x15.rh_trex.v1.ScientistR\x05items\x120\n" +
 	"\bmetadata\x18\x02 \x01(\v2\x14.rh_trex.v1.ListMetaR\bmetadata\"\x19\n" +
-	"\x17DeleteScientistResponse\"\x18\n" +
-	"\x16WatchScientistsRequest\"\x96\x01\n" +
+	"\x17DeleteScientistResponse\"n\n" +
+	"\x16WatchScientistsRequest\x12\x1f\n" +
+	"\vresume_from\x18\x01 \x01(\tR\n" +
+	"resumeFrom\x12\x16\n" +
+	"\x06filter\x18\x02 \x01(\tR\x06filter\x12\x1b\n" +
+	"\tevent_seq\x18\x03 \x01(\x03R\beventSeq\"\xf5\x01\n" +
 	"\x13ScientistWatchEvent\x12)\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x15.rh_trex.v1.EventTypeR\x04type\x123\n" +
 	"\tscientist\x18\x02 \x01(\v2\x15.rh_trex.v1.ScientistR\tscientist\x12\x1f\n" +
 	"\vresource_id\x18\x03 \x01(\tR\n" +
-	"resourceId2\x85\x04\n" +
+	"resourceId\x12\x19\n" +
+	"\bevent_id\x18\x04 \x01(\tR\aeventId\x12%\n" +
+	"\x0echanged_fields\x18\x05 \x03(\tR\rchangedFields\x12\x1b\n" +
+	"\tevent_seq\x18\x06 \x01(\x03R\beventSeq\"\x84\x01\n" +
+	"\x1cBatchCreateScientistsRequest\x12>\n" +
+	"\brequests\x18\x01 \x03(\v2\".rh_trex.v1.CreateScientistRequestR\brequests\x12$\n" +
+	"\x0eall_or_nothing\x18\x02 \x01(\bR\fallOrNothing\"\x84\x01\n" +
+	"\x1cBatchUpdateScientistsRequest\x12>\n" +
+	"\brequests\x18\x01 \x03(\v2\".rh_trex.v1.UpdateScientistRequestR\brequests\x12$\n" +
+	"\x0eall_or_nothing\x18\x02 \x01(\bR\fallOrNothing\"V\n" +
+	"\x1cBatchDeleteScientistsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12$\n" +
+	"\x0eall_or_nothing\x18\x02 \x01(\bR\fallOrNothing\"\x8d\x01\n" +
+	"\x14ScientistBatchResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x123\n" +
+	"\tscientist\x18\x02 \x01(\v2\x15.rh_trex.v1.ScientistR\tscientist\x120\n" +
+	"\x05error\x18\x03 \x01(\v2\x1a.rh_trex.v1.BatchItemErrorR\x05error\"T\n" +
+	"\x16ScientistBatchResponse\x12:\n" +
+	"\aresults\x18\x01 \x03(\v2 .rh_trex.v1.ScientistBatchResultR\aresults\"O\n" +
+	"\x1cLinkScientistDinosaurRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vdinosaur_id\x18\x02 \x01(\tR\n" +
+	"dinosaurId\"\x1f\n" +
+	"\x1dLinkScientistDinosaurResponse\"Q\n" +
+	"\x1eUnlinkScientistDinosaurRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vdinosaur_id\x18\x02 \x01(\tR\n" +
+	"dinosaurId\"!\n" +
+	"\x1fUnlinkScientistDinosaurResponse\"\xb2\x02\n" +
+	"\x1dListScientistDinosaursRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x05R\x04size\x12%\n" +
+	"\x0econtinue_token\x18\x04 \x01(\tR\rcontinueToken\x12\x1d\n" +
+	"\n" +
+	"skip_count\x18\x05 \x01(\bR\tskipCount\x12'\n" +
+	"\x0finclude_deleted\x18\x06 \x01(\bR\x0eincludeDeleted\x12\x16\n" +
+	"\x06search\x18\a \x01(\tR\x06search\x12\x19\n" +
+	"\border_by\x18\b \x03(\tR\aorderBy\x127\n" +
+	"\tread_mask\x18\t \x01(\v2\x1a.google.protobuf.FieldMaskR\breadMask2\x84\t\n" +
 	"\x10ScientistService\x12F\n" +
 	"\fGetScientist\x12\x1f.rh_trex.v1.GetScientistRequest\x1a\x15.rh_trex.v1.Scientist\x12L\n" +
 	"\x0fCreateScientist\x12\".rh_trex.v1.CreateScientistRequest\x1a\x15.rh_trex.v1.Scientist\x12L\n" +
 	"\x0fUpdateScientist\x12\".rh_trex.v1.UpdateScientistRequest\x1a\x15.rh_trex.v1.Scientist\x12Z\n" +
 	"\x0fDeleteScientist\x12\".rh_trex.v1.DeleteScientistRequest\x1a#.rh_trex.v1.DeleteScientistResponse\x12W\n" +
 	"\x0eListScientists\x12!.rh_trex.v1.ListScientistsRequest\x1a\".rh_trex.v1.ListScientistsResponse\x12X\n" +
-	"\x0fWatchScientists\x12\".rh_trex.v1.WatchScientistsRequest\x1a\x1f.rh_trex.v1.ScientistWatchEvent0\x01BKZIgithub.com/openshift-online/rh-trex-ai/pkg/api/grpc/rh_trex/v1;rh_trex_v1b\x06proto3"
+	"\x0fWatchScientists\x12\".rh_trex.v1.WatchScientistsRequest\x1a\x1f.rh_trex.v1.ScientistWatchEvent0\x01\x12e\n" +
+	"\x15BatchCreateScientists\x12(.rh_trex.v1.BatchCreateScientistsRequest\x1a\".rh_trex.v1.ScientistBatchResponse\x12e\n" +
+	"\x15BatchUpdateScientists\x12(.rh_trex.v1.BatchUpdateScientistsRequest\x1a\".rh_trex.v1.ScientistBatchResponse\x12e\n" +
+	"\x15BatchDeleteScientists\x12(.rh_trex.v1.BatchDeleteScientistsRequest\x1a\".rh_trex.v1.ScientistBatchResponse\x12l\n" +
+	"\x15LinkScientistDinosaur\x12(.rh_trex.v1.LinkScientistDinosaurRequest\x1a).rh_trex.v1.LinkScientistDinosaurResponse\x12r\n" +
+	"\x17UnlinkScientistDinosaur\x12*.rh_trex.v1.UnlinkScientistDinosaurRequest\x1a+.rh_trex.v1.UnlinkScientistDinosaurResponse\x12f\n" +
+	"\x16ListScientistDinosaurs\x12).rh_trex.v1.ListScientistDinosaursRequest\x1a!.rh_trex.v1.ListDinosaursResponseBKZIgithub.com/openshift-online/rh-trex-ai/pkg/api/grpc/rh_trex/v1;rh_trex_v1b\x06proto3"
 
 var (
 	file_rh_trex_v1_scientists_proto_rawDescOnce sync.Once
@@ -573,45 +1323,79 @@ func file_rh_trex_v1_scientists_proto_rawDescGZIP() []byte {
 	return file_rh_trex_v1_scientists_proto_rawDescData
 }
 
-var file_rh_trex_v1_scientists_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_rh_trex_v1_scientists_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_rh_trex_v1_scientists_proto_goTypes = []any{
-	(*Scientist)(nil),               // 0: rh_trex.v1.Scientist
-	(*CreateScientistRequest)(nil),  // 1: rh_trex.v1.CreateScientistRequest
-	(*GetScientistRequest)(nil),     // 2: rh_trex.v1.GetScientistRequest
-	(*UpdateScientistRequest)(nil),  // 3: rh_trex.v1.UpdateScientistRequest
-	(*DeleteScientistRequest)(nil),  // 4: rh_trex.v1.DeleteScientistRequest
-	(*ListScientistsRequest)(nil),   // 5: rh_trex.v1.ListScientistsRequest
-	(*ListScientistsResponse)(nil),  // 6: rh_trex.v1.ListScientistsResponse
-	(*DeleteScientistResponse)(nil), // 7: rh_trex.v1.DeleteScientistResponse
-	(*WatchScientistsRequest)(nil),  // 8: rh_trex.v1.WatchScientistsRequest
-	(*ScientistWatchEvent)(nil),     // 9: rh_trex.v1.ScientistWatchEvent
-	(*ObjectReference)(nil),         // 10: rh_trex.v1.ObjectReference
-	(*ListMeta)(nil),                // 11: rh_trex.v1.ListMeta
-	(EventType)(0),                  // 12: rh_trex.v1.EventType
+	(*Scientist)(nil),                       // 0: rh_trex.v1.Scientist
+	(*CreateScientistRequest)(nil),          // 1: rh_trex.v1.CreateScientistRequest
+	(*GetScientistRequest)(nil),             // 2: rh_trex.v1.GetScientistRequest
+	(*UpdateScientistRequest)(nil),          // 3: rh_trex.v1.UpdateScientistRequest
+	(*DeleteScientistRequest)(nil),          // 4: rh_trex.v1.DeleteScientistRequest
+	(*ListScientistsRequest)(nil),           // 5: rh_trex.v1.ListScientistsRequest
+	(*ListScientistsResponse)(nil),          // 6: rh_trex.v1.ListScientistsResponse
+	(*DeleteScientistResponse)(nil),         // 7: rh_trex.v1.DeleteScientistResponse
+	(*WatchScientistsRequest)(nil),          // 8: rh_trex.v1.WatchScientistsRequest
+	(*ScientistWatchEvent)(nil),             // 9: rh_trex.v1.ScientistWatchEvent
+	(*BatchCreateScientistsRequest)(nil),    // 10: rh_trex.v1.BatchCreateScientistsRequest
+	(*BatchUpdateScientistsRequest)(nil),    // 11: rh_trex.v1.BatchUpdateScientistsRequest
+	(*BatchDeleteScientistsRequest)(nil),    // 12: rh_trex.v1.BatchDeleteScientistsRequest
+	(*ScientistBatchResult)(nil),            // 13: rh_trex.v1.ScientistBatchResult
+	(*ScientistBatchResponse)(nil),          // 14: rh_trex.v1.ScientistBatchResponse
+	(*LinkScientistDinosaurRequest)(nil),    // 15: rh_trex.v1.LinkScientistDinosaurRequest
+	(*LinkScientistDinosaurResponse)(nil),   // 16: rh_trex.v1.LinkScientistDinosaurResponse
+	(*UnlinkScientistDinosaurRequest)(nil),  // 17: rh_trex.v1.UnlinkScientistDinosaurRequest
+	(*UnlinkScientistDinosaurResponse)(nil), // 18: rh_trex.v1.UnlinkScientistDinosaurResponse
+	(*ListScientistDinosaursRequest)(nil),   // 19: rh_trex.v1.ListScientistDinosaursRequest
+	(*ObjectReference)(nil),                 // 20: rh_trex.v1.ObjectReference
+	(*fieldmaskpb.FieldMask)(nil),           // 21: google.protobuf.FieldMask
+	(*ListMeta)(nil),                        // 22: rh_trex.v1.ListMeta
+	(EventType)(0),                          // 23: rh_trex.v1.EventType
+	(*BatchItemError)(nil),                  // 24: rh_trex.v1.BatchItemError
+	(*ListDinosaursResponse)(nil),           // 25: rh_trex.v1.ListDinosaursResponse
 }
 var file_rh_trex_v1_scientists_proto_depIdxs = []int32{
-	10, // 0: rh_trex.v1.Scientist.metadata:type_name -> rh_trex.v1.ObjectReference
-	0,  // 1: rh_trex.v1.ListScientistsResponse.items:type_name -> rh_trex.v1.Scientist
-	11, // 2: rh_trex.v1.ListScientistsResponse.metadata:type_name -> rh_trex.v1.ListMeta
-	12, // 3: rh_trex.v1.ScientistWatchEvent.type:type_name -> rh_trex.v1.EventType
-	0,  // 4: rh_trex.v1.ScientistWatchEvent.scientist:type_name -> rh_trex.v1.Scientist
-	2,  // 5: rh_trex.v1.ScientistService.GetScientist:input_type -> rh_trex.v1.GetScientistRequest
-	1,  // 6: rh_trex.v1.ScientistService.CreateScientist:input_type -> rh_trex.v1.CreateScientistRequest
-	3,  // 7: rh_trex.v1.ScientistService.UpdateScientist:input_type -> rh_trex.v1.UpdateScientistRequest
-	4,  // 8: rh_trex.v1.ScientistService.DeleteScientist:input_type -> rh_trex.v1.DeleteScientistRequest
-	5,  // 9: rh_trex.v1.ScientistService.ListScientists:input_type -> rh_trex.v1.ListScientistsRequest
-	8,  // 10: rh_trex.v1.ScientistService.WatchScientists:input_type -> rh_trex.v1.WatchScientistsRequest
-	0,  // 11: rh_trex.v1.ScientistService.GetScientist:output_type -> rh_trex.v1.Scientist
-	0,  // 12: rh_trex.v1.ScientistService.CreateScientist:output_type -> rh_trex.v1.Scientist
-	0,  // 13: rh_trex.v1.ScientistService.UpdateScientist:output_type -> rh_trex.v1.Scientist
-	7,  // 14: rh_trex.v1.ScientistService.DeleteScientist:output_type -> rh_trex.v1.DeleteScientistResponse
-	6,  // 15: rh_trex.v1.ScientistService.ListScientists:output_type -> rh_trex.v1.ListScientistsResponse
-	9,  // 16: rh_trex.v1.ScientistService.WatchScientists:output_type -> rh_trex.v1.ScientistWatchEvent
-	11, // [11:17] is the sub-list for method output_type
-	5,  // [5:11] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	20, // 0: rh_trex.v1.Scientist.metadata:type_name -> rh_trex.v1.ObjectReference
+	21, // 1: rh_trex.v1.GetScientistRequest.read_mask:type_name -> google.protobuf.FieldMask
+	21, // 2: rh_trex.v1.UpdateScientistRequest.update_mask:type_name -> google.protobuf.FieldMask
+	21, // 3: rh_trex.v1.ListScientistsRequest.read_mask:type_name -> google.protobuf.FieldMask
+	0,  // 4: rh_trex.v1.ListScientistsResponse.items:type_name -> rh_trex.v1.Scientist
+	22, // 5: rh_trex.v1.ListScientistsResponse.metadata:type_name -> rh_trex.v1.ListMeta
+	23, // 6: rh_trex.v1.ScientistWatchEvent.type:type_name -> rh_trex.v1.EventType
+	0,  // 7: rh_trex.v1.ScientistWatchEvent.scientist:type_name -> rh_trex.v1.Scientist
+	1,  // 8: rh_trex.v1.BatchCreateScientistsRequest.requests:type_name -> rh_trex.v1.CreateScientistRequest
+	3,  // 9: rh_trex.v1.BatchUpdateScientistsRequest.requests:type_name -> rh_trex.v1.UpdateScientistRequest
+	0,  // 10: rh_trex.v1.ScientistBatchResult.scientist:type_name -> rh_trex.v1.Scientist
+	24, // 11: rh_trex.v1.ScientistBatchResult.error:type_name -> rh_trex.v1.BatchItemError
+	13, // 12: rh_trex.v1.ScientistBatchResponse.results:type_name -> rh_trex.v1.ScientistBatchResult
+	21, // 13: rh_trex.v1.ListScientistDinosaursRequest.read_mask:type_name -> google.protobuf.FieldMask
+	2,  // 14: rh_trex.v1.ScientistService.GetScientist:input_type -> rh_trex.v1.GetScientistRequest
+	1,  // 15: rh_trex.v1.ScientistService.CreateScientist:input_type -> rh_trex.v1.CreateScientistRequest
+	3,  // 16: rh_trex.v1.ScientistService.UpdateScientist:input_type -> rh_trex.v1.UpdateScientistRequest
+	4,  // 17: rh_trex.v1.ScientistService.DeleteScientist:input_type -> rh_trex.v1.DeleteScientistRequest
+	5,  // 18: rh_trex.v1.ScientistService.ListScientists:input_type -> rh_trex.v1.ListScientistsRequest
+	8,  // 19: rh_trex.v1.ScientistService.WatchScientists:input_type -> rh_trex.v1.WatchScientistsRequest
+	10, // 20: rh_trex.v1.ScientistService.BatchCreateScientists:input_type -> rh_trex.v1.BatchCreateScientistsRequest
+	11, // 21: rh_trex.v1.ScientistService.BatchUpdateScientists:input_type -> rh_trex.v1.BatchUpdateScientistsRequest
+	12, // 22: rh_trex.v1.ScientistService.BatchDeleteScientists:input_type -> rh_trex.v1.BatchDeleteScientistsRequest
+	15, // 23: rh_trex.v1.ScientistService.LinkScientistDinosaur:input_type -> rh_trex.v1.LinkScientistDinosaurRequest
+	17, // 24: rh_trex.v1.ScientistService.UnlinkScientistDinosaur:input_type -> rh_trex.v1.UnlinkScientistDinosaurRequest
+	19, // 25: rh_trex.v1.ScientistService.ListScientistDinosaurs:input_type -> rh_trex.v1.ListScientistDinosaursRequest
+	0,  // 26: rh_trex.v1.ScientistService.GetScientist:output_type -> rh_trex.v1.Scientist
+	0,  // 27: rh_trex.v1.ScientistService.CreateScientist:output_type -> rh_trex.v1.Scientist
+	0,  // 28: rh_trex.v1.ScientistService.UpdateScientist:output_type -> rh_trex.v1.Scientist
+	7,  // 29: rh_trex.v1.ScientistService.DeleteScientist:output_type -> rh_trex.v1.DeleteScientistResponse
+	6,  // 30: rh_trex.v1.ScientistService.ListScientists:output_type -> rh_trex.v1.ListScientistsResponse
+	9,  // 31: rh_trex.v1.ScientistService.WatchScientists:output_type -> rh_trex.v1.ScientistWatchEvent
+	14, // 32: rh_trex.v1.ScientistService.BatchCreateScientists:output_type -> rh_trex.v1.ScientistBatchResponse
+	14, // 33: rh_trex.v1.ScientistService.BatchUpdateScientists:output_type -> rh_trex.v1.ScientistBatchResponse
+	14, // 34: rh_trex.v1.ScientistService.BatchDeleteScientists:output_type -> rh_trex.v1.ScientistBatchResponse
+	16, // 35: rh_trex.v1.ScientistService.LinkScientistDinosaur:output_type -> rh_trex.v1.LinkScientistDinosaurResponse
+	18, // 36: rh_trex.v1.ScientistService.UnlinkScientistDinosaur:output_type -> rh_trex.v1.UnlinkScientistDinosaurResponse
+	25, // 37: rh_trex.v1.ScientistService.ListScientistDinosaurs:output_type -> rh_trex.v1.ListDinosaursResponse
+	26, // [26:38] is the sub-list for method output_type
+	14, // [14:26] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_rh_trex_v1_scientists_proto_init() }
@@ -620,6 +1404,7 @@ func file_rh_trex_v1_scientists_proto_init() {
 		return
 	}
 	file_rh_trex_v1_common_proto_init()
+	file_rh_trex_v1_dinosaurs_proto_init()
 	file_rh_trex_v1_scientists_proto_msgTypes[3].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -627,7 +1412,7 @@ func file_rh_trex_v1_scientists_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rh_trex_v1_scientists_proto_rawDesc), len(file_rh_trex_v1_scientists_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
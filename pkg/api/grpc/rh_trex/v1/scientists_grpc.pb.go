@@ -19,12 +19,18 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ScientistService_GetScientist_FullMethodName    = "/rh_trex.v1.ScientistService/GetScientist"
-	ScientistService_CreateScientist_FullMethodName = "/rh_trex.v1.ScientistService/CreateScientist"
-	ScientistService_UpdateScientist_FullMethodName = "/rh_trex.v1.ScientistService/UpdateScientist"
-	ScientistService_DeleteScientist_FullMethodName = "/rh_trex.v1.ScientistService/DeleteScientist"
-	ScientistService_ListScientists_FullMethodName  = "/rh_trex.v1.ScientistService/ListScientists"
-	ScientistService_WatchScientists_FullMethodName = "/rh_trex.v1.ScientistService/WatchScientists"
+	ScientistService_GetScientist_FullMethodName            = "/rh_trex.v1.ScientistService/GetScientist"
+	ScientistService_CreateScientist_FullMethodName         = "/rh_trex.v1.ScientistService/CreateScientist"
+	ScientistService_UpdateScientist_FullMethodName         = "/rh_trex.v1.ScientistService/UpdateScientist"
+	ScientistService_DeleteScientist_FullMethodName         = "/rh_trex.v1.ScientistService/DeleteScientist"
+	ScientistService_ListScientists_FullMethodName          = "/rh_trex.v1.ScientistService/ListScientists"
+	ScientistService_WatchScientists_FullMethodName         = "/rh_trex.v1.ScientistService/WatchScientists"
+	ScientistService_BatchCreateScientists_FullMethodName   = "/rh_trex.v1.ScientistService/BatchCreateScientists"
+	ScientistService_BatchUpdateScientists_FullMethodName   = "/rh_trex.v1.ScientistService/BatchUpdateScientists"
+	ScientistService_BatchDeleteScientists_FullMethodName   = "/rh_trex.v1.ScientistService/BatchDeleteScientists"
+	ScientistService_LinkScientistDinosaur_FullMethodName   = "/rh_trex.v1.ScientistService/LinkScientistDinosaur"
+	ScientistService_UnlinkScientistDinosaur_FullMethodName = "/rh_trex.v1.ScientistService/UnlinkScientistDinosaur"
+	ScientistService_ListScientistDinosaurs_FullMethodName  = "/rh_trex.v1.ScientistService/ListScientistDinosaurs"
 )
 
 // ScientistServiceClient is the client API for ScientistService service.
@@ -37,6 +43,12 @@ type ScientistServiceClient interface {
 	DeleteScientist(ctx context.Context, in *DeleteScientistRequest, opts ...grpc.CallOption) (*DeleteScientistResponse, error)
 	ListScientists(ctx context.Context, in *ListScientistsRequest, opts ...grpc.CallOption) (*ListScientistsResponse, error)
 	WatchScientists(ctx context.Context, in *WatchScientistsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ScientistWatchEvent], error)
+	BatchCreateScientists(ctx context.Context, in *BatchCreateScientistsRequest, opts ...grpc.CallOption) (*ScientistBatchResponse, error)
+	BatchUpdateScientists(ctx context.Context, in *BatchUpdateScientistsRequest, opts ...grpc.CallOption) (*ScientistBatchResponse, error)
+	BatchDeleteScientists(ctx context.Context, in *BatchDeleteScientistsRequest, opts ...grpc.CallOption) (*ScientistBatchResponse, error)
+	LinkScientistDinosaur(ctx context.Context, in *LinkScientistDinosaurRequest, opts ...grpc.CallOption) (*LinkScientistDinosaurResponse, error)
+	UnlinkScientistDinosaur(ctx context.Context, in *UnlinkScientistDinosaurRequest, opts ...grpc.CallOption) (*UnlinkScientistDinosaurResponse, error)
+	ListScientistDinosaurs(ctx context.Context, in *ListScientistDinosaursRequest, opts ...grpc.CallOption) (*ListDinosaursResponse, error)
 }
 
 type scientistServiceClient struct {
@@ -116,6 +128,66 @@ func (c *scientistServiceClient) WatchScientists(ctx context.Context, in *WatchS
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ScientistService_WatchScientistsClient = grpc.ServerStreamingClient[ScientistWatchEvent]
 
+func (c *scientistServiceClient) BatchCreateScientists(ctx context.Context, in *BatchCreateScientistsRequest, opts ...grpc.CallOption) (*ScientistBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScientistBatchResponse)
+	err := c.cc.Invoke(ctx, ScientistService_BatchCreateScientists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scientistServiceClient) BatchUpdateScientists(ctx context.Context, in *BatchUpdateScientistsRequest, opts ...grpc.CallOption) (*ScientistBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScientistBatchResponse)
+	err := c.cc.Invoke(ctx, ScientistService_BatchUpdateScientists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scientistServiceClient) BatchDeleteScientists(ctx context.Context, in *BatchDeleteScientistsRequest, opts ...grpc.CallOption) (*ScientistBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScientistBatchResponse)
+	err := c.cc.Invoke(ctx, ScientistService_BatchDeleteScientists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scientistServiceClient) LinkScientistDinosaur(ctx context.Context, in *LinkScientistDinosaurRequest, opts ...grpc.CallOption) (*LinkScientistDinosaurResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LinkScientistDinosaurResponse)
+	err := c.cc.Invoke(ctx, ScientistService_LinkScientistDinosaur_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scientistServiceClient) UnlinkScientistDinosaur(ctx context.Context, in *UnlinkScientistDinosaurRequest, opts ...grpc.CallOption) (*UnlinkScientistDinosaurResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnlinkScientistDinosaurResponse)
+	err := c.cc.Invoke(ctx, ScientistService_UnlinkScientistDinosaur_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scientistServiceClient) ListScientistDinosaurs(ctx context.Context, in *ListScientistDinosaursRequest, opts ...grpc.CallOption) (*ListDinosaursResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDinosaursResponse)
+	err := c.cc.Invoke(ctx, ScientistService_ListScientistDinosaurs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ScientistServiceServer is the server API for ScientistService service.
 // All implementations must embed UnimplementedScientistServiceServer
 // for forward compatibility.
@@ -126,6 +198,12 @@ type ScientistServiceServer interface {
 	DeleteScientist(context.Context, *DeleteScientistRequest) (*DeleteScientistResponse, error)
 	ListScientists(context.Context, *ListScientistsRequest) (*ListScientistsResponse, error)
 	WatchScientists(*WatchScientistsRequest, grpc.ServerStreamingServer[ScientistWatchEvent]) error
+	BatchCreateScientists(context.Context, *BatchCreateScientistsRequest) (*ScientistBatchResponse, error)
+	BatchUpdateScientists(context.Context, *BatchUpdateScientistsRequest) (*ScientistBatchResponse, error)
+	BatchDeleteScientists(context.Context, *BatchDeleteScientistsRequest) (*ScientistBatchResponse, error)
+	LinkScientistDinosaur(context.Context, *LinkScientistDinosaurRequest) (*LinkScientistDinosaurResponse, error)
+	UnlinkScientistDinosaur(context.Context, *UnlinkScientistDinosaurRequest) (*UnlinkScientistDinosaurResponse, error)
+	ListScientistDinosaurs(context.Context, *ListScientistDinosaursRequest) (*ListDinosaursResponse, error)
 	mustEmbedUnimplementedScientistServiceServer()
 }
 
@@ -154,6 +232,24 @@ func (UnimplementedScientistServiceServer) ListScientists(context.Context, *List
 func (UnimplementedScientistServiceServer) WatchScientists(*WatchScientistsRequest, grpc.ServerStreamingServer[ScientistWatchEvent]) error {
 	return status.Error(codes.Unimplemented, "method WatchScientists not implemented")
 }
+func (UnimplementedScientistServiceServer) BatchCreateScientists(context.Context, *BatchCreateScientistsRequest) (*ScientistBatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchCreateScientists not implemented")
+}
+func (UnimplementedScientistServiceServer) BatchUpdateScientists(context.Context, *BatchUpdateScientistsRequest) (*ScientistBatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchUpdateScientists not implemented")
+}
+func (UnimplementedScientistServiceServer) BatchDeleteScientists(context.Context, *BatchDeleteScientistsRequest) (*ScientistBatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchDeleteScientists not implemented")
+}
+func (UnimplementedScientistServiceServer) LinkScientistDinosaur(context.Context, *LinkScientistDinosaurRequest) (*LinkScientistDinosaurResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method LinkScientistDinosaur not implemented")
+}
+func (UnimplementedScientistServiceServer) UnlinkScientistDinosaur(context.Context, *UnlinkScientistDinosaurRequest) (*UnlinkScientistDinosaurResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnlinkScientistDinosaur not implemented")
+}
+func (UnimplementedScientistServiceServer) ListScientistDinosaurs(context.Context, *ListScientistDinosaursRequest) (*ListDinosaursResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListScientistDinosaurs not implemented")
+}
 func (UnimplementedScientistServiceServer) mustEmbedUnimplementedScientistServiceServer() {}
 func (UnimplementedScientistServiceServer) testEmbeddedByValue()                          {}
 
@@ -276,6 +372,114 @@ func _ScientistService_WatchScientists_Handler(srv interface{}, stream grpc.Serv
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type ScientistService_WatchScientistsServer = grpc.ServerStreamingServer[ScientistWatchEvent]
 
+func _ScientistService_BatchCreateScientists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateScientistsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScientistServiceServer).BatchCreateScientists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScientistService_BatchCreateScientists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScientistServiceServer).BatchCreateScientists(ctx, req.(*BatchCreateScientistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScientistService_BatchUpdateScientists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateScientistsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScientistServiceServer).BatchUpdateScientists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScientistService_BatchUpdateScientists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScientistServiceServer).BatchUpdateScientists(ctx, req.(*BatchUpdateScientistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScientistService_BatchDeleteScientists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteScientistsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScientistServiceServer).BatchDeleteScientists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScientistService_BatchDeleteScientists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScientistServiceServer).BatchDeleteScientists(ctx, req.(*BatchDeleteScientistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScientistService_LinkScientistDinosaur_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkScientistDinosaurRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScientistServiceServer).LinkScientistDinosaur(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScientistService_LinkScientistDinosaur_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScientistServiceServer).LinkScientistDinosaur(ctx, req.(*LinkScientistDinosaurRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScientistService_UnlinkScientistDinosaur_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlinkScientistDinosaurRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScientistServiceServer).UnlinkScientistDinosaur(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScientistService_UnlinkScientistDinosaur_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScientistServiceServer).UnlinkScientistDinosaur(ctx, req.(*UnlinkScientistDinosaurRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScientistService_ListScientistDinosaurs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScientistDinosaursRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScientistServiceServer).ListScientistDinosaurs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScientistService_ListScientistDinosaurs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScientistServiceServer).ListScientistDinosaurs(ctx, req.(*ListScientistDinosaursRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ScientistService_ServiceDesc is the grpc.ServiceDesc for ScientistService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -303,6 +507,30 @@ var ScientistService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListScientists",
 			Handler:    _ScientistService_ListScientists_Handler,
 		},
+		{
+			MethodName: "BatchCreateScientists",
+			Handler:    _ScientistService_BatchCreateScientists_Handler,
+		},
+		{
+			MethodName: "BatchUpdateScientists",
+			Handler:    _ScientistService_BatchUpdateScientists_Handler,
+		},
+		{
+			MethodName: "BatchDeleteScientists",
+			Handler:    _ScientistService_BatchDeleteScientists_Handler,
+		},
+		{
+			MethodName: "LinkScientistDinosaur",
+			Handler:    _ScientistService_LinkScientistDinosaur_Handler,
+		},
+		{
+			MethodName: "UnlinkScientistDinosaur",
+			Handler:    _ScientistService_UnlinkScientistDinosaur_Handler,
+		},
+		{
+			MethodName: "ListScientistDinosaurs",
+			Handler:    _ScientistService_ListScientistDinosaurs_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IdempotencyKey remembers the response to a request sent with an Idempotency-Key, so that a retry of the
+// request replays the response instead of running the request again
+type IdempotencyKey struct {
+	ID        string `gorm:"primaryKey"`
+	CreatedAt time.Time
+	// Scope is the caller the key belongs to, the same key sent by different callers never collides
+	Scope string
+	Key   string
+	// RequestHash tells a retry apart from a different request reusing the key
+	RequestHash string
+	StatusCode  int
+	// Header holds the JSON encoded response headers to replay, if any
+	Header string
+	Body   []byte
+}
+
+type IdempotencyKeyList []*IdempotencyKey
+
+func (d *IdempotencyKey) BeforeCreate(tx *gorm.DB) error {
+	d.ID = NewID()
+	return nil
+}
@@ -34,11 +34,20 @@ type Metadata struct {
 }
 
 // Meta is base model definition, embedded in all kinds
+//
+// ResourceVersion is incremented on every successful Replace and is used for optimistic
+// concurrency: it is surfaced to clients as an ETag and checked against If-Match.
+//
+// OrganizationID and Owner are the organization and the user of the request that created the row.
+// Requests only see the rows of their own organization, see dao.TenantScope.
 type Meta struct {
-	ID        string
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID              string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
+	ResourceVersion int64
+	OrganizationID  string `gorm:"index"`
+	Owner           string
 }
 
 // PagingMeta List Paging metadata
@@ -46,4 +55,6 @@ type PagingMeta struct {
 	Page  int
 	Size  int64
 	Total int64
+	// Continue is the token for the next page, empty on the last page
+	Continue string
 }
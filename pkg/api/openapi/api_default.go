@@ -23,13 +23,16 @@ import (
 type DefaultAPIService service
 
 type ApiApiRhTrexAiV1DinosaursGetRequest struct {
-	ctx        context.Context
-	ApiService *DefaultAPIService
-	page       *int32
-	size       *int32
-	search     *string
-	orderBy    *string
-	fields     *string
+	ctx            context.Context
+	ApiService     *DefaultAPIService
+	page           *int32
+	size           *int32
+	search         *string
+	orderBy        *string
+	fields         *string
+	continue_      *string
+	skipCount      *bool
+	includeDeleted *bool
 }
 
 // Page number of record list when record list exceeds specified page size
@@ -62,6 +65,24 @@ func (r ApiApiRhTrexAiV1DinosaursGetRequest) Fields(fields string) ApiApiRhTrexA
 	return r
 }
 
+// Opaque token returned as &#x60;continue&#x60; in the previous list response. The list resumes right after the last record of that page, and &#x60;page&#x60; is ignored. The &#x60;search&#x60; of the previous request must be repeated. &#x60;total&#x60; counts the whole list on every page.
+func (r ApiApiRhTrexAiV1DinosaursGetRequest) Continue_(continue_ string) ApiApiRhTrexAiV1DinosaursGetRequest {
+	r.continue_ = &continue_
+	return r
+}
+
+// Skip counting the total number of records, which is expensive on large collections. &#x60;total&#x60; is returned as 0.
+func (r ApiApiRhTrexAiV1DinosaursGetRequest) SkipCount(skipCount bool) ApiApiRhTrexAiV1DinosaursGetRequest {
+	r.skipCount = &skipCount
+	return r
+}
+
+// Include soft-deleted records, which carry &#x60;deleted_at&#x60;, in the list.
+func (r ApiApiRhTrexAiV1DinosaursGetRequest) IncludeDeleted(includeDeleted bool) ApiApiRhTrexAiV1DinosaursGetRequest {
+	r.includeDeleted = &includeDeleted
+	return r
+}
+
 func (r ApiApiRhTrexAiV1DinosaursGetRequest) Execute() (*DinosaurList, *http.Response, error) {
 	return r.ApiService.ApiRhTrexAiV1DinosaursGetExecute(r)
 }
@@ -122,6 +143,15 @@ func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursGetExecute(r ApiApiRhTrexAiV1D
 	if r.fields != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "fields", r.fields, "form", "")
 	}
+	if r.continue_ != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "continue", r.continue_, "form", "")
+	}
+	if r.skipCount != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "skipCount", r.skipCount, "form", "")
+	}
+	if r.includeDeleted != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "includeDeleted", r.includeDeleted, "form", "")
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -208,25 +238,88 @@ func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursGetExecute(r ApiApiRhTrexAiV1D
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type ApiApiRhTrexAiV1DinosaursIdGetRequest struct {
-	ctx        context.Context
-	ApiService *DefaultAPIService
-	id         string
+type ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest struct {
+	ctx            context.Context
+	ApiService     *DefaultAPIService
+	id             string
+	page           *int32
+	size           *int32
+	search         *string
+	orderBy        *string
+	fields         *string
+	continue_      *string
+	skipCount      *bool
+	includeDeleted *bool
+	preload        *string
 }
 
-func (r ApiApiRhTrexAiV1DinosaursIdGetRequest) Execute() (*Dinosaur, *http.Response, error) {
-	return r.ApiService.ApiRhTrexAiV1DinosaursIdGetExecute(r)
+// Page number of record list when record list exceeds specified page size
+func (r ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest) Page(page int32) ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest {
+	r.page = &page
+	return r
+}
+
+// Maximum number of records to return
+func (r ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest) Size(size int32) ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest {
+	r.size = &size
+	return r
+}
+
+// Specifies the search criteria. The syntax of this parameter is similar to the syntax of the _where_ clause of an SQL statement, using the names of the json attributes / column names of the account.  For example, in order to retrieve all the accounts with a username starting with &#x60;my&#x60;:  &#x60;&#x60;&#x60;sql username like &#39;my%&#39; &#x60;&#x60;&#x60;  The search criteria can also be applied on related resource. For example, in order to retrieve all the subscriptions labeled by &#x60;foo&#x3D;bar&#x60;,  &#x60;&#x60;&#x60;sql subscription_labels.key &#x3D; &#39;foo&#39; and subscription_labels.value &#x3D; &#39;bar&#39; &#x60;&#x60;&#x60;  If the parameter isn&#39;t provided, or if the value is empty, then all the accounts that the user has permission to see will be returned.
+func (r ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest) Search(search string) ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest {
+	r.search = &search
+	return r
+}
+
+// Specifies the order by criteria. The syntax of this parameter is similar to the syntax of the _order by_ clause of an SQL statement, but using the names of the json attributes / column of the account. For example, in order to retrieve all accounts ordered by username:  &#x60;&#x60;&#x60;sql username asc &#x60;&#x60;&#x60;  Or in order to retrieve all accounts ordered by username _and_ first name:  &#x60;&#x60;&#x60;sql username asc, firstName asc &#x60;&#x60;&#x60;  If the parameter isn&#39;t provided, or if the value is empty, then no explicit ordering will be applied.
+func (r ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest) OrderBy(orderBy string) ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest {
+	r.orderBy = &orderBy
+	return r
+}
+
+// Supplies a comma-separated list of fields to be returned. Fields of sub-structures and of arrays use &lt;structure&gt;.&lt;field&gt; notation. &lt;stucture&gt;.* means all field of a structure Example: For each Subscription to get id, href, plan(id and kind) and labels (all fields)  &#x60;&#x60;&#x60; curl &#39;/api/v1/subscriptions?fields&#x3D;id,href,plan.id,plan.kind,labels.*&amp;fetchLabels&#x3D;true&#39; &#x60;&#x60;&#x60;
+func (r ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest) Fields(fields string) ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest {
+	r.fields = &fields
+	return r
+}
+
+// Opaque token returned as &#x60;continue&#x60; in the previous list response. The list resumes right after the last record of that page, and &#x60;page&#x60; is ignored. The &#x60;search&#x60; of the previous request must be repeated. &#x60;total&#x60; counts the whole list on every page.
+func (r ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest) Continue_(continue_ string) ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest {
+	r.continue_ = &continue_
+	return r
+}
+
+// Skip counting the total number of records, which is expensive on large collections. &#x60;total&#x60; is returned as 0.
+func (r ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest) SkipCount(skipCount bool) ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest {
+	r.skipCount = &skipCount
+	return r
+}
+
+// Include soft-deleted records, which carry &#x60;deleted_at&#x60;, in the list.
+func (r ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest) IncludeDeleted(includeDeleted bool) ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest {
+	r.includeDeleted = &includeDeleted
+	return r
+}
+
+// Comma-separated list of the related resources to load along with the records, e.g. &#x60;dinosaur&#x60;
+func (r ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest) Preload(preload string) ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest {
+	r.preload = &preload
+	return r
+}
+
+func (r ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest) Execute() (*FossilList, *http.Response, error) {
+	return r.ApiService.ApiRhTrexAiV1DinosaursIdFossilsGetExecute(r)
 }
 
 /*
-ApiRhTrexAiV1DinosaursIdGet Get an dinosaur by id
+ApiRhTrexAiV1DinosaursIdFossilsGet Returns the fossils of a dinosaur
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
 	@param id The id of record
-	@return ApiApiRhTrexAiV1DinosaursIdGetRequest
+	@return ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest
 */
-func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursIdGet(ctx context.Context, id string) ApiApiRhTrexAiV1DinosaursIdGetRequest {
-	return ApiApiRhTrexAiV1DinosaursIdGetRequest{
+func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursIdFossilsGet(ctx context.Context, id string) ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest {
+	return ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest{
 		ApiService: a,
 		ctx:        ctx,
 		id:         id,
@@ -235,27 +328,60 @@ func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursIdGet(ctx context.Context, id
 
 // Execute executes the request
 //
-//	@return Dinosaur
-func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursIdGetExecute(r ApiApiRhTrexAiV1DinosaursIdGetRequest) (*Dinosaur, *http.Response, error) {
+//	@return FossilList
+func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursIdFossilsGetExecute(r ApiApiRhTrexAiV1DinosaursIdFossilsGetRequest) (*FossilList, *http.Response, error) {
 	var (
 		localVarHTTPMethod  = http.MethodGet
 		localVarPostBody    interface{}
 		formFiles           []formFile
-		localVarReturnValue *Dinosaur
+		localVarReturnValue *FossilList
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.ApiRhTrexAiV1DinosaursIdGet")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.ApiRhTrexAiV1DinosaursIdFossilsGet")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/api/rh-trex-ai/v1/dinosaurs/{id}"
+	localVarPath := localBasePath + "/api/rh-trex-ai/v1/dinosaurs/{id}/fossils"
 	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
 
+	if r.page != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "page", r.page, "form", "")
+	} else {
+		var defaultValue int32 = 1
+		r.page = &defaultValue
+	}
+	if r.size != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "size", r.size, "form", "")
+	} else {
+		var defaultValue int32 = 100
+		r.size = &defaultValue
+	}
+	if r.search != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "search", r.search, "form", "")
+	}
+	if r.orderBy != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "orderBy", r.orderBy, "form", "")
+	}
+	if r.fields != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "fields", r.fields, "form", "")
+	}
+	if r.continue_ != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "continue", r.continue_, "form", "")
+	}
+	if r.skipCount != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "skipCount", r.skipCount, "form", "")
+	}
+	if r.includeDeleted != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "includeDeleted", r.includeDeleted, "form", "")
+	}
+	if r.preload != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "preload", r.preload, "form", "")
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -353,32 +479,25 @@ func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursIdGetExecute(r ApiApiRhTrexAiV
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type ApiApiRhTrexAiV1DinosaursIdPatchRequest struct {
-	ctx                  context.Context
-	ApiService           *DefaultAPIService
-	id                   string
-	dinosaurPatchRequest *DinosaurPatchRequest
-}
-
-// Updated dinosaur data
-func (r ApiApiRhTrexAiV1DinosaursIdPatchRequest) DinosaurPatchRequest(dinosaurPatchRequest DinosaurPatchRequest) ApiApiRhTrexAiV1DinosaursIdPatchRequest {
-	r.dinosaurPatchRequest = &dinosaurPatchRequest
-	return r
+type ApiApiRhTrexAiV1DinosaursIdGetRequest struct {
+	ctx        context.Context
+	ApiService *DefaultAPIService
+	id         string
 }
 
-func (r ApiApiRhTrexAiV1DinosaursIdPatchRequest) Execute() (*Dinosaur, *http.Response, error) {
-	return r.ApiService.ApiRhTrexAiV1DinosaursIdPatchExecute(r)
+func (r ApiApiRhTrexAiV1DinosaursIdGetRequest) Execute() (*Dinosaur, *http.Response, error) {
+	return r.ApiService.ApiRhTrexAiV1DinosaursIdGetExecute(r)
 }
 
 /*
-ApiRhTrexAiV1DinosaursIdPatch Update an dinosaur
+ApiRhTrexAiV1DinosaursIdGet Get an dinosaur by id
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
 	@param id The id of record
-	@return ApiApiRhTrexAiV1DinosaursIdPatchRequest
+	@return ApiApiRhTrexAiV1DinosaursIdGetRequest
 */
-func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursIdPatch(ctx context.Context, id string) ApiApiRhTrexAiV1DinosaursIdPatchRequest {
-	return ApiApiRhTrexAiV1DinosaursIdPatchRequest{
+func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursIdGet(ctx context.Context, id string) ApiApiRhTrexAiV1DinosaursIdGetRequest {
+	return ApiApiRhTrexAiV1DinosaursIdGetRequest{
 		ApiService: a,
 		ctx:        ctx,
 		id:         id,
@@ -388,15 +507,15 @@ func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursIdPatch(ctx context.Context, i
 // Execute executes the request
 //
 //	@return Dinosaur
-func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursIdPatchExecute(r ApiApiRhTrexAiV1DinosaursIdPatchRequest) (*Dinosaur, *http.Response, error) {
+func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursIdGetExecute(r ApiApiRhTrexAiV1DinosaursIdGetRequest) (*Dinosaur, *http.Response, error) {
 	var (
-		localVarHTTPMethod  = http.MethodPatch
+		localVarHTTPMethod  = http.MethodGet
 		localVarPostBody    interface{}
 		formFiles           []formFile
 		localVarReturnValue *Dinosaur
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.ApiRhTrexAiV1DinosaursIdPatch")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.ApiRhTrexAiV1DinosaursIdGet")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
@@ -407,12 +526,9 @@ func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursIdPatchExecute(r ApiApiRhTrexA
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
-	if r.dinosaurPatchRequest == nil {
-		return localVarReturnValue, nil, reportError("dinosaurPatchRequest is required and must be specified")
-	}
 
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{"application/json"}
+	localVarHTTPContentTypes := []string{}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -428,8 +544,6 @@ func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursIdPatchExecute(r ApiApiRhTrexA
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
-	// body params
-	localVarPostBody = r.dinosaurPatchRequest
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
 		return localVarReturnValue, nil, err
@@ -452,17 +566,6 @@ func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursIdPatchExecute(r ApiApiRhTrexA
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		if localVarHTTPResponse.StatusCode == 400 {
-			var v Error
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
-			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
 		if localVarHTTPResponse.StatusCode == 401 {
 			var v Error
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -496,17 +599,6 @@ func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursIdPatchExecute(r ApiApiRhTrexA
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
-		if localVarHTTPResponse.StatusCode == 409 {
-			var v Error
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
-			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v Error
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -532,62 +624,66 @@ func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursIdPatchExecute(r ApiApiRhTrexA
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type ApiApiRhTrexAiV1DinosaursPostRequest struct {
-	ctx        context.Context
-	ApiService *DefaultAPIService
-	dinosaur   *Dinosaur
+type ApiApiRhTrexAiV1DinosaursIdPatchRequest struct {
+	ctx                  context.Context
+	ApiService           *DefaultAPIService
+	id                   string
+	dinosaurPatchRequest *DinosaurPatchRequest
 }
 
-// Dinosaur data
-func (r ApiApiRhTrexAiV1DinosaursPostRequest) Dinosaur(dinosaur Dinosaur) ApiApiRhTrexAiV1DinosaursPostRequest {
-	r.dinosaur = &dinosaur
+// Updated dinosaur data
+func (r ApiApiRhTrexAiV1DinosaursIdPatchRequest) DinosaurPatchRequest(dinosaurPatchRequest DinosaurPatchRequest) ApiApiRhTrexAiV1DinosaursIdPatchRequest {
+	r.dinosaurPatchRequest = &dinosaurPatchRequest
 	return r
 }
 
-func (r ApiApiRhTrexAiV1DinosaursPostRequest) Execute() (*Dinosaur, *http.Response, error) {
-	return r.ApiService.ApiRhTrexAiV1DinosaursPostExecute(r)
+func (r ApiApiRhTrexAiV1DinosaursIdPatchRequest) Execute() (*Dinosaur, *http.Response, error) {
+	return r.ApiService.ApiRhTrexAiV1DinosaursIdPatchExecute(r)
 }
 
 /*
-ApiRhTrexAiV1DinosaursPost Create a new dinosaur
+ApiRhTrexAiV1DinosaursIdPatch Update an dinosaur
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-	@return ApiApiRhTrexAiV1DinosaursPostRequest
+	@param id The id of record
+	@return ApiApiRhTrexAiV1DinosaursIdPatchRequest
 */
-func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursPost(ctx context.Context) ApiApiRhTrexAiV1DinosaursPostRequest {
-	return ApiApiRhTrexAiV1DinosaursPostRequest{
+func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursIdPatch(ctx context.Context, id string) ApiApiRhTrexAiV1DinosaursIdPatchRequest {
+	return ApiApiRhTrexAiV1DinosaursIdPatchRequest{
 		ApiService: a,
 		ctx:        ctx,
+		id:         id,
 	}
 }
 
 // Execute executes the request
 //
 //	@return Dinosaur
-func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursPostExecute(r ApiApiRhTrexAiV1DinosaursPostRequest) (*Dinosaur, *http.Response, error) {
+func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursIdPatchExecute(r ApiApiRhTrexAiV1DinosaursIdPatchRequest) (*Dinosaur, *http.Response, error) {
 	var (
-		localVarHTTPMethod  = http.MethodPost
+		localVarHTTPMethod  = http.MethodPatch
 		localVarPostBody    interface{}
 		formFiles           []formFile
 		localVarReturnValue *Dinosaur
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.ApiRhTrexAiV1DinosaursPost")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.ApiRhTrexAiV1DinosaursIdPatch")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/api/rh-trex-ai/v1/dinosaurs"
+	localVarPath := localBasePath + "/api/rh-trex-ai/v1/dinosaurs/{id}"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
-	if r.dinosaur == nil {
-		return localVarReturnValue, nil, reportError("dinosaur is required and must be specified")
+	if r.dinosaurPatchRequest == nil {
+		return localVarReturnValue, nil, reportError("dinosaurPatchRequest is required and must be specified")
 	}
 
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{"application/json"}
+	localVarHTTPContentTypes := []string{"application/json", "application/merge-patch+json", "application/json-patch+json"}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -604,7 +700,7 @@ func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursPostExecute(r ApiApiRhTrexAiV1
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
 	// body params
-	localVarPostBody = r.dinosaur
+	localVarPostBody = r.dinosaurPatchRequest
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
 		return localVarReturnValue, nil, err
@@ -660,6 +756,17 @@ func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursPostExecute(r ApiApiRhTrexAiV1
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 409 {
 			var v Error
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -696,80 +803,112 @@ func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursPostExecute(r ApiApiRhTrexAiV1
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type ApiApiRhTrexAiV1FossilsGetRequest struct {
-	ctx        context.Context
-	ApiService *DefaultAPIService
-	page       *int32
-	size       *int32
-	search     *string
-	orderBy    *string
-	fields     *string
+type ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest struct {
+	ctx            context.Context
+	ApiService     *DefaultAPIService
+	id             string
+	page           *int32
+	size           *int32
+	search         *string
+	orderBy        *string
+	fields         *string
+	continue_      *string
+	skipCount      *bool
+	includeDeleted *bool
+	preload        *string
 }
 
 // Page number of record list when record list exceeds specified page size
-func (r ApiApiRhTrexAiV1FossilsGetRequest) Page(page int32) ApiApiRhTrexAiV1FossilsGetRequest {
+func (r ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest) Page(page int32) ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest {
 	r.page = &page
 	return r
 }
 
 // Maximum number of records to return
-func (r ApiApiRhTrexAiV1FossilsGetRequest) Size(size int32) ApiApiRhTrexAiV1FossilsGetRequest {
+func (r ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest) Size(size int32) ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest {
 	r.size = &size
 	return r
 }
 
 // Specifies the search criteria. The syntax of this parameter is similar to the syntax of the _where_ clause of an SQL statement, using the names of the json attributes / column names of the account.  For example, in order to retrieve all the accounts with a username starting with &#x60;my&#x60;:  &#x60;&#x60;&#x60;sql username like &#39;my%&#39; &#x60;&#x60;&#x60;  The search criteria can also be applied on related resource. For example, in order to retrieve all the subscriptions labeled by &#x60;foo&#x3D;bar&#x60;,  &#x60;&#x60;&#x60;sql subscription_labels.key &#x3D; &#39;foo&#39; and subscription_labels.value &#x3D; &#39;bar&#39; &#x60;&#x60;&#x60;  If the parameter isn&#39;t provided, or if the value is empty, then all the accounts that the user has permission to see will be returned.
-func (r ApiApiRhTrexAiV1FossilsGetRequest) Search(search string) ApiApiRhTrexAiV1FossilsGetRequest {
+func (r ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest) Search(search string) ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest {
 	r.search = &search
 	return r
 }
 
 // Specifies the order by criteria. The syntax of this parameter is similar to the syntax of the _order by_ clause of an SQL statement, but using the names of the json attributes / column of the account. For example, in order to retrieve all accounts ordered by username:  &#x60;&#x60;&#x60;sql username asc &#x60;&#x60;&#x60;  Or in order to retrieve all accounts ordered by username _and_ first name:  &#x60;&#x60;&#x60;sql username asc, firstName asc &#x60;&#x60;&#x60;  If the parameter isn&#39;t provided, or if the value is empty, then no explicit ordering will be applied.
-func (r ApiApiRhTrexAiV1FossilsGetRequest) OrderBy(orderBy string) ApiApiRhTrexAiV1FossilsGetRequest {
+func (r ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest) OrderBy(orderBy string) ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest {
 	r.orderBy = &orderBy
 	return r
 }
 
 // Supplies a comma-separated list of fields to be returned. Fields of sub-structures and of arrays use &lt;structure&gt;.&lt;field&gt; notation. &lt;stucture&gt;.* means all field of a structure Example: For each Subscription to get id, href, plan(id and kind) and labels (all fields)  &#x60;&#x60;&#x60; curl &#39;/api/v1/subscriptions?fields&#x3D;id,href,plan.id,plan.kind,labels.*&amp;fetchLabels&#x3D;true&#39; &#x60;&#x60;&#x60;
-func (r ApiApiRhTrexAiV1FossilsGetRequest) Fields(fields string) ApiApiRhTrexAiV1FossilsGetRequest {
+func (r ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest) Fields(fields string) ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest {
 	r.fields = &fields
 	return r
 }
 
-func (r ApiApiRhTrexAiV1FossilsGetRequest) Execute() (*FossilList, *http.Response, error) {
-	return r.ApiService.ApiRhTrexAiV1FossilsGetExecute(r)
+// Opaque token returned as &#x60;continue&#x60; in the previous list response. The list resumes right after the last record of that page, and &#x60;page&#x60; is ignored. The &#x60;search&#x60; of the previous request must be repeated. &#x60;total&#x60; counts the whole list on every page.
+func (r ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest) Continue_(continue_ string) ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest {
+	r.continue_ = &continue_
+	return r
+}
+
+// Skip counting the total number of records, which is expensive on large collections. &#x60;total&#x60; is returned as 0.
+func (r ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest) SkipCount(skipCount bool) ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest {
+	r.skipCount = &skipCount
+	return r
+}
+
+// Include soft-deleted records, which carry &#x60;deleted_at&#x60;, in the list.
+func (r ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest) IncludeDeleted(includeDeleted bool) ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest {
+	r.includeDeleted = &includeDeleted
+	return r
+}
+
+// Comma-separated list of the related resources to load along with the records, e.g. &#x60;dinosaurs&#x60;
+func (r ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest) Preload(preload string) ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest {
+	r.preload = &preload
+	return r
+}
+
+func (r ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest) Execute() (*ScientistList, *http.Response, error) {
+	return r.ApiService.ApiRhTrexAiV1DinosaursIdScientistsGetExecute(r)
 }
 
 /*
-ApiRhTrexAiV1FossilsGet Returns a list of fossils
+ApiRhTrexAiV1DinosaursIdScientistsGet Returns the scientists linked to a dinosaur
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-	@return ApiApiRhTrexAiV1FossilsGetRequest
+	@param id The id of record
+	@return ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest
 */
-func (a *DefaultAPIService) ApiRhTrexAiV1FossilsGet(ctx context.Context) ApiApiRhTrexAiV1FossilsGetRequest {
-	return ApiApiRhTrexAiV1FossilsGetRequest{
+func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursIdScientistsGet(ctx context.Context, id string) ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest {
+	return ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest{
 		ApiService: a,
 		ctx:        ctx,
+		id:         id,
 	}
 }
 
 // Execute executes the request
 //
-//	@return FossilList
-func (a *DefaultAPIService) ApiRhTrexAiV1FossilsGetExecute(r ApiApiRhTrexAiV1FossilsGetRequest) (*FossilList, *http.Response, error) {
+//	@return ScientistList
+func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursIdScientistsGetExecute(r ApiApiRhTrexAiV1DinosaursIdScientistsGetRequest) (*ScientistList, *http.Response, error) {
 	var (
 		localVarHTTPMethod  = http.MethodGet
 		localVarPostBody    interface{}
 		formFiles           []formFile
-		localVarReturnValue *FossilList
+		localVarReturnValue *ScientistList
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.ApiRhTrexAiV1FossilsGet")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.ApiRhTrexAiV1DinosaursIdScientistsGet")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/api/rh-trex-ai/v1/fossils"
+	localVarPath := localBasePath + "/api/rh-trex-ai/v1/dinosaurs/{id}/scientists"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
@@ -796,6 +935,18 @@ func (a *DefaultAPIService) ApiRhTrexAiV1FossilsGetExecute(r ApiApiRhTrexAiV1Fos
 	if r.fields != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "fields", r.fields, "form", "")
 	}
+	if r.continue_ != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "continue", r.continue_, "form", "")
+	}
+	if r.skipCount != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "skipCount", r.skipCount, "form", "")
+	}
+	if r.includeDeleted != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "includeDeleted", r.includeDeleted, "form", "")
+	}
+	if r.preload != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "preload", r.preload, "form", "")
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -857,7 +1008,7 @@ func (a *DefaultAPIService) ApiRhTrexAiV1FossilsGetExecute(r ApiApiRhTrexAiV1Fos
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
-		if localVarHTTPResponse.StatusCode == 500 {
+		if localVarHTTPResponse.StatusCode == 404 {
 			var v Error
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
@@ -866,9 +1017,20 @@ func (a *DefaultAPIService) ApiRhTrexAiV1FossilsGetExecute(r ApiApiRhTrexAiV1Fos
 			}
 			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
 			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
-	}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
 
 	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 	if err != nil {
@@ -882,49 +1044,1154 @@ func (a *DefaultAPIService) ApiRhTrexAiV1FossilsGetExecute(r ApiApiRhTrexAiV1Fos
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type ApiApiRhTrexAiV1FossilsIdGetRequest struct {
+type ApiApiRhTrexAiV1DinosaursPostRequest struct {
 	ctx        context.Context
 	ApiService *DefaultAPIService
-	id         string
+	dinosaur   *Dinosaur
 }
 
-func (r ApiApiRhTrexAiV1FossilsIdGetRequest) Execute() (*Fossil, *http.Response, error) {
-	return r.ApiService.ApiRhTrexAiV1FossilsIdGetExecute(r)
+// Dinosaur data
+func (r ApiApiRhTrexAiV1DinosaursPostRequest) Dinosaur(dinosaur Dinosaur) ApiApiRhTrexAiV1DinosaursPostRequest {
+	r.dinosaur = &dinosaur
+	return r
+}
+
+func (r ApiApiRhTrexAiV1DinosaursPostRequest) Execute() (*Dinosaur, *http.Response, error) {
+	return r.ApiService.ApiRhTrexAiV1DinosaursPostExecute(r)
 }
 
 /*
-ApiRhTrexAiV1FossilsIdGet Get an fossil by id
+ApiRhTrexAiV1DinosaursPost Create a new dinosaur
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-	@param id The id of record
-	@return ApiApiRhTrexAiV1FossilsIdGetRequest
+	@return ApiApiRhTrexAiV1DinosaursPostRequest
 */
-func (a *DefaultAPIService) ApiRhTrexAiV1FossilsIdGet(ctx context.Context, id string) ApiApiRhTrexAiV1FossilsIdGetRequest {
-	return ApiApiRhTrexAiV1FossilsIdGetRequest{
+func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursPost(ctx context.Context) ApiApiRhTrexAiV1DinosaursPostRequest {
+	return ApiApiRhTrexAiV1DinosaursPostRequest{
 		ApiService: a,
 		ctx:        ctx,
-		id:         id,
 	}
 }
 
 // Execute executes the request
 //
-//	@return Fossil
-func (a *DefaultAPIService) ApiRhTrexAiV1FossilsIdGetExecute(r ApiApiRhTrexAiV1FossilsIdGetRequest) (*Fossil, *http.Response, error) {
+//	@return Dinosaur
+func (a *DefaultAPIService) ApiRhTrexAiV1DinosaursPostExecute(r ApiApiRhTrexAiV1DinosaursPostRequest) (*Dinosaur, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue *Dinosaur
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.ApiRhTrexAiV1DinosaursPost")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/api/rh-trex-ai/v1/dinosaurs"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.dinosaur == nil {
+		return localVarReturnValue, nil, reportError("dinosaur is required and must be specified")
+	}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/json"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.dinosaur
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 403 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 409 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiApiRhTrexAiV1FossilsGetRequest struct {
+	ctx            context.Context
+	ApiService     *DefaultAPIService
+	page           *int32
+	size           *int32
+	search         *string
+	orderBy        *string
+	fields         *string
+	continue_      *string
+	skipCount      *bool
+	includeDeleted *bool
+	preload        *string
+}
+
+// Page number of record list when record list exceeds specified page size
+func (r ApiApiRhTrexAiV1FossilsGetRequest) Page(page int32) ApiApiRhTrexAiV1FossilsGetRequest {
+	r.page = &page
+	return r
+}
+
+// Maximum number of records to return
+func (r ApiApiRhTrexAiV1FossilsGetRequest) Size(size int32) ApiApiRhTrexAiV1FossilsGetRequest {
+	r.size = &size
+	return r
+}
+
+// Specifies the search criteria. The syntax of this parameter is similar to the syntax of the _where_ clause of an SQL statement, using the names of the json attributes / column names of the account.  For example, in order to retrieve all the accounts with a username starting with &#x60;my&#x60;:  &#x60;&#x60;&#x60;sql username like &#39;my%&#39; &#x60;&#x60;&#x60;  The search criteria can also be applied on related resource. For example, in order to retrieve all the subscriptions labeled by &#x60;foo&#x3D;bar&#x60;,  &#x60;&#x60;&#x60;sql subscription_labels.key &#x3D; &#39;foo&#39; and subscription_labels.value &#x3D; &#39;bar&#39; &#x60;&#x60;&#x60;  If the parameter isn&#39;t provided, or if the value is empty, then all the accounts that the user has permission to see will be returned.
+func (r ApiApiRhTrexAiV1FossilsGetRequest) Search(search string) ApiApiRhTrexAiV1FossilsGetRequest {
+	r.search = &search
+	return r
+}
+
+// Specifies the order by criteria. The syntax of this parameter is similar to the syntax of the _order by_ clause of an SQL statement, but using the names of the json attributes / column of the account. For example, in order to retrieve all accounts ordered by username:  &#x60;&#x60;&#x60;sql username asc &#x60;&#x60;&#x60;  Or in order to retrieve all accounts ordered by username _and_ first name:  &#x60;&#x60;&#x60;sql username asc, firstName asc &#x60;&#x60;&#x60;  If the parameter isn&#39;t provided, or if the value is empty, then no explicit ordering will be applied.
+func (r ApiApiRhTrexAiV1FossilsGetRequest) OrderBy(orderBy string) ApiApiRhTrexAiV1FossilsGetRequest {
+	r.orderBy = &orderBy
+	return r
+}
+
+// Supplies a comma-separated list of fields to be returned. Fields of sub-structures and of arrays use &lt;structure&gt;.&lt;field&gt; notation. &lt;stucture&gt;.* means all field of a structure Example: For each Subscription to get id, href, plan(id and kind) and labels (all fields)  &#x60;&#x60;&#x60; curl &#39;/api/v1/subscriptions?fields&#x3D;id,href,plan.id,plan.kind,labels.*&amp;fetchLabels&#x3D;true&#39; &#x60;&#x60;&#x60;
+func (r ApiApiRhTrexAiV1FossilsGetRequest) Fields(fields string) ApiApiRhTrexAiV1FossilsGetRequest {
+	r.fields = &fields
+	return r
+}
+
+// Opaque token returned as &#x60;continue&#x60; in the previous list response. The list resumes right after the last record of that page, and &#x60;page&#x60; is ignored. The &#x60;search&#x60; of the previous request must be repeated. &#x60;total&#x60; counts the whole list on every page.
+func (r ApiApiRhTrexAiV1FossilsGetRequest) Continue_(continue_ string) ApiApiRhTrexAiV1FossilsGetRequest {
+	r.continue_ = &continue_
+	return r
+}
+
+// Skip counting the total number of records, which is expensive on large collections. &#x60;total&#x60; is returned as 0.
+func (r ApiApiRhTrexAiV1FossilsGetRequest) SkipCount(skipCount bool) ApiApiRhTrexAiV1FossilsGetRequest {
+	r.skipCount = &skipCount
+	return r
+}
+
+// Include soft-deleted records, which carry &#x60;deleted_at&#x60;, in the list.
+func (r ApiApiRhTrexAiV1FossilsGetRequest) IncludeDeleted(includeDeleted bool) ApiApiRhTrexAiV1FossilsGetRequest {
+	r.includeDeleted = &includeDeleted
+	return r
+}
+
+// Comma-separated list of the related resources to load along with the records, e.g. &#x60;dinosaur&#x60;
+func (r ApiApiRhTrexAiV1FossilsGetRequest) Preload(preload string) ApiApiRhTrexAiV1FossilsGetRequest {
+	r.preload = &preload
+	return r
+}
+
+func (r ApiApiRhTrexAiV1FossilsGetRequest) Execute() (*FossilList, *http.Response, error) {
+	return r.ApiService.ApiRhTrexAiV1FossilsGetExecute(r)
+}
+
+/*
+ApiRhTrexAiV1FossilsGet Returns a list of fossils
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@return ApiApiRhTrexAiV1FossilsGetRequest
+*/
+func (a *DefaultAPIService) ApiRhTrexAiV1FossilsGet(ctx context.Context) ApiApiRhTrexAiV1FossilsGetRequest {
+	return ApiApiRhTrexAiV1FossilsGetRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+// Execute executes the request
+//
+//	@return FossilList
+func (a *DefaultAPIService) ApiRhTrexAiV1FossilsGetExecute(r ApiApiRhTrexAiV1FossilsGetRequest) (*FossilList, *http.Response, error) {
 	var (
 		localVarHTTPMethod  = http.MethodGet
 		localVarPostBody    interface{}
 		formFiles           []formFile
-		localVarReturnValue *Fossil
+		localVarReturnValue *FossilList
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.ApiRhTrexAiV1FossilsGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/api/rh-trex-ai/v1/fossils"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.page != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "page", r.page, "form", "")
+	} else {
+		var defaultValue int32 = 1
+		r.page = &defaultValue
+	}
+	if r.size != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "size", r.size, "form", "")
+	} else {
+		var defaultValue int32 = 100
+		r.size = &defaultValue
+	}
+	if r.search != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "search", r.search, "form", "")
+	}
+	if r.orderBy != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "orderBy", r.orderBy, "form", "")
+	}
+	if r.fields != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "fields", r.fields, "form", "")
+	}
+	if r.continue_ != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "continue", r.continue_, "form", "")
+	}
+	if r.skipCount != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "skipCount", r.skipCount, "form", "")
+	}
+	if r.includeDeleted != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "includeDeleted", r.includeDeleted, "form", "")
+	}
+	if r.preload != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "preload", r.preload, "form", "")
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 403 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiApiRhTrexAiV1FossilsIdGetRequest struct {
+	ctx        context.Context
+	ApiService *DefaultAPIService
+	id         string
+}
+
+func (r ApiApiRhTrexAiV1FossilsIdGetRequest) Execute() (*Fossil, *http.Response, error) {
+	return r.ApiService.ApiRhTrexAiV1FossilsIdGetExecute(r)
+}
+
+/*
+ApiRhTrexAiV1FossilsIdGet Get an fossil by id
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@param id The id of record
+	@return ApiApiRhTrexAiV1FossilsIdGetRequest
+*/
+func (a *DefaultAPIService) ApiRhTrexAiV1FossilsIdGet(ctx context.Context, id string) ApiApiRhTrexAiV1FossilsIdGetRequest {
+	return ApiApiRhTrexAiV1FossilsIdGetRequest{
+		ApiService: a,
+		ctx:        ctx,
+		id:         id,
+	}
+}
+
+// Execute executes the request
+//
+//	@return Fossil
+func (a *DefaultAPIService) ApiRhTrexAiV1FossilsIdGetExecute(r ApiApiRhTrexAiV1FossilsIdGetRequest) (*Fossil, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue *Fossil
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.ApiRhTrexAiV1FossilsIdGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/api/rh-trex-ai/v1/fossils/{id}"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 403 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiApiRhTrexAiV1FossilsIdPatchRequest struct {
+	ctx                context.Context
+	ApiService         *DefaultAPIService
+	id                 string
+	fossilPatchRequest *FossilPatchRequest
+}
+
+// Updated fossil data
+func (r ApiApiRhTrexAiV1FossilsIdPatchRequest) FossilPatchRequest(fossilPatchRequest FossilPatchRequest) ApiApiRhTrexAiV1FossilsIdPatchRequest {
+	r.fossilPatchRequest = &fossilPatchRequest
+	return r
+}
+
+func (r ApiApiRhTrexAiV1FossilsIdPatchRequest) Execute() (*Fossil, *http.Response, error) {
+	return r.ApiService.ApiRhTrexAiV1FossilsIdPatchExecute(r)
+}
+
+/*
+ApiRhTrexAiV1FossilsIdPatch Update an fossil
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@param id The id of record
+	@return ApiApiRhTrexAiV1FossilsIdPatchRequest
+*/
+func (a *DefaultAPIService) ApiRhTrexAiV1FossilsIdPatch(ctx context.Context, id string) ApiApiRhTrexAiV1FossilsIdPatchRequest {
+	return ApiApiRhTrexAiV1FossilsIdPatchRequest{
+		ApiService: a,
+		ctx:        ctx,
+		id:         id,
+	}
+}
+
+// Execute executes the request
+//
+//	@return Fossil
+func (a *DefaultAPIService) ApiRhTrexAiV1FossilsIdPatchExecute(r ApiApiRhTrexAiV1FossilsIdPatchRequest) (*Fossil, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPatch
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue *Fossil
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.ApiRhTrexAiV1FossilsIdPatch")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/api/rh-trex-ai/v1/fossils/{id}"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.fossilPatchRequest == nil {
+		return localVarReturnValue, nil, reportError("fossilPatchRequest is required and must be specified")
+	}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/json", "application/merge-patch+json", "application/json-patch+json"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.fossilPatchRequest
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 403 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 409 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiApiRhTrexAiV1FossilsPostRequest struct {
+	ctx        context.Context
+	ApiService *DefaultAPIService
+	fossil     *Fossil
+}
+
+// Fossil data
+func (r ApiApiRhTrexAiV1FossilsPostRequest) Fossil(fossil Fossil) ApiApiRhTrexAiV1FossilsPostRequest {
+	r.fossil = &fossil
+	return r
+}
+
+func (r ApiApiRhTrexAiV1FossilsPostRequest) Execute() (*Fossil, *http.Response, error) {
+	return r.ApiService.ApiRhTrexAiV1FossilsPostExecute(r)
+}
+
+/*
+ApiRhTrexAiV1FossilsPost Create a new fossil
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@return ApiApiRhTrexAiV1FossilsPostRequest
+*/
+func (a *DefaultAPIService) ApiRhTrexAiV1FossilsPost(ctx context.Context) ApiApiRhTrexAiV1FossilsPostRequest {
+	return ApiApiRhTrexAiV1FossilsPostRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+// Execute executes the request
+//
+//	@return Fossil
+func (a *DefaultAPIService) ApiRhTrexAiV1FossilsPostExecute(r ApiApiRhTrexAiV1FossilsPostRequest) (*Fossil, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodPost
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue *Fossil
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.ApiRhTrexAiV1FossilsPost")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/api/rh-trex-ai/v1/fossils"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.fossil == nil {
+		return localVarReturnValue, nil, reportError("fossil is required and must be specified")
+	}
+
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{"application/json"}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	// body params
+	localVarPostBody = r.fossil
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 400 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 403 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 409 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiApiRhTrexAiV1ScientistsGetRequest struct {
+	ctx            context.Context
+	ApiService     *DefaultAPIService
+	page           *int32
+	size           *int32
+	search         *string
+	orderBy        *string
+	fields         *string
+	continue_      *string
+	skipCount      *bool
+	includeDeleted *bool
+	preload        *string
+}
+
+// Page number of record list when record list exceeds specified page size
+func (r ApiApiRhTrexAiV1ScientistsGetRequest) Page(page int32) ApiApiRhTrexAiV1ScientistsGetRequest {
+	r.page = &page
+	return r
+}
+
+// Maximum number of records to return
+func (r ApiApiRhTrexAiV1ScientistsGetRequest) Size(size int32) ApiApiRhTrexAiV1ScientistsGetRequest {
+	r.size = &size
+	return r
+}
+
+// Specifies the search criteria. The syntax of this parameter is similar to the syntax of the _where_ clause of an SQL statement, using the names of the json attributes / column names of the account.  For example, in order to retrieve all the accounts with a username starting with &#x60;my&#x60;:  &#x60;&#x60;&#x60;sql username like &#39;my%&#39; &#x60;&#x60;&#x60;  The search criteria can also be applied on related resource. For example, in order to retrieve all the subscriptions labeled by &#x60;foo&#x3D;bar&#x60;,  &#x60;&#x60;&#x60;sql subscription_labels.key &#x3D; &#39;foo&#39; and subscription_labels.value &#x3D; &#39;bar&#39; &#x60;&#x60;&#x60;  If the parameter isn&#39;t provided, or if the value is empty, then all the accounts that the user has permission to see will be returned.
+func (r ApiApiRhTrexAiV1ScientistsGetRequest) Search(search string) ApiApiRhTrexAiV1ScientistsGetRequest {
+	r.search = &search
+	return r
+}
+
+// Specifies the order by criteria. The syntax of this parameter is similar to the syntax of the _order by_ clause of an SQL statement, but using the names of the json attributes / column of the account. For example, in order to retrieve all accounts ordered by username:  &#x60;&#x60;&#x60;sql username asc &#x60;&#x60;&#x60;  Or in order to retrieve all accounts ordered by username _and_ first name:  &#x60;&#x60;&#x60;sql username asc, firstName asc &#x60;&#x60;&#x60;  If the parameter isn&#39;t provided, or if the value is empty, then no explicit ordering will be applied.
+func (r ApiApiRhTrexAiV1ScientistsGetRequest) OrderBy(orderBy string) ApiApiRhTrexAiV1ScientistsGetRequest {
+	r.orderBy = &orderBy
+	return r
+}
+
+// Supplies a comma-separated list of fields to be returned. Fields of sub-structures and of arrays use &lt;structure&gt;.&lt;field&gt; notation. &lt;stucture&gt;.* means all field of a structure Example: For each Subscription to get id, href, plan(id and kind) and labels (all fields)  &#x60;&#x60;&#x60; curl &#39;/api/v1/subscriptions?fields&#x3D;id,href,plan.id,plan.kind,labels.*&amp;fetchLabels&#x3D;true&#39; &#x60;&#x60;&#x60;
+func (r ApiApiRhTrexAiV1ScientistsGetRequest) Fields(fields string) ApiApiRhTrexAiV1ScientistsGetRequest {
+	r.fields = &fields
+	return r
+}
+
+// Opaque token returned as &#x60;continue&#x60; in the previous list response. The list resumes right after the last record of that page, and &#x60;page&#x60; is ignored. The &#x60;search&#x60; of the previous request must be repeated. &#x60;total&#x60; counts the whole list on every page.
+func (r ApiApiRhTrexAiV1ScientistsGetRequest) Continue_(continue_ string) ApiApiRhTrexAiV1ScientistsGetRequest {
+	r.continue_ = &continue_
+	return r
+}
+
+// Skip counting the total number of records, which is expensive on large collections. &#x60;total&#x60; is returned as 0.
+func (r ApiApiRhTrexAiV1ScientistsGetRequest) SkipCount(skipCount bool) ApiApiRhTrexAiV1ScientistsGetRequest {
+	r.skipCount = &skipCount
+	return r
+}
+
+// Include soft-deleted records, which carry &#x60;deleted_at&#x60;, in the list.
+func (r ApiApiRhTrexAiV1ScientistsGetRequest) IncludeDeleted(includeDeleted bool) ApiApiRhTrexAiV1ScientistsGetRequest {
+	r.includeDeleted = &includeDeleted
+	return r
+}
+
+// Comma-separated list of the related resources to load along with the records, e.g. &#x60;dinosaurs&#x60;
+func (r ApiApiRhTrexAiV1ScientistsGetRequest) Preload(preload string) ApiApiRhTrexAiV1ScientistsGetRequest {
+	r.preload = &preload
+	return r
+}
+
+func (r ApiApiRhTrexAiV1ScientistsGetRequest) Execute() (*ScientistList, *http.Response, error) {
+	return r.ApiService.ApiRhTrexAiV1ScientistsGetExecute(r)
+}
+
+/*
+ApiRhTrexAiV1ScientistsGet Returns a list of scientists
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@return ApiApiRhTrexAiV1ScientistsGetRequest
+*/
+func (a *DefaultAPIService) ApiRhTrexAiV1ScientistsGet(ctx context.Context) ApiApiRhTrexAiV1ScientistsGetRequest {
+	return ApiApiRhTrexAiV1ScientistsGetRequest{
+		ApiService: a,
+		ctx:        ctx,
+	}
+}
+
+// Execute executes the request
+//
+//	@return ScientistList
+func (a *DefaultAPIService) ApiRhTrexAiV1ScientistsGetExecute(r ApiApiRhTrexAiV1ScientistsGetRequest) (*ScientistList, *http.Response, error) {
+	var (
+		localVarHTTPMethod  = http.MethodGet
+		localVarPostBody    interface{}
+		formFiles           []formFile
+		localVarReturnValue *ScientistList
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.ApiRhTrexAiV1ScientistsGet")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/api/rh-trex-ai/v1/scientists"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.page != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "page", r.page, "form", "")
+	} else {
+		var defaultValue int32 = 1
+		r.page = &defaultValue
+	}
+	if r.size != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "size", r.size, "form", "")
+	} else {
+		var defaultValue int32 = 100
+		r.size = &defaultValue
+	}
+	if r.search != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "search", r.search, "form", "")
+	}
+	if r.orderBy != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "orderBy", r.orderBy, "form", "")
+	}
+	if r.fields != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "fields", r.fields, "form", "")
+	}
+	if r.continue_ != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "continue", r.continue_, "form", "")
+	}
+	if r.skipCount != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "skipCount", r.skipCount, "form", "")
+	}
+	if r.includeDeleted != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "includeDeleted", r.includeDeleted, "form", "")
+	}
+	if r.preload != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "preload", r.preload, "form", "")
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 403 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
+		if localVarHTTPResponse.StatusCode == 500 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
+type ApiApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdDeleteRequest struct {
+	ctx        context.Context
+	ApiService *DefaultAPIService
+	id         string
+	dinosaurId string
+}
+
+func (r ApiApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdDeleteRequest) Execute() (*http.Response, error) {
+	return r.ApiService.ApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdDeleteExecute(r)
+}
+
+/*
+ApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdDelete Unlink a dinosaur from a scientist
+
+	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+	@param id The id of record
+	@param dinosaurId The id of the linked dinosaur
+	@return ApiApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdDeleteRequest
+*/
+func (a *DefaultAPIService) ApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdDelete(ctx context.Context, id string, dinosaurId string) ApiApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdDeleteRequest {
+	return ApiApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdDeleteRequest{
+		ApiService: a,
+		ctx:        ctx,
+		id:         id,
+		dinosaurId: dinosaurId,
+	}
+}
+
+// Execute executes the request
+func (a *DefaultAPIService) ApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdDeleteExecute(r ApiApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdDeleteRequest) (*http.Response, error) {
+	var (
+		localVarHTTPMethod = http.MethodDelete
+		localVarPostBody   interface{}
+		formFiles          []formFile
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.ApiRhTrexAiV1FossilsIdGet")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.ApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdDelete")
 	if err != nil {
-		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+		return nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/api/rh-trex-ai/v1/fossils/{id}"
+	localVarPath := localBasePath + "/api/rh-trex-ai/v1/scientists/{id}/dinosaurs/{dinosaur_id}"
 	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"dinosaur_id"+"}", url.PathEscape(parameterValueToString(r.dinosaurId, "dinosaurId")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
@@ -949,19 +2216,19 @@ func (a *DefaultAPIService) ApiRhTrexAiV1FossilsIdGetExecute(r ApiApiRhTrexAiV1F
 	}
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
-		return localVarReturnValue, nil, err
+		return nil, err
 	}
 
 	localVarHTTPResponse, err := a.client.callAPI(req)
 	if err != nil || localVarHTTPResponse == nil {
-		return localVarReturnValue, localVarHTTPResponse, err
+		return localVarHTTPResponse, err
 	}
 
 	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
 	localVarHTTPResponse.Body.Close()
 	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
 	if err != nil {
-		return localVarReturnValue, localVarHTTPResponse, err
+		return localVarHTTPResponse, err
 	}
 
 	if localVarHTTPResponse.StatusCode >= 300 {
@@ -974,119 +2241,101 @@ func (a *DefaultAPIService) ApiRhTrexAiV1FossilsIdGetExecute(r ApiApiRhTrexAiV1F
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
+				return localVarHTTPResponse, newErr
 			}
 			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
 			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
+			return localVarHTTPResponse, newErr
 		}
 		if localVarHTTPResponse.StatusCode == 403 {
 			var v Error
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
+				return localVarHTTPResponse, newErr
 			}
 			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
 			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
+			return localVarHTTPResponse, newErr
 		}
 		if localVarHTTPResponse.StatusCode == 404 {
 			var v Error
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
+				return localVarHTTPResponse, newErr
 			}
 			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
 			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
+			return localVarHTTPResponse, newErr
 		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v Error
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
+				return localVarHTTPResponse, newErr
 			}
 			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
 			newErr.model = v
 		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
-	}
-
-	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-	if err != nil {
-		newErr := &GenericOpenAPIError{
-			body:  localVarBody,
-			error: err.Error(),
-		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
+		return localVarHTTPResponse, newErr
 	}
 
-	return localVarReturnValue, localVarHTTPResponse, nil
-}
-
-type ApiApiRhTrexAiV1FossilsIdPatchRequest struct {
-	ctx                context.Context
-	ApiService         *DefaultAPIService
-	id                 string
-	fossilPatchRequest *FossilPatchRequest
+	return localVarHTTPResponse, nil
 }
 
-// Updated fossil data
-func (r ApiApiRhTrexAiV1FossilsIdPatchRequest) FossilPatchRequest(fossilPatchRequest FossilPatchRequest) ApiApiRhTrexAiV1FossilsIdPatchRequest {
-	r.fossilPatchRequest = &fossilPatchRequest
-	return r
+type ApiApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdPutRequest struct {
+	ctx        context.Context
+	ApiService *DefaultAPIService
+	id         string
+	dinosaurId string
 }
 
-func (r ApiApiRhTrexAiV1FossilsIdPatchRequest) Execute() (*Fossil, *http.Response, error) {
-	return r.ApiService.ApiRhTrexAiV1FossilsIdPatchExecute(r)
+func (r ApiApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdPutRequest) Execute() (*http.Response, error) {
+	return r.ApiService.ApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdPutExecute(r)
 }
 
 /*
-ApiRhTrexAiV1FossilsIdPatch Update an fossil
+ApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdPut Link a dinosaur to a scientist
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
 	@param id The id of record
-	@return ApiApiRhTrexAiV1FossilsIdPatchRequest
+	@param dinosaurId The id of the linked dinosaur
+	@return ApiApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdPutRequest
 */
-func (a *DefaultAPIService) ApiRhTrexAiV1FossilsIdPatch(ctx context.Context, id string) ApiApiRhTrexAiV1FossilsIdPatchRequest {
-	return ApiApiRhTrexAiV1FossilsIdPatchRequest{
+func (a *DefaultAPIService) ApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdPut(ctx context.Context, id string, dinosaurId string) ApiApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdPutRequest {
+	return ApiApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdPutRequest{
 		ApiService: a,
 		ctx:        ctx,
 		id:         id,
+		dinosaurId: dinosaurId,
 	}
 }
 
 // Execute executes the request
-//
-//	@return Fossil
-func (a *DefaultAPIService) ApiRhTrexAiV1FossilsIdPatchExecute(r ApiApiRhTrexAiV1FossilsIdPatchRequest) (*Fossil, *http.Response, error) {
+func (a *DefaultAPIService) ApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdPutExecute(r ApiApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdPutRequest) (*http.Response, error) {
 	var (
-		localVarHTTPMethod  = http.MethodPatch
-		localVarPostBody    interface{}
-		formFiles           []formFile
-		localVarReturnValue *Fossil
+		localVarHTTPMethod = http.MethodPut
+		localVarPostBody   interface{}
+		formFiles          []formFile
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.ApiRhTrexAiV1FossilsIdPatch")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.ApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdPut")
 	if err != nil {
-		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+		return nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/api/rh-trex-ai/v1/fossils/{id}"
+	localVarPath := localBasePath + "/api/rh-trex-ai/v1/scientists/{id}/dinosaurs/{dinosaur_id}"
 	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
+	localVarPath = strings.Replace(localVarPath, "{"+"dinosaur_id"+"}", url.PathEscape(parameterValueToString(r.dinosaurId, "dinosaurId")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
-	if r.fossilPatchRequest == nil {
-		return localVarReturnValue, nil, reportError("fossilPatchRequest is required and must be specified")
-	}
 
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{"application/json"}
+	localVarHTTPContentTypes := []string{}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -1102,23 +2351,21 @@ func (a *DefaultAPIService) ApiRhTrexAiV1FossilsIdPatchExecute(r ApiApiRhTrexAiV
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
-	// body params
-	localVarPostBody = r.fossilPatchRequest
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
-		return localVarReturnValue, nil, err
+		return nil, err
 	}
 
 	localVarHTTPResponse, err := a.client.callAPI(req)
 	if err != nil || localVarHTTPResponse == nil {
-		return localVarReturnValue, localVarHTTPResponse, err
+		return localVarHTTPResponse, err
 	}
 
 	localVarBody, err := io.ReadAll(localVarHTTPResponse.Body)
 	localVarHTTPResponse.Body.Close()
 	localVarHTTPResponse.Body = io.NopCloser(bytes.NewBuffer(localVarBody))
 	if err != nil {
-		return localVarReturnValue, localVarHTTPResponse, err
+		return localVarHTTPResponse, err
 	}
 
 	if localVarHTTPResponse.StatusCode >= 300 {
@@ -1126,142 +2373,191 @@ func (a *DefaultAPIService) ApiRhTrexAiV1FossilsIdPatchExecute(r ApiApiRhTrexAiV
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		if localVarHTTPResponse.StatusCode == 400 {
-			var v Error
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
-			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
 		if localVarHTTPResponse.StatusCode == 401 {
 			var v Error
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
+				return localVarHTTPResponse, newErr
 			}
 			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
 			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
+			return localVarHTTPResponse, newErr
 		}
 		if localVarHTTPResponse.StatusCode == 403 {
 			var v Error
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
+				return localVarHTTPResponse, newErr
 			}
 			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
 			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
+			return localVarHTTPResponse, newErr
 		}
 		if localVarHTTPResponse.StatusCode == 404 {
 			var v Error
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
-			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
-		if localVarHTTPResponse.StatusCode == 409 {
-			var v Error
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
+				return localVarHTTPResponse, newErr
 			}
 			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
 			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
+			return localVarHTTPResponse, newErr
 		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v Error
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
 				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
+				return localVarHTTPResponse, newErr
 			}
 			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
 			newErr.model = v
 		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
+		return localVarHTTPResponse, newErr
 	}
 
-	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-	if err != nil {
-		newErr := &GenericOpenAPIError{
-			body:  localVarBody,
-			error: err.Error(),
-		}
-		return localVarReturnValue, localVarHTTPResponse, newErr
-	}
+	return localVarHTTPResponse, nil
+}
 
-	return localVarReturnValue, localVarHTTPResponse, nil
+type ApiApiRhTrexAiV1ScientistsIdDinosaursGetRequest struct {
+	ctx            context.Context
+	ApiService     *DefaultAPIService
+	id             string
+	page           *int32
+	size           *int32
+	search         *string
+	orderBy        *string
+	fields         *string
+	continue_      *string
+	skipCount      *bool
+	includeDeleted *bool
 }
 
-type ApiApiRhTrexAiV1FossilsPostRequest struct {
-	ctx        context.Context
-	ApiService *DefaultAPIService
-	fossil     *Fossil
+// Page number of record list when record list exceeds specified page size
+func (r ApiApiRhTrexAiV1ScientistsIdDinosaursGetRequest) Page(page int32) ApiApiRhTrexAiV1ScientistsIdDinosaursGetRequest {
+	r.page = &page
+	return r
 }
 
-// Fossil data
-func (r ApiApiRhTrexAiV1FossilsPostRequest) Fossil(fossil Fossil) ApiApiRhTrexAiV1FossilsPostRequest {
-	r.fossil = &fossil
+// Maximum number of records to return
+func (r ApiApiRhTrexAiV1ScientistsIdDinosaursGetRequest) Size(size int32) ApiApiRhTrexAiV1ScientistsIdDinosaursGetRequest {
+	r.size = &size
 	return r
 }
 
-func (r ApiApiRhTrexAiV1FossilsPostRequest) Execute() (*Fossil, *http.Response, error) {
-	return r.ApiService.ApiRhTrexAiV1FossilsPostExecute(r)
+// Specifies the search criteria. The syntax of this parameter is similar to the syntax of the _where_ clause of an SQL statement, using the names of the json attributes / column names of the account.  For example, in order to retrieve all the accounts with a username starting with &#x60;my&#x60;:  &#x60;&#x60;&#x60;sql username like &#39;my%&#39; &#x60;&#x60;&#x60;  The search criteria can also be applied on related resource. For example, in order to retrieve all the subscriptions labeled by &#x60;foo&#x3D;bar&#x60;,  &#x60;&#x60;&#x60;sql subscription_labels.key &#x3D; &#39;foo&#39; and subscription_labels.value &#x3D; &#39;bar&#39; &#x60;&#x60;&#x60;  If the parameter isn&#39;t provided, or if the value is empty, then all the accounts that the user has permission to see will be returned.
+func (r ApiApiRhTrexAiV1ScientistsIdDinosaursGetRequest) Search(search string) ApiApiRhTrexAiV1ScientistsIdDinosaursGetRequest {
+	r.search = &search
+	return r
+}
+
+// Specifies the order by criteria. The syntax of this parameter is similar to the syntax of the _order by_ clause of an SQL statement, but using the names of the json attributes / column of the account. For example, in order to retrieve all accounts ordered by username:  &#x60;&#x60;&#x60;sql username asc &#x60;&#x60;&#x60;  Or in order to retrieve all accounts ordered by username _and_ first name:  &#x60;&#x60;&#x60;sql username asc, firstName asc &#x60;&#x60;&#x60;  If the parameter isn&#39;t provided, or if the value is empty, then no explicit ordering will be applied.
+func (r ApiApiRhTrexAiV1ScientistsIdDinosaursGetRequest) OrderBy(orderBy string) ApiApiRhTrexAiV1ScientistsIdDinosaursGetRequest {
+	r.orderBy = &orderBy
+	return r
+}
+
+// Supplies a comma-separated list of fields to be returned. Fields of sub-structures and of arrays use &lt;structure&gt;.&lt;field&gt; notation. &lt;stucture&gt;.* means all field of a structure Example: For each Subscription to get id, href, plan(id and kind) and labels (all fields)  &#x60;&#x60;&#x60; curl &#39;/api/v1/subscriptions?fields&#x3D;id,href,plan.id,plan.kind,labels.*&amp;fetchLabels&#x3D;true&#39; &#x60;&#x60;&#x60;
+func (r ApiApiRhTrexAiV1ScientistsIdDinosaursGetRequest) Fields(fields string) ApiApiRhTrexAiV1ScientistsIdDinosaursGetRequest {
+	r.fields = &fields
+	return r
+}
+
+// Opaque token returned as &#x60;continue&#x60; in the previous list response. The list resumes right after the last record of that page, and &#x60;page&#x60; is ignored. The &#x60;search&#x60; of the previous request must be repeated. &#x60;total&#x60; counts the whole list on every page.
+func (r ApiApiRhTrexAiV1ScientistsIdDinosaursGetRequest) Continue_(continue_ string) ApiApiRhTrexAiV1ScientistsIdDinosaursGetRequest {
+	r.continue_ = &continue_
+	return r
+}
+
+// Skip counting the total number of records, which is expensive on large collections. &#x60;total&#x60; is returned as 0.
+func (r ApiApiRhTrexAiV1ScientistsIdDinosaursGetRequest) SkipCount(skipCount bool) ApiApiRhTrexAiV1ScientistsIdDinosaursGetRequest {
+	r.skipCount = &skipCount
+	return r
+}
+
+// Include soft-deleted records, which carry &#x60;deleted_at&#x60;, in the list.
+func (r ApiApiRhTrexAiV1ScientistsIdDinosaursGetRequest) IncludeDeleted(includeDeleted bool) ApiApiRhTrexAiV1ScientistsIdDinosaursGetRequest {
+	r.includeDeleted = &includeDeleted
+	return r
+}
+
+func (r ApiApiRhTrexAiV1ScientistsIdDinosaursGetRequest) Execute() (*DinosaurList, *http.Response, error) {
+	return r.ApiService.ApiRhTrexAiV1ScientistsIdDinosaursGetExecute(r)
 }
 
 /*
-ApiRhTrexAiV1FossilsPost Create a new fossil
+ApiRhTrexAiV1ScientistsIdDinosaursGet Returns the dinosaurs linked to a scientist
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-	@return ApiApiRhTrexAiV1FossilsPostRequest
+	@param id The id of record
+	@return ApiApiRhTrexAiV1ScientistsIdDinosaursGetRequest
 */
-func (a *DefaultAPIService) ApiRhTrexAiV1FossilsPost(ctx context.Context) ApiApiRhTrexAiV1FossilsPostRequest {
-	return ApiApiRhTrexAiV1FossilsPostRequest{
+func (a *DefaultAPIService) ApiRhTrexAiV1ScientistsIdDinosaursGet(ctx context.Context, id string) ApiApiRhTrexAiV1ScientistsIdDinosaursGetRequest {
+	return ApiApiRhTrexAiV1ScientistsIdDinosaursGetRequest{
 		ApiService: a,
 		ctx:        ctx,
+		id:         id,
 	}
 }
 
 // Execute executes the request
 //
-//	@return Fossil
-func (a *DefaultAPIService) ApiRhTrexAiV1FossilsPostExecute(r ApiApiRhTrexAiV1FossilsPostRequest) (*Fossil, *http.Response, error) {
+//	@return DinosaurList
+func (a *DefaultAPIService) ApiRhTrexAiV1ScientistsIdDinosaursGetExecute(r ApiApiRhTrexAiV1ScientistsIdDinosaursGetRequest) (*DinosaurList, *http.Response, error) {
 	var (
-		localVarHTTPMethod  = http.MethodPost
+		localVarHTTPMethod  = http.MethodGet
 		localVarPostBody    interface{}
 		formFiles           []formFile
-		localVarReturnValue *Fossil
+		localVarReturnValue *DinosaurList
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.ApiRhTrexAiV1FossilsPost")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.ApiRhTrexAiV1ScientistsIdDinosaursGet")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/api/rh-trex-ai/v1/fossils"
+	localVarPath := localBasePath + "/api/rh-trex-ai/v1/scientists/{id}/dinosaurs"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
 	localVarFormParams := url.Values{}
-	if r.fossil == nil {
-		return localVarReturnValue, nil, reportError("fossil is required and must be specified")
-	}
 
+	if r.page != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "page", r.page, "form", "")
+	} else {
+		var defaultValue int32 = 1
+		r.page = &defaultValue
+	}
+	if r.size != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "size", r.size, "form", "")
+	} else {
+		var defaultValue int32 = 100
+		r.size = &defaultValue
+	}
+	if r.search != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "search", r.search, "form", "")
+	}
+	if r.orderBy != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "orderBy", r.orderBy, "form", "")
+	}
+	if r.fields != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "fields", r.fields, "form", "")
+	}
+	if r.continue_ != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "continue", r.continue_, "form", "")
+	}
+	if r.skipCount != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "skipCount", r.skipCount, "form", "")
+	}
+	if r.includeDeleted != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "includeDeleted", r.includeDeleted, "form", "")
+	}
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{"application/json"}
+	localVarHTTPContentTypes := []string{}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -1277,8 +2573,6 @@ func (a *DefaultAPIService) ApiRhTrexAiV1FossilsPostExecute(r ApiApiRhTrexAiV1Fo
 	if localVarHTTPHeaderAccept != "" {
 		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
 	}
-	// body params
-	localVarPostBody = r.fossil
 	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
 	if err != nil {
 		return localVarReturnValue, nil, err
@@ -1301,17 +2595,6 @@ func (a *DefaultAPIService) ApiRhTrexAiV1FossilsPostExecute(r ApiApiRhTrexAiV1Fo
 			body:  localVarBody,
 			error: localVarHTTPResponse.Status,
 		}
-		if localVarHTTPResponse.StatusCode == 400 {
-			var v Error
-			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
-			if err != nil {
-				newErr.error = err.Error()
-				return localVarReturnValue, localVarHTTPResponse, newErr
-			}
-			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
-			newErr.model = v
-			return localVarReturnValue, localVarHTTPResponse, newErr
-		}
 		if localVarHTTPResponse.StatusCode == 401 {
 			var v Error
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -1334,7 +2617,7 @@ func (a *DefaultAPIService) ApiRhTrexAiV1FossilsPostExecute(r ApiApiRhTrexAiV1Fo
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
-		if localVarHTTPResponse.StatusCode == 409 {
+		if localVarHTTPResponse.StatusCode == 404 {
 			var v Error
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
 			if err != nil {
@@ -1370,80 +2653,112 @@ func (a *DefaultAPIService) ApiRhTrexAiV1FossilsPostExecute(r ApiApiRhTrexAiV1Fo
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
-type ApiApiRhTrexAiV1ScientistsGetRequest struct {
-	ctx        context.Context
-	ApiService *DefaultAPIService
-	page       *int32
-	size       *int32
-	search     *string
-	orderBy    *string
-	fields     *string
+type ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest struct {
+	ctx            context.Context
+	ApiService     *DefaultAPIService
+	id             string
+	page           *int32
+	size           *int32
+	search         *string
+	orderBy        *string
+	fields         *string
+	continue_      *string
+	skipCount      *bool
+	includeDeleted *bool
+	preload        *string
 }
 
 // Page number of record list when record list exceeds specified page size
-func (r ApiApiRhTrexAiV1ScientistsGetRequest) Page(page int32) ApiApiRhTrexAiV1ScientistsGetRequest {
+func (r ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest) Page(page int32) ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest {
 	r.page = &page
 	return r
 }
 
 // Maximum number of records to return
-func (r ApiApiRhTrexAiV1ScientistsGetRequest) Size(size int32) ApiApiRhTrexAiV1ScientistsGetRequest {
+func (r ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest) Size(size int32) ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest {
 	r.size = &size
 	return r
 }
 
 // Specifies the search criteria. The syntax of this parameter is similar to the syntax of the _where_ clause of an SQL statement, using the names of the json attributes / column names of the account.  For example, in order to retrieve all the accounts with a username starting with &#x60;my&#x60;:  &#x60;&#x60;&#x60;sql username like &#39;my%&#39; &#x60;&#x60;&#x60;  The search criteria can also be applied on related resource. For example, in order to retrieve all the subscriptions labeled by &#x60;foo&#x3D;bar&#x60;,  &#x60;&#x60;&#x60;sql subscription_labels.key &#x3D; &#39;foo&#39; and subscription_labels.value &#x3D; &#39;bar&#39; &#x60;&#x60;&#x60;  If the parameter isn&#39;t provided, or if the value is empty, then all the accounts that the user has permission to see will be returned.
-func (r ApiApiRhTrexAiV1ScientistsGetRequest) Search(search string) ApiApiRhTrexAiV1ScientistsGetRequest {
+func (r ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest) Search(search string) ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest {
 	r.search = &search
 	return r
 }
 
 // Specifies the order by criteria. The syntax of this parameter is similar to the syntax of the _order by_ clause of an SQL statement, but using the names of the json attributes / column of the account. For example, in order to retrieve all accounts ordered by username:  &#x60;&#x60;&#x60;sql username asc &#x60;&#x60;&#x60;  Or in order to retrieve all accounts ordered by username _and_ first name:  &#x60;&#x60;&#x60;sql username asc, firstName asc &#x60;&#x60;&#x60;  If the parameter isn&#39;t provided, or if the value is empty, then no explicit ordering will be applied.
-func (r ApiApiRhTrexAiV1ScientistsGetRequest) OrderBy(orderBy string) ApiApiRhTrexAiV1ScientistsGetRequest {
+func (r ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest) OrderBy(orderBy string) ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest {
 	r.orderBy = &orderBy
 	return r
 }
 
 // Supplies a comma-separated list of fields to be returned. Fields of sub-structures and of arrays use &lt;structure&gt;.&lt;field&gt; notation. &lt;stucture&gt;.* means all field of a structure Example: For each Subscription to get id, href, plan(id and kind) and labels (all fields)  &#x60;&#x60;&#x60; curl &#39;/api/v1/subscriptions?fields&#x3D;id,href,plan.id,plan.kind,labels.*&amp;fetchLabels&#x3D;true&#39; &#x60;&#x60;&#x60;
-func (r ApiApiRhTrexAiV1ScientistsGetRequest) Fields(fields string) ApiApiRhTrexAiV1ScientistsGetRequest {
+func (r ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest) Fields(fields string) ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest {
 	r.fields = &fields
 	return r
 }
 
-func (r ApiApiRhTrexAiV1ScientistsGetRequest) Execute() (*ScientistList, *http.Response, error) {
-	return r.ApiService.ApiRhTrexAiV1ScientistsGetExecute(r)
+// Opaque token returned as &#x60;continue&#x60; in the previous list response. The list resumes right after the last record of that page, and &#x60;page&#x60; is ignored. The &#x60;search&#x60; of the previous request must be repeated. &#x60;total&#x60; counts the whole list on every page.
+func (r ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest) Continue_(continue_ string) ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest {
+	r.continue_ = &continue_
+	return r
+}
+
+// Skip counting the total number of records, which is expensive on large collections. &#x60;total&#x60; is returned as 0.
+func (r ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest) SkipCount(skipCount bool) ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest {
+	r.skipCount = &skipCount
+	return r
+}
+
+// Include soft-deleted records, which carry &#x60;deleted_at&#x60;, in the list.
+func (r ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest) IncludeDeleted(includeDeleted bool) ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest {
+	r.includeDeleted = &includeDeleted
+	return r
+}
+
+// Comma-separated list of the related resources to load along with the records, e.g. &#x60;dinosaur&#x60;
+func (r ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest) Preload(preload string) ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest {
+	r.preload = &preload
+	return r
+}
+
+func (r ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest) Execute() (*FossilList, *http.Response, error) {
+	return r.ApiService.ApiRhTrexAiV1ScientistsIdFossilsGetExecute(r)
 }
 
 /*
-ApiRhTrexAiV1ScientistsGet Returns a list of scientists
+ApiRhTrexAiV1ScientistsIdFossilsGet Returns the fossils of a scientist
 
 	@param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
-	@return ApiApiRhTrexAiV1ScientistsGetRequest
+	@param id The id of record
+	@return ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest
 */
-func (a *DefaultAPIService) ApiRhTrexAiV1ScientistsGet(ctx context.Context) ApiApiRhTrexAiV1ScientistsGetRequest {
-	return ApiApiRhTrexAiV1ScientistsGetRequest{
+func (a *DefaultAPIService) ApiRhTrexAiV1ScientistsIdFossilsGet(ctx context.Context, id string) ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest {
+	return ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest{
 		ApiService: a,
 		ctx:        ctx,
+		id:         id,
 	}
 }
 
 // Execute executes the request
 //
-//	@return ScientistList
-func (a *DefaultAPIService) ApiRhTrexAiV1ScientistsGetExecute(r ApiApiRhTrexAiV1ScientistsGetRequest) (*ScientistList, *http.Response, error) {
+//	@return FossilList
+func (a *DefaultAPIService) ApiRhTrexAiV1ScientistsIdFossilsGetExecute(r ApiApiRhTrexAiV1ScientistsIdFossilsGetRequest) (*FossilList, *http.Response, error) {
 	var (
 		localVarHTTPMethod  = http.MethodGet
 		localVarPostBody    interface{}
 		formFiles           []formFile
-		localVarReturnValue *ScientistList
+		localVarReturnValue *FossilList
 	)
 
-	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.ApiRhTrexAiV1ScientistsGet")
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "DefaultAPIService.ApiRhTrexAiV1ScientistsIdFossilsGet")
 	if err != nil {
 		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
 	}
 
-	localVarPath := localBasePath + "/api/rh-trex-ai/v1/scientists"
+	localVarPath := localBasePath + "/api/rh-trex-ai/v1/scientists/{id}/fossils"
+	localVarPath = strings.Replace(localVarPath, "{"+"id"+"}", url.PathEscape(parameterValueToString(r.id, "id")), -1)
 
 	localVarHeaderParams := make(map[string]string)
 	localVarQueryParams := url.Values{}
@@ -1470,6 +2785,18 @@ func (a *DefaultAPIService) ApiRhTrexAiV1ScientistsGetExecute(r ApiApiRhTrexAiV1
 	if r.fields != nil {
 		parameterAddToHeaderOrQuery(localVarQueryParams, "fields", r.fields, "form", "")
 	}
+	if r.continue_ != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "continue", r.continue_, "form", "")
+	}
+	if r.skipCount != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "skipCount", r.skipCount, "form", "")
+	}
+	if r.includeDeleted != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "includeDeleted", r.includeDeleted, "form", "")
+	}
+	if r.preload != nil {
+		parameterAddToHeaderOrQuery(localVarQueryParams, "preload", r.preload, "form", "")
+	}
 	// to determine the Content-Type header
 	localVarHTTPContentTypes := []string{}
 
@@ -1531,6 +2858,17 @@ func (a *DefaultAPIService) ApiRhTrexAiV1ScientistsGetExecute(r ApiApiRhTrexAiV1
 			newErr.model = v
 			return localVarReturnValue, localVarHTTPResponse, newErr
 		}
+		if localVarHTTPResponse.StatusCode == 404 {
+			var v Error
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+			newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+			newErr.model = v
+			return localVarReturnValue, localVarHTTPResponse, newErr
+		}
 		if localVarHTTPResponse.StatusCode == 500 {
 			var v Error
 			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
@@ -1760,7 +3098,7 @@ func (a *DefaultAPIService) ApiRhTrexAiV1ScientistsIdPatchExecute(r ApiApiRhTrex
 	}
 
 	// to determine the Content-Type header
-	localVarHTTPContentTypes := []string{"application/json"}
+	localVarHTTPContentTypes := []string{"application/json", "application/merge-patch+json", "application/json-patch+json"}
 
 	// set Content-Type header
 	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
@@ -22,12 +22,14 @@ var _ MappedNullable = &Dinosaur{}
 
 // Dinosaur struct for Dinosaur
 type Dinosaur struct {
-	Id        *string    `json:"id,omitempty"`
-	Kind      *string    `json:"kind,omitempty"`
-	Href      *string    `json:"href,omitempty"`
-	CreatedAt *time.Time `json:"created_at,omitempty"`
-	UpdatedAt *time.Time `json:"updated_at,omitempty"`
-	Species   string     `json:"species"`
+	Id              *string    `json:"id,omitempty"`
+	Kind            *string    `json:"kind,omitempty"`
+	Href            *string    `json:"href,omitempty"`
+	CreatedAt       *time.Time `json:"created_at,omitempty"`
+	UpdatedAt       *time.Time `json:"updated_at,omitempty"`
+	ResourceVersion *int64     `json:"resource_version,omitempty"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
+	Species         string     `json:"species"`
 }
 
 type _Dinosaur Dinosaur
@@ -210,6 +212,70 @@ func (o *Dinosaur) SetUpdatedAt(v time.Time) {
 	o.UpdatedAt = &v
 }
 
+// GetResourceVersion returns the ResourceVersion field value if set, zero value otherwise.
+func (o *Dinosaur) GetResourceVersion() int64 {
+	if o == nil || IsNil(o.ResourceVersion) {
+		var ret int64
+		return ret
+	}
+	return *o.ResourceVersion
+}
+
+// GetResourceVersionOk returns a tuple with the ResourceVersion field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Dinosaur) GetResourceVersionOk() (*int64, bool) {
+	if o == nil || IsNil(o.ResourceVersion) {
+		return nil, false
+	}
+	return o.ResourceVersion, true
+}
+
+// HasResourceVersion returns a boolean if a field has been set.
+func (o *Dinosaur) HasResourceVersion() bool {
+	if o != nil && !IsNil(o.ResourceVersion) {
+		return true
+	}
+
+	return false
+}
+
+// SetResourceVersion gets a reference to the given int64 and assigns it to the ResourceVersion field.
+func (o *Dinosaur) SetResourceVersion(v int64) {
+	o.ResourceVersion = &v
+}
+
+// GetDeletedAt returns the DeletedAt field value if set, zero value otherwise.
+func (o *Dinosaur) GetDeletedAt() time.Time {
+	if o == nil || IsNil(o.DeletedAt) {
+		var ret time.Time
+		return ret
+	}
+	return *o.DeletedAt
+}
+
+// GetDeletedAtOk returns a tuple with the DeletedAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Dinosaur) GetDeletedAtOk() (*time.Time, bool) {
+	if o == nil || IsNil(o.DeletedAt) {
+		return nil, false
+	}
+	return o.DeletedAt, true
+}
+
+// HasDeletedAt returns a boolean if a field has been set.
+func (o *Dinosaur) HasDeletedAt() bool {
+	if o != nil && !IsNil(o.DeletedAt) {
+		return true
+	}
+
+	return false
+}
+
+// SetDeletedAt gets a reference to the given time.Time and assigns it to the DeletedAt field.
+func (o *Dinosaur) SetDeletedAt(v time.Time) {
+	o.DeletedAt = &v
+}
+
 // GetSpecies returns the Species field value
 func (o *Dinosaur) GetSpecies() string {
 	if o == nil {
@@ -259,6 +325,12 @@ func (o Dinosaur) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.UpdatedAt) {
 		toSerialize["updated_at"] = o.UpdatedAt
 	}
+	if !IsNil(o.ResourceVersion) {
+		toSerialize["resource_version"] = o.ResourceVersion
+	}
+	if !IsNil(o.DeletedAt) {
+		toSerialize["deleted_at"] = o.DeletedAt
+	}
 	toSerialize["species"] = o.Species
 	return toSerialize, nil
 }
@@ -20,14 +20,16 @@ var _ MappedNullable = &Error{}
 
 // Error struct for Error
 type Error struct {
-	Id          *string    `json:"id,omitempty"`
-	Kind        *string    `json:"kind,omitempty"`
-	Href        *string    `json:"href,omitempty"`
-	CreatedAt   *time.Time `json:"created_at,omitempty"`
-	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
-	Code        *string    `json:"code,omitempty"`
-	Reason      *string    `json:"reason,omitempty"`
-	OperationId *string    `json:"operation_id,omitempty"`
+	Id              *string          `json:"id,omitempty"`
+	Kind            *string          `json:"kind,omitempty"`
+	Href            *string          `json:"href,omitempty"`
+	CreatedAt       *time.Time       `json:"created_at,omitempty"`
+	UpdatedAt       *time.Time       `json:"updated_at,omitempty"`
+	ResourceVersion *int64           `json:"resource_version,omitempty"`
+	Code            *string          `json:"code,omitempty"`
+	Reason          *string          `json:"reason,omitempty"`
+	OperationId     *string          `json:"operation_id,omitempty"`
+	FieldViolations []FieldViolation `json:"field_violations,omitempty"`
 }
 
 // NewError instantiates a new Error object
@@ -207,6 +209,38 @@ func (o *Error) SetUpdatedAt(v time.Time) {
 	o.UpdatedAt = &v
 }
 
+// GetResourceVersion returns the ResourceVersion field value if set, zero value otherwise.
+func (o *Error) GetResourceVersion() int64 {
+	if o == nil || IsNil(o.ResourceVersion) {
+		var ret int64
+		return ret
+	}
+	return *o.ResourceVersion
+}
+
+// GetResourceVersionOk returns a tuple with the ResourceVersion field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Error) GetResourceVersionOk() (*int64, bool) {
+	if o == nil || IsNil(o.ResourceVersion) {
+		return nil, false
+	}
+	return o.ResourceVersion, true
+}
+
+// HasResourceVersion returns a boolean if a field has been set.
+func (o *Error) HasResourceVersion() bool {
+	if o != nil && !IsNil(o.ResourceVersion) {
+		return true
+	}
+
+	return false
+}
+
+// SetResourceVersion gets a reference to the given int64 and assigns it to the ResourceVersion field.
+func (o *Error) SetResourceVersion(v int64) {
+	o.ResourceVersion = &v
+}
+
 // GetCode returns the Code field value if set, zero value otherwise.
 func (o *Error) GetCode() string {
 	if o == nil || IsNil(o.Code) {
@@ -303,6 +337,38 @@ func (o *Error) SetOperationId(v string) {
 	o.OperationId = &v
 }
 
+// GetFieldViolations returns the FieldViolations field value if set, zero value otherwise.
+func (o *Error) GetFieldViolations() []FieldViolation {
+	if o == nil || IsNil(o.FieldViolations) {
+		var ret []FieldViolation
+		return ret
+	}
+	return o.FieldViolations
+}
+
+// GetFieldViolationsOk returns a tuple with the FieldViolations field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Error) GetFieldViolationsOk() ([]FieldViolation, bool) {
+	if o == nil || IsNil(o.FieldViolations) {
+		return nil, false
+	}
+	return o.FieldViolations, true
+}
+
+// HasFieldViolations returns a boolean if a field has been set.
+func (o *Error) HasFieldViolations() bool {
+	if o != nil && !IsNil(o.FieldViolations) {
+		return true
+	}
+
+	return false
+}
+
+// SetFieldViolations gets a reference to the given []FieldViolation and assigns it to the FieldViolations field.
+func (o *Error) SetFieldViolations(v []FieldViolation) {
+	o.FieldViolations = v
+}
+
 func (o Error) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -328,6 +394,9 @@ func (o Error) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.UpdatedAt) {
 		toSerialize["updated_at"] = o.UpdatedAt
 	}
+	if !IsNil(o.ResourceVersion) {
+		toSerialize["resource_version"] = o.ResourceVersion
+	}
 	if !IsNil(o.Code) {
 		toSerialize["code"] = o.Code
 	}
@@ -337,6 +406,9 @@ func (o Error) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.OperationId) {
 		toSerialize["operation_id"] = o.OperationId
 	}
+	if !IsNil(o.FieldViolations) {
+		toSerialize["field_violations"] = o.FieldViolations
+	}
 	return toSerialize, nil
 }
 
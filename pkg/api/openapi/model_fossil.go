@@ -27,10 +27,16 @@ type Fossil struct {
 	Href              *string    `json:"href,omitempty"`
 	CreatedAt         *time.Time `json:"created_at,omitempty"`
 	UpdatedAt         *time.Time `json:"updated_at,omitempty"`
+	ResourceVersion   *int64     `json:"resource_version,omitempty"`
+	DeletedAt         *time.Time `json:"deleted_at,omitempty"`
 	DiscoveryLocation string     `json:"discovery_location"`
 	EstimatedAge      *int32     `json:"estimated_age,omitempty"`
 	FossilType        *string    `json:"fossil_type,omitempty"`
 	ExcavatorName     *string    `json:"excavator_name,omitempty"`
+	DinosaurId        *string    `json:"dinosaur_id,omitempty"`
+	ScientistId       *string    `json:"scientist_id,omitempty"`
+	Dinosaur          *Dinosaur  `json:"dinosaur,omitempty"`
+	Scientist         *Scientist `json:"scientist,omitempty"`
 }
 
 type _Fossil Fossil
@@ -213,6 +219,70 @@ func (o *Fossil) SetUpdatedAt(v time.Time) {
 	o.UpdatedAt = &v
 }
 
+// GetResourceVersion returns the ResourceVersion field value if set, zero value otherwise.
+func (o *Fossil) GetResourceVersion() int64 {
+	if o == nil || IsNil(o.ResourceVersion) {
+		var ret int64
+		return ret
+	}
+	return *o.ResourceVersion
+}
+
+// GetResourceVersionOk returns a tuple with the ResourceVersion field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Fossil) GetResourceVersionOk() (*int64, bool) {
+	if o == nil || IsNil(o.ResourceVersion) {
+		return nil, false
+	}
+	return o.ResourceVersion, true
+}
+
+// HasResourceVersion returns a boolean if a field has been set.
+func (o *Fossil) HasResourceVersion() bool {
+	if o != nil && !IsNil(o.ResourceVersion) {
+		return true
+	}
+
+	return false
+}
+
+// SetResourceVersion gets a reference to the given int64 and assigns it to the ResourceVersion field.
+func (o *Fossil) SetResourceVersion(v int64) {
+	o.ResourceVersion = &v
+}
+
+// GetDeletedAt returns the DeletedAt field value if set, zero value otherwise.
+func (o *Fossil) GetDeletedAt() time.Time {
+	if o == nil || IsNil(o.DeletedAt) {
+		var ret time.Time
+		return ret
+	}
+	return *o.DeletedAt
+}
+
+// GetDeletedAtOk returns a tuple with the DeletedAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Fossil) GetDeletedAtOk() (*time.Time, bool) {
+	if o == nil || IsNil(o.DeletedAt) {
+		return nil, false
+	}
+	return o.DeletedAt, true
+}
+
+// HasDeletedAt returns a boolean if a field has been set.
+func (o *Fossil) HasDeletedAt() bool {
+	if o != nil && !IsNil(o.DeletedAt) {
+		return true
+	}
+
+	return false
+}
+
+// SetDeletedAt gets a reference to the given time.Time and assigns it to the DeletedAt field.
+func (o *Fossil) SetDeletedAt(v time.Time) {
+	o.DeletedAt = &v
+}
+
 // GetDiscoveryLocation returns the DiscoveryLocation field value
 func (o *Fossil) GetDiscoveryLocation() string {
 	if o == nil {
@@ -333,6 +403,134 @@ func (o *Fossil) SetExcavatorName(v string) {
 	o.ExcavatorName = &v
 }
 
+// GetDinosaurId returns the DinosaurId field value if set, zero value otherwise.
+func (o *Fossil) GetDinosaurId() string {
+	if o == nil || IsNil(o.DinosaurId) {
+		var ret string
+		return ret
+	}
+	return *o.DinosaurId
+}
+
+// GetDinosaurIdOk returns a tuple with the DinosaurId field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Fossil) GetDinosaurIdOk() (*string, bool) {
+	if o == nil || IsNil(o.DinosaurId) {
+		return nil, false
+	}
+	return o.DinosaurId, true
+}
+
+// HasDinosaurId returns a boolean if a field has been set.
+func (o *Fossil) HasDinosaurId() bool {
+	if o != nil && !IsNil(o.DinosaurId) {
+		return true
+	}
+
+	return false
+}
+
+// SetDinosaurId gets a reference to the given string and assigns it to the DinosaurId field.
+func (o *Fossil) SetDinosaurId(v string) {
+	o.DinosaurId = &v
+}
+
+// GetScientistId returns the ScientistId field value if set, zero value otherwise.
+func (o *Fossil) GetScientistId() string {
+	if o == nil || IsNil(o.ScientistId) {
+		var ret string
+		return ret
+	}
+	return *o.ScientistId
+}
+
+// GetScientistIdOk returns a tuple with the ScientistId field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Fossil) GetScientistIdOk() (*string, bool) {
+	if o == nil || IsNil(o.ScientistId) {
+		return nil, false
+	}
+	return o.ScientistId, true
+}
+
+// HasScientistId returns a boolean if a field has been set.
+func (o *Fossil) HasScientistId() bool {
+	if o != nil && !IsNil(o.ScientistId) {
+		return true
+	}
+
+	return false
+}
+
+// SetScientistId gets a reference to the given string and assigns it to the ScientistId field.
+func (o *Fossil) SetScientistId(v string) {
+	o.ScientistId = &v
+}
+
+// GetDinosaur returns the Dinosaur field value if set, zero value otherwise.
+func (o *Fossil) GetDinosaur() Dinosaur {
+	if o == nil || IsNil(o.Dinosaur) {
+		var ret Dinosaur
+		return ret
+	}
+	return *o.Dinosaur
+}
+
+// GetDinosaurOk returns a tuple with the Dinosaur field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Fossil) GetDinosaurOk() (*Dinosaur, bool) {
+	if o == nil || IsNil(o.Dinosaur) {
+		return nil, false
+	}
+	return o.Dinosaur, true
+}
+
+// HasDinosaur returns a boolean if a field has been set.
+func (o *Fossil) HasDinosaur() bool {
+	if o != nil && !IsNil(o.Dinosaur) {
+		return true
+	}
+
+	return false
+}
+
+// SetDinosaur gets a reference to the given Dinosaur and assigns it to the Dinosaur field.
+func (o *Fossil) SetDinosaur(v Dinosaur) {
+	o.Dinosaur = &v
+}
+
+// GetScientist returns the Scientist field value if set, zero value otherwise.
+func (o *Fossil) GetScientist() Scientist {
+	if o == nil || IsNil(o.Scientist) {
+		var ret Scientist
+		return ret
+	}
+	return *o.Scientist
+}
+
+// GetScientistOk returns a tuple with the Scientist field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Fossil) GetScientistOk() (*Scientist, bool) {
+	if o == nil || IsNil(o.Scientist) {
+		return nil, false
+	}
+	return o.Scientist, true
+}
+
+// HasScientist returns a boolean if a field has been set.
+func (o *Fossil) HasScientist() bool {
+	if o != nil && !IsNil(o.Scientist) {
+		return true
+	}
+
+	return false
+}
+
+// SetScientist gets a reference to the given Scientist and assigns it to the Scientist field.
+func (o *Fossil) SetScientist(v Scientist) {
+	o.Scientist = &v
+}
+
 func (o Fossil) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -358,6 +556,12 @@ func (o Fossil) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.UpdatedAt) {
 		toSerialize["updated_at"] = o.UpdatedAt
 	}
+	if !IsNil(o.ResourceVersion) {
+		toSerialize["resource_version"] = o.ResourceVersion
+	}
+	if !IsNil(o.DeletedAt) {
+		toSerialize["deleted_at"] = o.DeletedAt
+	}
 	toSerialize["discovery_location"] = o.DiscoveryLocation
 	if !IsNil(o.EstimatedAge) {
 		toSerialize["estimated_age"] = o.EstimatedAge
@@ -368,6 +572,18 @@ func (o Fossil) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.ExcavatorName) {
 		toSerialize["excavator_name"] = o.ExcavatorName
 	}
+	if !IsNil(o.DinosaurId) {
+		toSerialize["dinosaur_id"] = o.DinosaurId
+	}
+	if !IsNil(o.ScientistId) {
+		toSerialize["scientist_id"] = o.ScientistId
+	}
+	if !IsNil(o.Dinosaur) {
+		toSerialize["dinosaur"] = o.Dinosaur
+	}
+	if !IsNil(o.Scientist) {
+		toSerialize["scientist"] = o.Scientist
+	}
 	return toSerialize, nil
 }
 
@@ -23,6 +23,8 @@ type FossilPatchRequest struct {
 	EstimatedAge      *int32  `json:"estimated_age,omitempty"`
 	FossilType        *string `json:"fossil_type,omitempty"`
 	ExcavatorName     *string `json:"excavator_name,omitempty"`
+	DinosaurId        *string `json:"dinosaur_id,omitempty"`
+	ScientistId       *string `json:"scientist_id,omitempty"`
 }
 
 // NewFossilPatchRequest instantiates a new FossilPatchRequest object
@@ -170,6 +172,70 @@ func (o *FossilPatchRequest) SetExcavatorName(v string) {
 	o.ExcavatorName = &v
 }
 
+// GetDinosaurId returns the DinosaurId field value if set, zero value otherwise.
+func (o *FossilPatchRequest) GetDinosaurId() string {
+	if o == nil || IsNil(o.DinosaurId) {
+		var ret string
+		return ret
+	}
+	return *o.DinosaurId
+}
+
+// GetDinosaurIdOk returns a tuple with the DinosaurId field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *FossilPatchRequest) GetDinosaurIdOk() (*string, bool) {
+	if o == nil || IsNil(o.DinosaurId) {
+		return nil, false
+	}
+	return o.DinosaurId, true
+}
+
+// HasDinosaurId returns a boolean if a field has been set.
+func (o *FossilPatchRequest) HasDinosaurId() bool {
+	if o != nil && !IsNil(o.DinosaurId) {
+		return true
+	}
+
+	return false
+}
+
+// SetDinosaurId gets a reference to the given string and assigns it to the DinosaurId field.
+func (o *FossilPatchRequest) SetDinosaurId(v string) {
+	o.DinosaurId = &v
+}
+
+// GetScientistId returns the ScientistId field value if set, zero value otherwise.
+func (o *FossilPatchRequest) GetScientistId() string {
+	if o == nil || IsNil(o.ScientistId) {
+		var ret string
+		return ret
+	}
+	return *o.ScientistId
+}
+
+// GetScientistIdOk returns a tuple with the ScientistId field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *FossilPatchRequest) GetScientistIdOk() (*string, bool) {
+	if o == nil || IsNil(o.ScientistId) {
+		return nil, false
+	}
+	return o.ScientistId, true
+}
+
+// HasScientistId returns a boolean if a field has been set.
+func (o *FossilPatchRequest) HasScientistId() bool {
+	if o != nil && !IsNil(o.ScientistId) {
+		return true
+	}
+
+	return false
+}
+
+// SetScientistId gets a reference to the given string and assigns it to the ScientistId field.
+func (o *FossilPatchRequest) SetScientistId(v string) {
+	o.ScientistId = &v
+}
+
 func (o FossilPatchRequest) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -192,6 +258,12 @@ func (o FossilPatchRequest) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.ExcavatorName) {
 		toSerialize["excavator_name"] = o.ExcavatorName
 	}
+	if !IsNil(o.DinosaurId) {
+		toSerialize["dinosaur_id"] = o.DinosaurId
+	}
+	if !IsNil(o.ScientistId) {
+		toSerialize["scientist_id"] = o.ScientistId
+	}
 	return toSerialize, nil
 }
 
@@ -20,11 +20,13 @@ var _ MappedNullable = &ObjectReference{}
 
 // ObjectReference struct for ObjectReference
 type ObjectReference struct {
-	Id        *string    `json:"id,omitempty"`
-	Kind      *string    `json:"kind,omitempty"`
-	Href      *string    `json:"href,omitempty"`
-	CreatedAt *time.Time `json:"created_at,omitempty"`
-	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	Id              *string    `json:"id,omitempty"`
+	Kind            *string    `json:"kind,omitempty"`
+	Href            *string    `json:"href,omitempty"`
+	CreatedAt       *time.Time `json:"created_at,omitempty"`
+	UpdatedAt       *time.Time `json:"updated_at,omitempty"`
+	ResourceVersion *int64     `json:"resource_version,omitempty"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
 }
 
 // NewObjectReference instantiates a new ObjectReference object
@@ -204,6 +206,70 @@ func (o *ObjectReference) SetUpdatedAt(v time.Time) {
 	o.UpdatedAt = &v
 }
 
+// GetResourceVersion returns the ResourceVersion field value if set, zero value otherwise.
+func (o *ObjectReference) GetResourceVersion() int64 {
+	if o == nil || IsNil(o.ResourceVersion) {
+		var ret int64
+		return ret
+	}
+	return *o.ResourceVersion
+}
+
+// GetResourceVersionOk returns a tuple with the ResourceVersion field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ObjectReference) GetResourceVersionOk() (*int64, bool) {
+	if o == nil || IsNil(o.ResourceVersion) {
+		return nil, false
+	}
+	return o.ResourceVersion, true
+}
+
+// HasResourceVersion returns a boolean if a field has been set.
+func (o *ObjectReference) HasResourceVersion() bool {
+	if o != nil && !IsNil(o.ResourceVersion) {
+		return true
+	}
+
+	return false
+}
+
+// SetResourceVersion gets a reference to the given int64 and assigns it to the ResourceVersion field.
+func (o *ObjectReference) SetResourceVersion(v int64) {
+	o.ResourceVersion = &v
+}
+
+// GetDeletedAt returns the DeletedAt field value if set, zero value otherwise.
+func (o *ObjectReference) GetDeletedAt() time.Time {
+	if o == nil || IsNil(o.DeletedAt) {
+		var ret time.Time
+		return ret
+	}
+	return *o.DeletedAt
+}
+
+// GetDeletedAtOk returns a tuple with the DeletedAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ObjectReference) GetDeletedAtOk() (*time.Time, bool) {
+	if o == nil || IsNil(o.DeletedAt) {
+		return nil, false
+	}
+	return o.DeletedAt, true
+}
+
+// HasDeletedAt returns a boolean if a field has been set.
+func (o *ObjectReference) HasDeletedAt() bool {
+	if o != nil && !IsNil(o.DeletedAt) {
+		return true
+	}
+
+	return false
+}
+
+// SetDeletedAt gets a reference to the given time.Time and assigns it to the DeletedAt field.
+func (o *ObjectReference) SetDeletedAt(v time.Time) {
+	o.DeletedAt = &v
+}
+
 func (o ObjectReference) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -229,6 +295,12 @@ func (o ObjectReference) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.UpdatedAt) {
 		toSerialize["updated_at"] = o.UpdatedAt
 	}
+	if !IsNil(o.ResourceVersion) {
+		toSerialize["resource_version"] = o.ResourceVersion
+	}
+	if !IsNil(o.DeletedAt) {
+		toSerialize["deleted_at"] = o.DeletedAt
+	}
 	return toSerialize, nil
 }
 
@@ -22,13 +22,16 @@ var _ MappedNullable = &Scientist{}
 
 // Scientist struct for Scientist
 type Scientist struct {
-	Id        *string    `json:"id,omitempty"`
-	Kind      *string    `json:"kind,omitempty"`
-	Href      *string    `json:"href,omitempty"`
-	CreatedAt *time.Time `json:"created_at,omitempty"`
-	UpdatedAt *time.Time `json:"updated_at,omitempty"`
-	Name      string     `json:"name"`
-	Field     string     `json:"field"`
+	Id              *string    `json:"id,omitempty"`
+	Kind            *string    `json:"kind,omitempty"`
+	Href            *string    `json:"href,omitempty"`
+	CreatedAt       *time.Time `json:"created_at,omitempty"`
+	UpdatedAt       *time.Time `json:"updated_at,omitempty"`
+	ResourceVersion *int64     `json:"resource_version,omitempty"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
+	Name            string     `json:"name"`
+	Field           string     `json:"field"`
+	Dinosaurs       []Dinosaur `json:"dinosaurs,omitempty"`
 }
 
 type _Scientist Scientist
@@ -212,6 +215,70 @@ func (o *Scientist) SetUpdatedAt(v time.Time) {
 	o.UpdatedAt = &v
 }
 
+// GetResourceVersion returns the ResourceVersion field value if set, zero value otherwise.
+func (o *Scientist) GetResourceVersion() int64 {
+	if o == nil || IsNil(o.ResourceVersion) {
+		var ret int64
+		return ret
+	}
+	return *o.ResourceVersion
+}
+
+// GetResourceVersionOk returns a tuple with the ResourceVersion field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Scientist) GetResourceVersionOk() (*int64, bool) {
+	if o == nil || IsNil(o.ResourceVersion) {
+		return nil, false
+	}
+	return o.ResourceVersion, true
+}
+
+// HasResourceVersion returns a boolean if a field has been set.
+func (o *Scientist) HasResourceVersion() bool {
+	if o != nil && !IsNil(o.ResourceVersion) {
+		return true
+	}
+
+	return false
+}
+
+// SetResourceVersion gets a reference to the given int64 and assigns it to the ResourceVersion field.
+func (o *Scientist) SetResourceVersion(v int64) {
+	o.ResourceVersion = &v
+}
+
+// GetDeletedAt returns the DeletedAt field value if set, zero value otherwise.
+func (o *Scientist) GetDeletedAt() time.Time {
+	if o == nil || IsNil(o.DeletedAt) {
+		var ret time.Time
+		return ret
+	}
+	return *o.DeletedAt
+}
+
+// GetDeletedAtOk returns a tuple with the DeletedAt field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Scientist) GetDeletedAtOk() (*time.Time, bool) {
+	if o == nil || IsNil(o.DeletedAt) {
+		return nil, false
+	}
+	return o.DeletedAt, true
+}
+
+// HasDeletedAt returns a boolean if a field has been set.
+func (o *Scientist) HasDeletedAt() bool {
+	if o != nil && !IsNil(o.DeletedAt) {
+		return true
+	}
+
+	return false
+}
+
+// SetDeletedAt gets a reference to the given time.Time and assigns it to the DeletedAt field.
+func (o *Scientist) SetDeletedAt(v time.Time) {
+	o.DeletedAt = &v
+}
+
 // GetName returns the Name field value
 func (o *Scientist) GetName() string {
 	if o == nil {
@@ -260,6 +327,38 @@ func (o *Scientist) SetField(v string) {
 	o.Field = v
 }
 
+// GetDinosaurs returns the Dinosaurs field value if set, zero value otherwise.
+func (o *Scientist) GetDinosaurs() []Dinosaur {
+	if o == nil || IsNil(o.Dinosaurs) {
+		var ret []Dinosaur
+		return ret
+	}
+	return o.Dinosaurs
+}
+
+// GetDinosaursOk returns a tuple with the Dinosaurs field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *Scientist) GetDinosaursOk() ([]Dinosaur, bool) {
+	if o == nil || IsNil(o.Dinosaurs) {
+		return nil, false
+	}
+	return o.Dinosaurs, true
+}
+
+// HasDinosaurs returns a boolean if a field has been set.
+func (o *Scientist) HasDinosaurs() bool {
+	if o != nil && !IsNil(o.Dinosaurs) {
+		return true
+	}
+
+	return false
+}
+
+// SetDinosaurs gets a reference to the given []Dinosaur and assigns it to the Dinosaurs field.
+func (o *Scientist) SetDinosaurs(v []Dinosaur) {
+	o.Dinosaurs = v
+}
+
 func (o Scientist) MarshalJSON() ([]byte, error) {
 	toSerialize, err := o.ToMap()
 	if err != nil {
@@ -285,8 +384,17 @@ func (o Scientist) ToMap() (map[string]interface{}, error) {
 	if !IsNil(o.UpdatedAt) {
 		toSerialize["updated_at"] = o.UpdatedAt
 	}
+	if !IsNil(o.ResourceVersion) {
+		toSerialize["resource_version"] = o.ResourceVersion
+	}
+	if !IsNil(o.DeletedAt) {
+		toSerialize["deleted_at"] = o.DeletedAt
+	}
 	toSerialize["name"] = o.Name
 	toSerialize["field"] = o.Field
+	if !IsNil(o.Dinosaurs) {
+		toSerialize["dinosaurs"] = o.Dinosaurs
+	}
 	return toSerialize, nil
 }
 
@@ -21,11 +21,13 @@ var _ MappedNullable = &ScientistList{}
 
 // ScientistList struct for ScientistList
 type ScientistList struct {
-	Kind  string      `json:"kind"`
-	Page  int32       `json:"page"`
-	Size  int32       `json:"size"`
-	Total int32       `json:"total"`
-	Items []Scientist `json:"items"`
+	Kind  string `json:"kind"`
+	Page  int32  `json:"page"`
+	Size  int32  `json:"size"`
+	Total int32  `json:"total"`
+	// Token to request the next page with, absent on the last page
+	Continue *string     `json:"continue,omitempty"`
+	Items    []Scientist `json:"items"`
 }
 
 type _ScientistList ScientistList
@@ -148,6 +150,38 @@ func (o *ScientistList) SetTotal(v int32) {
 	o.Total = v
 }
 
+// GetContinue returns the Continue field value if set, zero value otherwise.
+func (o *ScientistList) GetContinue() string {
+	if o == nil || IsNil(o.Continue) {
+		var ret string
+		return ret
+	}
+	return *o.Continue
+}
+
+// GetContinueOk returns a tuple with the Continue field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *ScientistList) GetContinueOk() (*string, bool) {
+	if o == nil || IsNil(o.Continue) {
+		return nil, false
+	}
+	return o.Continue, true
+}
+
+// HasContinue returns a boolean if a field has been set.
+func (o *ScientistList) HasContinue() bool {
+	if o != nil && !IsNil(o.Continue) {
+		return true
+	}
+
+	return false
+}
+
+// SetContinue gets a reference to the given string and assigns it to the Continue field.
+func (o *ScientistList) SetContinue(v string) {
+	o.Continue = &v
+}
+
 // GetItems returns the Items field value
 func (o *ScientistList) GetItems() []Scientist {
 	if o == nil {
@@ -186,6 +220,9 @@ func (o ScientistList) ToMap() (map[string]interface{}, error) {
 	toSerialize["page"] = o.Page
 	toSerialize["size"] = o.Size
 	toSerialize["total"] = o.Total
+	if !IsNil(o.Continue) {
+		toSerialize["continue"] = o.Continue
+	}
 	toSerialize["items"] = o.Items
 	return toSerialize, nil
 }
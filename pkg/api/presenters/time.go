@@ -3,6 +3,8 @@ package presenters
 import (
 	"time"
 
+	"gorm.io/gorm"
+
 	"github.com/openshift-online/rh-trex-ai/pkg/util"
 )
 
@@ -12,3 +14,11 @@ func PresentTime(t time.Time) *time.Time {
 	}
 	return util.ToPtr(t.Round(time.Microsecond))
 }
+
+// PresentDeletedAt returns the deletion time of a soft-deleted record, or nil for a live one
+func PresentDeletedAt(deletedAt gorm.DeletedAt) *time.Time {
+	if !deletedAt.Valid {
+		return nil
+	}
+	return PresentTime(deletedAt.Time)
+}
@@ -0,0 +1,20 @@
+package api
+
+import (
+	"gorm.io/gorm"
+)
+
+// RoleBinding grants a role of the authorization policy to a user
+type RoleBinding struct {
+	Meta
+	Username string
+	Role     string
+}
+
+type RoleBindingList []*RoleBinding
+
+func (d *RoleBinding) BeforeCreate(tx *gorm.DB) error {
+	d.ID = NewID()
+	d.ResourceVersion = 1
+	return nil
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Webhook subscribes an HTTP endpoint to the events of the Kinds. Every delivery is POSTed to URL and
+// signed with Secret.
+type Webhook struct {
+	Meta
+	URL    string
+	Secret string
+	// Source narrows the webhook to the events of one Kind, e.g. Dinosaurs. Empty means every Kind.
+	Source string
+	// Filter is a search the resource of an event must match, e.g. "species = 'Tyrannosaurus'". It
+	// requires a Source.
+	Filter string
+}
+
+type WebhookList []*Webhook
+
+func (d *Webhook) BeforeCreate(tx *gorm.DB) error {
+	d.ID = NewID()
+	d.ResourceVersion = 1
+	return nil
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending attempts are due at ScheduledAt
+	WebhookDeliveryPending WebhookDeliveryStatus = "Pending"
+	// WebhookDeliverySucceeded attempts got a 2xx response
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "Succeeded"
+	// WebhookDeliveryFailed attempts failed and the next attempt is scheduled
+	WebhookDeliveryFailed WebhookDeliveryStatus = "Failed"
+	// WebhookDeliveryDeadLettered attempts failed and exhausted the retries of the event
+	WebhookDeliveryDeadLettered WebhookDeliveryStatus = "DeadLettered"
+)
+
+// WebhookDelivery is one attempt to deliver an event to a webhook. A failed attempt schedules the next
+// one as a new row, so the deliveries of an event are the history of its attempts.
+type WebhookDelivery struct {
+	ID             string `gorm:"primaryKey"`
+	CreatedAt      time.Time
+	WebhookID      string
+	OrganizationID string
+	EventID        string
+	Source         string
+	SourceID       string
+	EventType      EventType
+	// Attempt counts the attempts to deliver the event to the webhook, starting at 1
+	Attempt int
+	// Payload is the JSON body POSTed to the webhook
+	Payload     string
+	Status      WebhookDeliveryStatus
+	ScheduledAt time.Time
+	AttemptedAt *time.Time
+	// StatusCode is the response status of the attempt, 0 if no response was received
+	StatusCode int
+	Error      string
+}
+
+type WebhookDeliveryList []*WebhookDelivery
+
+func (d *WebhookDelivery) BeforeCreate(tx *gorm.DB) error {
+	d.ID = NewID()
+	return nil
+}
@@ -30,8 +30,9 @@ func (a *Middleware) AuthenticateAccountJWT(next http.Handler) http.Handler {
 			return
 		}
 
-		// Append the username to the request context
+		// Append the username and the tenant of the user to the request context
 		ctx = SetUsernameContext(ctx, payload.Username)
+		ctx = SetTenantContext(ctx, Tenant{OrganizationID: payload.OrganizationID})
 		*r = *r.WithContext(ctx)
 
 		next.ServeHTTP(w, r)
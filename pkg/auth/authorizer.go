@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+)
+
+// Actions routes and RPCs are authorized for. Resources may declare actions of their own, e.g. "restore".
+const (
+	ActionGet    = "get"
+	ActionList   = "list"
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+	ActionWatch  = "watch"
+)
+
+// Authorizer decides whether a user may perform an action on a resource. Resources are named after
+// their collection in the API, e.g. "dinosaurs".
+type Authorizer interface {
+	Authorize(ctx context.Context, username, action, resource string) (bool, error)
+}
+
+type allowAllAuthorizer struct{}
+
+var _ Authorizer = &allowAllAuthorizer{}
+
+// NewAllowAllAuthorizer returns an Authorizer that allows everything, for use when authorization is disabled
+func NewAllowAllAuthorizer() Authorizer {
+	return &allowAllAuthorizer{}
+}
+
+func (a *allowAllAuthorizer) Authorize(ctx context.Context, username, action, resource string) (bool, error) {
+	return true, nil
+}
+
+// ActionForMethod returns the action implied by an HTTP method
+func ActionForMethod(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return ActionGet
+	case http.MethodPost:
+		return ActionCreate
+	case http.MethodPut, http.MethodPatch:
+		return ActionUpdate
+	case http.MethodDelete:
+		return ActionDelete
+	default:
+		return method
+	}
+}
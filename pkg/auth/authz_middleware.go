@@ -1,72 +1,94 @@
 package auth
 
 /*
-   The goal of this simple authz middlewre is to provide a way for access review
-   parameters to be declared for each route in a microservice. This is not meant
-   to handle more complex access review calls in particular scopes, but rather
-   just authz calls at the application scope
-
-  This is a big TODO, not ready for consumption
+   The authz middleware authorizes requests with an Authorizer. Routes declare the action and the
+   resource they need with Authorize; AuthorizeApi derives both from the request for routes that
+   don't.
 */
 
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
-	"github.com/openshift-online/rh-trex-ai/pkg/client/apiclient"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 )
 
 type AuthorizationMiddleware interface {
+	// AuthorizeApi authorizes the action implied by the HTTP method on the collection named by the first
+	// path segment after the API version, e.g. PATCH .../v1/dinosaurs/{id} needs "update" on "dinosaurs"
 	AuthorizeApi(next http.Handler) http.Handler
+	// Authorize wraps the handler of a single route so it only runs if the caller may perform action on resource
+	Authorize(action, resource string, next http.HandlerFunc) http.HandlerFunc
 }
 
 type authzMiddleware struct {
-	action       string
-	resourceType string
-
-	apiClient *apiclient.Client
+	authorizer Authorizer
 }
 
 var _ AuthorizationMiddleware = &authzMiddleware{}
 
-func NewAuthzMiddleware(apiClient *apiclient.Client, action, resourceType string) AuthorizationMiddleware {
+func NewAuthzMiddleware(authorizer Authorizer) AuthorizationMiddleware {
 	return &authzMiddleware{
-		apiClient:    apiClient,
-		action:       action,
-		resourceType: resourceType,
+		authorizer: authorizer,
 	}
 }
 
 func (a authzMiddleware) AuthorizeApi(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx := r.Context()
+		action, resource := actionAndResource(r)
+		a.authorize(action, resource, next.ServeHTTP)(w, r)
+	})
+}
+
+func (a authzMiddleware) Authorize(action, resource string, next http.HandlerFunc) http.HandlerFunc {
+	return a.authorize(action, resource, next)
+}
+
+func (a authzMiddleware) authorize(action, resource string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := logger.WithKind(r.Context(), resource)
 
-		// Get username from context
 		username := GetUsernameFromContext(ctx)
 		if username == "" {
-			_ = fmt.Errorf("authenticated username not present in request context")
-			// TODO
-			//body := api.E500.Format(r, "Authentication details not present in context")
-			//api.SendError(w, r, &body)
+			handleError(ctx, w, errors.ErrorForbidden, "Authenticated username not present in request context")
 			return
 		}
 
-		allowed, err := a.apiClient.Authorization.AccessReview(
-			ctx, username, a.action, a.resourceType, "", "", "")
+		allowed, err := a.authorizer.Authorize(ctx, username, action, resource)
 		if err != nil {
-			_ = fmt.Errorf("unable to make authorization request: %s", err)
-			// TODO
-			//body := api.E500.Format(r, "Unable to make authorization request")
-			//api.SendError(w, r, &body)
+			handleError(ctx, w, errors.ErrorGeneral, fmt.Sprintf("Unable to authorize request: %s", err))
+			return
+		}
+		if !allowed {
+			handleError(ctx, w, errors.ErrorForbidden, fmt.Sprintf("User '%s' may not %s %s", username, action, resource))
 			return
 		}
 
-		if allowed {
-			next.ServeHTTP(w, r)
+		ctx, err = AuthorizeAnyOrganization(ctx, a.authorizer, username, resource)
+		if err != nil {
+			handleError(ctx, w, errors.ErrorGeneral, fmt.Sprintf("Unable to authorize request: %s", err))
+			return
 		}
 
-		// TODO
-		//body := api.E403.Format(r, "")
-		//api.SendError(w, r, &body)
-	})
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// actionAndResource derives the action and resource of a request from its method and path.
+// A GET of the collection itself is a list.
+func actionAndResource(r *http.Request) (string, string) {
+	path := r.URL.Path
+	if i := strings.Index(path, "/v1/"); i >= 0 {
+		path = path[i+len("/v1/"):]
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	resource := strings.SplitN(segments[0], ":", 2)[0]
+
+	action := ActionForMethod(r.Method)
+	if action == ActionGet && len(segments) == 1 {
+		action = ActionList
+	}
+	return action, resource
 }
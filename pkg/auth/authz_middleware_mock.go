@@ -3,7 +3,7 @@ package auth
 import (
 	"net/http"
 
-	"github.com/golang/glog"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 )
 
 type authzMiddlewareMock struct{}
@@ -16,7 +16,15 @@ func NewAuthzMiddlewareMock() AuthorizationMiddleware {
 
 func (a authzMiddlewareMock) AuthorizeApi(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		glog.Infof("Mock authz allows <any>/<any> for %q/%q", r.Method, r.URL)
+		logger.Infof("Mock authz allows <any>/<any> for %q/%q", r.Method, r.URL)
 		next.ServeHTTP(w, r)
 	})
 }
+
+func (a authzMiddlewareMock) Authorize(action, resource string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := logger.WithKind(r.Context(), resource)
+		logger.NewLogger(ctx).Infof("Mock authz allows %s/%s for %q/%q", action, resource, r.Method, r.URL)
+		next(w, r.WithContext(ctx))
+	}
+}
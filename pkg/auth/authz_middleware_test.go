@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthzMiddleware_Authorize(t *testing.T) {
+	middleware := NewAuthzMiddleware(NewPolicyAuthorizer(DefaultPolicy(), nil))
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	tests := []struct {
+		name     string
+		username string
+		action   string
+		expected int
+	}{
+		{name: "allowed", username: "alice", action: ActionGet, expected: http.StatusOK},
+		{name: "denied", username: "alice", action: "purge", expected: http.StatusForbidden},
+		{name: "unauthenticated", username: "", action: ActionGet, expected: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/rh-trex-ai/v1/dinosaurs/123", nil)
+			if tt.username != "" {
+				req = req.WithContext(SetUsernameContext(req.Context(), tt.username))
+			}
+			rr := httptest.NewRecorder()
+			middleware.Authorize(tt.action, "dinosaurs", ok)(rr, req)
+			if rr.Code != tt.expected {
+				t.Errorf("status = %d, want %d", rr.Code, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAuthzMiddleware_AnyOrganization(t *testing.T) {
+	policy := DefaultPolicy()
+	policy.Bindings = append(policy.Bindings, RoleBinding{Username: "root", Role: RoleAdmin})
+	middleware := NewAuthzMiddleware(NewPolicyAuthorizer(policy, nil))
+
+	for username, anyOrganization := range map[string]bool{"alice": false, "root": true} {
+		t.Run(username, func(t *testing.T) {
+			var tenant Tenant
+			next := func(w http.ResponseWriter, r *http.Request) {
+				tenant, _ = GetTenantFromContext(r.Context())
+			}
+			req := httptest.NewRequest(http.MethodGet, "/api/rh-trex-ai/v1/dinosaurs", nil)
+			ctx := SetUsernameContext(req.Context(), username)
+			ctx = SetTenantContext(ctx, Tenant{OrganizationID: "acme"})
+			middleware.Authorize(ActionList, "dinosaurs", next)(httptest.NewRecorder(), req.WithContext(ctx))
+			if tenant.OrganizationID != "acme" || tenant.AnyOrganization != anyOrganization {
+				t.Errorf("tenant = %+v, want acme with AnyOrganization=%v", tenant, anyOrganization)
+			}
+		})
+	}
+}
+
+func TestActionAndResource(t *testing.T) {
+	tests := []struct {
+		method   string
+		path     string
+		action   string
+		resource string
+	}{
+		{http.MethodGet, "/api/rh-trex-ai/v1/dinosaurs", ActionList, "dinosaurs"},
+		{http.MethodGet, "/api/rh-trex-ai/v1/dinosaurs/123", ActionGet, "dinosaurs"},
+		{http.MethodPost, "/api/rh-trex-ai/v1/dinosaurs", ActionCreate, "dinosaurs"},
+		{http.MethodPatch, "/api/rh-trex-ai/v1/dinosaurs/123", ActionUpdate, "dinosaurs"},
+		{http.MethodDelete, "/api/rh-trex-ai/v1/dinosaurs/123", ActionDelete, "dinosaurs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			action, resource := actionAndResource(httptest.NewRequest(tt.method, tt.path, nil))
+			if action != tt.action || resource != tt.resource {
+				t.Errorf("got %s %s, want %s %s", action, resource, tt.action, tt.resource)
+			}
+		})
+	}
+}
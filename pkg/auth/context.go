@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 )
 
 // Context key type defined to avoid collisions in other pkgs using context
@@ -30,9 +32,13 @@ type Payload struct {
 	Email     string `json:"email"`
 	Issuer    string `json:"iss"`
 	ClientID  string `json:"clientId"`
+	// OrganizationID is the tenant of the user, see OrganizationIDFromClaims
+	OrganizationID string `json:"org_id"`
 }
 
+// SetUsernameContext returns a context of the caller username, which the log lines logged with it carry too
 func SetUsernameContext(ctx context.Context, username string) context.Context {
+	ctx = logger.WithUsername(ctx, username)
 	return context.WithValue(ctx, ContextUsernameKey, username)
 }
 
@@ -80,6 +86,7 @@ func GetAuthPayloadFromContext(ctx context.Context) (*Payload, error) {
 	payload.LastName, _ = claims["last_name"].(string)
 	payload.Email, _ = claims["email"].(string)
 	payload.ClientID, _ = claims["clientId"].(string)
+	payload.OrganizationID = OrganizationIDFromClaims(claims)
 
 	// Check values, if empty, use alternative claims from RHD
 	if payload.Username == "" {
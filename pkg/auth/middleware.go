@@ -15,7 +15,8 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
-	"github.com/golang/glog"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 )
 
 // JWTHandler provides JWT authentication without OCM dependencies
@@ -88,7 +89,7 @@ func (j *JWTHandler) Build() (func(http.Handler) http.Handler, error) {
 			// Extract JWT token from Authorization header
 			token, err := j.extractToken(r)
 			if err != nil {
-				glog.Warningf("JWT extraction failed: %v", err)
+				logger.Warningf("JWT extraction failed: %v", err)
 				http.Error(w, "Unauthorized", http.StatusUnauthorized)
 				return
 			}
@@ -96,7 +97,7 @@ func (j *JWTHandler) Build() (func(http.Handler) http.Handler, error) {
 			// Validate and parse the JWT token
 			parsedToken, err := j.validateToken(token)
 			if err != nil {
-				glog.Warningf("JWT validation failed: %v", err)
+				logger.Warningf("JWT validation failed: %v", err)
 				http.Error(w, "Unauthorized", http.StatusUnauthorized)
 				return
 			}
@@ -210,12 +211,12 @@ func (j *JWTHandler) refreshKeysLoop() {
 		select {
 		case <-ticker.C:
 			if err := j.loadKeysFromURL(); err != nil {
-				glog.Warningf("Failed to refresh JWT keys: %v", err)
+				logger.Warningf("Failed to refresh JWT keys: %v", err)
 			} else {
-				glog.V(1).Info("JWT keys refreshed successfully")
+				logger.V(1).Info("JWT keys refreshed successfully")
 			}
 		case <-j.refreshStop:
-			glog.V(1).Info("Stopping JWT key refresh loop")
+			logger.V(1).Info("Stopping JWT key refresh loop")
 			return
 		}
 	}
@@ -228,7 +229,7 @@ func (j *JWTHandler) Stop() {
 
 // loadKeysFromURL fetches JWK keys from the specified URL
 func (j *JWTHandler) loadKeysFromURL() error {
-	glog.V(2).Infof("Loading JWT keys from URL: %s", j.keysURL)
+	logger.V(2).Infof("Loading JWT keys from URL: %s", j.keysURL)
 
 	resp, err := j.httpClient.Get(j.keysURL)
 	if err != nil {
@@ -251,7 +252,7 @@ func (j *JWTHandler) loadKeysFromURL() error {
 
 // loadKeysFromFile loads JWK keys from a local file
 func (j *JWTHandler) loadKeysFromFile() error {
-	glog.Infof("Loading JWT keys from file: %s", j.keysFile)
+	logger.Infof("Loading JWT keys from file: %s", j.keysFile)
 
 	data, err := os.ReadFile(j.keysFile)
 	if err != nil {
@@ -277,12 +278,12 @@ func (j *JWTHandler) parseJWKSet(jwkSet *JWKSet) error {
 
 		publicKey, err := j.jwkToRSAPublicKey(&jwk)
 		if err != nil {
-			glog.Warningf("Failed to convert JWK to RSA public key for kid %s: %v", jwk.Kid, err)
+			logger.Warningf("Failed to convert JWK to RSA public key for kid %s: %v", jwk.Kid, err)
 			continue
 		}
 
 		newKeys[jwk.Kid] = publicKey
-		glog.V(2).Infof("Loaded RSA public key with kid: %s", jwk.Kid)
+		logger.V(2).Infof("Loaded RSA public key with kid: %s", jwk.Kid)
 	}
 
 	if len(newKeys) == 0 {
@@ -294,7 +295,7 @@ func (j *JWTHandler) parseJWKSet(jwkSet *JWKSet) error {
 	j.publicKeys = newKeys
 	j.keysMutex.Unlock()
 
-	glog.Infof("Updated JWT keys: %d RSA keys loaded", len(newKeys))
+	logger.Infof("Updated JWT keys: %d RSA keys loaded", len(newKeys))
 	return nil
 }
 
@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ghodss/yaml"
+)
+
+// AllUsers binds a role to every authenticated user
+const AllUsers = "*"
+
+// Built-in roles of the default policy
+const (
+	RoleAdmin  = "admin"
+	RoleEditor = "editor"
+	RoleViewer = "viewer"
+)
+
+// Policy is the set of roles the policy engine knows and the role bindings that are part of the
+// configuration rather than stored in the database
+type Policy struct {
+	Roles    []Role        `json:"roles"`
+	Bindings []RoleBinding `json:"bindings"`
+}
+
+// Role is a named set of rules
+type Role struct {
+	Name  string `json:"name"`
+	Rules []Rule `json:"rules"`
+}
+
+// Rule allows actions on resources. "*" matches any action or resource, and Except removes resources
+// from a "*" match.
+type Rule struct {
+	Resources []string `json:"resources"`
+	Actions   []string `json:"actions"`
+	Except    []string `json:"except,omitempty"`
+}
+
+// RoleBinding grants a role to a user, or to every authenticated user with AllUsers
+type RoleBinding struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+// RoleBindingStore looks up the roles bound to a user outside the policy, e.g. in the database
+type RoleBindingStore interface {
+	RolesForUser(ctx context.Context, username string) ([]string, error)
+}
+
+// DefaultPolicy lets every authenticated user work with the resources of the API, and leaves
+// role bindings, the event queue, the audit trail, webhooks and the log level to admins
+func DefaultPolicy() *Policy {
+	adminOnly := []string{"role_bindings", "events", "audit_events", "webhooks", "loglevel"}
+	return &Policy{
+		Roles: []Role{
+			{Name: RoleAdmin, Rules: []Rule{{Resources: []string{"*"}, Actions: []string{"*"}}}},
+			{Name: RoleEditor, Rules: []Rule{{
+				Resources: []string{"*"},
+				Actions:   []string{ActionGet, ActionList, ActionWatch, ActionCreate, ActionUpdate, ActionDelete, "restore"},
+				Except:    adminOnly,
+			}}},
+			{Name: RoleViewer, Rules: []Rule{{
+				Resources: []string{"*"},
+				Actions:   []string{ActionGet, ActionList, ActionWatch},
+				Except:    adminOnly,
+			}}},
+		},
+		Bindings: []RoleBinding{{Username: AllUsers, Role: RoleEditor}},
+	}
+}
+
+// LoadPolicy reads a YAML or JSON policy file, or returns the default policy if file is empty
+func LoadPolicy(file string) (*Policy, error) {
+	if file == "" {
+		return DefaultPolicy(), nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read authorization policy %s: %v", file, err)
+	}
+	policy := &Policy{}
+	if err := yaml.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("unable to parse authorization policy %s: %v", file, err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid authorization policy %s: %v", file, err)
+	}
+	return policy, nil
+}
+
+// Validate checks that every role has a name and every binding refers to a known role
+func (p *Policy) Validate() error {
+	roles := map[string]bool{}
+	for _, role := range p.Roles {
+		if role.Name == "" {
+			return fmt.Errorf("role without a name")
+		}
+		roles[role.Name] = true
+	}
+	for _, binding := range p.Bindings {
+		if !roles[binding.Role] {
+			return fmt.Errorf("binding of %q refers to unknown role %q", binding.Username, binding.Role)
+		}
+	}
+	return nil
+}
+
+type policyAuthorizer struct {
+	roles    map[string]Role
+	bindings []RoleBinding
+	store    RoleBindingStore
+}
+
+var _ Authorizer = &policyAuthorizer{}
+
+// NewPolicyAuthorizer returns the built-in policy engine. Roles bound in store apply in addition to the
+// bindings of the policy; store may be nil.
+func NewPolicyAuthorizer(policy *Policy, store RoleBindingStore) Authorizer {
+	roles := map[string]Role{}
+	for _, role := range policy.Roles {
+		roles[role.Name] = role
+	}
+	return &policyAuthorizer{
+		roles:    roles,
+		bindings: policy.Bindings,
+		store:    store,
+	}
+}
+
+func (a *policyAuthorizer) Authorize(ctx context.Context, username, action, resource string) (bool, error) {
+	if username == "" {
+		return false, nil
+	}
+
+	var roles []string
+	for _, binding := range a.bindings {
+		if binding.Username == username || binding.Username == AllUsers {
+			roles = append(roles, binding.Role)
+		}
+	}
+	if a.store != nil {
+		bound, err := a.store.RolesForUser(ctx, username)
+		if err != nil {
+			return false, err
+		}
+		roles = append(roles, bound...)
+	}
+
+	for _, name := range roles {
+		role, ok := a.roles[name]
+		if !ok {
+			continue
+		}
+		for _, rule := range role.Rules {
+			if rule.allows(action, resource) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (r Rule) allows(action, resource string) bool {
+	if contains(r.Except, resource) {
+		return false
+	}
+	return matches(r.Resources, resource) && matches(r.Actions, action)
+}
+
+func matches(patterns []string, value string) bool {
+	return contains(patterns, "*") || contains(patterns, value)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type roleBindingStoreMock map[string][]string
+
+func (m roleBindingStoreMock) RolesForUser(ctx context.Context, username string) ([]string, error) {
+	return m[username], nil
+}
+
+func TestPolicyAuthorizer_Authorize(t *testing.T) {
+	authorizer := NewPolicyAuthorizer(DefaultPolicy(), roleBindingStoreMock{"root": {RoleAdmin}})
+
+	tests := []struct {
+		name     string
+		username string
+		action   string
+		resource string
+		expected bool
+	}{
+		{
+			name:     "every user edits kinds",
+			username: "alice",
+			action:   ActionUpdate,
+			resource: "dinosaurs",
+			expected: true,
+		},
+		{
+			name:     "restoring is part of editing",
+			username: "alice",
+			action:   "restore",
+			resource: "dinosaurs",
+			expected: true,
+		},
+		{
+			name:     "purging needs an admin",
+			username: "alice",
+			action:   "purge",
+			resource: "dinosaurs",
+			expected: false,
+		},
+		{
+			name:     "role bindings are admin only",
+			username: "alice",
+			action:   ActionList,
+			resource: "role_bindings",
+			expected: false,
+		},
+		{
+			name:     "roles bound in the store apply",
+			username: "root",
+			action:   ActionCreate,
+			resource: "role_bindings",
+			expected: true,
+		},
+		{
+			name:     "anonymous callers are denied",
+			username: "",
+			action:   ActionGet,
+			resource: "dinosaurs",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, err := authorizer.Authorize(context.Background(), tt.username, tt.action, tt.resource)
+			if err != nil {
+				t.Fatalf("Authorize() error = %v", err)
+			}
+			if allowed != tt.expected {
+				t.Errorf("Authorize(%q, %q, %q) = %v, want %v", tt.username, tt.action, tt.resource, allowed, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	dir := t.TempDir()
+
+	file := filepath.Join(dir, "policy.yaml")
+	data := `
+roles:
+- name: fossil-viewer
+  rules:
+  - resources: ["fossils"]
+    actions: ["get", "list"]
+bindings:
+- username: "*"
+  role: fossil-viewer
+`
+	if err := os.WriteFile(file, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	policy, err := LoadPolicy(file)
+	if err != nil {
+		t.Fatalf("LoadPolicy() error = %v", err)
+	}
+	authorizer := NewPolicyAuthorizer(policy, nil)
+	if allowed, _ := authorizer.Authorize(context.Background(), "alice", ActionList, "fossils"); !allowed {
+		t.Error("expected fossils to be listable")
+	}
+	if allowed, _ := authorizer.Authorize(context.Background(), "alice", ActionList, "dinosaurs"); allowed {
+		t.Error("expected dinosaurs not to be listable")
+	}
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalid, []byte("bindings:\n- username: alice\n  role: missing\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadPolicy(invalid); err == nil {
+		t.Error("expected a binding to an unknown role to be rejected")
+	}
+
+	policy, err = LoadPolicy("")
+	if err != nil || len(policy.Roles) != 3 {
+		t.Errorf("expected the default policy without a file, got %v, %v", policy, err)
+	}
+}
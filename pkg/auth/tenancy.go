@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// ActionAnyOrganization lifts the tenancy scope of a request, so the caller works with the rows of
+// every organization. Only roles that allow it, e.g. admin, get past their own organization.
+const ActionAnyOrganization = "any_organization"
+
+const ContextTenantKey contextKey = "tenant"
+
+// Tenant is the organization the rows a request reads and writes are scoped to
+type Tenant struct {
+	OrganizationID string
+	// AnyOrganization is set for callers allowed ActionAnyOrganization on the requested resource
+	AnyOrganization bool
+}
+
+// SetTenantContext scopes the request of ctx to tenant. Requests without a tenant, e.g. those of
+// controllers or with authentication disabled, are not scoped.
+func SetTenantContext(ctx context.Context, tenant Tenant) context.Context {
+	return context.WithValue(ctx, ContextTenantKey, tenant)
+}
+
+func GetTenantFromContext(ctx context.Context) (Tenant, bool) {
+	tenant, ok := ctx.Value(ContextTenantKey).(Tenant)
+	return tenant, ok
+}
+
+// InTenantScope tells whether the rows of organizationID are within the tenancy scope of ctx, like
+// dao.TenantScope decides for the rows a query reads
+func InTenantScope(ctx context.Context, organizationID string) bool {
+	tenant, ok := GetTenantFromContext(ctx)
+	return !ok || tenant.AnyOrganization || tenant.OrganizationID == organizationID
+}
+
+// AuthorizeAnyOrganization lifts the tenancy scope of ctx if username may work with resource across
+// organizations, and returns ctx unchanged otherwise
+func AuthorizeAnyOrganization(ctx context.Context, authorizer Authorizer, username, resource string) (context.Context, error) {
+	tenant, ok := GetTenantFromContext(ctx)
+	if !ok || tenant.AnyOrganization {
+		return ctx, nil
+	}
+	allowed, err := authorizer.Authorize(ctx, username, ActionAnyOrganization, resource)
+	if err != nil {
+		return ctx, err
+	}
+	if !allowed {
+		return ctx, nil
+	}
+	tenant.AnyOrganization = true
+	return SetTenantContext(ctx, tenant), nil
+}
+
+// OrganizationIDFromClaims returns the organization of a token, "org_id" in RHSSO tokens
+// and "organization_id" in others. Tokens without one belong to the empty organization.
+func OrganizationIDFromClaims(claims jwt.MapClaims) string {
+	orgID, _ := claims["org_id"].(string)
+	if orgID == "" {
+		orgID, _ = claims["organization_id"].(string)
+	}
+	return orgID
+}
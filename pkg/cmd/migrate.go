@@ -4,12 +4,12 @@ import (
 	"context"
 	"flag"
 
-	"github.com/golang/glog"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/config"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
 	"github.com/openshift-online/rh-trex-ai/pkg/db/db_session"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 )
 
 func NewMigrateCommand(serviceName string) *cobra.Command {
@@ -22,12 +22,12 @@ func NewMigrateCommand(serviceName string) *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			err := dbConfig.ReadFiles()
 			if err != nil {
-				glog.Fatal(err)
+				logger.Fatalf("%v", err)
 			}
 
 			connection := db_session.NewProdFactory(dbConfig)
 			if err := db.Migrate(connection.New(context.Background())); err != nil {
-				glog.Fatal(err)
+				logger.Fatalf("%v", err)
 			}
 		},
 	}
@@ -8,10 +8,10 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/golang/glog"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/environments"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 	pkgserver "github.com/openshift-online/rh-trex-ai/pkg/server"
 )
 
@@ -26,7 +26,7 @@ func NewServeCommand(getSpecData func() ([]byte, error)) *cobra.Command {
 	}
 	err := environments.Environment().AddFlags(cmd.PersistentFlags())
 	if err != nil {
-		glog.Fatalf("Unable to add environment flags to serve command: %s", err.Error())
+		logger.Fatalf("Unable to add environment flags to serve command: %s", err.Error())
 	}
 
 	return cmd
@@ -36,12 +36,12 @@ func runServe(getSpecData func() ([]byte, error)) {
 	env := environments.Environment()
 	err := env.Initialize()
 	if err != nil {
-		glog.Fatalf("Unable to initialize environment: %s", err.Error())
+		logger.Fatalf("Unable to initialize environment: %s", err.Error())
 	}
 
 	specData, err := getSpecData()
 	if err != nil {
-		glog.Fatalf("Unable to load OpenAPI spec: %s", err.Error())
+		logger.Fatalf("Unable to load OpenAPI spec: %s", err.Error())
 	}
 
 	var servers []pkgserver.Server
@@ -70,13 +70,13 @@ func runServe(getSpecData func() ([]byte, error)) {
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGTERM, syscall.SIGINT)
 	sig := <-sigCh
-	glog.Infof("Received signal %v, shutting down", sig)
+	logger.Infof("Received signal %v, shutting down", sig)
 
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	controllersServer.Stop()
-	glog.Info("Controllers server stopped")
+	logger.Infof("Controllers server stopped")
 
 	var wg sync.WaitGroup
 	for _, s := range servers {
@@ -84,7 +84,7 @@ func runServe(getSpecData func() ([]byte, error)) {
 		go func(srv pkgserver.Server) {
 			defer wg.Done()
 			if err := srv.Stop(); err != nil {
-				glog.Errorf("Error stopping server: %v", err)
+				logger.Errorf("Error stopping server: %v", err)
 			}
 		}(s)
 	}
@@ -97,11 +97,11 @@ func runServe(getSpecData func() ([]byte, error)) {
 
 	select {
 	case <-doneCh:
-		glog.Info("All servers stopped gracefully")
+		logger.Infof("All servers stopped gracefully")
 	case <-shutdownCtx.Done():
-		glog.Warning("Shutdown timed out, forcing exit")
+		logger.Warningf("Shutdown timed out, forcing exit")
 	}
 
 	env.Database.SessionFactory.Close()
-	glog.Info("Database connections closed")
+	logger.Infof("Database connections closed")
 }
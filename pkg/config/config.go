@@ -22,6 +22,9 @@ type ApplicationConfig struct {
 	HealthCheck *HealthCheckConfig `json:"health_check"`
 	Database    *DatabaseConfig    `json:"database"`
 	APIClient   *APIClientConfig   `json:"api_client"`
+	Tracing     *TracingConfig     `json:"tracing"`
+	Logging     *LoggingConfig     `json:"logging"`
+	RateLimit   *RateLimitConfig   `json:"rate_limit"`
 }
 
 func NewApplicationConfig() *ApplicationConfig {
@@ -32,6 +35,9 @@ func NewApplicationConfig() *ApplicationConfig {
 		HealthCheck: NewHealthCheckConfig(),
 		Database:    NewDatabaseConfig(),
 		APIClient:   NewAPIClientConfig(),
+		Tracing:     NewTracingConfig(),
+		Logging:     NewLoggingConfig(),
+		RateLimit:   NewRateLimitConfig(),
 	}
 }
 
@@ -43,6 +49,9 @@ func (c *ApplicationConfig) AddFlags(flagset *pflag.FlagSet) {
 	c.HealthCheck.AddFlags(flagset)
 	c.Database.AddFlags(flagset)
 	c.APIClient.AddFlags(flagset)
+	c.Tracing.AddFlags(flagset)
+	c.Logging.AddFlags(flagset)
+	c.RateLimit.AddFlags(flagset)
 }
 
 func (c *ApplicationConfig) ReadFiles() []string {
@@ -56,6 +65,9 @@ func (c *ApplicationConfig) ReadFiles() []string {
 		{c.APIClient.ReadFiles, "APIClient"},
 		{c.Metrics.ReadFiles, "Metrics"},
 		{c.HealthCheck.ReadFiles, "HealthCheck"},
+		{c.Tracing.ReadFiles, "Tracing"},
+		{c.Logging.ReadFiles, "Logging"},
+		{c.RateLimit.ReadFiles, "RateLimit"},
 	}
 	var messages []string
 	for _, rf := range readFiles {
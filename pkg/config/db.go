@@ -11,6 +11,11 @@ type DatabaseConfig struct {
 	SSLMode            string `json:"sslmode"`
 	Debug              bool   `json:"debug"`
 	MaxOpenConnections int    `json:"max_connections"`
+	EventBus           string `json:"event_bus"`
+	// EventBusURL is the server of the redis event bus, EventBusPassword is read from EventBusPasswordFile
+	EventBusURL          string `json:"event_bus_url"`
+	EventBusPassword     string `json:"event_bus_password"`
+	EventBusPasswordFile string `json:"event_bus_password_file"`
 
 	Host     string `json:"host"`
 	Port     int    `json:"port"`
@@ -32,6 +37,8 @@ func NewDatabaseConfig() *DatabaseConfig {
 		SSLMode:            "disable",
 		Debug:              false,
 		MaxOpenConnections: 50,
+		EventBus:           "postgres",
+		EventBusURL:        "redis://localhost:6379",
 
 		HostFile:     "secrets/db.host",
 		PortFile:     "secrets/db.port",
@@ -52,6 +59,9 @@ func (c *DatabaseConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&c.SSLMode, "db-sslmode", c.SSLMode, "Database ssl mode (disable | require | verify-ca | verify-full)")
 	fs.BoolVar(&c.Debug, "enable-db-debug", c.Debug, " framework's debug mode")
 	fs.IntVar(&c.MaxOpenConnections, "db-max-open-connections", c.MaxOpenConnections, "Maximum open DB connections for this instance")
+	fs.StringVar(&c.EventBus, "event-bus", c.EventBus, "Event bus announcing committed events to the controllers and watches (postgres | memory | redis)")
+	fs.StringVar(&c.EventBusURL, "event-bus-url", c.EventBusURL, "Server of the redis event bus (redis://host:port | rediss://host:port)")
+	fs.StringVar(&c.EventBusPasswordFile, "event-bus-password-file", c.EventBusPasswordFile, "Password file of the redis event bus, if it requires one")
 }
 
 func (c *DatabaseConfig) ReadFiles() error {
@@ -76,6 +86,13 @@ func (c *DatabaseConfig) ReadFiles() error {
 	}
 
 	err = readFileValueString(c.NameFile, &c.Name)
+	if err != nil {
+		return err
+	}
+
+	if c.EventBusPasswordFile != "" {
+		err = readFileValueString(c.EventBusPasswordFile, &c.EventBusPassword)
+	}
 	return err
 }
 
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"github.com/spf13/pflag"
+)
+
+type LoggingConfig struct {
+	// Level is a level name, DEBUG, INFO, WARN or ERROR, or a verbosity like 4 or V4
+	Level string `json:"level"`
+	// Format is json or text
+	Format string `json:"format"`
+}
+
+func NewLoggingConfig() *LoggingConfig {
+	return &LoggingConfig{
+		Level:  "INFO",
+		Format: "text",
+	}
+}
+
+func (c *LoggingConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.Level, "log-level", c.Level, "Lowest level logged: DEBUG, INFO, WARN, ERROR, or a verbosity such as 4, which also logs the messages of V(1) to V(4)")
+	fs.StringVar(&c.Format, "log-format", c.Format, "Format of the log lines (text | json)")
+}
+
+func (c *LoggingConfig) ReadFiles() error {
+	return nil
+}
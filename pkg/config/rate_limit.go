@@ -0,0 +1,52 @@
+package config
+
+import (
+	"github.com/spf13/pflag"
+)
+
+type RateLimitConfig struct {
+	Enabled bool `json:"enabled"`
+	// Backend keeps the token buckets: memory, per replica, or postgres, shared by every replica
+	Backend string `json:"backend"`
+	// Key is who a bucket belongs to: user, organization or ip. Unauthenticated requests are keyed by ip.
+	Key string `json:"key"`
+	// Rate is the number of requests per second a client may sustain, Burst the number it may send at once
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+	// Routes and Kinds override the default limit, as <rate>:<burst>. Routes are REST route templates prefixed
+	// with their method, e.g. "POST /api/rh-trex-ai/v1/dinosaurs", or gRPC full method names. Kinds are
+	// collections, e.g. dinosaurs.
+	Routes map[string]string `json:"routes"`
+	Kinds  map[string]string `json:"kinds"`
+	// TrustedProxies are the addresses or CIDRs of the proxies in front of the service. The address of a
+	// client that comes through one of them is taken from the X-Forwarded-For or Forwarded header.
+	TrustedProxies []string `json:"trusted_proxies"`
+}
+
+func NewRateLimitConfig() *RateLimitConfig {
+	return &RateLimitConfig{
+		Enabled:        false,
+		Backend:        "memory",
+		Key:            "user",
+		Rate:           10,
+		Burst:          20,
+		Routes:         map[string]string{},
+		Kinds:          map[string]string{},
+		TrustedProxies: []string{},
+	}
+}
+
+func (c *RateLimitConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&c.Enabled, "enable-rate-limit", c.Enabled, "Limit the rate of the REST requests and gRPC calls of every client")
+	fs.StringVar(&c.Backend, "rate-limit-backend", c.Backend, "Where the rate limits are counted (memory | postgres), postgres shares them across replicas")
+	fs.StringVar(&c.Key, "rate-limit-key", c.Key, "Who a rate limit applies to (user | organization | ip), unauthenticated requests are limited by ip")
+	fs.Float64Var(&c.Rate, "rate-limit-rate", c.Rate, "Requests per second a client may sustain")
+	fs.IntVar(&c.Burst, "rate-limit-burst", c.Burst, "Requests a client may send at once")
+	fs.StringToStringVar(&c.Routes, "rate-limit-routes", c.Routes, "Limits of routes as <rate>:<burst>, e.g. \"POST /api/rh-trex-ai/v1/dinosaurs=1:5\" or a gRPC full method name")
+	fs.StringToStringVar(&c.Kinds, "rate-limit-kinds", c.Kinds, "Limits of Kinds as <rate>:<burst>, e.g. dinosaurs=5:10")
+	fs.StringSliceVar(&c.TrustedProxies, "rate-limit-trusted-proxies", c.TrustedProxies, "Addresses or CIDRs of the proxies whose X-Forwarded-For or Forwarded header gives the address of the client")
+}
+
+func (c *RateLimitConfig) ReadFiles() error {
+	return nil
+}
@@ -19,24 +19,37 @@ type ServerConfig struct {
 	JwkCertFile        string        `json:"jwk_cert_file"`
 	JwkCertURL         string        `json:"jwk_cert_url"`
 	ACLFile            string        `json:"acl_file"`
+	AuthzPolicyFile    string        `json:"authz_policy_file"`
 	CORSAllowedOrigins []string      `json:"cors_allowed_origins"`
 	CORSAllowedHeaders []string      `json:"cors_allowed_headers"`
+	IdempotencyKeyTTL  time.Duration `json:"idempotency_key_ttl"`
+	AuditRetention     time.Duration `json:"audit_retention"`
+	// EventRetention is how long reconciled events are kept, EventArchive moves them to the event_archives
+	// table once it has passed rather than deleting them
+	EventRetention          time.Duration `json:"event_retention"`
+	EventArchive            bool          `json:"event_archive"`
+	EventCompactionInterval time.Duration `json:"event_compaction_interval"`
 }
 
 func NewServerConfig() *ServerConfig {
 	return &ServerConfig{
-		Hostname:      "",
-		BindAddress:   "localhost:8000",
-		ReadTimeout:   5 * time.Second,
-		WriteTimeout:  30 * time.Second,
-		EnableHTTPS:   false,
-		EnableJWT:     true,
-		EnableAuthz:   true,
-		JwkCertFile:   "",
-		JwkCertURL:    "https://sso.redhat.com/auth/realms/redhat-external/protocol/openid-connect/certs", // Default to Red Hat SSO, configurable for other OIDC providers
-		ACLFile:       "",
-		HTTPSCertFile: "",
-		HTTPSKeyFile:  "",
+		Hostname:                "",
+		BindAddress:             "localhost:8000",
+		ReadTimeout:             5 * time.Second,
+		WriteTimeout:            30 * time.Second,
+		EnableHTTPS:             false,
+		EnableJWT:               true,
+		EnableAuthz:             true,
+		JwkCertFile:             "",
+		JwkCertURL:              "https://sso.redhat.com/auth/realms/redhat-external/protocol/openid-connect/certs", // Default to Red Hat SSO, configurable for other OIDC providers
+		ACLFile:                 "",
+		HTTPSCertFile:           "",
+		HTTPSKeyFile:            "",
+		IdempotencyKeyTTL:       24 * time.Hour,
+		AuditRetention:          365 * 24 * time.Hour,
+		EventRetention:          7 * 24 * time.Hour,
+		EventArchive:            false,
+		EventCompactionInterval: 10 * time.Minute,
 	}
 }
 
@@ -53,8 +66,14 @@ func (s *ServerConfig) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.JwkCertFile, "jwk-cert-file", s.JwkCertFile, "JWK Certificate file")
 	fs.StringVar(&s.JwkCertURL, "jwk-cert-url", s.JwkCertURL, "JWK Certificate URL")
 	fs.StringVar(&s.ACLFile, "acl-file", s.ACLFile, "Access control list file")
+	fs.StringVar(&s.AuthzPolicyFile, "authz-policy-file", s.AuthzPolicyFile, "Authorization policy file with roles and role bindings, the built-in policy is used if empty")
 	fs.StringSliceVar(&s.CORSAllowedOrigins, "cors-allowed-origins", s.CORSAllowedOrigins, "Comma-separated list of CORS allowed origins")
 	fs.StringSliceVar(&s.CORSAllowedHeaders, "cors-allowed-headers", s.CORSAllowedHeaders, "Comma-separated list of additional CORS allowed headers")
+	fs.DurationVar(&s.IdempotencyKeyTTL, "idempotency-key-ttl", s.IdempotencyKeyTTL, "How long the response to a request with an Idempotency-Key is replayed to retries")
+	fs.DurationVar(&s.AuditRetention, "audit-retention", s.AuditRetention, "How long audit events are kept, 0 keeps them forever")
+	fs.DurationVar(&s.EventRetention, "event-retention", s.EventRetention, "How long reconciled events are kept, 0 keeps them forever")
+	fs.BoolVar(&s.EventArchive, "event-archive", s.EventArchive, "Move reconciled events past their retention to the event_archives table rather than deleting them")
+	fs.DurationVar(&s.EventCompactionInterval, "event-compaction-interval", s.EventCompactionInterval, "Interval between the runs of the event compaction controller")
 }
 
 func (s *ServerConfig) ReadFiles() error {
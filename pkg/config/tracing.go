@@ -0,0 +1,36 @@
+package config
+
+import (
+	"github.com/spf13/pflag"
+)
+
+type TracingConfig struct {
+	// Exporter is where the spans go: none, otlp or memory
+	Exporter    string  `json:"exporter"`
+	Endpoint    string  `json:"endpoint"`
+	Insecure    bool    `json:"insecure"`
+	SampleRatio float64 `json:"sample_ratio"`
+	ServiceName string  `json:"service_name"`
+}
+
+func NewTracingConfig() *TracingConfig {
+	return &TracingConfig{
+		Exporter:    "none",
+		Endpoint:    "localhost:4318",
+		Insecure:    false,
+		SampleRatio: 1,
+		ServiceName: "rh-trex-ai",
+	}
+}
+
+func (c *TracingConfig) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&c.Exporter, "tracing-exporter", c.Exporter, "OpenTelemetry span exporter (none | otlp | memory)")
+	fs.StringVar(&c.Endpoint, "tracing-endpoint", c.Endpoint, "host:port of the OTLP/HTTP collector receiving the spans")
+	fs.BoolVar(&c.Insecure, "tracing-insecure", c.Insecure, "Send the spans to the OTLP collector over plain HTTP")
+	fs.Float64Var(&c.SampleRatio, "tracing-sample-ratio", c.SampleRatio, "Ratio of the traces started by this service that are sampled, from 0 to 1")
+	fs.StringVar(&c.ServiceName, "tracing-service-name", c.ServiceName, "service.name of the spans")
+}
+
+func (c *TracingConfig) ReadFiles() error {
+	return nil
+}
@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"time"
+)
+
+// BackoffPolicy decides when a failed event is retried and when it is dead-lettered.
+// The delay before retry n (1-based) is InitialInterval * Multiplier^(n-1), capped at MaxInterval.
+type BackoffPolicy struct {
+	// InitialInterval is the delay after the first failure (default: 10 seconds)
+	InitialInterval time.Duration
+	// MaxInterval caps the delay between attempts (default: 1 hour)
+	MaxInterval time.Duration
+	// Multiplier grows the delay after every failure (default: 2)
+	Multiplier float64
+	// MaxAttempts is the number of failed attempts after which the event is dead-lettered (default: 10)
+	MaxAttempts int
+}
+
+func DefaultBackoffPolicy() *BackoffPolicy {
+	return &BackoffPolicy{
+		InitialInterval: 10 * time.Second,
+		MaxInterval:     1 * time.Hour,
+		Multiplier:      2,
+		MaxAttempts:     10,
+	}
+}
+
+// withDefaults returns a copy of the policy with unset fields filled in from DefaultBackoffPolicy
+func (p *BackoffPolicy) withDefaults() *BackoffPolicy {
+	defaults := DefaultBackoffPolicy()
+	if p == nil {
+		return defaults
+	}
+	policy := *p
+	if policy.InitialInterval <= 0 {
+		policy.InitialInterval = defaults.InitialInterval
+	}
+	if policy.MaxInterval <= 0 {
+		policy.MaxInterval = defaults.MaxInterval
+	}
+	if policy.Multiplier < 1 {
+		policy.Multiplier = defaults.Multiplier
+	}
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = defaults.MaxAttempts
+	}
+	return &policy
+}
+
+// Exhausted returns true when an event that failed the given number of times should be dead-lettered
+func (p *BackoffPolicy) Exhausted(attempts int) bool {
+	return attempts >= p.MaxAttempts
+}
+
+// Next returns the delay before the next attempt of an event that failed the given number of times
+func (p *BackoffPolicy) Next(attempts int) time.Duration {
+	delay := float64(p.InitialInterval)
+	for i := 1; i < attempts; i++ {
+		delay *= p.Multiplier
+		if delay >= float64(p.MaxInterval) {
+			return p.MaxInterval
+		}
+	}
+	if delay > float64(p.MaxInterval) {
+		return p.MaxInterval
+	}
+	return time.Duration(delay)
+}
@@ -0,0 +1,197 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
+
+const compactionLockType db.LockType = "event_compaction"
+
+// CompactionController keeps the events table from growing unbounded. Every cycle it collapses the pending
+// Update events of a resource into the latest one and removes the events reconciled longer than the retention
+// ago, deleting or archiving them. A cycle runs under a fail-fast advisory lock, so only one replica compacts
+// at a time.
+type CompactionController struct {
+	lockFactory  db.LockFactory
+	eventService services.EventService
+	interval     time.Duration
+	retention    time.Duration
+	archive      bool
+	cancel       context.CancelFunc
+	done         chan struct{}
+	startOnce    sync.Once
+	metrics      *compactionMetrics
+}
+
+type CompactionControllerConfig struct {
+	// Interval between compaction runs (default: 10 minutes)
+	Interval time.Duration
+	// Retention is how long reconciled events are kept, 0 keeps them forever
+	Retention time.Duration
+	// Archive moves reconciled events past their retention to the event_archives table rather than deleting them
+	Archive bool
+}
+
+type compactionMetrics struct {
+	rowsRemoved        *prometheus.CounterVec
+	compactionErrors   prometheus.Counter
+	compactionDuration prometheus.Histogram
+}
+
+func newCompactionMetrics() *compactionMetrics {
+	return &compactionMetrics{
+		rowsRemoved: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "controller_event_compaction_rows_total",
+			Help: "Total number of events removed by the compaction controller, by action (deleted, archived or collapsed)",
+		}, []string{"action"}),
+		compactionErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "controller_event_compaction_errors_total",
+			Help: "Total number of failed event compaction runs",
+		}),
+		compactionDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "controller_event_compaction_duration_seconds",
+			Help:    "Duration of event compaction runs",
+			Buckets: []float64{0.1, 0.5, 1, 2, 5, 10, 30, 60, 120},
+		}),
+	}
+}
+
+func (m *compactionMetrics) Register() {
+	prometheus.MustRegister(m.rowsRemoved)
+	prometheus.MustRegister(m.compactionErrors)
+	prometheus.MustRegister(m.compactionDuration)
+}
+
+// NewCompactionController creates a new compaction controller with the given configuration
+func NewCompactionController(lockFactory db.LockFactory, eventService services.EventService, config CompactionControllerConfig) *CompactionController {
+	return newCompactionController(lockFactory, eventService, config, true)
+}
+
+// NewCompactionControllerForTesting creates a compaction controller without registering Prometheus metrics
+func NewCompactionControllerForTesting(lockFactory db.LockFactory, eventService services.EventService, config CompactionControllerConfig) *CompactionController {
+	return newCompactionController(lockFactory, eventService, config, false)
+}
+
+func newCompactionController(lockFactory db.LockFactory, eventService services.EventService, config CompactionControllerConfig, registerMetrics bool) *CompactionController {
+	if config.Interval == 0 {
+		config.Interval = 10 * time.Minute
+	}
+
+	metrics := newCompactionMetrics()
+	if registerMetrics {
+		metrics.Register()
+	}
+
+	return &CompactionController{
+		lockFactory:  lockFactory,
+		eventService: eventService,
+		interval:     config.Interval,
+		retention:    config.Retention,
+		archive:      config.Archive,
+		done:         make(chan struct{}),
+		metrics:      metrics,
+	}
+}
+
+// Start begins the periodic compaction process
+func (cc *CompactionController) Start() {
+	cc.startOnce.Do(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cc.cancel = cancel
+
+		log := logger.NewLogger(ctx)
+		log.Infof("Starting event compaction controller with interval=%v, retention=%v, archive=%t",
+			cc.interval, cc.retention, cc.archive)
+
+		go cc.compactionLoop(ctx)
+	})
+}
+
+// Stop gracefully shuts down the compaction controller
+func (cc *CompactionController) Stop() error {
+	if cc.cancel != nil {
+		cc.cancel()
+		<-cc.done
+	}
+	return nil
+}
+
+func (cc *CompactionController) compactionLoop(ctx context.Context) {
+	defer close(cc.done)
+
+	log := logger.NewLogger(ctx)
+	ticker := time.NewTicker(cc.interval)
+	defer ticker.Stop()
+
+	cc.compact(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Event compaction controller shutting down")
+			return
+		case <-ticker.C:
+			cc.compact(ctx)
+		}
+	}
+}
+
+// compact runs one compaction cycle
+func (cc *CompactionController) compact(ctx context.Context) {
+	log := logger.NewLogger(ctx)
+
+	lockOwnerID, acquired, err := cc.lockFactory.NewNonBlockingLock(ctx, "events", compactionLockType)
+	defer cc.lockFactory.Unlock(ctx, lockOwnerID)
+	if err != nil {
+		cc.metrics.compactionErrors.Inc()
+		log.Error(fmt.Sprintf("Error obtaining the event compaction lock: %v", err))
+		return
+	}
+	if !acquired {
+		log.V(2).Infof("Events are compacted by another worker")
+		return
+	}
+
+	start := time.Now()
+	defer func() {
+		cc.metrics.compactionDuration.Observe(time.Since(start).Seconds())
+	}()
+
+	collapsed, svcErr := cc.eventService.CollapseUpdates(ctx)
+	cc.metrics.rowsRemoved.WithLabelValues("collapsed").Add(float64(collapsed))
+	if svcErr != nil {
+		cc.metrics.compactionErrors.Inc()
+		log.Error(fmt.Sprintf("Failed to collapse update events: %v", svcErr))
+	}
+	if collapsed > 0 {
+		log.Infof("Collapsed %d redundant update events", collapsed)
+	}
+
+	// a retention of 0 keeps the reconciled events forever
+	if cc.retention <= 0 {
+		return
+	}
+	action := "deleted"
+	if cc.archive {
+		action = "archived"
+	}
+	cutoff := time.Now().Add(-cc.retention)
+	removed, svcErr := cc.eventService.PurgeReconciled(ctx, cutoff, cc.archive)
+	cc.metrics.rowsRemoved.WithLabelValues(action).Add(float64(removed))
+	if svcErr != nil {
+		cc.metrics.compactionErrors.Inc()
+		log.Error(fmt.Sprintf("Failed to remove events reconciled before %v: %v", cutoff, svcErr))
+		return
+	}
+	if removed > 0 {
+		log.Infof("Removed %d events reconciled before %v, %s", removed, cutoff, action)
+	}
+}
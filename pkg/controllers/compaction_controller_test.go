@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao/mocks"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
+
+func newCompactionEvents(eventDao dao.EventDao) {
+	now := time.Now()
+	old := now.Add(-48 * time.Hour)
+	_, _ = eventDao.CreateBatch(context.Background(), api.EventList{
+		{Meta: api.Meta{ID: "old", CreatedAt: old}, Source: "Dinosaurs", SourceID: "a", EventType: api.CreateEventType, ReconciledDate: &old},
+		{Meta: api.Meta{ID: "recent", CreatedAt: now}, Source: "Dinosaurs", SourceID: "a", EventType: api.UpdateEventType, ReconciledDate: &now},
+		{Meta: api.Meta{ID: "u1", CreatedAt: now.Add(-2 * time.Minute)}, Source: "Dinosaurs", SourceID: "b", EventType: api.UpdateEventType, ChangedFields: "species"},
+		{Meta: api.Meta{ID: "u2", CreatedAt: now.Add(-time.Minute)}, Source: "Dinosaurs", SourceID: "b", EventType: api.UpdateEventType, ChangedFields: "name"},
+	})
+}
+
+func TestCompactionController_CollapsesAndRemoves(t *testing.T) {
+	RegisterTestingT(t)
+
+	eventDao := mocks.NewEventDao()
+	newCompactionEvents(eventDao)
+	eventService := services.NewEventService(&mockLockFactory{}, eventDao, nil)
+	cc := NewCompactionControllerForTesting(&mockLockFactory{}, eventService, CompactionControllerConfig{Retention: 24 * time.Hour})
+	Expect(cc.interval).To(Equal(10 * time.Minute))
+
+	cc.compact(context.Background())
+
+	events, _ := eventDao.All(context.Background())
+	Expect(events.Index()).To(HaveLen(2))
+	Expect(events.Index()).To(HaveKey("recent"))
+	Expect(events.Index()).To(HaveKey("u2"))
+	Expect(events.Index()["u2"].FieldMask()).To(Equal([]string{"species", "name"}))
+	Expect(eventDao.Archived()).To(BeEmpty())
+}
+
+func TestCompactionController_Archives(t *testing.T) {
+	RegisterTestingT(t)
+
+	eventDao := mocks.NewEventDao()
+	newCompactionEvents(eventDao)
+	eventService := services.NewEventService(&mockLockFactory{}, eventDao, nil)
+	cc := NewCompactionControllerForTesting(&mockLockFactory{}, eventService, CompactionControllerConfig{Retention: 24 * time.Hour, Archive: true})
+
+	cc.compact(context.Background())
+
+	Expect(eventDao.Archived()).To(HaveLen(1))
+	Expect(eventDao.Archived()[0].ID).To(Equal("old"))
+}
+
+func TestCompactionController_KeepsWithoutRetention(t *testing.T) {
+	RegisterTestingT(t)
+
+	eventDao := mocks.NewEventDao()
+	newCompactionEvents(eventDao)
+	eventService := services.NewEventService(&mockLockFactory{}, eventDao, nil)
+	cc := NewCompactionControllerForTesting(&mockLockFactory{}, eventService, CompactionControllerConfig{})
+
+	cc.compact(context.Background())
+
+	// updates are still collapsed, but reconciled events are kept forever
+	events, _ := eventDao.All(context.Background())
+	Expect(events.Index()).To(HaveKey("old"))
+	Expect(events.Index()).NotTo(HaveKey("u1"))
+}
+
+func TestCompactionController_SkipsWhenLockHeld(t *testing.T) {
+	RegisterTestingT(t)
+
+	eventDao := mocks.NewEventDao()
+	newCompactionEvents(eventDao)
+	eventService := services.NewEventService(&mockLockFactory{}, eventDao, nil)
+	cc := NewCompactionControllerForTesting(&heldLockFactory{}, eventService, CompactionControllerConfig{Retention: 24 * time.Hour})
+
+	cc.compact(context.Background())
+
+	events, _ := eventDao.All(context.Background())
+	Expect(events).To(HaveLen(4))
+}
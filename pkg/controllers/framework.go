@@ -3,12 +3,17 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
 	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 	"github.com/openshift-online/rh-trex-ai/pkg/services"
+	"github.com/openshift-online/rh-trex-ai/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 /*
@@ -17,33 +22,55 @@ sync-the-world for any messages missed.
 
 The implementation is specific to the Event table in this service and leverages features of PostresDB:
 
-	1. pg_notify(channel, msg) is used for real time notification to listeners
+	1. committed events are announced to listeners in real time on the EventBus, by default with pg_notify(channel, msg)
 	2. advisory locks are used for concurrency when doing background work
 
 DAOs decorated similarly to the DinosaurDAO will persist Events to the database and listeners are notified of the changed.
 A worker attemping to process the Event will first obtain a fail-fast adivosry lock. Of many competing workers, only
 one would first successfully obtain the lock. All other workers will *not* wait to obtain the lock.
 
-Any successful processing of an Event will remove it from the Events table permanently.
+Any successful processing of an Event stamps its ReconciledDate. Reconciled Events are kept for the watches resuming
+from them until the CompactionController deletes or archives them once their retention has passed.
 
 A periodic process reads from the Events table and calls pg_notify, ensuring any failed Events are re-processed. Competing
 consumers for the lock will fail fast on redundant messages.
 
+A failed Event records the attempt and its error and is not retried before NextAttemptAt, which grows with every failure
+according to the BackoffPolicy of its ControllerConfig. Once the policy is exhausted the Event is dead-lettered and left
+alone until an operator re-drives it.
+
+Operators may pause the controllers of a Source through the EventService. Its Events are skipped, left unreconciled,
+until the Source is resumed.
+
 */
 
 type contextKey string
 
 const contextKeyEvent contextKey = "event"
 
+// AnySource registers the handlers of a ControllerConfig for the events of every source. They run after
+// the handlers of the event's own source.
+const AnySource = "*"
+
 type ControllerHandlerFunc func(ctx context.Context, id string) error
 
+// EventFromContext returns the event a ControllerHandlerFunc is called for
+func EventFromContext(ctx context.Context) (*api.Event, bool) {
+	event, ok := ctx.Value(contextKeyEvent).(*api.Event)
+	return event, ok
+}
+
 type ControllerConfig struct {
+	// Source is the Source of the handled events, e.g. Dinosaurs, or AnySource
 	Source   string
 	Handlers map[api.EventType][]ControllerHandlerFunc
+	// Backoff controls retries of failed events for this Source. DefaultBackoffPolicy is used when nil.
+	Backoff *BackoffPolicy
 }
 
 type KindControllerManager struct {
 	controllers map[string]map[api.EventType][]ControllerHandlerFunc
+	backoff     map[string]*BackoffPolicy
 	lockFactory db.LockFactory
 	events      services.EventService
 }
@@ -51,6 +78,7 @@ type KindControllerManager struct {
 func NewKindControllerManager(lockFactory db.LockFactory, events services.EventService) *KindControllerManager {
 	return &KindControllerManager{
 		controllers: map[string]map[api.EventType][]ControllerHandlerFunc{},
+		backoff:     map[string]*BackoffPolicy{},
 		lockFactory: lockFactory,
 		events:      events,
 	}
@@ -60,6 +88,16 @@ func (km *KindControllerManager) Add(config *ControllerConfig) {
 	for ev, fn := range config.Handlers {
 		km.add(config.Source, ev, fn)
 	}
+	if config.Backoff != nil || km.backoff[config.Source] == nil {
+		km.backoff[config.Source] = config.Backoff.withDefaults()
+	}
+}
+
+func (km *KindControllerManager) backoffPolicy(source string) *BackoffPolicy {
+	if policy, found := km.backoff[source]; found {
+		return policy
+	}
+	return DefaultBackoffPolicy()
 }
 
 func (km *KindControllerManager) add(source string, ev api.EventType, fns []ControllerHandlerFunc) {
@@ -74,9 +112,21 @@ func (km *KindControllerManager) add(source string, ev api.EventType, fns []Cont
 	km.controllers[source][ev] = append(km.controllers[source][ev], fns...)
 }
 
+// Handles tells whether any controller handles the events of source and eventType
+func (km *KindControllerManager) Handles(source string, eventType api.EventType) bool {
+	return len(km.controllers[source][eventType]) > 0 || len(km.controllers[AnySource][eventType]) > 0
+}
+
 func (km *KindControllerManager) Handle(id string) {
 
-	ctx := context.Background()
+	// the event is handled apart from the request that produced it: its span is the root of a trace of its
+	// own, linked to the request once handle has read the event
+	ctx, span := tracing.Tracer().Start(context.Background(), "KindControllerManager.Handle",
+		trace.WithNewRoot(),
+		trace.WithAttributes(attribute.String("event.id", id)),
+	)
+	defer span.End()
+	ctx = logger.WithEventID(ctx, id)
 	logger := logger.NewLogger(ctx)
 
 	// lock the Event with a fail-fast advisory lock context.
@@ -87,45 +137,81 @@ func (km *KindControllerManager) Handle(id string) {
 	defer km.lockFactory.Unlock(ctx, lockOwnerID)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Error obtaining the event lock: %v", err))
+		span.SetStatus(codes.Error, err.Error())
 		return
 	}
+	span.SetAttributes(attribute.Bool("event.lock_acquired", acquired))
 	if !acquired {
 		logger.Infof("Event %s is processed by another worker, continue to process the next", id)
 		return
 	}
-	threadContext := context.WithValue(ctx, contextKeyEvent, id)
-
-	km.handle(threadContext, id)
+	km.handle(ctx, id)
 }
 
 func (km *KindControllerManager) handle(ctx context.Context, id string) {
 
 	log := logger.NewLogger(ctx)
 
+	span := trace.SpanFromContext(ctx)
 	event, err := km.events.Get(ctx, id)
 
 	if err != nil {
 		log.Error(err.Error())
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("event.source", event.Source),
+		attribute.String("event.source_id", event.SourceID),
+		attribute.String("event.type", string(event.EventType)),
+	)
+	if origin := tracing.SpanContext(event.TraceParent, event.TraceState); origin.IsValid() {
+		span.AddLink(trace.Link{SpanContext: origin})
+	}
+	ctx = logger.WithKind(ctx, strings.ToLower(event.Source))
+	log = logger.NewLogger(ctx)
+
+	if event.ReconciledDate != nil || event.DeadLettered() {
+		log.V(2).Infof("Event %s is already reconciled or dead-lettered, skipping", id)
+		return
+	}
+	// a failed event announced again, e.g. once its updates are collapsed, waits for its backoff to elapse
+	if event.NextAttemptAt != nil && event.NextAttemptAt.After(time.Now()) {
+		log.V(2).Infof("Event %s is backing off until %s, skipping", id, event.NextAttemptAt.Format(time.RFC3339))
 		return
 	}
 
-	source, found := km.controllers[event.Source]
-	if !found {
-		log.Infof("No controllers found for '%s'\n", event.Source)
+	// the events of a paused source are left unreconciled, they are announced again once it is resumed
+	paused, svcErr := km.events.Paused(ctx, event.Source)
+	if svcErr != nil {
+		log.Error(svcErr.Error())
+		span.SetStatus(codes.Error, svcErr.Error())
+		return
+	}
+	span.SetAttributes(attribute.Bool("event.paused", paused))
+	if paused {
+		log.V(2).Infof("Controllers of %s are paused, skipping event %s", event.Source, id)
 		return
 	}
 
-	handlerFns, found := source[event.EventType]
-	if !found {
+	var handlerFns []ControllerHandlerFunc
+	handlerFns = append(handlerFns, km.controllers[event.Source][event.EventType]...)
+	handlerFns = append(handlerFns, km.controllers[AnySource][event.EventType]...)
+	if len(handlerFns) == 0 {
 		log.Infof("No handler functions found for '%s-%s'\n", event.Source, event.EventType)
 		return
 	}
 
+	ctx = context.WithValue(ctx, contextKeyEvent, event)
 	for _, fn := range handlerFns {
 		err := fn(ctx, event.SourceID)
 		if err != nil {
 			errStr := fmt.Sprintf("error handing event %s, %s, %s: %s", event.Source, event.EventType, id, err)
 			log.Error(errStr)
+			span.RecordError(err)
+			span.SetStatus(codes.Error, errStr)
+			km.fail(ctx, event, err)
 			return
 		}
 	}
@@ -133,8 +219,31 @@ func (km *KindControllerManager) handle(ctx context.Context, id string) {
 	// all handlers successfully executed
 	now := time.Now()
 	event.ReconciledDate = &now
+	event.NextAttemptAt = nil
 	_, err = km.events.Replace(ctx, event)
 	if err != nil {
 		log.Error(err.Error())
 	}
 }
+
+// fail records a failed attempt and either schedules the next one or dead-letters the event
+func (km *KindControllerManager) fail(ctx context.Context, event *api.Event, handlerErr error) {
+	log := logger.NewLogger(ctx)
+	policy := km.backoffPolicy(event.Source)
+
+	now := time.Now()
+	event.Attempts++
+	event.LastError = handlerErr.Error()
+	if policy.Exhausted(event.Attempts) {
+		event.NextAttemptAt = nil
+		event.DeadLetteredAt = &now
+		log.Warning(fmt.Sprintf("Event %s dead-lettered after %d attempts", event.ID, event.Attempts))
+	} else {
+		next := now.Add(policy.Next(event.Attempts))
+		event.NextAttemptAt = &next
+	}
+
+	if _, err := km.events.Replace(ctx, event); err != nil {
+		log.Error(err.Error())
+	}
+}
@@ -2,13 +2,19 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/config"
 	"github.com/openshift-online/rh-trex-ai/pkg/dao/mocks"
 	dbmocks "github.com/openshift-online/rh-trex-ai/pkg/db/mocks"
 	"github.com/openshift-online/rh-trex-ai/pkg/services"
+	"github.com/openshift-online/rh-trex-ai/pkg/tracing"
 )
 
 func newExampleControllerConfig(ctrl *exampleController) *ControllerConfig {
@@ -48,12 +54,14 @@ func TestControllerFramework(t *testing.T) {
 
 	ctx := context.Background()
 	eventsDao := mocks.NewEventDao()
-	events := services.NewEventService(eventsDao)
+	events := services.NewEventService(dbmocks.NewMockAdvisoryLockFactory(), eventsDao, nil)
 	mgr := NewKindControllerManager(dbmocks.NewMockAdvisoryLockFactory(), events)
 
 	ctrl := &exampleController{}
 	config := newExampleControllerConfig(ctrl)
 	mgr.Add(config)
+	Expect(mgr.Handles(config.Source, api.CreateEventType)).To(BeTrue())
+	Expect(mgr.Handles("Unknown", api.CreateEventType)).To(BeFalse())
 
 	_, _ = eventsDao.Create(ctx, &api.Event{
 		Meta:      api.Meta{ID: "1"},
@@ -87,3 +95,212 @@ func TestControllerFramework(t *testing.T) {
 	eve, _ := eventsDao.Get(ctx, "1")
 	Expect(eve.ReconciledDate).ToNot(BeNil(), "event reconcile date should be set")
 }
+
+func TestControllerFrameworkAnySource(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx := context.Background()
+	eventsDao := mocks.NewEventDao()
+	events := services.NewEventService(dbmocks.NewMockAdvisoryLockFactory(), eventsDao, nil)
+	mgr := NewKindControllerManager(dbmocks.NewMockAdvisoryLockFactory(), events)
+
+	var handled []string
+	record := func(name string) ControllerHandlerFunc {
+		return func(ctx context.Context, id string) error {
+			event, ok := EventFromContext(ctx)
+			Expect(ok).To(BeTrue())
+			Expect(event.SourceID).To(Equal(id))
+			handled = append(handled, name+":"+event.Source)
+			return nil
+		}
+	}
+	mgr.Add(&ControllerConfig{
+		Source:   AnySource,
+		Handlers: map[api.EventType][]ControllerHandlerFunc{api.CreateEventType: {record("any")}},
+	})
+	mgr.Add(&ControllerConfig{
+		Source:   "my-event-source",
+		Handlers: map[api.EventType][]ControllerHandlerFunc{api.CreateEventType: {record("own")}},
+	})
+	Expect(mgr.Handles("Unknown", api.CreateEventType)).To(BeTrue())
+	Expect(mgr.Handles("Unknown", api.DeleteEventType)).To(BeFalse())
+
+	_, _ = eventsDao.Create(ctx, &api.Event{Meta: api.Meta{ID: "1"}, Source: "my-event-source", SourceID: "a", EventType: api.CreateEventType})
+	_, _ = eventsDao.Create(ctx, &api.Event{Meta: api.Meta{ID: "2"}, Source: "Unknown", SourceID: "b", EventType: api.CreateEventType})
+	mgr.Handle("1")
+	mgr.Handle("2")
+
+	// the handlers of the event's own source run first
+	Expect(handled).To(Equal([]string{"own:my-event-source", "any:my-event-source", "any:Unknown"}))
+	eve, _ := eventsDao.Get(ctx, "2")
+	Expect(eve.ReconciledDate).ToNot(BeNil())
+}
+
+func TestControllerFrameworkBackoff(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx := context.Background()
+	eventsDao := mocks.NewEventDao()
+	events := services.NewEventService(dbmocks.NewMockAdvisoryLockFactory(), eventsDao, nil)
+	mgr := NewKindControllerManager(dbmocks.NewMockAdvisoryLockFactory(), events)
+
+	calls := 0
+	mgr.Add(&ControllerConfig{
+		Source: "poison-source",
+		Handlers: map[api.EventType][]ControllerHandlerFunc{
+			api.CreateEventType: {func(ctx context.Context, id string) error {
+				calls++
+				return fmt.Errorf("boom %d", calls)
+			}},
+		},
+		Backoff: &BackoffPolicy{InitialInterval: time.Minute, MaxInterval: 3 * time.Minute, Multiplier: 2, MaxAttempts: 3},
+	})
+
+	_, _ = eventsDao.Create(ctx, &api.Event{
+		Meta:      api.Meta{ID: "1"},
+		Source:    "poison-source",
+		SourceID:  "any id",
+		EventType: api.CreateEventType,
+	})
+
+	before := time.Now()
+	mgr.Handle("1")
+	eve, _ := eventsDao.Get(ctx, "1")
+	Expect(eve.Attempts).To(Equal(1))
+	Expect(eve.LastError).To(Equal("boom 1"))
+	Expect(eve.NextAttemptAt).ToNot(BeNil())
+	Expect(eve.NextAttemptAt.Sub(before)).To(BeNumerically(">=", time.Minute))
+	Expect(eve.DeadLettered()).To(BeFalse())
+
+	// an event announced again before its backoff elapsed is left alone
+	mgr.Handle("1")
+	Expect(calls).To(Equal(1))
+	Expect(eve.Attempts).To(Equal(1))
+
+	elapse := func() {
+		past := time.Now().Add(-time.Second)
+		eve.NextAttemptAt = &past
+	}
+	elapse()
+	mgr.Handle("1")
+	Expect(eve.Attempts).To(Equal(2))
+	Expect(eve.NextAttemptAt.Sub(before)).To(BeNumerically(">=", 2*time.Minute))
+
+	elapse()
+	mgr.Handle("1")
+	Expect(eve.Attempts).To(Equal(3))
+	Expect(eve.LastError).To(Equal("boom 3"))
+	Expect(eve.NextAttemptAt).To(BeNil())
+	Expect(eve.DeadLettered()).To(BeTrue(), "event should be dead-lettered once the policy is exhausted")
+	Expect(eve.ReconciledDate).To(BeNil())
+
+	// dead-lettered events are left alone
+	mgr.Handle("1")
+	Expect(calls).To(Equal(3))
+
+	// re-driving hands the event back to the controllers
+	_, svcErr := events.Redrive(ctx, "1")
+	Expect(svcErr).To(BeNil())
+	Expect(eve.Attempts).To(Equal(0))
+	Expect(eve.DeadLettered()).To(BeFalse())
+	mgr.Handle("1")
+	Expect(calls).To(Equal(4))
+
+	_, svcErr = events.Redrive(ctx, "1")
+	Expect(svcErr).ToNot(BeNil(), "only dead-lettered events can be re-driven")
+}
+
+func TestControllerFrameworkTracing(t *testing.T) {
+	RegisterTestingT(t)
+
+	provider, err := tracing.New(&config.TracingConfig{Exporter: tracing.MemoryExporter, SampleRatio: 1})
+	Expect(err).NotTo(HaveOccurred())
+	defer provider.Shutdown(context.Background())
+
+	eventsDao := mocks.NewEventDao()
+	events := services.NewEventService(dbmocks.NewMockAdvisoryLockFactory(), eventsDao, nil)
+	mgr := NewKindControllerManager(dbmocks.NewMockAdvisoryLockFactory(), events)
+	mgr.Add(&ControllerConfig{
+		Source: "my-event-source",
+		Handlers: map[api.EventType][]ControllerHandlerFunc{
+			api.CreateEventType: {func(ctx context.Context, id string) error { return nil }},
+		},
+	})
+
+	// the event keeps the trace context of the request writing it
+	ctx, request := tracing.Tracer().Start(context.Background(), "request")
+	_, svcErr := events.Create(ctx, &api.Event{Meta: api.Meta{ID: "1"}, Source: "my-event-source", SourceID: "a", EventType: api.CreateEventType})
+	Expect(svcErr).To(BeNil())
+	request.End()
+	eve, _ := eventsDao.Get(context.Background(), "1")
+	Expect(eve.TraceParent).To(ContainSubstring(request.SpanContext().TraceID().String()))
+
+	mgr.Handle("1")
+	var handle *tracetest.SpanStub
+	for _, span := range provider.Spans() {
+		if span.Name == "KindControllerManager.Handle" {
+			handle = &span
+		}
+	}
+	Expect(handle).NotTo(BeNil())
+	Expect(handle.SpanContext.TraceID()).NotTo(Equal(request.SpanContext().TraceID()))
+	Expect(handle.Links).To(HaveLen(1))
+	Expect(handle.Links[0].SpanContext.SpanID()).To(Equal(request.SpanContext().SpanID()))
+}
+
+func TestControllerFrameworkPause(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx := context.Background()
+	eventsDao := mocks.NewEventDao()
+	events := services.NewEventService(dbmocks.NewMockAdvisoryLockFactory(), eventsDao, nil)
+	mgr := NewKindControllerManager(dbmocks.NewMockAdvisoryLockFactory(), events)
+
+	ctrl := &exampleController{}
+	config := newExampleControllerConfig(ctrl)
+	mgr.Add(config)
+
+	_, svcErr := events.Pause(ctx, config.Source)
+	Expect(svcErr).To(BeNil())
+	_, _ = eventsDao.Create(ctx, &api.Event{Meta: api.Meta{ID: "1"}, Source: config.Source, SourceID: "a", EventType: api.CreateEventType})
+
+	// the event of the paused source is left unreconciled
+	mgr.Handle("1")
+	Expect(ctrl.addCounter).To(Equal(0))
+	eve, _ := eventsDao.Get(ctx, "1")
+	Expect(eve.ReconciledDate).To(BeNil())
+
+	stats, svcErr := events.Resume(ctx, config.Source)
+	Expect(svcErr).To(BeNil())
+	Expect(stats.Pause).To(BeNil())
+	Expect(stats.Pending).To(Equal(int64(1)))
+
+	mgr.Handle("1")
+	Expect(ctrl.addCounter).To(Equal(1))
+	eve, _ = eventsDao.Get(ctx, "1")
+	Expect(eve.ReconciledDate).NotTo(BeNil())
+
+	// reprocessing hands the reconciled event back to the controllers
+	eve, svcErr = events.Reprocess(ctx, "1")
+	Expect(svcErr).To(BeNil())
+	Expect(eve.ReconciledDate).To(BeNil())
+	mgr.Handle("1")
+	Expect(ctrl.addCounter).To(Equal(2))
+}
+
+func TestBackoffPolicy(t *testing.T) {
+	RegisterTestingT(t)
+
+	policy := (&BackoffPolicy{InitialInterval: time.Second, MaxInterval: 10 * time.Second}).withDefaults()
+	Expect(policy.Multiplier).To(Equal(float64(2)))
+	Expect(policy.MaxAttempts).To(Equal(10))
+	Expect(policy.Next(1)).To(Equal(time.Second))
+	Expect(policy.Next(2)).To(Equal(2 * time.Second))
+	Expect(policy.Next(4)).To(Equal(8 * time.Second))
+	Expect(policy.Next(5)).To(Equal(10 * time.Second))
+	Expect(policy.Next(100)).To(Equal(10 * time.Second))
+	Expect(policy.Exhausted(9)).To(BeFalse())
+	Expect(policy.Exhausted(10)).To(BeTrue())
+
+	Expect((*BackoffPolicy)(nil).withDefaults()).To(Equal(DefaultBackoffPolicy()))
+}
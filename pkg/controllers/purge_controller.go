@@ -0,0 +1,194 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+)
+
+// DefaultPurgeRetention is how long soft-deleted rows stay restorable when a Kind doesn't choose otherwise
+const DefaultPurgeRetention = 30 * 24 * time.Hour
+
+const purgeLockType db.LockType = "purge"
+
+// PurgeFunc hard-deletes rows soft-deleted before the cutoff and returns how many were removed
+type PurgeFunc func(ctx context.Context, deletedBefore time.Time) (int64, *errors.ServiceError)
+
+// PurgeConfig registers a Kind with the PurgeController
+type PurgeConfig struct {
+	Kind string
+	// Retention is how long soft-deleted rows are kept for restore (default: DefaultPurgeRetention)
+	Retention time.Duration
+	Purge     PurgeFunc
+}
+
+// PurgeController periodically hard-deletes soft-deleted rows once their Kind's retention has passed.
+// Every Kind is purged under a fail-fast advisory lock, so only one replica purges it per cycle.
+type PurgeController struct {
+	lockFactory db.LockFactory
+	interval    time.Duration
+	kinds       map[string]*PurgeConfig
+	cancel      context.CancelFunc
+	done        chan struct{}
+	startOnce   sync.Once
+	metrics     *purgeMetrics
+}
+
+type PurgeControllerConfig struct {
+	// Interval between purge runs (default: 1 hour)
+	Interval time.Duration
+}
+
+type purgeMetrics struct {
+	rowsPurged  *prometheus.CounterVec
+	purgeErrors *prometheus.CounterVec
+}
+
+func newPurgeMetrics() *purgeMetrics {
+	return &purgeMetrics{
+		rowsPurged: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "controller_purge_rows_total",
+			Help: "Total number of soft-deleted rows hard-deleted by the purge controller",
+		}, []string{"kind"}),
+		purgeErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "controller_purge_errors_total",
+			Help: "Total number of failed purge runs",
+		}, []string{"kind"}),
+	}
+}
+
+func (m *purgeMetrics) Register() {
+	prometheus.MustRegister(m.rowsPurged)
+	prometheus.MustRegister(m.purgeErrors)
+}
+
+// NewPurgeController creates a new purge controller with the given configuration
+func NewPurgeController(lockFactory db.LockFactory, config PurgeControllerConfig) *PurgeController {
+	return newPurgeController(lockFactory, config, true)
+}
+
+// NewPurgeControllerForTesting creates a purge controller without registering Prometheus metrics
+func NewPurgeControllerForTesting(lockFactory db.LockFactory, config PurgeControllerConfig) *PurgeController {
+	return newPurgeController(lockFactory, config, false)
+}
+
+func newPurgeController(lockFactory db.LockFactory, config PurgeControllerConfig, registerMetrics bool) *PurgeController {
+	if config.Interval == 0 {
+		config.Interval = 1 * time.Hour
+	}
+
+	metrics := newPurgeMetrics()
+	if registerMetrics {
+		metrics.Register()
+	}
+
+	return &PurgeController{
+		lockFactory: lockFactory,
+		interval:    config.Interval,
+		kinds:       map[string]*PurgeConfig{},
+		done:        make(chan struct{}),
+		metrics:     metrics,
+	}
+}
+
+// Add registers a Kind for purging. A later registration of the same Kind replaces the earlier one.
+func (pc *PurgeController) Add(config *PurgeConfig) {
+	if config.Retention <= 0 {
+		config.Retention = DefaultPurgeRetention
+	}
+	pc.kinds[config.Kind] = config
+}
+
+// Start begins the periodic purge process
+func (pc *PurgeController) Start() {
+	pc.startOnce.Do(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		pc.cancel = cancel
+
+		log := logger.NewLogger(ctx)
+		log.Infof("Starting purge controller with interval=%v for %d kinds", pc.interval, len(pc.kinds))
+
+		go pc.purgeLoop(ctx)
+	})
+}
+
+// Stop gracefully shuts down the purge controller
+func (pc *PurgeController) Stop() error {
+	if pc.cancel != nil {
+		pc.cancel()
+		<-pc.done
+	}
+	return nil
+}
+
+func (pc *PurgeController) purgeLoop(ctx context.Context) {
+	defer close(pc.done)
+
+	log := logger.NewLogger(ctx)
+	ticker := time.NewTicker(pc.interval)
+	defer ticker.Stop()
+
+	pc.purgeAll(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Purge controller shutting down")
+			return
+		case <-ticker.C:
+			pc.purgeAll(ctx)
+		}
+	}
+}
+
+// purgeAll runs one purge cycle over all registered Kinds
+func (pc *PurgeController) purgeAll(ctx context.Context) {
+	kinds := make([]string, 0, len(pc.kinds))
+	for kind := range pc.kinds {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	for _, kind := range kinds {
+		if ctx.Err() != nil {
+			return
+		}
+		pc.purge(ctx, pc.kinds[kind])
+	}
+}
+
+func (pc *PurgeController) purge(ctx context.Context, config *PurgeConfig) {
+	log := logger.NewLogger(ctx)
+
+	lockOwnerID, acquired, err := pc.lockFactory.NewNonBlockingLock(ctx, config.Kind, purgeLockType)
+	defer pc.lockFactory.Unlock(ctx, lockOwnerID)
+	if err != nil {
+		pc.metrics.purgeErrors.WithLabelValues(config.Kind).Inc()
+		log.Error(fmt.Sprintf("Error obtaining the purge lock for %s: %v", config.Kind, err))
+		return
+	}
+	if !acquired {
+		log.V(2).Infof("%s are purged by another worker", config.Kind)
+		return
+	}
+
+	cutoff := time.Now().Add(-config.Retention)
+	purged, svcErr := config.Purge(ctx, cutoff)
+	if svcErr != nil {
+		pc.metrics.purgeErrors.WithLabelValues(config.Kind).Inc()
+		log.Error(fmt.Sprintf("Failed to purge %s deleted before %v: %v", config.Kind, cutoff, svcErr))
+		return
+	}
+	pc.metrics.rowsPurged.WithLabelValues(config.Kind).Add(float64(purged))
+	if purged > 0 {
+		log.Infof("Purged %d %s deleted before %v", purged, config.Kind, cutoff)
+	}
+}
@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+func TestPurgeController_PurgesPastRetention(t *testing.T) {
+	RegisterTestingT(t)
+
+	pc := NewPurgeControllerForTesting(&mockLockFactory{}, PurgeControllerConfig{})
+	Expect(pc.interval).To(Equal(1 * time.Hour))
+
+	var cutoffs []time.Time
+	pc.Add(&PurgeConfig{
+		Kind:      "Dinosaurs",
+		Retention: 24 * time.Hour,
+		Purge: func(ctx context.Context, deletedBefore time.Time) (int64, *errors.ServiceError) {
+			cutoffs = append(cutoffs, deletedBefore)
+			return 3, nil
+		},
+	})
+	failed := 0
+	pc.Add(&PurgeConfig{
+		Kind: "Fossils",
+		Purge: func(ctx context.Context, deletedBefore time.Time) (int64, *errors.ServiceError) {
+			failed++
+			return 0, errors.GeneralError("boom")
+		},
+	})
+	Expect(pc.kinds["Fossils"].Retention).To(Equal(DefaultPurgeRetention))
+
+	before := time.Now()
+	pc.purgeAll(context.Background())
+
+	// a failing Kind doesn't keep the others from being purged
+	Expect(failed).To(Equal(1))
+	Expect(cutoffs).To(HaveLen(1))
+	Expect(cutoffs[0]).To(BeTemporally("~", before.Add(-24*time.Hour), time.Minute))
+}
+
+func TestPurgeController_SkipsWhenLockHeld(t *testing.T) {
+	RegisterTestingT(t)
+
+	pc := NewPurgeControllerForTesting(&heldLockFactory{}, PurgeControllerConfig{})
+	called := false
+	pc.Add(&PurgeConfig{
+		Kind: "Dinosaurs",
+		Purge: func(ctx context.Context, deletedBefore time.Time) (int64, *errors.ServiceError) {
+			called = true
+			return 0, nil
+		},
+	})
+
+	pc.purgeAll(context.Background())
+	Expect(called).To(BeFalse())
+}
+
+// heldLockFactory behaves as if another replica holds every lock
+type heldLockFactory struct {
+	mockLockFactory
+}
+
+func (m *heldLockFactory) NewNonBlockingLock(ctx context.Context, id string, lockType db.LockType) (string, bool, error) {
+	return "", false, nil
+}
@@ -190,7 +190,7 @@ func (sc *SyncController) performSync(ctx context.Context) {
 	sc.metrics.syncRuns.Inc()
 	log.Info("Starting sync-the-world cycle")
 
-	// Find unreconciled events older than maxAge
+	// Find unreconciled events older than maxAge and failed events whose backoff has elapsed
 	events, svcErr := sc.eventService.FindUnreconciled(ctx, sc.maxAge)
 	if svcErr != nil {
 		sc.metrics.syncErrors.Inc()
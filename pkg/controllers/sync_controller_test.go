@@ -69,7 +69,7 @@ func TestSyncController_FindsUnreconciledEvents(t *testing.T) {
 	mockDao.Create(context.Background(), recentEvent)
 	mockDao.Create(context.Background(), reconciledEvent)
 	
-	eventService := services.NewEventService(mockDao)
+	eventService := services.NewEventService(&mockLockFactory{}, mockDao, nil)
 	
 	// Test FindUnreconciled with 1 hour max age
 	events, err := eventService.FindUnreconciled(context.Background(), 1*time.Hour)
@@ -87,6 +87,47 @@ func TestSyncController_FindsUnreconciledEvents(t *testing.T) {
 	}
 }
 
+func TestSyncController_RespectsNextAttemptAt(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-1 * time.Minute)
+	future := now.Add(10 * time.Minute)
+
+	dueRetry := &api.Event{
+		Meta:          api.Meta{ID: "due-retry", CreatedAt: now.Add(-1 * time.Minute)},
+		Source:        "Dinosaurs",
+		EventType:     api.UpdateEventType,
+		Attempts:      1,
+		NextAttemptAt: &past, // backoff elapsed, even though the event is recent
+	}
+	backingOff := &api.Event{
+		Meta:          api.Meta{ID: "backing-off", CreatedAt: now.Add(-2 * time.Hour)},
+		Source:        "Dinosaurs",
+		EventType:     api.UpdateEventType,
+		Attempts:      2,
+		NextAttemptAt: &future,
+	}
+	deadLettered := &api.Event{
+		Meta:           api.Meta{ID: "dead-lettered", CreatedAt: now.Add(-2 * time.Hour)},
+		Source:         "Dinosaurs",
+		EventType:      api.UpdateEventType,
+		Attempts:       10,
+		DeadLetteredAt: &past,
+	}
+
+	mockDao := mocks.NewEventDao()
+	mockDao.Create(context.Background(), dueRetry)
+	mockDao.Create(context.Background(), backingOff)
+	mockDao.Create(context.Background(), deadLettered)
+
+	events, err := services.NewEventService(&mockLockFactory{}, mockDao, nil).FindUnreconciled(context.Background(), 1*time.Hour)
+	if err != nil {
+		t.Fatalf("FindUnreconciled failed: %v", err)
+	}
+	if len(events) != 1 || events[0].ID != dueRetry.ID {
+		t.Errorf("Expected only event %s to be due, got %d events", dueRetry.ID, len(events))
+	}
+}
+
 func TestSyncController_HandlerTracking(t *testing.T) {
 	// Track handler calls
 	handlerCalls := make(map[string]int)
@@ -98,7 +139,7 @@ func TestSyncController_HandlerTracking(t *testing.T) {
 	
 	// Create mock services
 	mockEventDao := mocks.NewEventDao()
-	eventService := services.NewEventService(mockEventDao)
+	eventService := services.NewEventService(&mockLockFactory{}, mockEventDao, nil)
 	
 	// Create a mock lock factory that always succeeds
 	mockLockFactory := &mockLockFactory{}
@@ -149,7 +190,7 @@ func TestSyncController_HandlerTracking(t *testing.T) {
 
 func TestSyncController_EventLimiting(t *testing.T) {
 	mockEventDao := mocks.NewEventDao()
-	eventService := services.NewEventService(mockEventDao)
+	eventService := services.NewEventService(&mockLockFactory{}, mockEventDao, nil)
 	
 	// Create many unreconciled events (more than limit)
 	now := time.Now()
@@ -196,7 +237,7 @@ func TestSyncController_EventLimiting(t *testing.T) {
 
 func TestSyncController_Configuration(t *testing.T) {
 	mockEventDao := mocks.NewEventDao()
-	eventService := services.NewEventService(mockEventDao)
+	eventService := services.NewEventService(&mockLockFactory{}, mockEventDao, nil)
 	mockLockFactory := &mockLockFactory{}
 	manager := NewKindControllerManager(mockLockFactory, eventService)
 	
@@ -237,7 +278,7 @@ func TestSyncController_Configuration(t *testing.T) {
 
 func TestSyncController_StartStop(t *testing.T) {
 	mockEventDao := mocks.NewEventDao()
-	eventService := services.NewEventService(mockEventDao)
+	eventService := services.NewEventService(&mockLockFactory{}, mockEventDao, nil)
 	mockLockFactory := &mockLockFactory{}
 	manager := NewKindControllerManager(mockLockFactory, eventService)
 	
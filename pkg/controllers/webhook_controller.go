@@ -0,0 +1,261 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
+
+const webhookLockType db.LockType = "webhooks"
+
+// Headers of webhook deliveries. Receivers check the signature with VerifyWebhookSignature and use the
+// event id to drop the deliveries they already got.
+const (
+	WebhookIDHeader        = "X-Webhook-Id"
+	WebhookEventIDHeader   = "X-Webhook-Event-Id"
+	WebhookAttemptHeader   = "X-Webhook-Attempt"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// SignWebhook returns the X-Webhook-Signature of a delivery: "sha256=" and the hex encoded HMAC-SHA256,
+// keyed with the secret of the webhook, of the X-Webhook-Timestamp, a "." and the body
+func SignWebhook(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature tells whether signature is the SignWebhook of the timestamp and the body
+func VerifyWebhookSignature(secret string, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(signature), []byte(SignWebhook(secret, timestamp, body)))
+}
+
+// WebhookController periodically POSTs the due webhook deliveries the webhooks controller enqueued for
+// every event. A delivery succeeds on a 2xx response. Failed deliveries are retried according to the
+// BackoffPolicy and dead-lettered once it is exhausted. Every run takes a fail-fast advisory lock, so
+// only one replica delivers at a time.
+type WebhookController struct {
+	lockFactory db.LockFactory
+	webhooks    services.WebhookService
+	client      *http.Client
+	interval    time.Duration
+	batchSize   int
+	backoff     *BackoffPolicy
+	cancel      context.CancelFunc
+	done        chan struct{}
+	startOnce   sync.Once
+	metrics     *webhookMetrics
+}
+
+type WebhookControllerConfig struct {
+	// Interval between delivery runs (default: 5 seconds)
+	Interval time.Duration
+	// BatchSize is the maximum number of deliveries attempted per run (default: 100)
+	BatchSize int
+	// Timeout of a delivery request (default: 10 seconds)
+	Timeout time.Duration
+	// Backoff controls retries of failed deliveries. DefaultBackoffPolicy is used when nil.
+	Backoff *BackoffPolicy
+}
+
+type webhookMetrics struct {
+	deliveries *prometheus.CounterVec
+}
+
+func newWebhookMetrics() *webhookMetrics {
+	return &webhookMetrics{
+		deliveries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "controller_webhook_deliveries_total",
+			Help: "Total number of webhook delivery attempts by outcome",
+		}, []string{"status"}),
+	}
+}
+
+func (m *webhookMetrics) Register() {
+	prometheus.MustRegister(m.deliveries)
+}
+
+// NewWebhookController creates a new webhook controller with the given configuration
+func NewWebhookController(lockFactory db.LockFactory, webhooks services.WebhookService, config WebhookControllerConfig) *WebhookController {
+	return newWebhookController(lockFactory, webhooks, config, true)
+}
+
+// NewWebhookControllerForTesting creates a webhook controller without registering Prometheus metrics
+func NewWebhookControllerForTesting(lockFactory db.LockFactory, webhooks services.WebhookService, config WebhookControllerConfig) *WebhookController {
+	return newWebhookController(lockFactory, webhooks, config, false)
+}
+
+func newWebhookController(lockFactory db.LockFactory, webhooks services.WebhookService, config WebhookControllerConfig, registerMetrics bool) *WebhookController {
+	if config.Interval == 0 {
+		config.Interval = 5 * time.Second
+	}
+	if config.BatchSize == 0 {
+		config.BatchSize = 100
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+
+	metrics := newWebhookMetrics()
+	if registerMetrics {
+		metrics.Register()
+	}
+
+	return &WebhookController{
+		lockFactory: lockFactory,
+		webhooks:    webhooks,
+		client:      &http.Client{Timeout: config.Timeout},
+		interval:    config.Interval,
+		batchSize:   config.BatchSize,
+		backoff:     config.Backoff.withDefaults(),
+		done:        make(chan struct{}),
+		metrics:     metrics,
+	}
+}
+
+// Start begins the periodic delivery process
+func (wc *WebhookController) Start() {
+	wc.startOnce.Do(func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		wc.cancel = cancel
+
+		log := logger.NewLogger(ctx)
+		log.Infof("Starting webhook controller with interval=%v", wc.interval)
+
+		go wc.deliverLoop(ctx)
+	})
+}
+
+// Stop gracefully shuts down the webhook controller
+func (wc *WebhookController) Stop() error {
+	if wc.cancel != nil {
+		wc.cancel()
+		<-wc.done
+	}
+	return nil
+}
+
+func (wc *WebhookController) deliverLoop(ctx context.Context) {
+	defer close(wc.done)
+
+	log := logger.NewLogger(ctx)
+	ticker := time.NewTicker(wc.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("Webhook controller shutting down")
+			return
+		case <-ticker.C:
+			wc.deliverDue(ctx)
+		}
+	}
+}
+
+// deliverDue runs one delivery cycle over the due deliveries
+func (wc *WebhookController) deliverDue(ctx context.Context) {
+	log := logger.NewLogger(ctx)
+
+	lockOwnerID, acquired, err := wc.lockFactory.NewNonBlockingLock(ctx, "deliveries", webhookLockType)
+	defer wc.lockFactory.Unlock(ctx, lockOwnerID)
+	if err != nil {
+		log.Error(fmt.Sprintf("Error obtaining the webhook delivery lock: %v", err))
+		return
+	}
+	if !acquired {
+		log.V(2).Infof("Webhooks are delivered by another worker")
+		return
+	}
+
+	deliveries, svcErr := wc.webhooks.FindDueDeliveries(ctx, wc.batchSize)
+	if svcErr != nil {
+		log.Error(svcErr.Error())
+		return
+	}
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			return
+		}
+		wc.deliver(ctx, delivery)
+	}
+}
+
+func (wc *WebhookController) deliver(ctx context.Context, delivery *api.WebhookDelivery) {
+	log := logger.NewLogger(ctx)
+
+	var statusCode int
+	var attemptErr error
+	webhook, svcErr := wc.webhooks.Get(ctx, delivery.WebhookID)
+	if svcErr != nil {
+		attemptErr = svcErr.AsError()
+	} else {
+		statusCode, attemptErr = wc.post(ctx, webhook, delivery)
+	}
+
+	// a webhook deleted since has nothing left to retry
+	var retryAt *time.Time
+	if attemptErr != nil && !wc.backoff.Exhausted(delivery.Attempt) && (svcErr == nil || !svcErr.Is404()) {
+		next := time.Now().Add(wc.backoff.Next(delivery.Attempt))
+		retryAt = &next
+	}
+
+	delivery, svcErr = wc.webhooks.RecordAttempt(ctx, delivery, statusCode, attemptErr, retryAt)
+	if svcErr != nil {
+		log.Error(svcErr.Error())
+		return
+	}
+	wc.metrics.deliveries.WithLabelValues(string(delivery.Status)).Inc()
+	if attemptErr != nil {
+		log.Warning(fmt.Sprintf("Webhook delivery %s of event %s to webhook %s failed (attempt %d, %s): %v",
+			delivery.ID, delivery.EventID, delivery.WebhookID, delivery.Attempt, delivery.Status, attemptErr))
+	}
+}
+
+// post sends the payload of delivery to the webhook and returns the response status, with an error
+// unless it is a 2xx
+func (wc *WebhookController) post(ctx context.Context, webhook *api.Webhook, delivery *api.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookIDHeader, webhook.ID)
+	request.Header.Set(WebhookEventIDHeader, delivery.EventID)
+	request.Header.Set(WebhookAttemptHeader, strconv.Itoa(delivery.Attempt))
+	request.Header.Set(WebhookTimestampHeader, timestamp)
+	request.Header.Set(WebhookSignatureHeader, SignWebhook(webhook.Secret, timestamp, body))
+
+	response, err := wc.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	// drain a bit of the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64*1024))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("unexpected response status %d", response.StatusCode)
+	}
+	return response.StatusCode, nil
+}
@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao/mocks"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
+
+type receivedDelivery struct {
+	header http.Header
+	body   []byte
+}
+
+// newWebhookReceiver starts an httptest receiver answering with the given status codes in turn
+func newWebhookReceiver(t *testing.T, statusCodes ...int) (*httptest.Server, chan receivedDelivery) {
+	received := make(chan receivedDelivery, 10)
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- receivedDelivery{header: r.Header.Clone(), body: body}
+		w.WriteHeader(statusCodes[calls%len(statusCodes)])
+		calls++
+	}))
+	t.Cleanup(server.Close)
+	return server, received
+}
+
+func TestWebhookControllerDelivers(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx := context.Background()
+	webhookService := services.NewWebhookService(mocks.NewWebhookDao())
+	wc := NewWebhookControllerForTesting(&mockLockFactory{}, webhookService, WebhookControllerConfig{})
+	Expect(wc.interval).To(Equal(5 * time.Second))
+
+	server, received := newWebhookReceiver(t, http.StatusNoContent)
+	webhook, svcErr := webhookService.Create(ctx, &api.Webhook{URL: server.URL, Secret: "s3cr3t"})
+	Expect(svcErr).To(BeNil())
+	event := &api.Event{Meta: api.Meta{ID: "event-1"}, Source: "Dinosaurs", SourceID: "rex", EventType: api.CreateEventType}
+	Expect(webhookService.Enqueue(ctx, webhook, event, []byte(`{"event_id": "event-1"}`))).To(BeNil())
+	// an event handled again is not delivered twice
+	Expect(webhookService.Enqueue(ctx, webhook, event, []byte(`{"event_id": "event-1"}`))).To(BeNil())
+
+	due, svcErr := webhookService.FindDueDeliveries(ctx, 10)
+	Expect(svcErr).To(BeNil())
+	Expect(due).To(HaveLen(1))
+	delivery := due[0]
+
+	wc.deliverDue(ctx)
+
+	var got receivedDelivery
+	Expect(received).To(Receive(&got))
+	Expect(received).NotTo(Receive())
+	Expect(got.body).To(MatchJSON(`{"event_id": "event-1"}`))
+	Expect(got.header.Get("Content-Type")).To(Equal("application/json"))
+	Expect(got.header.Get(WebhookIDHeader)).To(Equal(webhook.ID))
+	Expect(got.header.Get(WebhookEventIDHeader)).To(Equal("event-1"))
+	Expect(got.header.Get(WebhookAttemptHeader)).To(Equal("1"))
+	timestamp := got.header.Get(WebhookTimestampHeader)
+	signature := got.header.Get(WebhookSignatureHeader)
+	Expect(signature).To(HavePrefix("sha256="))
+	Expect(VerifyWebhookSignature("s3cr3t", timestamp, got.body, signature)).To(BeTrue())
+	Expect(VerifyWebhookSignature("guess", timestamp, got.body, signature)).To(BeFalse())
+	Expect(VerifyWebhookSignature("s3cr3t", timestamp, []byte(`{"event_id": "event-2"}`), signature)).To(BeFalse())
+
+	Expect(delivery.Status).To(Equal(api.WebhookDeliverySucceeded))
+	Expect(delivery.StatusCode).To(Equal(http.StatusNoContent))
+	Expect(delivery.AttemptedAt).NotTo(BeNil())
+	due, _ = webhookService.FindDueDeliveries(ctx, 10)
+	Expect(due).To(BeEmpty())
+}
+
+func TestWebhookControllerRetries(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx := context.Background()
+	webhookService := services.NewWebhookService(mocks.NewWebhookDao())
+	backoff := &BackoffPolicy{InitialInterval: time.Millisecond, MaxAttempts: 3}
+	wc := NewWebhookControllerForTesting(&mockLockFactory{}, webhookService, WebhookControllerConfig{Backoff: backoff})
+
+	server, received := newWebhookReceiver(t, http.StatusInternalServerError)
+	webhook, _ := webhookService.Create(ctx, &api.Webhook{URL: server.URL, Secret: "s3cr3t"})
+	event := &api.Event{Meta: api.Meta{ID: "event-1"}, Source: "Dinosaurs", SourceID: "rex", EventType: api.UpdateEventType}
+	Expect(webhookService.Enqueue(ctx, webhook, event, []byte(`{}`))).To(BeNil())
+
+	// every failed attempt schedules the next one until the policy is exhausted
+	var attempts []*api.WebhookDelivery
+	for attempt := 1; attempt <= 3; attempt++ {
+		Eventually(func() api.WebhookDeliveryList {
+			due, _ := webhookService.FindDueDeliveries(ctx, 10)
+			return due
+		}).Should(HaveLen(1))
+		due, _ := webhookService.FindDueDeliveries(ctx, 10)
+		Expect(due[0].Attempt).To(Equal(attempt))
+		attempts = append(attempts, due[0])
+
+		wc.deliverDue(ctx)
+		var got receivedDelivery
+		Expect(received).To(Receive(&got))
+		Expect(got.header.Get(WebhookAttemptHeader)).To(Equal(strconv.Itoa(attempt)))
+	}
+
+	Expect(attempts[0].Status).To(Equal(api.WebhookDeliveryFailed))
+	Expect(attempts[0].StatusCode).To(Equal(http.StatusInternalServerError))
+	Expect(attempts[0].Error).To(ContainSubstring("unexpected response status 500"))
+	Expect(attempts[1].Status).To(Equal(api.WebhookDeliveryFailed))
+	Expect(attempts[2].Status).To(Equal(api.WebhookDeliveryDeadLettered))
+	Expect(attempts[1].ScheduledAt).To(BeTemporally(">", *attempts[0].AttemptedAt))
+
+	time.Sleep(10 * time.Millisecond)
+	due, _ := webhookService.FindDueDeliveries(ctx, 10)
+	Expect(due).To(BeEmpty())
+}
+
+func TestWebhookControllerDeletedWebhook(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx := context.Background()
+	webhookService := services.NewWebhookService(mocks.NewWebhookDao())
+	wc := NewWebhookControllerForTesting(&mockLockFactory{}, webhookService, WebhookControllerConfig{})
+
+	webhook := &api.Webhook{Meta: api.Meta{ID: "gone"}, URL: "http://127.0.0.1:1", Secret: "s3cr3t"}
+	event := &api.Event{Meta: api.Meta{ID: "event-1"}, Source: "Dinosaurs", SourceID: "rex", EventType: api.DeleteEventType}
+	Expect(webhookService.Enqueue(ctx, webhook, event, []byte(`{}`))).To(BeNil())
+	due, _ := webhookService.FindDueDeliveries(ctx, 10)
+	Expect(due).To(HaveLen(1))
+
+	// the attempts of a webhook deleted since aren't retried
+	wc.deliverDue(ctx)
+	Expect(due[0].Status).To(Equal(api.WebhookDeliveryDeadLettered))
+	Expect(due[0].Error).To(ContainSubstring("not found"))
+	due, _ = webhookService.FindDueDeliveries(ctx, 10)
+	Expect(due).To(BeEmpty())
+}
@@ -0,0 +1,55 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+)
+
+// AuditEventDao has no Replace nor Delete, audit events are append-only
+type AuditEventDao interface {
+	Get(ctx context.Context, id string) (*api.AuditEvent, error)
+	Create(ctx context.Context, auditEvent *api.AuditEvent) (*api.AuditEvent, error)
+	// DeleteCreatedBefore removes all audit events created before the cutoff and returns how many there were
+	DeleteCreatedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+var _ AuditEventDao = &sqlAuditEventDao{}
+
+type sqlAuditEventDao struct {
+	sessionFactory *db.SessionFactory
+}
+
+func NewAuditEventDao(sessionFactory *db.SessionFactory) AuditEventDao {
+	return &sqlAuditEventDao{sessionFactory: sessionFactory}
+}
+
+func (d *sqlAuditEventDao) Get(ctx context.Context, id string) (*api.AuditEvent, error) {
+	g2 := (*d.sessionFactory).New(ctx).Scopes(TenantScope(ctx))
+	var auditEvent api.AuditEvent
+	if err := g2.Take(&auditEvent, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &auditEvent, nil
+}
+
+func (d *sqlAuditEventDao) Create(ctx context.Context, auditEvent *api.AuditEvent) (*api.AuditEvent, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Create(auditEvent).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return auditEvent, nil
+}
+
+func (d *sqlAuditEventDao) DeleteCreatedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	result := g2.Where("created_at < ?", cutoff).Delete(&api.AuditEvent{})
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -3,35 +3,72 @@ package dao
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/pkg/eventbus"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 )
 
 type EventDao interface {
 	Get(ctx context.Context, id string) (*api.Event, error)
+	// GetBySeq returns the event numbered seq, see api.Event
+	GetBySeq(ctx context.Context, seq int64) (*api.Event, error)
 	Create(ctx context.Context, event *api.Event) (*api.Event, error)
+	// CreateBatch inserts events with a single statement
+	CreateBatch(ctx context.Context, events api.EventList) (api.EventList, error)
 	Replace(ctx context.Context, event *api.Event) (*api.Event, error)
 	Delete(ctx context.Context, id string) error
 	FindByIDs(ctx context.Context, ids []string) (api.EventList, error)
 	All(ctx context.Context) (api.EventList, error)
-	
+	// Notify announces the event on the event bus once the caller's transaction commits
+	Notify(ctx context.Context, event *api.Event)
+
 	// Sync-the-world methods for missed event recovery
 	FindUnreconciled(ctx context.Context, olderThan time.Duration) (api.EventList, error)
 	FindBySourceAndType(ctx context.Context, source string, eventType api.EventType) (api.EventList, error)
+
+	// FindBySourceAfter returns up to limit events of source numbered after afterSeq, in the order they were recorded
+	FindBySourceAfter(ctx context.Context, source string, afterSeq int64, limit int) (api.EventList, error)
+
+	// FindPending returns the events of source that are neither reconciled nor dead-lettered and are due
+	FindPending(ctx context.Context, source string) (api.EventList, error)
+	// Requeue clears the retry state of the unreconciled events of source, dead-lettered ones included,
+	// records reprocessedBy as the user who reprocessed them and returns the events it changed
+	Requeue(ctx context.Context, source, reprocessedBy string) (api.EventList, error)
+	// Stats returns the backlog of the given sources, of all sources with events when none are given
+	Stats(ctx context.Context, sources ...string) ([]*api.EventStats, error)
+
+	// DeleteReconciled deletes up to limit events reconciled before reconciledBefore, ArchiveReconciled moves
+	// them to the event_archives table. Both return the number of events removed from the events table.
+	DeleteReconciled(ctx context.Context, reconciledBefore time.Time, limit int) (int64, error)
+	ArchiveReconciled(ctx context.Context, reconciledBefore time.Time, limit int) (int64, error)
+	// FindRedundantUpdates returns the pending Update events of up to limit resources with more than one of
+	// them, ordered by resource and creation time
+	FindRedundantUpdates(ctx context.Context, limit int) (api.EventList, error)
+
+	// GetPause returns the pause of source, gorm.ErrRecordNotFound when its controllers aren't paused
+	GetPause(ctx context.Context, source string) (*api.EventPause, error)
+	FindPauses(ctx context.Context) ([]*api.EventPause, error)
+	// Pause records pause unless its source is paused already
+	Pause(ctx context.Context, pause *api.EventPause) error
+	Resume(ctx context.Context, source string) error
 }
 
 var _ EventDao = &sqlEventDao{}
 
 type sqlEventDao struct {
 	sessionFactory *db.SessionFactory
+	bus            eventbus.EventBus
 }
 
-func NewEventDao(sessionFactory *db.SessionFactory) EventDao {
-	return &sqlEventDao{sessionFactory: sessionFactory}
+func NewEventDao(sessionFactory *db.SessionFactory, bus eventbus.EventBus) EventDao {
+	return &sqlEventDao{sessionFactory: sessionFactory, bus: bus}
 }
 
 func (d *sqlEventDao) Get(ctx context.Context, id string) (*api.Event, error) {
@@ -43,6 +80,15 @@ func (d *sqlEventDao) Get(ctx context.Context, id string) (*api.Event, error) {
 	return &event, nil
 }
 
+func (d *sqlEventDao) GetBySeq(ctx context.Context, seq int64) (*api.Event, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	var event api.Event
+	if err := g2.Take(&event, "seq = ?", seq).Error; err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
 func (d *sqlEventDao) Create(ctx context.Context, event *api.Event) (*api.Event, error) {
 	g2 := (*d.sessionFactory).New(ctx)
 	if err := g2.Omit(clause.Associations).Create(event).Error; err != nil {
@@ -50,14 +96,33 @@ func (d *sqlEventDao) Create(ctx context.Context, event *api.Event) (*api.Event,
 		return nil, err
 	}
 
-	notify := fmt.Sprintf("select pg_notify('%s', '%s')", "events", event.ID)
+	// The event row is written in the caller's transaction (the outbox). Listeners are only told about
+	// it once that transaction commits, so they never see events for rolled back writes. Should the
+	// notification itself be lost, the sync controller still finds the unreconciled row.
+	d.Notify(ctx, event)
 
-	err := g2.Exec(notify).Error
-	if err != nil {
+	return event, nil
+}
+
+func (d *sqlEventDao) CreateBatch(ctx context.Context, events api.EventList) (api.EventList, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Omit(clause.Associations).Create(&events).Error; err != nil {
+		db.MarkForRollback(ctx, err)
 		return nil, err
 	}
+	for _, event := range events {
+		d.Notify(ctx, event)
+	}
+	return events, nil
+}
 
-	return event, nil
+func (d *sqlEventDao) Notify(ctx context.Context, event *api.Event) {
+	msg := eventbus.NewMessage(event)
+	db.AfterCommit(ctx, func() {
+		if err := d.bus.Publish(ctx, msg); err != nil {
+			logger.NewLogger(ctx).Extra("event_id", msg.ID).Error(fmt.Sprintf("Unable to announce event on the event bus: %v", err))
+		}
+	})
 }
 
 func (d *sqlEventDao) Replace(ctx context.Context, event *api.Event) (*api.Event, error) {
@@ -101,8 +166,11 @@ func (d *sqlEventDao) FindUnreconciled(ctx context.Context, olderThan time.Durat
 	events := api.EventList{}
 	cutoff := time.Now().Add(-olderThan)
 	
-	// Find events where ReconciledDate is NULL and created_at is older than the cutoff
-	if err := g2.Where("reconciled_date IS NULL AND created_at < ?", cutoff).
+	// Find events that are neither reconciled nor dead-lettered and are due: failed events once their
+	// backoff elapsed, all others once they are older than the cutoff. Paused sources are left alone.
+	if err := g2.Where("reconciled_date IS NULL AND dead_lettered_at IS NULL AND "+
+		"((next_attempt_at IS NULL AND created_at < ?) OR next_attempt_at <= ?)", cutoff, time.Now()).
+		Where("source NOT IN (SELECT source FROM event_pauses)").
 		Order("created_at ASC").
 		Find(&events).Error; err != nil {
 		return nil, err
@@ -121,3 +189,153 @@ func (d *sqlEventDao) FindBySourceAndType(ctx context.Context, source string, ev
 	}
 	return events, nil
 }
+
+func (d *sqlEventDao) FindBySourceAfter(ctx context.Context, source string, afterSeq int64, limit int) (api.EventList, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	events := api.EventList{}
+
+	// creation times come from the clocks of the replicas, only the sequence orders events reliably
+	if err := g2.Where("source = ? AND seq > ?", source, afterSeq).
+		Order("seq ASC").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (d *sqlEventDao) FindPending(ctx context.Context, source string) (api.EventList, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	events := api.EventList{}
+
+	if err := g2.Where("source = ? AND reconciled_date IS NULL AND dead_lettered_at IS NULL AND "+
+		"(next_attempt_at IS NULL OR next_attempt_at <= ?)", source, time.Now()).
+		Order("created_at ASC").
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (d *sqlEventDao) Requeue(ctx context.Context, source, reprocessedBy string) (api.EventList, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	events := api.EventList{}
+
+	// a single statement, so an event reconciled meanwhile is neither reset nor handed back
+	now := time.Now()
+	if err := g2.Raw("UPDATE events SET attempts = 0, next_attempt_at = NULL, dead_lettered_at = NULL, "+
+		"reprocessed_by = ?, reprocessed_at = ?, updated_at = ? "+
+		"WHERE source = ? AND reconciled_date IS NULL AND deleted_at IS NULL RETURNING *", reprocessedBy, now, now, source).
+		Scan(&events).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return events, nil
+}
+
+func (d *sqlEventDao) Stats(ctx context.Context, sources ...string) ([]*api.EventStats, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	stats := []*api.EventStats{}
+
+	const pending = "reconciled_date IS NULL AND dead_lettered_at IS NULL"
+	query := g2.Model(&api.Event{}).Select("source, count(*) AS total, " +
+		"count(*) FILTER (WHERE " + pending + ") AS pending, " +
+		"count(*) FILTER (WHERE " + pending + " AND attempts > 0) AS retrying, " +
+		"count(dead_lettered_at) AS dead_lettered, " +
+		"min(created_at) FILTER (WHERE " + pending + ") AS oldest_pending_at")
+	if len(sources) > 0 {
+		query = query.Where("source IN (?)", sources)
+	}
+	if err := query.Group("source").Order("source").Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (d *sqlEventDao) GetPause(ctx context.Context, source string) (*api.EventPause, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	var pause api.EventPause
+	if err := g2.Take(&pause, "source = ?", source).Error; err != nil {
+		return nil, err
+	}
+	return &pause, nil
+}
+
+func (d *sqlEventDao) FindPauses(ctx context.Context) ([]*api.EventPause, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	pauses := []*api.EventPause{}
+	if err := g2.Order("source").Find(&pauses).Error; err != nil {
+		return nil, err
+	}
+	return pauses, nil
+}
+
+func (d *sqlEventDao) Pause(ctx context.Context, pause *api.EventPause) error {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Clauses(clause.OnConflict{DoNothing: true}).Create(pause).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (d *sqlEventDao) Resume(ctx context.Context, source string) error {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Where("source = ?", source).Delete(&api.EventPause{}).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (d *sqlEventDao) DeleteReconciled(ctx context.Context, reconciledBefore time.Time, limit int) (int64, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	result := g2.Exec("DELETE FROM events WHERE id IN "+
+		"(SELECT id FROM events WHERE reconciled_date < ? ORDER BY reconciled_date LIMIT ?)", reconciledBefore, limit)
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+func (d *sqlEventDao) ArchiveReconciled(ctx context.Context, reconciledBefore time.Time, limit int) (int64, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+
+	// every column of the events is archived, the event_archives table has each of them
+	events := &gorm.Statement{DB: g2}
+	if err := events.Parse(&api.Event{}); err != nil {
+		return 0, err
+	}
+	columns := strings.Join(events.Schema.DBNames, ", ")
+
+	// the events are deleted and archived by a single statement, so none is lost or kept twice
+	result := g2.Exec("WITH archived AS (DELETE FROM events WHERE id IN "+
+		"(SELECT id FROM events WHERE reconciled_date < ? ORDER BY reconciled_date LIMIT ?) RETURNING "+columns+") "+
+		"INSERT INTO event_archives ("+columns+", archived_at) SELECT "+columns+", now() FROM archived", reconciledBefore, limit)
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+func (d *sqlEventDao) FindRedundantUpdates(ctx context.Context, limit int) (api.EventList, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	events := api.EventList{}
+
+	const pendingUpdates = "event_type = ? AND reconciled_date IS NULL AND dead_lettered_at IS NULL"
+	resources := (*d.sessionFactory).New(ctx).Model(&api.Event{}).
+		Select("source, source_id").
+		Where(pendingUpdates, api.UpdateEventType).
+		Group("source, source_id").
+		Having("count(*) > 1").
+		Limit(limit)
+	if err := g2.Where(pendingUpdates, api.UpdateEventType).
+		Where("(source, source_id) IN (?)", resources).
+		Order("source ASC, source_id ASC, created_at ASC, id ASC").
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
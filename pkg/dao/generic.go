@@ -6,6 +6,7 @@ import (
 
 	"github.com/jinzhu/inflection"
 	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
 )
@@ -27,6 +28,7 @@ type GenericDao interface {
 
 	GetInstanceDao(ctx context.Context, model interface{}) GenericDao
 	Preload(preload string)
+	Unscoped()
 	OrderBy(orderBy string)
 	Joins(sql string)
 	Group(sql string)
@@ -36,6 +38,7 @@ type GenericDao interface {
 
 	GetTableName() string
 	GetTableRelation(fieldName string) (TableRelation, bool)
+	GetColumnField(column string) (*schema.Field, bool)
 }
 
 var _ GenericDao = &sqlGenericDao{}
@@ -47,11 +50,19 @@ type sqlGenericDao struct {
 
 // TableRelation represents a relationship between two tables. They can be joined,
 // ON TableName.ColumnName = ForeignTableName.ForeignColumnName
+// or, for many-to-many relationships, through their join table,
+// ON TableName.ColumnName = JoinTableName.JoinColumnName
+// and ON JoinTableName.JoinForeignColumnName = ForeignTableName.ForeignColumnName
 type TableRelation struct {
-	TableName         string
-	ColumnName        string
-	ForeignTableName  string
-	ForeignColumnName string
+	// FieldName is the field of the model holding the related resource, e.g. Dinosaur
+	FieldName             string
+	TableName             string
+	ColumnName            string
+	ForeignTableName      string
+	ForeignColumnName     string
+	JoinTableName         string
+	JoinColumnName        string
+	JoinForeignColumnName string
 }
 
 func NewGenericDao(sessionFactory *db.SessionFactory) GenericDao {
@@ -73,6 +84,11 @@ func (d *sqlGenericDao) Preload(preload string) {
 	d.g2 = d.g2.Preload(preload)
 }
 
+// Unscoped includes soft-deleted rows
+func (d *sqlGenericDao) Unscoped() {
+	d.g2 = d.g2.Unscoped()
+}
+
 func (d *sqlGenericDao) OrderBy(orderBy string) {
 	d.g2 = d.g2.Order(orderBy)
 }
@@ -95,10 +111,13 @@ func (d *sqlGenericDao) Count(model interface{}, total *int64) {
 	// Considers existing joins and search params from previous session
 	if len(d.g2.Statement.Joins) > 0 {
 		g2.Statement.Joins = d.g2.Statement.Joins
+		// a resource joined to several related rows is counted once
+		g2 = g2.Distinct(db.GetTableName(d.g2) + ".id")
 	}
 	if where, ok := d.g2.Statement.Clauses["WHERE"]; ok {
 		g2.Statement.Clauses["WHERE"] = where
 	}
+	g2.Statement.Unscoped = d.g2.Statement.Unscoped
 	g2.Count(total)
 }
 
@@ -115,6 +134,23 @@ func (d *sqlGenericDao) GetTableName() string {
 	return db.GetTableName(d.g2)
 }
 
+// GetColumnField returns the model field stored in a column of the resource table.
+// The column may be qualified with the resource table name; columns of other tables are not found.
+func (d *sqlGenericDao) GetColumnField(column string) (*schema.Field, bool) {
+	if d.g2.Statement.Parse(d.g2.Statement.Model) != nil || d.g2.Statement.Schema == nil {
+		return nil, false
+	}
+	modelSchema := d.g2.Statement.Schema
+	if parts := strings.Split(column, "."); len(parts) == 2 {
+		if parts[0] != modelSchema.Table {
+			return nil, false
+		}
+		column = parts[1]
+	}
+	field, ok := modelSchema.FieldsByDBName[column]
+	return field, ok
+}
+
 // extract the relation from the api model
 func (d *sqlGenericDao) GetTableRelation(fieldName string) (TableRelation, bool) {
 	// try singular
@@ -131,8 +167,11 @@ func (d *sqlGenericDao) GetTableRelation(fieldName string) (TableRelation, bool)
 		}
 	}
 
+	if association.Relationship.Type == "many_to_many" {
+		return manyToManyRelation(association.Relationship), true
+	}
 	if association.Relationship.Type != "belongs_to" && association.Relationship.Type != "has_many" {
-		// we don't use has_one or many_to_many relations
+		// we don't use has_one relations
 		return TableRelation{}, false
 	}
 
@@ -144,9 +183,31 @@ func (d *sqlGenericDao) GetTableRelation(fieldName string) (TableRelation, bool)
 	}
 
 	return TableRelation{
+		FieldName:         association.Relationship.Name,
 		TableName:         association.Relationship.Field.Schema.Table,
 		ForeignTableName:  association.Relationship.FieldSchema.Table,
 		ForeignColumnName: foreignColumnName,
 		ColumnName:        columnName,
 	}, true
 }
+
+// manyToManyRelation joins the tables of a many2many field through its join table, whose references are
+// the column of the model's own primary key first and the column of the related primary key second
+func manyToManyRelation(relationship *schema.Relationship) TableRelation {
+	relation := TableRelation{
+		FieldName:        relationship.Name,
+		TableName:        relationship.Field.Schema.Table,
+		ForeignTableName: relationship.FieldSchema.Table,
+		JoinTableName:    relationship.JoinTable.Table,
+	}
+	for _, reference := range relationship.References {
+		if reference.OwnPrimaryKey {
+			relation.ColumnName = reference.PrimaryKey.DBName
+			relation.JoinColumnName = reference.ForeignKey.DBName
+		} else {
+			relation.ForeignColumnName = reference.PrimaryKey.DBName
+			relation.JoinForeignColumnName = reference.ForeignKey.DBName
+		}
+	}
+	return relation
+}
@@ -0,0 +1,65 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+)
+
+type IdempotencyKeyDao interface {
+	Get(ctx context.Context, scope, key string) (*api.IdempotencyKey, error)
+	Create(ctx context.Context, idempotencyKey *api.IdempotencyKey) (*api.IdempotencyKey, error)
+	// DeleteExpired removes key of scope if it was created before the cutoff, so that it can be used again
+	DeleteExpired(ctx context.Context, scope, key string, cutoff time.Time) error
+	// DeleteCreatedBefore removes all keys created before the cutoff and returns how many there were
+	DeleteCreatedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+var _ IdempotencyKeyDao = &sqlIdempotencyKeyDao{}
+
+type sqlIdempotencyKeyDao struct {
+	sessionFactory *db.SessionFactory
+}
+
+func NewIdempotencyKeyDao(sessionFactory *db.SessionFactory) IdempotencyKeyDao {
+	return &sqlIdempotencyKeyDao{sessionFactory: sessionFactory}
+}
+
+func (d *sqlIdempotencyKeyDao) Get(ctx context.Context, scope, key string) (*api.IdempotencyKey, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	var idempotencyKey api.IdempotencyKey
+	if err := g2.Take(&idempotencyKey, "scope = ? AND key = ?", scope, key).Error; err != nil {
+		return nil, err
+	}
+	return &idempotencyKey, nil
+}
+
+func (d *sqlIdempotencyKeyDao) Create(ctx context.Context, idempotencyKey *api.IdempotencyKey) (*api.IdempotencyKey, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Create(idempotencyKey).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return idempotencyKey, nil
+}
+
+func (d *sqlIdempotencyKeyDao) DeleteExpired(ctx context.Context, scope, key string, cutoff time.Time) error {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Where("scope = ? AND key = ? AND created_at < ?", scope, key, cutoff).Delete(&api.IdempotencyKey{}).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (d *sqlIdempotencyKeyDao) DeleteCreatedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	result := g2.Where("created_at < ?", cutoff).Delete(&api.IdempotencyKey{})
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+)
+
+var _ dao.AuditEventDao = &auditEventDaoMock{}
+
+type auditEventDaoMock struct {
+	auditEvents api.AuditEventList
+}
+
+func NewAuditEventDao() *auditEventDaoMock {
+	return &auditEventDaoMock{}
+}
+
+func (d *auditEventDaoMock) Get(ctx context.Context, id string) (*api.AuditEvent, error) {
+	for _, auditEvent := range d.auditEvents {
+		if auditEvent.ID == id {
+			return auditEvent, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (d *auditEventDaoMock) Create(ctx context.Context, auditEvent *api.AuditEvent) (*api.AuditEvent, error) {
+	if auditEvent.ID == "" {
+		auditEvent.ID = api.NewID()
+	}
+	if auditEvent.CreatedAt.IsZero() {
+		auditEvent.CreatedAt = time.Now()
+	}
+	d.auditEvents = append(d.auditEvents, auditEvent)
+	return auditEvent, nil
+}
+
+func (d *auditEventDaoMock) DeleteCreatedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	kept := api.AuditEventList{}
+	for _, auditEvent := range d.auditEvents {
+		if !auditEvent.CreatedAt.Before(cutoff) {
+			kept = append(kept, auditEvent)
+		}
+	}
+	deleted := int64(len(d.auditEvents) - len(kept))
+	d.auditEvents = kept
+	return deleted, nil
+}
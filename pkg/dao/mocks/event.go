@@ -2,19 +2,23 @@ package mocks
 
 import (
 	"context"
+	"slices"
+	"sort"
 	"time"
 
 	"gorm.io/gorm"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
 	"github.com/openshift-online/rh-trex-ai/pkg/dao"
-	"github.com/openshift-online/rh-trex-ai/pkg/errors"
 )
 
 var _ dao.EventDao = &eventDaoMock{}
 
 type eventDaoMock struct {
-	events api.EventList
+	events   api.EventList
+	pauses   []*api.EventPause
+	archived api.EventList
+	seq      int64
 }
 
 func NewEventDao() *eventDaoMock {
@@ -30,13 +34,39 @@ func (d *eventDaoMock) Get(ctx context.Context, id string) (*api.Event, error) {
 	return nil, gorm.ErrRecordNotFound
 }
 
+func (d *eventDaoMock) GetBySeq(ctx context.Context, seq int64) (*api.Event, error) {
+	for _, event := range d.events {
+		if event.Seq == seq {
+			return event, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
 func (d *eventDaoMock) Create(ctx context.Context, event *api.Event) (*api.Event, error) {
+	d.seq++
+	event.Seq = d.seq
 	d.events = append(d.events, event)
 	return event, nil
 }
 
+func (d *eventDaoMock) CreateBatch(ctx context.Context, events api.EventList) (api.EventList, error) {
+	for _, event := range events {
+		d.seq++
+		event.Seq = d.seq
+	}
+	d.events = append(d.events, events...)
+	return events, nil
+}
+
 func (d *eventDaoMock) Replace(ctx context.Context, event *api.Event) (*api.Event, error) {
-	return nil, errors.NotImplemented("Event").AsError()
+	for i, e := range d.events {
+		if e.ID == event.ID {
+			d.events[i] = event
+			return event, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
 }
 
 func (d *eventDaoMock) Delete(ctx context.Context, id string) error {
@@ -54,19 +84,36 @@ func (d *eventDaoMock) Delete(ctx context.Context, id string) error {
 }
 
 func (d *eventDaoMock) FindByIDs(ctx context.Context, ids []string) (api.EventList, error) {
-	return nil, errors.NotImplemented("Event").AsError()
+	result := api.EventList{}
+	for _, event := range d.events {
+		if slices.Contains(ids, event.ID) {
+			result = append(result, event)
+		}
+	}
+	return result, nil
 }
 
 func (d *eventDaoMock) All(ctx context.Context) (api.EventList, error) {
 	return d.events, nil
 }
 
+func (d *eventDaoMock) Notify(ctx context.Context, event *api.Event) {
+}
+
 func (d *eventDaoMock) FindUnreconciled(ctx context.Context, olderThan time.Duration) (api.EventList, error) {
-	cutoff := time.Now().Add(-olderThan)
+	now := time.Now()
+	cutoff := now.Add(-olderThan)
 	result := api.EventList{}
 	
 	for _, event := range d.events {
-		if event.ReconciledDate == nil && event.CreatedAt.Before(cutoff) {
+		if event.ReconciledDate != nil || event.DeadLettered() || d.paused(event.Source) {
+			continue
+		}
+		due := event.CreatedAt.Before(cutoff)
+		if event.NextAttemptAt != nil {
+			due = !event.NextAttemptAt.After(now)
+		}
+		if due {
 			result = append(result, event)
 		}
 	}
@@ -83,3 +130,174 @@ func (d *eventDaoMock) FindBySourceAndType(ctx context.Context, source string, e
 	}
 	return result, nil
 }
+
+func (d *eventDaoMock) FindBySourceAfter(ctx context.Context, source string, afterSeq int64, limit int) (api.EventList, error) {
+	result := api.EventList{}
+	for _, event := range d.events {
+		if event.Source == source && event.Seq > afterSeq && len(result) < limit {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+func (d *eventDaoMock) FindPending(ctx context.Context, source string) (api.EventList, error) {
+	now := time.Now()
+	result := api.EventList{}
+	for _, event := range d.events {
+		if event.Source != source || event.ReconciledDate != nil || event.DeadLettered() {
+			continue
+		}
+		if event.NextAttemptAt == nil || !event.NextAttemptAt.After(now) {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+func (d *eventDaoMock) Requeue(ctx context.Context, source, reprocessedBy string) (api.EventList, error) {
+	result := api.EventList{}
+	now := time.Now()
+	for _, event := range d.events {
+		if event.Source == source && event.ReconciledDate == nil {
+			event.Attempts = 0
+			event.NextAttemptAt = nil
+			event.DeadLetteredAt = nil
+			event.ReprocessedBy = reprocessedBy
+			event.ReprocessedAt = &now
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+func (d *eventDaoMock) Stats(ctx context.Context, sources ...string) ([]*api.EventStats, error) {
+	index := map[string]*api.EventStats{}
+	result := []*api.EventStats{}
+	for _, event := range d.events {
+		if len(sources) > 0 && !slices.Contains(sources, event.Source) {
+			continue
+		}
+		stats, found := index[event.Source]
+		if !found {
+			stats = &api.EventStats{Source: event.Source}
+			index[event.Source] = stats
+			result = append(result, stats)
+		}
+		stats.Total++
+		switch {
+		case event.DeadLettered():
+			stats.DeadLettered++
+		case event.ReconciledDate == nil:
+			stats.Pending++
+			if event.Attempts > 0 {
+				stats.Retrying++
+			}
+			if stats.OldestPendingAt == nil || event.CreatedAt.Before(*stats.OldestPendingAt) {
+				createdAt := event.CreatedAt
+				stats.OldestPendingAt = &createdAt
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Source < result[j].Source })
+	return result, nil
+}
+
+func (d *eventDaoMock) paused(source string) bool {
+	for _, pause := range d.pauses {
+		if pause.Source == source {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *eventDaoMock) GetPause(ctx context.Context, source string) (*api.EventPause, error) {
+	for _, pause := range d.pauses {
+		if pause.Source == source {
+			return pause, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (d *eventDaoMock) FindPauses(ctx context.Context) ([]*api.EventPause, error) {
+	return d.pauses, nil
+}
+
+func (d *eventDaoMock) Pause(ctx context.Context, pause *api.EventPause) error {
+	if !d.paused(pause.Source) {
+		pause.CreatedAt = time.Now()
+		d.pauses = append(d.pauses, pause)
+	}
+	return nil
+}
+
+func (d *eventDaoMock) Resume(ctx context.Context, source string) error {
+	pauses := []*api.EventPause{}
+	for _, pause := range d.pauses {
+		if pause.Source != source {
+			pauses = append(pauses, pause)
+		}
+	}
+	d.pauses = pauses
+	return nil
+}
+
+func (d *eventDaoMock) DeleteReconciled(ctx context.Context, reconciledBefore time.Time, limit int) (int64, error) {
+	removed := d.removeReconciled(reconciledBefore, limit)
+	return int64(len(removed)), nil
+}
+
+func (d *eventDaoMock) ArchiveReconciled(ctx context.Context, reconciledBefore time.Time, limit int) (int64, error) {
+	removed := d.removeReconciled(reconciledBefore, limit)
+	d.archived = append(d.archived, removed...)
+	return int64(len(removed)), nil
+}
+
+// Archived returns the events moved out by ArchiveReconciled
+func (d *eventDaoMock) Archived() api.EventList {
+	return d.archived
+}
+
+func (d *eventDaoMock) removeReconciled(reconciledBefore time.Time, limit int) api.EventList {
+	kept := api.EventList{}
+	removed := api.EventList{}
+	for _, event := range d.events {
+		if event.ReconciledDate != nil && event.ReconciledDate.Before(reconciledBefore) && len(removed) < limit {
+			removed = append(removed, event)
+		} else {
+			kept = append(kept, event)
+		}
+	}
+	d.events = kept
+	return removed
+}
+
+func (d *eventDaoMock) FindRedundantUpdates(ctx context.Context, limit int) (api.EventList, error) {
+	pending := map[string]api.EventList{}
+	for _, event := range d.events {
+		if event.EventType == api.UpdateEventType && event.ReconciledDate == nil && !event.DeadLettered() {
+			key := event.Source + "/" + event.SourceID
+			pending[key] = append(pending[key], event)
+		}
+	}
+	keys := []string{}
+	for key, events := range pending {
+		if len(events) > 1 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	if len(keys) > limit {
+		keys = keys[:limit]
+	}
+
+	result := api.EventList{}
+	for _, key := range keys {
+		events := pending[key]
+		sort.SliceStable(events, func(i, j int) bool { return events[i].CreatedAt.Before(events[j].CreatedAt) })
+		result = append(result, events...)
+	}
+	return result, nil
+}
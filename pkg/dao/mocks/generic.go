@@ -3,18 +3,21 @@ package mocks
 import (
 	"context"
 
+	"gorm.io/gorm/schema"
+
 	"github.com/openshift-online/rh-trex-ai/pkg/dao"
 )
 
 var _ dao.GenericDao = &genericDaoMock{}
 
 type genericDaoMock struct {
-	preload string
-	orderBy string
-	joins   string
-	group   string
-	wheres  []dao.Where
-	model   interface{}
+	preload  string
+	unscoped bool
+	orderBy  string
+	joins    string
+	group    string
+	wheres   []dao.Where
+	model    interface{}
 }
 
 func NewGenericDao() *genericDaoMock {
@@ -39,6 +42,10 @@ func (g *genericDaoMock) Preload(preload string) {
 	g.preload = preload
 }
 
+func (g *genericDaoMock) Unscoped() {
+	g.unscoped = true
+}
+
 func (g *genericDaoMock) OrderBy(orderBy string) {
 	g.orderBy = orderBy
 }
@@ -74,3 +81,8 @@ func (g *genericDaoMock) GetTableRelation(fieldName string) (dao.TableRelation,
 	// Mock implementation - returns empty relation and false
 	return dao.TableRelation{}, false
 }
+
+func (g *genericDaoMock) GetColumnField(column string) (*schema.Field, bool) {
+	// Mock implementation - returns no field and false
+	return nil, false
+}
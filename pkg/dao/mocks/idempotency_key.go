@@ -0,0 +1,70 @@
+package mocks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+)
+
+var _ dao.IdempotencyKeyDao = &idempotencyKeyDaoMock{}
+
+type idempotencyKeyDaoMock struct {
+	idempotencyKeys api.IdempotencyKeyList
+}
+
+func NewIdempotencyKeyDao() *idempotencyKeyDaoMock {
+	return &idempotencyKeyDaoMock{}
+}
+
+func (d *idempotencyKeyDaoMock) Get(ctx context.Context, scope, key string) (*api.IdempotencyKey, error) {
+	for _, idempotencyKey := range d.idempotencyKeys {
+		if idempotencyKey.Scope == scope && idempotencyKey.Key == key {
+			return idempotencyKey, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (d *idempotencyKeyDaoMock) Create(ctx context.Context, idempotencyKey *api.IdempotencyKey) (*api.IdempotencyKey, error) {
+	if _, err := d.Get(ctx, idempotencyKey.Scope, idempotencyKey.Key); err == nil {
+		return nil, fmt.Errorf("duplicate key value violates unique constraint \"idx_idempotency_keys_scope_key\"")
+	}
+	if idempotencyKey.ID == "" {
+		idempotencyKey.ID = api.NewID()
+	}
+	if idempotencyKey.CreatedAt.IsZero() {
+		idempotencyKey.CreatedAt = time.Now()
+	}
+	d.idempotencyKeys = append(d.idempotencyKeys, idempotencyKey)
+	return idempotencyKey, nil
+}
+
+func (d *idempotencyKeyDaoMock) DeleteExpired(ctx context.Context, scope, key string, cutoff time.Time) error {
+	d.deleteWhere(func(idempotencyKey *api.IdempotencyKey) bool {
+		return idempotencyKey.Scope == scope && idempotencyKey.Key == key && idempotencyKey.CreatedAt.Before(cutoff)
+	})
+	return nil
+}
+
+func (d *idempotencyKeyDaoMock) DeleteCreatedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	return d.deleteWhere(func(idempotencyKey *api.IdempotencyKey) bool {
+		return idempotencyKey.CreatedAt.Before(cutoff)
+	}), nil
+}
+
+func (d *idempotencyKeyDaoMock) deleteWhere(match func(*api.IdempotencyKey) bool) int64 {
+	kept := api.IdempotencyKeyList{}
+	for _, idempotencyKey := range d.idempotencyKeys {
+		if !match(idempotencyKey) {
+			kept = append(kept, idempotencyKey)
+		}
+	}
+	deleted := int64(len(d.idempotencyKeys) - len(kept))
+	d.idempotencyKeys = kept
+	return deleted
+}
@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+)
+
+var _ dao.RelationshipDao = &relationshipDaoMock{}
+
+type relationshipDaoMock struct {
+	// rows holds the column values of the live rows of each table
+	rows map[string][]map[string]string
+}
+
+func NewRelationshipDao() *relationshipDaoMock {
+	return &relationshipDaoMock{rows: map[string][]map[string]string{}}
+}
+
+// Insert adds a live row with the column values to table, the id column included
+func (d *relationshipDaoMock) Insert(table string, columns map[string]string) {
+	d.rows[table] = append(d.rows[table], columns)
+}
+
+func (d *relationshipDaoMock) Exists(ctx context.Context, table, id string) (bool, error) {
+	count, err := d.CountReferences(ctx, table, "id", id)
+	return count > 0, err
+}
+
+func (d *relationshipDaoMock) CountReferences(ctx context.Context, table, column, id string) (int64, error) {
+	var count int64
+	for _, row := range d.rows[table] {
+		if row[column] == id {
+			count++
+		}
+	}
+	return count, nil
+}
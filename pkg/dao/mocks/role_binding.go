@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+)
+
+var _ dao.RoleBindingDao = &roleBindingDaoMock{}
+
+type roleBindingDaoMock struct {
+	roleBindings api.RoleBindingList
+}
+
+func NewRoleBindingDao() *roleBindingDaoMock {
+	return &roleBindingDaoMock{}
+}
+
+func (d *roleBindingDaoMock) Get(ctx context.Context, id string) (*api.RoleBinding, error) {
+	for _, roleBinding := range d.roleBindings {
+		if roleBinding.ID == id {
+			return roleBinding, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (d *roleBindingDaoMock) Create(ctx context.Context, roleBinding *api.RoleBinding) (*api.RoleBinding, error) {
+	if roleBinding.ID == "" {
+		roleBinding.ID = api.NewID()
+	}
+	d.roleBindings = append(d.roleBindings, roleBinding)
+	return roleBinding, nil
+}
+
+func (d *roleBindingDaoMock) Delete(ctx context.Context, id string) error {
+	for i, roleBinding := range d.roleBindings {
+		if roleBinding.ID == id {
+			d.roleBindings = append(d.roleBindings[:i], d.roleBindings[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (d *roleBindingDaoMock) FindByUsername(ctx context.Context, username string) (api.RoleBindingList, error) {
+	roleBindings := api.RoleBindingList{}
+	for _, roleBinding := range d.roleBindings {
+		if roleBinding.Username == username {
+			roleBindings = append(roleBindings, roleBinding)
+		}
+	}
+	return roleBindings, nil
+}
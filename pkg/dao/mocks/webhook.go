@@ -0,0 +1,129 @@
+package mocks
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+)
+
+var _ dao.WebhookDao = &webhookDaoMock{}
+
+type webhookDaoMock struct {
+	webhooks   api.WebhookList
+	deliveries api.WebhookDeliveryList
+}
+
+func NewWebhookDao() *webhookDaoMock {
+	return &webhookDaoMock{}
+}
+
+func (d *webhookDaoMock) Get(ctx context.Context, id string) (*api.Webhook, error) {
+	for _, webhook := range d.webhooks {
+		if webhook.ID == id {
+			return webhook, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (d *webhookDaoMock) Create(ctx context.Context, webhook *api.Webhook) (*api.Webhook, error) {
+	if webhook.ID == "" {
+		webhook.ID = api.NewID()
+	}
+	d.webhooks = append(d.webhooks, webhook)
+	return webhook, nil
+}
+
+func (d *webhookDaoMock) Replace(ctx context.Context, webhook *api.Webhook) (*api.Webhook, error) {
+	for i, existing := range d.webhooks {
+		if existing.ID == webhook.ID {
+			d.webhooks[i] = webhook
+			return webhook, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (d *webhookDaoMock) Delete(ctx context.Context, id string) error {
+	for i, webhook := range d.webhooks {
+		if webhook.ID == id {
+			d.webhooks = append(d.webhooks[:i], d.webhooks[i+1:]...)
+			kept := api.WebhookDeliveryList{}
+			for _, delivery := range d.deliveries {
+				if delivery.WebhookID != id {
+					kept = append(kept, delivery)
+				}
+			}
+			d.deliveries = kept
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (d *webhookDaoMock) FindBySource(ctx context.Context, source string) (api.WebhookList, error) {
+	webhooks := api.WebhookList{}
+	for _, webhook := range d.webhooks {
+		if webhook.Source == source || webhook.Source == "" {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+func (d *webhookDaoMock) CreateDelivery(ctx context.Context, delivery *api.WebhookDelivery) (*api.WebhookDelivery, error) {
+	for _, existing := range d.deliveries {
+		if existing.WebhookID == delivery.WebhookID && existing.EventID == delivery.EventID && existing.Attempt == delivery.Attempt {
+			return delivery, nil
+		}
+	}
+	if delivery.ID == "" {
+		delivery.ID = api.NewID()
+	}
+	if delivery.CreatedAt.IsZero() {
+		delivery.CreatedAt = time.Now()
+	}
+	d.deliveries = append(d.deliveries, delivery)
+	return delivery, nil
+}
+
+func (d *webhookDaoMock) ReplaceDelivery(ctx context.Context, delivery *api.WebhookDelivery) (*api.WebhookDelivery, error) {
+	for i, existing := range d.deliveries {
+		if existing.ID == delivery.ID {
+			d.deliveries[i] = delivery
+			return delivery, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (d *webhookDaoMock) FindDueDeliveries(ctx context.Context, now time.Time, limit int) (api.WebhookDeliveryList, error) {
+	due := api.WebhookDeliveryList{}
+	for _, delivery := range d.deliveries {
+		if delivery.Status == api.WebhookDeliveryPending && !delivery.ScheduledAt.After(now) {
+			due = append(due, delivery)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].ScheduledAt.Before(due[j].ScheduledAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (d *webhookDaoMock) DeleteDeliveriesCreatedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	kept := api.WebhookDeliveryList{}
+	for _, delivery := range d.deliveries {
+		if !delivery.CreatedAt.Before(cutoff) || delivery.Status == api.WebhookDeliveryPending {
+			kept = append(kept, delivery)
+		}
+	}
+	deleted := int64(len(d.deliveries) - len(kept))
+	d.deliveries = kept
+	return deleted, nil
+}
@@ -0,0 +1,45 @@
+package dao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+)
+
+// RelationshipDao checks the rows at both ends of a foreign key. Tables and columns are those of the
+// relationships registered by the Kinds, never client input.
+type RelationshipDao interface {
+	// Exists tells whether the live row id of table is visible to the organization in ctx
+	Exists(ctx context.Context, table, id string) (bool, error)
+	// CountReferences counts the live rows of table whose column refers to id, across organizations
+	CountReferences(ctx context.Context, table, column, id string) (int64, error)
+}
+
+var _ RelationshipDao = &sqlRelationshipDao{}
+
+type sqlRelationshipDao struct {
+	sessionFactory *db.SessionFactory
+}
+
+func NewRelationshipDao(sessionFactory *db.SessionFactory) RelationshipDao {
+	return &sqlRelationshipDao{sessionFactory: sessionFactory}
+}
+
+func (d *sqlRelationshipDao) Exists(ctx context.Context, table, id string) (bool, error) {
+	g2 := (*d.sessionFactory).New(ctx).Scopes(TenantScope(ctx))
+	var count int64
+	if err := g2.Table(table).Where("id = ? AND deleted_at IS NULL", id).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (d *sqlRelationshipDao) CountReferences(ctx context.Context, table, column, id string) (int64, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	var count int64
+	if err := g2.Table(table).Where(fmt.Sprintf("%s = ? AND deleted_at IS NULL", column), id).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
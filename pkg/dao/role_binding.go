@@ -0,0 +1,64 @@
+package dao
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+)
+
+type RoleBindingDao interface {
+	Get(ctx context.Context, id string) (*api.RoleBinding, error)
+	Create(ctx context.Context, roleBinding *api.RoleBinding) (*api.RoleBinding, error)
+	Delete(ctx context.Context, id string) error
+	FindByUsername(ctx context.Context, username string) (api.RoleBindingList, error)
+}
+
+var _ RoleBindingDao = &sqlRoleBindingDao{}
+
+type sqlRoleBindingDao struct {
+	sessionFactory *db.SessionFactory
+}
+
+func NewRoleBindingDao(sessionFactory *db.SessionFactory) RoleBindingDao {
+	return &sqlRoleBindingDao{sessionFactory: sessionFactory}
+}
+
+func (d *sqlRoleBindingDao) Get(ctx context.Context, id string) (*api.RoleBinding, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	var roleBinding api.RoleBinding
+	if err := g2.Take(&roleBinding, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &roleBinding, nil
+}
+
+func (d *sqlRoleBindingDao) Create(ctx context.Context, roleBinding *api.RoleBinding) (*api.RoleBinding, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Omit(clause.Associations).Create(roleBinding).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return roleBinding, nil
+}
+
+// Delete removes the binding for good, a revoked role must not come back with a restore
+func (d *sqlRoleBindingDao) Delete(ctx context.Context, id string) error {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Unscoped().Omit(clause.Associations).Delete(&api.RoleBinding{Meta: api.Meta{ID: id}}).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (d *sqlRoleBindingDao) FindByUsername(ctx context.Context, username string) (api.RoleBindingList, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	roleBindings := api.RoleBindingList{}
+	if err := g2.Where("username = ?", username).Find(&roleBindings).Error; err != nil {
+		return nil, err
+	}
+	return roleBindings, nil
+}
@@ -0,0 +1,45 @@
+package dao
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+)
+
+// TenantScope restricts a query to the rows of the organization of the request in ctx.
+// Requests without a tenant and callers allowed to work across organizations are not restricted.
+//
+//	g2.Scopes(dao.TenantScope(ctx)).Take(&dinosaur, "id = ?", id)
+func TenantScope(ctx context.Context) func(*gorm.DB) *gorm.DB {
+	return func(g2 *gorm.DB) *gorm.DB {
+		if sql, values, scoped := TenantCondition(ctx, ""); scoped {
+			return g2.Where(sql, values...)
+		}
+		return g2
+	}
+}
+
+// TenantCondition returns the WHERE condition of TenantScope on table, or scoped=false if ctx isn't scoped.
+// The column is qualified with table unless it is empty, for queries that join other tables.
+func TenantCondition(ctx context.Context, table string) (sql string, values []any, scoped bool) {
+	tenant, ok := auth.GetTenantFromContext(ctx)
+	if !ok || tenant.AnyOrganization {
+		return "", nil, false
+	}
+	column := "organization_id"
+	if table != "" {
+		column = table + "." + column
+	}
+	return column + " = ?", []any{tenant.OrganizationID}, true
+}
+
+// SetTenancy stamps a new row with the organization and the user of the request in ctx
+func SetTenancy(ctx context.Context, meta *api.Meta) {
+	if tenant, ok := auth.GetTenantFromContext(ctx); ok {
+		meta.OrganizationID = tenant.OrganizationID
+	}
+	meta.Owner = auth.GetUsernameFromContext(ctx)
+}
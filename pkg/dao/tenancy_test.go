@@ -0,0 +1,47 @@
+package dao
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+)
+
+func TestTenantCondition(t *testing.T) {
+	RegisterTestingT(t)
+
+	// requests without a tenant, e.g. of controllers, aren't scoped
+	_, _, scoped := TenantCondition(context.Background(), "dinosaurs")
+	Expect(scoped).To(BeFalse())
+
+	ctx := auth.SetTenantContext(context.Background(), auth.Tenant{OrganizationID: "acme"})
+	sql, values, scoped := TenantCondition(ctx, "dinosaurs")
+	Expect(scoped).To(BeTrue())
+	Expect(sql).To(Equal("dinosaurs.organization_id = ?"))
+	Expect(values).To(ConsistOf("acme"))
+
+	// tokens without an organization only see rows without one
+	ctx = auth.SetTenantContext(context.Background(), auth.Tenant{})
+	sql, values, scoped = TenantCondition(ctx, "")
+	Expect(scoped).To(BeTrue())
+	Expect(sql).To(Equal("organization_id = ?"))
+	Expect(values).To(ConsistOf(""))
+
+	ctx = auth.SetTenantContext(context.Background(), auth.Tenant{OrganizationID: "acme", AnyOrganization: true})
+	_, _, scoped = TenantCondition(ctx, "dinosaurs")
+	Expect(scoped).To(BeFalse())
+}
+
+func TestSetTenancy(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx := auth.SetUsernameContext(context.Background(), "alice")
+	ctx = auth.SetTenantContext(ctx, auth.Tenant{OrganizationID: "acme"})
+	meta := api.Meta{}
+	SetTenancy(ctx, &meta)
+	Expect(meta.OrganizationID).To(Equal("acme"))
+	Expect(meta.Owner).To(Equal("alice"))
+}
@@ -0,0 +1,140 @@
+package dao
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+)
+
+// WebhookDao reads and writes the webhooks of the organization of the request in ctx, see TenantScope,
+// and the deliveries of their events
+type WebhookDao interface {
+	Get(ctx context.Context, id string) (*api.Webhook, error)
+	Create(ctx context.Context, webhook *api.Webhook) (*api.Webhook, error)
+	Replace(ctx context.Context, webhook *api.Webhook) (*api.Webhook, error)
+	// Delete removes the webhook and its deliveries for good, a deleted webhook must not keep its secret
+	Delete(ctx context.Context, id string) error
+	// FindBySource returns the webhooks of every organization subscribed to the events of source
+	FindBySource(ctx context.Context, source string) (api.WebhookList, error)
+
+	// CreateDelivery inserts delivery unless the webhook already has the same attempt of the event
+	CreateDelivery(ctx context.Context, delivery *api.WebhookDelivery) (*api.WebhookDelivery, error)
+	ReplaceDelivery(ctx context.Context, delivery *api.WebhookDelivery) (*api.WebhookDelivery, error)
+	// FindDueDeliveries returns up to limit pending deliveries scheduled before now, the oldest first
+	FindDueDeliveries(ctx context.Context, now time.Time, limit int) (api.WebhookDeliveryList, error)
+	// DeleteDeliveriesCreatedBefore removes the attempts made before the cutoff and returns how many there were
+	DeleteDeliveriesCreatedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+var _ WebhookDao = &sqlWebhookDao{}
+
+type sqlWebhookDao struct {
+	sessionFactory *db.SessionFactory
+}
+
+func NewWebhookDao(sessionFactory *db.SessionFactory) WebhookDao {
+	return &sqlWebhookDao{sessionFactory: sessionFactory}
+}
+
+func (d *sqlWebhookDao) Get(ctx context.Context, id string) (*api.Webhook, error) {
+	g2 := (*d.sessionFactory).New(ctx).Scopes(TenantScope(ctx))
+	var webhook api.Webhook
+	if err := g2.Take(&webhook, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (d *sqlWebhookDao) Create(ctx context.Context, webhook *api.Webhook) (*api.Webhook, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	SetTenancy(ctx, &webhook.Meta)
+	if err := g2.Omit(clause.Associations).Create(webhook).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (d *sqlWebhookDao) Replace(ctx context.Context, webhook *api.Webhook) (*api.Webhook, error) {
+	g2 := (*d.sessionFactory).New(ctx).Scopes(TenantScope(ctx))
+	if err := db.SaveVersioned(g2.Omit(clause.Associations), webhook, &webhook.ResourceVersion); err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (d *sqlWebhookDao) Delete(ctx context.Context, id string) error {
+	g2 := (*d.sessionFactory).New(ctx)
+	result := g2.Scopes(TenantScope(ctx)).Unscoped().Omit(clause.Associations).Delete(&api.Webhook{Meta: api.Meta{ID: id}})
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	if err := g2.Where("webhook_id = ?", id).Delete(&api.WebhookDelivery{}).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+func (d *sqlWebhookDao) FindBySource(ctx context.Context, source string) (api.WebhookList, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	webhooks := api.WebhookList{}
+	if err := g2.Where("source = ? or source = ''", source).Order("created_at").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (d *sqlWebhookDao) CreateDelivery(ctx context.Context, delivery *api.WebhookDelivery) (*api.WebhookDelivery, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	// an event handled again after a failure of another controller must not be delivered twice
+	conflict := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "webhook_id"}, {Name: "event_id"}, {Name: "attempt"}},
+		DoNothing: true,
+	}
+	if err := g2.Clauses(conflict).Create(delivery).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func (d *sqlWebhookDao) ReplaceDelivery(ctx context.Context, delivery *api.WebhookDelivery) (*api.WebhookDelivery, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	if err := g2.Save(delivery).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func (d *sqlWebhookDao) FindDueDeliveries(ctx context.Context, now time.Time, limit int) (api.WebhookDeliveryList, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	deliveries := api.WebhookDeliveryList{}
+	err := g2.Where("status = ? and scheduled_at <= ?", api.WebhookDeliveryPending, now).
+		Order("scheduled_at").Limit(limit).Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (d *sqlWebhookDao) DeleteDeliveriesCreatedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	result := g2.Where("created_at < ? and status <> ?", cutoff, api.WebhookDeliveryPending).Delete(&api.WebhookDelivery{})
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -9,7 +9,12 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	dbContext "github.com/openshift-online/rh-trex-ai/pkg/db/db_context"
 	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+	"github.com/openshift-online/rh-trex-ai/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"gorm.io/gorm"
 )
 
@@ -50,10 +55,13 @@ func NewAdvisoryLockFactory(connection SessionFactory) *AdvisoryLockFactory {
 }
 
 func (f *AdvisoryLockFactory) NewAdvisoryLock(ctx context.Context, id string, lockType LockType) (string, error) {
+	ctx, span := startLockSpan(ctx, id, lockType, true)
+	defer span.End()
 	log := logger.NewLogger(ctx)
 
 	lock, err := f.newLock(ctx, id, lockType)
 	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
 		return "", err
 	}
 
@@ -62,6 +70,7 @@ func (f *AdvisoryLockFactory) NewAdvisoryLock(ctx context.Context, id string, lo
 		UpdateAdvisoryLockCountMetric(lockType, "lock error")
 		errMsg := fmt.Sprintf("error obtaining the advisory lock for id %s type %s, %v", id, lockType, err)
 		log.Error(errMsg)
+		span.SetStatus(codes.Error, errMsg)
 		// the lock transaction is already started, if error happens, we return the transaction id, so that the caller
 		// can end this transaction.
 		return *lock.uuid, fmt.Errorf("%s", errMsg)
@@ -75,19 +84,24 @@ func (f *AdvisoryLockFactory) NewAdvisoryLock(ctx context.Context, id string, lo
 }
 
 func (f *AdvisoryLockFactory) NewNonBlockingLock(ctx context.Context, id string, lockType LockType) (string, bool, error) {
+	ctx, span := startLockSpan(ctx, id, lockType, false)
+	defer span.End()
 	log := logger.NewLogger(ctx)
 
 	lock, err := f.newLock(ctx, id, lockType)
 	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
 		return "", false, err
 	}
 
 	// obtain the advisory lock (unblocking)
 	acquired, err := lock.nonBlockingLock()
+	span.SetAttributes(attribute.Bool("lock.acquired", acquired))
 	if err != nil {
 		UpdateAdvisoryLockCountMetric(lockType, "lock error")
 		errMsg := fmt.Sprintf("error obtaining the non blocking advisory lock for id %s type %s, %v", id, lockType, err)
 		log.Error(errMsg)
+		span.SetStatus(codes.Error, errMsg)
 		// the lock transaction is already started, if error happens, we return the transaction id, so that the caller
 		// can end this transaction.
 		return *lock.uuid, false, fmt.Errorf("%s", errMsg)
@@ -100,6 +114,16 @@ func (f *AdvisoryLockFactory) NewNonBlockingLock(ctx context.Context, id string,
 	return *lock.uuid, acquired, nil
 }
 
+// startLockSpan starts the span of the acquisition of the lock (id, lockType). The queries taking the lock
+// are its children.
+func startLockSpan(ctx context.Context, id string, lockType LockType, blocking bool) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "advisory_lock "+string(lockType), trace.WithAttributes(
+		attribute.String("lock.id", id),
+		attribute.String("lock.type", string(lockType)),
+		attribute.Bool("lock.blocking", blocking),
+	))
+}
+
 func (f *AdvisoryLockFactory) newLock(ctx context.Context, id string, lockType LockType) (*AdvisoryLock, error) {
 	// lockOwnerID will be different for every service function that attempts to start a lock.
 	// only the initial call in the stack must unlock.
@@ -177,8 +201,9 @@ type AdvisoryLock struct {
 
 // newAdvisoryLock constructs a new AdvisoryLock object.
 func newAdvisoryLock(ctx context.Context, connection SessionFactory) (*AdvisoryLock, error) {
-	// it requires a new DB session to start the advisory lock.
-	g2 := connection.New(ctx)
+	// it requires a new DB session to start the advisory lock. The lock runs in its own Tx rather than
+	// the caller's, so it is released on Unlock independently of the caller's transaction.
+	g2 := connection.New(dbContext.WithoutTransaction(ctx))
 
 	// start a Tx to ensure gorm will obtain/release the lock using a same connection.
 	tx := g2.Begin()
@@ -3,6 +3,8 @@ package db
 import (
 	"context"
 
+	"gorm.io/gorm"
+
 	dbContext "github.com/openshift-online/rh-trex-ai/pkg/db/db_context"
 	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 )
@@ -55,3 +57,58 @@ func MarkForRollback(ctx context.Context, err error) {
 	transaction.SetRollbackFlag(true)
 	log.Infof("Marked transaction for rollback, err: %v", err)
 }
+
+// MarkedForRollback reports whether the transaction stored in the context is going to roll back
+func MarkedForRollback(ctx context.Context) bool {
+	tx, ok := dbContext.Transaction(ctx)
+	return ok && tx.MarkedForRollback()
+}
+
+// AfterCommit runs fn once the transaction stored in the context has committed. It is dropped if the
+// transaction rolls back. Without an open transaction every statement has already been committed as it
+// executed, so fn runs immediately.
+func AfterCommit(ctx context.Context, fn func()) {
+	tx, ok := dbContext.Transaction(ctx)
+	if !ok || !tx.Active() {
+		fn()
+		return
+	}
+	tx.AfterCommit(fn)
+}
+
+// WithSavepoint runs fn in a savepoint of the transaction stored in the context. If fn fails, only its
+// statements are rolled back and the transaction stays usable, e.g. for the other items of a batch, and
+// fn's error is returned. Should the savepoint itself fail, the transaction is marked for rollback.
+// Without an open transaction fn just runs.
+func WithSavepoint(ctx context.Context, fn func() error) error {
+	tx, ok := dbContext.Transaction(ctx)
+	if !ok || !tx.Active() {
+		return fn()
+	}
+	if err := tx.Savepoint(); err != nil {
+		MarkForRollback(ctx, err)
+		return err
+	}
+	if err := fn(); err != nil {
+		if spErr := tx.RollbackToSavepoint(); spErr != nil {
+			MarkForRollback(ctx, spErr)
+			return spErr
+		}
+		return err
+	}
+	if err := tx.ReleaseSavepoint(); err != nil {
+		MarkForRollback(ctx, err)
+		return err
+	}
+	return nil
+}
+
+// WithContextTransaction binds a session to the open transaction stored in the context, if any, so that
+// its statements commit or roll back together with the rest of the caller's unit of work.
+// SessionFactory implementations call this from New.
+func WithContextTransaction(ctx context.Context, g2 *gorm.DB) *gorm.DB {
+	if tx, ok := dbContext.Transaction(ctx); ok && tx.Active() {
+		g2.Statement.ConnPool = tx.Tx()
+	}
+	return g2
+}
@@ -19,10 +19,15 @@ func WithTransaction(ctx context.Context, tx *transaction.Transaction) context.C
 	return context.WithValue(ctx, transactionKey, tx)
 }
 
+// WithoutTransaction returns a context that hides any transaction stored in its parent
+func WithoutTransaction(ctx context.Context) context.Context {
+	return context.WithValue(ctx, transactionKey, (*transaction.Transaction)(nil))
+}
+
 // Transaction extracts the transaction value from the context
 func Transaction(ctx context.Context) (tx *transaction.Transaction, ok bool) {
 	tx, ok = ctx.Value(transactionKey).(*transaction.Transaction)
-	return tx, ok
+	return tx, ok && tx != nil
 }
 
 // TxID Return the transaction ID from the context, if it exists. If there is no transaction, ok is false.
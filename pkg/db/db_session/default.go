@@ -86,6 +86,9 @@ func (f *Default) Init(config *config.DatabaseConfig) {
 				err.Error(),
 			))
 		}
+		if err := g2.Use(db.TracingPlugin{}); err != nil {
+			panic(fmt.Sprintf("GORM failed to register the tracing plugin: %s", err.Error()))
+		}
 
 		f.config = config
 		f.g2 = g2
@@ -97,7 +100,7 @@ func (f *Default) DirectDB() *sql.DB {
 	return f.db
 }
 
-func waitForNotification(ctx context.Context, l *pq.Listener, callback func(id string)) bool {
+func waitForNotification(ctx context.Context, l *pq.Listener, callback func(payload string)) bool {
 	logger := trexlogger.NewLogger(ctx)
 	select {
 	case <-ctx.Done():
@@ -118,7 +121,7 @@ func waitForNotification(ctx context.Context, l *pq.Listener, callback func(id s
 	}
 }
 
-func newListener(ctx context.Context, connstr, channel string, callback func(id string)) {
+func newListener(ctx context.Context, connstr, channel string, callback func(payload string)) {
 	logger := trexlogger.NewLogger(ctx)
 
 	plog := func(ev pq.ListenerEventType, err error) {
@@ -145,7 +148,7 @@ func newListener(ctx context.Context, connstr, channel string, callback func(id
 	logger.Infof("Stopped channeling monitor for %s", channel)
 }
 
-func (f *Default) NewListener(ctx context.Context, channel string, callback func(id string)) {
+func (f *Default) NewListener(ctx context.Context, channel string, callback func(payload string)) {
 	newListener(ctx, f.config.ConnectionString(true), channel, callback)
 }
 
@@ -157,7 +160,7 @@ func (f *Default) New(ctx context.Context) *gorm.DB {
 	if f.config.Debug {
 		conn = conn.Debug()
 	}
-	return conn
+	return db.WithContextTransaction(ctx, conn)
 }
 
 func (f *Default) CheckConnection() error {
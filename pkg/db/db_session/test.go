@@ -6,15 +6,15 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/golang/glog"
 	"github.com/lib/pq"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	gormlogger "gorm.io/gorm/logger"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/config"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 )
 
 type Test struct {
@@ -51,12 +51,12 @@ func (f *Test) Init(config *config.DatabaseConfig) {
 	// Only the first time
 	once.Do(func() {
 		if err := initDatabase(config, db.Migrate); err != nil {
-			glog.Errorf("error initializing test database: %s", err)
+			logger.Errorf("error initializing test database: %s", err)
 			return
 		}
 
 		if err := resetDB(config); err != nil {
-			glog.Errorf("error resetting test database: %s", err)
+			logger.Errorf("error resetting test database: %s", err)
 			return
 		}
 	})
@@ -131,7 +131,7 @@ func connect(name string, config *config.DatabaseConfig) (*sql.DB, *gorm.DB, fun
 		PrepareStmt:            false,
 		FullSaveAssociations:   false,
 		SkipDefaultTransaction: true,
-		Logger:                 logger.Default.LogMode(logger.Silent),
+		Logger:                 gormlogger.Default.LogMode(gormlogger.Silent),
 	}
 	g2, err = gorm.Open(postgres.New(postgres.Config{
 		Conn: dbx,
@@ -150,6 +150,9 @@ func connect(name string, config *config.DatabaseConfig) (*sql.DB, *gorm.DB, fun
 			err.Error(),
 		))
 	}
+	if err := g2.Use(db.TracingPlugin{}); err != nil {
+		panic(fmt.Sprintf("GORM failed to register the tracing plugin: %s", err.Error()))
+	}
 
 	return dbx, g2, func() {
 		if err := dbx.Close(); err != nil {
@@ -195,12 +198,12 @@ func (f *Test) New(ctx context.Context) *gorm.DB {
 
 	conn := f.g2.Session(&gorm.Session{
 		Context: ctx,
-		Logger:  f.g2.Logger.LogMode(logger.Silent),
+		Logger:  f.g2.Logger.LogMode(gormlogger.Silent),
 	})
 	if f.config.Debug {
 		conn = conn.Debug()
 	}
-	return conn
+	return db.WithContextTransaction(ctx, conn)
 }
 
 // CheckConnection checks to ensure a connection is present
@@ -220,6 +223,6 @@ func (f *Test) ResetDB() {
 	f.wasDisconnected = true
 }
 
-func (f *Test) NewListener(ctx context.Context, channel string, callback func(id string)) {
+func (f *Test) NewListener(ctx context.Context, channel string, callback func(payload string)) {
 	newListener(ctx, f.config.ConnectionString(true), channel, callback)
 }
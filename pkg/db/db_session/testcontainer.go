@@ -6,17 +6,17 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/golang/glog"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"github.com/testcontainers/testcontainers-go/wait"
 
 	gormpostgres "gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	gormlogger "gorm.io/gorm/logger"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/config"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 )
 
 type Testcontainer struct {
@@ -41,7 +41,7 @@ func NewTestcontainerFactory(config *config.DatabaseConfig) *Testcontainer {
 func (f *Testcontainer) Init(config *config.DatabaseConfig) {
 	ctx := context.Background()
 
-	glog.Infof("Starting PostgreSQL testcontainer...")
+	logger.Infof("Starting PostgreSQL testcontainer...")
 
 	// Create PostgreSQL container
 	container, err := postgres.Run(ctx,
@@ -55,7 +55,7 @@ func (f *Testcontainer) Init(config *config.DatabaseConfig) {
 				WithStartupTimeout(60*time.Second)),
 	)
 	if err != nil {
-		glog.Fatalf("Failed to start PostgreSQL testcontainer: %s", err)
+		logger.Fatalf("Failed to start PostgreSQL testcontainer: %s", err)
 	}
 
 	f.container = container
@@ -63,15 +63,15 @@ func (f *Testcontainer) Init(config *config.DatabaseConfig) {
 	// Get connection string from container
 	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
 	if err != nil {
-		glog.Fatalf("Failed to get connection string from testcontainer: %s", err)
+		logger.Fatalf("Failed to get connection string from testcontainer: %s", err)
 	}
 
-	glog.Infof("PostgreSQL testcontainer started at: %s", connStr)
+	logger.Infof("PostgreSQL testcontainer started at: %s", connStr)
 
 	// Open SQL connection
 	f.sqlDB, err = sql.Open("postgres", connStr)
 	if err != nil {
-		glog.Fatalf("Failed to connect to testcontainer database: %s", err)
+		logger.Fatalf("Failed to connect to testcontainer database: %s", err)
 	}
 
 	// Configure connection pool
@@ -82,11 +82,11 @@ func (f *Testcontainer) Init(config *config.DatabaseConfig) {
 		PrepareStmt:            false,
 		FullSaveAssociations:   false,
 		SkipDefaultTransaction: true,
-		Logger:                 logger.Default.LogMode(logger.Silent),
+		Logger:                 gormlogger.Default.LogMode(gormlogger.Silent),
 	}
 
 	if config.Debug {
-		conf.Logger = logger.Default.LogMode(logger.Info)
+		conf.Logger = gormlogger.Default.LogMode(gormlogger.Info)
 	}
 
 	f.g2, err = gorm.Open(gormpostgres.New(gormpostgres.Config{
@@ -94,16 +94,19 @@ func (f *Testcontainer) Init(config *config.DatabaseConfig) {
 		PreferSimpleProtocol: true,
 	}), conf)
 	if err != nil {
-		glog.Fatalf("Failed to connect GORM to testcontainer database: %s", err)
+		logger.Fatalf("Failed to connect GORM to testcontainer database: %s", err)
+	}
+	if err := f.g2.Use(db.TracingPlugin{}); err != nil {
+		logger.Fatalf("Failed to register the tracing plugin: %s", err)
 	}
 
 	// Run migrations
-	glog.Infof("Running database migrations on testcontainer...")
+	logger.Infof("Running database migrations on testcontainer...")
 	if err := db.Migrate(f.g2); err != nil {
-		glog.Fatalf("Failed to run migrations on testcontainer: %s", err)
+		logger.Fatalf("Failed to run migrations on testcontainer: %s", err)
 	}
 
-	glog.Infof("Testcontainer database initialized successfully")
+	logger.Infof("Testcontainer database initialized successfully")
 }
 
 func (f *Testcontainer) DirectDB() *sql.DB {
@@ -113,12 +116,12 @@ func (f *Testcontainer) DirectDB() *sql.DB {
 func (f *Testcontainer) New(ctx context.Context) *gorm.DB {
 	conn := f.g2.Session(&gorm.Session{
 		Context: ctx,
-		Logger:  f.g2.Logger.LogMode(logger.Silent),
+		Logger:  f.g2.Logger.LogMode(gormlogger.Silent),
 	})
 	if f.config.Debug {
 		conn = conn.Debug()
 	}
-	return conn
+	return db.WithContextTransaction(ctx, conn)
 }
 
 func (f *Testcontainer) CheckConnection() error {
@@ -132,17 +135,17 @@ func (f *Testcontainer) Close() error {
 	// Close SQL connection
 	if f.sqlDB != nil {
 		if err := f.sqlDB.Close(); err != nil {
-			glog.Errorf("Error closing SQL connection: %s", err)
+			logger.Errorf("Error closing SQL connection: %s", err)
 		}
 	}
 
 	// Terminate container
 	if f.container != nil {
-		glog.Infof("Stopping PostgreSQL testcontainer...")
+		logger.Infof("Stopping PostgreSQL testcontainer...")
 		if err := f.container.Terminate(ctx); err != nil {
 			return fmt.Errorf("failed to terminate testcontainer: %s", err)
 		}
-		glog.Infof("PostgreSQL testcontainer stopped")
+		logger.Infof("PostgreSQL testcontainer stopped")
 	}
 
 	return nil
@@ -160,22 +163,22 @@ func (f *Testcontainer) ResetDB() {
 		AND table_name != 'migrations'
 	`).Scan(&tables).Error
 	if err != nil {
-		glog.Errorf("Error querying tables: %s", err)
+		logger.Errorf("Error querying tables: %s", err)
 		return
 	}
 
 	for _, table := range tables {
 		if err := g2.Exec(fmt.Sprintf("TRUNCATE TABLE \"%s\" CASCADE", table)).Error; err != nil {
-			glog.Errorf("Error truncating table %s: %s", table, err)
+			logger.Errorf("Error truncating table %s: %s", table, err)
 		}
 	}
 }
 
-func (f *Testcontainer) NewListener(ctx context.Context, channel string, callback func(id string)) {
+func (f *Testcontainer) NewListener(ctx context.Context, channel string, callback func(payload string)) {
 	// Get the connection string for the listener
 	connStr, err := f.container.ConnectionString(ctx, "sslmode=disable")
 	if err != nil {
-		glog.Errorf("Failed to get connection string for listener: %s", err)
+		logger.Errorf("Failed to get connection string for listener: %s", err)
 		return
 	}
 
@@ -4,9 +4,10 @@ import (
 	"context"
 
 	"github.com/go-gormigrate/gormigrate/v2"
-	"github.com/golang/glog"
 
 	"gorm.io/gorm"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 )
 
 // gormigrate is a wrapper for gorm's migration functions that adds schema versioning and rollback capabilities.
@@ -29,7 +30,7 @@ func MigrateTo(sessionFactory SessionFactory, migrationID string) {
 	m := newGormigrate(g2)
 
 	if err := m.MigrateTo(migrationID); err != nil {
-		glog.Fatalf("Could not migrate: %v", err)
+		logger.Fatalf("Could not migrate: %v", err)
 	}
 }
 
@@ -56,7 +56,7 @@ func (m *MockSessionFactory) DirectDB() *sql.DB {
 }
 
 func (m *MockSessionFactory) New(ctx context.Context) *gorm.DB {
-	return m.gormDB.WithContext(ctx)
+	return db.WithContextTransaction(ctx, m.gormDB.WithContext(ctx))
 }
 
 func (m *MockSessionFactory) CheckConnection() error {
@@ -74,6 +74,6 @@ func (m *MockSessionFactory) ResetDB() {
 	// Mock implementation - does nothing
 }
 
-func (m *MockSessionFactory) NewListener(ctx context.Context, channel string, callback func(id string)) {
+func (m *MockSessionFactory) NewListener(ctx context.Context, channel string, callback func(payload string)) {
 	// Mock implementation - does nothing
 }
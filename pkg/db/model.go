@@ -1,6 +1,7 @@
 package db
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -8,10 +9,59 @@ import (
 )
 
 type Model struct {
-	ID        string `gorm:"primary_key"`
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt gorm.DeletedAt `gorm:"index"`
+	ID              string `gorm:"primary_key"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	DeletedAt       gorm.DeletedAt `gorm:"index"`
+	ResourceVersion int64          `gorm:"not null;default:1"`
+	OrganizationID  string         `gorm:"index"`
+	Owner           string
+}
+
+// ErrResourceVersionConflict is returned by SaveVersioned and DeleteVersioned when the row was
+// modified (or removed) after it was read.
+var ErrResourceVersionConflict = errors.New("resource version conflict")
+
+// SaveVersioned saves obj only if its stored resource_version still equals *version,
+// incrementing *version on success. The compare-and-swap happens in the UPDATE itself,
+// so a concurrent writer that read the same version will get ErrResourceVersionConflict
+// instead of silently overwriting this change.
+func SaveVersioned(g2 *gorm.DB, obj interface{}, version *int64) error {
+	expected := *version
+	*version = expected + 1
+
+	// An explicit Select keeps Save from falling back to an INSERT when no rows match
+	result := g2.Select("*").Where("resource_version = ?", expected).Save(obj)
+	if result.Error != nil {
+		*version = expected
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		*version = expected
+		return ErrResourceVersionConflict
+	}
+	return nil
+}
+
+// DeleteVersioned deletes obj, only if its stored resource_version is one of versions when versions
+// isn't nil. The check happens in the DELETE itself, so a concurrent writer can't slip in between a
+// read and the delete. It returns gorm.ErrRecordNotFound when nothing is deleted without versions,
+// and ErrResourceVersionConflict with them.
+func DeleteVersioned(g2 *gorm.DB, obj interface{}, versions []int64) error {
+	if versions != nil {
+		g2 = g2.Where("resource_version IN (?)", versions)
+	}
+	result := g2.Delete(obj)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		if versions != nil {
+			return ErrResourceVersionConflict
+		}
+		return gorm.ErrRecordNotFound
+	}
+	return nil
 }
 
 type FKMigration struct {
@@ -19,19 +69,41 @@ type FKMigration struct {
 	Dest      string
 	Field     string
 	Reference string
+	// OnDelete is the referential action when the referenced row is deleted, e.g. CASCADE. Default RESTRICT.
+	OnDelete string
 }
 
 func CreateFK(g2 *gorm.DB, fks ...FKMigration) error {
-	var query = `ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE RESTRICT ON UPDATE RESTRICT;`
+	var query = `ALTER TABLE %s ADD CONSTRAINT %s FOREIGN KEY (%s) REFERENCES %s ON DELETE %s ON UPDATE RESTRICT;`
 	var drop = `ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;`
 
 	for _, fk := range fks {
-		name := fmt.Sprintf("fk_%s_%s", fk.Model, fk.Dest)
+		name := fkName(fk)
+		onDelete := fk.OnDelete
+		if onDelete == "" {
+			onDelete = "RESTRICT"
+		}
 
 		g2.Exec(fmt.Sprintf(drop, fk.Model, name))
-		if err := g2.Exec(fmt.Sprintf(query, fk.Model, name, fk.Field, fk.Reference)).Error; err != nil {
+		if err := g2.Exec(fmt.Sprintf(query, fk.Model, name, fk.Field, fk.Reference, onDelete)).Error; err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// DropFK drops the constraints of CreateFK, for rollbacks
+func DropFK(g2 *gorm.DB, fks ...FKMigration) error {
+	var drop = `ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s;`
+
+	for _, fk := range fks {
+		if err := g2.Exec(fmt.Sprintf(drop, fk.Model, fkName(fk))).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func fkName(fk FKMigration) string {
+	return fmt.Sprintf("fk_%s_%s", fk.Model, fk.Dest)
+}
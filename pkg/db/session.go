@@ -16,5 +16,5 @@ type SessionFactory interface {
 	CheckConnection() error
 	Close() error
 	ResetDB()
-	NewListener(ctx context.Context, channel string, callback func(id string))
+	NewListener(ctx context.Context, channel string, callback func(payload string))
 }
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/tracing"
+)
+
+// TracingPlugin is a gorm plugin starting a span for every query, as a child of the span of the statement's
+// context. Session factories register it on the connection they open.
+type TracingPlugin struct{}
+
+var _ gorm.Plugin = TracingPlugin{}
+
+const tracingParentContextKey = "tracing:parent_context"
+
+func (TracingPlugin) Name() string {
+	return "tracing"
+}
+
+func (p TracingPlugin) Initialize(g2 *gorm.DB) error {
+	callbacks := g2.Callback()
+	for _, err := range []error{
+		callbacks.Create().Before("gorm:create").Register("tracing:before_create", p.before("create")),
+		callbacks.Create().After("gorm:create").Register("tracing:after_create", p.after),
+		callbacks.Query().Before("gorm:query").Register("tracing:before_query", p.before("query")),
+		callbacks.Query().After("gorm:query").Register("tracing:after_query", p.after),
+		callbacks.Update().Before("gorm:update").Register("tracing:before_update", p.before("update")),
+		callbacks.Update().After("gorm:update").Register("tracing:after_update", p.after),
+		callbacks.Delete().Before("gorm:delete").Register("tracing:before_delete", p.before("delete")),
+		callbacks.Delete().After("gorm:delete").Register("tracing:after_delete", p.after),
+		callbacks.Row().Before("gorm:row").Register("tracing:before_row", p.before("row")),
+		callbacks.Row().After("gorm:row").Register("tracing:after_row", p.after),
+		callbacks.Raw().Before("gorm:raw").Register("tracing:before_raw", p.before("raw")),
+		callbacks.Raw().After("gorm:raw").Register("tracing:after_raw", p.after),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (TracingPlugin) before(operation string) func(*gorm.DB) {
+	return func(g2 *gorm.DB) {
+		parent := g2.Statement.Context
+		if parent == nil {
+			parent = context.Background()
+		}
+		ctx, _ := tracing.Tracer().Start(parent, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(semconv.DBSystemNamePostgreSQL),
+		)
+		g2.InstanceSet(tracingParentContextKey, parent)
+		g2.Statement.Context = ctx
+	}
+}
+
+func (TracingPlugin) after(g2 *gorm.DB) {
+	span := trace.SpanFromContext(g2.Statement.Context)
+	span.SetAttributes(
+		semconv.DBQueryText(g2.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", g2.RowsAffected),
+	)
+	if g2.Statement.Table != "" {
+		span.SetAttributes(semconv.DBCollectionName(g2.Statement.Table))
+	}
+	// a missing record is an answer, not a failure of the query
+	if g2.Error != nil && !errors.Is(g2.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(g2.Error)
+		span.SetStatus(codes.Error, g2.Error.Error())
+	}
+	span.End()
+
+	// the statement may be reused by the next call of a chain, which starts from the caller's span again
+	if parent, ok := g2.InstanceGet(tracingParentContextKey); ok {
+		g2.Statement.Context = parent.(context.Context)
+	}
+}
@@ -3,6 +3,7 @@ package transaction
 import (
 	"database/sql"
 	"errors"
+	"fmt"
 )
 
 // By default do no roll back transaction.
@@ -14,6 +15,15 @@ type Transaction struct {
 	rollbackFlag bool
 	tx           *sql.Tx
 	txid         int64
+	afterCommit  []func()
+	savepoints   []savepoint
+}
+
+// savepoint remembers what RollbackToSavepoint restores besides the rows
+type savepoint struct {
+	name         string
+	hooks        int
+	rollbackFlag bool
 }
 
 // Build Creates a new transaction object
@@ -38,6 +48,17 @@ func (tx *Transaction) TxID() int64 {
 	return tx.txid
 }
 
+// Active returns true until the transaction has been committed or rolled back.
+func (tx *Transaction) Active() bool {
+	return tx.tx != nil
+}
+
+// AfterCommit registers a hook that runs once the transaction has been committed successfully.
+// Hooks run in registration order and are discarded if the transaction is rolled back or the commit fails.
+func (tx *Transaction) AfterCommit(fn func()) {
+	tx.afterCommit = append(tx.afterCommit, fn)
+}
+
 func (tx *Transaction) Commit() error {
 	// tx must exits
 	if tx.tx == nil {
@@ -48,7 +69,17 @@ func (tx *Transaction) Commit() error {
 	// do *not* call commit on the underlying transaction itself. Gorm does that.
 	err := tx.tx.Commit()
 	tx.tx = nil
-	return err
+
+	hooks := tx.afterCommit
+	tx.afterCommit = nil
+	tx.savepoints = nil
+	if err != nil {
+		return err
+	}
+	for _, fn := range hooks {
+		fn()
+	}
+	return nil
 }
 
 // Rollback ends the transaction by rolling back
@@ -59,9 +90,62 @@ func (tx *Transaction) Rollback() error {
 	}
 	err := tx.tx.Rollback()
 	tx.tx = nil
+	tx.afterCommit = nil
+	tx.savepoints = nil
+	return err
+}
+
+// Savepoint starts a nested savepoint. RollbackToSavepoint undoes the statements run since without
+// ending the transaction; ReleaseSavepoint keeps them.
+func (tx *Transaction) Savepoint() error {
+	if tx.tx == nil {
+		return errors.New("db: transaction hasn't been started yet")
+	}
+	name := fmt.Sprintf("sp_%d", len(tx.savepoints)+1)
+	if _, err := tx.tx.Exec("SAVEPOINT " + name); err != nil {
+		return err
+	}
+	tx.savepoints = append(tx.savepoints, savepoint{name: name, hooks: len(tx.afterCommit), rollbackFlag: tx.rollbackFlag})
+	return nil
+}
+
+// RollbackToSavepoint undoes the statements run since the innermost savepoint and ends it. The AfterCommit
+// hooks registered since are dropped and the rollback flag is restored, so the transaction can go on and commit.
+func (tx *Transaction) RollbackToSavepoint() error {
+	sp, err := tx.popSavepoint()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.tx.Exec("ROLLBACK TO SAVEPOINT " + sp.name); err != nil {
+		return err
+	}
+	tx.afterCommit = tx.afterCommit[:sp.hooks]
+	tx.rollbackFlag = sp.rollbackFlag
+	return nil
+}
+
+// ReleaseSavepoint ends the innermost savepoint, keeping the statements run since
+func (tx *Transaction) ReleaseSavepoint() error {
+	sp, err := tx.popSavepoint()
+	if err != nil {
+		return err
+	}
+	_, err = tx.tx.Exec("RELEASE SAVEPOINT " + sp.name)
 	return err
 }
 
+func (tx *Transaction) popSavepoint() (savepoint, error) {
+	if tx.tx == nil {
+		return savepoint{}, errors.New("db: transaction hasn't been started yet")
+	}
+	if len(tx.savepoints) == 0 {
+		return savepoint{}, errors.New("db: no savepoint to end")
+	}
+	sp := tx.savepoints[len(tx.savepoints)-1]
+	tx.savepoints = tx.savepoints[:len(tx.savepoints)-1]
+	return sp, nil
+}
+
 func (tx *Transaction) SetRollbackFlag(flag bool) {
 	tx.rollbackFlag = flag
 }
@@ -0,0 +1,91 @@
+package transaction
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	. "github.com/onsi/gomega"
+)
+
+func newMockTransaction(t *testing.T) (*Transaction, sqlmock.Sqlmock) {
+	sqlDB, mock, err := sqlmock.New()
+	Expect(err).NotTo(HaveOccurred())
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	mock.ExpectBegin()
+	tx, err := sqlDB.Begin()
+	Expect(err).NotTo(HaveOccurred())
+	return Build(tx, 1, false), mock
+}
+
+func TestAfterCommitRunsHooksInOrder(t *testing.T) {
+	RegisterTestingT(t)
+	tx, mock := newMockTransaction(t)
+
+	var calls []string
+	tx.AfterCommit(func() { calls = append(calls, "first") })
+	tx.AfterCommit(func() { calls = append(calls, "second") })
+	Expect(calls).To(BeEmpty())
+
+	mock.ExpectCommit()
+	Expect(tx.Commit()).To(Succeed())
+	Expect(calls).To(Equal([]string{"first", "second"}))
+	Expect(tx.Active()).To(BeFalse())
+	Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestAfterCommitSkippedOnRollback(t *testing.T) {
+	RegisterTestingT(t)
+	tx, mock := newMockTransaction(t)
+
+	called := false
+	tx.AfterCommit(func() { called = true })
+
+	mock.ExpectRollback()
+	Expect(tx.Rollback()).To(Succeed())
+	Expect(called).To(BeFalse())
+	Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
+
+func TestAfterCommitSkippedOnFailedCommit(t *testing.T) {
+	RegisterTestingT(t)
+	tx, mock := newMockTransaction(t)
+
+	called := false
+	tx.AfterCommit(func() { called = true })
+
+	mock.ExpectCommit().WillReturnError(errors.New("serialization failure"))
+	Expect(tx.Commit()).NotTo(Succeed())
+	Expect(called).To(BeFalse())
+}
+
+func TestRollbackToSavepointKeepsTransactionUsable(t *testing.T) {
+	RegisterTestingT(t)
+	tx, mock := newMockTransaction(t)
+
+	var calls []string
+	tx.AfterCommit(func() { calls = append(calls, "before") })
+
+	mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	Expect(tx.Savepoint()).To(Succeed())
+	tx.AfterCommit(func() { calls = append(calls, "undone") })
+	tx.SetRollbackFlag(true)
+
+	mock.ExpectExec("ROLLBACK TO SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	Expect(tx.RollbackToSavepoint()).To(Succeed())
+	Expect(tx.MarkedForRollback()).To(BeFalse())
+
+	mock.ExpectExec("SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	Expect(tx.Savepoint()).To(Succeed())
+	tx.AfterCommit(func() { calls = append(calls, "kept") })
+	mock.ExpectExec("RELEASE SAVEPOINT sp_1").WillReturnResult(sqlmock.NewResult(0, 0))
+	Expect(tx.ReleaseSavepoint()).To(Succeed())
+
+	Expect(tx.RollbackToSavepoint()).NotTo(Succeed())
+
+	mock.ExpectCommit()
+	Expect(tx.Commit()).To(Succeed())
+	Expect(calls).To(Equal([]string{"before", "kept"}))
+	Expect(mock.ExpectationsWereMet()).To(Succeed())
+}
@@ -1,17 +1,21 @@
 package environments
 
 import (
+	"context"
 	"os"
 	"strings"
 	"sync"
 
-	"github.com/golang/glog"
 	"github.com/spf13/pflag"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/client/apiclient"
 	"github.com/openshift-online/rh-trex-ai/pkg/config"
 	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/eventbus"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+	"github.com/openshift-online/rh-trex-ai/pkg/ratelimit"
 	"github.com/openshift-online/rh-trex-ai/pkg/registry"
+	"github.com/openshift-online/rh-trex-ai/pkg/tracing"
 )
 
 var (
@@ -55,37 +59,62 @@ func (e *Env) AddFlags(flags *pflag.FlagSet) error {
 }
 
 func (e *Env) Initialize() error {
-	glog.Infof("Initializing %s environment", e.Name)
+	logger.Infof("Initializing %s environment", e.Name)
 
 	envImpl, found := envImpls[e.Name]
 	if !found {
-		glog.Fatalf("Unknown runtime environment: %s", e.Name)
+		logger.Fatalf("Unknown runtime environment: %s", e.Name)
 	}
 
 	if err := envImpl.OverrideConfig(e.Config); err != nil {
-		glog.Fatalf("Failed to configure ApplicationConfig: %s", err)
+		logger.Fatalf("Failed to configure ApplicationConfig: %s", err)
 	}
 
 	messages := globalEnv.Config.ReadFiles()
 	if len(messages) != 0 {
-		glog.Fatalf("unable to read configuration files:\n%s", strings.Join(messages, "\n"))
+		logger.Fatalf("unable to read configuration files:\n%s", strings.Join(messages, "\n"))
+	}
+
+	if err := logger.Configure(e.Config.Logging); err != nil {
+		logger.Fatalf("Failed to configure logging: %s", err)
+	}
+
+	if e.Tracing == nil {
+		provider, err := tracing.New(e.Config.Tracing)
+		if err != nil {
+			logger.Fatalf("Failed to configure tracing: %s", err)
+		}
+		e.Tracing = provider
 	}
 
 	if err := envImpl.OverrideDatabase(&e.Database); err != nil {
-		glog.Fatalf("Failed to configure Database: %s", err)
+		logger.Fatalf("Failed to configure Database: %s", err)
+	}
+	if e.Database.EventBus == nil {
+		bus, err := eventbus.New(e.Config.Database, e.Database.SessionFactory)
+		if err != nil {
+			logger.Fatalf("Failed to configure the event bus: %s", err)
+		}
+		e.Database.EventBus = bus
+	}
+
+	limiter, err := ratelimit.New(e.Config.RateLimit, e.Database.SessionFactory)
+	if err != nil {
+		logger.Fatalf("Failed to configure rate limiting: %s", err)
 	}
+	e.RateLimiter = limiter
 
-	err := e.LoadClients()
+	err = e.LoadClients()
 	if err != nil {
 		return err
 	}
 	if err := envImpl.OverrideClients(&e.Clients); err != nil {
-		glog.Fatalf("Failed to configure Clients: %s", err)
+		logger.Fatalf("Failed to configure Clients: %s", err)
 	}
 
 	e.LoadServices()
 	if err := envImpl.OverrideServices(&e.Services); err != nil {
-		glog.Fatalf("Failed to configure Services: %s", err)
+		logger.Fatalf("Failed to configure Services: %s", err)
 	}
 
 	seedErr := e.Seed()
@@ -94,7 +123,7 @@ func (e *Env) Initialize() error {
 	}
 
 	if err := envImpl.OverrideHandlers(&e.Handlers); err != nil {
-		glog.Fatalf("Failed to configure Handlers: %s", err)
+		logger.Fatalf("Failed to configure Handlers: %s", err)
 	}
 
 	return nil
@@ -123,13 +152,13 @@ func (e *Env) LoadClients() error {
 	}
 
 	if e.Config.APIClient.EnableMock {
-		glog.Infof("Using Mock Authz Client")
+		logger.Infof("Using Mock Authz Client")
 		e.Clients.APIClient, err = apiclient.NewClientMock(apiClientConfig)
 	} else {
 		e.Clients.APIClient, err = apiclient.NewClient(apiClientConfig)
 	}
 	if err != nil {
-		glog.Errorf("Unable to create API Authz client: %s", err.Error())
+		logger.Errorf("Unable to create API Authz client: %s", err.Error())
 		return err
 	}
 
@@ -137,18 +166,24 @@ func (e *Env) LoadClients() error {
 }
 
 func (e *Env) Teardown() {
+	if e.Database.EventBus != nil {
+		e.Database.EventBus.Close()
+	}
 	if e.Database.SessionFactory != nil {
 		if err := e.Database.SessionFactory.Close(); err != nil {
-			glog.Errorf("Error closing database session factory: %s", err.Error())
+			logger.Errorf("Error closing database session factory: %s", err.Error())
 		}
 	}
 	e.Clients.APIClient.Close()
+	if err := e.Tracing.Shutdown(context.Background()); err != nil {
+		logger.Errorf("Error shutting down tracing: %s", err.Error())
+	}
 }
 
 func SetConfigDefaults(flags *pflag.FlagSet, defaults map[string]string) error {
 	for name, value := range defaults {
 		if err := flags.Set(name, value); err != nil {
-			glog.Errorf("Error setting flag %s: %v", name, err)
+			logger.Errorf("Error setting flag %s: %v", name, err)
 			return err
 		}
 	}
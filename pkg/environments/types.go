@@ -7,6 +7,9 @@ import (
 	"github.com/openshift-online/rh-trex-ai/pkg/client/apiclient"
 	"github.com/openshift-online/rh-trex-ai/pkg/config"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/pkg/eventbus"
+	"github.com/openshift-online/rh-trex-ai/pkg/ratelimit"
+	"github.com/openshift-online/rh-trex-ai/pkg/tracing"
 )
 
 const (
@@ -26,6 +29,10 @@ type Env struct {
 	Clients  Clients
 	Database Database
 	Config   *config.ApplicationConfig
+	// Tracing is the tracer provider set up from the tracing config
+	Tracing *tracing.Provider
+	// RateLimiter limits the rate of the requests of every client, it's nil if rate limiting is disabled
+	RateLimiter *ratelimit.Limiter
 }
 
 type ApplicationConfig struct {
@@ -34,6 +41,8 @@ type ApplicationConfig struct {
 
 type Database struct {
 	SessionFactory db.SessionFactory
+	// EventBus announces committed events, it's set from the event_bus config unless the environment sets one
+	EventBus eventbus.EventBus
 }
 
 type Handlers struct {
@@ -4,10 +4,10 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
-
-	"github.com/golang/glog"
+	"strings"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 )
 
 var (
@@ -60,6 +60,21 @@ const (
 
 	// DatabaseAdvisoryLock occurs whe the advisory lock is failed to get
 	ErrorDatabaseAdvisoryLock ServiceErrorCode = 26
+
+	// PreconditionFailed occurs when a conditional request (e.g. If-Match) does not match the current resource version
+	ErrorPreconditionFailed ServiceErrorCode = 27
+
+	// VersionConflict occurs when a resource was modified concurrently between read and write
+	ErrorVersionConflict ServiceErrorCode = 28
+
+	// IdempotencyKeyReused occurs when an Idempotency-Key is sent again with a different request
+	ErrorIdempotencyKeyReused ServiceErrorCode = 29
+
+	// UnsupportedMediaType occurs when the Content-Type of a request body isn't one the endpoint accepts
+	ErrorUnsupportedMediaType ServiceErrorCode = 30
+
+	// TooManyRequests occurs when a caller has used up its rate limit
+	ErrorTooManyRequests ServiceErrorCode = 31
 )
 
 type ServiceErrorCode int
@@ -77,19 +92,24 @@ func Find(code ServiceErrorCode) (bool, *ServiceError) {
 
 func Errors() ServiceErrors {
 	return ServiceErrors{
-		ServiceError{ErrorInvalidToken, "Invalid token provided", http.StatusForbidden},
-		ServiceError{ErrorForbidden, "Forbidden to perform this action", http.StatusForbidden},
-		ServiceError{ErrorConflict, "An entity with the specified unique values already exists", http.StatusConflict},
-		ServiceError{ErrorNotFound, "Resource not found", http.StatusNotFound},
-		ServiceError{ErrorValidation, "General validation failure", http.StatusBadRequest},
-		ServiceError{ErrorGeneral, "Unspecified error", http.StatusInternalServerError},
-		ServiceError{ErrorNotImplemented, "HTTP Method not implemented for this endpoint", http.StatusMethodNotAllowed},
-		ServiceError{ErrorUnauthorized, "Account is unauthorized to perform this action", http.StatusForbidden},
-		ServiceError{ErrorUnauthenticated, "Account authentication could not be verified", http.StatusUnauthorized},
-		ServiceError{ErrorMalformedRequest, "Unable to read request body", http.StatusBadRequest},
-		ServiceError{ErrorBadRequest, "Bad request", http.StatusBadRequest},
-		ServiceError{ErrorFailedToParseSearch, "Failed to parse search query", http.StatusBadRequest},
-		ServiceError{ErrorDatabaseAdvisoryLock, "Database advisory lock error", http.StatusInternalServerError},
+		ServiceError{ErrorInvalidToken, "Invalid token provided", http.StatusForbidden, nil},
+		ServiceError{ErrorForbidden, "Forbidden to perform this action", http.StatusForbidden, nil},
+		ServiceError{ErrorConflict, "An entity with the specified unique values already exists", http.StatusConflict, nil},
+		ServiceError{ErrorNotFound, "Resource not found", http.StatusNotFound, nil},
+		ServiceError{ErrorValidation, "General validation failure", http.StatusBadRequest, nil},
+		ServiceError{ErrorGeneral, "Unspecified error", http.StatusInternalServerError, nil},
+		ServiceError{ErrorNotImplemented, "HTTP Method not implemented for this endpoint", http.StatusMethodNotAllowed, nil},
+		ServiceError{ErrorUnauthorized, "Account is unauthorized to perform this action", http.StatusForbidden, nil},
+		ServiceError{ErrorUnauthenticated, "Account authentication could not be verified", http.StatusUnauthorized, nil},
+		ServiceError{ErrorMalformedRequest, "Unable to read request body", http.StatusBadRequest, nil},
+		ServiceError{ErrorBadRequest, "Bad request", http.StatusBadRequest, nil},
+		ServiceError{ErrorFailedToParseSearch, "Failed to parse search query", http.StatusBadRequest, nil},
+		ServiceError{ErrorDatabaseAdvisoryLock, "Database advisory lock error", http.StatusInternalServerError, nil},
+		ServiceError{ErrorPreconditionFailed, "Resource version does not match the request precondition", http.StatusPreconditionFailed, nil},
+		ServiceError{ErrorVersionConflict, "Resource was modified concurrently", http.StatusConflict, nil},
+		ServiceError{ErrorIdempotencyKeyReused, "Idempotency key was already used for a different request", http.StatusUnprocessableEntity, nil},
+		ServiceError{ErrorUnsupportedMediaType, "Unsupported media type", http.StatusUnsupportedMediaType, nil},
+		ServiceError{ErrorTooManyRequests, "Too many requests, the rate limit was exceeded", http.StatusTooManyRequests, nil},
 	}
 }
 
@@ -100,6 +120,18 @@ type ServiceError struct {
 	Reason string
 	// HttopCode is the HttpCode associated with the error when the error is returned as an API response
 	HttpCode int
+	// FieldViolations are the fields of the request that failed validation, if the error is about them
+	FieldViolations []FieldViolation
+}
+
+// FieldViolation is a field of a request that failed a validation rule
+type FieldViolation struct {
+	// Field is the name of the field in the request, e.g. species or items[2].species
+	Field string
+	// Code is the rule the field failed, e.g. required or max_length
+	Code string
+	// Message describes the violation, e.g. species is required
+	Message string
 }
 
 // New Reason can be a string with format verbs, which will be replace by the specified values
@@ -108,8 +140,8 @@ func New(code ServiceErrorCode, reason string, values ...interface{}) *ServiceEr
 	var err *ServiceError
 	exists, err := Find(code)
 	if !exists {
-		glog.Errorf("Undefined error code used: %d", code)
-		err = &ServiceError{ErrorGeneral, "Unspecified error", 500}
+		logger.Errorf("Undefined error code used: %d", code)
+		err = &ServiceError{ErrorGeneral, "Unspecified error", 500, nil}
 	}
 
 	// If the reason is unspecified, use the default
@@ -136,12 +168,20 @@ func (e *ServiceError) IsConflict() bool {
 	return e.Code == Conflict("").Code
 }
 
+func (e *ServiceError) IsPreconditionFailed() bool {
+	return e.Code == PreconditionFailed("").Code
+}
+
+func (e *ServiceError) IsVersionConflict() bool {
+	return e.Code == VersionConflict("").Code
+}
+
 func (e *ServiceError) IsForbidden() bool {
 	return e.Code == Forbidden("").Code
 }
 
 func (e *ServiceError) AsOpenapiError(operationID string) openapi.Error {
-	return openapi.Error{
+	openapiErr := openapi.Error{
 		Kind:        openapi.PtrString("Error"),
 		Id:          openapi.PtrString(strconv.Itoa(int(e.Code))),
 		Href:        Href(e.Code),
@@ -149,6 +189,14 @@ func (e *ServiceError) AsOpenapiError(operationID string) openapi.Error {
 		Reason:      openapi.PtrString(e.Reason),
 		OperationId: openapi.PtrString(operationID),
 	}
+	for _, violation := range e.FieldViolations {
+		openapiErr.FieldViolations = append(openapiErr.FieldViolations, openapi.FieldViolation{
+			Field:   openapi.PtrString(violation.Field),
+			Code:    openapi.PtrString(violation.Code),
+			Message: openapi.PtrString(violation.Message),
+		})
+	}
+	return openapiErr
 }
 
 func CodeStr(code ServiceErrorCode) *string {
@@ -187,10 +235,42 @@ func Conflict(reason string, values ...interface{}) *ServiceError {
 	return New(ErrorConflict, reason, values...)
 }
 
+func PreconditionFailed(reason string, values ...interface{}) *ServiceError {
+	return New(ErrorPreconditionFailed, reason, values...)
+}
+
+func VersionConflict(reason string, values ...interface{}) *ServiceError {
+	return New(ErrorVersionConflict, reason, values...)
+}
+
+func IdempotencyKeyReused(reason string, values ...interface{}) *ServiceError {
+	return New(ErrorIdempotencyKeyReused, reason, values...)
+}
+
+func UnsupportedMediaType(reason string, values ...interface{}) *ServiceError {
+	return New(ErrorUnsupportedMediaType, reason, values...)
+}
+
+func TooManyRequests(reason string, values ...interface{}) *ServiceError {
+	return New(ErrorTooManyRequests, reason, values...)
+}
+
 func Validation(reason string, values ...interface{}) *ServiceError {
 	return New(ErrorValidation, reason, values...)
 }
 
+// FieldValidation is a validation error for the fields of a request that failed their rules, its reason
+// lists every violation
+func FieldValidation(violations []FieldViolation) *ServiceError {
+	messages := make([]string, len(violations))
+	for i, violation := range violations {
+		messages[i] = violation.Message
+	}
+	err := New(ErrorValidation, "%s", strings.Join(messages, "; "))
+	err.FieldViolations = violations
+	return err
+}
+
 func MalformedRequest(reason string, values ...interface{}) *ServiceError {
 	return New(ErrorMalformedRequest, reason, values...)
 }
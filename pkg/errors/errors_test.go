@@ -1,6 +1,7 @@
 package errors
 
 import (
+	"net/http"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -23,3 +24,33 @@ func TestErrorFind(t *testing.T) {
 	Expect(exists).To(Equal(false))
 	Expect(err).To(BeNil())
 }
+
+func TestConcurrencyErrors(t *testing.T) {
+	RegisterTestingT(t)
+	err := PreconditionFailed("If-Match %s does not match", `"1"`)
+	Expect(err.HttpCode).To(Equal(http.StatusPreconditionFailed))
+	Expect(err.IsPreconditionFailed()).To(BeTrue())
+	Expect(err.IsConflict()).To(BeFalse())
+
+	err = VersionConflict("")
+	Expect(err.HttpCode).To(Equal(http.StatusConflict))
+	Expect(err.IsVersionConflict()).To(BeTrue())
+	Expect(err.IsConflict()).To(BeFalse())
+}
+
+func TestFieldValidation(t *testing.T) {
+	RegisterTestingT(t)
+	err := FieldValidation([]FieldViolation{
+		{Field: "species", Code: "required", Message: "species is required"},
+		{Field: "estimated_age", Code: "minimum", Message: "estimated_age must be at least 0"},
+	})
+	Expect(err.Code).To(Equal(ErrorValidation))
+	Expect(err.HttpCode).To(Equal(http.StatusBadRequest))
+	Expect(err.Reason).To(Equal("species is required; estimated_age must be at least 0"))
+
+	openapiErr := err.AsOpenapiError("op-1")
+	Expect(openapiErr.FieldViolations).To(HaveLen(2))
+	Expect(openapiErr.FieldViolations[1].GetField()).To(Equal("estimated_age"))
+	Expect(openapiErr.FieldViolations[1].GetCode()).To(Equal("minimum"))
+	Expect(NotFound("nope").AsOpenapiError("op-1").FieldViolations).To(BeEmpty())
+}
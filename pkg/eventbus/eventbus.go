@@ -0,0 +1,115 @@
+// Package eventbus announces committed events to the controllers and watch brokers of every replica.
+//
+// Events are written to the events table in the transaction of the change they record (the outbox), and
+// announced on the EventBus once that transaction commits. Announcements are best effort: the sync
+// controller finds the events of lost messages in the events table.
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/config"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+)
+
+// Backends of the EventBus, for the --event-bus flag
+const (
+	// PostgresBackend announces events with pg_notify on the database every replica shares
+	PostgresBackend = "postgres"
+	// MemoryBackend announces events to the subscribers of the same process only
+	MemoryBackend = "memory"
+	// RedisBackend announces events with Redis pub/sub on a server every replica shares
+	RedisBackend = "redis"
+)
+
+// EventsChannel is the channel events are announced on
+const EventsChannel = "events"
+
+// Message announces a committed event. It carries what the controllers and the watch brokers need to
+// route the event, so they don't have to load it first.
+type Message struct {
+	ID        string        `json:"id"`
+	Source    string        `json:"source"`
+	SourceID  string        `json:"source_id"`
+	EventType api.EventType `json:"event_type"`
+	// ChangedFields is the field mask of an update, see api.Event
+	ChangedFields []string `json:"changed_fields,omitempty"`
+	// OrganizationID is the organization of the changed resource
+	OrganizationID string `json:"organization_id,omitempty"`
+	// Seq numbers the event, see api.Event
+	Seq int64 `json:"seq,omitempty"`
+}
+
+func NewMessage(event *api.Event) *Message {
+	return &Message{
+		ID:             event.ID,
+		Source:         event.Source,
+		SourceID:       event.SourceID,
+		EventType:      event.EventType,
+		ChangedFields:  event.FieldMask(),
+		OrganizationID: event.OrganizationID,
+		Seq:            event.Seq,
+	}
+}
+
+// Complete tells whether the message carries the event's data. Replicas of earlier versions only
+// announce the event id.
+func (m *Message) Complete() bool {
+	return m.Source != ""
+}
+
+// Encode returns the payload of the message
+func (m *Message) Encode() (string, error) {
+	payload, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+	return string(payload), nil
+}
+
+// DecodeMessage parses a payload of Encode, or the bare event id earlier versions announced
+func DecodeMessage(payload string) (*Message, error) {
+	if !strings.HasPrefix(payload, "{") {
+		return &Message{ID: payload}, nil
+	}
+	msg := &Message{}
+	if err := json.Unmarshal([]byte(payload), msg); err != nil {
+		return nil, err
+	}
+	if msg.ID == "" {
+		return nil, fmt.Errorf("message without an event id")
+	}
+	return msg, nil
+}
+
+// Handler receives the messages of a subscription
+type Handler func(msg *Message)
+
+// EventBus carries the announcements of committed events between the replicas of the service
+type EventBus interface {
+	// Publish announces msg to the subscribers of every replica
+	Publish(ctx context.Context, msg *Message) error
+	// Subscribe calls handler with the messages published from now on, one at a time, until ctx ends or
+	// the bus is closed
+	Subscribe(ctx context.Context, handler Handler)
+	// Close ends the subscriptions of the bus
+	Close()
+}
+
+// New returns the EventBus of the event_bus config
+func New(cfg *config.DatabaseConfig, sessionFactory db.SessionFactory) (EventBus, error) {
+	switch cfg.EventBus {
+	case PostgresBackend:
+		return NewPostgresEventBus(sessionFactory), nil
+	case MemoryBackend:
+		return NewMemoryEventBus(), nil
+	case RedisBackend:
+		return NewRedisEventBus(cfg.EventBusURL, cfg.EventBusPassword)
+	default:
+		return nil, fmt.Errorf("unknown event bus %q, expected %s, %s or %s", cfg.EventBus, PostgresBackend, MemoryBackend, RedisBackend)
+	}
+}
@@ -0,0 +1,130 @@
+package eventbus
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/config"
+)
+
+func TestMessageEncoding(t *testing.T) {
+	RegisterTestingT(t)
+
+	event := &api.Event{Meta: api.Meta{ID: "2XIENcJIi9t2eBblhWVCtWLdbDZ", OrganizationID: "acme"}, Source: "Dinosaurs", SourceID: "rex", EventType: api.UpdateEventType}
+	payload, err := NewMessage(event).Encode()
+	Expect(err).To(BeNil())
+	msg, err := DecodeMessage(payload)
+	Expect(err).To(BeNil())
+	Expect(msg).To(Equal(&Message{ID: event.ID, Source: "Dinosaurs", SourceID: "rex", EventType: api.UpdateEventType, OrganizationID: "acme"}))
+	Expect(msg.Complete()).To(BeTrue())
+
+	// earlier versions announce the bare event id
+	msg, err = DecodeMessage(event.ID)
+	Expect(err).To(BeNil())
+	Expect(msg).To(Equal(&Message{ID: event.ID}))
+	Expect(msg.Complete()).To(BeFalse())
+
+	_, err = DecodeMessage(`{"source": "Dinosaurs"}`)
+	Expect(err).NotTo(BeNil())
+}
+
+func TestMemoryEventBus(t *testing.T) {
+	RegisterTestingT(t)
+
+	bus := NewMemoryEventBus()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// every subscriber receives every message, in order
+	received := []chan string{make(chan string, 10), make(chan string, 10)}
+	done := make(chan struct{}, 2)
+	for _, ch := range received {
+		go func(ch chan string) {
+			bus.Subscribe(ctx, func(msg *Message) { ch <- msg.ID })
+			done <- struct{}{}
+		}(ch)
+	}
+	Eventually(func() int {
+		b := bus.(*memoryEventBus)
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+		return len(b.subscribers)
+	}).Should(Equal(2))
+
+	Expect(bus.Publish(ctx, &Message{ID: "a"})).To(Succeed())
+	Expect(bus.Publish(ctx, &Message{ID: "b"})).To(Succeed())
+	for _, ch := range received {
+		Eventually(ch).Should(Receive(Equal("a")))
+		Eventually(ch).Should(Receive(Equal("b")))
+	}
+
+	// subscriptions end with their context, and all of them with the bus
+	cancel()
+	Eventually(done).Should(Receive())
+	Eventually(done).Should(Receive())
+	go func() {
+		bus.Subscribe(context.Background(), func(msg *Message) {})
+		done <- struct{}{}
+	}()
+	bus.Close()
+	Eventually(done).Should(Receive())
+	Expect(bus.Publish(context.Background(), &Message{ID: "c"})).To(Equal(ErrBusClosed))
+}
+
+func TestMemoryEventBusQueueSize(t *testing.T) {
+	RegisterTestingT(t)
+
+	bus := NewMemoryEventBus()
+	defer bus.Close()
+	bus.(*memoryEventBus).queueSize = 2
+
+	// the handler blocks on the first message, the queue holds two more and drops the rest
+	received := make(chan string, 10)
+	release := make(chan struct{})
+	go bus.Subscribe(context.Background(), func(msg *Message) {
+		received <- msg.ID
+		<-release
+	})
+	Eventually(func() int {
+		b := bus.(*memoryEventBus)
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+		return len(b.subscribers)
+	}).Should(Equal(1))
+
+	Expect(bus.Publish(context.Background(), &Message{ID: "a"})).To(Succeed())
+	Eventually(received).Should(Receive(Equal("a")))
+	for _, id := range []string{"b", "c", "d"} {
+		Expect(bus.Publish(context.Background(), &Message{ID: id})).To(Succeed())
+	}
+	close(release)
+	Eventually(received).Should(Receive(Equal("b")))
+	Eventually(received).Should(Receive(Equal("c")))
+	Consistently(received).ShouldNot(Receive())
+}
+
+func TestNew(t *testing.T) {
+	RegisterTestingT(t)
+
+	bus, err := New(&config.DatabaseConfig{EventBus: MemoryBackend}, nil)
+	Expect(err).To(BeNil())
+	Expect(bus).To(BeAssignableToTypeOf(&memoryEventBus{}))
+
+	bus, err = New(&config.DatabaseConfig{EventBus: RedisBackend, EventBusURL: "redis://:secret@redis.example.com"}, nil)
+	Expect(err).To(BeNil())
+	Expect(bus).To(Equal(&redisEventBus{address: "redis.example.com:6379", password: "secret", closed: bus.(*redisEventBus).closed}))
+
+	bus, err = New(&config.DatabaseConfig{EventBus: RedisBackend, EventBusURL: "rediss://redis.example.com:6380", EventBusPassword: "secret"}, nil)
+	Expect(err).To(BeNil())
+	Expect(bus.(*redisEventBus).tls).To(BeTrue())
+	Expect(bus.(*redisEventBus).address).To(Equal("redis.example.com:6380"))
+	Expect(bus.(*redisEventBus).password).To(Equal("secret"))
+
+	_, err = New(&config.DatabaseConfig{EventBus: RedisBackend, EventBusURL: "http://redis.example.com"}, nil)
+	Expect(err).NotTo(BeNil())
+
+	_, err = New(&config.DatabaseConfig{EventBus: "kafka"}, nil)
+	Expect(err).NotTo(BeNil())
+}
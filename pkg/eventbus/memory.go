@@ -0,0 +1,120 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+)
+
+var ErrBusClosed = errors.New("event bus is closed")
+
+// memoryQueueSize is how many messages a subscriber of the memory event bus holds before further ones are
+// dropped
+const memoryQueueSize = 10000
+
+var _ EventBus = &memoryEventBus{}
+
+// memoryEventBus delivers messages to the subscribers of its own process. It stands in for a message
+// bus shared by the replicas in tests and single replica deployments, without holding a database
+// connection per subscription.
+type memoryEventBus struct {
+	mu          sync.RWMutex
+	subscribers map[*memorySubscriber]bool
+	queueSize   int
+	closed      chan struct{}
+	closeOnce   sync.Once
+}
+
+// memorySubscriber queues the messages of a subscription, so a slow handler doesn't block Publish. Once
+// queueSize messages wait, further ones are dropped like the announcements a bus loses, and their events
+// are left to the sync controller.
+type memorySubscriber struct {
+	mu        sync.Mutex
+	queue     []*Message
+	queueSize int
+	wake      chan struct{}
+}
+
+func NewMemoryEventBus() EventBus {
+	return &memoryEventBus{
+		subscribers: map[*memorySubscriber]bool{},
+		queueSize:   memoryQueueSize,
+		closed:      make(chan struct{}),
+	}
+}
+
+func (b *memoryEventBus) Publish(ctx context.Context, msg *Message) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	select {
+	case <-b.closed:
+		return ErrBusClosed
+	default:
+	}
+	for sub := range b.subscribers {
+		sub.push(msg)
+	}
+	return nil
+}
+
+func (b *memoryEventBus) Subscribe(ctx context.Context, handler Handler) {
+	sub := &memorySubscriber{queueSize: b.queueSize, wake: make(chan struct{}, 1)}
+	b.mu.Lock()
+	b.subscribers[sub] = true
+	b.mu.Unlock()
+	defer func() {
+		b.mu.Lock()
+		delete(b.subscribers, sub)
+		b.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-b.closed:
+			return
+		case <-sub.wake:
+			for msg := sub.pop(); msg != nil; msg = sub.pop() {
+				handler(msg)
+			}
+		}
+	}
+}
+
+func (b *memoryEventBus) Close() {
+	b.closeOnce.Do(func() {
+		close(b.closed)
+	})
+}
+
+func (s *memorySubscriber) push(msg *Message) {
+	s.mu.Lock()
+	if len(s.queue) >= s.queueSize {
+		s.mu.Unlock()
+		logger.Warningf("Memory event bus subscriber holds %d messages, dropping the message of event %s", s.queueSize, msg.ID)
+		return
+	}
+	s.queue = append(s.queue, msg)
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *memorySubscriber) pop() *Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.queue) == 0 {
+		return nil
+	}
+	msg := s.queue[0]
+	s.queue = s.queue[1:]
+	return msg
+}
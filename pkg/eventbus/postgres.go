@@ -0,0 +1,44 @@
+package eventbus
+
+import (
+	"context"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+)
+
+var _ EventBus = &postgresEventBus{}
+
+// postgresEventBus announces events with pg_notify on EventsChannel, and subscribes with a LISTEN
+// connection per subscription
+type postgresEventBus struct {
+	sessionFactory db.SessionFactory
+}
+
+func NewPostgresEventBus(sessionFactory db.SessionFactory) EventBus {
+	return &postgresEventBus{sessionFactory: sessionFactory}
+}
+
+func (b *postgresEventBus) Publish(ctx context.Context, msg *Message) error {
+	payload, err := msg.Encode()
+	if err != nil {
+		return err
+	}
+	g2 := b.sessionFactory.New(ctx)
+	return g2.Exec("select pg_notify(?, ?)", EventsChannel, payload).Error
+}
+
+func (b *postgresEventBus) Subscribe(ctx context.Context, handler Handler) {
+	b.sessionFactory.NewListener(ctx, EventsChannel, func(payload string) {
+		msg, err := DecodeMessage(payload)
+		if err != nil {
+			logger.Errorf("Unable to decode message of the %s channel %q: %s", EventsChannel, payload, err)
+			return
+		}
+		handler(msg)
+	})
+}
+
+// Close is a no-op, subscriptions end with their context
+func (b *postgresEventBus) Close() {
+}
@@ -0,0 +1,319 @@
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+)
+
+var _ EventBus = &redisEventBus{}
+
+var (
+	// redisTimeout bounds connecting to the Redis server and each publish
+	redisTimeout = 10 * time.Second
+	// redisMinReconnectInterval and redisMaxReconnectInterval bound the backoff of a subscription that
+	// lost its connection
+	redisMinReconnectInterval = time.Second
+	redisMaxReconnectInterval = time.Minute
+)
+
+// redisEventBus announces events with PUBLISH on EventsChannel of a Redis server every replica shares, and
+// subscribes with a connection per subscription. Like LISTEN, Redis pub/sub doesn't keep messages: those
+// published while a subscription reconnects, or after Redis disconnected a subscriber that fell behind,
+// are lost.
+type redisEventBus struct {
+	address  string
+	tls      bool
+	password string
+
+	// mu guards conn, the connection Publish reuses. It's nil until the first Publish and after a failure.
+	mu        sync.Mutex
+	conn      *redisConn
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// NewRedisEventBus returns the EventBus of the Redis server at rawURL, redis://host:port or
+// rediss://host:port for TLS. password overrides the password of rawURL, if any.
+func NewRedisEventBus(rawURL, password string) (EventBus, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis event bus URL: %w", err)
+	}
+	if u.Scheme != "redis" && u.Scheme != "rediss" {
+		return nil, fmt.Errorf("invalid redis event bus URL %q, expected redis:// or rediss://", u.Redacted())
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("redis event bus URL %q has no host", u.Redacted())
+	}
+	address := u.Host
+	if u.Port() == "" {
+		address = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if urlPassword, ok := u.User.Password(); ok && password == "" {
+		password = urlPassword
+	}
+	return &redisEventBus{
+		address:  address,
+		tls:      u.Scheme == "rediss",
+		password: password,
+		closed:   make(chan struct{}),
+	}, nil
+}
+
+func (b *redisEventBus) Publish(ctx context.Context, msg *Message) error {
+	select {
+	case <-b.closed:
+		return ErrBusClosed
+	default:
+	}
+	payload, err := msg.Encode()
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	reused := b.conn != nil
+	err = b.publish(ctx, payload)
+	var reply redisError
+	if err != nil && reused && !errors.As(err, &reply) {
+		// the connection may have been dropped while it was idle, e.g. when Redis restarted: publish once more
+		// on a new one
+		err = b.publish(ctx, payload)
+	}
+	return err
+}
+
+// publish sends payload with PUBLISH on b.conn, connecting first if there's no connection. The connection is
+// closed when the publish fails. b.mu must be held.
+func (b *redisEventBus) publish(ctx context.Context, payload string) error {
+	if b.conn == nil {
+		conn, err := b.dial(ctx)
+		if err != nil {
+			return err
+		}
+		b.conn = conn
+	}
+	if err := b.conn.conn.SetDeadline(time.Now().Add(redisTimeout)); err != nil {
+		b.conn.close()
+		b.conn = nil
+		return err
+	}
+	if _, err := b.conn.do("PUBLISH", EventsChannel, payload); err != nil {
+		b.conn.close()
+		b.conn = nil
+		return err
+	}
+	return nil
+}
+
+func (b *redisEventBus) Subscribe(ctx context.Context, handler Handler) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-b.closed:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	backoff := redisMinReconnectInterval
+	for {
+		err := b.subscribe(ctx, handler, func() { backoff = redisMinReconnectInterval })
+		if ctx.Err() != nil {
+			return
+		}
+		logger.Errorf("Redis event bus subscription to %s lost, reconnecting in %s: %s", b.address, backoff, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, redisMaxReconnectInterval)
+	}
+}
+
+// subscribe passes the messages of EventsChannel to handler until the connection fails or ctx ends.
+// subscribed is called once the server confirmed the subscription.
+func (b *redisEventBus) subscribe(ctx context.Context, handler Handler, subscribed func()) error {
+	conn, err := b.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.close()
+	// closing the connection ends the blocking read below
+	stop := context.AfterFunc(ctx, conn.close)
+	defer stop()
+
+	if err := conn.send("SUBSCRIBE", EventsChannel); err != nil {
+		return err
+	}
+	for {
+		reply, err := conn.receive()
+		if err != nil {
+			return err
+		}
+		push, ok := reply.([]interface{})
+		if !ok || len(push) != 3 {
+			return fmt.Errorf("unexpected reply to a subscription: %v", reply)
+		}
+		switch push[0] {
+		case "subscribe":
+			logger.Infof("Subscribed to the %s channel of the redis event bus at %s", EventsChannel, b.address)
+			subscribed()
+		case "message":
+			payload, _ := push[2].(string)
+			msg, err := DecodeMessage(payload)
+			if err != nil {
+				logger.Errorf("Unable to decode message of the %s channel %q: %s", EventsChannel, payload, err)
+				continue
+			}
+			handler(msg)
+		}
+	}
+}
+
+func (b *redisEventBus) Close() {
+	b.closeOnce.Do(func() {
+		close(b.closed)
+	})
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.conn != nil {
+		b.conn.close()
+		b.conn = nil
+	}
+}
+
+// dial connects to the Redis server and authenticates
+func (b *redisEventBus) dial(ctx context.Context) (*redisConn, error) {
+	ctx, cancel := context.WithTimeout(ctx, redisTimeout)
+	defer cancel()
+
+	dialer := &net.Dialer{}
+	var conn net.Conn
+	var err error
+	if b.tls {
+		host, _, _ := net.SplitHostPort(b.address)
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", b.address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", b.address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &redisConn{conn: conn, reader: bufio.NewReader(conn)}
+	if b.password != "" {
+		if err := conn.SetDeadline(time.Now().Add(redisTimeout)); err != nil {
+			c.close()
+			return nil, err
+		}
+		if _, err := c.do("AUTH", b.password); err != nil {
+			c.close()
+			return nil, fmt.Errorf("unable to authenticate to the redis event bus: %w", err)
+		}
+		if err := conn.SetDeadline(time.Time{}); err != nil {
+			c.close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// redisError is an error reply of the Redis server
+type redisError string
+
+func (e redisError) Error() string {
+	return string(e)
+}
+
+// redisConn speaks the Redis serialization protocol (RESP2) over a connection
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// do sends a command and returns its reply
+func (c *redisConn) do(args ...string) (interface{}, error) {
+	if err := c.send(args...); err != nil {
+		return nil, err
+	}
+	return c.receive()
+}
+
+// send writes a command as an array of bulk strings
+func (c *redisConn) send(args ...string) error {
+	var command strings.Builder
+	fmt.Fprintf(&command, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&command, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	_, err := io.WriteString(c.conn, command.String())
+	return err
+}
+
+// receive reads a reply: a string for simple and bulk strings, an int64 for integers, a []interface{} for
+// arrays and nil for null replies. Error replies are returned as redisError.
+func (c *redisConn) receive() (interface{}, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || !strings.HasSuffix(line, "\r\n") {
+		return nil, fmt.Errorf("malformed redis reply %q", line)
+	}
+	kind, body := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return body, nil
+	case '-':
+		return nil, redisError(body)
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+	case '$':
+		size, err := strconv.Atoi(body)
+		if err != nil || size < 0 {
+			return nil, err
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(c.reader, data); err != nil {
+			return nil, err
+		}
+		return string(data[:size]), nil
+	case '*':
+		size, err := strconv.Atoi(body)
+		if err != nil || size < 0 {
+			return nil, err
+		}
+		items := make([]interface{}, size)
+		for i := range items {
+			if items[i], err = c.receive(); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("malformed redis reply %q", line)
+	}
+}
+
+func (c *redisConn) close() {
+	_ = c.conn.Close()
+}
@@ -0,0 +1,171 @@
+package eventbus
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+// fakeRedis is a local stand-in for a Redis server, implementing the commands of the event bus: AUTH,
+// SUBSCRIBE and PUBLISH
+type fakeRedis struct {
+	listener net.Listener
+	password string
+
+	mu          sync.Mutex
+	conns       map[net.Conn]bool
+	subscribers map[net.Conn]bool
+}
+
+func newFakeRedis(t *testing.T, password string) *fakeRedis {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).To(BeNil())
+	server := &fakeRedis{listener: listener, password: password, conns: map[net.Conn]bool{}, subscribers: map[net.Conn]bool{}}
+	t.Cleanup(func() {
+		listener.Close()
+		server.disconnect()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			server.mu.Lock()
+			server.conns[conn] = true
+			server.mu.Unlock()
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (s *fakeRedis) url() string {
+	return "redis://" + s.listener.Addr().String()
+}
+
+// disconnect closes every client connection
+func (s *fakeRedis) disconnect() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for conn := range s.conns {
+		conn.Close()
+		delete(s.conns, conn)
+		delete(s.subscribers, conn)
+	}
+}
+
+func (s *fakeRedis) subscriberCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.subscribers)
+}
+
+func (s *fakeRedis) serve(conn net.Conn) {
+	client := &redisConn{conn: conn, reader: bufio.NewReader(conn)}
+	authenticated := s.password == ""
+	for {
+		reply, err := client.receive()
+		if err != nil {
+			return
+		}
+		command, _ := reply.([]interface{})
+		args := make([]string, len(command))
+		for i := range command {
+			args[i], _ = command[i].(string)
+		}
+		if len(args) == 0 {
+			return
+		}
+
+		s.mu.Lock()
+		switch {
+		case strings.EqualFold(args[0], "AUTH"):
+			authenticated = len(args) == 2 && args[1] == s.password
+			if authenticated {
+				fmt.Fprint(conn, "+OK\r\n")
+			} else {
+				fmt.Fprint(conn, "-WRONGPASS invalid password\r\n")
+			}
+		case !authenticated:
+			fmt.Fprint(conn, "-NOAUTH Authentication required.\r\n")
+		case strings.EqualFold(args[0], "SUBSCRIBE"):
+			s.subscribers[conn] = true
+			fmt.Fprintf(conn, "*3\r\n$9\r\nsubscribe\r\n%s:1\r\n", bulkString(args[1]))
+		case strings.EqualFold(args[0], "PUBLISH"):
+			for subscriber := range s.subscribers {
+				fmt.Fprintf(subscriber, "*3\r\n$7\r\nmessage\r\n%s%s", bulkString(args[1]), bulkString(args[2]))
+			}
+			fmt.Fprintf(conn, ":%d\r\n", len(s.subscribers))
+		default:
+			fmt.Fprintf(conn, "-ERR unknown command '%s'\r\n", args[0])
+		}
+		s.mu.Unlock()
+	}
+}
+
+func bulkString(value string) string {
+	return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+}
+
+func TestRedisEventBus(t *testing.T) {
+	RegisterTestingT(t)
+	reconnectInterval := redisMinReconnectInterval
+	redisMinReconnectInterval = 10 * time.Millisecond
+	t.Cleanup(func() { redisMinReconnectInterval = reconnectInterval })
+
+	server := newFakeRedis(t, "secret")
+	// replicas announce on and subscribe to the same server
+	publisher, err := NewRedisEventBus(server.url(), "secret")
+	Expect(err).To(BeNil())
+	subscriber, err := NewRedisEventBus(server.url(), "secret")
+	Expect(err).To(BeNil())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	received := make(chan *Message, 10)
+	done := make(chan struct{})
+	go func() {
+		subscriber.Subscribe(ctx, func(msg *Message) { received <- msg })
+		close(done)
+	}()
+	Eventually(server.subscriberCount).Should(Equal(1))
+
+	msg := &Message{ID: "a", Source: "Dinosaurs", SourceID: "rex", Seq: 1}
+	Expect(publisher.Publish(ctx, msg)).To(Succeed())
+	Eventually(received).Should(Receive(Equal(msg)))
+
+	// both connections are reestablished once the server drops them, the publish right away
+	server.disconnect()
+	Eventually(server.subscriberCount).Should(Equal(1))
+	Expect(publisher.Publish(ctx, &Message{ID: "b"})).To(Succeed())
+	Eventually(received).Should(Receive(Equal(&Message{ID: "b"})))
+
+	// subscriptions end with their context
+	cancel()
+	Eventually(done).Should(BeClosed())
+
+	// and with the bus
+	done = make(chan struct{})
+	go func() {
+		subscriber.Subscribe(context.Background(), func(msg *Message) {})
+		close(done)
+	}()
+	subscriber.Close()
+	Eventually(done).Should(BeClosed())
+	publisher.Close()
+	Expect(publisher.Publish(context.Background(), &Message{ID: "c"})).To(Equal(ErrBusClosed))
+
+	// a wrong password fails the publish
+	unauthorized, err := NewRedisEventBus(server.url(), "wrong")
+	Expect(err).To(BeNil())
+	defer unauthorized.Close()
+	Expect(unauthorized.Publish(context.Background(), &Message{ID: "d"})).To(MatchError(ContainSubstring("WRONGPASS")))
+}
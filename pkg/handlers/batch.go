@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
+
+// BatchDeleteRequest is the body of POST /{kind}:batchDelete
+type BatchDeleteRequest struct {
+	IDs          []string `json:"ids"`
+	AllOrNothing bool     `json:"all_or_nothing"`
+}
+
+// BatchResponse is the response of the batch endpoints, with one result per item in request order
+type BatchResponse struct {
+	Kind  string        `json:"kind"`
+	Items []BatchResult `json:"items"`
+}
+
+// BatchResult is the outcome of one item of a batch. Status is the HTTP status the item would have had
+// as a request of its own; Item is the resource for successful creates and patches, Error is set for failures.
+type BatchResult struct {
+	ID     string         `json:"id,omitempty"`
+	Status int            `json:"status"`
+	Item   interface{}    `json:"item,omitempty"`
+	Error  *openapi.Error `json:"error,omitempty"`
+}
+
+// NewBatchResult returns the result of an item that succeeded with status, or failed with err
+func NewBatchResult(ctx context.Context, id string, status int, item interface{}, err *errors.ServiceError) BatchResult {
+	if err != nil {
+		openapiErr := err.AsOpenapiError(logger.GetOperationID(ctx))
+		return BatchResult{ID: id, Status: err.HttpCode, Error: &openapiErr}
+	}
+	return BatchResult{ID: id, Status: status, Item: item}
+}
+
+// ValidateBatchSize checks that the slice field of a batch request holds between 1 and services.MaxBatchSize items
+func ValidateBatchSize(i interface{}, fieldName string, field string) Validate {
+	return func() *errors.ServiceError {
+		n := reflect.ValueOf(i).Elem().FieldByName(fieldName).Len()
+		if n == 0 {
+			return errors.Validation("%s is required", field)
+		}
+		if n > services.MaxBatchSize {
+			return errors.Validation("%s holds %d items, at most %d are allowed", field, n, services.MaxBatchSize)
+		}
+		return nil
+	}
+}
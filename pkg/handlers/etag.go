@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+// ETag formats a resource version as a strong entity tag
+func ETag(resourceVersion int64) string {
+	return strconv.Quote(strconv.FormatInt(resourceVersion, 10))
+}
+
+// SetETag sets the ETag response header for the given resource version
+func SetETag(w http.ResponseWriter, resourceVersion int64) {
+	w.Header().Set("ETag", ETag(resourceVersion))
+}
+
+// HasIfMatch returns true when the request carries an If-Match precondition
+func HasIfMatch(r *http.Request) bool {
+	return r.Header.Get("If-Match") != ""
+}
+
+// ValidateIfMatch checks the If-Match request header against the current resource version.
+// Requests without an If-Match header are unconditional and always pass.
+func ValidateIfMatch(r *http.Request, resourceVersion int64) *errors.ServiceError {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" {
+		return nil
+	}
+	if !matchesETag(ifMatch, ETag(resourceVersion), false) {
+		return errors.PreconditionFailed("If-Match %s does not match current resource version %s", ifMatch, ETag(resourceVersion))
+	}
+	return nil
+}
+
+// IfMatchVersions returns the resource versions the If-Match request header accepts, for a write that checks
+// them in its WHERE clause rather than after a read. It returns nil for requests without If-Match or with
+// If-Match: *, which accept any version, and a precondition failure when no entity tag can ever match.
+func IfMatchVersions(r *http.Request) ([]int64, *errors.ServiceError) {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" {
+		return nil, nil
+	}
+	var versions []int64
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return nil, nil
+		}
+		// If-Match uses strong comparison, weak entity tags never match
+		unquoted, err := strconv.Unquote(candidate)
+		if err != nil || strings.HasPrefix(candidate, "W/") {
+			continue
+		}
+		if version, err := strconv.ParseInt(unquoted, 10, 64); err == nil {
+			versions = append(versions, version)
+		}
+	}
+	if len(versions) == 0 {
+		return nil, errors.PreconditionFailed("If-Match %s does not match any resource version", ifMatch)
+	}
+	return versions, nil
+}
+
+// notModified returns true when the If-None-Match request header matches etag
+func notModified(r *http.Request, etag string) bool {
+	ifNoneMatch := r.Header.Get("If-None-Match")
+	if ifNoneMatch == "" || etag == "" {
+		return false
+	}
+	return matchesETag(ifNoneMatch, etag, true)
+}
+
+// matchesETag reports whether any entity tag in a comma separated header value matches etag.
+// If-Match requires strong comparison while If-None-Match uses weak comparison (RFC 7232).
+func matchesETag(header, etag string, weak bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if strings.HasPrefix(candidate, "W/") {
+			if !weak {
+				continue
+			}
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
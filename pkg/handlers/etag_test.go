@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+func TestValidateIfMatch(t *testing.T) {
+	RegisterTestingT(t)
+
+	tests := []struct {
+		ifMatch string
+		pass    bool
+	}{
+		{"", true},
+		{`"3"`, true},
+		{"*", true},
+		{`"1", "3"`, true},
+		{`"2"`, false},
+		{`W/"3"`, false},
+		{"3", false},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPatch, "/", nil)
+		if tt.ifMatch != "" {
+			r.Header.Set("If-Match", tt.ifMatch)
+		}
+		err := ValidateIfMatch(r, 3)
+		if tt.pass {
+			Expect(err).To(BeNil(), "If-Match: %s", tt.ifMatch)
+		} else {
+			Expect(err).NotTo(BeNil(), "If-Match: %s", tt.ifMatch)
+			Expect(err.HttpCode).To(Equal(http.StatusPreconditionFailed))
+		}
+	}
+}
+
+func TestIfMatchVersions(t *testing.T) {
+	RegisterTestingT(t)
+
+	versions := func(ifMatch string) ([]int64, *errors.ServiceError) {
+		r := httptest.NewRequest(http.MethodDelete, "/", nil)
+		if ifMatch != "" {
+			r.Header.Set("If-Match", ifMatch)
+		}
+		return IfMatchVersions(r)
+	}
+
+	for _, ifMatch := range []string{"", "*", `"2", *`} {
+		found, err := versions(ifMatch)
+		Expect(err).To(BeNil(), "If-Match: %s", ifMatch)
+		Expect(found).To(BeNil(), "If-Match: %s", ifMatch)
+	}
+
+	found, err := versions(`"3"`)
+	Expect(err).To(BeNil())
+	Expect(found).To(Equal([]int64{3}))
+
+	found, err = versions(`"1", W/"2", "3"`)
+	Expect(err).To(BeNil())
+	Expect(found).To(Equal([]int64{1, 3}))
+
+	for _, ifMatch := range []string{`W/"3"`, "3", `"abc"`} {
+		_, err = versions(ifMatch)
+		Expect(err).NotTo(BeNil(), "If-Match: %s", ifMatch)
+		Expect(err.HttpCode).To(Equal(http.StatusPreconditionFailed))
+	}
+}
+
+func TestHandleGetIfNoneMatch(t *testing.T) {
+	RegisterTestingT(t)
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if ifNoneMatch != "" {
+			r.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		HandleGet(w, r, &HandlerConfig{
+			Action: func() (interface{}, *errors.ServiceError) {
+				SetETag(w, 7)
+				return map[string]string{"id": "abc"}, nil
+			},
+		})
+		return w
+	}
+
+	w := get("")
+	Expect(w.Code).To(Equal(http.StatusOK))
+	Expect(w.Header().Get("ETag")).To(Equal(`"7"`))
+	Expect(w.Body.String()).To(ContainSubstring("abc"))
+
+	w = get(`W/"7"`)
+	Expect(w.Code).To(Equal(http.StatusNotModified))
+	Expect(w.Header().Get("ETag")).To(Equal(`"7"`))
+	Expect(w.Body.Len()).To(Equal(0))
+
+	w = get(`"6"`)
+	Expect(w.Code).To(Equal(http.StatusOK))
+}
@@ -15,7 +15,8 @@ import (
 // This is not meant to be an HTTP framework or anything larger than simple CRUD in handlers.
 //
 //	MarshalInto is a pointer to the object to hold the unmarshaled JSON.
-//	Validate is a list of validation function that run in order, returning fast on the first error.
+//	Validate is a list of validation function that run in order, returning fast on the first error. Errors with
+//	field violations are the exception, the violations of every validator are reported together.
 //	Action is the specific logic a handler must take (e.g, find an object, save an object)
 //	ErrorHandler is the way errors are returned to the client
 type HandlerConfig struct {
@@ -41,6 +42,26 @@ func HandleError(ctx context.Context, w http.ResponseWriter, err *errors.Service
 	writeJSONResponse(w, err.HttpCode, err.AsOpenapiError(operationID))
 }
 
+// validate runs the validators in order. An error without field violations returns fast, the field violations
+// of the others are collected so that a client learns about every field it has to fix at once.
+func validate(validators []Validate) *errors.ServiceError {
+	var violations []errors.FieldViolation
+	for _, v := range validators {
+		err := v()
+		if err == nil {
+			continue
+		}
+		if len(err.FieldViolations) == 0 {
+			return err
+		}
+		violations = append(violations, err.FieldViolations...)
+	}
+	if len(violations) > 0 {
+		return errors.FieldValidation(violations)
+	}
+	return nil
+}
+
 func Handle(w http.ResponseWriter, r *http.Request, cfg *HandlerConfig, httpStatus int) {
 	if cfg.ErrorHandler == nil {
 		cfg.ErrorHandler = HandleError
@@ -58,12 +79,9 @@ func Handle(w http.ResponseWriter, r *http.Request, cfg *HandlerConfig, httpStat
 		return
 	}
 
-	for _, v := range cfg.Validators {
-		err := v()
-		if err != nil {
-			cfg.ErrorHandler(r.Context(), w, err)
-			return
-		}
+	if err := validate(cfg.Validators); err != nil {
+		cfg.ErrorHandler(r.Context(), w, err)
+		return
 	}
 
 	result, serviceErr := cfg.Action()
@@ -78,15 +96,17 @@ func Handle(w http.ResponseWriter, r *http.Request, cfg *HandlerConfig, httpStat
 }
 
 func HandleDelete(w http.ResponseWriter, r *http.Request, cfg *HandlerConfig, httpStatus int) {
+	HandleWithoutBody(w, r, cfg, httpStatus)
+}
+
+// HandleWithoutBody runs the validators and action of requests that carry no body, such as DELETE or action POSTs
+func HandleWithoutBody(w http.ResponseWriter, r *http.Request, cfg *HandlerConfig, httpStatus int) {
 	if cfg.ErrorHandler == nil {
 		cfg.ErrorHandler = HandleError
 	}
-	for _, v := range cfg.Validators {
-		err := v()
-		if err != nil {
-			cfg.ErrorHandler(r.Context(), w, err)
-			return
-		}
+	if err := validate(cfg.Validators); err != nil {
+		cfg.ErrorHandler(r.Context(), w, err)
+		return
 	}
 
 	result, serviceErr := cfg.Action()
@@ -107,10 +127,14 @@ func HandleGet(w http.ResponseWriter, r *http.Request, cfg *HandlerConfig) {
 
 	result, serviceErr := cfg.Action()
 	switch {
-	case serviceErr == nil:
-		writeJSONResponse(w, http.StatusOK, result)
-	default:
+	case serviceErr != nil:
 		cfg.ErrorHandler(r.Context(), w, serviceErr)
+	case notModified(r, w.Header().Get("ETag")):
+		// The Action set an ETag matching If-None-Match; the client's copy is current
+		w.Header().Set("Vary", "Authorization")
+		w.WriteHeader(http.StatusNotModified)
+	default:
+		writeJSONResponse(w, http.StatusOK, result)
 	}
 }
 
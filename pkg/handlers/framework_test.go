@@ -1,6 +1,14 @@
 package handlers
 
-import "net/http"
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/validation"
+)
 
 type mockResponseWriter struct {
 	written string
@@ -17,3 +25,29 @@ func (m *mockResponseWriter) Write(b []byte) (int, error) {
 func (m *mockResponseWriter) WriteHeader(code int) {
 	m.status = code
 }
+
+func TestValidateCollectsFieldViolations(t *testing.T) {
+	RegisterTestingT(t)
+
+	body := &struct {
+		ID      string
+		Species string
+	}{ID: "set"}
+	err := validate([]Validate{
+		ValidateEmpty(body, "ID", "id"),
+		ValidateNotEmpty(body, "Species", "species"),
+	})
+	Expect(err).NotTo(BeNil())
+	Expect(err.Code).To(Equal(errors.ErrorValidation))
+	Expect(err.Reason).To(Equal("id must be empty; species is required"))
+	Expect(err.FieldViolations).To(HaveLen(2))
+	Expect(err.FieldViolations[0].Code).To(Equal(validation.CodeReadOnly))
+	Expect(err.FieldViolations[1].Code).To(Equal(validation.CodeRequired))
+
+	// other errors still return fast
+	notFound := func() *errors.ServiceError { return errors.NotFound("gone") }
+	err = validate([]Validate{ValidateNotEmpty(body, "Species", "species"), notFound, ValidateEmpty(body, "ID", "id")})
+	Expect(err.Code).To(Equal(errors.ErrorNotFound))
+
+	Expect(validate([]Validate{ValidateEmpty(body, "Species", "species")})).To(BeNil())
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
+
+const (
+	// IdempotencyKeyHeader carries the key a client picks for a POST or PATCH, so that it can safely retry it
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on the responses replayed for a retried Idempotency-Key
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// replayedHeaders are the response headers remembered along with the response body
+var replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// IdempotencyMiddleware replays the response to a POST or PATCH retried with the same Idempotency-Key instead
+// of running it again. Reusing a key for a different request fails with 422. Successful responses are remembered
+// in the transaction of the request, so it has to run inside the TransactionMiddleware and after authentication,
+// since keys are scoped to the caller.
+func IdempotencyMiddleware(idempotencyKeys services.IdempotencyKeyService) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" || (r.Method != http.MethodPost && r.Method != http.MethodPatch) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := r.Context()
+			if len(key) > maxIdempotencyKeyLength {
+				HandleError(ctx, w, errors.Validation("%s exceeds maximum length of %d", IdempotencyKeyHeader, maxIdempotencyKeyLength))
+				return
+			}
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				HandleError(ctx, w, errors.MalformedRequest("Unable to read request body: %s", err))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			requestHash := HashRequest(r.Method, r.URL.RequestURI(), body)
+
+			remembered, svcErr := idempotencyKeys.Lookup(ctx, key, requestHash)
+			if svcErr != nil {
+				HandleError(ctx, w, svcErr)
+				return
+			}
+			if remembered != nil {
+				replay(w, remembered)
+				return
+			}
+
+			recorder := newResponseRecorder()
+			next.ServeHTTP(recorder, r)
+
+			if recorder.status >= 200 && recorder.status < 300 && !db.MarkedForRollback(ctx) {
+				header, err := json.Marshal(recorder.replayedHeader())
+				if err != nil {
+					HandleError(ctx, w, errors.GeneralError("Unable to remember response headers: %s", err))
+					return
+				}
+				svcErr := idempotencyKeys.Remember(ctx, &api.IdempotencyKey{
+					Key:         key,
+					RequestHash: requestHash,
+					StatusCode:  recorder.status,
+					Header:      string(header),
+					Body:        recorder.body.Bytes(),
+				})
+				if svcErr != nil {
+					HandleError(ctx, w, svcErr)
+					return
+				}
+			}
+			recorder.flush(w)
+		})
+	}
+}
+
+// HashRequest returns the hash telling requests sent with the same Idempotency-Key apart
+func HashRequest(method, uri string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method + " " + uri + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func replay(w http.ResponseWriter, remembered *api.IdempotencyKey) {
+	header := http.Header{}
+	if remembered.Header != "" {
+		_ = json.Unmarshal([]byte(remembered.Header), &header)
+	}
+	for name, values := range header {
+		for _, value := range values {
+			w.Header().Add(name, value)
+		}
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(remembered.StatusCode)
+	_, _ = w.Write(remembered.Body)
+}
+
+// responseRecorder holds back a response until it is known whether it can be remembered
+type responseRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newResponseRecorder() *responseRecorder {
+	return &responseRecorder{header: http.Header{}, status: http.StatusOK}
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+}
+
+func (r *responseRecorder) replayedHeader() http.Header {
+	header := http.Header{}
+	for _, name := range replayedHeaders {
+		if values, ok := r.header[http.CanonicalHeaderKey(name)]; ok {
+			header[http.CanonicalHeaderKey(name)] = values
+		}
+	}
+	return header
+}
+
+func (r *responseRecorder) flush(w http.ResponseWriter) {
+	for name, values := range r.header {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(r.status)
+	_, _ = w.Write(r.body.Bytes())
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao/mocks"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	created := 0
+	handler := IdempotencyMiddleware(services.NewIdempotencyKeyService(mocks.NewIdempotencyKeyDao(), time.Hour))(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			created++
+			w.Header().Set("Content-Type", "application/json")
+			SetETag(w, int64(created))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":"dino"}`))
+		}))
+
+	send := func(username, key, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/api/rh-trex/v1/dinosaurs", strings.NewReader(body))
+		r = r.WithContext(auth.SetUsernameContext(context.Background(), username))
+		if key != "" {
+			r.Header.Set(IdempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	first := send("alice", "key-1", `{"species":"Stegosaurus"}`)
+	Expect(first.Code).To(Equal(http.StatusCreated))
+	Expect(first.Header().Get(IdempotentReplayedHeader)).To(BeEmpty())
+
+	// a retry replays the first response without running the request again
+	retry := send("alice", "key-1", `{"species":"Stegosaurus"}`)
+	Expect(created).To(Equal(1))
+	Expect(retry.Code).To(Equal(http.StatusCreated))
+	Expect(retry.Body.String()).To(Equal(`{"id":"dino"}`))
+	Expect(retry.Header().Get("ETag")).To(Equal(first.Header().Get("ETag")))
+	Expect(retry.Header().Get(IdempotentReplayedHeader)).To(Equal("true"))
+
+	Expect(send("alice", "key-1", `{"species":"Triceratops"}`).Code).To(Equal(http.StatusUnprocessableEntity))
+
+	// keys belong to their caller, and requests without a key always run
+	Expect(send("bob", "key-1", `{"species":"Triceratops"}`).Code).To(Equal(http.StatusCreated))
+	Expect(send("alice", "", `{"species":"Stegosaurus"}`).Code).To(Equal(http.StatusCreated))
+	Expect(created).To(Equal(3))
+}
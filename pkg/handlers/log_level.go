@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+)
+
+// LogLevel is the body of the /debug/loglevel endpoint. Level is a level name or a verbosity, see
+// logger.ParseLevel.
+type LogLevel struct {
+	Level  string `json:"level,omitempty"`
+	Format string `json:"format,omitempty"`
+}
+
+// LogLevelHandler reads and changes the level and the format of the logs of the running service
+type LogLevelHandler struct{}
+
+func NewLogLevelHandler() *LogLevelHandler {
+	return &LogLevelHandler{}
+}
+
+func (h LogLevelHandler) Get(w http.ResponseWriter, r *http.Request) {
+	writeJSONResponse(w, http.StatusOK, currentLogLevel())
+}
+
+// Set changes the level and the format given by the body, the ones missing are left as they are
+func (h LogLevelHandler) Set(w http.ResponseWriter, r *http.Request) {
+	var body LogLevel
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		HandleError(r.Context(), w, errors.MalformedRequest("Invalid request body: %s", err))
+		return
+	}
+
+	if body.Level != "" {
+		level, err := logger.ParseLevel(body.Level)
+		if err != nil {
+			HandleError(r.Context(), w, errors.Validation("%s", err))
+			return
+		}
+		logger.SetLevel(level)
+	}
+	if body.Format != "" {
+		if err := logger.SetFormat(body.Format); err != nil {
+			HandleError(r.Context(), w, errors.Validation("%s", err))
+			return
+		}
+	}
+
+	current := currentLogLevel()
+	logger.Warningf("Log level set to %s, format to %s", current.Level, current.Format)
+	writeJSONResponse(w, http.StatusOK, current)
+}
+
+func currentLogLevel() LogLevel {
+	return LogLevel{
+		Level:  logger.LevelName(logger.Level()),
+		Format: logger.Format(),
+	}
+}
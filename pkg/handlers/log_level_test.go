@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+)
+
+func TestLogLevelHandler(t *testing.T) {
+	RegisterTestingT(t)
+
+	previous := logger.Level()
+	t.Cleanup(func() { logger.SetLevel(previous) })
+	logger.SetLevel(slog.LevelInfo)
+
+	handler := NewLogLevelHandler()
+	send := func(method, body string) (*httptest.ResponseRecorder, LogLevel) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(method, "/debug/loglevel", strings.NewReader(body))
+		if method == http.MethodGet {
+			handler.Get(w, r)
+		} else {
+			handler.Set(w, r)
+		}
+		var current LogLevel
+		_ = json.Unmarshal(w.Body.Bytes(), &current)
+		return w, current
+	}
+
+	w, current := send(http.MethodGet, "")
+	Expect(w.Code).To(Equal(http.StatusOK))
+	Expect(current).To(Equal(LogLevel{Level: "INFO", Format: logger.TextFormat}))
+
+	w, current = send(http.MethodPut, `{"level":"V4"}`)
+	Expect(w.Code).To(Equal(http.StatusOK))
+	Expect(current.Level).To(Equal("V4"))
+	Expect(logger.Enabled(4)).To(BeTrue())
+
+	w, _ = send(http.MethodPut, `{"level":"loud"}`)
+	Expect(w.Code).To(Equal(http.StatusBadRequest))
+	Expect(logger.Level()).To(Equal(logger.VLevel(4)))
+}
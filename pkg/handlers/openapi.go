@@ -6,8 +6,8 @@ import (
 	"net/http"
 
 	"github.com/ghodss/yaml"
-	"github.com/golang/glog"
 	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 )
 
 //go:embed openapi-ui.html
@@ -26,7 +26,7 @@ func NewOpenAPIHandler(specData []byte) (*OpenAPIHandler, error) {
 			err,
 		)
 	}
-	glog.Info("Loaded OpenAPI specification")
+	logger.Infof("Loaded OpenAPI specification")
 
 	uiContent, err := fs.ReadFile(openapiui, "openapi-ui.html")
 	if err != nil {
@@ -35,7 +35,7 @@ func NewOpenAPIHandler(specData []byte) (*OpenAPIHandler, error) {
 			err,
 		)
 	}
-	glog.Info("Loaded OpenAPI UI HTML from embedded file")
+	logger.Infof("Loaded OpenAPI UI HTML from embedded file")
 
 	return &OpenAPIHandler{
 		openAPIDefinitions: data,
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"encoding/json"
+	e "errors"
+	"mime"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/validation"
+)
+
+// PatchFormat is the format of the body of a PATCH request, given by its Content-Type
+type PatchFormat string
+
+const (
+	// PatchFormatJSON bodies are partial resources: the fields they set replace those of the resource and
+	// fields set to null are left as they are
+	PatchFormatJSON PatchFormat = "application/json"
+	// PatchFormatMergePatch bodies are JSON Merge Patches (RFC 7386), fields set to null are cleared
+	PatchFormatMergePatch PatchFormat = "application/merge-patch+json"
+	// PatchFormatJSONPatch bodies are JSON Patches (RFC 6902), lists of operations on the resource
+	PatchFormatJSONPatch PatchFormat = "application/json-patch+json"
+)
+
+// PatchFormatOf returns the format of the body of the PATCH request r. Requests without a Content-Type are
+// partial resources.
+func PatchFormatOf(r *http.Request) (PatchFormat, *errors.ServiceError) {
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		return PatchFormatJSON, nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", errors.UnsupportedMediaType("Invalid Content-Type '%s': %s", contentType, err)
+	}
+	switch format := PatchFormat(mediaType); format {
+	case PatchFormatJSON, PatchFormatMergePatch, PatchFormatJSONPatch:
+		return format, nil
+	}
+	return "", errors.UnsupportedMediaType("Content-Type '%s' isn't supported, PATCH accepts %s, %s and %s",
+		mediaType, PatchFormatJSON, PatchFormatMergePatch, PatchFormatJSONPatch)
+}
+
+// ApplyPatch applies patch, a body of format, to current, the presented resource, checks the fields it
+// changes against the rules of the Kind and decodes the result into patched, a pointer to the presented type.
+// patchable is the PatchRequest of the Kind: the fields it doesn't have are read only and a patch changing
+// them fails validation, like one setting a field the resource doesn't have. ApplyPatch returns the names of
+// the fields the patch changed, sorted. None are returned for a patch that leaves the resource as it is,
+// which the handlers answer with the current resource without writing it.
+func ApplyPatch(format PatchFormat, patch []byte, rules validation.Rules, current, patched, patchable interface{}) ([]string, *errors.ServiceError) {
+	currentDoc, err := json.Marshal(current)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to marshal the resource to patch: %s", err)
+	}
+
+	var patchedDoc []byte
+	switch format {
+	case PatchFormatJSON, PatchFormatMergePatch:
+		var fields map[string]interface{}
+		if err := json.Unmarshal(patch, &fields); err != nil || fields == nil {
+			return nil, errors.MalformedRequest("A %s patch must be a JSON object", format)
+		}
+		if format == PatchFormatJSON {
+			patch, err = json.Marshal(withoutNulls(fields))
+			if err != nil {
+				return nil, errors.MalformedRequest("Invalid patch: %s", err)
+			}
+		}
+		patchedDoc, err = jsonpatch.MergePatch(currentDoc, patch)
+		if err != nil {
+			return nil, errors.MalformedRequest("Invalid merge patch: %s", err)
+		}
+	case PatchFormatJSONPatch:
+		operations, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return nil, errors.MalformedRequest("Invalid JSON patch: %s", err)
+		}
+		patchedDoc, err = operations.Apply(currentDoc)
+		switch {
+		case e.Is(err, jsonpatch.ErrTestFailed):
+			return nil, errors.Conflict("The JSON patch wasn't applied: %s", err)
+		case err != nil:
+			return nil, errors.Validation("The JSON patch doesn't apply to the resource: %s", err)
+		}
+	default:
+		return nil, errors.UnsupportedMediaType("Unsupported patch format '%s'", format)
+	}
+
+	before := validation.FieldsOf(json.RawMessage(currentDoc))
+	after := validation.FieldsOf(json.RawMessage(patchedDoc))
+	known := jsonFieldNames(current)
+	writable := jsonFieldNames(patchable)
+	var changed []string
+	var violations []errors.FieldViolation
+	for _, field := range fieldNames(before, after) {
+		if reflect.DeepEqual(before[field], after[field]) {
+			continue
+		}
+		switch {
+		case !known[field]:
+			violations = append(violations, errors.FieldViolation{
+				Field: field, Code: validation.CodeUnknown, Message: field + " isn't a field of the resource",
+			})
+		case !writable[field]:
+			violations = append(violations, errors.FieldViolation{
+				Field: field, Code: validation.CodeReadOnly, Message: field + " is read only",
+			})
+		default:
+			changed = append(changed, field)
+		}
+	}
+	if len(violations) > 0 {
+		return nil, errors.FieldValidation(violations)
+	}
+
+	// cleared fields are empty to the rules, they can't clear a required field
+	fields := validation.Fields{}
+	for _, field := range changed {
+		fields[field] = after[field]
+	}
+	if err := rules.ValidateUpdate(fields, before); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(patchedDoc, patched); err != nil {
+		return nil, errors.Validation("The patched resource is invalid: %s", err)
+	}
+	return changed, nil
+}
+
+// withoutNulls drops the fields set to null, a partial resource leaves them as they are
+func withoutNulls(fields map[string]interface{}) map[string]interface{} {
+	for name, value := range fields {
+		if value == nil {
+			delete(fields, name)
+		}
+	}
+	return fields
+}
+
+// fieldNames returns the names of the fields of any of docs, sorted
+func fieldNames(docs ...validation.Fields) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, doc := range docs {
+		for name := range doc {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// jsonFieldNames returns the JSON names of the fields of v, a struct or a pointer to one
+func jsonFieldNames(v interface{}) map[string]bool {
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		if name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
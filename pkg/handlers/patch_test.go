@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/validation"
+)
+
+type nest struct {
+	ID      string  `json:"id"`
+	Name    string  `json:"name"`
+	Habitat *string `json:"habitat,omitempty"`
+	Eggs    *int32  `json:"eggs,omitempty"`
+}
+
+type nestPatchRequest struct {
+	Name    *string `json:"name,omitempty"`
+	Habitat *string `json:"habitat,omitempty"`
+	Eggs    *int32  `json:"eggs,omitempty"`
+}
+
+var nestRules = validation.NewRules(
+	validation.Rule{Field: "name", Required: true},
+	validation.Rule{Field: "eggs", Maximum: validation.Bound(12)},
+)
+
+func patchNest(format PatchFormat, patch string) (*nest, []string, *errors.ServiceError) {
+	habitat, eggs := "swamp", int32(3)
+	var patched nest
+	changed, err := ApplyPatch(format, []byte(patch), nestRules, nest{ID: "1", Name: "Ridge", Habitat: &habitat, Eggs: &eggs}, &patched, nestPatchRequest{})
+	return &patched, changed, err
+}
+
+func TestApplyPatch(t *testing.T) {
+	RegisterTestingT(t)
+
+	// a partial resource leaves the fields set to null as they are
+	patched, changed, err := patchNest(PatchFormatJSON, `{"name": "Hollow", "habitat": null}`)
+	Expect(err).To(BeNil())
+	Expect(changed).To(Equal([]string{"name"}))
+	Expect(patched.Name).To(Equal("Hollow"))
+	Expect(*patched.Habitat).To(Equal("swamp"))
+
+	// a merge patch clears them
+	patched, changed, err = patchNest(PatchFormatMergePatch, `{"habitat": null, "eggs": 3}`)
+	Expect(err).To(BeNil())
+	Expect(changed).To(Equal([]string{"habitat"}))
+	Expect(patched.Habitat).To(BeNil())
+	Expect(*patched.Eggs).To(Equal(int32(3)))
+
+	patched, changed, err = patchNest(PatchFormatJSONPatch, `[{"op": "test", "path": "/eggs", "value": 3}, {"op": "replace", "path": "/eggs", "value": 4}, {"op": "remove", "path": "/habitat"}]`)
+	Expect(err).To(BeNil())
+	Expect(changed).To(Equal([]string{"eggs", "habitat"}))
+	Expect(*patched.Eggs).To(Equal(int32(4)))
+	Expect(patched.Habitat).To(BeNil())
+
+	_, _, err = patchNest(PatchFormatJSONPatch, `[{"op": "test", "path": "/eggs", "value": 5}, {"op": "replace", "path": "/eggs", "value": 4}]`)
+	Expect(err).NotTo(BeNil())
+	Expect(err.HttpCode).To(Equal(http.StatusConflict))
+
+	_, _, err = patchNest(PatchFormatMergePatch, `["name"]`)
+	Expect(err).NotTo(BeNil())
+	Expect(err.HttpCode).To(Equal(http.StatusBadRequest))
+}
+
+func TestApplyPatchViolations(t *testing.T) {
+	RegisterTestingT(t)
+
+	codes := func(err *errors.ServiceError) map[string]string {
+		found := map[string]string{}
+		for _, violation := range err.FieldViolations {
+			found[violation.Field] = violation.Code
+		}
+		return found
+	}
+
+	_, _, err := patchNest(PatchFormatMergePatch, `{"id": "2", "colour": "green"}`)
+	Expect(err).NotTo(BeNil())
+	Expect(codes(err)).To(Equal(map[string]string{"id": validation.CodeReadOnly, "colour": validation.CodeUnknown}))
+
+	// the rules see the patched fields, a cleared required field is a violation
+	_, _, err = patchNest(PatchFormatJSONPatch, `[{"op": "replace", "path": "/name", "value": ""}, {"op": "replace", "path": "/eggs", "value": 13}]`)
+	Expect(err).NotTo(BeNil())
+	Expect(codes(err)).To(Equal(map[string]string{"name": validation.CodeRequired, "eggs": validation.CodeMaximum}))
+
+	// fields a patch leaves as they are aren't checked
+	_, changed, err := patchNest(PatchFormatJSON, `{}`)
+	Expect(err).To(BeNil())
+	Expect(changed).To(BeEmpty())
+}
+
+func TestPatchFormatOf(t *testing.T) {
+	RegisterTestingT(t)
+
+	tests := []struct {
+		contentType string
+		format      PatchFormat
+	}{
+		{"", PatchFormatJSON},
+		{"application/json; charset=utf-8", PatchFormatJSON},
+		{"application/merge-patch+json", PatchFormatMergePatch},
+		{"application/json-patch+json", PatchFormatJSONPatch},
+		{"text/plain", ""},
+		{"application/", ""},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodPatch, "/", nil)
+		if tt.contentType != "" {
+			r.Header.Set("Content-Type", tt.contentType)
+		}
+		format, err := PatchFormatOf(r)
+		Expect(format).To(Equal(tt.format), "Content-Type: %s", tt.contentType)
+		if tt.format == "" {
+			Expect(err).NotTo(BeNil(), "Content-Type: %s", tt.contentType)
+			Expect(err.HttpCode).To(Equal(http.StatusUnsupportedMediaType))
+		} else {
+			Expect(err).To(BeNil(), "Content-Type: %s", tt.contentType)
+		}
+	}
+}
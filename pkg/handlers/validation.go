@@ -1,10 +1,12 @@
 package handlers
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/validation"
 )
 
 func ValidateNotEmpty(i interface{}, fieldName string, field string) Validate {
@@ -12,12 +14,12 @@ func ValidateNotEmpty(i interface{}, fieldName string, field string) Validate {
 		value := reflect.ValueOf(i).Elem().FieldByName(fieldName)
 		if value.Kind() == reflect.Ptr {
 			if value.IsNil() {
-				return errors.Validation("%s is required", field)
+				return fieldViolation(field, validation.CodeRequired, "%s is required", field)
 			}
 			value = value.Elem()
 		}
 		if len(value.String()) == 0 {
-			return errors.Validation("%s is required", field)
+			return fieldViolation(field, validation.CodeRequired, "%s is required", field)
 		}
 		return nil
 	}
@@ -33,12 +35,16 @@ func ValidateEmpty(i interface{}, fieldName string, field string) Validate {
 			value = value.Elem()
 		}
 		if len(value.String()) != 0 {
-			return errors.Validation("%s must be empty", field)
+			return fieldViolation(field, validation.CodeReadOnly, "%s must be empty", field)
 		}
 		return nil
 	}
 }
 
+func fieldViolation(field, code, message string, values ...interface{}) *errors.ServiceError {
+	return errors.FieldValidation([]errors.FieldViolation{{Field: field, Code: code, Message: fmt.Sprintf(message, values...)}})
+}
+
 // Note that because this uses strings.EqualFold, it is case-insensitive
 func ValidateInclusionIn(value *string, list []string, category *string) Validate {
 	return func() *errors.ServiceError {
@@ -53,3 +59,10 @@ func ValidateInclusionIn(value *string, list []string, category *string) Validat
 		return errors.Validation("%s is not a valid %s", *value, *category)
 	}
 }
+
+// ValidateCreate checks the body of a create request against the rules of its Kind
+func ValidateCreate(rules validation.Rules, body interface{}) Validate {
+	return func() *errors.ServiceError {
+		return rules.ValidateCreate(validation.FieldsOf(body))
+	}
+}
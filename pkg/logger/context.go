@@ -0,0 +1,31 @@
+package logger
+
+import "context"
+
+type fieldKey string
+
+const (
+	usernameKey fieldKey = "username"
+	kindKey     fieldKey = "kind"
+	eventIDKey  fieldKey = "event_id"
+)
+
+// WithUsername returns a context whose log lines carry the username of the caller
+func WithUsername(ctx context.Context, username string) context.Context {
+	return context.WithValue(ctx, usernameKey, username)
+}
+
+// WithKind returns a context whose log lines carry the Kind being worked on, by its collection, e.g. dinosaurs
+func WithKind(ctx context.Context, kind string) context.Context {
+	return context.WithValue(ctx, kindKey, kind)
+}
+
+// WithEventID returns a context whose log lines carry the id of the event being handled
+func WithEventID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, eventIDKey, id)
+}
+
+func stringValue(ctx context.Context, key fieldKey) string {
+	value, _ := ctx.Value(key).(string)
+	return value
+}
@@ -0,0 +1,210 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/config"
+	dbContext "github.com/openshift-online/rh-trex-ai/pkg/db/db_context"
+	"github.com/openshift-online/rh-trex-ai/pkg/util"
+)
+
+const (
+	TextFormat = "text"
+	JSONFormat = "json"
+)
+
+// LevelFatal is the level of the messages logged right before the process exits
+const LevelFatal = slog.LevelError + 4
+
+// VLevel returns the level of the messages of verbosity v, like the V of glog. Verbosity 0 is INFO and
+// every verbosity above it is a level below INFO, so verbosity 4 is DEBUG.
+func VLevel(v int32) slog.Level {
+	if v <= 0 {
+		return slog.LevelInfo
+	}
+	return slog.LevelInfo - slog.Level(v)
+}
+
+// ParseLevel parses a level name, DEBUG, INFO, WARN or ERROR, or a verbosity such as 4 or V4
+func ParseLevel(text string) (slog.Level, error) {
+	verbosity := strings.TrimPrefix(strings.ToUpper(text), "V")
+	if v, err := strconv.ParseInt(verbosity, 10, 32); err == nil && v >= 0 {
+		return VLevel(int32(v)), nil
+	}
+	if strings.EqualFold(text, "FATAL") {
+		return LevelFatal, nil
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(text)); err != nil {
+		return 0, fmt.Errorf("unknown log level %q, expected DEBUG, INFO, WARN, ERROR or a verbosity", text)
+	}
+	return level, nil
+}
+
+// LevelName returns the name of level in the log lines: the verbosity, e.g. V4, for the levels below INFO
+func LevelName(level slog.Level) string {
+	switch {
+	case level < slog.LevelInfo:
+		return fmt.Sprintf("V%d", slog.LevelInfo-level)
+	case level == LevelFatal:
+		return "FATAL"
+	default:
+		return level.String()
+	}
+}
+
+var (
+	level  = new(slog.LevelVar)
+	mutex  sync.Mutex
+	format           = TextFormat
+	output io.Writer = os.Stderr
+	base   atomic.Pointer[slog.Handler]
+)
+
+// handler is the root of the handlers of the service. The standard log and slog packages log through it too.
+var handler slog.Handler = &contextHandler{}
+
+func init() {
+	_ = configure(format, output)
+	slog.SetDefault(slog.New(handler))
+}
+
+// Configure sets the level and the format of the logs from the logging config. Both can be changed again at
+// any time.
+func Configure(cfg *config.LoggingConfig) error {
+	l, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return err
+	}
+	if err := SetFormat(cfg.Format); err != nil {
+		return err
+	}
+	SetLevel(l)
+	return nil
+}
+
+// Level returns the lowest level logged
+func Level() slog.Level {
+	return level.Level()
+}
+
+// SetLevel sets the lowest level logged
+func SetLevel(l slog.Level) {
+	level.Set(l)
+}
+
+// Format returns the format of the log lines
+func Format() string {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return format
+}
+
+// SetFormat sets the format of the log lines, text or json
+func SetFormat(f string) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return configure(f, output)
+}
+
+// SetOutput sets where the log lines are written, os.Stderr by default
+func SetOutput(w io.Writer) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	_ = configure(format, w)
+}
+
+func configure(f string, w io.Writer) error {
+	opts := &slog.HandlerOptions{
+		AddSource: true,
+		Level:     level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.LevelKey && len(groups) == 0 {
+				if l, ok := a.Value.Any().(slog.Level); ok {
+					a.Value = slog.StringValue(LevelName(l))
+				}
+			}
+			return a
+		},
+	}
+	var h slog.Handler
+	switch f {
+	case TextFormat:
+		h = slog.NewTextHandler(w, opts)
+	case JSONFormat:
+		h = slog.NewJSONHandler(w, opts)
+	default:
+		return fmt.Errorf("unknown log format %q, expected %s or %s", f, TextFormat, JSONFormat)
+	}
+	format, output = f, w
+	base.Store(&h)
+	return nil
+}
+
+// contextHandler adds the fields of the context of a record to it, and hands it to the handler of the
+// configured format
+type contextHandler struct {
+	with []func(slog.Handler) slog.Handler
+}
+
+func (h *contextHandler) Enabled(_ context.Context, l slog.Level) bool {
+	return l >= level.Level()
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx != nil {
+		record.AddAttrs(contextAttrs(ctx)...)
+	}
+	next := *base.Load()
+	for _, with := range h.with {
+		next = with(next)
+	}
+	return next.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.and(func(next slog.Handler) slog.Handler { return next.WithAttrs(attrs) })
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return h.and(func(next slog.Handler) slog.Handler { return next.WithGroup(name) })
+}
+
+func (h *contextHandler) and(with func(slog.Handler) slog.Handler) slog.Handler {
+	withs := make([]func(slog.Handler) slog.Handler, len(h.with), len(h.with)+1)
+	copy(withs, h.with)
+	return &contextHandler{with: append(withs, with)}
+}
+
+// contextAttrs returns the fields of ctx every line logged with it carries
+func contextAttrs(ctx context.Context) []slog.Attr {
+	var attrs []slog.Attr
+	add := func(key, value string) {
+		if value != "" {
+			attrs = append(attrs, slog.String(key, value))
+		}
+	}
+	add("op_id", GetOperationID(ctx))
+	if txid, ok := dbContext.TxID(ctx); ok {
+		attrs = append(attrs, slog.Int64("tx_id", txid))
+	}
+	add("username", stringValue(ctx, usernameKey))
+	add("account_id", util.GetAccountIDFromContext(ctx))
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		add("trace_id", span.TraceID().String())
+		add("span_id", span.SpanID().String())
+	}
+	add("kind", stringValue(ctx, kindKey))
+	add("event_id", stringValue(ctx, eventIDKey))
+	return attrs
+}
@@ -3,10 +3,10 @@ package logger
 import (
 	"context"
 	"fmt"
-	"strings"
-
-	"github.com/golang/glog"
-	"github.com/openshift-online/rh-trex-ai/pkg/util"
+	"log/slog"
+	"os"
+	"runtime"
+	"time"
 )
 
 type Logger interface {
@@ -21,104 +21,108 @@ type Logger interface {
 
 var _ Logger = &logger{}
 
-type extra map[string]interface{}
-
 type logger struct {
-	context   context.Context
-	level     int32
-	accountID string
-	username  string
-	extra     extra
+	context context.Context
+	level   slog.Level
+	attrs   []slog.Attr
 }
 
+// NewLogger returns a logger whose lines carry the fields of ctx, see Handler. Info and Infof log at
+// verbosity 1 unless V sets another one.
 func NewLogger(ctx context.Context) Logger {
-	logger := &logger{
-		context:   ctx,
-		level:     1,
-		extra:     make(extra),
-		accountID: util.GetAccountIDFromContext(ctx),
+	if ctx == nil {
+		ctx = context.Background()
 	}
-	return logger
-}
-
-func (l *logger) prepareLogPrefix(message string, extra extra) string {
-	prefix := " "
-
-	if txid, ok := l.context.Value("txid").(int64); ok {
-		prefix = fmt.Sprintf("[tx_id=%d]%s", txid, prefix)
+	return &logger{
+		context: ctx,
+		level:   VLevel(1),
 	}
+}
 
-	if l.accountID != "" {
-		prefix = fmt.Sprintf("[accountID=%s]%s", l.accountID, prefix)
+// V returns a logger whose Info and Infof log at verbosity level, like the V of glog
+func (l *logger) V(level int32) Logger {
+	return &logger{
+		context: l.context,
+		level:   VLevel(level),
+		attrs:   l.attrs,
 	}
+}
 
-	if opid, ok := l.context.Value(OpIDKey).(string); ok {
-		prefix = fmt.Sprintf("[opid=%s]%s", opid, prefix)
-	}
+func (l *logger) Infof(format string, args ...interface{}) {
+	l.log(l.level, fmt.Sprintf(format, args...))
+}
 
-	var args []string
-	for k, v := range extra {
-		args = append(args, fmt.Sprintf("%s=%v", k, v))
+// Extra returns a logger adding the field key to its lines
+func (l *logger) Extra(key string, value interface{}) Logger {
+	attrs := make([]slog.Attr, len(l.attrs), len(l.attrs)+1)
+	copy(attrs, l.attrs)
+	return &logger{
+		context: l.context,
+		level:   l.level,
+		attrs:   append(attrs, slog.Any(key, value)),
 	}
-
-	return fmt.Sprintf("%s %s %s", prefix, message, strings.Join(args, " "))
 }
 
-func (l *logger) prepareLogPrefixf(format string, args ...interface{}) string {
-	orig := fmt.Sprintf(format, args...)
-	prefix := " "
-
-	if txid, ok := l.context.Value("txid").(int64); ok {
-		prefix = fmt.Sprintf("[tx_id=%d]%s", txid, prefix)
-	}
+func (l *logger) Info(message string) {
+	l.log(l.level, message)
+}
 
-	if l.accountID != "" {
-		prefix = fmt.Sprintf("[accountID=%s]%s", l.accountID, prefix)
-	}
+func (l *logger) Warning(message string) {
+	l.log(slog.LevelWarn, message)
+}
 
-	if opid, ok := l.context.Value(OpIDKey).(string); ok {
-		prefix = fmt.Sprintf("[opid=%s]%s", opid, prefix)
-	}
+func (l *logger) Error(message string) {
+	l.log(slog.LevelError, message)
+}
 
-	return fmt.Sprintf("%s%s", prefix, orig)
+// Fatal logs the message and exits
+func (l *logger) Fatal(message string) {
+	l.log(LevelFatal, message)
+	os.Exit(1)
 }
 
-func (l *logger) V(level int32) Logger {
-	return &logger{
-		context:   l.context,
-		accountID: l.accountID,
-		username:  l.username,
-		level:     level,
+// log sends the message to the handler. It is called by the method or function logging the message, whose
+// caller is the source of the line.
+func (l *logger) log(level slog.Level, message string) {
+	if !handler.Enabled(l.context, level) {
+		return
 	}
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:])
+	record := slog.NewRecord(time.Now(), level, message, pcs[0])
+	record.AddAttrs(l.attrs...)
+	_ = handler.Handle(l.context, record)
 }
 
-func (l *logger) Infof(format string, args ...interface{}) {
-	prefixed := l.prepareLogPrefixf(format, args...)
-	glog.V(glog.Level(l.level)).Infof("%s", prefixed)
+// Enabled tells whether the messages of verbosity v are logged
+func Enabled(v int32) bool {
+	return handler.Enabled(context.Background(), VLevel(v))
 }
 
-func (l *logger) Extra(key string, value interface{}) Logger {
-	l.extra[key] = value
-	return l
-}
+var background = &logger{context: context.Background(), level: slog.LevelInfo}
 
-func (l *logger) Info(message string) {
-	l.log(message, glog.V(glog.Level(l.level)).Infoln)
+// V returns a logger without context whose Info and Infof log at verbosity level
+func V(level int32) Logger {
+	return background.V(level)
 }
 
-func (l *logger) Warning(message string) {
-	l.log(message, glog.Warningln)
+// Infof logs a message without context at INFO
+func Infof(format string, args ...interface{}) {
+	background.log(slog.LevelInfo, fmt.Sprintf(format, args...))
 }
 
-func (l *logger) Error(message string) {
-	l.log(message, glog.Errorln)
+// Warningf logs a message without context at WARN
+func Warningf(format string, args ...interface{}) {
+	background.log(slog.LevelWarn, fmt.Sprintf(format, args...))
 }
 
-func (l *logger) Fatal(message string) {
-	l.log(message, glog.Fatalln)
+// Errorf logs a message without context at ERROR
+func Errorf(format string, args ...interface{}) {
+	background.log(slog.LevelError, fmt.Sprintf(format, args...))
 }
 
-func (l *logger) log(message string, glogFunc func(args ...interface{})) {
-	prefixed := l.prepareLogPrefix(message, l.extra)
-	glogFunc(prefixed)
+// Fatalf logs a message without context and exits
+func Fatalf(format string, args ...interface{}) {
+	background.log(LevelFatal, fmt.Sprintf(format, args...))
+	os.Exit(1)
 }
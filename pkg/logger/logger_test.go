@@ -0,0 +1,119 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/trace"
+
+	dbContext "github.com/openshift-online/rh-trex-ai/pkg/db/db_context"
+	"github.com/openshift-online/rh-trex-ai/pkg/db/transaction"
+)
+
+// capture sends the log lines to a buffer in the JSON format until the test ends
+func capture(t *testing.T, level slog.Level) *bytes.Buffer {
+	buffer := &bytes.Buffer{}
+	previous := Level()
+	SetOutput(buffer)
+	Expect(SetFormat(JSONFormat)).To(Succeed())
+	SetLevel(level)
+	t.Cleanup(func() {
+		SetLevel(previous)
+		Expect(SetFormat(TextFormat)).To(Succeed())
+		SetOutput(os.Stderr)
+	})
+	return buffer
+}
+
+func lines(buffer *bytes.Buffer) []map[string]interface{} {
+	var result []map[string]interface{}
+	decoder := json.NewDecoder(buffer)
+	for decoder.More() {
+		line := map[string]interface{}{}
+		Expect(decoder.Decode(&line)).To(Succeed())
+		result = append(result, line)
+	}
+	return result
+}
+
+func TestContextFields(t *testing.T) {
+	RegisterTestingT(t)
+	buffer := capture(t, slog.LevelInfo)
+
+	traceID, _ := trace.TraceIDFromHex("0af7651916cd43dd8448eb211c80319c")
+	spanID, _ := trace.SpanIDFromHex("b7ad6b7169203331")
+	ctx := trace.ContextWithSpanContext(WithOpID(context.Background()), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+	ctx = WithEventID(WithKind(WithUsername(ctx, "alice"), "dinosaurs"), "event-1")
+	ctx = dbContext.WithTransaction(ctx, transaction.Build(nil, 42, false))
+
+	NewLogger(ctx).Extra("species", "Stegosaurus").Warning("roar")
+
+	logged := lines(buffer)
+	Expect(logged).To(HaveLen(1))
+	Expect(logged[0]).To(HaveKeyWithValue("msg", "roar"))
+	Expect(logged[0]).To(HaveKeyWithValue("level", "WARN"))
+	Expect(logged[0]).To(HaveKeyWithValue("op_id", GetOperationID(ctx)))
+	Expect(logged[0]).To(HaveKeyWithValue("tx_id", BeNumerically("==", 42)))
+	Expect(logged[0]).To(HaveKeyWithValue("username", "alice"))
+	Expect(logged[0]).To(HaveKeyWithValue("trace_id", traceID.String()))
+	Expect(logged[0]).To(HaveKeyWithValue("span_id", spanID.String()))
+	Expect(logged[0]).To(HaveKeyWithValue("kind", "dinosaurs"))
+	Expect(logged[0]).To(HaveKeyWithValue("event_id", "event-1"))
+	Expect(logged[0]).To(HaveKeyWithValue("species", "Stegosaurus"))
+	Expect(logged[0]["source"]).To(HaveKeyWithValue("file", ContainSubstring("logger_test.go")))
+}
+
+func TestVerbosity(t *testing.T) {
+	RegisterTestingT(t)
+	buffer := capture(t, VLevel(2))
+
+	log := NewLogger(context.Background())
+	log.Info("verbosity 1")
+	log.V(2).Infof("verbosity %d", 2)
+	log.V(3).Info("verbosity 3")
+	Infof("info")
+
+	var messages []interface{}
+	for _, line := range lines(buffer) {
+		messages = append(messages, line["msg"])
+	}
+	Expect(messages).To(Equal([]interface{}{"verbosity 1", "verbosity 2", "info"}))
+	Expect(Enabled(2)).To(BeTrue())
+	Expect(Enabled(3)).To(BeFalse())
+
+	SetLevel(slog.LevelWarn)
+	log.Info("hidden")
+	Expect(buffer.Len()).To(BeZero())
+}
+
+func TestParseLevel(t *testing.T) {
+	RegisterTestingT(t)
+
+	for text, expected := range map[string]slog.Level{
+		"INFO":  slog.LevelInfo,
+		"warn":  slog.LevelWarn,
+		"DEBUG": slog.LevelDebug,
+		"0":     slog.LevelInfo,
+		"4":     slog.LevelDebug,
+		"V10":   VLevel(10),
+		"FATAL": LevelFatal,
+	} {
+		level, err := ParseLevel(text)
+		Expect(err).NotTo(HaveOccurred(), text)
+		Expect(level).To(Equal(expected), text)
+	}
+	_, err := ParseLevel("loud")
+	Expect(err).To(HaveOccurred())
+
+	Expect(LevelName(VLevel(4))).To(Equal("V4"))
+	Expect(LevelName(slog.LevelError)).To(Equal("ERROR"))
+	Expect(LevelName(LevelFatal)).To(Equal("FATAL"))
+}
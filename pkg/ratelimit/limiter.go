@@ -0,0 +1,189 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"time"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/config"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+)
+
+// Keys of the buckets, for the --rate-limit-key flag
+const (
+	// UserKey gives every user of an organization buckets of their own
+	UserKey = "user"
+	// OrganizationKey makes the users of an organization share its buckets
+	OrganizationKey = "organization"
+	// IPKey gives every client address buckets of its own
+	IPKey = "ip"
+)
+
+// DefaultPurgeRetention is how long the buckets of the postgres store are kept once they were last used,
+// unless a limit takes longer to refill
+const DefaultPurgeRetention = time.Hour
+
+// Request is what the limit of a request and the bucket it takes a token from depend on
+type Request struct {
+	// Route is the route template of a REST request prefixed with its method, e.g.
+	// "GET /api/rh-trex-ai/v1/dinosaurs/{id}", or the full method of a gRPC call
+	Route string
+	// Kind is the collection the request works on, e.g. dinosaurs, if it works on one
+	Kind string
+	// ClientIP is the address of the client, the key of unauthenticated requests
+	ClientIP string
+}
+
+// Limiter decides whether the requests of clients are within their rate limits
+type Limiter struct {
+	store  Store
+	key    string
+	limit  Limit
+	routes map[string]Limit
+	kinds  map[string]Limit
+	// proxies are the trusted proxies, see TrustsProxy
+	proxies []netip.Prefix
+}
+
+// New returns the Limiter of the rate limit config, or nil if rate limiting is disabled
+func New(cfg *config.RateLimitConfig, sessionFactory db.SessionFactory) (*Limiter, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	switch cfg.Key {
+	case UserKey, OrganizationKey, IPKey:
+	default:
+		return nil, fmt.Errorf("unknown rate limit key %q, expected %s, %s or %s", cfg.Key, UserKey, OrganizationKey, IPKey)
+	}
+
+	limit := Limit{Rate: cfg.Rate, Burst: cfg.Burst}
+	if err := limit.validate(); err != nil {
+		return nil, err
+	}
+	routes, err := parseLimits(cfg.Routes)
+	if err != nil {
+		return nil, err
+	}
+	kinds, err := parseLimits(cfg.Kinds)
+	if err != nil {
+		return nil, err
+	}
+	proxies, err := parseProxies(cfg.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := NewStore(cfg.Backend, sessionFactory)
+	if err != nil {
+		return nil, err
+	}
+	return &Limiter{store: store, key: cfg.Key, limit: limit, routes: routes, kinds: kinds, proxies: proxies}, nil
+}
+
+func parseLimits(texts map[string]string) (map[string]Limit, error) {
+	limits := map[string]Limit{}
+	for name, text := range texts {
+		limit, err := ParseLimit(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		limits[name] = limit
+	}
+	return limits, nil
+}
+
+// parseProxies parses the trusted proxies, an address stands for the prefix of that address alone
+func parseProxies(texts []string) ([]netip.Prefix, error) {
+	proxies := make([]netip.Prefix, 0, len(texts))
+	for _, text := range texts {
+		if addr, err := netip.ParseAddr(text); err == nil {
+			proxies = append(proxies, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q, expected an address or a CIDR", text)
+		}
+		proxies = append(proxies, prefix.Masked())
+	}
+	return proxies, nil
+}
+
+// Allow takes a token for the request from the bucket of its client. It returns nil if the limiter is nil or
+// the bucket couldn't be reached: the service keeps serving requests when its store is down.
+func (l *Limiter) Allow(ctx context.Context, request Request) *Result {
+	if l == nil {
+		return nil
+	}
+	scope, limit := l.limitOf(request)
+	result, err := l.store.Take(ctx, scope+"|"+l.clientOf(ctx, request), limit)
+	if err != nil {
+		logger.NewLogger(ctx).Error(fmt.Sprintf("Unable to take a rate limit token: %s", err))
+		return nil
+	}
+	return &result
+}
+
+// TrustsProxy tells whether ip is the address of a trusted proxy, whose forwarding headers name the client
+// of a request. It's false for a nil limiter.
+func (l *Limiter) TrustsProxy(ip string) bool {
+	if l == nil {
+		return false
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	for _, proxy := range l.proxies {
+		if proxy.Contains(addr.Unmap()) {
+			return true
+		}
+	}
+	return false
+}
+
+// PurgeIdle deletes the buckets last used before the cutoff, see Retention
+func (l *Limiter) PurgeIdle(ctx context.Context, usedBefore time.Time) (int64, error) {
+	return l.store.PurgeIdle(ctx, usedBefore)
+}
+
+// Retention is how long a bucket is kept once it was last used: it's full again by then, so deleting it
+// changes nothing
+func (l *Limiter) Retention() time.Duration {
+	retention := max(DefaultPurgeRetention, l.limit.refill())
+	for _, limit := range l.routes {
+		retention = max(retention, limit.refill())
+	}
+	for _, limit := range l.kinds {
+		retention = max(retention, limit.refill())
+	}
+	return retention
+}
+
+// limitOf returns the most specific limit of the request and the scope of its buckets
+func (l *Limiter) limitOf(request Request) (string, Limit) {
+	if limit, ok := l.routes[request.Route]; ok {
+		return "route:" + request.Route, limit
+	}
+	if limit, ok := l.kinds[request.Kind]; ok && request.Kind != "" {
+		return "kind:" + request.Kind, limit
+	}
+	return "default", l.limit
+}
+
+// clientOf returns who the bucket of the request belongs to
+func (l *Limiter) clientOf(ctx context.Context, request Request) string {
+	username := auth.GetUsernameFromContext(ctx)
+	tenant, _ := auth.GetTenantFromContext(ctx)
+	switch {
+	case username == "" || l.key == IPKey:
+		return "ip:" + request.ClientIP
+	case l.key == OrganizationKey && tenant.OrganizationID != "":
+		return "organization:" + tenant.OrganizationID
+	default:
+		return "user:" + tenant.OrganizationID + "/" + username
+	}
+}
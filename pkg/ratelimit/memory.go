@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often the memory store drops the buckets that are full again
+const sweepInterval = time.Minute
+
+var _ Store = &memoryStore{}
+
+type memoryBucket struct {
+	bucket
+	limit Limit
+}
+
+type memoryStore struct {
+	mutex   sync.Mutex
+	buckets map[string]*memoryBucket
+	swept   time.Time
+}
+
+// NewMemoryStore returns a Store keeping the buckets in the memory of the replica
+func NewMemoryStore() Store {
+	return &memoryStore{buckets: map[string]*memoryBucket{}, swept: time.Now()}
+}
+
+func (s *memoryStore) Take(_ context.Context, key string, limit Limit) (Result, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.swept) > sweepInterval {
+		s.sweep(now)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &memoryBucket{bucket: *newBucket(limit, now)}
+		s.buckets[key] = b
+	}
+	b.limit = limit
+	return b.take(limit, now), nil
+}
+
+func (s *memoryStore) PurgeIdle(_ context.Context, usedBefore time.Time) (int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var purged int64
+	for key, b := range s.buckets {
+		if b.Updated.Before(usedBefore) {
+			delete(s.buckets, key)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// sweep drops the buckets that have refilled since they were last used, they would be created full anyway
+func (s *memoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if now.Sub(b.Updated) >= b.limit.refill() {
+			delete(s.buckets, key)
+		}
+	}
+	s.swept = now
+}
@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+)
+
+// RateLimitBucket is a bucket of the postgres store, in the rate_limit_buckets table
+type RateLimitBucket struct {
+	Key    string `gorm:"primaryKey"`
+	Tokens float64
+	UsedAt time.Time
+}
+
+var _ Store = &postgresStore{}
+
+type postgresStore struct {
+	sessionFactory db.SessionFactory
+}
+
+// NewPostgresStore returns a Store keeping the buckets in the database, so that every replica takes its
+// tokens from the same buckets
+func NewPostgresStore(sessionFactory db.SessionFactory) Store {
+	return &postgresStore{sessionFactory: sessionFactory}
+}
+
+func (s *postgresStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	var result Result
+	err := s.sessionFactory.New(detach(ctx)).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		row := RateLimitBucket{Key: key, Tokens: float64(limit.Burst), UsedAt: now}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&row).Error; err != nil {
+			return err
+		}
+		// the row lock serializes the requests of the client across replicas
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Take(&row, "key = ?", key).Error; err != nil {
+			return err
+		}
+		b := bucket{Tokens: row.Tokens, Updated: row.UsedAt}
+		result = b.take(limit, now)
+		return tx.Model(&row).Updates(map[string]interface{}{"tokens": b.Tokens, "used_at": b.Updated}).Error
+	})
+	return result, err
+}
+
+func (s *postgresStore) PurgeIdle(ctx context.Context, usedBefore time.Time) (int64, error) {
+	result := s.sessionFactory.New(detach(ctx)).Where("used_at < ?", usedBefore).Delete(&RateLimitBucket{})
+	return result.RowsAffected, result.Error
+}
+
+// detach returns a context without the transaction of the request, a request rolled back still counts
+func detach(ctx context.Context) context.Context {
+	return trace.ContextWithSpan(context.Background(), trace.SpanFromContext(ctx))
+}
@@ -0,0 +1,130 @@
+// Package ratelimit limits the rate of the requests of every client with token buckets.
+//
+// A client has a bucket per limit it is subject to: the limit of the route of a request if it has one,
+// else the limit of its Kind, else the default limit. A bucket holds up to Burst tokens and refills at Rate
+// tokens per second; every request takes a token and is rejected if there is none left.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+)
+
+// Backends of the Store, for the --rate-limit-backend flag
+const (
+	// MemoryBackend counts the requests a replica serves, so every replica enforces the limits on its own
+	MemoryBackend = "memory"
+	// PostgresBackend counts the requests in the database, so the limits hold across replicas
+	PostgresBackend = "postgres"
+)
+
+// Limit is the rate a client may sustain, in requests per second, and the number of requests it may send at once
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// ParseLimit parses a limit written as <rate>:<burst>, e.g. 0.5:10
+func ParseLimit(text string) (Limit, error) {
+	rate, burst, found := strings.Cut(text, ":")
+	if !found {
+		return Limit{}, fmt.Errorf("invalid rate limit %q, expected <rate>:<burst>", text)
+	}
+	limit := Limit{}
+	var err error
+	if limit.Rate, err = strconv.ParseFloat(rate, 64); err != nil {
+		return Limit{}, fmt.Errorf("invalid rate of rate limit %q: %w", text, err)
+	}
+	if limit.Burst, err = strconv.Atoi(burst); err != nil {
+		return Limit{}, fmt.Errorf("invalid burst of rate limit %q: %w", text, err)
+	}
+	return limit, limit.validate()
+}
+
+func (l Limit) validate() error {
+	if l.Rate <= 0 || l.Burst < 1 {
+		return fmt.Errorf("invalid rate limit %g:%d, the rate must be positive and the burst at least 1", l.Rate, l.Burst)
+	}
+	return nil
+}
+
+// refill is how long an empty bucket of the limit takes to fill up
+func (l Limit) refill() time.Duration {
+	return time.Duration(float64(l.Burst) / l.Rate * float64(time.Second))
+}
+
+// Result is the state of the bucket of a client once a request took its token, or was rejected
+type Result struct {
+	Allowed bool
+	Limit   Limit
+	// Remaining is the number of requests the client may still send at once
+	Remaining int
+	// Reset is how long until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is how long until the next request is allowed, zero if it already is
+	RetryAfter time.Duration
+}
+
+// bucket is a token bucket, it holds Tokens at Updated
+type bucket struct {
+	Tokens  float64
+	Updated time.Time
+}
+
+func newBucket(limit Limit, now time.Time) *bucket {
+	return &bucket{Tokens: float64(limit.Burst), Updated: now}
+}
+
+// take refills the bucket up to now and takes a token if there is one
+func (b *bucket) take(limit Limit, now time.Time) Result {
+	elapsed := now.Sub(b.Updated).Seconds()
+	if elapsed < 0 {
+		// the clocks of replicas sharing a bucket don't quite agree
+		elapsed = 0
+	}
+	b.Tokens = math.Min(float64(limit.Burst), b.Tokens+elapsed*limit.Rate)
+	if now.After(b.Updated) {
+		b.Updated = now
+	}
+
+	result := Result{Limit: limit}
+	if b.Tokens >= 1 {
+		b.Tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - b.Tokens) / limit.Rate)
+	}
+	result.Remaining = int(b.Tokens)
+	result.Reset = seconds((float64(limit.Burst) - b.Tokens) / limit.Rate)
+	return result
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// Store keeps the token buckets of the clients
+type Store interface {
+	// Take takes a token from the bucket of key, which is created full
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+	// PurgeIdle deletes the buckets last used before the cutoff and returns how many there were
+	PurgeIdle(ctx context.Context, usedBefore time.Time) (int64, error)
+}
+
+// NewStore returns the Store of backend
+func NewStore(backend string, sessionFactory db.SessionFactory) (Store, error) {
+	switch backend {
+	case MemoryBackend:
+		return NewMemoryStore(), nil
+	case PostgresBackend:
+		return NewPostgresStore(sessionFactory), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit backend %q, expected %s or %s", backend, MemoryBackend, PostgresBackend)
+	}
+}
@@ -0,0 +1,144 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/config"
+)
+
+func TestParseLimit(t *testing.T) {
+	RegisterTestingT(t)
+
+	limit, err := ParseLimit("0.5:10")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(limit).To(Equal(Limit{Rate: 0.5, Burst: 10}))
+
+	for _, text := range []string{"10", "a:10", "10:b", "0:10", "10:0"} {
+		_, err := ParseLimit(text)
+		Expect(err).To(HaveOccurred(), text)
+	}
+}
+
+func TestBucket(t *testing.T) {
+	RegisterTestingT(t)
+
+	limit := Limit{Rate: 2, Burst: 3}
+	now := time.Now()
+	b := newBucket(limit, now)
+
+	// the burst goes through at once
+	for remaining := 2; remaining >= 0; remaining-- {
+		result := b.take(limit, now)
+		Expect(result.Allowed).To(BeTrue())
+		Expect(result.Remaining).To(Equal(remaining))
+	}
+	result := b.take(limit, now)
+	Expect(result.Allowed).To(BeFalse())
+	Expect(result.RetryAfter).To(Equal(500 * time.Millisecond))
+	Expect(result.Reset).To(Equal(1500 * time.Millisecond))
+
+	// the bucket refills at the rate, up to the burst
+	Expect(b.take(limit, now.Add(500*time.Millisecond)).Allowed).To(BeTrue())
+	result = b.take(limit, now.Add(time.Hour))
+	Expect(result.Allowed).To(BeTrue())
+	Expect(result.Remaining).To(Equal(2))
+}
+
+func TestLimiter(t *testing.T) {
+	RegisterTestingT(t)
+
+	cfg := config.NewRateLimitConfig()
+	limiter, err := New(cfg, nil)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(limiter).To(BeNil(), "rate limiting is disabled by default")
+	Expect(limiter.Allow(context.Background(), Request{})).To(BeNil())
+
+	cfg.Enabled = true
+	cfg.Rate, cfg.Burst = 1, 2
+	cfg.Routes = map[string]string{"POST /api/rh-trex-ai/v1/dinosaurs": "1:1"}
+	cfg.Kinds = map[string]string{"fossils": "1:3"}
+	limiter, err = New(cfg, nil)
+	Expect(err).NotTo(HaveOccurred())
+
+	alice := auth.SetTenantContext(auth.SetUsernameContext(context.Background(), "alice"), auth.Tenant{OrganizationID: "acme"})
+	bob := auth.SetTenantContext(auth.SetUsernameContext(context.Background(), "bob"), auth.Tenant{OrganizationID: "acme"})
+	allowed := func(ctx context.Context, request Request) bool {
+		return limiter.Allow(ctx, request).Allowed
+	}
+
+	// the route limit is the most specific, then the Kind limit, then the default limit
+	create := Request{Route: "POST /api/rh-trex-ai/v1/dinosaurs", Kind: "dinosaurs"}
+	Expect(allowed(alice, create)).To(BeTrue())
+	Expect(allowed(alice, create)).To(BeFalse())
+	Expect(limiter.Allow(alice, Request{Route: "GET /api/rh-trex-ai/v1/fossils", Kind: "fossils"}).Limit).To(Equal(Limit{Rate: 1, Burst: 3}))
+	list := Request{Route: "GET /api/rh-trex-ai/v1/dinosaurs", Kind: "dinosaurs"}
+	Expect(allowed(alice, list)).To(BeTrue())
+	Expect(allowed(alice, list)).To(BeTrue())
+	Expect(allowed(alice, list)).To(BeFalse())
+
+	// every user has buckets of their own, unauthenticated clients are keyed by their address
+	Expect(allowed(bob, create)).To(BeTrue())
+	Expect(allowed(context.Background(), Request{Route: create.Route, ClientIP: "10.0.0.1"})).To(BeTrue())
+	Expect(allowed(context.Background(), Request{Route: create.Route, ClientIP: "10.0.0.1"})).To(BeFalse())
+	Expect(allowed(context.Background(), Request{Route: create.Route, ClientIP: "10.0.0.2"})).To(BeTrue())
+
+	// the users of an organization share its buckets
+	cfg.Key = OrganizationKey
+	limiter, err = New(cfg, nil)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(allowed(alice, create)).To(BeTrue())
+	Expect(allowed(bob, create)).To(BeFalse())
+
+	cfg.Key = "tenant"
+	_, err = New(cfg, nil)
+	Expect(err).To(HaveOccurred())
+	cfg.Key = UserKey
+	cfg.Kinds = map[string]string{"fossils": "fast"}
+	_, err = New(cfg, nil)
+	Expect(err).To(HaveOccurred())
+	cfg.Kinds = nil
+	cfg.TrustedProxies = []string{"proxy.local"}
+	_, err = New(cfg, nil)
+	Expect(err).To(HaveOccurred())
+}
+
+func TestLimiterTrustsProxy(t *testing.T) {
+	RegisterTestingT(t)
+
+	cfg := config.NewRateLimitConfig()
+	cfg.Enabled = true
+	cfg.TrustedProxies = []string{"10.0.0.0/8", "192.0.2.1", "2001:db8::/32"}
+	limiter, err := New(cfg, nil)
+	Expect(err).NotTo(HaveOccurred())
+
+	Expect(limiter.TrustsProxy("10.1.2.3")).To(BeTrue())
+	Expect(limiter.TrustsProxy("::ffff:10.1.2.3")).To(BeTrue())
+	Expect(limiter.TrustsProxy("192.0.2.1")).To(BeTrue())
+	Expect(limiter.TrustsProxy("192.0.2.2")).To(BeFalse())
+	Expect(limiter.TrustsProxy("2001:db8::1")).To(BeTrue())
+	Expect(limiter.TrustsProxy("unknown")).To(BeFalse())
+
+	var disabled *Limiter
+	Expect(disabled.TrustsProxy("10.1.2.3")).To(BeFalse())
+}
+
+func TestMemoryStorePurgeIdle(t *testing.T) {
+	RegisterTestingT(t)
+
+	store := NewMemoryStore()
+	limit := Limit{Rate: 1, Burst: 1}
+	_, err := store.Take(context.Background(), "alice", limit)
+	Expect(err).NotTo(HaveOccurred())
+
+	purged, err := store.PurgeIdle(context.Background(), time.Now().Add(time.Second))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(purged).To(Equal(int64(1)))
+	result, err := store.Take(context.Background(), "alice", limit)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(result.Allowed).To(BeTrue(), "a purged bucket is created full again")
+}
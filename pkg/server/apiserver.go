@@ -8,11 +8,12 @@ import (
 	"strings"
 	"time"
 
-	"github.com/golang/glog"
 	gorillahandlers "github.com/gorilla/handlers"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/auth"
 	"github.com/openshift-online/rh-trex-ai/pkg/environments"
+	"github.com/openshift-online/rh-trex-ai/pkg/handlers"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 	"github.com/openshift-online/rh-trex-ai/pkg/trex"
 )
 
@@ -41,7 +42,7 @@ func NewDefaultAPIServer(env *environments.Env, specData []byte) Server {
 	}
 
 	if env.Config.Server.EnableJWT {
-		glog.Info("Enabling JWT authentication middleware")
+		logger.Infof("Enabling JWT authentication middleware")
 
 		jwtHandler, err := auth.NewJWTHandler().
 			WithKeysFile(env.Config.Server.JwkCertFile).
@@ -67,6 +68,7 @@ func NewDefaultAPIServer(env *environments.Env, specData []byte) Server {
 		"Authorization",
 		"Content-Type",
 		"X-Forwarded-Access-Token",
+		handlers.IdempotencyKeyHeader,
 	}
 	if len(env.Config.Server.CORSAllowedHeaders) > 0 {
 		corsHeaders = append(corsHeaders, env.Config.Server.CORSAllowedHeaders...)
@@ -104,15 +106,15 @@ func (s defaultAPIServer) Serve(listener net.Listener) {
 			)
 		}
 
-		glog.Infof("Serving with TLS at %s", s.env.Config.Server.BindAddress)
+		logger.Infof("Serving with TLS at %s", s.env.Config.Server.BindAddress)
 		err = s.httpServer.ServeTLS(listener, s.env.Config.Server.HTTPSCertFile, s.env.Config.Server.HTTPSKeyFile)
 	} else {
-		glog.Infof("Serving without TLS at %s", s.env.Config.Server.BindAddress)
+		logger.Infof("Serving without TLS at %s", s.env.Config.Server.BindAddress)
 		err = s.httpServer.Serve(listener)
 	}
 
 	Check(err, "Web server terminated with errors")
-	glog.Info("Web server terminated")
+	logger.Infof("Web server terminated")
 }
 
 func (s defaultAPIServer) Listen() (listener net.Listener, err error) {
@@ -122,7 +124,7 @@ func (s defaultAPIServer) Listen() (listener net.Listener, err error) {
 func (s defaultAPIServer) Start() {
 	listener, err := s.Listen()
 	if err != nil {
-		glog.Fatalf("Unable to start API server: %s", err)
+		logger.Fatalf("Unable to start API server: %s", err)
 	}
 	s.Serve(listener)
 
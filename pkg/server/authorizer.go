@@ -0,0 +1,39 @@
+package server
+
+import (
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/environments"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
+
+// AuthorizerFactory builds the Authorizer shared by the REST routes and the gRPC interceptors
+type AuthorizerFactory func(env *environments.Env) (auth.Authorizer, error)
+
+var authorizerFactory AuthorizerFactory = NewPolicyAuthorizer
+
+// RegisterAuthorizer replaces the built-in policy engine with another Authorizer
+func RegisterAuthorizer(factory AuthorizerFactory) {
+	authorizerFactory = factory
+}
+
+// NewPolicyAuthorizer builds the policy engine from the configured policy file and the role bindings
+// stored in the database
+func NewPolicyAuthorizer(env *environments.Env) (auth.Authorizer, error) {
+	policy, err := auth.LoadPolicy(env.Config.Server.AuthzPolicyFile)
+	if err != nil {
+		return nil, err
+	}
+	var store auth.RoleBindingStore
+	if locator, ok := env.Services.GetService("RoleBindings").(services.RoleBindingServiceLocator); ok {
+		store = locator()
+	}
+	return auth.NewPolicyAuthorizer(policy, store), nil
+}
+
+// NewAuthorizer returns the registered Authorizer, or one that allows everything when authorization is disabled
+func NewAuthorizer(env *environments.Env) (auth.Authorizer, error) {
+	if !env.Config.Server.EnableAuthz {
+		return auth.NewAllowAllAuthorizer(), nil
+	}
+	return authorizerFactory(env)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/openshift-online/rh-trex-ai/pkg/controllers"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
 	"github.com/openshift-online/rh-trex-ai/pkg/environments"
+	"github.com/openshift-online/rh-trex-ai/pkg/eventbus"
 	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 	"github.com/openshift-online/rh-trex-ai/pkg/services"
 )
@@ -16,27 +17,58 @@ import (
 type ControllersServer struct {
 	KindControllerManager *controllers.KindControllerManager
 	SyncController        *controllers.SyncController
+	CompactionController  *controllers.CompactionController
+	PurgeController       *controllers.PurgeController
+	WebhookController     *controllers.WebhookController
 	Broker                *EventBroker
-	SessionFactory        db.SessionFactory
+	EventBus              eventbus.EventBus
 	cancel                context.CancelFunc
 	done                  chan struct{}
 	startOnce             sync.Once
 }
 
+// Start subscribes the kind controllers and the watch broker to the event bus and starts the background
+// controllers. It doesn't block, Stop ends the subscription.
 func (s *ControllersServer) Start() {
-	log := logger.NewLogger(context.Background())
-	log.Infof("Kind controller listening for events")
-	s.SessionFactory.NewListener(context.Background(), "events", func(id string) {
-		s.KindControllerManager.Handle(id)
-		if s.Broker != nil {
-			s.Broker.Publish(id)
+	s.startOnce.Do(func() {
+		log := logger.NewLogger(context.Background())
+		ctx, cancel := context.WithCancel(context.Background())
+		s.cancel = cancel
+		s.done = make(chan struct{})
+		go func() {
+			defer close(s.done)
+			log.Infof("Kind controller listening for events")
+			s.EventBus.Subscribe(ctx, s.handle)
+		}()
+
+		// Start sync-the-world controller for missed event recovery
+		if s.SyncController != nil {
+			s.SyncController.Start()
+			log.Infof("Sync controller started for missed event recovery")
+		}
+
+		if s.CompactionController != nil {
+			s.CompactionController.Start()
+		}
+
+		if s.PurgeController != nil {
+			s.PurgeController.Start()
+		}
+
+		if s.WebhookController != nil {
+			s.WebhookController.Start()
 		}
 	})
-	
-	// Start sync-the-world controller for missed event recovery
-	if s.SyncController != nil {
-		s.SyncController.Start()
-		log.Infof("Sync controller started for missed event recovery")
+}
+
+// handle passes an announced event to the kind controllers and the watch broker. Events no controller
+// handles are left alone without taking their lock.
+func (s *ControllersServer) handle(msg *eventbus.Message) {
+	if !msg.Complete() || s.KindControllerManager.Handles(msg.Source, msg.EventType) {
+		s.KindControllerManager.Handle(msg.ID)
+	}
+	if s.Broker != nil {
+		s.Broker.Publish(msg)
 	}
 }
 
@@ -50,6 +82,24 @@ func (s *ControllersServer) Stop() {
 		}
 	}
 	
+	if s.CompactionController != nil {
+		if err := s.CompactionController.Stop(); err != nil {
+			log.Error(fmt.Sprintf("Error stopping event compaction controller: %v", err))
+		}
+	}
+
+	if s.PurgeController != nil {
+		if err := s.PurgeController.Stop(); err != nil {
+			log.Error(fmt.Sprintf("Error stopping purge controller: %v", err))
+		}
+	}
+
+	if s.WebhookController != nil {
+		if err := s.WebhookController.Stop(); err != nil {
+			log.Error(fmt.Sprintf("Error stopping webhook controller: %v", err))
+		}
+	}
+
 	if s.Broker != nil {
 		s.Broker.Close()
 	}
@@ -90,14 +140,50 @@ func NewDefaultControllersServer(env *environments.Env) *ControllersServer {
 		)
 	}
 
+	// Create compaction controller to collapse redundant update events and remove old reconciled ones
+	var compactionController *controllers.CompactionController
+	if eventService != nil {
+		compactionController = controllers.NewCompactionController(
+			db.NewAdvisoryLockFactory(env.Database.SessionFactory),
+			eventService,
+			controllers.CompactionControllerConfig{
+				Interval:  env.Config.Server.EventCompactionInterval,
+				Retention: env.Config.Server.EventRetention,
+				Archive:   env.Config.Server.EventArchive,
+			},
+		)
+	}
+
+	// Create purge controller to hard-delete soft-deleted rows past their Kind's retention
+	purgeController := controllers.NewPurgeController(
+		db.NewAdvisoryLockFactory(env.Database.SessionFactory),
+		controllers.PurgeControllerConfig{
+			Interval: 1 * time.Hour,
+		},
+	)
+
+	// Create webhook controller to send the deliveries the webhooks controller enqueues
+	var webhookController *controllers.WebhookController
+	if locator := env.Services.GetService("Webhooks"); locator != nil {
+		webhookController = controllers.NewWebhookController(
+			db.NewAdvisoryLockFactory(env.Database.SessionFactory),
+			locator.(services.WebhookServiceLocator)(),
+			controllers.WebhookControllerConfig{},
+		)
+	}
+
 	s := &ControllersServer{
 		KindControllerManager: kindControllerManager,
 		SyncController:        syncController,
+		CompactionController:  compactionController,
+		PurgeController:       purgeController,
+		WebhookController:     webhookController,
 		Broker:                broker,
-		SessionFactory:        env.Database.SessionFactory,
+		EventBus:              env.Database.EventBus,
 	}
 
 	LoadDiscoveredControllers(s.KindControllerManager, &env.Services)
+	LoadDiscoveredPurgers(s.PurgeController, &env.Services)
 
 	return s
 }
@@ -112,12 +198,13 @@ func NewDefaultHealthCheckServer(env *environments.Env) *HealthCheckServer {
 }
 
 func NewDefaultMetricsServer(env *environments.Env) Server {
+	authMiddleware, authzMiddleware := newAuthMiddlewares(env)
 	return NewMetricsServer(ServerConfig{
 		BindAddress:   env.Config.Metrics.BindAddress,
 		EnableHTTPS:   env.Config.Metrics.EnableHTTPS,
 		HTTPSCertFile: env.Config.Server.HTTPSCertFile,
 		HTTPSKeyFile:  env.Config.Server.HTTPSKeyFile,
-	})
+	}, authMiddleware, authzMiddleware)
 }
 
 type ControllerRegistrationFunc func(manager *controllers.KindControllerManager, services ServicesInterface)
@@ -133,3 +220,17 @@ func LoadDiscoveredControllers(manager *controllers.KindControllerManager, servi
 		registrationFunc(manager, services)
 	}
 }
+
+type PurgerRegistrationFunc func(purger *controllers.PurgeController, services ServicesInterface)
+
+var purgerRegistry = make(map[string]PurgerRegistrationFunc)
+
+func RegisterPurger(name string, registrationFunc PurgerRegistrationFunc) {
+	purgerRegistry[name] = registrationFunc
+}
+
+func LoadDiscoveredPurgers(purger *controllers.PurgeController, services ServicesInterface) {
+	for _, registrationFunc := range purgerRegistry {
+		registrationFunc(purger, services)
+	}
+}
@@ -2,18 +2,23 @@ package server
 
 import (
 	"context"
-	"errors"
+	e "errors"
 	"sync"
+	"sync/atomic"
 
-	"github.com/golang/glog"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/segmentio/ksuid"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	dbContext "github.com/openshift-online/rh-trex-ai/pkg/db/db_context"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/eventbus"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 	"github.com/openshift-online/rh-trex-ai/pkg/services"
 )
 
-var ErrBrokerClosed = errors.New("event broker is closed")
+var ErrBrokerClosed = e.New("event broker is closed")
 
 var (
 	brokerSubscribersActive = prometheus.NewGauge(
@@ -31,7 +36,7 @@ var (
 	brokerEventsDropped = prometheus.NewCounter(
 		prometheus.CounterOpts{
 			Name: "grpc_stream_events_dropped_total",
-			Help: "Total events dropped due to slow stream subscribers",
+			Help: "Total events not sent to slow stream subscribers, which are told to resync",
 		},
 	)
 )
@@ -47,16 +52,61 @@ type BrokerEvent struct {
 	Source    string
 	SourceID  string
 	EventType api.EventType
+	// ChangedFields is the field mask of an update, nil when it isn't known field by field
+	ChangedFields []string
+	// OrganizationID is the organization of the changed resource
+	OrganizationID string
+	// Seq numbers the event, watches pass it on as the event_seq to resume from
+	Seq int64
+
+	// resource is the resource of the event once Resource loaded it
+	resource struct {
+		once  sync.Once
+		value interface{}
+		err   *errors.ServiceError
+	}
+}
+
+// VisibleTo tells whether the caller of ctx may learn about the event without reading its resource, e.g.
+// the delete of a resource purged since: only callers of its organization, or of any organization, may.
+func (e *BrokerEvent) VisibleTo(ctx context.Context) bool {
+	return auth.InTenantScope(ctx, e.OrganizationID)
 }
 
+// Resource returns what load returns for the resource of the event. The event is passed to every watcher
+// of its source, and load runs once for all of them rather than once per watcher. Since callers of every
+// organization share its result, load runs unscoped by tenancy and outside of the transaction and the
+// cancellation of ctx: callers check whether they may see the resource themselves.
+func (e *BrokerEvent) Resource(ctx context.Context, load func(ctx context.Context) (interface{}, *errors.ServiceError)) (interface{}, *errors.ServiceError) {
+	e.resource.once.Do(func() {
+		ctx := auth.SetTenantContext(context.WithoutCancel(dbContext.WithoutTransaction(ctx)), auth.Tenant{AnyOrganization: true})
+		e.resource.value, e.resource.err = load(ctx)
+	})
+	return e.resource.value, e.resource.err
+}
+
+// Subscription receives the published events of its sources. A subscriber that doesn't keep up is
+// unsubscribed rather than handed a gap: Events is closed and Lagged returns true.
 type Subscription struct {
 	ID     string
 	Events <-chan *BrokerEvent
+	lagged atomic.Bool
+}
+
+// Lagged returns true when the subscription was closed because its buffer was full
+func (s *Subscription) Lagged() bool {
+	return s.lagged.Load()
+}
+
+type subscriber struct {
+	ch           chan *BrokerEvent
+	sources      map[string]bool
+	subscription *Subscription
 }
 
 type EventBroker struct {
 	mu          sync.RWMutex
-	subscribers map[string]chan *BrokerEvent
+	subscribers map[string]*subscriber
 	bufferSize  int
 	events      services.EventService
 	closed      bool
@@ -64,13 +114,26 @@ type EventBroker struct {
 
 func NewEventBroker(bufferSize int, events services.EventService) *EventBroker {
 	return &EventBroker{
-		subscribers: make(map[string]chan *BrokerEvent),
+		subscribers: make(map[string]*subscriber),
 		bufferSize:  bufferSize,
 		events:      events,
 	}
 }
 
-func (b *EventBroker) Subscribe(ctx context.Context) (*Subscription, error) {
+// EventBrokerFunc returns a func looking up the EventBroker of services. The broker is set when the
+// controllers start, so the func returns nil before.
+func EventBrokerFunc(services ServicesInterface) func() *EventBroker {
+	return func() *EventBroker {
+		if broker, ok := services.GetService("EventBroker").(*EventBroker); ok {
+			return broker
+		}
+		return nil
+	}
+}
+
+// Subscribe returns a subscription to the events of sources, e.g. "Dinosaurs", or to all events if
+// no source is given. It ends with ctx.
+func (b *EventBroker) Subscribe(ctx context.Context, sources ...string) (*Subscription, error) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
@@ -80,7 +143,15 @@ func (b *EventBroker) Subscribe(ctx context.Context) (*Subscription, error) {
 
 	id := ksuid.New().String()
 	ch := make(chan *BrokerEvent, b.bufferSize)
-	b.subscribers[id] = ch
+	sub := &subscriber{
+		ch:           ch,
+		sources:      map[string]bool{},
+		subscription: &Subscription{ID: id, Events: ch},
+	}
+	for _, source := range sources {
+		sub.sources[source] = true
+	}
+	b.subscribers[id] = sub
 	brokerSubscribersActive.Inc()
 
 	go func() {
@@ -88,24 +159,22 @@ func (b *EventBroker) Subscribe(ctx context.Context) (*Subscription, error) {
 		b.Unsubscribe(id)
 	}()
 
-	return &Subscription{
-		ID:     id,
-		Events: ch,
-	}, nil
+	return sub.subscription, nil
 }
 
 func (b *EventBroker) Unsubscribe(id string) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	if ch, ok := b.subscribers[id]; ok {
+	if sub, ok := b.subscribers[id]; ok {
 		delete(b.subscribers, id)
-		close(ch)
+		close(sub.ch)
 		brokerSubscribersActive.Dec()
 	}
 }
 
-func (b *EventBroker) Publish(eventID string) {
+// Publish passes the event msg announces to the subscribers of its source
+func (b *EventBroker) Publish(msg *eventbus.Message) {
 	b.mu.RLock()
 	if b.closed {
 		b.mu.RUnlock()
@@ -113,36 +182,67 @@ func (b *EventBroker) Publish(eventID string) {
 	}
 	b.mu.RUnlock()
 
-	ctx := context.Background()
-	event, svcErr := b.events.Get(ctx, eventID)
-	if svcErr != nil {
-		glog.Warningf("EventBroker: failed to load event %s: %v", eventID, svcErr)
-		return
-	}
-
 	brokerEvent := &BrokerEvent{
-		EventID:   event.ID,
-		Source:    event.Source,
-		SourceID:  event.SourceID,
-		EventType: event.EventType,
+		EventID:        msg.ID,
+		Source:         msg.Source,
+		SourceID:       msg.SourceID,
+		EventType:      msg.EventType,
+		ChangedFields:  msg.ChangedFields,
+		OrganizationID: msg.OrganizationID,
+		Seq:            msg.Seq,
+	}
+	if !msg.Complete() || msg.Seq == 0 {
+		// announced by a replica that doesn't send all the event data
+		event, svcErr := b.events.Get(context.Background(), msg.ID)
+		if svcErr != nil {
+			logger.Warningf("EventBroker: failed to load event %s: %v", msg.ID, svcErr)
+			return
+		}
+		brokerEvent = newBrokerEvent(event)
 	}
 
+	var lagging []string
 	b.mu.RLock()
-	defer b.mu.RUnlock()
-
 	if b.closed {
+		b.mu.RUnlock()
 		return
 	}
-
-	for subID, ch := range b.subscribers {
+	for subID, sub := range b.subscribers {
+		if len(sub.sources) > 0 && !sub.sources[brokerEvent.Source] {
+			continue
+		}
+		if sub.subscription.Lagged() {
+			continue
+		}
 		select {
-		case ch <- brokerEvent:
+		case sub.ch <- brokerEvent:
 			brokerEventsSent.Inc()
 		default:
+			// the subscriber must not see later events without this one, it is closed once the
+			// events buffered before the gap are delivered
+			sub.subscription.lagged.Store(true)
+			lagging = append(lagging, subID)
 			brokerEventsDropped.Inc()
-			glog.V(5).Infof("EventBroker: dropped event %s for slow subscriber %s", eventID, subID)
+			logger.V(5).Infof("EventBroker: subscriber %s fell behind at event %s", subID, msg.ID)
 		}
 	}
+	b.mu.RUnlock()
+
+	for _, subID := range lagging {
+		b.Unsubscribe(subID)
+	}
+}
+
+func newBrokerEvent(event *api.Event) *BrokerEvent {
+	return &BrokerEvent{
+		EventID:        event.ID,
+		Source:         event.Source,
+		SourceID:       event.SourceID,
+		EventType:      event.EventType,
+		ChangedFields:  event.FieldMask(),
+		OrganizationID: event.OrganizationID,
+		Seq:            event.Seq,
+	}
 }
 
 func (b *EventBroker) Close() {
@@ -150,9 +250,9 @@ func (b *EventBroker) Close() {
 	defer b.mu.Unlock()
 
 	b.closed = true
-	for id, ch := range b.subscribers {
+	for id, sub := range b.subscribers {
 		delete(b.subscribers, id)
-		close(ch)
+		close(sub.ch)
 	}
 	brokerSubscribersActive.Set(0)
 }
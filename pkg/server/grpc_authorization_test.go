@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+)
+
+func TestAuthorizeGRPCRequest(t *testing.T) {
+	original := grpcMethodAuthorizations
+	defer func() { grpcMethodAuthorizations = original }()
+	grpcMethodAuthorizations = map[string]grpcMethodAuthorization{}
+
+	RegisterGRPCMethodAuthorization("/test.Service/Get", auth.ActionGet, "dinosaurs")
+	RegisterGRPCMethodAuthorization("/test.Service/Grant", auth.ActionCreate, "role_bindings")
+	authorizer := auth.NewPolicyAuthorizer(auth.DefaultPolicy(), nil)
+
+	if _, err := authorizeGRPCRequest(context.Background(), authorizer, "/test.Service/Get", "alice"); err != nil {
+		t.Errorf("expected get to be allowed, got %v", err)
+	}
+	_, err := authorizeGRPCRequest(context.Background(), authorizer, "/test.Service/Grant", "alice")
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
+	_, err = authorizeGRPCRequest(context.Background(), authorizer, "/test.Service/Undeclared", "alice")
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected undeclared methods to be denied, got %v", err)
+	}
+	for _, method := range []string{healthgrpc.Health_Watch_FullMethodName, reflectionv1.ServerReflection_ServerReflectionInfo_FullMethodName} {
+		if _, err := authorizeGRPCRequest(context.Background(), authorizer, method, "alice"); err != nil {
+			t.Errorf("expected %s to be allowed, got %v", method, err)
+		}
+	}
+}
+
+func TestAuthorizeGRPCRequest_LiftsTenancyForAdmins(t *testing.T) {
+	original := grpcMethodAuthorizations
+	defer func() { grpcMethodAuthorizations = original }()
+	grpcMethodAuthorizations = map[string]grpcMethodAuthorization{}
+
+	RegisterGRPCMethodAuthorization("/test.Service/List", auth.ActionList, "dinosaurs")
+	policy := auth.DefaultPolicy()
+	policy.Bindings = append(policy.Bindings, auth.RoleBinding{Username: "root", Role: auth.RoleAdmin})
+	authorizer := auth.NewPolicyAuthorizer(policy, nil)
+	ctx := auth.SetTenantContext(context.Background(), auth.Tenant{OrganizationID: "acme"})
+
+	for username, anyOrganization := range map[string]bool{"alice": false, "root": true} {
+		scoped, err := authorizeGRPCRequest(ctx, authorizer, "/test.Service/List", username)
+		if err != nil {
+			t.Fatalf("expected list to be allowed for %s, got %v", username, err)
+		}
+		tenant, _ := auth.GetTenantFromContext(scoped)
+		if tenant.OrganizationID != "acme" || tenant.AnyOrganization != anyOrganization {
+			t.Errorf("expected %s to be scoped to acme with AnyOrganization=%v, got %+v", username, anyOrganization, tenant)
+		}
+	}
+}
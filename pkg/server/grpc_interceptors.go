@@ -2,23 +2,36 @@ package server
 
 import (
 	"context"
+	"fmt"
 	"runtime/debug"
 	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
-	"github.com/golang/glog"
 	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
 
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
 	"github.com/openshift-online/rh-trex-ai/pkg/auth"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
 	"github.com/openshift-online/rh-trex-ai/pkg/environments"
+	"github.com/openshift-online/rh-trex-ai/pkg/handlers"
 	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+	"github.com/openshift-online/rh-trex-ai/pkg/ratelimit"
 	"github.com/openshift-online/rh-trex-ai/pkg/server/grpcutil"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+	"github.com/openshift-online/rh-trex-ai/pkg/tracing"
 )
 
 var (
@@ -44,6 +57,13 @@ func init() {
 	prometheus.MustRegister(grpcRequestDuration)
 }
 
+const (
+	// idempotencyKeyMetadata is the gRPC counterpart of the Idempotency-Key header
+	idempotencyKeyMetadata = "idempotency-key"
+	// idempotentReplayedMetadata is set on the header of responses replayed for a retried idempotency-key
+	idempotentReplayedMetadata = "idempotent-replayed"
+)
+
 type wrappedServerStream struct {
 	grpc.ServerStream
 	ctx context.Context
@@ -51,11 +71,74 @@ type wrappedServerStream struct {
 
 func (w *wrappedServerStream) Context() context.Context { return w.ctx }
 
+// grpcMetadataCarrier reads and writes the trace context of a gRPC call in its metadata
+type grpcMetadataCarrier metadata.MD
+
+func (c grpcMetadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (c grpcMetadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c grpcMetadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// startGRPCSpan starts the server span of the gRPC method fullMethod, continuing the trace of the W3C
+// trace context in the metadata of the call
+func startGRPCSpan(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, grpcMetadataCarrier(md))
+
+	name := strings.TrimPrefix(fullMethod, "/")
+	service, method, _ := strings.Cut(name, "/")
+	return tracing.Tracer().Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.RPCSystemGRPC,
+			semconv.RPCService(service),
+			semconv.RPCMethod(method),
+		),
+	)
+}
+
+// endGRPCSpan records the status of the call on its span and ends it
+func endGRPCSpan(span trace.Span, err error) {
+	code := status.Code(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int64(int64(code)))
+	// the codes of the caller's mistakes leave the span successful, like the 4xx of REST
+	switch code {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented, codes.Internal,
+		codes.Unavailable, codes.DataLoss:
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, status.Convert(err).Message())
+	}
+	span.End()
+}
+
+func TracingUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, span := startGRPCSpan(ctx, info.FullMethod)
+		resp, err := handler(ctx, req)
+		endGRPCSpan(span, err)
+		return resp, err
+	}
+}
+
 func RecoveryUnaryInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 		defer func() {
 			if r := recover(); r != nil {
-				glog.Errorf("gRPC panic in %s: %v\n%s", info.FullMethod, r, debug.Stack())
+				logger.Errorf("gRPC panic in %s: %v\n%s", info.FullMethod, r, debug.Stack())
 				err = status.Error(codes.Internal, "internal server error")
 			}
 		}()
@@ -97,7 +180,7 @@ func TransactionUnaryInterceptor(sessionFactory db.SessionFactory) grpc.UnarySer
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		ctx, err := db.NewContext(ctx, sessionFactory)
 		if err != nil {
-			glog.Errorf("Failed to create DB transaction for gRPC call %s: %v", info.FullMethod, err)
+			logger.Errorf("Failed to create DB transaction for gRPC call %s: %v", info.FullMethod, err)
 			return nil, status.Error(codes.Internal, "internal database error")
 		}
 		defer func() { db.Resolve(ctx) }()
@@ -106,33 +189,116 @@ func TransactionUnaryInterceptor(sessionFactory db.SessionFactory) grpc.UnarySer
 	}
 }
 
-func AuthUnaryInterceptor(env *environments.Env, keyProvider *grpcutil.JWKKeyProvider) grpc.UnaryServerInterceptor {
+func AuthUnaryInterceptor(env *environments.Env, keyProvider *grpcutil.JWKKeyProvider, authorizer auth.Authorizer) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		if !env.Config.Server.EnableJWT {
 			return handler(ctx, req)
 		}
 
-		if info.FullMethod == "/grpc.health.v1.Health/Check" ||
-			info.FullMethod == "/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo" {
+		if info.FullMethod == healthgrpc.Health_Check_FullMethodName {
 			return handler(ctx, req)
 		}
 
-		username, err := authenticateGRPCRequest(ctx, keyProvider)
+		username, orgID, err := authenticateGRPCRequest(ctx, keyProvider)
 		if err != nil {
 			return nil, err
 		}
 
 		ctx = auth.SetUsernameContext(ctx, username)
+		ctx = auth.SetTenantContext(ctx, auth.Tenant{OrganizationID: orgID})
+
+		ctx, err = authorizeGRPCRequest(ctx, authorizer, info.FullMethod, username)
+		if err != nil {
+			return nil, err
+		}
 
 		return handler(ctx, req)
 	}
 }
 
+// IdempotencyUnaryInterceptor replays the response to a unary call retried with the same idempotency-key
+// metadata instead of running it again, like handlers.IdempotencyMiddleware does for REST. It has to run
+// after the TransactionUnaryInterceptor and after authentication, since keys are scoped to the caller.
+func IdempotencyUnaryInterceptor(idempotencyKeys services.IdempotencyKeyService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		keys := md.Get(idempotencyKeyMetadata)
+		msg, ok := req.(proto.Message)
+		if len(keys) == 0 || keys[0] == "" || !ok {
+			return handler(ctx, req)
+		}
+
+		key := keys[0]
+		if len(key) > grpcutil.MaxStringFieldLength {
+			return nil, status.Errorf(codes.InvalidArgument, "%s exceeds maximum length of %d", idempotencyKeyMetadata, grpcutil.MaxStringFieldLength)
+		}
+		body, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "unable to marshal request: %v", err)
+		}
+		// gRPC calls are POSTs to the full method name
+		requestHash := handlers.HashRequest("POST", info.FullMethod, body)
+
+		remembered, svcErr := idempotencyKeys.Lookup(ctx, key, requestHash)
+		if svcErr != nil {
+			return nil, grpcutil.ServiceErrorToGRPC(svcErr)
+		}
+		if remembered != nil {
+			var response anypb.Any
+			if err := proto.Unmarshal(remembered.Body, &response); err != nil {
+				return nil, status.Errorf(codes.Internal, "unable to replay response: %v", err)
+			}
+			replayed, err := response.UnmarshalNew()
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "unable to replay response: %v", err)
+			}
+			_ = grpc.SetHeader(ctx, metadata.Pairs(idempotentReplayedMetadata, "true"))
+			return replayed, nil
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil || db.MarkedForRollback(ctx) {
+			return resp, err
+		}
+		respMsg, ok := resp.(proto.Message)
+		if !ok {
+			return resp, nil
+		}
+		response, err := anypb.New(respMsg)
+		if err == nil {
+			body, err = proto.Marshal(response)
+		}
+		if err != nil {
+			db.MarkForRollback(ctx, err)
+			return nil, status.Errorf(codes.Internal, "unable to remember response: %v", err)
+		}
+		svcErr = idempotencyKeys.Remember(ctx, &api.IdempotencyKey{
+			Key:         key,
+			RequestHash: requestHash,
+			StatusCode:  int(codes.OK),
+			Body:        body,
+		})
+		if svcErr != nil {
+			return nil, grpcutil.ServiceErrorToGRPC(svcErr)
+		}
+		return resp, nil
+	}
+}
+
+func TracingStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := startGRPCSpan(ss.Context(), info.FullMethod)
+		err := handler(srv, &wrappedServerStream{ServerStream: ss, ctx: ctx})
+		endGRPCSpan(span, err)
+		return err
+	}
+}
+
 func RecoveryStreamInterceptor() grpc.StreamServerInterceptor {
 	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
 		defer func() {
 			if r := recover(); r != nil {
-				glog.Errorf("gRPC stream panic in %s: %v\n%s", info.FullMethod, r, debug.Stack())
+				logger.Errorf("gRPC stream panic in %s: %v\n%s", info.FullMethod, r, debug.Stack())
 				err = status.Error(codes.Internal, "internal server error")
 			}
 		}()
@@ -172,33 +338,123 @@ func MetricsStreamInterceptor() grpc.StreamServerInterceptor {
 	}
 }
 
-func AuthStreamInterceptor(env *environments.Env, keyProvider *grpcutil.JWKKeyProvider) grpc.StreamServerInterceptor {
+func AuthStreamInterceptor(env *environments.Env, keyProvider *grpcutil.JWKKeyProvider, authorizer auth.Authorizer) grpc.StreamServerInterceptor {
 	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 		if !env.Config.Server.EnableJWT {
 			return handler(srv, ss)
 		}
 
-		username, err := authenticateGRPCRequest(ss.Context(), keyProvider)
+		username, orgID, err := authenticateGRPCRequest(ss.Context(), keyProvider)
 		if err != nil {
 			return err
 		}
 
 		ctx := auth.SetUsernameContext(ss.Context(), username)
+		ctx = auth.SetTenantContext(ctx, auth.Tenant{OrganizationID: orgID})
+
+		ctx, err = authorizeGRPCRequest(ctx, authorizer, info.FullMethod, username)
+		if err != nil {
+			return err
+		}
+
 		wrapped := &wrappedServerStream{ServerStream: ss, ctx: ctx}
 
 		return handler(srv, wrapped)
 	}
 }
 
-func authenticateGRPCRequest(ctx context.Context, keyProvider *grpcutil.JWKKeyProvider) (string, error) {
+// RateLimitUnaryInterceptor rejects the calls of clients over their rate limit with ResourceExhausted, like
+// RateLimitMiddleware does for REST. It has to run after authentication, since clients are keyed by their user.
+func RateLimitUnaryInterceptor(limiter *ratelimit.Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := allowGRPCCall(ctx, limiter, info.FullMethod, func(md metadata.MD) error {
+			return grpc.SetHeader(ctx, md)
+		}); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// RateLimitStreamInterceptor is the RateLimitUnaryInterceptor of streams, a stream takes a single token
+func RateLimitStreamInterceptor(limiter *ratelimit.Limiter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := allowGRPCCall(ss.Context(), limiter, info.FullMethod, ss.SetHeader); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// allowGRPCCall takes a token for the call of fullMethod and sends the rate limit headers of the call with
+// setHeader. The Kind of a call is the resource declared for its method.
+func allowGRPCCall(ctx context.Context, limiter *ratelimit.Limiter, fullMethod string, setHeader func(metadata.MD) error) error {
+	request := ratelimit.Request{
+		Route: fullMethod,
+		Kind:  grpcMethodAuthorizations[fullMethod].resource,
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		md, _ := metadata.FromIncomingContext(ctx)
+		request.ClientIP = clientIP(limiter, p.Addr.String(), func(header string) []string {
+			return md.Get(header)
+		})
+	}
+	result := limiter.Allow(ctx, request)
+	if result == nil {
+		return nil
+	}
+
+	md := metadata.MD{}
+	for header, value := range rateLimitHeaders(result) {
+		md.Set(header, value)
+	}
+	if err := setHeader(md); err != nil {
+		logger.NewLogger(ctx).V(4).Infof("Unable to send the rate limit headers of %s: %v", fullMethod, err)
+	}
+	if !result.Allowed {
+		return grpcutil.ServiceErrorToGRPC(rateLimitExceeded(result))
+	}
+	return nil
+}
+
+// authorizeGRPCRequest checks the caller against the action and resource declared for the method, and
+// lifts the tenancy scope of the returned context for callers allowed to work across organizations.
+// Methods without a declaration are denied, so a new RPC isn't open until its plugin declares it.
+func authorizeGRPCRequest(ctx context.Context, authorizer auth.Authorizer, fullMethod, username string) (context.Context, error) {
+	declared, ok := grpcMethodAuthorizations[fullMethod]
+	if !ok {
+		if grpcUndeclaredMethods[fullMethod] {
+			return ctx, nil
+		}
+		logger.NewLogger(ctx).Warning(fmt.Sprintf("Denied gRPC call %s, which has no declared authorization", fullMethod))
+		return ctx, status.Errorf(codes.PermissionDenied, "method %s is not authorized", fullMethod)
+	}
+	ctx = logger.WithKind(ctx, declared.resource)
+	allowed, err := authorizer.Authorize(ctx, username, declared.action, declared.resource)
+	if err != nil {
+		logger.Errorf("Unable to authorize gRPC call %s: %v", fullMethod, err)
+		return ctx, status.Error(codes.Internal, "unable to authorize request")
+	}
+	if !allowed {
+		return ctx, status.Errorf(codes.PermissionDenied, "user '%s' may not %s %s", username, declared.action, declared.resource)
+	}
+	ctx, err = auth.AuthorizeAnyOrganization(ctx, authorizer, username, declared.resource)
+	if err != nil {
+		logger.Errorf("Unable to authorize gRPC call %s: %v", fullMethod, err)
+		return ctx, status.Error(codes.Internal, "unable to authorize request")
+	}
+	return ctx, nil
+}
+
+func authenticateGRPCRequest(ctx context.Context, keyProvider *grpcutil.JWKKeyProvider) (string, string, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return "", status.Error(codes.Unauthenticated, "missing metadata")
+		return "", "", status.Error(codes.Unauthenticated, "missing metadata")
 	}
 
 	authHeader := md.Get("authorization")
 	if len(authHeader) == 0 {
-		return "", status.Error(codes.Unauthenticated, "missing authorization token")
+		return "", "", status.Error(codes.Unauthenticated, "missing authorization token")
 	}
 
 	tokenStr := strings.TrimPrefix(authHeader[0], "Bearer ")
@@ -214,12 +470,12 @@ func authenticateGRPCRequest(ctx context.Context, keyProvider *grpcutil.JWKKeyPr
 		jwtToken, _, err = parser.ParseUnverified(tokenStr, jwt.MapClaims{})
 	}
 	if err != nil {
-		return "", status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+		return "", "", status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
 	}
 
 	claims, ok := jwtToken.Claims.(jwt.MapClaims)
 	if !ok {
-		return "", status.Error(codes.Unauthenticated, "invalid token claims")
+		return "", "", status.Error(codes.Unauthenticated, "invalid token claims")
 	}
 
 	username, _ := claims["username"].(string)
@@ -227,8 +483,8 @@ func authenticateGRPCRequest(ctx context.Context, keyProvider *grpcutil.JWKKeyPr
 		username, _ = claims["preferred_username"].(string)
 	}
 	if username == "" {
-		return "", status.Error(codes.Unauthenticated, "token missing username claim")
+		return "", "", status.Error(codes.Unauthenticated, "token missing username claim")
 	}
 
-	return username, nil
+	return username, auth.OrganizationIDFromClaims(claims), nil
 }
@@ -2,6 +2,9 @@ package server
 
 import (
 	"google.golang.org/grpc"
+	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionv1alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 )
 
 type GRPCServiceRegistrationFunc func(grpcServer *grpc.Server, services ServicesInterface)
@@ -17,3 +20,28 @@ func LoadDiscoveredGRPCServices(grpcServer *grpc.Server, services ServicesInterf
 		registrationFunc(grpcServer, services)
 	}
 }
+
+// grpcMethodAuthorization is the action and resource a gRPC method is authorized for
+type grpcMethodAuthorization struct {
+	action   string
+	resource string
+}
+
+var grpcMethodAuthorizations = make(map[string]grpcMethodAuthorization)
+
+// grpcUndeclaredMethods are the methods of the health and reflection services, which any caller that
+// passes authentication may call without a declared authorization
+var grpcUndeclaredMethods = map[string]bool{
+	healthgrpc.Health_Check_FullMethodName:                                 true,
+	healthgrpc.Health_List_FullMethodName:                                  true,
+	healthgrpc.Health_Watch_FullMethodName:                                 true,
+	reflectionv1.ServerReflection_ServerReflectionInfo_FullMethodName:      true,
+	reflectionv1alpha.ServerReflection_ServerReflectionInfo_FullMethodName: true,
+}
+
+// RegisterGRPCMethodAuthorization declares the action and resource the caller of a gRPC method needs,
+// e.g. RegisterGRPCMethodAuthorization(pb.DinosaurService_GetDinosaur_FullMethodName, auth.ActionGet, "dinosaurs").
+// Methods that aren't declared are denied, apart from those of the health and reflection services.
+func RegisterGRPCMethodAuthorization(fullMethod, action, resource string) {
+	grpcMethodAuthorizations[fullMethod] = grpcMethodAuthorization{action: action, resource: resource}
+}
@@ -3,7 +3,6 @@ package server
 import (
 	"net"
 
-	"github.com/golang/glog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
@@ -11,7 +10,9 @@ import (
 	"google.golang.org/grpc/reflection"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/environments"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 	"github.com/openshift-online/rh-trex-ai/pkg/server/grpcutil"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
 )
 
 // Global interceptor registries for pre-auth interceptors
@@ -44,8 +45,14 @@ func NewDefaultGRPCServer(env *environments.Env) Server {
 		keyProvider = grpcutil.NewJWKKeyProvider(env.Config.Server.JwkCertURL, env.Config.Server.JwkCertFile)
 	}
 
+	authorizer, err := NewAuthorizer(env)
+	if err != nil {
+		logger.Fatalf("Unable to create gRPC authorizer: %v", err)
+	}
+
 	// Build interceptor chains with pre-auth interceptors running BEFORE JWT auth
 	unaryChain := []grpc.UnaryServerInterceptor{
+		TracingUnaryInterceptor(),
 		RecoveryUnaryInterceptor(),
 		LoggingUnaryInterceptor(),
 		MetricsUnaryInterceptor(),
@@ -53,16 +60,26 @@ func NewDefaultGRPCServer(env *environments.Env) Server {
 	}
 	// Add pre-auth interceptors before JWT auth
 	unaryChain = append(unaryChain, preAuthUnaryInterceptors...)
-	unaryChain = append(unaryChain, AuthUnaryInterceptor(env, keyProvider))
+	unaryChain = append(unaryChain, AuthUnaryInterceptor(env, keyProvider, authorizer))
+	if env.RateLimiter != nil {
+		unaryChain = append(unaryChain, RateLimitUnaryInterceptor(env.RateLimiter))
+	}
+	if locator, ok := env.Services.GetService("IdempotencyKeys").(services.IdempotencyKeyServiceLocator); ok {
+		unaryChain = append(unaryChain, IdempotencyUnaryInterceptor(locator()))
+	}
 
 	streamChain := []grpc.StreamServerInterceptor{
+		TracingStreamInterceptor(),
 		RecoveryStreamInterceptor(),
 		LoggingStreamInterceptor(),
 		MetricsStreamInterceptor(),
 	}
 	// Add pre-auth interceptors before JWT auth
 	streamChain = append(streamChain, preAuthStreamInterceptors...)
-	streamChain = append(streamChain, AuthStreamInterceptor(env, keyProvider))
+	streamChain = append(streamChain, AuthStreamInterceptor(env, keyProvider, authorizer))
+	if env.RateLimiter != nil {
+		streamChain = append(streamChain, RateLimitStreamInterceptor(env.RateLimiter))
+	}
 
 	opts := []grpc.ServerOption{
 		grpc.ChainUnaryInterceptor(unaryChain...),
@@ -75,7 +92,7 @@ func NewDefaultGRPCServer(env *environments.Env) Server {
 			env.Config.GRPC.TLSKeyFile,
 		)
 		if err != nil {
-			glog.Fatalf("Failed to load gRPC TLS credentials: %v", err)
+			logger.Fatalf("Failed to load gRPC TLS credentials: %v", err)
 		}
 		opts = append(opts, grpc.Creds(creds))
 	}
@@ -98,9 +115,9 @@ func NewDefaultGRPCServer(env *environments.Env) Server {
 func (s *grpcAPIServer) Start() {
 	listener, err := s.Listen()
 	if err != nil {
-		glog.Fatalf("Unable to start gRPC server: %v", err)
+		logger.Fatalf("Unable to start gRPC server: %v", err)
 	}
-	glog.Infof("gRPC server listening at %s", s.env.Config.GRPC.BindAddress)
+	logger.Infof("gRPC server listening at %s", s.env.Config.GRPC.BindAddress)
 	s.Serve(listener)
 }
 
@@ -112,11 +129,11 @@ func (s *grpcAPIServer) Serve(listener net.Listener) {
 	if err := s.grpcServer.Serve(listener); err != nil {
 		Check(err, "gRPC server terminated with errors")
 	}
-	glog.Info("gRPC server terminated")
+	logger.Infof("gRPC server terminated")
 }
 
 func (s *grpcAPIServer) Stop() error {
-	glog.Info("gRPC server shutting down gracefully")
+	logger.Infof("gRPC server shutting down gracefully")
 	s.grpcServer.GracefulStop()
 	return nil
 }
@@ -0,0 +1,139 @@
+package grpcutil
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/validation"
+)
+
+// The fields of an Update request that aren't fields of the resource
+var updateRequestFields = map[string]bool{"id": true, "resource_version": true, "update_mask": true}
+
+// FieldSet is the set of the fields an update writes, by their proto name
+type FieldSet map[string]bool
+
+// UpdatedFields returns the fields the Update request req writes. Without a mask those are the fields req
+// sets. With one, they are the fields it names: a field it names that req leaves unset is cleared. A mask
+// naming a field the resource doesn't have, or one the update can't write, fails with InvalidArgument.
+func UpdatedFields(req proto.Message, mask *fieldmaskpb.FieldMask) (FieldSet, error) {
+	fields := FieldSet{}
+	message := req.ProtoReflect()
+	if len(mask.GetPaths()) == 0 {
+		message.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+			if name := string(fd.Name()); !updateRequestFields[name] {
+				fields[name] = true
+			}
+			return true
+		})
+		return fields, nil
+	}
+
+	var violations []errors.FieldViolation
+	descriptors := message.Descriptor().Fields()
+	for _, path := range mask.GetPaths() {
+		switch {
+		case updateRequestFields[path]:
+			violations = append(violations, errors.FieldViolation{
+				Field: "update_mask", Code: validation.CodeReadOnly, Message: "update_mask can't name " + path,
+			})
+		case descriptors.ByName(protoreflect.Name(path)) == nil:
+			violations = append(violations, errors.FieldViolation{
+				Field: "update_mask", Code: validation.CodeUnknown, Message: path + " isn't a field of the resource",
+			})
+		default:
+			fields[path] = true
+		}
+	}
+	if len(violations) > 0 {
+		return nil, ServiceErrorToGRPC(errors.FieldValidation(violations))
+	}
+	return fields, nil
+}
+
+// Values returns the values req gives the fields of s, for the rules of the Kind. The fields req leaves
+// unset are cleared, they have no value.
+func (s FieldSet) Values(req proto.Message) validation.Fields {
+	set := validation.ProtoFieldsOf(req)
+	fields := validation.Fields{}
+	for name := range s {
+		fields[name] = set[name]
+	}
+	return fields
+}
+
+// ChangedFields returns the fields of a resource, by their proto name, whose values differ between before
+// and after, its proto message before and after an update. The metadata is left out.
+func ChangedFields(before, after proto.Message) []string {
+	old, updated := validation.ProtoFieldsOf(before), validation.ProtoFieldsOf(after)
+	var changed []string
+	for name, value := range updated {
+		if name != "metadata" && !reflect.DeepEqual(value, old[name]) {
+			changed = append(changed, name)
+		}
+	}
+	for name := range old {
+		if _, ok := updated[name]; !ok && name != "metadata" {
+			changed = append(changed, name)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// ValidateReadMask checks that the paths of mask, the read_mask of a Get or List request, are fields of m,
+// a message of the Kind. Nested fields are separated by dots, e.g. metadata.id.
+func ValidateReadMask(mask *fieldmaskpb.FieldMask, m proto.Message) error {
+	if mask == nil || mask.IsValid(m) {
+		return nil
+	}
+	for _, path := range mask.GetPaths() {
+		if !(&fieldmaskpb.FieldMask{Paths: []string{path}}).IsValid(m) {
+			return ServiceErrorToGRPC(errors.FieldValidation([]errors.FieldViolation{{
+				Field: "read_mask", Code: validation.CodeUnknown, Message: path + " isn't a field of the resource",
+			}}))
+		}
+	}
+	return status.Error(codes.InvalidArgument, "read_mask is invalid")
+}
+
+// ApplyReadMask clears the fields of m that mask doesn't name. An empty mask leaves m as it is.
+func ApplyReadMask(m proto.Message, mask *fieldmaskpb.FieldMask) {
+	if len(mask.GetPaths()) == 0 {
+		return
+	}
+	keep := map[string][]string{}
+	for _, path := range mask.GetPaths() {
+		field, nested, _ := strings.Cut(path, ".")
+		if paths, ok := keep[field]; !ok || paths != nil {
+			// a path naming the field itself keeps all of it
+			if nested == "" {
+				keep[field] = nil
+			} else {
+				keep[field] = append(paths, nested)
+			}
+		}
+	}
+	prune(m.ProtoReflect(), keep)
+}
+
+func prune(message protoreflect.Message, keep map[string][]string) {
+	message.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		nested, ok := keep[string(fd.Name())]
+		switch {
+		case !ok:
+			message.Clear(fd)
+		case nested != nil && fd.Message() != nil && !fd.IsList() && !fd.IsMap():
+			ApplyReadMask(value.Message().Interface(), &fieldmaskpb.FieldMask{Paths: nested})
+		}
+		return true
+	})
+}
@@ -0,0 +1,81 @@
+package grpcutil
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/openshift-online/rh-trex-ai/pkg/api/grpc/rh_trex/v1"
+)
+
+func TestUpdatedFields(t *testing.T) {
+	RegisterTestingT(t)
+
+	// without a mask the fields set are written, the id and the resource version aren't fields
+	req := &pb.UpdateFossilRequest{Id: "1", EstimatedAge: proto.Int32(3), ResourceVersion: proto.Int64(2)}
+	fields, err := UpdatedFields(req, nil)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(fields).To(Equal(FieldSet{"estimated_age": true}))
+
+	// with one the fields it names are, and those left unset are cleared
+	req.UpdateMask = &fieldmaskpb.FieldMask{Paths: []string{"fossil_type"}}
+	fields, err = UpdatedFields(req, req.UpdateMask)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(fields).To(Equal(FieldSet{"fossil_type": true}))
+	values := fields.Values(req)
+	Expect(values).To(HaveKeyWithValue("fossil_type", BeNil()))
+	Expect(values).NotTo(HaveKey("estimated_age"))
+
+	_, err = UpdatedFields(req, &fieldmaskpb.FieldMask{Paths: []string{"id", "colour", "metadata.id"}})
+	Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+	violations := FieldViolationsFromGRPC(err)
+	Expect(violations).To(HaveLen(3))
+	Expect(violations[0].Code).To(Equal("read_only"))
+	Expect(violations[1].Code).To(Equal("unknown"))
+	Expect(violations[2].Code).To(Equal("unknown"))
+}
+
+func TestChangedFields(t *testing.T) {
+	RegisterTestingT(t)
+
+	before := &pb.Fossil{Metadata: &pb.ObjectReference{Id: "1"}, DiscoveryLocation: "Gobi", FossilType: proto.String("Tooth")}
+	after := &pb.Fossil{Metadata: &pb.ObjectReference{Id: "1", ResourceVersion: 2}, DiscoveryLocation: "Gobi", EstimatedAge: proto.Int32(3)}
+	Expect(ChangedFields(before, after)).To(Equal([]string{"estimated_age", "fossil_type"}))
+	Expect(ChangedFields(before, before)).To(BeEmpty())
+}
+
+func TestReadMask(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(ValidateReadMask(nil, &pb.Fossil{})).To(Succeed())
+	Expect(ValidateReadMask(&fieldmaskpb.FieldMask{Paths: []string{"estimated_age", "metadata.id"}}, &pb.Fossil{})).To(Succeed())
+	err := ValidateReadMask(&fieldmaskpb.FieldMask{Paths: []string{"metadata.colour"}}, &pb.Fossil{})
+	Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+	Expect(FieldViolationsFromGRPC(err)[0].Field).To(Equal("read_mask"))
+
+	createdAt := timestamppb.Now()
+	fossil := func() *pb.Fossil {
+		return &pb.Fossil{
+			Metadata:          &pb.ObjectReference{Id: "1", Kind: "Fossil", CreatedAt: createdAt},
+			DiscoveryLocation: "Gobi",
+			EstimatedAge:      proto.Int32(3),
+		}
+	}
+
+	m := fossil()
+	ApplyReadMask(m, nil)
+	Expect(proto.Equal(m, fossil())).To(BeTrue())
+
+	ApplyReadMask(m, &fieldmaskpb.FieldMask{Paths: []string{"estimated_age", "metadata.id"}})
+	Expect(proto.Equal(m, &pb.Fossil{Metadata: &pb.ObjectReference{Id: "1"}, EstimatedAge: proto.Int32(3)})).To(BeTrue())
+
+	// naming a message keeps all of it
+	m = fossil()
+	ApplyReadMask(m, &fieldmaskpb.FieldMask{Paths: []string{"metadata.id", "metadata"}})
+	Expect(proto.Equal(m, &pb.Fossil{Metadata: fossil().Metadata})).To(BeTrue())
+}
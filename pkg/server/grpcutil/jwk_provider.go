@@ -13,7 +13,8 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
-	"github.com/golang/glog"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 )
 
 type jwkKeyData struct {
@@ -95,7 +96,7 @@ func (p *JWKKeyProvider) loadKeys() error {
 
 	if p.keysFile != "" {
 		if err := p.loadKeysFromFile(); err != nil {
-			glog.Warningf("JWKKeyProvider: failed to load keys from file %s: %v", p.keysFile, err)
+			logger.Warningf("JWKKeyProvider: failed to load keys from file %s: %v", p.keysFile, err)
 		}
 	}
 	if p.keysURL != "" {
@@ -144,7 +145,7 @@ func (p *JWKKeyProvider) parseAndStoreKeys(data []byte) error {
 		}
 		pubKey, err := parseRSAPublicKey(k)
 		if err != nil {
-			glog.Warningf("JWKKeyProvider: skipping key kid=%s: %v", k.Kid, err)
+			logger.Warningf("JWKKeyProvider: skipping key kid=%s: %v", k.Kid, err)
 			continue
 		}
 		p.keys[k.Kid] = pubKey
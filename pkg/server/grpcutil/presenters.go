@@ -6,13 +6,74 @@ import (
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
 	pb "github.com/openshift-online/rh-trex-ai/pkg/api/grpc/rh_trex/v1"
 	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
 )
 
 func ServiceErrorToGRPC(svcErr *errors.ServiceError) error {
+	// A concurrent modification is retryable, unlike a unique constraint conflict
+	if svcErr.IsVersionConflict() {
+		return status.Error(codes.Aborted, svcErr.Reason)
+	}
 	code := HTTPStatusToGRPCCode(svcErr.HttpCode)
-	return status.Error(code, svcErr.Reason)
+	if len(svcErr.FieldViolations) == 0 {
+		return status.Error(code, svcErr.Reason)
+	}
+	// The field violations go to the status details as a google.rpc.BadRequest, like the field_violations of
+	// the REST error body
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range svcErr.FieldViolations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       violation.Field,
+			Reason:      violation.Code,
+			Description: violation.Message,
+		})
+	}
+	st, err := status.New(code, svcErr.Reason).WithDetails(badRequest)
+	if err != nil {
+		return status.Error(code, svcErr.Reason)
+	}
+	return st.Err()
+}
+
+// FieldViolationsFromGRPC returns the field violations of the BadRequest details of a gRPC error, the inverse
+// of ServiceErrorToGRPC for clients
+func FieldViolationsFromGRPC(err error) []errors.FieldViolation {
+	var violations []errors.FieldViolation
+	for _, detail := range status.Convert(err).Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		for _, violation := range badRequest.FieldViolations {
+			violations = append(violations, errors.FieldViolation{
+				Field:   violation.Field,
+				Code:    violation.Reason,
+				Message: violation.Description,
+			})
+		}
+	}
+	return violations
+}
+
+// BatchItemErrorToProto returns the error of one item of a batch RPC, nil for an item that succeeded
+func BatchItemErrorToProto(svcErr *errors.ServiceError) *pb.BatchItemError {
+	if svcErr == nil {
+		return nil
+	}
+	st := status.Convert(ServiceErrorToGRPC(svcErr))
+	itemErr := &pb.BatchItemError{Code: int32(st.Code()), Message: st.Message()}
+	for _, violation := range svcErr.FieldViolations {
+		itemErr.FieldViolations = append(itemErr.FieldViolations, &pb.FieldViolation{
+			Field:   violation.Field,
+			Code:    violation.Code,
+			Message: violation.Message,
+		})
+	}
+	return itemErr
 }
 
 func HTTPStatusToGRPCCode(httpCode int) codes.Code {
@@ -27,6 +88,8 @@ func HTTPStatusToGRPCCode(httpCode int) codes.Code {
 		return codes.NotFound
 	case http.StatusConflict:
 		return codes.AlreadyExists
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
 	case http.StatusUnprocessableEntity:
 		return codes.InvalidArgument
 	case http.StatusTooManyRequests:
@@ -55,3 +118,11 @@ func APIEventTypeToProto(et api.EventType) pb.EventType {
 		return pb.EventType_EVENT_TYPE_UNSPECIFIED
 	}
 }
+
+// DeletedAtToProto returns the deletion time of a soft-deleted record, or nil for a live one
+func DeletedAtToProto(deletedAt gorm.DeletedAt) *timestamppb.Timestamp {
+	if !deletedAt.Valid {
+		return nil
+	}
+	return timestamppb.New(deletedAt.Time)
+}
@@ -3,6 +3,10 @@ package grpcutil
 import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+	"github.com/openshift-online/rh-trex-ai/pkg/validation"
 )
 
 const (
@@ -29,6 +33,57 @@ func ValidateStringField(name, value string, required bool) error {
 	return nil
 }
 
+// ValidateCreate checks a create request against the rules of its Kind, like handlers.ValidateCreate
+func ValidateCreate(rules validation.Rules, req proto.Message) error {
+	if svcErr := rules.ValidateCreate(validation.ProtoFieldsOf(req)); svcErr != nil {
+		return ServiceErrorToGRPC(svcErr)
+	}
+	return nil
+}
+
+// ValidateUpdate checks the fields an update request writes, see UpdatedFields, against the rules of its
+// Kind, like handlers.ApplyPatch. current is the resource it updates, nil to leave its immutable fields for
+// later.
+func ValidateUpdate(rules validation.Rules, req proto.Message, fields FieldSet, current proto.Message) error {
+	var currentFields validation.Fields
+	if current != nil {
+		currentFields = validation.ProtoFieldsOf(current)
+	}
+	if svcErr := rules.ValidateUpdate(fields.Values(req), currentFields); svcErr != nil {
+		return ServiceErrorToGRPC(svcErr)
+	}
+	return nil
+}
+
+// ValidateResourceVersion checks an Update request's expected resource version, if any,
+// against the version currently stored.
+func ValidateResourceVersion(expected *int64, current int64) error {
+	if expected != nil && *expected != current {
+		return status.Errorf(codes.FailedPrecondition, "resource_version %d does not match current resource version %d", *expected, current)
+	}
+	return nil
+}
+
+// ValidateBatchSize checks that the repeated field of a batch request holds between 1 and services.MaxBatchSize items
+func ValidateBatchSize(name string, n int) error {
+	if n == 0 {
+		return status.Errorf(codes.InvalidArgument, "%s is required", name)
+	}
+	if n > services.MaxBatchSize {
+		return status.Errorf(codes.InvalidArgument, "%s holds %d items, at most %d are allowed", name, n, services.MaxBatchSize)
+	}
+	return nil
+}
+
+// ValidateBatchItem prefixes the validation error of the item at index i of a batch request with its position
+func ValidateBatchItem(name string, i int, err error) error {
+	if err == nil {
+		return nil
+	}
+	st := status.Convert(err)
+	return status.Errorf(st.Code(), "%s[%d]: %s", name, i, st.Message())
+}
+
 func NormalizePagination(page, size int32) (int32, int32) {
 	if page < 1 {
 		page = DefaultPage
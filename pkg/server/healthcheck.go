@@ -7,8 +7,9 @@ import (
 	"net/http"
 
 	health "github.com/docker/go-healthcheck"
-	"github.com/golang/glog"
 	"github.com/gorilla/mux"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 )
 
 var updater = health.NewStatusUpdater()
@@ -49,14 +50,14 @@ func (s HealthCheckServer) Start() {
 			)
 		}
 
-		glog.Infof("Serving HealthCheck with TLS at %s", s.config.BindAddress)
+		logger.Infof("Serving HealthCheck with TLS at %s", s.config.BindAddress)
 		err = s.httpServer.ListenAndServeTLS(s.config.HTTPSCertFile, s.config.HTTPSKeyFile)
 	} else {
-		glog.Infof("Serving HealthCheck without TLS at %s", s.config.BindAddress)
+		logger.Infof("Serving HealthCheck without TLS at %s", s.config.BindAddress)
 		err = s.httpServer.ListenAndServe()
 	}
 	Check(err, "HealthCheck server terminated with errors")
-	glog.Infof("HealthCheck server terminated")
+	logger.Infof("HealthCheck server terminated")
 }
 
 func (s HealthCheckServer) Stop() error {
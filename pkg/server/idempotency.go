@@ -0,0 +1,21 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/handlers"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
+
+// IdempotencyMiddleware returns the handlers.IdempotencyMiddleware of the registered idempotency key service,
+// or a middleware passing requests through when there is none. Routers use it after authentication.
+func IdempotencyMiddleware(envServices ServicesInterface) mux.MiddlewareFunc {
+	if locator, ok := envServices.GetService("IdempotencyKeys").(services.IdempotencyKeyServiceLocator); ok {
+		return handlers.IdempotencyMiddleware(locator())
+	}
+	return func(next http.Handler) http.Handler {
+		return next
+	}
+}
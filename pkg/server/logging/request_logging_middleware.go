@@ -8,6 +8,8 @@ import (
 	"github.com/openshift-online/rh-trex-ai/pkg/trex"
 )
 
+// RequestLoggingMiddleware logs every request and its response through the logger of the service, so they
+// share the format and the fields of the application logs
 func RequestLoggingMiddleware(handler http.Handler) http.Handler {
 	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
 		path := strings.TrimSuffix(request.URL.Path, "/")
@@ -19,18 +21,17 @@ func RequestLoggingMiddleware(handler http.Handler) http.Handler {
 			doLog = false
 		}
 
-		loggingWriter := NewLoggingWriter(writer, request, NewJSONLogFormatter())
+		loggingWriter := NewLoggingWriter(writer, request)
 
 		if doLog {
-			loggingWriter.log(loggingWriter.prepareRequestLog())
+			loggingWriter.logRequest()
 		}
 
 		before := time.Now()
 		handler.ServeHTTP(loggingWriter, request)
-		elapsed := time.Since(before).String()
 
 		if doLog {
-			loggingWriter.log(loggingWriter.prepareResponseLog(elapsed))
+			loggingWriter.logResponse(time.Since(before))
 		}
 	})
 }
@@ -2,18 +2,18 @@ package logging
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 )
 
-func NewLoggingWriter(w http.ResponseWriter, r *http.Request, f LogFormatter) *LoggingWriter {
-	return &LoggingWriter{ResponseWriter: w, request: r, formatter: f}
+func NewLoggingWriter(w http.ResponseWriter, r *http.Request) *LoggingWriter {
+	return &LoggingWriter{ResponseWriter: w, request: r}
 }
 
 type LoggingWriter struct {
 	http.ResponseWriter
 	request        *http.Request
-	formatter      LogFormatter
 	responseStatus int
 	responseBody   []byte
 }
@@ -28,27 +28,32 @@ func (writer *LoggingWriter) WriteHeader(status int) {
 	writer.ResponseWriter.WriteHeader(status)
 }
 
-func (writer *LoggingWriter) log(logMsg string, err error) {
-	log := logger.NewLogger(writer.request.Context())
-	switch err {
-	case nil:
-		log.V(Threshold).Infof(logMsg)
-	default:
-		log.Extra("error", err.Error()).Error("Unable to format request/response for log.")
-	}
+// Unwrap lets http.ResponseController reach the writer of the server, e.g. to flush watches
+func (writer *LoggingWriter) Unwrap() http.ResponseWriter {
+	return writer.ResponseWriter
 }
 
-func (writer *LoggingWriter) prepareRequestLog() (string, error) {
-	return writer.formatter.FormatRequestLog(writer.request)
+// logRequest logs the request, with its headers at verbosity 10
+func (writer *LoggingWriter) logRequest() {
+	log := logger.NewLogger(writer.request.Context()).V(Threshold).
+		Extra("request_method", writer.request.Method).
+		Extra("request_url", writer.request.RequestURI).
+		Extra("request_remote_ip", writer.request.RemoteAddr)
+	if logger.Enabled(10) {
+		log = log.Extra("request_header", writer.request.Header)
+	}
+	log.Info("request")
 }
 
-func (writer *LoggingWriter) prepareResponseLog(elapsed string) (string, error) {
-	info := &ResponseInfo{
-		Header:  writer.ResponseWriter.Header(),
-		Body:    writer.responseBody,
-		Status:  writer.responseStatus,
-		Elapsed: elapsed,
+// logResponse logs the response, with its body at verbosity 10
+func (writer *LoggingWriter) logResponse(elapsed time.Duration) {
+	log := logger.NewLogger(writer.request.Context()).V(Threshold).
+		Extra("request_method", writer.request.Method).
+		Extra("request_url", writer.request.RequestURI).
+		Extra("response_status", writer.responseStatus).
+		Extra("elapsed", elapsed.String())
+	if logger.Enabled(10) {
+		log = log.Extra("response_body", string(writer.responseBody))
 	}
-
-	return writer.formatter.FormatResponseLog(info)
+	log.Info("response")
 }
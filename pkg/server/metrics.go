@@ -120,6 +120,11 @@ func (w *metricsResponseWrapper) WriteHeader(code int) {
 	w.wrapped.WriteHeader(code)
 }
 
+// Unwrap lets http.ResponseController reach the writer of the server, e.g. to flush watches
+func (w *metricsResponseWrapper) Unwrap() http.ResponseWriter {
+	return w.wrapped
+}
+
 var metricsOnce sync.Once
 
 func RegisterMetrics() {
@@ -9,6 +9,7 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
 	"github.com/openshift-online/rh-trex-ai/pkg/handlers"
 	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 )
@@ -20,13 +21,21 @@ type metricsServer struct {
 
 var _ Server = &metricsServer{}
 
-func NewMetricsServer(cfg ServerConfig) Server {
+// NewMetricsServer serves the metrics and the log level without authentication, changing the log level
+// takes an authenticated user allowed to update "loglevel", an admin by default
+func NewMetricsServer(cfg ServerConfig, authMiddleware auth.JWTMiddleware, authzMiddleware auth.AuthorizationMiddleware) Server {
 	mainRouter := mux.NewRouter()
 	mainRouter.NotFoundHandler = http.HandlerFunc(api.SendNotFound)
 
 	prometheusMetricsHandler := handlers.NewPrometheusMetricsHandler()
 	mainRouter.Handle("/metrics", prometheusMetricsHandler.Handler())
 
+	logLevelHandler := handlers.NewLogLevelHandler()
+	mainRouter.HandleFunc("/debug/loglevel", logLevelHandler.Get).Methods(http.MethodGet)
+	mainRouter.Handle("/debug/loglevel", authMiddleware.AuthenticateAccountJWT(
+		authzMiddleware.Authorize(auth.ActionUpdate, "loglevel", logLevelHandler.Set),
+	)).Methods(http.MethodPut, http.MethodPost)
+
 	var mainHandler http.Handler = mainRouter
 
 	s := &metricsServer{config: cfg}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+)
+
+// headerJWTMiddleware authenticates the user named by the Username header
+type headerJWTMiddleware struct{}
+
+func (headerJWTMiddleware) AuthenticateAccountJWT(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username := r.Header.Get("Username"); username != "" {
+			r = r.WithContext(auth.SetUsernameContext(r.Context(), username))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func TestMetricsServerLogLevel(t *testing.T) {
+	RegisterTestingT(t)
+	level := logger.Level()
+	t.Cleanup(func() { logger.SetLevel(level) })
+
+	policy := auth.DefaultPolicy()
+	policy.Bindings = append(policy.Bindings, auth.RoleBinding{Username: "root", Role: auth.RoleAdmin})
+	authzMiddleware := auth.NewAuthzMiddleware(auth.NewPolicyAuthorizer(policy, nil))
+	server := NewMetricsServer(ServerConfig{}, headerJWTMiddleware{}, authzMiddleware).(*metricsServer)
+
+	serve := func(method, path, username string) int {
+		req := httptest.NewRequest(method, path, strings.NewReader(`{"level": "DEBUG"}`))
+		if username != "" {
+			req.Header.Set("Username", username)
+		}
+		w := httptest.NewRecorder()
+		server.httpServer.Handler.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// reading stays open, like the metrics
+	Expect(serve(http.MethodGet, "/metrics", "")).To(Equal(http.StatusOK))
+	Expect(serve(http.MethodGet, "/debug/loglevel", "")).To(Equal(http.StatusOK))
+
+	// changing the log level takes an admin
+	Expect(serve(http.MethodPut, "/debug/loglevel", "")).To(Equal(http.StatusForbidden))
+	Expect(serve(http.MethodPut, "/debug/loglevel", "alice")).To(Equal(http.StatusForbidden))
+	Expect(logger.Level()).To(Equal(level))
+	Expect(serve(http.MethodPost, "/debug/loglevel", "root")).To(Equal(http.StatusOK))
+	Expect(logger.Level()).NotTo(Equal(level))
+}
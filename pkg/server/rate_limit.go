@@ -0,0 +1,140 @@
+package server
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/handlers"
+	"github.com/openshift-online/rh-trex-ai/pkg/ratelimit"
+)
+
+// Headers telling a client its rate limit, after the RateLimit header fields of the IETF httpapi draft. gRPC
+// calls carry them in their header metadata, lower-cased.
+const (
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+	RetryAfterHeader         = "Retry-After"
+)
+
+// RateLimitMiddleware rejects the requests of clients over their rate limit with a 429 and tells every client
+// where it stands in the RateLimit-* headers. Routers use it after authentication, since clients are keyed by
+// their user, with the Kind of their routes. It passes requests through when limiter is nil.
+func RateLimitMiddleware(limiter *ratelimit.Limiter, kind string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		if limiter == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			result := limiter.Allow(r.Context(), ratelimit.Request{
+				Route:    restRoute(r),
+				Kind:     kind,
+				ClientIP: clientIP(limiter, r.RemoteAddr, r.Header.Values),
+			})
+			if result == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			for header, value := range rateLimitHeaders(result) {
+				w.Header().Set(header, value)
+			}
+			if !result.Allowed {
+				handlers.HandleError(r.Context(), w, rateLimitExceeded(result))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// restRoute returns the route template of the request prefixed with its method, the name of its route limit
+func restRoute(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return r.Method + " " + template
+		}
+	}
+	return r.Method + " " + r.URL.Path
+}
+
+// clientIP returns the address of the client of a request from the host:port address of its peer and
+// the values of its headers. When the peer is a trusted proxy of the limiter, it's the last address the
+// proxies forwarded the request for that isn't itself a trusted proxy: the addresses before it are
+// whatever the client claimed.
+func clientIP(limiter *ratelimit.Limiter, address string, values func(header string) []string) string {
+	ip := hostOf(address)
+	if !limiter.TrustsProxy(ip) {
+		return ip
+	}
+	forwarded := forwardedFor(values)
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		ip = hostOf(forwarded[i])
+		if !limiter.TrustsProxy(ip) {
+			return ip
+		}
+	}
+	return ip
+}
+
+// forwardedFor returns the addresses the proxies of a request forwarded it for, the client first, from the
+// X-Forwarded-For header or else from the for parameters of the Forwarded header
+func forwardedFor(values func(header string) []string) []string {
+	var addresses []string
+	for _, value := range values("X-Forwarded-For") {
+		for _, address := range strings.Split(value, ",") {
+			addresses = append(addresses, strings.TrimSpace(address))
+		}
+	}
+	if len(addresses) > 0 {
+		return addresses
+	}
+	for _, value := range values("Forwarded") {
+		for _, element := range strings.Split(value, ",") {
+			for _, pair := range strings.Split(element, ";") {
+				key, address, _ := strings.Cut(strings.TrimSpace(pair), "=")
+				if strings.EqualFold(key, "for") {
+					addresses = append(addresses, strings.Trim(address, `"`))
+				}
+			}
+		}
+	}
+	return addresses
+}
+
+// hostOf returns the host of a host:port address, or the address itself if it has no port
+func hostOf(address string) string {
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		return host
+	}
+	return strings.Trim(address, "[]")
+}
+
+// rateLimitHeaders returns the headers of the rate limit of a request, the delays in whole seconds
+func rateLimitHeaders(result *ratelimit.Result) map[string]string {
+	headers := map[string]string{
+		RateLimitLimitHeader:     strconv.Itoa(result.Limit.Burst),
+		RateLimitRemainingHeader: strconv.Itoa(result.Remaining),
+		RateLimitResetHeader:     wholeSeconds(result.Reset),
+	}
+	if !result.Allowed {
+		headers[RetryAfterHeader] = wholeSeconds(result.RetryAfter)
+	}
+	return headers
+}
+
+func rateLimitExceeded(result *ratelimit.Result) *errors.ServiceError {
+	return errors.TooManyRequests("Rate limit of %g requests per second exceeded, retry in %s seconds",
+		result.Limit.Rate, wholeSeconds(result.RetryAfter))
+}
+
+func wholeSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,163 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/config"
+	"github.com/openshift-online/rh-trex-ai/pkg/ratelimit"
+)
+
+func newTestLimiter(routes map[string]string) *ratelimit.Limiter {
+	cfg := config.NewRateLimitConfig()
+	cfg.Enabled = true
+	cfg.Rate, cfg.Burst = 1, 2
+	cfg.Routes = routes
+	limiter, err := ratelimit.New(cfg, nil)
+	Expect(err).NotTo(HaveOccurred())
+	return limiter
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	router := mux.NewRouter()
+	router.Use(RateLimitMiddleware(newTestLimiter(map[string]string{"POST /dinosaurs": "1:1"}), "dinosaurs"))
+	router.HandleFunc("/dinosaurs", func(w http.ResponseWriter, r *http.Request) {}).Methods(http.MethodGet, http.MethodPost)
+
+	send := func(method, username string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, "/dinosaurs", nil)
+		r = r.WithContext(auth.SetUsernameContext(r.Context(), username))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	w := send(http.MethodGet, "alice")
+	Expect(w.Code).To(Equal(http.StatusOK))
+	Expect(w.Header().Get(RateLimitLimitHeader)).To(Equal("2"))
+	Expect(w.Header().Get(RateLimitRemainingHeader)).To(Equal("1"))
+	Expect(w.Header().Get(RateLimitResetHeader)).To(Equal("1"))
+	Expect(send(http.MethodGet, "alice").Code).To(Equal(http.StatusOK))
+
+	w = send(http.MethodGet, "alice")
+	Expect(w.Code).To(Equal(http.StatusTooManyRequests))
+	Expect(w.Header().Get(RateLimitRemainingHeader)).To(Equal("0"))
+	Expect(w.Header().Get(RetryAfterHeader)).To(Equal("1"))
+	var body openapi.Error
+	Expect(json.Unmarshal(w.Body.Bytes(), &body)).To(Succeed())
+	Expect(body.GetCode()).To(HaveSuffix("-31"))
+
+	// the route limit has buckets of its own, and so has every user
+	w = send(http.MethodPost, "alice")
+	Expect(w.Code).To(Equal(http.StatusOK))
+	Expect(w.Header().Get(RateLimitLimitHeader)).To(Equal("1"))
+	Expect(send(http.MethodPost, "alice").Code).To(Equal(http.StatusTooManyRequests))
+	Expect(send(http.MethodGet, "bob").Code).To(Equal(http.StatusOK))
+
+	// without a limiter requests go through untouched
+	router = mux.NewRouter()
+	router.Use(RateLimitMiddleware(nil, "dinosaurs"))
+	router.HandleFunc("/dinosaurs", func(w http.ResponseWriter, r *http.Request) {})
+	w = send(http.MethodGet, "alice")
+	Expect(w.Code).To(Equal(http.StatusOK))
+	Expect(w.Header().Get(RateLimitLimitHeader)).To(BeEmpty())
+}
+
+func TestRateLimitClientIP(t *testing.T) {
+	RegisterTestingT(t)
+
+	cfg := config.NewRateLimitConfig()
+	cfg.Enabled = true
+	cfg.TrustedProxies = []string{"192.0.2.0/24"}
+	limiter, err := ratelimit.New(cfg, nil)
+	Expect(err).NotTo(HaveOccurred())
+
+	clientOf := func(limiter *ratelimit.Limiter, remoteAddr string, headers map[string]string) string {
+		r := httptest.NewRequest(http.MethodGet, "/dinosaurs", nil)
+		r.RemoteAddr = remoteAddr
+		for header, value := range headers {
+			r.Header.Set(header, value)
+		}
+		return clientIP(limiter, r.RemoteAddr, r.Header.Values)
+	}
+
+	// only trusted proxies name the client
+	forwarded := map[string]string{"X-Forwarded-For": "198.51.100.7"}
+	Expect(clientOf(limiter, "203.0.113.9:4242", forwarded)).To(Equal("203.0.113.9"))
+	Expect(clientOf(nil, "192.0.2.1:4242", forwarded)).To(Equal("192.0.2.1"))
+	Expect(clientOf(limiter, "192.0.2.1:4242", forwarded)).To(Equal("198.51.100.7"))
+	Expect(clientOf(limiter, "192.0.2.1:4242", nil)).To(Equal("192.0.2.1"))
+
+	// the addresses a client claims before those its proxies added don't count
+	Expect(clientOf(limiter, "192.0.2.1:4242", map[string]string{"X-Forwarded-For": "6.6.6.6, 198.51.100.7, 192.0.2.2"})).
+		To(Equal("198.51.100.7"))
+	Expect(clientOf(limiter, "192.0.2.1:4242", map[string]string{"Forwarded": `for=6.6.6.6, for="[2001:db8::1]:4711";proto=https`})).
+		To(Equal("2001:db8::1"))
+
+	// gRPC calls carry the headers in their metadata
+	md := metadata.Pairs("x-forwarded-for", "198.51.100.7")
+	Expect(clientIP(limiter, "192.0.2.1:4242", md.Get)).To(Equal("198.51.100.7"))
+}
+
+type headerRecordingStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	header metadata.MD
+}
+
+func (s *headerRecordingStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *headerRecordingStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func TestRateLimitInterceptors(t *testing.T) {
+	RegisterTestingT(t)
+
+	limiter := newTestLimiter(nil)
+	ctx := auth.SetUsernameContext(context.Background(), "alice")
+	handled := 0
+
+	unary := RateLimitUnaryInterceptor(limiter)
+	info := &grpc.UnaryServerInfo{FullMethod: "/rh_trex.v1.DinosaurService/GetDinosaur"}
+	call := func() error {
+		_, err := unary(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			handled++
+			return nil, nil
+		})
+		return err
+	}
+	Expect(call()).To(Succeed())
+	Expect(call()).To(Succeed())
+	err := call()
+	Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+	Expect(handled).To(Equal(2))
+
+	// the calls without a limit of their own share the default bucket of the caller
+	stream := &headerRecordingStream{ctx: ctx}
+	err = RateLimitStreamInterceptor(limiter)(nil, stream, &grpc.StreamServerInfo{FullMethod: "/rh_trex.v1.DinosaurService/WatchDinosaurs"},
+		func(srv interface{}, ss grpc.ServerStream) error {
+			handled++
+			return nil
+		})
+	Expect(status.Code(err)).To(Equal(codes.ResourceExhausted))
+	Expect(handled).To(Equal(2))
+	Expect(stream.header.Get("ratelimit-limit")).To(Equal([]string{"2"}))
+	Expect(stream.header.Get("retry-after")).To(Equal([]string{"1"}))
+}
@@ -23,23 +23,11 @@ func BuildDefaultRoutes(env *environments.Env, specData []byte) *mux.Router {
 
 	metadataHandler := handlers.NewMetadataHandler()
 
-	var authMiddleware auth.JWTMiddleware
-	authMiddleware = &auth.MiddlewareMock{}
-	if env.Config.Server.EnableJWT {
-		var err error
-		authMiddleware, err = auth.NewAuthMiddleware()
-		if err != nil {
-			Check(err, "Unable to create auth middleware")
-		}
-	}
-	if authMiddleware == nil {
-		Check(fmt.Errorf("auth middleware is nil"), "Unable to create auth middleware: missing middleware")
-	}
-
-	authzMiddleware := auth.NewAuthzMiddlewareMock() //nolint:staticcheck // placeholder for real authz middleware
+	authMiddleware, authzMiddleware := newAuthMiddlewares(env)
 
 	mainRouter := mux.NewRouter()
 	mainRouter.NotFoundHandler = http.HandlerFunc(api.SendNotFound)
+	mainRouter.Use(TracingMiddleware)
 	mainRouter.Use(logger.OperationIDMiddleware)
 	mainRouter.Use(logging.RequestLoggingMiddleware)
 
@@ -57,14 +45,36 @@ func BuildDefaultRoutes(env *environments.Env, specData []byte) *mux.Router {
 	apiV1Router.HandleFunc("/openapi", openapiHandler.GetOpenAPI).Methods(http.MethodGet)
 
 	apiV1Router.Use(MetricsMiddleware)
-	apiV1Router.Use(
+	apiV1Router.Use(SkipForWatch(
 		func(next http.Handler) http.Handler {
 			return db.TransactionMiddleware(next, env.Database.SessionFactory)
 		},
-	)
-	apiV1Router.Use(gorillahandlers.CompressHandler)
+	))
+	apiV1Router.Use(SkipForWatch(gorillahandlers.CompressHandler))
 
 	LoadDiscoveredRoutes(apiV1Router, services, authMiddleware, authzMiddleware)
 
 	return mainRouter
 }
+
+// newAuthMiddlewares returns the middlewares authenticating and authorizing the requests of the servers
+func newAuthMiddlewares(env *environments.Env) (auth.JWTMiddleware, auth.AuthorizationMiddleware) {
+	var authMiddleware auth.JWTMiddleware
+	authMiddleware = &auth.MiddlewareMock{}
+	if env.Config.Server.EnableJWT {
+		var err error
+		authMiddleware, err = auth.NewAuthMiddleware()
+		if err != nil {
+			Check(err, "Unable to create auth middleware")
+		}
+	}
+	if authMiddleware == nil {
+		Check(fmt.Errorf("auth middleware is nil"), "Unable to create auth middleware: missing middleware")
+	}
+
+	authorizer, err := NewAuthorizer(env)
+	if err != nil {
+		Check(err, "Unable to create authorizer")
+	}
+	return authMiddleware, auth.NewAuthzMiddleware(authorizer)
+}
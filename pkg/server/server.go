@@ -6,7 +6,7 @@ import (
 	"os"
 	"strings"
 
-	"github.com/golang/glog"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 )
 
 type Server interface {
@@ -32,7 +32,7 @@ func RemoveTrailingSlash(next http.Handler) http.Handler {
 
 func Check(err error, msg string) {
 	if err != nil && err != http.ErrServerClosed {
-		glog.Errorf("%s: %s", msg, err)
+		logger.Errorf("%s: %s", msg, err)
 		os.Exit(1)
 	}
 }
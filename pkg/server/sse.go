@@ -0,0 +1,191 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	e "errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+)
+
+// sseKeepAliveInterval is how often an idle Server-Sent Events watch sends a comment, so proxies don't
+// close the connection
+var sseKeepAliveInterval = 15 * time.Second
+
+// SSE event names for the frames of a watch that don't carry a resource event
+const (
+	SSEResyncEvent = "resync"
+	SSEErrorEvent  = "error"
+)
+
+// WatchEvent is the data of a Server-Sent Events frame of a REST watch. Object is the presented resource,
+// it's left out for deletes. ChangedFields is the field mask of an update, when it's known. EventSeq
+// numbers the event, a watch can resume from it like from the event id.
+type WatchEvent struct {
+	Type          api.EventType `json:"type"`
+	EventID       string        `json:"event_id"`
+	EventSeq      int64         `json:"event_seq"`
+	ResourceID    string        `json:"resource_id"`
+	ChangedFields []string      `json:"changed_fields,omitempty"`
+	Object        interface{}   `json:"object,omitempty"`
+}
+
+// ResyncEvent is the data of the resync frame that ends a REST watch which can't go on without the
+// client missing events. ResumeFrom is empty if the client has to list the resources again.
+type ResyncEvent struct {
+	ResumeFrom string `json:"resume_from"`
+	EventSeq   int64  `json:"event_seq,omitempty"`
+}
+
+// IsWatchRequest tells whether r is a REST watch, GET /{kind}?watch=true. Watches stream for as long
+// as the client stays connected, so they run without the request transaction and compression.
+func IsWatchRequest(r *http.Request) bool {
+	return r.Method == http.MethodGet && r.URL.Query().Get("watch") == "true"
+}
+
+// RegisterWatch routes the REST watches of the collection of router to handler, authorized for the watch
+// action on resource. It has to come before the List route of the collection, which matches them as well.
+func RegisterWatch(router *mux.Router, authzMiddleware auth.AuthorizationMiddleware, resource string, handler http.HandlerFunc) {
+	router.HandleFunc("", authzMiddleware.Authorize(auth.ActionWatch, resource, handler)).
+		MatcherFunc(func(r *http.Request, _ *mux.RouteMatch) bool { return IsWatchRequest(r) })
+}
+
+// SkipForWatch applies middleware to every request but REST watches
+func SkipForWatch(middleware func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		wrapped := middleware(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if IsWatchRequest(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			wrapped.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ServeSSE runs the Watch of source for a REST watch, writing every event as a Server-Sent Events frame
+// until the client disconnects. The watch resumes after the Last-Event-ID header or else the
+// event_seq query parameter, if set. present returns
+// the object of an event, or false to skip the event, e.g. when the resource doesn't match the filter of
+// the watch. A watch that has to resync ends with a resync frame, other failures with an error frame.
+func (b *EventBroker) ServeSSE(w http.ResponseWriter, r *http.Request, source string, present func(ctx context.Context, evt *BrokerEvent) (interface{}, bool, *errors.ServiceError)) {
+	ctx := r.Context()
+	rc := http.NewResponseController(w)
+	// the stream outlives the write timeout of the server
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		logger.V(4).Infof("Unable to clear the write deadline of the %s watch: %s", source, err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	var mu sync.Mutex
+	write := func(frame string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if _, err := fmt.Fprint(w, frame); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+	if err := write(": watching " + source + "\n\n"); err != nil {
+		return
+	}
+
+	keepAliveCtx, stopKeepAlive := context.WithCancel(ctx)
+	defer stopKeepAlive()
+	go func() {
+		ticker := time.NewTicker(sseKeepAliveInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-keepAliveCtx.Done():
+				return
+			case <-ticker.C:
+				if err := write(": keepalive\n\n"); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	from, err := sseResumePoint(r)
+	if err == nil {
+		err = b.Watch(ctx, source, from, func(evt *BrokerEvent) error {
+			object, ok, svcErr := present(ctx, evt)
+			if svcErr != nil {
+				return svcErr
+			}
+			if !ok {
+				return nil
+			}
+			return write(sseFrame(evt.EventID, string(evt.EventType), &WatchEvent{
+				Type:          evt.EventType,
+				EventID:       evt.EventID,
+				EventSeq:      evt.Seq,
+				ResourceID:    evt.SourceID,
+				ChangedFields: evt.ChangedFields,
+				Object:        object,
+			}))
+		})
+	}
+
+	var resyncErr *ResyncRequiredError
+	var svcErr *errors.ServiceError
+	switch {
+	case err == nil:
+	case e.As(err, &resyncErr):
+		logger.V(4).Infof("%s watch: resync required, resume from %q", source, resyncErr.ResumeFrom)
+		_ = write(sseFrame(resyncErr.ResumeFrom, SSEResyncEvent, &ResyncEvent{ResumeFrom: resyncErr.ResumeFrom, EventSeq: resyncErr.EventSeq}))
+	case e.As(err, &svcErr):
+		_ = write(sseFrame("", SSEErrorEvent, svcErr.AsOpenapiError(logger.GetOperationID(ctx))))
+	case e.Is(err, ErrBrokerClosed):
+		closedErr := errors.GeneralError("Event broker closed")
+		_ = write(sseFrame("", SSEErrorEvent, closedErr.AsOpenapiError(logger.GetOperationID(ctx))))
+	default:
+		// writing to the client failed, there's no one left to tell
+		logger.V(4).Infof("%s watch ended: %s", source, err)
+	}
+}
+
+// sseResumePoint returns the event a REST watch resumes after
+func sseResumePoint(r *http.Request) (ResumePoint, error) {
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		return ResumePoint{EventID: lastEventID}, nil
+	}
+	eventSeq := r.URL.Query().Get("event_seq")
+	if eventSeq == "" {
+		return ResumePoint{}, nil
+	}
+	seq, err := strconv.ParseInt(eventSeq, 10, 64)
+	if err != nil || seq <= 0 {
+		return ResumePoint{}, errors.BadRequest("event_seq %q is not the sequence number of a watch event", eventSeq)
+	}
+	return ResumePoint{EventSeq: seq}, nil
+}
+
+// sseFrame formats a Server-Sent Events frame with the JSON of data. The id field is left out if id is empty.
+func sseFrame(id, event string, data interface{}) string {
+	body, err := json.Marshal(data)
+	if err != nil {
+		body = []byte("{}")
+	}
+	frame := ""
+	if id != "" {
+		frame += "id: " + id + "\n"
+	}
+	return frame + "event: " + event + "\ndata: " + string(body) + "\n\n"
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao/mocks"
+	dbmocks "github.com/openshift-online/rh-trex-ai/pkg/db/mocks"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/eventbus"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
+
+func TestEventBrokerServeSSE(t *testing.T) {
+	RegisterTestingT(t)
+
+	eventService := services.NewEventService(dbmocks.NewMockAdvisoryLockFactory(), mocks.NewEventDao(), nil)
+	broker := NewEventBroker(10, eventService)
+	defer broker.Close()
+
+	create := func(sourceID string) string {
+		event := &api.Event{Meta: api.Meta{ID: api.NewID()}, Source: "Dinosaurs", SourceID: sourceID, EventType: api.CreateEventType}
+		_, err := eventService.Create(context.Background(), event)
+		Expect(err).To(BeNil())
+		return event.ID
+	}
+	resumeFrom := create("a")
+	create("skipped")
+	missed := create("b")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		broker.ServeSSE(w, r, "Dinosaurs", func(ctx context.Context, evt *BrokerEvent) (interface{}, bool, *errors.ServiceError) {
+			if evt.SourceID == "skipped" {
+				return nil, false, nil
+			}
+			return map[string]string{"id": evt.SourceID}, true, nil
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	watch := func(lastEventID string, query string) (*http.Response, *bufio.Reader) {
+		req, err := http.NewRequest(http.MethodGet, server.URL+query, nil)
+		Expect(err).To(BeNil())
+		req.Header.Set("Last-Event-ID", lastEventID)
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).To(BeNil())
+		// closed before the server, which waits for the watch to end
+		t.Cleanup(func() { resp.Body.Close() })
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Header.Get("Content-Type")).To(Equal("text/event-stream"))
+		return resp, bufio.NewReader(resp.Body)
+	}
+	// readFrame returns the next frame that isn't a comment
+	readFrame := func(reader *bufio.Reader) string {
+		frame := ""
+		for {
+			line, err := reader.ReadString('\n')
+			Expect(err).To(BeNil())
+			switch {
+			case strings.HasPrefix(line, ":"):
+			case line == "\n":
+				if frame != "" {
+					return frame
+				}
+			default:
+				frame += line
+			}
+		}
+	}
+
+	// the events after Last-Event-ID are replayed, those present skips are left out
+	resp, reader := watch(resumeFrom, "")
+	Expect(readFrame(reader)).To(Equal("id: " + missed + "\nevent: Create\n" +
+		`data: {"type":"Create","event_id":"` + missed + `","event_seq":3,"resource_id":"b","object":{"id":"b"}}` + "\n"))
+
+	live := create("c")
+	broker.Publish(&eventbus.Message{ID: live, Source: "Dinosaurs", SourceID: "c", EventType: api.CreateEventType})
+	Expect(readFrame(reader)).To(HavePrefix("id: " + live + "\nevent: Create\n"))
+	Expect(resp.Body.Close()).To(Succeed())
+
+	// the resource version of an event is a resume point as well
+	resp, reader = watch("", "?event_seq=2")
+	Expect(readFrame(reader)).To(HavePrefix("id: " + missed + "\nevent: Create\n"))
+	Expect(resp.Body.Close()).To(Succeed())
+
+	_, reader = watch("", "?event_seq=latest")
+	Expect(readFrame(reader)).To(HavePrefix("event: error\n"))
+
+	// an event that's no longer kept ends the watch with a resync
+	_, reader = watch("gone", "")
+	Expect(readFrame(reader)).To(Equal("event: resync\ndata: {\"resume_from\":\"\"}\n"))
+	_, err := reader.ReadString('\n')
+	Expect(err).ToNot(BeNil())
+}
+
+func TestIsWatchRequest(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(IsWatchRequest(httptest.NewRequest(http.MethodGet, "/api/rh-trex/v1/dinosaurs?watch=true", nil))).To(BeTrue())
+	Expect(IsWatchRequest(httptest.NewRequest(http.MethodGet, "/api/rh-trex/v1/dinosaurs", nil))).To(BeFalse())
+	Expect(IsWatchRequest(httptest.NewRequest(http.MethodPost, "/api/rh-trex/v1/dinosaurs?watch=true", nil))).To(BeFalse())
+}
+
+func TestRegisterWatch(t *testing.T) {
+	RegisterTestingT(t)
+
+	served := ""
+	handler := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) { served = name }
+	}
+	router := mux.NewRouter().PathPrefix("/dinosaurs").Subrouter()
+	RegisterWatch(router, auth.NewAuthzMiddlewareMock(), "dinosaurs", handler("watch"))
+	router.HandleFunc("", handler("list")).Methods(http.MethodGet)
+	router.HandleFunc("", handler("create")).Methods(http.MethodPost)
+
+	for target, name := range map[string]string{
+		"GET /dinosaurs?watch=true":  "watch",
+		"GET /dinosaurs":             "list",
+		"GET /dinosaurs?watch=false": "list",
+		"POST /dinosaurs?watch=true": "create",
+	} {
+		method, url, _ := strings.Cut(target, " ")
+		served = ""
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, url, nil))
+		Expect(served).To(Equal(name), target)
+	}
+}
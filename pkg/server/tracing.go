@@ -0,0 +1,50 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/tracing"
+)
+
+// TracingMiddleware starts a server span for every request of a mux route, named after its method and
+// route template. The span continues the trace of the W3C trace context headers of the request.
+func TracingMiddleware(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+		name := r.Method
+		opts := []trace.SpanStartOption{
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPRequestMethodKey.String(r.Method), semconv.URLPath(r.URL.Path)),
+		}
+		if route := mux.CurrentRoute(r); route != nil {
+			if template, err := route.GetPathTemplate(); err == nil {
+				name += " " + template
+				opts = append(opts, trace.WithAttributes(semconv.HTTPRoute(template)))
+			}
+		}
+
+		ctx, span := tracing.Tracer().Start(ctx, name, opts...)
+		defer span.End()
+
+		wrapper := &metricsResponseWrapper{wrapped: w}
+		handler.ServeHTTP(wrapper, r.WithContext(ctx))
+
+		code := wrapper.code
+		if code == 0 {
+			code = http.StatusOK
+		}
+		span.SetAttributes(semconv.HTTPResponseStatusCode(code))
+		// client errors are the caller's, only server errors fail the span
+		if code >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(code))
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/config"
+	"github.com/openshift-online/rh-trex-ai/pkg/tracing"
+)
+
+const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestTracingMiddleware(t *testing.T) {
+	RegisterTestingT(t)
+
+	provider, err := tracing.New(&config.TracingConfig{Exporter: tracing.MemoryExporter, SampleRatio: 1})
+	Expect(err).NotTo(HaveOccurred())
+	defer provider.Shutdown(context.Background())
+
+	router := mux.NewRouter()
+	router.Use(TracingMiddleware)
+	router.HandleFunc("/dinosaurs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		Expect(trace.SpanContextFromContext(r.Context()).IsValid()).To(BeTrue())
+		if mux.Vars(r)["id"] == "broken" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}).Methods(http.MethodGet)
+
+	req := httptest.NewRequest(http.MethodGet, "/dinosaurs/1", nil)
+	req.Header.Set("traceparent", traceParent)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/dinosaurs/broken", nil))
+
+	spans := provider.Spans()
+	Expect(spans).To(HaveLen(2))
+	Expect(spans[0].Name).To(Equal("GET /dinosaurs/{id}"))
+	Expect(spans[0].SpanKind).To(Equal(trace.SpanKindServer))
+	Expect(spans[0].Parent.TraceID().String()).To(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+	Expect(spans[0].Attributes).To(ContainElement(attribute.Int("http.response.status_code", http.StatusOK)))
+	Expect(spans[0].Status.Code).To(Equal(otelcodes.Unset))
+	Expect(spans[1].Parent.IsValid()).To(BeFalse())
+	Expect(spans[1].Status.Code).To(Equal(otelcodes.Error))
+}
+
+func TestTracingUnaryInterceptor(t *testing.T) {
+	RegisterTestingT(t)
+
+	provider, err := tracing.New(&config.TracingConfig{Exporter: tracing.MemoryExporter, SampleRatio: 1})
+	Expect(err).NotTo(HaveOccurred())
+	defer provider.Shutdown(context.Background())
+
+	interceptor := TracingUnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/rh_trex.v1.DinosaurService/GetDinosaur"}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", traceParent))
+
+	_, err = interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	})
+	Expect(status.Code(err)).To(Equal(codes.NotFound))
+	_, err = interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.Internal, "boom")
+	})
+	Expect(status.Code(err)).To(Equal(codes.Internal))
+
+	spans := provider.Spans()
+	Expect(spans).To(HaveLen(2))
+	Expect(spans[0].Name).To(Equal("rh_trex.v1.DinosaurService/GetDinosaur"))
+	Expect(spans[0].Parent.TraceID().String()).To(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+	Expect(spans[0].Attributes).To(ContainElements(
+		attribute.String("rpc.service", "rh_trex.v1.DinosaurService"),
+		attribute.String("rpc.method", "GetDinosaur"),
+		attribute.Int64("rpc.grpc.status_code", int64(codes.NotFound)),
+	))
+	// a missing resource is the caller's mistake, an internal error is the server's
+	Expect(spans[0].Status.Code).To(Equal(otelcodes.Unset))
+	Expect(spans[1].Status.Code).To(Equal(otelcodes.Error))
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"context"
+	e "errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/server/grpcutil"
+)
+
+// watchReplayPageSize is how many missed events a resumed watch loads from the events table at once
+const watchReplayPageSize = 500
+
+// ResumePoint is the event a watch resumes after, by its id or by its sequence number, the event_seq the
+// watch events carry. The zero ResumePoint starts the watch at the live events.
+type ResumePoint struct {
+	EventID  string
+	EventSeq int64
+}
+
+// ResyncRequiredError ends a watch that can't go on without the client missing events: either the
+// subscriber fell behind the live events, or the event to resume from is no longer kept.
+type ResyncRequiredError struct {
+	// ResumeFrom and EventSeq are the last event sent, to resume the watch from. They are empty if the
+	// watch can't be resumed and the client has to list the resources again.
+	ResumeFrom string
+	EventSeq   int64
+}
+
+func (e *ResyncRequiredError) Error() string {
+	if e.ResumeFrom == "" {
+		return "watch can't be resumed, resync required"
+	}
+	return "watch fell behind, resume from event " + e.ResumeFrom
+}
+
+// Watch passes the events of source to send until ctx ends: first the events that followed from, if set,
+// from the events table, then the live events. It fails with a ResyncRequiredError when the watch falls
+// behind or from is no longer kept, and with the first error of send.
+//
+// The events are replayed in the order of their sequence numbers. A transaction that records an event and
+// commits after a later numbered one is sent live to the watches subscribed by then; a client resuming
+// later from the later event doesn't get it replayed.
+//
+// The compaction controller removes events too: resuming from an Update event it collapsed into a later one of
+// the same resource fails with a ResyncRequiredError, like resuming from an event past the retention.
+func (b *EventBroker) Watch(ctx context.Context, source string, from ResumePoint, send func(*BrokerEvent) error) error {
+	// subscribe before replaying, so no event is missed in between
+	sub, err := b.Subscribe(ctx, source)
+	if err != nil {
+		return err
+	}
+	defer b.Unsubscribe(sub.ID)
+
+	last := &ResyncRequiredError{}
+	replayed := map[string]bool{}
+	if from != (ResumePoint{}) {
+		after, svcErr := b.resumePoint(ctx, from)
+		if svcErr != nil {
+			if svcErr.Is404() {
+				return &ResyncRequiredError{}
+			}
+			return svcErr
+		}
+		last.ResumeFrom, last.EventSeq = after.ID, after.Seq
+		for {
+			events, svcErr := b.events.FindBySourceAfter(ctx, source, last.EventSeq, watchReplayPageSize)
+			if svcErr != nil {
+				return svcErr
+			}
+			for _, event := range events {
+				if err := send(newBrokerEvent(event)); err != nil {
+					return err
+				}
+				replayed[event.ID] = true
+				last.ResumeFrom, last.EventSeq = event.ID, event.Seq
+			}
+			if len(events) < watchReplayPageSize {
+				break
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case evt, ok := <-sub.Events:
+			if !ok {
+				if ctx.Err() != nil {
+					return nil
+				}
+				if sub.Lagged() {
+					return last
+				}
+				return ErrBrokerClosed
+			}
+			if replayed[evt.EventID] {
+				continue
+			}
+			if err := send(evt); err != nil {
+				return err
+			}
+			last.ResumeFrom, last.EventSeq = evt.EventID, evt.Seq
+		}
+	}
+}
+
+// resumePoint returns the event of from
+func (b *EventBroker) resumePoint(ctx context.Context, from ResumePoint) (*api.Event, *errors.ServiceError) {
+	if from.EventID != "" {
+		return b.events.Get(ctx, from.EventID)
+	}
+	return b.events.GetBySeq(ctx, from.EventSeq)
+}
+
+// ServeWatch runs the Watch of source for a gRPC stream. send is called with every event, and resync once
+// with the event to resume from when the watch has to end with a resync; other errors are returned as
+// gRPC status errors.
+func (b *EventBroker) ServeWatch(ctx context.Context, source string, from ResumePoint, send func(*BrokerEvent) error, resync func(resumeFrom string, eventSeq int64) error) error {
+	err := b.Watch(ctx, source, from, send)
+
+	var resyncErr *ResyncRequiredError
+	var svcErr *errors.ServiceError
+	switch {
+	case err == nil:
+		return nil
+	case e.As(err, &resyncErr):
+		return resync(resyncErr.ResumeFrom, resyncErr.EventSeq)
+	case e.Is(err, ErrBrokerClosed):
+		return status.Error(codes.Unavailable, "event broker closed")
+	case e.As(err, &svcErr):
+		return grpcutil.ServiceErrorToGRPC(svcErr)
+	default:
+		return err
+	}
+}
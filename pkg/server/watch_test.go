@@ -0,0 +1,137 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao/mocks"
+	dbmocks "github.com/openshift-online/rh-trex-ai/pkg/db/mocks"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/eventbus"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
+
+func TestEventBrokerWatch(t *testing.T) {
+	RegisterTestingT(t)
+
+	eventService := services.NewEventService(dbmocks.NewMockAdvisoryLockFactory(), mocks.NewEventDao(), nil)
+	broker := NewEventBroker(1, eventService)
+	defer broker.Close()
+
+	create := func(source string) string {
+		event := &api.Event{Meta: api.Meta{ID: api.NewID()}, Source: source, EventType: api.CreateEventType}
+		_, err := eventService.Create(context.Background(), event)
+		Expect(err).To(BeNil())
+		return event.ID
+	}
+	resumeFrom := create("Dinosaurs")
+	create("Fossils")
+	missed := create("Dinosaurs")
+
+	sent := make(chan string, 10)
+	release := make(chan struct{}, 10)
+	done := make(chan error)
+	go func() {
+		done <- broker.Watch(context.Background(), "Dinosaurs", ResumePoint{EventID: resumeFrom}, func(evt *BrokerEvent) error {
+			sent <- evt.EventID
+			<-release
+			return nil
+		})
+	}()
+
+	// the missed event is replayed, the watch is subscribed by then
+	Expect(<-sent).To(Equal(missed))
+
+	// with the send of the missed event pending, the second live event overflows the buffer
+	// messages of replicas that only announce the event id are completed from the events table
+	live := create("Dinosaurs")
+	broker.Publish(&eventbus.Message{ID: live})
+	broker.Publish(&eventbus.Message{ID: create("Fossils")})
+	overflowed := create("Dinosaurs")
+	broker.Publish(&eventbus.Message{ID: overflowed})
+	release <- struct{}{}
+	release <- struct{}{}
+
+	// the event buffered before the gap is still sent, then the watch has to resync
+	Expect(<-sent).To(Equal(live))
+	var err error
+	Eventually(done).Should(Receive(&err))
+	Expect(err).To(Equal(&ResyncRequiredError{ResumeFrom: live, EventSeq: 4}))
+
+	// resuming from the last event sent replays the overflowed one, by event id or resource version
+	for _, from := range []ResumePoint{{EventID: live}, {EventSeq: 4}} {
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			done <- broker.Watch(ctx, "Dinosaurs", from, func(evt *BrokerEvent) error {
+				sent <- evt.EventID
+				return nil
+			})
+		}()
+		Expect(<-sent).To(Equal(overflowed))
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+	}
+
+	// an event that's no longer kept can't be resumed from
+	err = broker.Watch(context.Background(), "Dinosaurs", ResumePoint{EventID: "gone"}, func(evt *BrokerEvent) error { return nil })
+	Expect(err).To(Equal(&ResyncRequiredError{}))
+	err = broker.Watch(context.Background(), "Dinosaurs", ResumePoint{EventSeq: 100}, func(evt *BrokerEvent) error { return nil })
+	Expect(err).To(Equal(&ResyncRequiredError{}))
+}
+
+func TestBrokerEventVisibleTo(t *testing.T) {
+	RegisterTestingT(t)
+
+	evt := &BrokerEvent{Source: "Dinosaurs", SourceID: "rex", EventType: api.DeleteEventType, OrganizationID: "acme"}
+	Expect(evt.VisibleTo(context.Background())).To(BeTrue())
+	Expect(evt.VisibleTo(auth.SetTenantContext(context.Background(), auth.Tenant{OrganizationID: "acme"}))).To(BeTrue())
+	Expect(evt.VisibleTo(auth.SetTenantContext(context.Background(), auth.Tenant{OrganizationID: "initech"}))).To(BeFalse())
+	Expect(evt.VisibleTo(auth.SetTenantContext(context.Background(), auth.Tenant{OrganizationID: "initech", AnyOrganization: true}))).To(BeTrue())
+
+	// the event carries the organization of the request that recorded it
+	eventService := services.NewEventService(dbmocks.NewMockAdvisoryLockFactory(), mocks.NewEventDao(), nil)
+	event, svcErr := eventService.Create(auth.SetTenantContext(context.Background(), auth.Tenant{OrganizationID: "acme"}),
+		&api.Event{Meta: api.Meta{ID: api.NewID()}, Source: "Dinosaurs", SourceID: "rex", EventType: api.DeleteEventType})
+	Expect(svcErr).To(BeNil())
+	Expect(newBrokerEvent(event).OrganizationID).To(Equal("acme"))
+}
+
+func TestBrokerEventResource(t *testing.T) {
+	RegisterTestingT(t)
+
+	evt := &BrokerEvent{Source: "Dinosaurs", SourceID: "rex", EventType: api.UpdateEventType, OrganizationID: "acme"}
+	ctx, cancel := context.WithCancel(auth.SetTenantContext(context.Background(), auth.Tenant{OrganizationID: "acme"}))
+	var loads atomic.Int32
+	var loadCtx context.Context
+	load := func(ctx context.Context) (interface{}, *errors.ServiceError) {
+		loads.Add(1)
+		loadCtx = ctx
+		cancel()
+		return "rex", nil
+	}
+
+	// the watchers of an event load its resource once
+	resources := make([]interface{}, 10)
+	var wg sync.WaitGroup
+	for i := range resources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resources[i], _ = evt.Resource(ctx, load)
+		}()
+	}
+	wg.Wait()
+	Expect(loads.Load()).To(Equal(int32(1)))
+	Expect(resources).To(HaveEach("rex"))
+
+	// the resource is shared by the watchers of every organization and outlives the one that loaded it
+	tenant, _ := auth.GetTenantFromContext(loadCtx)
+	Expect(tenant.AnyOrganization).To(BeTrue())
+	Expect(loadCtx.Err()).To(BeNil())
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+)
+
+type AuditEventServiceLocator func() AuditEventService
+
+// AuditEventService keeps the audit trail of the changes made to the rows of every Kind
+type AuditEventService interface {
+	Get(ctx context.Context, id string) (*api.AuditEvent, *errors.ServiceError)
+	// Record appends auditEvent to the audit trail in the transaction of ctx, so that it is only kept if the
+	// change commits. The caller sets Source, SourceID, Action and the OrganizationID of the row; the username
+	// and the operation ID are taken from ctx. before and after are the row before and after the change, nil
+	// for creates and deletes respectively.
+	Record(ctx context.Context, auditEvent *api.AuditEvent, before, after interface{}) *errors.ServiceError
+	// PurgeExpired deletes the audit events created before the cutoff, it is the PurgeFunc of audit events
+	PurgeExpired(ctx context.Context, createdBefore time.Time) (int64, *errors.ServiceError)
+}
+
+func NewAuditEventService(auditEventDao dao.AuditEventDao) AuditEventService {
+	return &sqlAuditEventService{
+		auditEventDao: auditEventDao,
+	}
+}
+
+var _ AuditEventService = &sqlAuditEventService{}
+
+type sqlAuditEventService struct {
+	auditEventDao dao.AuditEventDao
+}
+
+func (s *sqlAuditEventService) Get(ctx context.Context, id string) (*api.AuditEvent, *errors.ServiceError) {
+	auditEvent, err := s.auditEventDao.Get(ctx, id)
+	if err != nil {
+		return nil, HandleGetError("AuditEvent", "id", id, err)
+	}
+	return auditEvent, nil
+}
+
+func (s *sqlAuditEventService) Record(ctx context.Context, auditEvent *api.AuditEvent, before, after interface{}) *errors.ServiceError {
+	var err error
+	if auditEvent.Before, err = marshalAuditState(before); err != nil {
+		return errors.GeneralError("Unable to record audit event: %s", err)
+	}
+	if auditEvent.After, err = marshalAuditState(after); err != nil {
+		return errors.GeneralError("Unable to record audit event: %s", err)
+	}
+	auditEvent.Username = auth.GetUsernameFromContext(ctx)
+	auditEvent.OperationID = logger.GetOperationID(ctx)
+	if tenant, ok := auth.GetTenantFromContext(ctx); ok && auditEvent.OrganizationID == "" {
+		auditEvent.OrganizationID = tenant.OrganizationID
+	}
+
+	if _, err := s.auditEventDao.Create(ctx, auditEvent); err != nil {
+		return errors.GeneralError("Unable to record audit event: %s", err)
+	}
+	return nil
+}
+
+func (s *sqlAuditEventService) PurgeExpired(ctx context.Context, createdBefore time.Time) (int64, *errors.ServiceError) {
+	purged, err := s.auditEventDao.DeleteCreatedBefore(ctx, createdBefore)
+	if err != nil {
+		return 0, errors.GeneralError("Unable to purge expired audit events: %s", err)
+	}
+	return purged, nil
+}
+
+func marshalAuditState(state interface{}) (string, error) {
+	if state == nil {
+		return "", nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
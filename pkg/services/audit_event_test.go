@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao/mocks"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
+)
+
+func TestAuditEventRecord(t *testing.T) {
+	RegisterTestingT(t)
+
+	auditEventDao := mocks.NewAuditEventDao()
+	auditEventService := NewAuditEventService(auditEventDao)
+
+	ctx := auth.SetUsernameContext(logger.WithOpID(context.Background()), "alice")
+	ctx = auth.SetTenantContext(ctx, auth.Tenant{OrganizationID: "acme"})
+
+	before := map[string]string{"species": "Stegosaurus"}
+	after := map[string]string{"species": "Triceratops"}
+	auditEvent := &api.AuditEvent{Source: "Dinosaurs", SourceID: "dino", Action: api.AuditUpdateAction}
+	Expect(auditEventService.Record(ctx, auditEvent, before, after)).To(BeNil())
+
+	recorded, err := auditEventService.Get(ctx, auditEvent.ID)
+	Expect(err).To(BeNil())
+	Expect(recorded.Username).To(Equal("alice"))
+	Expect(recorded.OperationID).To(Equal(logger.GetOperationID(ctx)))
+	Expect(recorded.OrganizationID).To(Equal("acme"))
+	Expect(recorded.Before).To(MatchJSON(`{"species": "Stegosaurus"}`))
+	Expect(recorded.After).To(MatchJSON(`{"species": "Triceratops"}`))
+
+	// the organization of the row wins over the caller's, e.g. for admins working across organizations
+	auditEvent = &api.AuditEvent{Source: "Dinosaurs", SourceID: "dino", Action: api.AuditDeleteAction, OrganizationID: "initech"}
+	Expect(auditEventService.Record(ctx, auditEvent, after, nil)).To(BeNil())
+	Expect(auditEvent.OrganizationID).To(Equal("initech"))
+	Expect(auditEvent.After).To(BeEmpty())
+
+	purged, err := auditEventService.PurgeExpired(ctx, time.Now().Add(time.Minute))
+	Expect(err).To(BeNil())
+	Expect(purged).To(Equal(int64(2)))
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+// MaxBatchSize caps the items of a batch request, which all run in the transaction of the request
+const MaxBatchSize = 1000
+
+// PurgeBatchSize is the number of rows a purge removes per statement
+const PurgeBatchSize = 1000
+
+// PurgeInBatches calls purge with a limit of PurgeBatchSize rows until it removes fewer, so no statement
+// holds the table for long, and returns the number of rows removed in total. It stops early when ctx is done.
+func PurgeInBatches(ctx context.Context, purge func(limit int) (int64, error)) (int64, error) {
+	var total int64
+	for {
+		removed, err := purge(PurgeBatchSize)
+		total += removed
+		if err != nil || removed < PurgeBatchSize || ctx.Err() != nil {
+			return total, err
+		}
+	}
+}
+
+// BatchErrors holds the error of every item of a batch by index, nil for the items that succeeded
+type BatchErrors []*errors.ServiceError
+
+// RunBatch runs item for every index of a batch of n items in the transaction of ctx.
+//
+// With allOrNothing the batch stops at the first failed item: the transaction is marked for rollback and
+// the item's error is returned. Otherwise every item runs in a savepoint of its own, so a failed item only
+// undoes its own writes, and the errors are returned by index.
+func RunBatch(ctx context.Context, n int, allOrNothing bool, item func(i int) *errors.ServiceError) (BatchErrors, *errors.ServiceError) {
+	itemErrs := make(BatchErrors, n)
+	for i := 0; i < n; i++ {
+		if allOrNothing {
+			if err := item(i); err != nil {
+				db.MarkForRollback(ctx, err)
+				return nil, errors.New(err.Code, "Item %d: %s", i, err.Reason)
+			}
+			continue
+		}
+
+		var itemErr *errors.ServiceError
+		err := db.WithSavepoint(ctx, func() error {
+			if itemErr = item(i); itemErr != nil {
+				return itemErr
+			}
+			return nil
+		})
+		if err != nil && (itemErr == nil || err != error(itemErr)) {
+			// the savepoint failed, which leaves the transaction unusable for the remaining items
+			return nil, errors.GeneralError("Unable to run batch: %s", err)
+		}
+		itemErrs[i] = itemErr
+	}
+	return itemErrs, nil
+}
+
+// ValidateResourceVersion checks the resource version a batch item expects, if any, against the one stored
+func ValidateResourceVersion(expected *int64, current int64) *errors.ServiceError {
+	if expected != nil && *expected != current {
+		return errors.PreconditionFailed("resource_version %d does not match current resource version %d", *expected, current)
+	}
+	return nil
+}
+
+// BatchEvents returns the events of the items of a batch that succeeded, for EventService.CreateBatch
+func BatchEvents(source string, eventType api.EventType, ids []string, itemErrs BatchErrors) api.EventList {
+	events := api.EventList{}
+	for i, id := range ids {
+		if itemErrs[i] != nil {
+			continue
+		}
+		events = append(events, &api.Event{
+			Source:    source,
+			SourceID:  id,
+			EventType: eventType,
+		})
+	}
+	return events
+}
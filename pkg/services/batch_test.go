@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+func TestRunBatch(t *testing.T) {
+	RegisterTestingT(t)
+
+	var ran []int
+	item := func(i int) *errors.ServiceError {
+		ran = append(ran, i)
+		if i == 1 {
+			return errors.NotFound("Dinosaur with id='b' not found")
+		}
+		return nil
+	}
+
+	// per item, a failure doesn't stop the batch
+	itemErrs, err := RunBatch(context.Background(), 3, false, item)
+	Expect(err).To(BeNil())
+	Expect(ran).To(Equal([]int{0, 1, 2}))
+	Expect(itemErrs).To(HaveLen(3))
+	Expect(itemErrs[0]).To(BeNil())
+	Expect(itemErrs[1].Is404()).To(BeTrue())
+	Expect(itemErrs[2]).To(BeNil())
+
+	// all or nothing stops at the first failure and reports its index
+	ran = nil
+	itemErrs, err = RunBatch(context.Background(), 3, true, item)
+	Expect(itemErrs).To(BeNil())
+	Expect(err.Is404()).To(BeTrue())
+	Expect(err.Reason).To(Equal("Item 1: Dinosaur with id='b' not found"))
+	Expect(ran).To(Equal([]int{0, 1}))
+
+	events := BatchEvents("Dinosaurs", api.DeleteEventType, []string{"a", "b", "c"}, BatchErrors{nil, errors.NotFound(""), nil})
+	Expect(events).To(HaveLen(2))
+	Expect(events[0].SourceID).To(Equal("a"))
+	Expect(events[1].SourceID).To(Equal("c"))
+	Expect(events[1].EventType).To(Equal(api.DeleteEventType))
+}
+
+func TestValidateResourceVersion(t *testing.T) {
+	RegisterTestingT(t)
+
+	expected := int64(2)
+	Expect(ValidateResourceVersion(nil, 3)).To(BeNil())
+	Expect(ValidateResourceVersion(&expected, 2)).To(BeNil())
+	Expect(ValidateResourceVersion(&expected, 3).HttpCode).To(Equal(http.StatusPreconditionFailed))
+}
+
+func TestPurgeInBatches(t *testing.T) {
+	RegisterTestingT(t)
+
+	// the purge runs until a batch comes back short
+	remaining := int64(2*PurgeBatchSize + 5)
+	calls := 0
+	purged, err := PurgeInBatches(context.Background(), func(limit int) (int64, error) {
+		calls++
+		removed := min(remaining, int64(limit))
+		remaining -= removed
+		return removed, nil
+	})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(purged).To(Equal(int64(2*PurgeBatchSize + 5)))
+	Expect(calls).To(Equal(3))
+
+	// a failed batch stops the purge with the rows removed so far
+	purged, err = PurgeInBatches(context.Background(), func(limit int) (int64, error) {
+		if calls++; calls > 4 {
+			return 0, errors.GeneralError("boom").AsError()
+		}
+		return int64(limit), nil
+	})
+	Expect(err).To(HaveOccurred())
+	Expect(purged).To(Equal(int64(PurgeBatchSize)))
+}
@@ -2,18 +2,34 @@ package services
 
 import (
 	"context"
+	e "errors"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
+	"gorm.io/gorm"
+
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
 	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
 	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/tracing"
 )
 
 type EventServiceLocator func() EventService
 
+// EventService is the transactional outbox for kind controllers. Services call Create with the same
+// context they used to write the entity, so the event row commits or rolls back with that write and
+// the announcement on the event bus that wakes the controllers is only sent after the commit.
 type EventService interface {
 	Get(ctx context.Context, id string) (*api.Event, *errors.ServiceError)
+	// GetBySeq returns the event numbered seq, see api.Event
+	GetBySeq(ctx context.Context, seq int64) (*api.Event, *errors.ServiceError)
 	Create(ctx context.Context, event *api.Event) (*api.Event, *errors.ServiceError)
+	// CreateBatch writes the events of a batch of changes with a single insert
+	CreateBatch(ctx context.Context, events api.EventList) (api.EventList, *errors.ServiceError)
 	Replace(ctx context.Context, event *api.Event) (*api.Event, *errors.ServiceError)
 	Delete(ctx context.Context, id string) *errors.ServiceError
 	All(ctx context.Context) (api.EventList, *errors.ServiceError)
@@ -23,18 +39,59 @@ type EventService interface {
 	// Sync-the-world methods for missed event recovery
 	FindUnreconciled(ctx context.Context, olderThan time.Duration) (api.EventList, *errors.ServiceError)
 	FindBySourceAndType(ctx context.Context, source string, eventType api.EventType) (api.EventList, *errors.ServiceError)
+	// FindBySourceAfter pages through the events of source numbered after afterSeq, for resuming watches
+	FindBySourceAfter(ctx context.Context, source string, afterSeq int64, limit int) (api.EventList, *errors.ServiceError)
+
+	// Redrive clears the retry state of a dead-lettered event and hands it back to the controllers
+	Redrive(ctx context.Context, id string) (*api.Event, *errors.ServiceError)
+	// Reprocess hands an event back to the controllers whatever its state, reconciled events included, and
+	// records the user of ctx as the one who reprocessed it
+	Reprocess(ctx context.Context, id string) (*api.Event, *errors.ServiceError)
+	// ReprocessKind clears the retry state of every unreconciled event of source and hands them back to
+	// the controllers, recording the user of ctx as for Reprocess
+	ReprocessKind(ctx context.Context, source string) (*api.EventStats, *errors.ServiceError)
+
+	// Stats returns the backlog of every source with events or a pause, KindStats the one of source
+	Stats(ctx context.Context) ([]*api.EventStats, *errors.ServiceError)
+	KindStats(ctx context.Context, source string) (*api.EventStats, *errors.ServiceError)
+
+	// Pause stops the controllers from processing the events of source until Resume is called, the events
+	// pending by then are handed back to the controllers. Paused tells the controllers whether to skip them.
+	Pause(ctx context.Context, source string) (*api.EventStats, *errors.ServiceError)
+	Resume(ctx context.Context, source string) (*api.EventStats, *errors.ServiceError)
+	Paused(ctx context.Context, source string) (bool, *errors.ServiceError)
+
+	// PurgeReconciled deletes the events reconciled before reconciledBefore, or moves them to the archive,
+	// and returns how many were removed
+	PurgeReconciled(ctx context.Context, reconciledBefore time.Time, archive bool) (int64, *errors.ServiceError)
+	// CollapseUpdates folds the pending Update events of a resource into its latest one, which carries the
+	// fields changed by all of them, and returns how many events were removed. Each resource is collapsed by a
+	// transaction of its own and its latest event is announced once that commits.
+	CollapseUpdates(ctx context.Context) (int64, *errors.ServiceError)
 }
 
-func NewEventService(eventDao dao.EventDao) EventService {
+// eventCompactionBatchSize bounds the events removed by one statement and the resources collapsed per call
+const eventCompactionBatchSize = 1000
+
+// eventKindsLockType serializes the operations on all the events of a source
+const eventKindsLockType db.LockType = "event_kinds"
+
+// NewEventService returns the EventService of eventDao. sessionFactory opens the transactions of the work the
+// service does on its own, e.g. collapsing updates; without one, e.g. in unit tests, statements commit as they run.
+func NewEventService(lockFactory db.LockFactory, eventDao dao.EventDao, sessionFactory db.SessionFactory) EventService {
 	return &sqlEventService{
-		eventDao: eventDao,
+		lockFactory:    lockFactory,
+		eventDao:       eventDao,
+		sessionFactory: sessionFactory,
 	}
 }
 
 var _ EventService = &sqlEventService{}
 
 type sqlEventService struct {
-	eventDao dao.EventDao
+	lockFactory    db.LockFactory
+	eventDao       dao.EventDao
+	sessionFactory db.SessionFactory
 }
 
 func (s *sqlEventService) Get(ctx context.Context, id string) (*api.Event, *errors.ServiceError) {
@@ -45,7 +102,20 @@ func (s *sqlEventService) Get(ctx context.Context, id string) (*api.Event, *erro
 	return event, nil
 }
 
+func (s *sqlEventService) GetBySeq(ctx context.Context, seq int64) (*api.Event, *errors.ServiceError) {
+	event, err := s.eventDao.GetBySeq(ctx, seq)
+	if err != nil {
+		return nil, HandleGetError("Event", "seq", seq, err)
+	}
+	return event, nil
+}
+
 func (s *sqlEventService) Create(ctx context.Context, event *api.Event) (*api.Event, *errors.ServiceError) {
+	if event.EventType == api.UpdateEventType && event.ChangedFields == "" {
+		event.ChangedFields = strings.Join(ChangedFields(ctx), ",")
+	}
+	withTraceContext(ctx, event)
+	withOrganization(ctx, event)
 	event, err := s.eventDao.Create(ctx, event)
 	if err != nil {
 		return nil, HandleCreateError("Event", err)
@@ -53,6 +123,36 @@ func (s *sqlEventService) Create(ctx context.Context, event *api.Event) (*api.Ev
 	return event, nil
 }
 
+func (s *sqlEventService) CreateBatch(ctx context.Context, events api.EventList) (api.EventList, *errors.ServiceError) {
+	if len(events) == 0 {
+		return events, nil
+	}
+	for _, event := range events {
+		withTraceContext(ctx, event)
+		withOrganization(ctx, event)
+	}
+	events, err := s.eventDao.CreateBatch(ctx, events)
+	if err != nil {
+		return nil, HandleCreateError("Event", err)
+	}
+	return events, nil
+}
+
+// withTraceContext records the trace context of ctx on the event, unless it carries one already
+func withTraceContext(ctx context.Context, event *api.Event) {
+	if event.TraceParent == "" {
+		event.TraceParent, event.TraceState = tracing.TraceContext(ctx)
+	}
+}
+
+// withOrganization records the organization of the request in ctx on the event, unless it carries one already.
+// Watches only announce the deletes of purged resources to the callers of their organization.
+func withOrganization(ctx context.Context, event *api.Event) {
+	if tenant, ok := auth.GetTenantFromContext(ctx); ok && event.OrganizationID == "" {
+		event.OrganizationID = tenant.OrganizationID
+	}
+}
+
 func (s *sqlEventService) Replace(ctx context.Context, event *api.Event) (*api.Event, *errors.ServiceError) {
 	event, err := s.eventDao.Replace(ctx, event)
 	if err != nil {
@@ -99,3 +199,299 @@ func (s *sqlEventService) FindBySourceAndType(ctx context.Context, source string
 	}
 	return events, nil
 }
+
+func (s *sqlEventService) FindBySourceAfter(ctx context.Context, source string, afterSeq int64, limit int) (api.EventList, *errors.ServiceError) {
+	events, err := s.eventDao.FindBySourceAfter(ctx, source, afterSeq, limit)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to find events of %s: %s", source, err)
+	}
+	return events, nil
+}
+
+func (s *sqlEventService) Redrive(ctx context.Context, id string) (*api.Event, *errors.ServiceError) {
+	event, svcErr := s.Get(ctx, id)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+	if !event.DeadLettered() {
+		return nil, errors.Conflict("Event with id='%s' is not dead-lettered", id)
+	}
+
+	event.Attempts = 0
+	event.NextAttemptAt = nil
+	event.DeadLetteredAt = nil
+	event, err := s.eventDao.Replace(ctx, event)
+	if err != nil {
+		return nil, HandleUpdateError("Event", err)
+	}
+	s.eventDao.Notify(ctx, event)
+	return event, nil
+}
+
+func (s *sqlEventService) Reprocess(ctx context.Context, id string) (*api.Event, *errors.ServiceError) {
+	// a controller handling the event holds its lock, wait for it so its outcome isn't written over ours
+	lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, id, db.Events)
+	defer s.lockFactory.Unlock(ctx, lockOwnerID)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to lock event with id='%s': %s", id, err)
+	}
+
+	event, svcErr := s.Get(ctx, id)
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	now := time.Now()
+	event.ReconciledDate = nil
+	event.Attempts = 0
+	event.NextAttemptAt = nil
+	event.DeadLetteredAt = nil
+	event.ReprocessedBy = auth.GetUsernameFromContext(ctx)
+	event.ReprocessedAt = &now
+	event, err = s.eventDao.Replace(ctx, event)
+	if err != nil {
+		return nil, HandleUpdateError("Event", err)
+	}
+	s.eventDao.Notify(ctx, event)
+	return event, nil
+}
+
+func (s *sqlEventService) ReprocessKind(ctx context.Context, source string) (*api.EventStats, *errors.ServiceError) {
+	lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, source, eventKindsLockType)
+	defer s.lockFactory.Unlock(ctx, lockOwnerID)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to lock the events of %s: %s", source, err)
+	}
+
+	events, err := s.eventDao.Requeue(ctx, source, auth.GetUsernameFromContext(ctx))
+	if err != nil {
+		return nil, errors.GeneralError("Unable to reprocess the events of %s: %s", source, err)
+	}
+	for _, event := range events {
+		s.eventDao.Notify(ctx, event)
+	}
+	return s.KindStats(ctx, source)
+}
+
+func (s *sqlEventService) Stats(ctx context.Context) ([]*api.EventStats, *errors.ServiceError) {
+	stats, err := s.eventDao.Stats(ctx)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to get event stats: %s", err)
+	}
+	pauses, err := s.eventDao.FindPauses(ctx)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to get event pauses: %s", err)
+	}
+
+	index := map[string]*api.EventStats{}
+	for _, kindStats := range stats {
+		index[kindStats.Source] = kindStats
+	}
+	for _, pause := range pauses {
+		if kindStats, found := index[pause.Source]; found {
+			kindStats.Pause = pause
+		} else {
+			stats = append(stats, &api.EventStats{Source: pause.Source, Pause: pause})
+		}
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Source < stats[j].Source })
+	return stats, nil
+}
+
+func (s *sqlEventService) KindStats(ctx context.Context, source string) (*api.EventStats, *errors.ServiceError) {
+	stats, err := s.eventDao.Stats(ctx, source)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to get event stats of %s: %s", source, err)
+	}
+	kindStats := &api.EventStats{Source: source}
+	if len(stats) > 0 {
+		kindStats = stats[0]
+	}
+	pause, err := s.eventDao.GetPause(ctx, source)
+	if err != nil && !e.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.GeneralError("Unable to get event pause of %s: %s", source, err)
+	}
+	kindStats.Pause = pause
+	return kindStats, nil
+}
+
+func (s *sqlEventService) Pause(ctx context.Context, source string) (*api.EventStats, *errors.ServiceError) {
+	lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, source, eventKindsLockType)
+	defer s.lockFactory.Unlock(ctx, lockOwnerID)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to lock the events of %s: %s", source, err)
+	}
+
+	pause := &api.EventPause{Source: source, PausedBy: auth.GetUsernameFromContext(ctx)}
+	if err := s.eventDao.Pause(ctx, pause); err != nil {
+		return nil, errors.GeneralError("Unable to pause the controllers of %s: %s", source, err)
+	}
+	return s.KindStats(ctx, source)
+}
+
+func (s *sqlEventService) Resume(ctx context.Context, source string) (*api.EventStats, *errors.ServiceError) {
+	lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, source, eventKindsLockType)
+	defer s.lockFactory.Unlock(ctx, lockOwnerID)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to lock the events of %s: %s", source, err)
+	}
+
+	if err := s.eventDao.Resume(ctx, source); err != nil {
+		return nil, errors.GeneralError("Unable to resume the controllers of %s: %s", source, err)
+	}
+	// the events recorded while paused were announced and skipped, announce them again
+	events, err := s.eventDao.FindPending(ctx, source)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to find the pending events of %s: %s", source, err)
+	}
+	for _, event := range events {
+		s.eventDao.Notify(ctx, event)
+	}
+	return s.KindStats(ctx, source)
+}
+
+func (s *sqlEventService) Paused(ctx context.Context, source string) (bool, *errors.ServiceError) {
+	_, err := s.eventDao.GetPause(ctx, source)
+	if e.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.GeneralError("Unable to get event pause of %s: %s", source, err)
+	}
+	return true, nil
+}
+
+func (s *sqlEventService) PurgeReconciled(ctx context.Context, reconciledBefore time.Time, archive bool) (int64, *errors.ServiceError) {
+	remove := s.eventDao.DeleteReconciled
+	if archive {
+		remove = s.eventDao.ArchiveReconciled
+	}
+
+	// remove the events in batches, so no statement holds the table for long
+	var total int64
+	for {
+		removed, err := remove(ctx, reconciledBefore, eventCompactionBatchSize)
+		total += removed
+		if err != nil {
+			return total, errors.GeneralError("Unable to remove events reconciled before %v: %s", reconciledBefore, err)
+		}
+		if removed < eventCompactionBatchSize || ctx.Err() != nil {
+			return total, nil
+		}
+	}
+}
+
+func (s *sqlEventService) CollapseUpdates(ctx context.Context) (int64, *errors.ServiceError) {
+	events, err := s.eventDao.FindRedundantUpdates(ctx, eventCompactionBatchSize)
+	if err != nil {
+		return 0, errors.GeneralError("Unable to find redundant update events: %s", err)
+	}
+
+	var total int64
+	for start := 0; start < len(events); {
+		end := start + 1
+		for end < len(events) && events[end].Source == events[start].Source && events[end].SourceID == events[start].SourceID {
+			end++
+		}
+		removed, svcErr := s.collapse(ctx, events[start:end])
+		total += removed
+		if svcErr != nil {
+			return total, svcErr
+		}
+		start = end
+	}
+	return total, nil
+}
+
+// collapse folds the pending Update events of a resource, in creation order, into the last one and announces
+// it. Resources with an event a controller is handling are left for the next call.
+func (s *sqlEventService) collapse(ctx context.Context, events api.EventList) (int64, *errors.ServiceError) {
+	ids := make([]string, len(events))
+	for i, event := range events {
+		lockOwnerID, acquired, err := s.lockFactory.NewNonBlockingLock(ctx, event.ID, db.Events)
+		defer s.lockFactory.Unlock(ctx, lockOwnerID)
+		if err != nil {
+			return 0, errors.GeneralError("Unable to lock event with id='%s': %s", event.ID, err)
+		}
+		if !acquired {
+			return 0, nil
+		}
+		ids[i] = event.ID
+	}
+
+	// the events of the resource are collapsed by a transaction of their own, which commits before the locks
+	// are released, and the latest event is announced once it has committed
+	if s.sessionFactory != nil {
+		txCtx, err := db.NewContext(ctx, s.sessionFactory)
+		if err != nil {
+			return 0, errors.GeneralError("Unable to start a transaction: %s", err)
+		}
+		ctx = txCtx
+		defer db.Resolve(ctx)
+	}
+
+	// the events may have been handled before they were locked
+	locked, err := s.eventDao.FindByIDs(ctx, ids)
+	if err != nil {
+		return 0, errors.GeneralError("Unable to get events: %s", err)
+	}
+	index := locked.Index()
+	pending := api.EventList{}
+	for _, id := range ids {
+		if event, found := index[id]; found && event.ReconciledDate == nil && !event.DeadLettered() {
+			pending = append(pending, event)
+		}
+	}
+	if len(pending) < 2 {
+		return 0, nil
+	}
+
+	// a failed statement rolls the transaction back, so nothing is removed then
+	latest := pending[len(pending)-1]
+	changedFields := mergeFieldMasks(pending)
+	if changedFields != latest.ChangedFields {
+		latest.ChangedFields = changedFields
+		if _, err := s.eventDao.Replace(ctx, latest); err != nil {
+			return 0, HandleUpdateError("Event", err)
+		}
+	}
+	for _, event := range pending[:len(pending)-1] {
+		if err := s.eventDao.Delete(ctx, event.ID); err != nil {
+			return 0, HandleDeleteError("Event", errors.GeneralError("Unable to delete event: %s", err))
+		}
+	}
+	// the controllers skipped the announcements of the events while they were locked
+	s.eventDao.Notify(ctx, latest)
+	return int64(len(pending) - 1), nil
+}
+
+// mergeFieldMasks returns the union of the field masks of events, empty if any update isn't known field by field
+func mergeFieldMasks(events api.EventList) string {
+	fields := []string{}
+	for _, event := range events {
+		mask := event.FieldMask()
+		if mask == nil {
+			return ""
+		}
+		for _, field := range mask {
+			if !slices.Contains(fields, field) {
+				fields = append(fields, field)
+			}
+		}
+	}
+	return strings.Join(fields, ",")
+}
+
+type changedFieldsKey struct{}
+
+// WithChangedFields returns ctx for an update that changes fields, e.g. the fields of a PATCH request. The
+// update event created in ctx carries them as its field mask.
+func WithChangedFields(ctx context.Context, fields []string) context.Context {
+	return context.WithValue(ctx, changedFieldsKey{}, fields)
+}
+
+// ChangedFields returns the fields of WithChangedFields, nil when the update isn't known field by field
+func ChangedFields(ctx context.Context) []string {
+	fields, _ := ctx.Value(changedFieldsKey{}).([]string)
+	return fields
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao/mocks"
+	dbmocks "github.com/openshift-online/rh-trex-ai/pkg/db/mocks"
+)
+
+func TestEventStats(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx := auth.SetUsernameContext(context.Background(), "alice")
+	eventDao := mocks.NewEventDao()
+	eventService := NewEventService(dbmocks.NewMockAdvisoryLockFactory(), eventDao, nil)
+
+	now := time.Now()
+	oldest := now.Add(-time.Hour)
+	_, _ = eventDao.CreateBatch(ctx, api.EventList{
+		{Meta: api.Meta{ID: "1", CreatedAt: oldest}, Source: "Dinosaurs", EventType: api.CreateEventType},
+		{Meta: api.Meta{ID: "2", CreatedAt: now}, Source: "Dinosaurs", EventType: api.UpdateEventType, Attempts: 2, NextAttemptAt: &now},
+		{Meta: api.Meta{ID: "3", CreatedAt: now}, Source: "Dinosaurs", EventType: api.DeleteEventType, Attempts: 10, DeadLetteredAt: &now},
+		{Meta: api.Meta{ID: "4", CreatedAt: now}, Source: "Fossils", EventType: api.CreateEventType, ReconciledDate: &now},
+	})
+
+	stats, svcErr := eventService.Pause(ctx, "Scientists")
+	Expect(svcErr).To(BeNil())
+	Expect(stats.Total).To(Equal(int64(0)))
+	Expect(stats.Pause.PausedBy).To(Equal("alice"))
+
+	all, svcErr := eventService.Stats(ctx)
+	Expect(svcErr).To(BeNil())
+	Expect(all).To(HaveLen(3))
+	Expect(all[0].Source).To(Equal("Dinosaurs"))
+	Expect(all[0].Total).To(Equal(int64(3)))
+	Expect(all[0].Pending).To(Equal(int64(2)))
+	Expect(all[0].Retrying).To(Equal(int64(1)))
+	Expect(all[0].DeadLettered).To(Equal(int64(1)))
+	Expect(*all[0].OldestPendingAt).To(BeTemporally("==", oldest))
+	Expect(all[0].Pause).To(BeNil())
+	Expect(all[1].Source).To(Equal("Fossils"))
+	Expect(all[1].Pending).To(Equal(int64(0)))
+	Expect(all[1].OldestPendingAt).To(BeNil())
+	Expect(all[2].Source).To(Equal("Scientists"))
+	Expect(all[2].Pause).NotTo(BeNil())
+
+	paused, svcErr := eventService.Paused(ctx, "Scientists")
+	Expect(svcErr).To(BeNil())
+	Expect(paused).To(BeTrue())
+	paused, _ = eventService.Paused(ctx, "Dinosaurs")
+	Expect(paused).To(BeFalse())
+}
+
+func TestEventReprocessKind(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx := auth.SetUsernameContext(context.Background(), "alice")
+	eventDao := mocks.NewEventDao()
+	eventService := NewEventService(dbmocks.NewMockAdvisoryLockFactory(), eventDao, nil)
+
+	now := time.Now()
+	_, _ = eventDao.CreateBatch(ctx, api.EventList{
+		{Meta: api.Meta{ID: "1"}, Source: "Dinosaurs", Attempts: 2, NextAttemptAt: &now},
+		{Meta: api.Meta{ID: "2"}, Source: "Dinosaurs", Attempts: 10, DeadLetteredAt: &now},
+		{Meta: api.Meta{ID: "3"}, Source: "Dinosaurs", ReconciledDate: &now},
+		{Meta: api.Meta{ID: "4"}, Source: "Fossils", Attempts: 10, DeadLetteredAt: &now},
+	})
+
+	stats, svcErr := eventService.ReprocessKind(ctx, "Dinosaurs")
+	Expect(svcErr).To(BeNil())
+	Expect(stats.Pending).To(Equal(int64(2)))
+	Expect(stats.Retrying).To(Equal(int64(0)))
+	Expect(stats.DeadLettered).To(Equal(int64(0)))
+	event, _ := eventDao.Get(ctx, "2")
+	Expect(event.ReprocessedBy).To(Equal("alice"))
+	Expect(event.ReprocessedAt).NotTo(BeNil())
+
+	// reconciled events and the events of other kinds are left alone
+	event, _ = eventDao.Get(ctx, "3")
+	Expect(event.ReconciledDate).NotTo(BeNil())
+	Expect(event.ReprocessedBy).To(BeEmpty())
+	event, _ = eventDao.Get(ctx, "4")
+	Expect(event.DeadLettered()).To(BeTrue())
+
+	// a single event is reprocessed even once reconciled
+	event, svcErr = eventService.Reprocess(ctx, "3")
+	Expect(svcErr).To(BeNil())
+	Expect(event.ReconciledDate).To(BeNil())
+	Expect(event.ReprocessedBy).To(Equal("alice"))
+
+	_, svcErr = eventService.Reprocess(ctx, "missing")
+	Expect(svcErr).NotTo(BeNil())
+	Expect(svcErr.HttpCode).To(Equal(404))
+}
+
+func TestEventPurgeReconciled(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx := context.Background()
+	eventDao := mocks.NewEventDao()
+	eventService := NewEventService(dbmocks.NewMockAdvisoryLockFactory(), eventDao, nil)
+
+	// more events than a batch removes
+	old := time.Now().Add(-48 * time.Hour)
+	events := api.EventList{{Meta: api.Meta{ID: "pending"}, Source: "Dinosaurs"}}
+	for i := 0; i < 2*eventCompactionBatchSize+1; i++ {
+		events = append(events, &api.Event{Meta: api.Meta{ID: api.NewID()}, Source: "Dinosaurs", ReconciledDate: &old})
+	}
+	_, _ = eventDao.CreateBatch(ctx, events)
+
+	removed, svcErr := eventService.PurgeReconciled(ctx, time.Now().Add(-24*time.Hour), false)
+	Expect(svcErr).To(BeNil())
+	Expect(removed).To(Equal(int64(2*eventCompactionBatchSize + 1)))
+	remaining, _ := eventDao.All(ctx)
+	Expect(remaining).To(HaveLen(1))
+	Expect(remaining[0].ID).To(Equal("pending"))
+}
+
+func TestEventCollapseUpdates(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx := context.Background()
+	eventDao := mocks.NewEventDao()
+	eventService := NewEventService(dbmocks.NewMockAdvisoryLockFactory(), eventDao, nil)
+
+	now := time.Now()
+	_, _ = eventDao.CreateBatch(ctx, api.EventList{
+		{Meta: api.Meta{ID: "a1", CreatedAt: now.Add(-3 * time.Minute)}, Source: "Dinosaurs", SourceID: "a", EventType: api.UpdateEventType, ChangedFields: "species"},
+		{Meta: api.Meta{ID: "a2", CreatedAt: now.Add(-2 * time.Minute)}, Source: "Dinosaurs", SourceID: "a", EventType: api.UpdateEventType},
+		{Meta: api.Meta{ID: "a3", CreatedAt: now.Add(-time.Minute)}, Source: "Dinosaurs", SourceID: "a", EventType: api.UpdateEventType, ChangedFields: "name"},
+		// creates, reconciled and dead-lettered updates and single updates are kept
+		{Meta: api.Meta{ID: "a0", CreatedAt: now.Add(-4 * time.Minute)}, Source: "Dinosaurs", SourceID: "a", EventType: api.CreateEventType},
+		{Meta: api.Meta{ID: "b1", CreatedAt: now}, Source: "Dinosaurs", SourceID: "b", EventType: api.UpdateEventType, ReconciledDate: &now},
+		{Meta: api.Meta{ID: "b2", CreatedAt: now}, Source: "Dinosaurs", SourceID: "b", EventType: api.UpdateEventType, DeadLetteredAt: &now},
+		{Meta: api.Meta{ID: "b3", CreatedAt: now}, Source: "Dinosaurs", SourceID: "b", EventType: api.UpdateEventType},
+		{Meta: api.Meta{ID: "c1", CreatedAt: now}, Source: "Fossils", SourceID: "a", EventType: api.UpdateEventType},
+	})
+
+	removed, svcErr := eventService.CollapseUpdates(ctx)
+	Expect(svcErr).To(BeNil())
+	Expect(removed).To(Equal(int64(2)))
+
+	remaining, _ := eventDao.All(ctx)
+	index := remaining.Index()
+	Expect(index).To(HaveLen(6))
+	Expect(index).NotTo(HaveKey("a1"))
+	Expect(index).NotTo(HaveKey("a2"))
+	// a2 changed fields that aren't known, so neither are those of the collapsed update
+	Expect(index["a3"].FieldMask()).To(BeNil())
+}
+
+func TestMergeFieldMasks(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(mergeFieldMasks(api.EventList{
+		{ChangedFields: "species,name"},
+		{ChangedFields: "name,weight"},
+	})).To(Equal("species,name,weight"))
+	Expect(mergeFieldMasks(api.EventList{
+		{ChangedFields: "species"},
+		{},
+	})).To(Equal(""))
+}
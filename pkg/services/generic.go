@@ -22,7 +22,8 @@ import (
 )
 
 type GenericService interface {
-	List(ctx context.Context, username string, args *ListArguments, resourceList interface{}) (*api.PagingMeta, *errors.ServiceError)
+	// List is scoped to the organization of the request in ctx, see dao.TenantScope
+	List(ctx context.Context, args *ListArguments, resourceList interface{}) (*api.PagingMeta, *errors.ServiceError)
 }
 
 func NewGenericService(genericDao dao.GenericDao) GenericService {
@@ -44,7 +45,6 @@ var (
 type listContext struct {
 	ctx              context.Context
 	args             *ListArguments
-	username         string
 	pagingMeta       *api.PagingMeta
 	ulog             *logger.Logger
 	resourceList     interface{}
@@ -53,9 +53,14 @@ type listContext struct {
 	joins            map[string]dao.TableRelation
 	groupBy          []string
 	set              map[string]bool
+	orderBy          []string
+	sortKey          []string
+	cursor           *listCursor
+	// keyset is the condition of the rows after the continue token, applied once the total is counted
+	keyset *dao.Where
 }
 
-func (s *sqlGenericService) newListContext(ctx context.Context, username string, args *ListArguments, resourceList interface{}) (*listContext, interface{}, *errors.ServiceError) {
+func (s *sqlGenericService) newListContext(ctx context.Context, args *ListArguments, resourceList interface{}) (*listContext, interface{}, *errors.ServiceError) {
 	log := logger.NewLogger(ctx)
 	resourceModel := reflect.TypeOf(resourceList).Elem().Elem()
 	resourceTypeStr := resourceModel.Name()
@@ -70,7 +75,6 @@ func (s *sqlGenericService) newListContext(ctx context.Context, username string,
 	return &listContext{
 		ctx:              ctx,
 		args:             args,
-		username:         username,
 		pagingMeta:       &api.PagingMeta{Page: args.Page},
 		ulog:             &log,
 		resourceList:     resourceList,
@@ -80,8 +84,8 @@ func (s *sqlGenericService) newListContext(ctx context.Context, username string,
 }
 
 // List resourceList must be a pointer to a slice of database resource objects
-func (s *sqlGenericService) List(ctx context.Context, username string, args *ListArguments, resourceList interface{}) (*api.PagingMeta, *errors.ServiceError) {
-	listCtx, model, err := s.newListContext(ctx, username, args, resourceList)
+func (s *sqlGenericService) List(ctx context.Context, args *ListArguments, resourceList interface{}) (*api.PagingMeta, *errors.ServiceError) {
+	listCtx, model, err := s.newListContext(ctx, args, resourceList)
 	if err != nil {
 		return nil, err
 	}
@@ -91,9 +95,15 @@ func (s *sqlGenericService) List(ctx context.Context, username string, args *Lis
 		// build SQL to load related resource. for now, it delegates to gorm.preload.
 		s.buildPreload,
 
+		// only list the rows of the caller's organization
+		s.buildTenancy,
+
 		// add "ORDER BY"
 		s.buildOrderBy,
 
+		// resume after the row of the continue token
+		s.buildKeyset,
+
 		// translate "search" into "WHERE"(s), and "JOIN"(s) if related resource is searched.
 		s.buildSearch,
 
@@ -129,22 +139,85 @@ func (s *sqlGenericService) buildPreload(listCtx *listContext, d *dao.GenericDao
 		listCtx.set[preload] = true
 	}
 	// preload each table only once; struct{} doesn't occupy any additional space
-	for _, preload := range listCtx.args.Preloads {
-		(*d).Preload(preload)
+	for preload := range listCtx.set {
+		relation, ok := (*d).GetTableRelation(preload)
+		if !ok {
+			return false, errors.BadRequest("%s is not a related resource of %s", preload, listCtx.resourceType)
+		}
+		(*d).Preload(relation.FieldName)
+	}
+	if listCtx.args.IncludeDeleted {
+		(*d).Unscoped()
+	}
+	return false, nil
+}
+
+func (s *sqlGenericService) buildTenancy(listCtx *listContext, d *dao.GenericDao) (bool, *errors.ServiceError) {
+	if sql, values, scoped := dao.TenantCondition(listCtx.ctx, (*d).GetTableName()); scoped {
+		(*d).Where(dao.NewWhere(sql, values))
 	}
 	return false, nil
 }
 
 func (s *sqlGenericService) buildOrderBy(listCtx *listContext, d *dao.GenericDao) (bool, *errors.ServiceError) {
-	if len(listCtx.args.OrderBy) != 0 {
-		orderByArgs, serviceErr := db.ArgsToOrderBy(listCtx.args.OrderBy, *listCtx.disallowedFields)
+	orderByArgs, serviceErr := db.ArgsToOrderBy(listCtx.args.OrderBy, *listCtx.disallowedFields)
+	if serviceErr != nil {
+		return false, serviceErr
+	}
+
+	if listCtx.args.Continue != "" {
+		cursor, serviceErr := decodeCursor(listCtx.args.Continue)
 		if serviceErr != nil {
 			return false, serviceErr
 		}
-		for _, orderByArg := range orderByArgs {
-			(*d).OrderBy(orderByArg)
+		if len(listCtx.args.OrderBy) != 0 && !reflect.DeepEqual(orderByArgs, cursor.OrderBy) {
+			return false, errors.BadRequest("The continue token was issued for a different orderBy")
+		}
+		// the token's ordering is client input, clean it like any other orderBy
+		if orderByArgs, serviceErr = db.ArgsToOrderBy(cursor.OrderBy, *listCtx.disallowedFields); serviceErr != nil {
+			return false, serviceErr
+		}
+		listCtx.cursor = cursor
+	}
+	listCtx.orderBy = orderByArgs
+
+	// rows are always ordered by id last, so pages are stable and every row has a distinct sort key
+	idColumn := (*d).GetTableName() + ".id"
+	sortKey := orderByArgs
+	orderedByID := false
+	for _, orderByArg := range orderByArgs {
+		column := strings.Split(orderByArg, " ")[0]
+		orderedByID = orderedByID || column == "id" || column == idColumn
+	}
+	if !orderedByID {
+		sortKey = append(sortKey, idColumn+" asc")
+	}
+
+	for _, orderByArg := range sortKey {
+		// NULLs sort last in either direction, which is where the keyset condition looks for them
+		if field, ok := (*d).GetColumnField(strings.Split(orderByArg, " ")[0]); ok && nullable(field) {
+			orderByArg += " nulls last"
 		}
+		(*d).OrderBy(orderByArg)
 	}
+	listCtx.sortKey = sortKey
+	return false, nil
+}
+
+func (s *sqlGenericService) buildKeyset(listCtx *listContext, d *dao.GenericDao) (bool, *errors.ServiceError) {
+	if listCtx.cursor == nil {
+		return false, nil
+	}
+	columns, ok := keysetColumns(listCtx.sortKey, *d)
+	if !ok {
+		return false, errors.BadRequest("Invalid continue token")
+	}
+	values, serviceErr := cursorValues(listCtx.cursor, columns)
+	if serviceErr != nil {
+		return false, serviceErr
+	}
+	keyset := dao.NewWhere(keysetCondition(columns, values))
+	listCtx.keyset = &keyset
 	return false, nil
 }
 
@@ -194,11 +267,22 @@ func (s *sqlGenericService) buildSearch(listCtx *listContext, d *dao.GenericDao)
 	return true, nil
 }
 
-// JOIN the tables that appear in the search string
+// JOIN the tables that appear in the search string. Preloads are separate queries, they don't join anything.
 func (s *sqlGenericService) addJoins(listCtx *listContext, d *dao.GenericDao) {
+	joined := map[string]bool{}
 	for _, r := range listCtx.joins {
-		if _, ok := listCtx.set[r.ForeignTableName]; ok {
-			// skip already included preloads
+		if joined[r.ForeignTableName] {
+			continue
+		}
+		if r.JoinTableName != "" {
+			(*d).Joins(fmt.Sprintf(
+				"LEFT JOIN %s ON %s.%s = %s.%s",
+				r.JoinTableName, r.JoinTableName, r.JoinColumnName, r.TableName, r.ColumnName))
+			(*d).Joins(fmt.Sprintf(
+				"LEFT JOIN %s ON %s.%s = %s.%s AND %s.deleted_at IS NULL",
+				r.ForeignTableName, r.ForeignTableName, r.ForeignColumnName, r.JoinTableName, r.JoinForeignColumnName, r.ForeignTableName))
+			// a resource linked to several matching rows is listed once
+			joined[r.ForeignTableName] = true
 			continue
 		}
 		sql := fmt.Sprintf(
@@ -207,7 +291,7 @@ func (s *sqlGenericService) addJoins(listCtx *listContext, d *dao.GenericDao) {
 		(*d).Joins(sql)
 
 		listCtx.groupBy = append(listCtx.groupBy, r.ForeignTableName+".id")
-		listCtx.set[r.ForeignTableName] = true
+		joined[r.ForeignTableName] = true
 	}
 	if len(listCtx.joins) > 0 {
 		// Add base relation
@@ -223,7 +307,13 @@ func (s *sqlGenericService) loadList(listCtx *listContext, d *dao.GenericDao) *e
 	args := listCtx.args
 	ulog := *listCtx.ulog
 
-	(*d).Count(listCtx.resourceList, &listCtx.pagingMeta.Total)
+	// the total counts the whole list, not the rows after the continue token
+	if !args.SkipCount {
+		(*d).Count(listCtx.resourceList, &listCtx.pagingMeta.Total)
+	}
+	if listCtx.keyset != nil {
+		(*d).Where(*listCtx.keyset)
+	}
 
 	// Set resourceList to be an empty slice with zero capacity. Real space will be allocated by g2.Find()
 	if err := zeroSlice(listCtx.resourceList, 0); err != nil {
@@ -242,16 +332,38 @@ func (s *sqlGenericService) loadList(listCtx *listContext, d *dao.GenericDao) *e
 		return nil
 	}
 
+	// A continue token already positions the list, so it starts at the first row after the token.
+	offset := (args.Page - 1) * int(args.Size)
+	if listCtx.cursor != nil {
+		offset = 0
+	}
+
 	// NOTE: Limit no longer supports '0' size and will cause issues. There is an early return, do not remove it.
 	//       https://github.com/go-gorm/gorm/blob/master/clause/limit.go#L18-L21
-	if err := (*d).Fetch((args.Page-1)*int(args.Size), int(args.Size), listCtx.resourceList); err != nil {
+	// One extra row is fetched to learn whether there is a next page.
+	limit := int(args.Size)
+	if limit > 0 {
+		limit++
+	}
+	if err := (*d).Fetch(offset, limit, listCtx.resourceList); err != nil {
 		if e.Is(err, gorm.ErrRecordNotFound) {
 			listCtx.pagingMeta.Size = 0
 		} else {
 			return errors.GeneralError("Unable to list resources: %s", err)
 		}
 	}
-	listCtx.pagingMeta.Size = int64(reflect.ValueOf(listCtx.resourceList).Elem().Len())
+
+	items := reflect.ValueOf(listCtx.resourceList).Elem()
+	if args.Size > 0 && int64(items.Len()) > args.Size {
+		items.Set(items.Slice(0, int(args.Size)))
+		if columns, ok := keysetColumns(listCtx.sortKey, *d); ok {
+			last := reflect.Indirect(items.Index(items.Len() - 1))
+			if token, ok := nextCursor(listCtx.orderBy, columns, last); ok {
+				listCtx.pagingMeta.Continue = token
+			}
+		}
+	}
+	listCtx.pagingMeta.Size = int64(items.Len())
 
 	return nil
 }
@@ -272,7 +384,7 @@ func zeroSlice(i interface{}, cap int64) *errors.ServiceError {
 }
 
 // walk the TSL tree looking for fields like, e.g., creator.username, and then:
-// (1) look up the related table by its 1st part - creator
+// (1) look up the related table by its 1st part - creator, a relation of the model or a registered Link
 // (2) replace it by table name - creator.username -> accounts.username
 func (s *sqlGenericService) treeWalkForRelatedTables(listCtx *listContext, tslTree tsl.Node, genericDao *dao.GenericDao) (tsl.Node, *errors.ServiceError) {
 	resourceTable := (*genericDao).GetTableName()
@@ -287,6 +399,8 @@ func (s *sqlGenericService) treeWalkForRelatedTables(listCtx *listContext, tslTr
 			if !exists {
 				if relation, ok := (*genericDao).GetTableRelation(fieldName); ok {
 					listCtx.joins[fieldName] = relation
+				} else if relation, ok := linkTableRelation(resourceTable, fieldName); ok {
+					listCtx.joins[fieldName] = relation
 				} else {
 					return field, fmt.Errorf("%s is not a related resource of %s", fieldName, listCtx.resourceType)
 				}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"net/url"
 	"testing"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/dao"
@@ -24,6 +25,23 @@ type testModel struct {
 
 func (testModel) TableName() string { return "dinosaurs" }
 
+type testChildModel struct {
+	api.Meta
+	DinosaurID *string
+	Dinosaur   *testModel
+}
+
+func (testChildModel) TableName() string { return "fossils" }
+
+// Keeper is exported, gorm builds the join model of a many2many field from the name of its model
+type Keeper struct {
+	api.Meta
+	Name      string
+	Dinosaurs []testModel `gorm:"many2many:keeper_dinosaurs;joinForeignKey:KeeperID;joinReferences:DinosaurID"`
+}
+
+func (Keeper) TableName() string { return "keepers" }
+
 func TestSQLTranslation(t *testing.T) {
 	RegisterTestingT(t)
 	var dbFactory db.SessionFactory = dbmocks.NewMockSessionFactory()
@@ -47,7 +65,7 @@ func TestSQLTranslation(t *testing.T) {
 		var list []testModel
 		search := test["search"].(string)
 		errorMsg := test["error"].(string)
-		listCtx, model, serviceErr := genericService.newListContext(context.Background(), "", &ListArguments{Search: search}, &list)
+		listCtx, model, serviceErr := genericService.newListContext(context.Background(), &ListArguments{Search: search}, &list)
 		Expect(serviceErr).ToNot(HaveOccurred())
 		d := g.GetInstanceDao(context.Background(), model)
 		(*listCtx.disallowedFields)["id"] = "id"
@@ -70,7 +88,7 @@ func TestSQLTranslation(t *testing.T) {
 		search := test["search"].(string)
 		sqlReal := test["sql"].(string)
 		valuesReal := test["values"].(types.GomegaMatcher)
-		listCtx, _, serviceErr := genericService.newListContext(context.Background(), "", &ListArguments{Search: search}, &list)
+		listCtx, _, serviceErr := genericService.newListContext(context.Background(), &ListArguments{Search: search}, &list)
 		Expect(serviceErr).ToNot(HaveOccurred())
 		tslTree, err := tsl.ParseTSL(search)
 		Expect(err).ToNot(HaveOccurred())
@@ -82,3 +100,78 @@ func TestSQLTranslation(t *testing.T) {
 		Expect(values).To(valuesReal)
 	}
 }
+
+func TestListPreloads(t *testing.T) {
+	RegisterTestingT(t)
+	var dbFactory db.SessionFactory = dbmocks.NewMockSessionFactory()
+	defer dbFactory.Close()
+
+	g := dao.NewGenericDao(&dbFactory)
+	genericService := sqlGenericService{genericDao: g}
+
+	listArgs := NewListArguments(url.Values{"preload": {" dinosaur, ,"}})
+	Expect(listArgs.Preloads).To(Equal([]string{"dinosaur"}))
+
+	var list []testChildModel
+	listCtx, model, serviceErr := genericService.newListContext(context.Background(), listArgs, &list)
+	Expect(serviceErr).ToNot(HaveOccurred())
+	d := g.GetInstanceDao(context.Background(), model)
+	_, serviceErr = genericService.buildPreload(listCtx, &d)
+	Expect(serviceErr).ToNot(HaveOccurred())
+
+	// only relations of the model can be preloaded
+	listArgs = NewListArguments(url.Values{"preload": {"scientist"}})
+	listCtx, model, serviceErr = genericService.newListContext(context.Background(), listArgs, &list)
+	Expect(serviceErr).ToNot(HaveOccurred())
+	d = g.GetInstanceDao(context.Background(), model)
+	_, serviceErr = genericService.buildPreload(listCtx, &d)
+	Expect(serviceErr).To(HaveOccurred())
+	Expect(serviceErr.Code).To(Equal(errors.ErrorBadRequest))
+	Expect(serviceErr.Reason).To(Equal("scientist is not a related resource of testChildModel"))
+}
+
+func TestManyToManySearch(t *testing.T) {
+	RegisterTestingT(t)
+	var dbFactory db.SessionFactory = dbmocks.NewMockSessionFactory()
+	defer dbFactory.Close()
+
+	g := dao.NewGenericDao(&dbFactory)
+	genericService := sqlGenericService{genericDao: g}
+
+	// the model's many2many field joins through its join table
+	var list []Keeper
+	search := "dinosaurs.species = 'raptor'"
+	listCtx, model, serviceErr := genericService.newListContext(context.Background(), &ListArguments{Search: search}, &list)
+	Expect(serviceErr).ToNot(HaveOccurred())
+	d := g.GetInstanceDao(context.Background(), model)
+	tslTree, err := tsl.ParseTSL(search)
+	Expect(err).ToNot(HaveOccurred())
+	_, serviceErr = genericService.treeWalkForRelatedTables(listCtx, tslTree, &d)
+	Expect(serviceErr).ToNot(HaveOccurred())
+	Expect(listCtx.joins).To(HaveKey("dinosaurs"))
+	relation := listCtx.joins["dinosaurs"]
+	Expect(relation.TableName).To(Equal("keepers"))
+	Expect(relation.JoinTableName).To(Equal("keeper_dinosaurs"))
+	Expect(relation.JoinColumnName).To(Equal("keeper_id"))
+	Expect(relation.JoinForeignColumnName).To(Equal("dinosaur_id"))
+	Expect(relation.ForeignTableName).To(Equal("dinosaurs"))
+
+	// the linked Kind has no field for the link, it joins through the registered Link
+	RegisterLink(&Link{
+		Owner: "Keepers", OwnerTable: "keepers", Linked: "Dinosaurs", LinkedTable: "dinosaurs",
+		JoinTable: "keeper_dinosaurs", OwnerKey: "keeper_id", LinkedKey: "dinosaur_id",
+	})
+	var dinosaurs []testModel
+	search = "keepers.name = 'alan'"
+	listCtx, model, serviceErr = genericService.newListContext(context.Background(), &ListArguments{Search: search}, &dinosaurs)
+	Expect(serviceErr).ToNot(HaveOccurred())
+	d = g.GetInstanceDao(context.Background(), model)
+	tslTree, err = tsl.ParseTSL(search)
+	Expect(err).ToNot(HaveOccurred())
+	_, serviceErr = genericService.treeWalkForRelatedTables(listCtx, tslTree, &d)
+	Expect(serviceErr).ToNot(HaveOccurred())
+	relation = listCtx.joins["keepers"]
+	Expect(relation.JoinTableName).To(Equal("keeper_dinosaurs"))
+	Expect(relation.JoinColumnName).To(Equal("dinosaur_id"))
+	Expect(relation.JoinForeignColumnName).To(Equal("keeper_id"))
+}
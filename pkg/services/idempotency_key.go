@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	e "errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+// DefaultIdempotencyKeyTTL is how long the response to a request with an Idempotency-Key is replayed
+const DefaultIdempotencyKeyTTL = 24 * time.Hour
+
+type IdempotencyKeyServiceLocator func() IdempotencyKeyService
+
+// IdempotencyKeyService remembers the responses to requests sent with an Idempotency-Key until the key expires.
+// Keys are scoped to the caller of the request, see IdempotencyScope.
+type IdempotencyKeyService interface {
+	// Lookup returns the response remembered for key, or nil if the key is new or has expired. A key sent
+	// again with a different request, i.e. another requestHash, fails with errors.IdempotencyKeyReused.
+	Lookup(ctx context.Context, key, requestHash string) (*api.IdempotencyKey, *errors.ServiceError)
+	// Remember stores the response to the request of a key in the transaction of the request, so that the
+	// key is only remembered if the request's writes commit
+	Remember(ctx context.Context, idempotencyKey *api.IdempotencyKey) *errors.ServiceError
+	// PurgeExpired deletes the keys created before the cutoff, it is the PurgeFunc of idempotency keys
+	PurgeExpired(ctx context.Context, createdBefore time.Time) (int64, *errors.ServiceError)
+}
+
+func NewIdempotencyKeyService(idempotencyKeyDao dao.IdempotencyKeyDao, ttl time.Duration) IdempotencyKeyService {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyKeyTTL
+	}
+	return &sqlIdempotencyKeyService{
+		idempotencyKeyDao: idempotencyKeyDao,
+		ttl:               ttl,
+	}
+}
+
+var _ IdempotencyKeyService = &sqlIdempotencyKeyService{}
+
+type sqlIdempotencyKeyService struct {
+	idempotencyKeyDao dao.IdempotencyKeyDao
+	ttl               time.Duration
+}
+
+// IdempotencyScope returns the caller of the request in ctx, which the idempotency keys it sends belong to
+func IdempotencyScope(ctx context.Context) string {
+	tenant, _ := auth.GetTenantFromContext(ctx)
+	return tenant.OrganizationID + "/" + auth.GetUsernameFromContext(ctx)
+}
+
+func (s *sqlIdempotencyKeyService) Lookup(ctx context.Context, key, requestHash string) (*api.IdempotencyKey, *errors.ServiceError) {
+	scope := IdempotencyScope(ctx)
+	idempotencyKey, err := s.idempotencyKeyDao.Get(ctx, scope, key)
+	if e.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.GeneralError("Unable to look up idempotency key: %s", err)
+	}
+
+	cutoff := time.Now().Add(-s.ttl)
+	if idempotencyKey.CreatedAt.Before(cutoff) {
+		// expired but not purged yet, make room for the key to be remembered again
+		if err := s.idempotencyKeyDao.DeleteExpired(ctx, scope, key, cutoff); err != nil {
+			return nil, errors.GeneralError("Unable to delete expired idempotency key: %s", err)
+		}
+		return nil, nil
+	}
+	if idempotencyKey.RequestHash != requestHash {
+		return nil, errors.IdempotencyKeyReused("Idempotency-Key '%s' was already used for a different request", key)
+	}
+	return idempotencyKey, nil
+}
+
+func (s *sqlIdempotencyKeyService) Remember(ctx context.Context, idempotencyKey *api.IdempotencyKey) *errors.ServiceError {
+	idempotencyKey.Scope = IdempotencyScope(ctx)
+	if _, err := s.idempotencyKeyDao.Create(ctx, idempotencyKey); err != nil {
+		if strings.Contains(err.Error(), "violates unique constraint") {
+			// another request with the key committed first, this one rolls back and can be retried
+			return errors.VersionConflict("A request with Idempotency-Key '%s' was processed concurrently, retry to get its response", idempotencyKey.Key)
+		}
+		return errors.GeneralError("Unable to remember idempotency key: %s", err)
+	}
+	return nil
+}
+
+func (s *sqlIdempotencyKeyService) PurgeExpired(ctx context.Context, createdBefore time.Time) (int64, *errors.ServiceError) {
+	purged, err := s.idempotencyKeyDao.DeleteCreatedBefore(ctx, createdBefore)
+	if err != nil {
+		return 0, errors.GeneralError("Unable to purge expired idempotency keys: %s", err)
+	}
+	return purged, nil
+}
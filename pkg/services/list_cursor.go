@@ -0,0 +1,172 @@
+package services
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm/schema"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+// listCursor is the decoded continue token. It records the requested ordering of the listing and the
+// sort key of the last row returned, so the next page starts right after that row instead of at an offset.
+type listCursor struct {
+	OrderBy []string          `json:"o"`
+	Values  []json.RawMessage `json:"v"`
+}
+
+// keysetColumn is one column of the sort key of a listing
+type keysetColumn struct {
+	column string
+	desc   bool
+	field  *schema.Field
+}
+
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+// nullable reports whether a column can hold NULL: pointers and the sql.Null types, e.g. deleted_at.
+// Listings sort their NULLs last, whatever the direction, so the keyset condition can place them.
+func nullable(field *schema.Field) bool {
+	return field.FieldType.Kind() == reflect.Ptr || reflect.PointerTo(field.FieldType).Implements(scannerType)
+}
+
+func encodeCursor(cursor *listCursor) (string, error) {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(token string) (*listCursor, *errors.ServiceError) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.BadRequest("Invalid continue token")
+	}
+	cursor := &listCursor{}
+	if err := json.Unmarshal(data, cursor); err != nil || len(cursor.Values) == 0 {
+		return nil, errors.BadRequest("Invalid continue token")
+	}
+	return cursor, nil
+}
+
+// keysetColumns maps a cleaned ORDER BY list to columns of the resource table.
+// It returns false when the listing is ordered by anything else, e.g. a related table or a JSON property.
+func keysetColumns(orderBy []string, d dao.GenericDao) ([]keysetColumn, bool) {
+	columns := make([]keysetColumn, 0, len(orderBy))
+	for _, o := range orderBy {
+		parts := strings.Split(o, " ")
+		if len(parts) != 2 {
+			return nil, false
+		}
+		field, ok := d.GetColumnField(parts[0])
+		if !ok {
+			return nil, false
+		}
+		columns = append(columns, keysetColumn{
+			column: fmt.Sprintf("%s.%s", d.GetTableName(), field.DBName),
+			desc:   parts[1] == "desc",
+			field:  field,
+		})
+	}
+	return columns, true
+}
+
+// keysetCondition builds the WHERE clause selecting rows that sort after the given key, e.g. for
+// "a asc, b desc": (a > ?) OR (a = ? AND b < ?). A nil value is a NULL, which sorts last: nothing sorts
+// after it in its own column, and the NULLs of a nullable column sort after every value.
+func keysetCondition(columns []keysetColumn, values []interface{}) (string, []any) {
+	var disjuncts []string
+	var args []any
+	for i, c := range columns {
+		if values[i] == nil {
+			continue
+		}
+		var conjuncts []string
+		for j := 0; j < i; j++ {
+			if values[j] == nil {
+				conjuncts = append(conjuncts, fmt.Sprintf("%s IS NULL", columns[j].column))
+				continue
+			}
+			conjuncts = append(conjuncts, fmt.Sprintf("%s = ?", columns[j].column))
+			args = append(args, values[j])
+		}
+		op := ">"
+		if c.desc {
+			op = "<"
+		}
+		if nullable(c.field) {
+			conjuncts = append(conjuncts, fmt.Sprintf("(%s %s ? OR %s IS NULL)", c.column, op, c.column))
+		} else {
+			conjuncts = append(conjuncts, fmt.Sprintf("%s %s ?", c.column, op))
+		}
+		args = append(args, values[i])
+		disjuncts = append(disjuncts, "("+strings.Join(conjuncts, " AND ")+")")
+	}
+	return strings.Join(disjuncts, " OR "), args
+}
+
+// cursorValues decodes the sort key of a continue token into the Go types of the key columns, and
+// its NULLs into nil
+func cursorValues(cursor *listCursor, columns []keysetColumn) ([]interface{}, *errors.ServiceError) {
+	if len(cursor.Values) != len(columns) {
+		return nil, errors.BadRequest("Invalid continue token")
+	}
+	values := make([]interface{}, len(columns))
+	for i, c := range columns {
+		if string(cursor.Values[i]) == "null" {
+			if !nullable(c.field) {
+				return nil, errors.BadRequest("Invalid continue token")
+			}
+			continue
+		}
+		value := reflect.New(c.field.FieldType)
+		if err := json.Unmarshal(cursor.Values[i], value.Interface()); err != nil {
+			return nil, errors.BadRequest("Invalid continue token")
+		}
+		values[i] = value.Elem().Interface()
+	}
+	return values, nil
+}
+
+// nextCursor returns the continue token pointing after row, with the NULLs of its sort key as JSON
+// null. columns is the full sort key while orderBy is the ordering the client asked for.
+func nextCursor(orderBy []string, columns []keysetColumn, row reflect.Value) (string, bool) {
+	cursor := &listCursor{OrderBy: orderBy}
+	for _, c := range columns {
+		value, _ := c.field.ValueOf(row)
+		if isNull(value) {
+			cursor.Values = append(cursor.Values, json.RawMessage("null"))
+			continue
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return "", false
+		}
+		cursor.Values = append(cursor.Values, data)
+	}
+	token, err := encodeCursor(cursor)
+	if err != nil {
+		return "", false
+	}
+	return token, true
+}
+
+// isNull reports whether value is stored as NULL, a nil pointer or an invalid sql.Null type
+func isNull(value interface{}) bool {
+	if v := reflect.ValueOf(value); !v.IsValid() || v.Kind() == reflect.Ptr && v.IsNil() {
+		return true
+	}
+	if valuer, ok := value.(driver.Valuer); ok {
+		stored, err := valuer.Value()
+		return err == nil && stored == nil
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	dbmocks "github.com/openshift-online/rh-trex-ai/pkg/db/mocks"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+func TestKeysetPagination(t *testing.T) {
+	RegisterTestingT(t)
+	var dbFactory db.SessionFactory = dbmocks.NewMockSessionFactory()
+	defer dbFactory.Close()
+
+	d := dao.NewGenericDao(&dbFactory).GetInstanceDao(context.Background(), &testModel{})
+
+	orderBy := []string{"created_at desc"}
+	columns, ok := keysetColumns(append(orderBy, "dinosaurs.id asc"), d)
+	Expect(ok).To(BeTrue())
+	Expect(columns).To(HaveLen(2))
+
+	sql, values := keysetCondition(columns, []interface{}{"t", "id"})
+	Expect(sql).To(Equal("(dinosaurs.created_at < ?) OR (dinosaurs.created_at = ? AND dinosaurs.id > ?)"))
+	Expect(values).To(Equal([]any{"t", "t", "id"}))
+
+	// the token carries the sort key of the last row and decodes into the column types
+	createdAt := time.Date(2026, 10, 17, 9, 30, 0, 123456000, time.UTC)
+	row := testModel{Meta: api.Meta{ID: "abc", CreatedAt: createdAt}, Species: "raptor"}
+	token, ok := nextCursor(orderBy, columns, reflect.ValueOf(row))
+	Expect(ok).To(BeTrue())
+
+	cursor, serviceErr := decodeCursor(token)
+	Expect(serviceErr).To(BeNil())
+	Expect(cursor.OrderBy).To(Equal(orderBy))
+	decoded, serviceErr := cursorValues(cursor, columns)
+	Expect(serviceErr).To(BeNil())
+	Expect(decoded[0].(time.Time).Equal(createdAt)).To(BeTrue())
+	Expect(decoded[1]).To(Equal("abc"))
+
+	// only columns of the resource table can be part of a keyset
+	_, ok = keysetColumns([]string{"creator.name asc"}, d)
+	Expect(ok).To(BeFalse())
+	_, ok = keysetColumns([]string{"properties ->> 'name' asc"}, d)
+	Expect(ok).To(BeFalse())
+
+	_, serviceErr = decodeCursor("not a token")
+	Expect(serviceErr).ToNot(BeNil())
+	Expect(serviceErr.Code).To(Equal(errors.ErrorBadRequest))
+	_, serviceErr = cursorValues(&listCursor{OrderBy: orderBy}, columns)
+	Expect(serviceErr).ToNot(BeNil())
+}
+
+func TestKeysetPaginationNulls(t *testing.T) {
+	RegisterTestingT(t)
+	var dbFactory db.SessionFactory = dbmocks.NewMockSessionFactory()
+	defer dbFactory.Close()
+
+	d := dao.NewGenericDao(&dbFactory).GetInstanceDao(context.Background(), &testModel{})
+
+	orderBy := []string{"deleted_at desc"}
+	columns, ok := keysetColumns(append(orderBy, "dinosaurs.id asc"), d)
+	Expect(ok).To(BeTrue())
+	Expect(nullable(columns[0].field)).To(BeTrue())
+	Expect(nullable(columns[1].field)).To(BeFalse())
+
+	// NULLs sort last, so they follow every value of the column
+	sql, values := keysetCondition(columns, []interface{}{"t", "id"})
+	Expect(sql).To(Equal("((dinosaurs.deleted_at < ? OR dinosaurs.deleted_at IS NULL)) OR (dinosaurs.deleted_at = ? AND dinosaurs.id > ?)"))
+	Expect(values).To(Equal([]any{"t", "t", "id"}))
+
+	// a row without deleted_at still gets a token, and the next page stays among the NULLs
+	row := testModel{Meta: api.Meta{ID: "abc"}}
+	token, ok := nextCursor(orderBy, columns, reflect.ValueOf(row))
+	Expect(ok).To(BeTrue())
+	cursor, serviceErr := decodeCursor(token)
+	Expect(serviceErr).To(BeNil())
+	decoded, serviceErr := cursorValues(cursor, columns)
+	Expect(serviceErr).To(BeNil())
+	Expect(decoded).To(Equal([]interface{}{nil, "abc"}))
+
+	sql, values = keysetCondition(columns, decoded)
+	Expect(sql).To(Equal("(dinosaurs.deleted_at IS NULL AND dinosaurs.id > ?)"))
+	Expect(values).To(Equal([]any{"abc"}))
+
+	// columns that can't be NULL don't take one from a token
+	_, serviceErr = cursorValues(&listCursor{OrderBy: orderBy, Values: []json.RawMessage{[]byte("null"), []byte("null")}}, columns)
+	Expect(serviceErr).ToNot(BeNil())
+}
+
+func TestContinueTokenOrderBy(t *testing.T) {
+	RegisterTestingT(t)
+	var dbFactory db.SessionFactory = dbmocks.NewMockSessionFactory()
+	defer dbFactory.Close()
+
+	g := dao.NewGenericDao(&dbFactory)
+	genericService := sqlGenericService{genericDao: g}
+	token, err := encodeCursor(&listCursor{OrderBy: []string{"species asc"}, Values: []json.RawMessage{[]byte(`"raptor"`), []byte(`"abc"`)}})
+	Expect(err).ToNot(HaveOccurred())
+
+	build := func(args *ListArguments) (*listContext, *errors.ServiceError) {
+		var list []testModel
+		listCtx, model, serviceErr := genericService.newListContext(context.Background(), args, &list)
+		Expect(serviceErr).To(BeNil())
+		d := g.GetInstanceDao(context.Background(), model)
+		_, serviceErr = genericService.buildOrderBy(listCtx, &d)
+		return listCtx, serviceErr
+	}
+
+	// without a token every listing is ordered by id last
+	listCtx, serviceErr := build(&ListArguments{OrderBy: []string{"species"}})
+	Expect(serviceErr).To(BeNil())
+	Expect(listCtx.sortKey).To(Equal([]string{"species asc", "dinosaurs.id asc"}))
+	Expect(listCtx.cursor).To(BeNil())
+
+	// the token's ordering applies when the request doesn't repeat it
+	listCtx, serviceErr = build(&ListArguments{Continue: token})
+	Expect(serviceErr).To(BeNil())
+	Expect(listCtx.sortKey).To(Equal([]string{"species asc", "dinosaurs.id asc"}))
+	Expect(listCtx.cursor).ToNot(BeNil())
+
+	listCtx, serviceErr = build(&ListArguments{Continue: token, OrderBy: []string{"species asc"}})
+	Expect(serviceErr).To(BeNil())
+	Expect(listCtx.cursor).ToNot(BeNil())
+
+	_, serviceErr = build(&ListArguments{Continue: token, OrderBy: []string{"species desc"}})
+	Expect(serviceErr).ToNot(BeNil())
+	Expect(serviceErr.Code).To(Equal(errors.ErrorBadRequest))
+}
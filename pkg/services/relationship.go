@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+// OnDeleteAction is what happens to the children of a relationship when their parent is deleted
+type OnDeleteAction string
+
+const (
+	// OnDeleteRestrict refuses to delete a parent while it has children
+	OnDeleteRestrict OnDeleteAction = "restrict"
+	// OnDeleteCascade deletes the children of a deleted parent. The child Kind deletes them in a controller
+	// for the delete events of the parent, so that every deleted child has its own event and audit trail.
+	OnDeleteCascade OnDeleteAction = "cascade"
+)
+
+// ReferentialAction is the ON DELETE action of the foreign key constraint of the relationship, see
+// db.FKMigration. Soft deletes never reach the constraint, it only guards purges of the parent.
+func (a OnDeleteAction) ReferentialAction() string {
+	if a == OnDeleteCascade {
+		return "CASCADE"
+	}
+	return "RESTRICT"
+}
+
+// Relationship is a foreign key of a child Kind to its parent Kind, e.g. fossils.dinosaur_id to dinosaurs.id
+type Relationship struct {
+	// Parent and Child are the event sources of the Kinds, e.g. Dinosaurs and Fossils
+	Parent      string
+	ParentTable string
+	Child       string
+	ChildTable  string
+	// ForeignKey is the column of the child table that refers to the parent, e.g. dinosaur_id
+	ForeignKey string
+	OnDelete   OnDeleteAction
+}
+
+var relationshipRegistry []*Relationship
+
+// RegisterRelationship declares a foreign key of a Kind, its plugin registers it in init
+func RegisterRelationship(relationship *Relationship) {
+	relationshipRegistry = append(relationshipRegistry, relationship)
+}
+
+// ChildRelationships returns the registered relationships of the children of parent
+func ChildRelationships(parent string) []*Relationship {
+	var relationships []*Relationship
+	for _, relationship := range relationshipRegistry {
+		if relationship.Parent == parent {
+			relationships = append(relationships, relationship)
+		}
+	}
+	return relationships
+}
+
+// FindRelationship returns the registered relationship of the foreign key of child
+func FindRelationship(child, foreignKey string) (*Relationship, bool) {
+	for _, relationship := range relationshipRegistry {
+		if relationship.Child == child && relationship.ForeignKey == foreignKey {
+			return relationship, true
+		}
+	}
+	return nil, false
+}
+
+// Link is a many-to-many relationship of two Kinds through a join table, e.g. scientists and dinosaurs through
+// scientist_dinosaurs. The Kind declaring it, the owner, serves the routes that link and unlink them.
+type Link struct {
+	// Owner and Linked are the event sources of the Kinds, e.g. Scientists and Dinosaurs
+	Owner       string
+	OwnerTable  string
+	Linked      string
+	LinkedTable string
+	JoinTable   string
+	// OwnerKey and LinkedKey are the columns of the join table that refer to each Kind, e.g. scientist_id
+	OwnerKey  string
+	LinkedKey string
+}
+
+var linkRegistry []*Link
+
+// RegisterLink declares a many-to-many relationship of a Kind, its plugin registers it in init
+func RegisterLink(link *Link) {
+	linkRegistry = append(linkRegistry, link)
+}
+
+// FindLink returns the registered link of owner to linked
+func FindLink(owner, linked string) (*Link, bool) {
+	for _, link := range linkRegistry {
+		if link.Owner == owner && link.Linked == linked {
+			return link, true
+		}
+	}
+	return nil, false
+}
+
+// linkTableRelation joins table to relatedTable through the join table of a registered link, in either
+// direction. It lets the search of a Kind refer to the Kinds it is linked with, e.g. scientists.name on
+// the dinosaurs, which have no field for the scientists that study them.
+func linkTableRelation(table, relatedTable string) (dao.TableRelation, bool) {
+	for _, link := range linkRegistry {
+		switch {
+		case link.OwnerTable == table && link.LinkedTable == relatedTable:
+			return dao.TableRelation{
+				TableName: table, ColumnName: "id", ForeignTableName: relatedTable, ForeignColumnName: "id",
+				JoinTableName: link.JoinTable, JoinColumnName: link.OwnerKey, JoinForeignColumnName: link.LinkedKey,
+			}, true
+		case link.LinkedTable == table && link.OwnerTable == relatedTable:
+			return dao.TableRelation{
+				TableName: table, ColumnName: "id", ForeignTableName: relatedTable, ForeignColumnName: "id",
+				JoinTableName: link.JoinTable, JoinColumnName: link.LinkedKey, JoinForeignColumnName: link.OwnerKey,
+			}, true
+		}
+	}
+	return dao.TableRelation{}, false
+}
+
+// RelationshipService enforces the registered relationships on the writes of the Kinds
+type RelationshipService interface {
+	// CheckParent fails with a validation error unless the foreign key of child refers to a parent that
+	// exists and is visible to the caller
+	CheckParent(ctx context.Context, child, foreignKey, parentID string) *errors.ServiceError
+	// CheckDelete fails with a conflict while the parent id still has children in a restrict relationship
+	CheckDelete(ctx context.Context, parent, id string) *errors.ServiceError
+	// CheckLinked fails with not found unless the linked id of a link of owner exists and is visible to the caller
+	CheckLinked(ctx context.Context, owner, linked, linkedID string) *errors.ServiceError
+}
+
+func NewRelationshipService(relationshipDao dao.RelationshipDao) RelationshipService {
+	return &sqlRelationshipService{relationshipDao: relationshipDao}
+}
+
+var _ RelationshipService = &sqlRelationshipService{}
+
+type sqlRelationshipService struct {
+	relationshipDao dao.RelationshipDao
+}
+
+func (s *sqlRelationshipService) CheckParent(ctx context.Context, child, foreignKey, parentID string) *errors.ServiceError {
+	relationship, found := FindRelationship(child, foreignKey)
+	if !found {
+		return errors.GeneralError("No relationship is registered for %s of %s", foreignKey, child)
+	}
+	exists, err := s.relationshipDao.Exists(ctx, relationship.ParentTable, parentID)
+	if err != nil {
+		return errors.GeneralError("Unable to find %s with id='%s': %s", relationship.Parent, parentID, err)
+	}
+	if !exists {
+		return errors.Validation("%s '%s' doesn't refer to an existing resource of %s", foreignKey, parentID, relationship.Parent)
+	}
+	return nil
+}
+
+func (s *sqlRelationshipService) CheckDelete(ctx context.Context, parent, id string) *errors.ServiceError {
+	for _, relationship := range ChildRelationships(parent) {
+		if relationship.OnDelete != OnDeleteRestrict {
+			continue
+		}
+		count, err := s.relationshipDao.CountReferences(ctx, relationship.ChildTable, relationship.ForeignKey, id)
+		if err != nil {
+			return errors.GeneralError("Unable to count the %s of %s with id='%s': %s", relationship.Child, parent, id, err)
+		}
+		if count > 0 {
+			return errors.Conflict("%s with id='%s' still has %d %s, delete them first", parent, id, count, relationship.Child)
+		}
+	}
+	return nil
+}
+
+func (s *sqlRelationshipService) CheckLinked(ctx context.Context, owner, linked, linkedID string) *errors.ServiceError {
+	link, found := FindLink(owner, linked)
+	if !found {
+		return errors.GeneralError("No link is registered for %s of %s", linked, owner)
+	}
+	exists, err := s.relationshipDao.Exists(ctx, link.LinkedTable, linkedID)
+	if err != nil {
+		return errors.GeneralError("Unable to find %s with id='%s': %s", linked, linkedID, err)
+	}
+	if !exists {
+		return errors.NotFound("No resource of %s with id='%s' exists", linked, linkedID)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/dao/mocks"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+func TestRelationshipService(t *testing.T) {
+	RegisterTestingT(t)
+
+	RegisterRelationship(&Relationship{
+		Parent: "Nests", ParentTable: "nests", Child: "Eggs", ChildTable: "eggs",
+		ForeignKey: "nest_id", OnDelete: OnDeleteRestrict,
+	})
+	RegisterRelationship(&Relationship{
+		Parent: "Nests", ParentTable: "nests", Child: "Feathers", ChildTable: "feathers",
+		ForeignKey: "nest_id", OnDelete: OnDeleteCascade,
+	})
+	Expect(ChildRelationships("Nests")).To(HaveLen(2))
+	Expect(OnDeleteCascade.ReferentialAction()).To(Equal("CASCADE"))
+	Expect(OnDeleteRestrict.ReferentialAction()).To(Equal("RESTRICT"))
+
+	ctx := context.Background()
+	relationshipDao := mocks.NewRelationshipDao()
+	relationshipDao.Insert("nests", map[string]string{"id": "nest-1"})
+	relationshipDao.Insert("nests", map[string]string{"id": "nest-2"})
+	relationships := NewRelationshipService(relationshipDao)
+
+	Expect(relationships.CheckParent(ctx, "Eggs", "nest_id", "nest-1")).To(BeNil())
+	svcErr := relationships.CheckParent(ctx, "Eggs", "nest_id", "nest-3")
+	Expect(svcErr).NotTo(BeNil())
+	Expect(svcErr.Code).To(Equal(errors.ErrorValidation))
+	Expect(relationships.CheckParent(ctx, "Eggs", "owner_id", "nest-1")).NotTo(BeNil())
+
+	// children of cascade relationships don't hold their parent back
+	relationshipDao.Insert("feathers", map[string]string{"id": "feather-1", "nest_id": "nest-1"})
+	Expect(relationships.CheckDelete(ctx, "Nests", "nest-1")).To(BeNil())
+
+	relationshipDao.Insert("eggs", map[string]string{"id": "egg-1", "nest_id": "nest-1"})
+	svcErr = relationships.CheckDelete(ctx, "Nests", "nest-1")
+	Expect(svcErr).NotTo(BeNil())
+	Expect(svcErr.Code).To(Equal(errors.ErrorConflict))
+	Expect(svcErr.Reason).To(ContainSubstring("still has 1 Eggs"))
+	Expect(relationships.CheckDelete(ctx, "Nests", "nest-2")).To(BeNil())
+}
+
+func TestLinks(t *testing.T) {
+	RegisterTestingT(t)
+
+	RegisterLink(&Link{
+		Owner: "Keepers", OwnerTable: "keepers", Linked: "Nests", LinkedTable: "nests",
+		JoinTable: "keeper_nests", OwnerKey: "keeper_id", LinkedKey: "nest_id",
+	})
+	_, found := FindLink("Keepers", "Nests")
+	Expect(found).To(BeTrue())
+	_, found = FindLink("Nests", "Keepers")
+	Expect(found).To(BeFalse())
+
+	// the join table is walked from either end of the link
+	relation, found := linkTableRelation("keepers", "nests")
+	Expect(found).To(BeTrue())
+	Expect(relation.JoinTableName).To(Equal("keeper_nests"))
+	Expect(relation.JoinColumnName).To(Equal("keeper_id"))
+	Expect(relation.JoinForeignColumnName).To(Equal("nest_id"))
+	relation, found = linkTableRelation("nests", "keepers")
+	Expect(found).To(BeTrue())
+	Expect(relation.JoinColumnName).To(Equal("nest_id"))
+	Expect(relation.JoinForeignColumnName).To(Equal("keeper_id"))
+	_, found = linkTableRelation("nests", "eggs")
+	Expect(found).To(BeFalse())
+
+	ctx := context.Background()
+	relationshipDao := mocks.NewRelationshipDao()
+	relationshipDao.Insert("nests", map[string]string{"id": "nest-1"})
+	relationships := NewRelationshipService(relationshipDao)
+
+	Expect(relationships.CheckLinked(ctx, "Keepers", "Nests", "nest-1")).To(BeNil())
+	svcErr := relationships.CheckLinked(ctx, "Keepers", "Nests", "nest-2")
+	Expect(svcErr).NotTo(BeNil())
+	Expect(svcErr.Code).To(Equal(errors.ErrorNotFound))
+	svcErr = relationships.CheckLinked(ctx, "Keepers", "Eggs", "egg-1")
+	Expect(svcErr).NotTo(BeNil())
+	Expect(svcErr.Code).To(Equal(errors.ErrorGeneral))
+}
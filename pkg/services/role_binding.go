@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+type RoleBindingServiceLocator func() RoleBindingService
+
+// RoleBindingService manages the role bindings stored in the database. It is the RoleBindingStore
+// the policy engine reads them from.
+type RoleBindingService interface {
+	auth.RoleBindingStore
+
+	Get(ctx context.Context, id string) (*api.RoleBinding, *errors.ServiceError)
+	Create(ctx context.Context, roleBinding *api.RoleBinding) (*api.RoleBinding, *errors.ServiceError)
+	Delete(ctx context.Context, id string) *errors.ServiceError
+}
+
+func NewRoleBindingService(roleBindingDao dao.RoleBindingDao) RoleBindingService {
+	return &sqlRoleBindingService{
+		roleBindingDao: roleBindingDao,
+	}
+}
+
+var _ RoleBindingService = &sqlRoleBindingService{}
+
+type sqlRoleBindingService struct {
+	roleBindingDao dao.RoleBindingDao
+}
+
+func (s *sqlRoleBindingService) Get(ctx context.Context, id string) (*api.RoleBinding, *errors.ServiceError) {
+	roleBinding, err := s.roleBindingDao.Get(ctx, id)
+	if err != nil {
+		return nil, HandleGetError("RoleBinding", "id", id, err)
+	}
+	return roleBinding, nil
+}
+
+func (s *sqlRoleBindingService) Create(ctx context.Context, roleBinding *api.RoleBinding) (*api.RoleBinding, *errors.ServiceError) {
+	roleBinding, err := s.roleBindingDao.Create(ctx, roleBinding)
+	if err != nil {
+		return nil, HandleCreateError("RoleBinding", err)
+	}
+	return roleBinding, nil
+}
+
+func (s *sqlRoleBindingService) Delete(ctx context.Context, id string) *errors.ServiceError {
+	if err := s.roleBindingDao.Delete(ctx, id); err != nil {
+		return HandleDeleteError("RoleBinding", errors.GeneralError("Unable to delete role binding: %s", err))
+	}
+	return nil
+}
+
+func (s *sqlRoleBindingService) RolesForUser(ctx context.Context, username string) ([]string, error) {
+	roleBindings, err := s.roleBindingDao.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]string, 0, len(roleBindings))
+	for _, roleBinding := range roleBindings {
+		roles = append(roles, roleBinding.Role)
+	}
+	return roles, nil
+}
@@ -9,12 +9,20 @@ import (
 // ListArguments are arguments relevant for listing objects.
 // This struct is common to all service List funcs in this package
 type ListArguments struct {
-	Page     int
-	Size     int64
+	Page int
+	Size int64
+	// Preloads are the related resources loaded along with the listed ones, e.g. "dinosaur" for fossils
 	Preloads []string
 	Search   string
 	OrderBy  []string
 	Fields   []string
+	// Continue is the opaque token returned with the previous page. When set, the list resumes right
+	// after the last row of that page (keyset pagination) and Page is ignored.
+	Continue string
+	// SkipCount omits the total row count, which is expensive on large tables
+	SkipCount bool
+	// IncludeDeleted lists soft-deleted rows along with live ones
+	IncludeDeleted bool
 }
 
 // ~65500 is the maximum number of parameters that can be provided to a postgres WHERE IN clause
@@ -45,6 +53,22 @@ func NewListArguments(params url.Values) *ListArguments {
 	if v := strings.Trim(params.Get("orderBy"), " "); v != "" {
 		listArgs.OrderBy = strings.Split(v, ",")
 	}
+	if v := strings.Trim(params.Get("continue"), " "); v != "" {
+		listArgs.Continue = v
+	}
+	if v := strings.Trim(params.Get("skipCount"), " "); v != "" {
+		listArgs.SkipCount, _ = strconv.ParseBool(v)
+	}
+	if v := strings.Trim(params.Get("includeDeleted"), " "); v != "" {
+		listArgs.IncludeDeleted, _ = strconv.ParseBool(v)
+	}
+	if v := strings.Trim(params.Get("preload"), " "); v != "" {
+		for _, preload := range strings.Split(v, ",") {
+			if preload = strings.Trim(preload, " "); preload != "" {
+				listArgs.Preloads = append(listArgs.Preloads, preload)
+			}
+		}
+	}
 	if v := strings.Trim(params.Get("fields"), " "); v != "" {
 		fields := strings.Split(v, ",")
 		idNotPresent := true
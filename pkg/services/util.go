@@ -6,6 +6,7 @@ import (
 
 	"gorm.io/gorm"
 
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
 	"github.com/openshift-online/rh-trex-ai/pkg/errors"
 )
 
@@ -36,16 +37,31 @@ func HandleCreateError(resourceType string, err error) *errors.ServiceError {
 	if strings.Contains(err.Error(), "violates unique constraint") {
 		return errors.Conflict("This %s already exists", resourceType)
 	}
+	if strings.Contains(err.Error(), "violates foreign key constraint") {
+		return errors.Validation("This %s refers to a resource that doesn't exist", resourceType)
+	}
 	return errors.GeneralError("Unable to create %s: %s", resourceType, err.Error())
 }
 
 func HandleUpdateError(resourceType string, err error) *errors.ServiceError {
-	if strings.Contains(err.Error(), "violates unique constraint") {
+	if e.Is(err, db.ErrResourceVersionConflict) {
+		return errors.VersionConflict("%s was modified by another request, retry with the current version", resourceType)
+	}
+	if strings.Contains(err.Error(), "violates unique constraint") || strings.Contains(err.Error(), "violates foreign key constraint") {
 		return errors.Conflict("Changes to %s conflict with existing records", resourceType)
 	}
 	return errors.GeneralError("Unable to update %s: %s", resourceType, err.Error())
 }
 
 func HandleDeleteError(resourceType string, err error) *errors.ServiceError {
+	if e.Is(err, gorm.ErrRecordNotFound) {
+		return errors.NotFound("%s not found", resourceType)
+	}
+	if e.Is(err, db.ErrResourceVersionConflict) {
+		return errors.PreconditionFailed("%s was modified, its resource version doesn't match the request precondition", resourceType)
+	}
+	if strings.Contains(err.Error(), "violates foreign key constraint") {
+		return errors.Conflict("%s is still referred to by other resources", resourceType)
+	}
 	return errors.GeneralError("Unable to delete %s: %s", resourceType, err.Error())
 }
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+type WebhookServiceLocator func() WebhookService
+
+// WebhookService manages the webhooks of the organizations and the deliveries of events to them. The
+// webhooks controller of the KindControllerManager enqueues the deliveries of every event, and the
+// WebhookController sends them and records the outcome of every attempt.
+type WebhookService interface {
+	Get(ctx context.Context, id string) (*api.Webhook, *errors.ServiceError)
+	Create(ctx context.Context, webhook *api.Webhook) (*api.Webhook, *errors.ServiceError)
+	Replace(ctx context.Context, webhook *api.Webhook) (*api.Webhook, *errors.ServiceError)
+	Delete(ctx context.Context, id string) *errors.ServiceError
+	// FindBySource returns the webhooks of every organization subscribed to the events of source
+	FindBySource(ctx context.Context, source string) (api.WebhookList, *errors.ServiceError)
+
+	// Enqueue schedules the first attempt to deliver event to webhook with payload
+	Enqueue(ctx context.Context, webhook *api.Webhook, event *api.Event, payload []byte) *errors.ServiceError
+	// FindDueDeliveries returns up to limit deliveries due now, the oldest first
+	FindDueDeliveries(ctx context.Context, limit int) (api.WebhookDeliveryList, *errors.ServiceError)
+	// RecordAttempt records the outcome of a delivery. A failed attempt (attemptErr != nil) schedules the next
+	// one at retryAt, or dead-letters the delivery if retryAt is nil.
+	RecordAttempt(ctx context.Context, delivery *api.WebhookDelivery, statusCode int, attemptErr error, retryAt *time.Time) (*api.WebhookDelivery, *errors.ServiceError)
+	// PurgeDeliveries deletes the attempts made before the cutoff, it is the PurgeFunc of webhook deliveries
+	PurgeDeliveries(ctx context.Context, createdBefore time.Time) (int64, *errors.ServiceError)
+}
+
+func NewWebhookService(webhookDao dao.WebhookDao) WebhookService {
+	return &sqlWebhookService{
+		webhookDao: webhookDao,
+	}
+}
+
+var _ WebhookService = &sqlWebhookService{}
+
+type sqlWebhookService struct {
+	webhookDao dao.WebhookDao
+}
+
+func (s *sqlWebhookService) Get(ctx context.Context, id string) (*api.Webhook, *errors.ServiceError) {
+	webhook, err := s.webhookDao.Get(ctx, id)
+	if err != nil {
+		return nil, HandleGetError("Webhook", "id", id, err)
+	}
+	return webhook, nil
+}
+
+func (s *sqlWebhookService) Create(ctx context.Context, webhook *api.Webhook) (*api.Webhook, *errors.ServiceError) {
+	webhook, err := s.webhookDao.Create(ctx, webhook)
+	if err != nil {
+		return nil, HandleCreateError("Webhook", err)
+	}
+	return webhook, nil
+}
+
+func (s *sqlWebhookService) Replace(ctx context.Context, webhook *api.Webhook) (*api.Webhook, *errors.ServiceError) {
+	webhook, err := s.webhookDao.Replace(ctx, webhook)
+	if err != nil {
+		return nil, HandleUpdateError("Webhook", err)
+	}
+	return webhook, nil
+}
+
+func (s *sqlWebhookService) Delete(ctx context.Context, id string) *errors.ServiceError {
+	if err := s.webhookDao.Delete(ctx, id); err != nil {
+		return HandleDeleteError("Webhook", errors.GeneralError("Unable to delete webhook: %s", err))
+	}
+	return nil
+}
+
+func (s *sqlWebhookService) FindBySource(ctx context.Context, source string) (api.WebhookList, *errors.ServiceError) {
+	webhooks, err := s.webhookDao.FindBySource(ctx, source)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to find the webhooks of %s: %s", source, err)
+	}
+	return webhooks, nil
+}
+
+func (s *sqlWebhookService) Enqueue(ctx context.Context, webhook *api.Webhook, event *api.Event, payload []byte) *errors.ServiceError {
+	delivery := &api.WebhookDelivery{
+		WebhookID:      webhook.ID,
+		OrganizationID: webhook.OrganizationID,
+		EventID:        event.ID,
+		Source:         event.Source,
+		SourceID:       event.SourceID,
+		EventType:      event.EventType,
+		Attempt:        1,
+		Payload:        string(payload),
+		Status:         api.WebhookDeliveryPending,
+		ScheduledAt:    time.Now(),
+	}
+	if _, err := s.webhookDao.CreateDelivery(ctx, delivery); err != nil {
+		return errors.GeneralError("Unable to enqueue the delivery of event %s to webhook %s: %s", event.ID, webhook.ID, err)
+	}
+	return nil
+}
+
+func (s *sqlWebhookService) FindDueDeliveries(ctx context.Context, limit int) (api.WebhookDeliveryList, *errors.ServiceError) {
+	deliveries, err := s.webhookDao.FindDueDeliveries(ctx, time.Now(), limit)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to find due webhook deliveries: %s", err)
+	}
+	return deliveries, nil
+}
+
+func (s *sqlWebhookService) RecordAttempt(ctx context.Context, delivery *api.WebhookDelivery, statusCode int, attemptErr error, retryAt *time.Time) (*api.WebhookDelivery, *errors.ServiceError) {
+	now := time.Now()
+	delivery.AttemptedAt = &now
+	delivery.StatusCode = statusCode
+	switch {
+	case attemptErr == nil:
+		delivery.Status = api.WebhookDeliverySucceeded
+		delivery.Error = ""
+	case retryAt != nil:
+		delivery.Status = api.WebhookDeliveryFailed
+		delivery.Error = attemptErr.Error()
+	default:
+		delivery.Status = api.WebhookDeliveryDeadLettered
+		delivery.Error = attemptErr.Error()
+	}
+	if _, err := s.webhookDao.ReplaceDelivery(ctx, delivery); err != nil {
+		return nil, errors.GeneralError("Unable to record webhook delivery %s: %s", delivery.ID, err)
+	}
+
+	if delivery.Status != api.WebhookDeliveryFailed {
+		return delivery, nil
+	}
+	next := &api.WebhookDelivery{
+		WebhookID:      delivery.WebhookID,
+		OrganizationID: delivery.OrganizationID,
+		EventID:        delivery.EventID,
+		Source:         delivery.Source,
+		SourceID:       delivery.SourceID,
+		EventType:      delivery.EventType,
+		Attempt:        delivery.Attempt + 1,
+		Payload:        delivery.Payload,
+		Status:         api.WebhookDeliveryPending,
+		ScheduledAt:    *retryAt,
+	}
+	if _, err := s.webhookDao.CreateDelivery(ctx, next); err != nil {
+		return nil, errors.GeneralError("Unable to schedule the next attempt of webhook delivery %s: %s", delivery.ID, err)
+	}
+	return delivery, nil
+}
+
+func (s *sqlWebhookService) PurgeDeliveries(ctx context.Context, createdBefore time.Time) (int64, *errors.ServiceError) {
+	purged, err := s.webhookDao.DeleteDeliveriesCreatedBefore(ctx, createdBefore)
+	if err != nil {
+		return 0, errors.GeneralError("Unable to purge webhook deliveries: %s", err)
+	}
+	return purged, nil
+}
@@ -102,6 +102,21 @@ func (h *BaseHelper) NewAccount(username, name, email string) *amv1.Account {
 	return acct
 }
 
+// NewRandAccountInOrganization returns a random account whose tokens are scoped to organization orgID
+func (h *BaseHelper) NewRandAccountInOrganization(orgID string) *amv1.Account {
+	acct, err := amv1.NewAccount().
+		Username(h.NewID()).
+		FirstName(faker.FirstName()).
+		LastName(faker.LastName()).
+		Email(faker.Email()).
+		Organization(amv1.NewOrganization().ID(orgID)).
+		Build()
+	if err != nil {
+		h.T.Errorf("Unable to build account: %s", err)
+	}
+	return acct
+}
+
 func (h *BaseHelper) StartJWKCertServerMock() (jwkURL string, teardown func() error) {
 	jwkURL, teardown = mocks.NewJWKCertServerMock(h.T, h.JWTCA, JwkKID, JwkAlg)
 	h.AppConfig.Server.JwkCertURL = jwkURL
@@ -196,6 +211,9 @@ func (h *BaseHelper) CreateJWTString(account *amv1.Account) string {
 	if account.Email() != "" {
 		claims["email"] = account.Email()
 	}
+	if org, ok := account.GetOrganization(); ok && org.ID() != "" {
+		claims["org_id"] = org.ID()
+	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
 	token.Header["kid"] = JwkKID
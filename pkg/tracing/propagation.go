@@ -0,0 +1,29 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceParentKey = "traceparent"
+	traceStateKey  = "tracestate"
+)
+
+// TraceContext returns the W3C traceparent and tracestate of the span of ctx, to keep along with work that
+// is carried out later, like an event. Both are empty when ctx has no span.
+func TraceContext(ctx context.Context) (traceParent, traceState string) {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier[traceParentKey], carrier[traceStateKey]
+}
+
+// SpanContext returns the span context of a traceparent and tracestate TraceContext returned. It is invalid
+// when traceParent is empty or malformed.
+func SpanContext(traceParent, traceState string) trace.SpanContext {
+	carrier := propagation.MapCarrier{traceParentKey: traceParent, traceStateKey: traceState}
+	ctx := propagation.TraceContext{}.Extract(context.Background(), carrier)
+	return trace.SpanContextFromContext(ctx)
+}
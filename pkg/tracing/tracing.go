@@ -0,0 +1,117 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/config"
+)
+
+const (
+	// NoneExporter leaves the spans unrecorded, only the incoming trace context is passed on
+	NoneExporter = "none"
+	// OTLPExporter sends the spans to an OTLP/HTTP collector
+	OTLPExporter = "otlp"
+	// MemoryExporter keeps the spans in memory, for tests
+	MemoryExporter = "memory"
+)
+
+const instrumentationName = "github.com/openshift-online/rh-trex-ai"
+
+// Tracer returns the tracer the service starts its spans with. It follows the global tracer provider, so
+// it can be taken before New sets that up.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Provider is the tracer provider New sets up from the tracing config
+type Provider struct {
+	tracerProvider *sdktrace.TracerProvider
+	memory         *tracetest.InMemoryExporter
+}
+
+// New sets the global tracer provider and the W3C trace context propagator from the tracing config. The
+// propagator is set for every exporter, so the trace context of a request is passed on to the events it
+// produces even when this service records no spans.
+func New(cfg *config.TracingConfig) (*Provider, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	p := &Provider{}
+	var exporter sdktrace.SpanExporter
+	switch cfg.Exporter {
+	case NoneExporter, "":
+		return p, nil
+	case OTLPExporter:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		otlp, err := otlptracehttp.New(context.Background(), opts...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create the OTLP exporter: %v", err)
+		}
+		exporter = otlp
+	case MemoryExporter:
+		p.memory = tracetest.NewInMemoryExporter()
+		exporter = p.memory
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q, expected %s, %s or %s", cfg.Exporter, NoneExporter, OTLPExporter, MemoryExporter)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the tracing resource: %v", err)
+	}
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	}
+	if p.memory != nil {
+		// export synchronously so tests see the spans as soon as they end
+		opts = append(opts, sdktrace.WithSyncer(exporter))
+	} else {
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+	p.tracerProvider = sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(p.tracerProvider)
+	return p, nil
+}
+
+// Spans returns the spans that ended so far when the exporter is memory, nil otherwise
+func (p *Provider) Spans() tracetest.SpanStubs {
+	if p == nil || p.memory == nil {
+		return nil
+	}
+	return p.memory.GetSpans()
+}
+
+// Reset drops the spans kept by the memory exporter
+func (p *Provider) Reset() {
+	if p != nil && p.memory != nil {
+		p.memory.Reset()
+	}
+}
+
+// Shutdown flushes the spans not exported yet and stops the provider
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p == nil || p.tracerProvider == nil {
+		return nil
+	}
+	return p.tracerProvider.Shutdown(ctx)
+}
@@ -0,0 +1,57 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/config"
+)
+
+func TestNew(t *testing.T) {
+	RegisterTestingT(t)
+
+	_, err := New(&config.TracingConfig{Exporter: "zipkin"})
+	Expect(err).To(MatchError(ContainSubstring(`unknown tracing exporter "zipkin"`)))
+
+	provider, err := New(&config.TracingConfig{Exporter: NoneExporter})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(provider.Spans()).To(BeNil())
+	Expect(provider.Shutdown(context.Background())).To(Succeed())
+
+	provider, err = New(&config.TracingConfig{Exporter: MemoryExporter, SampleRatio: 1, ServiceName: "trex"})
+	Expect(err).NotTo(HaveOccurred())
+	defer provider.Shutdown(context.Background())
+
+	_, span := Tracer().Start(context.Background(), "work")
+	span.End()
+	Expect(provider.Spans()).To(HaveLen(1))
+	Expect(provider.Spans()[0].Name).To(Equal("work"))
+	provider.Reset()
+	Expect(provider.Spans()).To(BeEmpty())
+}
+
+func TestTraceContext(t *testing.T) {
+	RegisterTestingT(t)
+
+	provider, err := New(&config.TracingConfig{Exporter: MemoryExporter, SampleRatio: 1})
+	Expect(err).NotTo(HaveOccurred())
+	defer provider.Shutdown(context.Background())
+
+	traceParent, traceState := TraceContext(context.Background())
+	Expect(traceParent).To(BeEmpty())
+	Expect(traceState).To(BeEmpty())
+	Expect(SpanContext(traceParent, traceState).IsValid()).To(BeFalse())
+	Expect(SpanContext("00-garbage", "").IsValid()).To(BeFalse())
+
+	ctx, span := Tracer().Start(context.Background(), "request")
+	defer span.End()
+	traceParent, traceState = TraceContext(ctx)
+	Expect(traceParent).To(Equal("00-" + span.SpanContext().TraceID().String() + "-" + span.SpanContext().SpanID().String() + "-01"))
+
+	sc := SpanContext(traceParent, traceState)
+	Expect(sc.TraceID()).To(Equal(span.SpanContext().TraceID()))
+	Expect(sc.SpanID()).To(Equal(span.SpanContext().SpanID()))
+	Expect(sc.IsRemote()).To(BeTrue())
+}
@@ -0,0 +1,125 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+)
+
+// openapiSchema is the part of an OpenAPI schema object the rules are read from
+type openapiSchema struct {
+	AllOf    []openapiSchema `yaml:"allOf"`
+	Ref      string          `yaml:"$ref"`
+	Required []string        `yaml:"required"`
+	// Properties is a mapping node, so the rules keep the order the properties are declared in
+	Properties yaml.Node `yaml:"properties"`
+	ReadOnly   bool      `yaml:"readOnly"`
+	MinLength  int       `yaml:"minLength"`
+	MaxLength  int       `yaml:"maxLength"`
+	Pattern    string    `yaml:"pattern"`
+	Minimum    *float64  `yaml:"minimum"`
+	Maximum    *float64  `yaml:"maximum"`
+	Enum       []string  `yaml:"enum"`
+	Immutable  bool      `yaml:"x-immutable"`
+}
+
+type openapiDocument struct {
+	Components struct {
+		Schemas map[string]openapiSchema `yaml:"schemas"`
+	} `yaml:"components"`
+}
+
+// embeddedDocument parses the embedded OpenAPI document of the service once
+var embeddedDocument = sync.OnceValues(func() (*openapiDocument, error) {
+	spec, err := api.GetOpenAPISpec()
+	if err != nil {
+		return nil, err
+	}
+	return parseDocument(spec)
+})
+
+// SchemaRules returns the rules of the constraints the schema of a Kind in an OpenAPI document declares on its
+// properties: required, minLength, maxLength, pattern, minimum, maximum, enum and x-immutable. Read-only
+// properties, which requests leave out, and properties without constraints have no rule. References to
+// other schemas, e.g. the ObjectReference of every Kind, are not followed.
+func SchemaRules(spec []byte, schema string) (Rules, error) {
+	document, err := parseDocument(spec)
+	if err != nil {
+		return Rules{}, err
+	}
+	return document.rules(schema)
+}
+
+// MustSchemaRules returns the SchemaRules of schema in the embedded OpenAPI document of the service. It panics
+// when the document lacks schema, the Kinds read their rules at init.
+func MustSchemaRules(schema string) Rules {
+	document, err := embeddedDocument()
+	if err != nil {
+		panic(fmt.Sprintf("unable to read the OpenAPI document: %s", err))
+	}
+	rules, err := document.rules(schema)
+	if err != nil {
+		panic(err.Error())
+	}
+	return rules
+}
+
+func parseDocument(spec []byte) (*openapiDocument, error) {
+	document := &openapiDocument{}
+	if err := yaml.Unmarshal(spec, document); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+	return document, nil
+}
+
+func (d *openapiDocument) rules(schema string) (Rules, error) {
+	object, ok := d.Components.Schemas[schema]
+	if !ok {
+		return Rules{}, fmt.Errorf("OpenAPI document has no schema %s", schema)
+	}
+
+	var rules []Rule
+	for _, part := range append([]openapiSchema{object}, object.AllOf...) {
+		if part.Ref != "" {
+			continue
+		}
+		required := map[string]bool{}
+		for _, field := range part.Required {
+			required[field] = true
+		}
+		// the content of a mapping node alternates the keys and the values
+		for i := 0; i+1 < len(part.Properties.Content); i += 2 {
+			field := part.Properties.Content[i].Value
+			property := openapiSchema{}
+			if err := part.Properties.Content[i+1].Decode(&property); err != nil {
+				return Rules{}, fmt.Errorf("invalid property %s of schema %s: %w", field, schema, err)
+			}
+			if property.ReadOnly {
+				continue
+			}
+			if _, err := regexp.Compile(property.Pattern); err != nil {
+				return Rules{}, fmt.Errorf("invalid pattern of property %s of schema %s: %w", field, schema, err)
+			}
+			rule := Rule{
+				Field:     field,
+				Required:  required[field],
+				Immutable: property.Immutable,
+				MinLength: property.MinLength,
+				MaxLength: property.MaxLength,
+				Pattern:   property.Pattern,
+				Minimum:   property.Minimum,
+				Maximum:   property.Maximum,
+				Enum:      property.Enum,
+			}
+			if rule.Required || rule.Immutable || rule.MinLength > 0 || rule.MaxLength > 0 || rule.Pattern != "" ||
+				rule.Minimum != nil || rule.Maximum != nil || len(rule.Enum) > 0 {
+				rules = append(rules, rule)
+			}
+		}
+	}
+	return NewRules(rules...), nil
+}
@@ -0,0 +1,70 @@
+package validation
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+const nestSpec = `
+components:
+  schemas:
+    Nest:
+      allOf:
+        - $ref: '#/components/schemas/ObjectReference'
+        - type: object
+          required:
+            - name
+          properties:
+            name:
+              type: string
+              minLength: 2
+              maxLength: 8
+              pattern: '^[A-Z]'
+            habitat:
+              type: string
+              enum: [forest, swamp]
+            eggs:
+              type: integer
+              minimum: 1
+              maximum: 12
+            location:
+              type: string
+              x-immutable: true
+            notes:
+              type: string
+            builder:
+              readOnly: true
+              type: string
+              maxLength: 255
+`
+
+func TestSchemaRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	rules, err := SchemaRules([]byte(nestSpec), "Nest")
+	Expect(err).To(BeNil())
+	Expect(rules).To(Equal(nestRules))
+
+	_, err = SchemaRules([]byte(nestSpec), "Cave")
+	Expect(err).NotTo(BeNil())
+	_, err = SchemaRules([]byte("components: ["), "Nest")
+	Expect(err).NotTo(BeNil())
+}
+
+func TestMustSchemaRules(t *testing.T) {
+	RegisterTestingT(t)
+
+	// the Kinds read their rules from the embedded OpenAPI document
+	Expect(MustSchemaRules("Fossil").rules).To(Equal([]Rule{
+		{Field: "discovery_location", Required: true, MaxLength: 255},
+		{Field: "estimated_age", Minimum: Bound(0)},
+		{Field: "fossil_type", MaxLength: 255},
+		{Field: "excavator_name", MaxLength: 255},
+	}))
+	Expect(MustSchemaRules("Scientist").rules).To(Equal([]Rule{
+		{Field: "name", Required: true, MaxLength: 255},
+		{Field: "field", Required: true, MaxLength: 255},
+	}))
+	Expect(func() { MustSchemaRules("Unicorn") }).To(Panic())
+}
@@ -0,0 +1,243 @@
+// Package validation checks the fields of create and update requests against the declarative rules of a
+// Kind. The rules are read from the constraints of the Kind's OpenAPI schema, see SchemaRules, so that REST
+// and gRPC enforce the constraints of the API documentation with the same field violations.
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+// The codes of the field violations, one for each constraint of a Rule. CodeReadOnly is for the fields the
+// server sets, which requests must leave out, and CodeUnknown for the fields a resource doesn't have.
+const (
+	CodeRequired  = "required"
+	CodeReadOnly  = "read_only"
+	CodeUnknown   = "unknown"
+	CodeImmutable = "immutable"
+	CodeMinLength = "min_length"
+	CodeMaxLength = "max_length"
+	CodePattern   = "pattern"
+	CodeMinimum   = "minimum"
+	CodeMaximum   = "maximum"
+	CodeEnum      = "enum"
+	CodeType      = "type"
+)
+
+// Rule declares the constraints of one field of a Kind. Zero values leave a constraint out.
+type Rule struct {
+	// Field is the name of the field in the REST and gRPC messages, e.g. discovery_location
+	Field string
+	// Required fields must be set, and not empty, on create. Updates may leave them out but not empty them.
+	Required bool
+	// Immutable fields can't change once created. Updates may repeat their current value.
+	Immutable bool
+	// MinLength and MaxLength bound the number of characters of a string
+	MinLength int
+	MaxLength int
+	// Pattern is a regular expression a string must match, see the pattern keyword of OpenAPI
+	Pattern string
+	// Minimum and Maximum bound a number, inclusively
+	Minimum *float64
+	Maximum *float64
+	// Enum lists the values a string may take
+	Enum []string
+}
+
+// Rules are the rules of the fields of a Kind. Build them with NewRules, which compiles the patterns.
+type Rules struct {
+	rules    []Rule
+	patterns map[string]*regexp.Regexp
+}
+
+// NewRules compiles the rules of a Kind. It panics on an invalid pattern, rules are declared by the plugins.
+func NewRules(rules ...Rule) Rules {
+	compiled := Rules{rules: rules, patterns: map[string]*regexp.Regexp{}}
+	for _, rule := range rules {
+		if rule.Pattern != "" {
+			compiled.patterns[rule.Field] = regexp.MustCompile(rule.Pattern)
+		}
+	}
+	return compiled
+}
+
+// Bound returns a pointer to n, for the Minimum and Maximum of a Rule
+func Bound(n float64) *float64 {
+	return &n
+}
+
+// Fields are the fields a request sets, by their name in the messages. Values are those of JSON: strings,
+// json.Number, booleans, nil, and maps and slices of them.
+type Fields map[string]interface{}
+
+// FieldsOf returns the fields set by v, a REST request body or any value that marshals to a JSON object.
+// Fields left out by omitempty are not set.
+func FieldsOf(v interface{}) Fields {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return Fields{}
+	}
+	return decodeFields(data)
+}
+
+// ProtoFieldsOf returns the fields of a gRPC message by their proto name. Unset optional fields are not set,
+// the other scalars always are, like the fields of a REST body without omitempty.
+func ProtoFieldsOf(m proto.Message) Fields {
+	data, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(m)
+	if err != nil {
+		return Fields{}
+	}
+	return decodeFields(data)
+}
+
+func decodeFields(data []byte) Fields {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	fields := Fields{}
+	if err := decoder.Decode(&fields); err != nil {
+		return Fields{}
+	}
+	return fields
+}
+
+// ValidateCreate checks the fields of a create request: required fields must be set, and every field set
+// must satisfy its constraints
+func (r Rules) ValidateCreate(fields Fields) *errors.ServiceError {
+	var violations []errors.FieldViolation
+	for _, rule := range r.rules {
+		value, set := fields[rule.Field]
+		if !set || isEmpty(value) {
+			if rule.Required {
+				violations = append(violations, violation(rule.Field, CodeRequired, "%s is required", rule.Field))
+			}
+			continue
+		}
+		violations = append(violations, r.check(rule, value)...)
+	}
+	return newError(violations)
+}
+
+// ValidateUpdate checks the fields set by an update request against current, the fields of the resource
+// it updates: every field set must satisfy its constraints, required fields can't be emptied and immutable
+// fields can't change. current is nil when the resource isn't known yet, immutable fields are then left for
+// a second call once it is.
+func (r Rules) ValidateUpdate(fields, current Fields) *errors.ServiceError {
+	var violations []errors.FieldViolation
+	for _, rule := range r.rules {
+		value, set := fields[rule.Field]
+		if !set {
+			continue
+		}
+		if rule.Immutable && current != nil && !reflect.DeepEqual(value, current[rule.Field]) {
+			violations = append(violations, violation(rule.Field, CodeImmutable, "%s can't be changed once created", rule.Field))
+			continue
+		}
+		if isEmpty(value) {
+			if rule.Required {
+				violations = append(violations, violation(rule.Field, CodeRequired, "%s is required", rule.Field))
+			}
+			continue
+		}
+		violations = append(violations, r.check(rule, value)...)
+	}
+	return newError(violations)
+}
+
+// check returns the constraints of rule that value violates
+func (r Rules) check(rule Rule, value interface{}) []errors.FieldViolation {
+	var violations []errors.FieldViolation
+	if s, ok := value.(string); ok {
+		length := len([]rune(s))
+		if rule.MinLength > 0 && length < rule.MinLength {
+			violations = append(violations, violation(rule.Field, CodeMinLength, "%s must be at least %d characters long", rule.Field, rule.MinLength))
+		}
+		if rule.MaxLength > 0 && length > rule.MaxLength {
+			violations = append(violations, violation(rule.Field, CodeMaxLength, "%s exceeds maximum length of %d", rule.Field, rule.MaxLength))
+		}
+		if pattern, ok := r.patterns[rule.Field]; ok && !pattern.MatchString(s) {
+			violations = append(violations, violation(rule.Field, CodePattern, "%s must match the pattern %s", rule.Field, rule.Pattern))
+		}
+		if len(rule.Enum) > 0 && !contains(rule.Enum, s) {
+			violations = append(violations, violation(rule.Field, CodeEnum, "%s must be one of %s", rule.Field, strings.Join(rule.Enum, ", ")))
+		}
+	}
+	if rule.Minimum == nil && rule.Maximum == nil {
+		return violations
+	}
+	n, ok := number(value)
+	if !ok {
+		return append(violations, violation(rule.Field, CodeType, "%s must be a number", rule.Field))
+	}
+	if rule.Minimum != nil && n < *rule.Minimum {
+		violations = append(violations, violation(rule.Field, CodeMinimum, "%s must be at least %s", rule.Field, formatBound(*rule.Minimum)))
+	}
+	if rule.Maximum != nil && n > *rule.Maximum {
+		violations = append(violations, violation(rule.Field, CodeMaximum, "%s must be at most %s", rule.Field, formatBound(*rule.Maximum)))
+	}
+	return violations
+}
+
+// ItemError prefixes the field violations of err, the validation error of the item at index i of the
+// list field name of a batch request, with the item's position
+func ItemError(name string, i int, err *errors.ServiceError) *errors.ServiceError {
+	if err == nil || len(err.FieldViolations) == 0 {
+		return err
+	}
+	violations := make([]errors.FieldViolation, len(err.FieldViolations))
+	for j, v := range err.FieldViolations {
+		field := fmt.Sprintf("%s[%d].%s", name, i, v.Field)
+		violations[j] = errors.FieldViolation{Field: field, Code: v.Code, Message: strings.Replace(v.Message, v.Field, field, 1)}
+	}
+	return errors.FieldValidation(violations)
+}
+
+func newError(violations []errors.FieldViolation) *errors.ServiceError {
+	if len(violations) == 0 {
+		return nil
+	}
+	return errors.FieldValidation(violations)
+}
+
+func violation(field, code, message string, values ...interface{}) errors.FieldViolation {
+	return errors.FieldViolation{Field: field, Code: code, Message: fmt.Sprintf(message, values...)}
+}
+
+func isEmpty(value interface{}) bool {
+	return value == nil || value == ""
+}
+
+// number reads a JSON number. protojson writes 64-bit integers as strings, so numeric strings count too.
+func number(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		n, err := v.Float64()
+		return n, err == nil
+	case string:
+		n, err := strconv.ParseFloat(v, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func formatBound(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
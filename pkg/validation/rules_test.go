@@ -0,0 +1,104 @@
+package validation
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/openshift-online/rh-trex-ai/pkg/api/grpc/rh_trex/v1"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+)
+
+type nest struct {
+	Name     string  `json:"name"`
+	Habitat  *string `json:"habitat,omitempty"`
+	Eggs     *int32  `json:"eggs,omitempty"`
+	Location *string `json:"location,omitempty"`
+}
+
+var nestRules = NewRules(
+	Rule{Field: "name", Required: true, MinLength: 2, MaxLength: 8, Pattern: "^[A-Z]"},
+	Rule{Field: "habitat", Enum: []string{"forest", "swamp"}},
+	Rule{Field: "eggs", Minimum: Bound(1), Maximum: Bound(12)},
+	Rule{Field: "location", Immutable: true},
+)
+
+func codes(err *errors.ServiceError) map[string]string {
+	found := map[string]string{}
+	for _, violation := range err.FieldViolations {
+		found[violation.Field] = violation.Code
+	}
+	return found
+}
+
+func TestValidateCreate(t *testing.T) {
+	RegisterTestingT(t)
+
+	habitat, eggs := "forest", int32(3)
+	Expect(nestRules.ValidateCreate(FieldsOf(nest{Name: "Ridge", Habitat: &habitat, Eggs: &eggs}))).To(BeNil())
+
+	// every field is checked, not just the first that fails
+	habitat, eggs = "desert", int32(0)
+	err := nestRules.ValidateCreate(FieldsOf(nest{Habitat: &habitat, Eggs: &eggs}))
+	Expect(err).NotTo(BeNil())
+	Expect(err.Code).To(Equal(errors.ErrorValidation))
+	Expect(codes(err)).To(Equal(map[string]string{"name": CodeRequired, "habitat": CodeEnum, "eggs": CodeMinimum}))
+	Expect(err.Reason).To(Equal("name is required; habitat must be one of forest, swamp; eggs must be at least 1"))
+
+	err = nestRules.ValidateCreate(FieldsOf(nest{Name: "ridge of the north"}))
+	Expect(err.FieldViolations).To(HaveLen(2))
+	Expect(err.FieldViolations[0].Code).To(Equal(CodeMaxLength))
+	Expect(err.FieldViolations[1].Code).To(Equal(CodePattern))
+
+	err = nestRules.ValidateCreate(FieldsOf(nest{Name: "R"}))
+	Expect(codes(err)).To(Equal(map[string]string{"name": CodeMinLength}))
+}
+
+func TestValidateUpdate(t *testing.T) {
+	RegisterTestingT(t)
+
+	location := "north"
+	current := FieldsOf(nest{Name: "Ridge", Location: &location})
+
+	// fields left out of an update aren't required
+	Expect(nestRules.ValidateUpdate(FieldsOf(map[string]interface{}{}), current)).To(BeNil())
+	Expect(nestRules.ValidateUpdate(FieldsOf(map[string]interface{}{"location": "north"}), current)).To(BeNil())
+
+	err := nestRules.ValidateUpdate(FieldsOf(map[string]interface{}{"name": "", "eggs": 13, "location": "south"}), current)
+	Expect(codes(err)).To(Equal(map[string]string{"name": CodeRequired, "eggs": CodeMaximum, "location": CodeImmutable}))
+
+	// immutable fields are left alone until the resource is known
+	Expect(nestRules.ValidateUpdate(FieldsOf(map[string]interface{}{"location": "south"}), nil)).To(BeNil())
+
+	err = nestRules.ValidateUpdate(FieldsOf(map[string]interface{}{"eggs": "many"}), nil)
+	Expect(codes(err)).To(Equal(map[string]string{"eggs": CodeType}))
+}
+
+func TestProtoFieldsOf(t *testing.T) {
+	RegisterTestingT(t)
+
+	// 64-bit integers are strings in protojson, and still numbers to the rules
+	rules := NewRules(Rule{Field: "resource_version", Maximum: Bound(10)})
+	err := rules.ValidateCreate(ProtoFieldsOf(&pb.UpdateDinosaurRequest{ResourceVersion: proto.Int64(11)}))
+	Expect(err).NotTo(BeNil())
+	Expect(codes(err)).To(Equal(map[string]string{"resource_version": CodeMaximum}))
+	Expect(rules.ValidateCreate(ProtoFieldsOf(&pb.UpdateDinosaurRequest{ResourceVersion: proto.Int64(10)}))).To(BeNil())
+
+	// unset optional fields aren't set, scalars at their zero value are, like the fields of a REST body
+	fields := ProtoFieldsOf(&pb.UpdateDinosaurRequest{})
+	Expect(fields).NotTo(HaveKey("species"))
+	fields = ProtoFieldsOf(&pb.CreateDinosaurRequest{})
+	Expect(fields).To(HaveKeyWithValue("species", ""))
+	Expect(NewRules(Rule{Field: "species", Required: true}).ValidateCreate(fields)).NotTo(BeNil())
+}
+
+func TestItemError(t *testing.T) {
+	RegisterTestingT(t)
+
+	err := ItemError("items", 2, nestRules.ValidateCreate(FieldsOf(nest{})))
+	Expect(err.FieldViolations).To(HaveLen(1))
+	Expect(err.FieldViolations[0].Field).To(Equal("items[2].name"))
+	Expect(err.Reason).To(Equal("items[2].name is required"))
+	Expect(ItemError("items", 0, nil)).To(BeNil())
+}
@@ -0,0 +1,69 @@
+package auditevents
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/handlers"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
+
+// auditEventHandler is read-only, audit events are only ever written by the services of the Kinds
+type auditEventHandler struct {
+	auditEvent services.AuditEventService
+	generic    services.GenericService
+}
+
+func NewAuditEventHandler(auditEvent services.AuditEventService, generic services.GenericService) *auditEventHandler {
+	return &auditEventHandler{
+		auditEvent: auditEvent,
+		generic:    generic,
+	}
+}
+
+func (h auditEventHandler) Get(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			auditEvent, err := h.auditEvent.Get(r.Context(), id)
+			if err != nil {
+				return nil, err
+			}
+			return PresentAuditEvent(auditEvent), nil
+		},
+	}
+
+	handlers.HandleGet(w, r, cfg)
+}
+
+// List lists audit events, narrowed by a search on any audit event column, e.g.
+// "source = 'Dinosaurs' and source_id = '...'" for the history of a dinosaur
+func (h auditEventHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := services.NewListArguments(r.URL.Query())
+			var auditEvents []api.AuditEvent
+			paging, err := h.generic.List(r.Context(), listArgs, &auditEvents)
+			if err != nil {
+				return nil, err
+			}
+			auditEventList := AuditEventList{
+				Kind:     "AuditEventList",
+				Page:     int32(paging.Page),
+				Size:     int32(paging.Size),
+				Total:    int32(paging.Total),
+				Continue: paging.Continue,
+				Items:    []AuditEvent{},
+			}
+			for i := range auditEvents {
+				auditEventList.Items = append(auditEventList.Items, PresentAuditEvent(&auditEvents[i]))
+			}
+			return auditEventList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
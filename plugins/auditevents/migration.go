@@ -0,0 +1,34 @@
+package auditevents
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func migration() *gormigrate.Migration {
+	type AuditEvent struct {
+		ID             string    `gorm:"primaryKey"`
+		CreatedAt      time.Time `gorm:"not null;index"`
+		Source         string    `gorm:"not null;index:idx_audit_events_source_source_id"`
+		SourceID       string    `gorm:"not null;index:idx_audit_events_source_source_id"`
+		Action         string    `gorm:"not null"`
+		Username       string    `gorm:"index"`
+		OperationID    string
+		OrganizationID string `gorm:"index"`
+		Before         string
+		After          string
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101714000925",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&AuditEvent{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&AuditEvent{})
+		},
+	}
+}
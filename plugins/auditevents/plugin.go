@@ -0,0 +1,76 @@
+package auditevents
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/api/presenters"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/controllers"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/pkg/environments"
+	"github.com/openshift-online/rh-trex-ai/pkg/registry"
+	pkgserver "github.com/openshift-online/rh-trex-ai/pkg/server"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+	"github.com/openshift-online/rh-trex-ai/plugins/generic"
+)
+
+func NewServiceLocator(env *environments.Env) services.AuditEventServiceLocator {
+	return func() services.AuditEventService {
+		return services.NewAuditEventService(dao.NewAuditEventDao(&env.Database.SessionFactory))
+	}
+}
+
+// Service helper function to get the audit event service from the registry
+func Service(s *environments.Services) services.AuditEventService {
+	if s == nil {
+		return nil
+	}
+	if obj := s.GetService("AuditEvents"); obj != nil {
+		locator := obj.(services.AuditEventServiceLocator)
+		return locator()
+	}
+	return nil
+}
+
+func init() {
+	registry.RegisterService("AuditEvents", func(env interface{}) interface{} {
+		return NewServiceLocator(env.(*environments.Env))
+	})
+
+	pkgserver.RegisterRoutes("audit_events", func(apiV1Router *mux.Router, services pkgserver.ServicesInterface, authMiddleware auth.JWTMiddleware, authzMiddleware auth.AuthorizationMiddleware) {
+		envServices := services.(*environments.Services)
+		auditEventHandler := NewAuditEventHandler(Service(envServices), generic.Service(envServices))
+
+		auditEventsRouter := apiV1Router.PathPrefix("/audit_events").Subrouter()
+		auditEventsRouter.HandleFunc("", authzMiddleware.Authorize(auth.ActionList, "audit_events", auditEventHandler.List)).Methods(http.MethodGet)
+		auditEventsRouter.HandleFunc("/{id}", authzMiddleware.Authorize(auth.ActionGet, "audit_events", auditEventHandler.Get)).Methods(http.MethodGet)
+		auditEventsRouter.Use(authMiddleware.AuthenticateAccountJWT)
+		auditEventsRouter.Use(pkgserver.RateLimitMiddleware(environments.Environment().RateLimiter, "audit_events"))
+	})
+
+	// a retention of 0 keeps the audit trail forever
+	pkgserver.RegisterPurger("AuditEvents", func(purger *controllers.PurgeController, services pkgserver.ServicesInterface) {
+		retention := environments.Environment().Config.Server.AuditRetention
+		if retention <= 0 {
+			return
+		}
+		auditEventService := Service(services.(*environments.Services))
+
+		purger.Add(&controllers.PurgeConfig{
+			Kind:      "AuditEvents",
+			Retention: retention,
+			Purge:     auditEventService.PurgeExpired,
+		})
+	})
+
+	presenters.RegisterPath(api.AuditEvent{}, "audit_events")
+	presenters.RegisterPath(&api.AuditEvent{}, "audit_events")
+	presenters.RegisterKind(api.AuditEvent{}, "AuditEvent")
+	presenters.RegisterKind(&api.AuditEvent{}, "AuditEvent")
+
+	db.RegisterMigration(migration())
+}
@@ -0,0 +1,62 @@
+package auditevents
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/api/presenters"
+	"github.com/openshift-online/rh-trex-ai/pkg/util"
+)
+
+// AuditEvent is the REST representation of an api.AuditEvent. Audit events are an operational resource
+// and are not part of the generated openapi client.
+type AuditEvent struct {
+	ID             string          `json:"id"`
+	Kind           string          `json:"kind"`
+	Href           string          `json:"href"`
+	CreatedAt      time.Time       `json:"created_at"`
+	Source         string          `json:"source"`
+	SourceID       string          `json:"source_id"`
+	Action         string          `json:"action"`
+	Username       string          `json:"username,omitempty"`
+	OperationID    string          `json:"operation_id,omitempty"`
+	OrganizationID string          `json:"organization_id,omitempty"`
+	Before         json.RawMessage `json:"before,omitempty"`
+	After          json.RawMessage `json:"after,omitempty"`
+}
+
+type AuditEventList struct {
+	Kind     string       `json:"kind"`
+	Page     int32        `json:"page"`
+	Size     int32        `json:"size"`
+	Total    int32        `json:"total"`
+	Continue string       `json:"continue,omitempty"`
+	Items    []AuditEvent `json:"items"`
+}
+
+func PresentAuditEvent(auditEvent *api.AuditEvent) AuditEvent {
+	reference := presenters.PresentReference(auditEvent.ID, auditEvent)
+	return AuditEvent{
+		ID:             util.NilToEmptyString(reference.Id),
+		Kind:           util.NilToEmptyString(reference.Kind),
+		Href:           util.NilToEmptyString(reference.Href),
+		CreatedAt:      auditEvent.CreatedAt,
+		Source:         auditEvent.Source,
+		SourceID:       auditEvent.SourceID,
+		Action:         string(auditEvent.Action),
+		Username:       auditEvent.Username,
+		OperationID:    auditEvent.OperationID,
+		OrganizationID: auditEvent.OrganizationID,
+		Before:         presentAuditState(auditEvent.Before),
+		After:          presentAuditState(auditEvent.After),
+	}
+}
+
+// presentAuditState embeds the JSON of a row as is, rather than as a string
+func presentAuditState(state string) json.RawMessage {
+	if state == "" {
+		return nil
+	}
+	return json.RawMessage(state)
+}
@@ -2,20 +2,32 @@ package dinosaurs
 
 import (
 	"context"
+	"time"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
 )
 
+// DinosaurDao reads and writes the dinosaurs of the organization of the request in ctx, see dao.TenantScope
 type DinosaurDao interface {
 	Get(ctx context.Context, id string) (*Dinosaur, error)
 	Create(ctx context.Context, dinosaur *Dinosaur) (*Dinosaur, error)
 	Replace(ctx context.Context, dinosaur *Dinosaur) (*Dinosaur, error)
-	Delete(ctx context.Context, id string) error
+	// Delete soft-deletes a dinosaur, only at one of resourceVersions unless they are nil
+	Delete(ctx context.Context, id string, resourceVersions []int64) error
 	FindByIDs(ctx context.Context, ids []string) (DinosaurList, error)
 	All(ctx context.Context) (DinosaurList, error)
+
+	// Restore undeletes a soft-deleted dinosaur
+	Restore(ctx context.Context, id string) (*Dinosaur, error)
+	// Purge hard-deletes a soft-deleted dinosaur
+	Purge(ctx context.Context, id string) error
+	// PurgeDeleted hard-deletes at most limit dinosaurs soft-deleted before the cutoff, the oldest first
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error)
 }
 
 var _ DinosaurDao = &sqlDinosaurDao{}
@@ -29,7 +41,7 @@ func NewDinosaurDao(sessionFactory *db.SessionFactory) DinosaurDao {
 }
 
 func (d *sqlDinosaurDao) Get(ctx context.Context, id string) (*Dinosaur, error) {
-	g2 := (*d.sessionFactory).New(ctx)
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
 	var dinosaur Dinosaur
 	if err := g2.Take(&dinosaur, "id = ?", id).Error; err != nil {
 		return nil, err
@@ -39,6 +51,7 @@ func (d *sqlDinosaurDao) Get(ctx context.Context, id string) (*Dinosaur, error)
 
 func (d *sqlDinosaurDao) Create(ctx context.Context, dinosaur *Dinosaur) (*Dinosaur, error) {
 	g2 := (*d.sessionFactory).New(ctx)
+	dao.SetTenancy(ctx, &dinosaur.Meta)
 	if err := g2.Omit(clause.Associations).Create(dinosaur).Error; err != nil {
 		db.MarkForRollback(ctx, err)
 		return nil, err
@@ -47,17 +60,17 @@ func (d *sqlDinosaurDao) Create(ctx context.Context, dinosaur *Dinosaur) (*Dinos
 }
 
 func (d *sqlDinosaurDao) Replace(ctx context.Context, dinosaur *Dinosaur) (*Dinosaur, error) {
-	g2 := (*d.sessionFactory).New(ctx)
-	if err := g2.Omit(clause.Associations).Save(dinosaur).Error; err != nil {
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
+	if err := db.SaveVersioned(g2.Omit(clause.Associations), dinosaur, &dinosaur.ResourceVersion); err != nil {
 		db.MarkForRollback(ctx, err)
 		return nil, err
 	}
 	return dinosaur, nil
 }
 
-func (d *sqlDinosaurDao) Delete(ctx context.Context, id string) error {
-	g2 := (*d.sessionFactory).New(ctx)
-	if err := g2.Omit(clause.Associations).Delete(&Dinosaur{Meta: api.Meta{ID: id}}).Error; err != nil {
+func (d *sqlDinosaurDao) Delete(ctx context.Context, id string, resourceVersions []int64) error {
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
+	if err := db.DeleteVersioned(g2.Omit(clause.Associations), &Dinosaur{Meta: api.Meta{ID: id}}, resourceVersions); err != nil {
 		db.MarkForRollback(ctx, err)
 		return err
 	}
@@ -65,7 +78,7 @@ func (d *sqlDinosaurDao) Delete(ctx context.Context, id string) error {
 }
 
 func (d *sqlDinosaurDao) FindByIDs(ctx context.Context, ids []string) (DinosaurList, error) {
-	g2 := (*d.sessionFactory).New(ctx)
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
 	dinosaurs := DinosaurList{}
 	if err := g2.Where("id in (?)", ids).Find(&dinosaurs).Error; err != nil {
 		return nil, err
@@ -74,10 +87,48 @@ func (d *sqlDinosaurDao) FindByIDs(ctx context.Context, ids []string) (DinosaurL
 }
 
 func (d *sqlDinosaurDao) All(ctx context.Context) (DinosaurList, error) {
-	g2 := (*d.sessionFactory).New(ctx)
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
 	dinosaurs := DinosaurList{}
 	if err := g2.Find(&dinosaurs).Error; err != nil {
 		return nil, err
 	}
 	return dinosaurs, nil
 }
+
+func (d *sqlDinosaurDao) Restore(ctx context.Context, id string) (*Dinosaur, error) {
+	g2 := (*d.sessionFactory).New(ctx).Unscoped().Scopes(dao.TenantScope(ctx))
+	var dinosaur Dinosaur
+	if err := g2.Take(&dinosaur, "id = ? AND deleted_at IS NOT NULL", id).Error; err != nil {
+		return nil, err
+	}
+	dinosaur.DeletedAt = gorm.DeletedAt{}
+	if err := db.SaveVersioned(g2.Omit(clause.Associations), &dinosaur, &dinosaur.ResourceVersion); err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return &dinosaur, nil
+}
+
+func (d *sqlDinosaurDao) Purge(ctx context.Context, id string) error {
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
+	result := g2.Unscoped().Omit(clause.Associations).Where("deleted_at IS NOT NULL").Delete(&Dinosaur{Meta: api.Meta{ID: id}})
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (d *sqlDinosaurDao) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
+	batch := g2.Unscoped().Model(&Dinosaur{}).Select("id").Where("deleted_at < ?", deletedBefore).Order("deleted_at").Limit(limit)
+	result := (*d.sessionFactory).New(ctx).Unscoped().Omit(clause.Associations).Where("id IN (?)", batch).Delete(&Dinosaur{})
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -3,16 +3,17 @@ package dinosaurs
 import (
 	"context"
 
-	"github.com/golang/glog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
-	"github.com/openshift-online/rh-trex-ai/pkg/api"
 	pb "github.com/openshift-online/rh-trex-ai/pkg/api/grpc/rh_trex/v1"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 	pkgserver "github.com/openshift-online/rh-trex-ai/pkg/server"
 	"github.com/openshift-online/rh-trex-ai/pkg/server/grpcutil"
 	"github.com/openshift-online/rh-trex-ai/pkg/services"
+	"github.com/openshift-online/rh-trex-ai/pkg/validation"
 )
 
 type dinosaurGRPCHandler struct {
@@ -30,51 +31,91 @@ func (h *dinosaurGRPCHandler) GetDinosaur(ctx context.Context, req *pb.GetDinosa
 	if err := grpcutil.ValidateRequiredID(req.Id); err != nil {
 		return nil, err
 	}
+	if err := grpcutil.ValidateReadMask(req.ReadMask, &pb.Dinosaur{}); err != nil {
+		return nil, err
+	}
 
 	dinosaur, svcErr := h.service.Get(ctx, req.Id)
 	if svcErr != nil {
 		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
 	}
-	return dinosaurToProto(dinosaur), nil
+	result := DinosaurToProto(dinosaur)
+	grpcutil.ApplyReadMask(result, req.ReadMask)
+	return result, nil
 }
 
 func (h *dinosaurGRPCHandler) CreateDinosaur(ctx context.Context, req *pb.CreateDinosaurRequest) (*pb.Dinosaur, error) {
-	if err := grpcutil.ValidateStringField("species", req.Species, true); err != nil {
+	if err := validateCreateDinosaurRequest(req); err != nil {
 		return nil, err
 	}
 
-	dinosaur := &Dinosaur{
-		Species: req.Species,
-	}
-	result, svcErr := h.service.Create(ctx, dinosaur)
+	result, svcErr := h.service.Create(ctx, dinosaurFromCreateRequest(req))
 	if svcErr != nil {
 		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
 	}
-	return dinosaurToProto(result), nil
+	return DinosaurToProto(result), nil
 }
 
 func (h *dinosaurGRPCHandler) UpdateDinosaur(ctx context.Context, req *pb.UpdateDinosaurRequest) (*pb.Dinosaur, error) {
-	if err := grpcutil.ValidateRequiredID(req.Id); err != nil {
+	fields, err := validateUpdateDinosaurRequest(req)
+	if err != nil {
 		return nil, err
 	}
-	if req.Species != nil {
-		if err := grpcutil.ValidateStringField("species", *req.Species, false); err != nil {
-			return nil, err
-		}
-	}
 
 	dinosaur, svcErr := h.service.Get(ctx, req.Id)
 	if svcErr != nil {
 		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
 	}
-	if req.Species != nil {
-		dinosaur.Species = *req.Species
+	if err := grpcutil.ValidateResourceVersion(req.ResourceVersion, dinosaur.ResourceVersion); err != nil {
+		return nil, err
 	}
-	result, svcErr := h.service.Replace(ctx, dinosaur)
+	before := DinosaurToProto(dinosaur)
+	if err := grpcutil.ValidateUpdate(dinosaurRules, req, fields, before); err != nil {
+		return nil, err
+	}
+	applyUpdateDinosaurRequest(dinosaur, req, fields)
+	changed := grpcutil.ChangedFields(before, DinosaurToProto(dinosaur))
+	// an update that changes nothing doesn't write, bump the resource version or record an event
+	if len(changed) == 0 {
+		return before, nil
+	}
+	result, svcErr := h.service.Replace(services.WithChangedFields(ctx, changed), dinosaur)
 	if svcErr != nil {
 		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
 	}
-	return dinosaurToProto(result), nil
+	return DinosaurToProto(result), nil
+}
+
+// validateCreateDinosaurRequest checks the fields of a create request against the rules of the dinosaurs
+func validateCreateDinosaurRequest(req *pb.CreateDinosaurRequest) error {
+	return grpcutil.ValidateCreate(dinosaurRules, req)
+}
+
+func dinosaurFromCreateRequest(req *pb.CreateDinosaurRequest) *Dinosaur {
+	return &Dinosaur{
+		Species: req.Species,
+	}
+}
+
+// validateUpdateDinosaurRequest checks the id, the update mask and the fields an update request writes, and
+// returns those fields. Immutable fields are checked once the dinosaur is found.
+func validateUpdateDinosaurRequest(req *pb.UpdateDinosaurRequest) (grpcutil.FieldSet, error) {
+	if err := grpcutil.ValidateRequiredID(req.Id); err != nil {
+		return nil, err
+	}
+	fields, err := grpcutil.UpdatedFields(req, req.UpdateMask)
+	if err != nil {
+		return nil, err
+	}
+	return fields, grpcutil.ValidateUpdate(dinosaurRules, req, fields, nil)
+}
+
+// applyUpdateDinosaurRequest sets fields, the fields the update writes, on dinosaur from req. Those req leaves
+// unset are cleared.
+func applyUpdateDinosaurRequest(dinosaur *Dinosaur, req *pb.UpdateDinosaurRequest, fields grpcutil.FieldSet) {
+	if fields["species"] {
+		dinosaur.Species = req.GetSpecies()
+	}
 }
 
 func (h *dinosaurGRPCHandler) DeleteDinosaur(ctx context.Context, req *pb.DeleteDinosaurRequest) (*pb.DeleteDinosaurResponse, error) {
@@ -82,7 +123,7 @@ func (h *dinosaurGRPCHandler) DeleteDinosaur(ctx context.Context, req *pb.Delete
 		return nil, err
 	}
 
-	svcErr := h.service.Delete(ctx, req.Id)
+	svcErr := h.service.Delete(ctx, req.Id, nil)
 	if svcErr != nil {
 		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
 	}
@@ -90,27 +131,36 @@ func (h *dinosaurGRPCHandler) DeleteDinosaur(ctx context.Context, req *pb.Delete
 }
 
 func (h *dinosaurGRPCHandler) ListDinosaurs(ctx context.Context, req *pb.ListDinosaursRequest) (*pb.ListDinosaursResponse, error) {
+	if err := grpcutil.ValidateReadMask(req.ReadMask, &pb.Dinosaur{}); err != nil {
+		return nil, err
+	}
 	page, size := grpcutil.NormalizePagination(req.Page, req.Size)
 
 	listArgs := &services.ListArguments{
-		Page: int(page),
-		Size: int64(size),
+		Page:           int(page),
+		Size:           int64(size),
+		Search:         req.Search,
+		OrderBy:        req.OrderBy,
+		Continue:       req.ContinueToken,
+		SkipCount:      req.SkipCount,
+		IncludeDeleted: req.IncludeDeleted,
 	}
 
 	var dinosaurs []Dinosaur
-	paging, svcErr := h.generic.List(ctx, "id", listArgs, &dinosaurs)
+	paging, svcErr := h.generic.List(ctx, listArgs, &dinosaurs)
 	if svcErr != nil {
 		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
 	}
 
 	items := make([]*pb.Dinosaur, len(dinosaurs))
 	for i, d := range dinosaurs {
-		items[i] = dinosaurToProto(&d)
+		items[i] = DinosaurToProto(&d)
+		grpcutil.ApplyReadMask(items[i], req.ReadMask)
 	}
 
 	return &pb.ListDinosaursResponse{
 		Items:    items,
-		Metadata: &pb.ListMeta{Page: page, Size: size, Total: int32(paging.Total)},
+		Metadata: &pb.ListMeta{Page: page, Size: size, Total: int32(paging.Total), ContinueToken: paging.Continue},
 	}, nil
 }
 
@@ -121,44 +171,130 @@ func (h *dinosaurGRPCHandler) WatchDinosaurs(req *pb.WatchDinosaursRequest, stre
 	}
 
 	ctx := stream.Context()
-	sub, err := broker.Subscribe(ctx)
-	if err != nil {
-		return status.Errorf(codes.Unavailable, "failed to subscribe: %v", err)
-	}
-	glog.V(4).Infof("WatchDinosaurs: subscriber %s connected", sub.ID)
-
-	for {
-		select {
-		case <-ctx.Done():
-			glog.V(4).Infof("WatchDinosaurs: subscriber %s disconnected", sub.ID)
-			return nil
-		case evt, ok := <-sub.Events:
-			if !ok {
-				return nil
-			}
-
-			if evt.Source != "Dinosaurs" {
-				continue
-			}
-
-			watchEvent := &pb.DinosaurWatchEvent{
-				Type:       grpcutil.APIEventTypeToProto(evt.EventType),
-				ResourceId: evt.SourceID,
-			}
-
-			if evt.EventType != api.DeleteEventType {
-				dinosaur, svcErr := h.service.Get(ctx, evt.SourceID)
-				if svcErr != nil {
-					glog.Warningf("WatchDinosaurs: failed to load dinosaur %s: %v", evt.SourceID, svcErr)
-					continue
-				}
-				watchEvent.Dinosaur = dinosaurToProto(dinosaur)
-			}
-
-			if err := stream.Send(watchEvent); err != nil {
-				glog.V(4).Infof("WatchDinosaurs: send error for subscriber %s: %v", sub.ID, err)
-				return err
-			}
+	if svcErr := validateDinosaurWatchFilter(ctx, h.generic, req.Filter); svcErr != nil {
+		return grpcutil.ServiceErrorToGRPC(svcErr)
+	}
+	from := pkgserver.ResumePoint{EventID: req.ResumeFrom, EventSeq: req.EventSeq}
+	logger.V(4).Infof("WatchDinosaurs: watch started, resuming from %+v", from)
+
+	return broker.ServeWatch(ctx, "Dinosaurs", from, func(evt *pkgserver.BrokerEvent) error {
+		watchEvent, err := h.dinosaurWatchEvent(ctx, evt, req.Filter)
+		if err != nil || watchEvent == nil {
+			return err
 		}
+		return stream.Send(watchEvent)
+	}, func(resumeFrom string, eventSeq int64) error {
+		logger.V(4).Infof("WatchDinosaurs: resync required, resume from %q", resumeFrom)
+		return stream.Send(&pb.DinosaurWatchEvent{Type: pb.EventType_EVENT_TYPE_RESYNC_REQUIRED, EventId: resumeFrom, EventSeq: eventSeq})
+	})
+}
+
+// dinosaurWatchEvent returns the watch event of evt, or nil if the dinosaur doesn't match filter or isn't
+// visible to the caller
+func (h *dinosaurGRPCHandler) dinosaurWatchEvent(ctx context.Context, evt *pkgserver.BrokerEvent, filter string) (*pb.DinosaurWatchEvent, error) {
+	dinosaur, ok, svcErr := watchedDinosaur(ctx, h.generic, evt, filter)
+	if svcErr != nil {
+		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	watchEvent := &pb.DinosaurWatchEvent{
+		Type:          grpcutil.APIEventTypeToProto(evt.EventType),
+		ResourceId:    evt.SourceID,
+		EventId:       evt.EventID,
+		EventSeq:      evt.Seq,
+		ChangedFields: evt.ChangedFields,
+	}
+	if dinosaur != nil {
+		watchEvent.Dinosaur = DinosaurToProto(dinosaur)
+	}
+	return watchEvent, nil
+}
+
+func (h *dinosaurGRPCHandler) BatchCreateDinosaurs(ctx context.Context, req *pb.BatchCreateDinosaursRequest) (*pb.DinosaurBatchResponse, error) {
+	if err := grpcutil.ValidateBatchSize("requests", len(req.Requests)); err != nil {
+		return nil, err
+	}
+	dinosaurs := make(DinosaurList, len(req.Requests))
+	for i, item := range req.Requests {
+		if err := grpcutil.ValidateBatchItem("requests", i, validateCreateDinosaurRequest(item)); err != nil {
+			return nil, err
+		}
+		dinosaurs[i] = dinosaurFromCreateRequest(item)
+	}
+
+	itemErrs, svcErr := h.service.BatchCreate(ctx, dinosaurs, req.AllOrNothing)
+	if svcErr != nil {
+		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
+	}
+	results := make([]*pb.DinosaurBatchResult, len(dinosaurs))
+	for i, dinosaur := range dinosaurs {
+		if itemErrs[i] != nil {
+			results[i] = &pb.DinosaurBatchResult{Error: grpcutil.BatchItemErrorToProto(itemErrs[i])}
+			continue
+		}
+		results[i] = &pb.DinosaurBatchResult{Id: dinosaur.ID, Dinosaur: DinosaurToProto(dinosaur)}
+	}
+	return &pb.DinosaurBatchResponse{Results: results}, nil
+}
+
+func (h *dinosaurGRPCHandler) BatchUpdateDinosaurs(ctx context.Context, req *pb.BatchUpdateDinosaursRequest) (*pb.DinosaurBatchResponse, error) {
+	if err := grpcutil.ValidateBatchSize("requests", len(req.Requests)); err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(req.Requests))
+	fields := make([]grpcutil.FieldSet, len(req.Requests))
+	for i, item := range req.Requests {
+		itemFields, err := validateUpdateDinosaurRequest(item)
+		if err := grpcutil.ValidateBatchItem("requests", i, err); err != nil {
+			return nil, err
+		}
+		ids[i], fields[i] = item.Id, itemFields
+	}
+
+	dinosaurs, itemErrs, svcErr := h.service.BatchUpdate(ctx, ids, func(i int, dinosaur *Dinosaur) *errors.ServiceError {
+		if err := services.ValidateResourceVersion(req.Requests[i].ResourceVersion, dinosaur.ResourceVersion); err != nil {
+			return err
+		}
+		if err := dinosaurRules.ValidateUpdate(fields[i].Values(req.Requests[i]), validation.ProtoFieldsOf(DinosaurToProto(dinosaur))); err != nil {
+			return err
+		}
+		applyUpdateDinosaurRequest(dinosaur, req.Requests[i], fields[i])
+		return nil
+	}, req.AllOrNothing)
+	if svcErr != nil {
+		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
+	}
+	results := make([]*pb.DinosaurBatchResult, len(ids))
+	for i, id := range ids {
+		if itemErrs[i] != nil {
+			results[i] = &pb.DinosaurBatchResult{Id: id, Error: grpcutil.BatchItemErrorToProto(itemErrs[i])}
+			continue
+		}
+		results[i] = &pb.DinosaurBatchResult{Id: id, Dinosaur: DinosaurToProto(dinosaurs[i])}
+	}
+	return &pb.DinosaurBatchResponse{Results: results}, nil
+}
+
+func (h *dinosaurGRPCHandler) BatchDeleteDinosaurs(ctx context.Context, req *pb.BatchDeleteDinosaursRequest) (*pb.DinosaurBatchResponse, error) {
+	if err := grpcutil.ValidateBatchSize("ids", len(req.Ids)); err != nil {
+		return nil, err
+	}
+	for i, id := range req.Ids {
+		if err := grpcutil.ValidateBatchItem("ids", i, grpcutil.ValidateRequiredID(id)); err != nil {
+			return nil, err
+		}
+	}
+
+	itemErrs, svcErr := h.service.BatchDelete(ctx, req.Ids, req.AllOrNothing)
+	if svcErr != nil {
+		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
+	}
+	results := make([]*pb.DinosaurBatchResult, len(req.Ids))
+	for i, id := range req.Ids {
+		results[i] = &pb.DinosaurBatchResult{Id: id, Error: grpcutil.BatchItemErrorToProto(itemErrs[i])}
 	}
+	return &pb.DinosaurBatchResponse{Results: results}, nil
 }
@@ -10,7 +10,9 @@ import (
 
 	. "github.com/onsi/gomega"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/openshift-online/rh-trex-ai/pkg/api/grpc/rh_trex/v1"
 	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
@@ -85,6 +87,11 @@ func TestGRPCDinosaurCRUD(t *testing.T) {
 	Expect(updated.Species).To(Equal("UpdatedDinosaurus"))
 	Expect(updated.Metadata.Id).To(Equal(dinoID))
 
+	// an update that changes nothing leaves the resource version alone
+	unchanged, err := grpcClient.UpdateDinosaur(ctx, updateReq)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(unchanged.Metadata.ResourceVersion).To(Equal(updated.Metadata.ResourceVersion))
+
 	// Test List
 	listReq := &pb.ListDinosaursRequest{
 		Page: 1,
@@ -248,4 +255,132 @@ func TestGRPCErrorHandling(t *testing.T) {
 	deleteReq := &pb.DeleteDinosaurRequest{Id: "nonexistent"}
 	_, err = grpcClient.DeleteDinosaur(context.Background(), deleteReq)
 	// Note: Delete of non-existent ID may succeed or fail depending on implementation
-}
\ No newline at end of file
+}
+func TestGRPCBatchDinosaurs(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	jwtToken := h.CreateJWTString(account)
+
+	conn, err := grpc.NewClient(
+		h.GRPCAddress(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(&bearerToken{token: jwtToken}),
+	)
+	Expect(err).NotTo(HaveOccurred())
+	defer conn.Close()
+
+	grpcClient := pb.NewDinosaurServiceClient(conn)
+	ctx := context.Background()
+
+	created, err := grpcClient.BatchCreateDinosaurs(ctx, &pb.BatchCreateDinosaursRequest{
+		Requests: []*pb.CreateDinosaurRequest{{Species: "Triceratops"}, {Species: "Stegosaurus"}},
+	})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(created.Results).To(HaveLen(2))
+	Expect(created.Results[0].Error).To(BeNil())
+	Expect(created.Results[0].Dinosaur.Species).To(Equal("Triceratops"))
+
+	species := "Brachiosaurus"
+	updated, err := grpcClient.BatchUpdateDinosaurs(ctx, &pb.BatchUpdateDinosaursRequest{
+		Requests: []*pb.UpdateDinosaurRequest{{Id: created.Results[0].Id, Species: &species}, {Id: "nonexistent", Species: &species}},
+	})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(updated.Results[0].Dinosaur.Species).To(Equal(species))
+	Expect(updated.Results[1].Error.Code).To(Equal(int32(codes.NotFound)))
+
+	// an invalid item fails the whole request
+	_, err = grpcClient.BatchCreateDinosaurs(ctx, &pb.BatchCreateDinosaursRequest{
+		Requests: []*pb.CreateDinosaurRequest{{Species: "Triceratops"}, {Species: ""}},
+	})
+	Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+	_, err = grpcClient.BatchDeleteDinosaurs(ctx, &pb.BatchDeleteDinosaursRequest{
+		Ids:          []string{created.Results[0].Id, "nonexistent"},
+		AllOrNothing: true,
+	})
+	Expect(status.Code(err)).To(Equal(codes.NotFound))
+
+	deleted, err := grpcClient.BatchDeleteDinosaurs(ctx, &pb.BatchDeleteDinosaursRequest{
+		Ids: []string{created.Results[0].Id, created.Results[1].Id},
+	})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(deleted.Results[0].Error).To(BeNil())
+	Expect(deleted.Results[1].Error).To(BeNil())
+}
+
+func TestGRPCWatchDinosaursResume(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+	h.StartControllersServer()
+
+	account := h.NewRandAccount()
+	jwtToken := h.CreateJWTString(account)
+
+	conn, err := grpc.NewClient(
+		h.GRPCAddress(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(&bearerToken{token: jwtToken}),
+	)
+	Expect(err).NotTo(HaveOccurred())
+	defer conn.Close()
+
+	grpcClient := pb.NewDinosaurServiceClient(conn)
+	species := fmt.Sprintf("Watchosaurus_%d", time.Now().UnixNano())
+	filter := fmt.Sprintf("species = '%s'", species)
+
+	watchCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// the first watch only sees the dinosaurs matching its filter
+	stream, err := grpcClient.WatchDinosaurs(watchCtx, &pb.WatchDinosaursRequest{Filter: filter})
+	Expect(err).NotTo(HaveOccurred())
+	time.Sleep(100 * time.Millisecond)
+	_, err = grpcClient.CreateDinosaur(context.Background(), &pb.CreateDinosaurRequest{Species: "Tyrannosaurus"})
+	Expect(err).NotTo(HaveOccurred())
+	first, err := grpcClient.CreateDinosaur(context.Background(), &pb.CreateDinosaurRequest{Species: species})
+	Expect(err).NotTo(HaveOccurred())
+
+	evt, err := stream.Recv()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(evt.ResourceId).To(Equal(first.Metadata.Id))
+	Expect(evt.EventId).NotTo(BeEmpty())
+	Expect(evt.EventSeq).To(BeNumerically(">", 0))
+	resumeFrom := evt.EventId
+	eventSeq := evt.EventSeq
+
+	// changes made while nobody watches are replayed when the watch resumes
+	_, err = grpcClient.CreateDinosaur(context.Background(), &pb.CreateDinosaurRequest{Species: "Tyrannosaurus"})
+	Expect(err).NotTo(HaveOccurred())
+	missed, err := grpcClient.CreateDinosaur(context.Background(), &pb.CreateDinosaurRequest{Species: species})
+	Expect(err).NotTo(HaveOccurred())
+
+	stream, err = grpcClient.WatchDinosaurs(watchCtx, &pb.WatchDinosaursRequest{ResumeFrom: resumeFrom, Filter: filter})
+	Expect(err).NotTo(HaveOccurred())
+	evt, err = stream.Recv()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(evt.Type).To(Equal(pb.EventType_EVENT_TYPE_CREATED))
+	Expect(evt.ResourceId).To(Equal(missed.Metadata.Id))
+	Expect(evt.EventSeq).To(BeNumerically(">", eventSeq))
+
+	// the event sequence number is a resume point as well
+	stream, err = grpcClient.WatchDinosaurs(watchCtx, &pb.WatchDinosaursRequest{EventSeq: eventSeq, Filter: filter})
+	Expect(err).NotTo(HaveOccurred())
+	evt, err = stream.Recv()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(evt.ResourceId).To(Equal(missed.Metadata.Id))
+
+	// an event that isn't kept can't be resumed from
+	stream, err = grpcClient.WatchDinosaurs(watchCtx, &pb.WatchDinosaursRequest{ResumeFrom: "unknown"})
+	Expect(err).NotTo(HaveOccurred())
+	evt, err = stream.Recv()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(evt.Type).To(Equal(pb.EventType_EVENT_TYPE_RESYNC_REQUIRED))
+	Expect(evt.EventId).To(BeEmpty())
+	_, err = stream.Recv()
+	Expect(err).To(Equal(io.EOF))
+
+	stream, err = grpcClient.WatchDinosaurs(watchCtx, &pb.WatchDinosaursRequest{Filter: "species ="})
+	Expect(err).NotTo(HaveOccurred())
+	_, err = stream.Recv()
+	Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+}
@@ -2,17 +2,21 @@ package dinosaurs
 
 import (
 	pb "github.com/openshift-online/rh-trex-ai/pkg/api/grpc/rh_trex/v1"
+	"github.com/openshift-online/rh-trex-ai/pkg/server/grpcutil"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func dinosaurToProto(d *Dinosaur) *pb.Dinosaur {
+// DinosaurToProto converts a Dinosaur to its protobuf message
+func DinosaurToProto(d *Dinosaur) *pb.Dinosaur {
 	return &pb.Dinosaur{
 		Metadata: &pb.ObjectReference{
-			Id:        d.ID,
-			CreatedAt: timestamppb.New(d.CreatedAt),
-			UpdatedAt: timestamppb.New(d.UpdatedAt),
-			Kind:      "Dinosaur",
-			Href:      "/api/rh-trex-ai/v1/dinosaurs/" + d.ID,
+			Id:              d.ID,
+			CreatedAt:       timestamppb.New(d.CreatedAt),
+			UpdatedAt:       timestamppb.New(d.UpdatedAt),
+			Kind:            "Dinosaur",
+			Href:            "/api/rh-trex-ai/v1/dinosaurs/" + d.ID,
+			ResourceVersion: d.ResourceVersion,
+			DeletedAt:       grpcutil.DeletedAtToProto(d.DeletedAt),
 		},
 		Species: d.Species,
 	}
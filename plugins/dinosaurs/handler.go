@@ -1,28 +1,37 @@
 package dinosaurs
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/gorilla/mux"
 
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
 	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
 	"github.com/openshift-online/rh-trex-ai/pkg/api/presenters"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
 	"github.com/openshift-online/rh-trex-ai/pkg/errors"
 	"github.com/openshift-online/rh-trex-ai/pkg/handlers"
+	pkgserver "github.com/openshift-online/rh-trex-ai/pkg/server"
 	"github.com/openshift-online/rh-trex-ai/pkg/services"
+	"github.com/openshift-online/rh-trex-ai/pkg/validation"
 )
 
 var _ handlers.RestHandler = dinosaurHandler{}
 
 type dinosaurHandler struct {
-	dinosaur DinosaurService
-	generic  services.GenericService
+	dinosaur   DinosaurService
+	generic    services.GenericService
+	brokerFunc func() *pkgserver.EventBroker
 }
 
-func NewDinosaurHandler(dinosaur DinosaurService, generic services.GenericService) *dinosaurHandler {
+func NewDinosaurHandler(dinosaur DinosaurService, generic services.GenericService, brokerFunc func() *pkgserver.EventBroker) *dinosaurHandler {
 	return &dinosaurHandler{
-		dinosaur: dinosaur,
-		generic:  generic,
+		dinosaur:   dinosaur,
+		generic:    generic,
+		brokerFunc: brokerFunc,
 	}
 }
 
@@ -32,6 +41,7 @@ func (h dinosaurHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Body: &dinosaur,
 		Validators: []handlers.Validate{
 			handlers.ValidateEmpty(&dinosaur, "Id", "id"),
+			handlers.ValidateCreate(dinosaurRules, &dinosaur),
 		},
 		Action: func() (interface{}, *errors.ServiceError) {
 			ctx := r.Context()
@@ -40,6 +50,7 @@ func (h dinosaurHandler) Create(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				return nil, err
 			}
+			handlers.SetETag(w, dinosaurModel.ResourceVersion)
 			return PresentDinosaur(dinosaurModel), nil
 		},
 		ErrorHandler: handlers.HandleError,
@@ -48,28 +59,41 @@ func (h dinosaurHandler) Create(w http.ResponseWriter, r *http.Request) {
 	handlers.Handle(w, r, cfg, http.StatusCreated)
 }
 
+// Patch applies a partial dinosaur, a JSON Merge Patch or a JSON Patch, by the Content-Type of the request
 func (h dinosaurHandler) Patch(w http.ResponseWriter, r *http.Request) {
-	var patch openapi.DinosaurPatchRequest
+	var patch json.RawMessage
 
 	cfg := &handlers.HandlerConfig{
-		Body:       &patch,
-		Validators: []handlers.Validate{},
+		Body: &patch,
 		Action: func() (interface{}, *errors.ServiceError) {
 			ctx := r.Context()
+			format, err := handlers.PatchFormatOf(r)
+			if err != nil {
+				return nil, err
+			}
 			id := mux.Vars(r)["id"]
 			found, err := h.dinosaur.Get(ctx, id)
 			if err != nil {
 				return nil, err
 			}
-
-			if patch.Species != nil {
-				found.Species = *patch.Species
+			if err := handlers.ValidateIfMatch(r, found.ResourceVersion); err != nil {
+				return nil, err
+			}
+			patched, changed, err := patchDinosaur(format, patch, found)
+			if err != nil {
+				return nil, err
 			}
 
-			dinosaurModel, err := h.dinosaur.Replace(ctx, found)
+			// a patch that changes nothing doesn't write, bump the resource version or record an event
+			if len(changed) == 0 {
+				handlers.SetETag(w, found.ResourceVersion)
+				return PresentDinosaur(found), nil
+			}
+			dinosaurModel, err := h.dinosaur.Replace(services.WithChangedFields(ctx, changed), patched)
 			if err != nil {
 				return nil, err
 			}
+			handlers.SetETag(w, dinosaurModel.ResourceVersion)
 			return PresentDinosaur(dinosaurModel), nil
 		},
 		ErrorHandler: handlers.HandleError,
@@ -85,7 +109,7 @@ func (h dinosaurHandler) List(w http.ResponseWriter, r *http.Request) {
 
 			listArgs := services.NewListArguments(r.URL.Query())
 			var dinosaurs []Dinosaur
-			paging, err := h.generic.List(ctx, "id", listArgs, &dinosaurs)
+			paging, err := h.generic.List(ctx, listArgs, &dinosaurs)
 			if err != nil {
 				return nil, err
 			}
@@ -96,6 +120,9 @@ func (h dinosaurHandler) List(w http.ResponseWriter, r *http.Request) {
 				Total: int32(paging.Total),
 				Items: []openapi.Dinosaur{},
 			}
+			if paging.Continue != "" {
+				dinosaurList.Continue = openapi.PtrString(paging.Continue)
+			}
 
 			for _, dinosaur := range dinosaurs {
 				converted := PresentDinosaur(&dinosaur)
@@ -115,6 +142,91 @@ func (h dinosaurHandler) List(w http.ResponseWriter, r *http.Request) {
 	handlers.HandleList(w, r, cfg)
 }
 
+// Watch streams the events of the dinosaurs that match the search parameter as Server-Sent Events,
+// resuming after the Last-Event-ID header if set
+func (h dinosaurHandler) Watch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	broker := h.brokerFunc()
+	if broker == nil {
+		handlers.HandleError(ctx, w, errors.GeneralError("Event broker not available"))
+		return
+	}
+	filter := r.URL.Query().Get("search")
+	if err := validateDinosaurWatchFilter(ctx, h.generic, filter); err != nil {
+		handlers.HandleError(ctx, w, err)
+		return
+	}
+
+	broker.ServeSSE(w, r, "Dinosaurs", func(ctx context.Context, evt *pkgserver.BrokerEvent) (interface{}, bool, *errors.ServiceError) {
+		dinosaur, ok, err := watchedDinosaur(ctx, h.generic, evt, filter)
+		if err != nil || dinosaur == nil {
+			return nil, ok, err
+		}
+		return PresentDinosaur(dinosaur), true, nil
+	})
+}
+
+// validateDinosaurWatchFilter fails a watch filter that isn't a valid search right away, rather than
+// with the first event
+func validateDinosaurWatchFilter(ctx context.Context, generic services.GenericService, filter string) *errors.ServiceError {
+	if filter == "" {
+		return nil
+	}
+	var dinosaurs []Dinosaur
+	_, err := generic.List(ctx, &services.ListArguments{Page: 1, Size: 1, Search: filter, SkipCount: true}, &dinosaurs)
+	return err
+}
+
+// watchedDinosaur returns the dinosaur of a watch event, and whether the event is sent at all: events of
+// dinosaurs that don't match filter or aren't visible to the caller are skipped. Deleted dinosaurs are
+// matched on their last state, no dinosaur is returned for deletes.
+func watchedDinosaur(ctx context.Context, generic services.GenericService, evt *pkgserver.BrokerEvent, filter string) (*Dinosaur, bool, *errors.ServiceError) {
+	if filter == "" {
+		return unfilteredWatchedDinosaur(ctx, generic, evt)
+	}
+	var dinosaurs []Dinosaur
+	search := fmt.Sprintf("id = '%s' and (%s)", evt.SourceID, filter)
+	listArgs := &services.ListArguments{Page: 1, Size: 1, Search: search, SkipCount: true, IncludeDeleted: true}
+	if _, err := generic.List(ctx, listArgs, &dinosaurs); err != nil || len(dinosaurs) == 0 {
+		return nil, false, err
+	}
+	if evt.EventType == api.DeleteEventType {
+		return nil, true, nil
+	}
+	return &dinosaurs[0], true, nil
+}
+
+// unfilteredWatchedDinosaur is watchedDinosaur for watches without a filter. Deletes are sent without reading the
+// dinosaur, and the dinosaur of other events is read once for every watcher of the event.
+func unfilteredWatchedDinosaur(ctx context.Context, generic services.GenericService, evt *pkgserver.BrokerEvent) (*Dinosaur, bool, *errors.ServiceError) {
+	if evt.EventType == api.DeleteEventType {
+		return nil, evt.VisibleTo(ctx), nil
+	}
+	loaded, err := evt.Resource(ctx, func(ctx context.Context) (interface{}, *errors.ServiceError) {
+		var dinosaurs []Dinosaur
+		listArgs := &services.ListArguments{Page: 1, Size: 1, Search: fmt.Sprintf("id = '%s'", evt.SourceID), SkipCount: true, IncludeDeleted: true}
+		if _, err := generic.List(ctx, listArgs, &dinosaurs); err != nil || len(dinosaurs) == 0 {
+			return nil, err
+		}
+		return &dinosaurs[0], nil
+	})
+	dinosaur, _ := loaded.(*Dinosaur)
+	if err != nil || dinosaur == nil {
+		// purged since
+		return nil, false, err
+	}
+	return dinosaur, auth.InTenantScope(ctx, dinosaur.OrganizationID), nil
+}
+
+// presentWatchedDinosaur is watchedDinosaur for consumers of presented dinosaurs, e.g. webhooks
+func presentWatchedDinosaur(ctx context.Context, generic services.GenericService, evt *pkgserver.BrokerEvent, filter string) (interface{}, bool, *errors.ServiceError) {
+	dinosaur, matched, err := watchedDinosaur(ctx, generic, evt, filter)
+	if dinosaur == nil {
+		return nil, matched, err
+	}
+	return PresentDinosaur(dinosaur), matched, err
+}
+
 func (h dinosaurHandler) Get(w http.ResponseWriter, r *http.Request) {
 	cfg := &handlers.HandlerConfig{
 		Action: func() (interface{}, *errors.ServiceError) {
@@ -124,6 +236,7 @@ func (h dinosaurHandler) Get(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				return nil, err
 			}
+			handlers.SetETag(w, dinosaur.ResourceVersion)
 
 			return PresentDinosaur(dinosaur), nil
 		},
@@ -137,7 +250,12 @@ func (h dinosaurHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		Action: func() (interface{}, *errors.ServiceError) {
 			id := mux.Vars(r)["id"]
 			ctx := r.Context()
-			err := h.dinosaur.Delete(ctx, id)
+			// the If-Match versions are checked by the DELETE itself, not by a read before it
+			resourceVersions, err := handlers.IfMatchVersions(r)
+			if err != nil {
+				return nil, err
+			}
+			err = h.dinosaur.Delete(ctx, id, resourceVersions)
 			if err != nil {
 				return nil, err
 			}
@@ -146,3 +264,189 @@ func (h dinosaurHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
 }
+
+// Restore undeletes a soft-deleted dinosaur
+func (h dinosaurHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			dinosaur, err := h.dinosaur.Restore(r.Context(), id)
+			if err != nil {
+				return nil, err
+			}
+			handlers.SetETag(w, dinosaur.ResourceVersion)
+			return PresentDinosaur(dinosaur), nil
+		},
+	}
+	handlers.HandleWithoutBody(w, r, cfg, http.StatusOK)
+}
+
+// Purge hard-deletes a soft-deleted dinosaur before its retention has passed
+func (h dinosaurHandler) Purge(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			if err := h.dinosaur.Purge(r.Context(), id); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		},
+	}
+	handlers.HandleWithoutBody(w, r, cfg, http.StatusNoContent)
+}
+
+// patchDinosaur applies patch, the body of a PATCH request in format, to dinosaur. It returns the patched
+// dinosaur and the fields the patch changed.
+func patchDinosaur(format handlers.PatchFormat, patch []byte, dinosaur *Dinosaur) (*Dinosaur, []string, *errors.ServiceError) {
+	var patched openapi.Dinosaur
+	changed, err := handlers.ApplyPatch(format, patch, dinosaurRules, PresentDinosaur(dinosaur), &patched, openapi.DinosaurPatchRequest{})
+	if err != nil {
+		return nil, nil, err
+	}
+	result := ConvertDinosaur(patched)
+	result.Meta = dinosaur.Meta
+	return result, changed, nil
+}
+
+type dinosaurBatchCreateRequest struct {
+	Items        []openapi.Dinosaur `json:"items"`
+	AllOrNothing bool               `json:"all_or_nothing"`
+}
+
+type dinosaurBatchPatchItem struct {
+	ID              string                       `json:"id"`
+	ResourceVersion *int64                       `json:"resource_version,omitempty"`
+	Patch           openapi.DinosaurPatchRequest `json:"patch"`
+}
+
+type dinosaurBatchPatchRequest struct {
+	Items        []dinosaurBatchPatchItem `json:"items"`
+	AllOrNothing bool                     `json:"all_or_nothing"`
+}
+
+// BatchCreate creates every dinosaur of the request in one transaction
+func (h dinosaurHandler) BatchCreate(w http.ResponseWriter, r *http.Request) {
+	var batch dinosaurBatchCreateRequest
+	cfg := &handlers.HandlerConfig{
+		Body: &batch,
+		Validators: []handlers.Validate{
+			handlers.ValidateBatchSize(&batch, "Items", "items"),
+			func() *errors.ServiceError {
+				for i := range batch.Items {
+					if batch.Items[i].Id != nil {
+						return errors.Validation("items[%d].id must be empty", i)
+					}
+					if err := dinosaurRules.ValidateCreate(validation.FieldsOf(batch.Items[i])); err != nil {
+						return validation.ItemError("items", i, err)
+					}
+				}
+				return nil
+			},
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			dinosaurs := make(DinosaurList, len(batch.Items))
+			for i := range batch.Items {
+				dinosaurs[i] = ConvertDinosaur(batch.Items[i])
+			}
+			itemErrs, err := h.dinosaur.BatchCreate(ctx, dinosaurs, batch.AllOrNothing)
+			if err != nil {
+				return nil, err
+			}
+			response := handlers.BatchResponse{Kind: "DinosaurBatchResponse", Items: []handlers.BatchResult{}}
+			for i, dinosaur := range dinosaurs {
+				if itemErrs[i] != nil {
+					response.Items = append(response.Items, handlers.NewBatchResult(ctx, "", 0, nil, itemErrs[i]))
+					continue
+				}
+				response.Items = append(response.Items, handlers.NewBatchResult(ctx, dinosaur.ID, http.StatusCreated, PresentDinosaur(dinosaur), nil))
+			}
+			return response, nil
+		},
+		ErrorHandler: handlers.HandleError,
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+// BatchPatch patches every dinosaur of the request in one transaction. An item's resource_version works like If-Match.
+func (h dinosaurHandler) BatchPatch(w http.ResponseWriter, r *http.Request) {
+	var batch dinosaurBatchPatchRequest
+	cfg := &handlers.HandlerConfig{
+		Body: &batch,
+		Validators: []handlers.Validate{
+			handlers.ValidateBatchSize(&batch, "Items", "items"),
+			func() *errors.ServiceError {
+				for i := range batch.Items {
+					if err := dinosaurRules.ValidateUpdate(validation.FieldsOf(batch.Items[i].Patch), nil); err != nil {
+						return validation.ItemError("items", i, err)
+					}
+				}
+				return nil
+			},
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			ids := make([]string, len(batch.Items))
+			for i, item := range batch.Items {
+				ids[i] = item.ID
+			}
+			dinosaurs, itemErrs, err := h.dinosaur.BatchUpdate(ctx, ids, func(i int, dinosaur *Dinosaur) *errors.ServiceError {
+				if err := services.ValidateResourceVersion(batch.Items[i].ResourceVersion, dinosaur.ResourceVersion); err != nil {
+					return err
+				}
+				patch, err := json.Marshal(batch.Items[i].Patch)
+				if err != nil {
+					return errors.MalformedRequest("Invalid patch: %s", err)
+				}
+				patched, _, svcErr := patchDinosaur(handlers.PatchFormatJSON, patch, dinosaur)
+				if svcErr != nil {
+					return svcErr
+				}
+				*dinosaur = *patched
+				return nil
+			}, batch.AllOrNothing)
+			if err != nil {
+				return nil, err
+			}
+			response := handlers.BatchResponse{Kind: "DinosaurBatchResponse", Items: []handlers.BatchResult{}}
+			for i, id := range ids {
+				if itemErrs[i] != nil {
+					response.Items = append(response.Items, handlers.NewBatchResult(ctx, id, 0, nil, itemErrs[i]))
+					continue
+				}
+				response.Items = append(response.Items, handlers.NewBatchResult(ctx, id, http.StatusOK, PresentDinosaur(dinosaurs[i]), nil))
+			}
+			return response, nil
+		},
+		ErrorHandler: handlers.HandleError,
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+// BatchDelete deletes every dinosaur of the request in one transaction
+func (h dinosaurHandler) BatchDelete(w http.ResponseWriter, r *http.Request) {
+	var batch handlers.BatchDeleteRequest
+	cfg := &handlers.HandlerConfig{
+		Body: &batch,
+		Validators: []handlers.Validate{
+			handlers.ValidateBatchSize(&batch, "IDs", "ids"),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			itemErrs, err := h.dinosaur.BatchDelete(ctx, batch.IDs, batch.AllOrNothing)
+			if err != nil {
+				return nil, err
+			}
+			response := handlers.BatchResponse{Kind: "DinosaurBatchResponse", Items: []handlers.BatchResult{}}
+			for i, id := range batch.IDs {
+				response.Items = append(response.Items, handlers.NewBatchResult(ctx, id, http.StatusNoContent, nil, itemErrs[i]))
+			}
+			return response, nil
+		},
+		ErrorHandler: handlers.HandleError,
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
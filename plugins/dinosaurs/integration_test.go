@@ -1,15 +1,25 @@
 package dinosaurs_test
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	"gopkg.in/resty.v1"
 
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
 	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/controllers"
+	"github.com/openshift-online/rh-trex-ai/pkg/handlers"
 	"github.com/openshift-online/rh-trex-ai/test"
 )
 
@@ -84,6 +94,15 @@ func TestDinosaurPatch(t *testing.T) {
 	Expect(*dinosaurOutput.Kind).To(Equal("Dinosaur"))
 	Expect(*dinosaurOutput.Href).To(Equal(fmt.Sprintf("/api/rh-trex-ai/v1/dinosaurs/%s", *dinosaurOutput.Id)))
 
+	// patches that change nothing don't write: the resource version and update time stay
+	Expect(*dinosaurOutput.ResourceVersion).To(Equal(dinosaurModel.ResourceVersion))
+	Expect(*dinosaurOutput.UpdatedAt).To(BeTemporally("~", dinosaurModel.UpdatedAt))
+	dinosaurOutput, resp, err = client.DefaultAPI.ApiRhTrexAiV1DinosaursIdPatch(ctx, dinosaurModel.ID).DinosaurPatchRequest(openapi.DinosaurPatchRequest{Species: &dinosaurModel.Species}).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	Expect(resp.Header.Get("ETag")).To(Equal(handlers.ETag(dinosaurModel.ResourceVersion)))
+	Expect(*dinosaurOutput.ResourceVersion).To(Equal(dinosaurModel.ResourceVersion))
+
 	jwtToken := ctx.Value(openapi.ContextAccessToken)
 	restyResp, err := resty.R().
 		SetHeader("Content-Type", "application/json").
@@ -94,6 +113,64 @@ func TestDinosaurPatch(t *testing.T) {
 	Expect(restyResp.StatusCode()).To(Equal(http.StatusBadRequest))
 }
 
+func TestDinosaurPatchIfMatch(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	dinosaurModel, err := newDinosaur(h.NewID())
+	Expect(err).NotTo(HaveOccurred())
+
+	dinosaurOutput, resp, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursIdGet(ctx, dinosaurModel.ID).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(*dinosaurOutput.ResourceVersion).To(Equal(int64(1)))
+	etag := resp.Header.Get("ETag")
+	Expect(etag).To(Equal(`"1"`))
+
+	jwtToken := ctx.Value(openapi.ContextAccessToken)
+	restyResp, err := resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetHeader("If-None-Match", etag).
+		Get(h.RestURL("/dinosaurs/" + dinosaurModel.ID))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusNotModified))
+
+	patch := func(ifMatch string) *resty.Response {
+		restyResp, err := resty.R().
+			SetHeader("Content-Type", "application/json").
+			SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+			SetHeader("If-Match", ifMatch).
+			SetBody(`{ "species": "updated-species" }`).
+			Patch(h.RestURL("/dinosaurs/" + dinosaurModel.ID))
+		Expect(err).NotTo(HaveOccurred())
+		return restyResp
+	}
+
+	restyResp = patch(etag)
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+	Expect(restyResp.Header().Get("ETag")).To(Equal(`"2"`))
+
+	// the first writer has already moved the resource on, so the stale version must be rejected
+	restyResp = patch(etag)
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusPreconditionFailed))
+
+	del := func(ifMatch string) *resty.Response {
+		restyResp, err := resty.R().
+			SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+			SetHeader("If-Match", ifMatch).
+			Delete(h.RestURL("/dinosaurs/" + dinosaurModel.ID))
+		Expect(err).NotTo(HaveOccurred())
+		return restyResp
+	}
+
+	// deletes check the version in the DELETE itself
+	restyResp = del(etag)
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusPreconditionFailed))
+	restyResp = del(`"2"`)
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusNoContent))
+}
+
 func TestDinosaurPaging(t *testing.T) {
 	h, client := test.RegisterIntegration(t)
 
@@ -118,6 +195,45 @@ func TestDinosaurPaging(t *testing.T) {
 	Expect(list.Page).To(Equal(int32(2)))
 }
 
+func TestDinosaurPagingContinue(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	_, err := newDinosaurList("Bronto", 12)
+	Expect(err).NotTo(HaveOccurred())
+
+	seen := map[string]bool{}
+	list, _, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursGet(ctx).Size(5).OrderBy("species desc").SkipCount(true).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error getting dinosaur list: %v", err)
+	Expect(list.Total).To(Equal(int32(0)))
+	for pages := 1; ; pages++ {
+		for _, dino := range list.Items {
+			Expect(seen[*dino.Id]).To(BeFalse(), "dinosaur %s listed twice", *dino.Id)
+			seen[*dino.Id] = true
+		}
+		if !list.HasContinue() {
+			Expect(pages).To(Equal(3))
+			break
+		}
+		list, _, err = client.DefaultAPI.ApiRhTrexAiV1DinosaursGet(ctx).Size(5).Continue_(list.GetContinue()).Execute()
+		Expect(err).NotTo(HaveOccurred(), "Error getting dinosaur list: %v", err)
+	}
+	Expect(seen).To(HaveLen(12))
+
+	// the total counts the whole list on the continued pages too
+	first, _, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursGet(ctx).Size(5).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error getting dinosaur list: %v", err)
+	next, _, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursGet(ctx).Size(5).Continue_(first.GetContinue()).Execute()
+	Expect(err).NotTo(HaveOccurred(), "Error getting dinosaur list: %v", err)
+	Expect(next.Total).To(Equal(first.Total))
+
+	_, resp, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursGet(ctx).Continue_("garbage").Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+}
+
 func TestDinosaurListSearch(t *testing.T) {
 	h, client := test.RegisterIntegration(t)
 
@@ -134,3 +250,437 @@ func TestDinosaurListSearch(t *testing.T) {
 	Expect(list.Total).To(Equal(int32(1)))
 	Expect(*list.Items[0].Id).To(Equal(dinosaurs[0].ID))
 }
+
+func TestDinosaurRestoreAndPurge(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	jwtToken := ctx.Value(openapi.ContextAccessToken)
+
+	dinosaurModel, err := newDinosaur(h.NewID())
+	Expect(err).NotTo(HaveOccurred())
+
+	post := func(action string) *resty.Response {
+		restyResp, err := resty.R().
+			SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+			Post(h.RestURL("/dinosaurs/" + dinosaurModel.ID + action))
+		Expect(err).NotTo(HaveOccurred())
+		return restyResp
+	}
+
+	// only deleted dinosaurs can be restored
+	Expect(post(":restore").StatusCode()).To(Equal(http.StatusConflict))
+
+	restyResp, err := resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		Delete(h.RestURL("/dinosaurs/" + dinosaurModel.ID))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusNoContent))
+
+	search := fmt.Sprintf("id = '%s'", dinosaurModel.ID)
+	list, _, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursGet(ctx).Search(search).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(list.Items).To(BeEmpty())
+
+	list, _, err = client.DefaultAPI.ApiRhTrexAiV1DinosaursGet(ctx).Search(search).IncludeDeleted(true).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(list.Items).To(HaveLen(1))
+	Expect(list.Items[0].DeletedAt).NotTo(BeNil())
+
+	restyResp = post(":restore")
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+	dinosaurOutput, _, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursIdGet(ctx, dinosaurModel.ID).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(dinosaurOutput.DeletedAt).To(BeNil())
+
+	restyResp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		Delete(h.RestURL("/dinosaurs/" + dinosaurModel.ID))
+	Expect(err).NotTo(HaveOccurred())
+
+	// purging is left to admins
+	Expect(post(":purge").StatusCode()).To(Equal(http.StatusForbidden))
+	h.GrantRole(account, auth.RoleAdmin)
+	Expect(post(":purge").StatusCode()).To(Equal(http.StatusNoContent))
+
+	list, _, err = client.DefaultAPI.ApiRhTrexAiV1DinosaursGet(ctx).Search(search).IncludeDeleted(true).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(list.Items).To(BeEmpty())
+	Expect(post(":restore").StatusCode()).To(Equal(http.StatusNotFound))
+}
+
+func TestDinosaurTenancy(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	owner := h.NewRandAccountInOrganization("org-" + h.NewID())
+	ownerCtx := h.NewAuthenticatedContext(owner)
+	stranger := h.NewRandAccountInOrganization("org-" + h.NewID())
+	strangerCtx := h.NewAuthenticatedContext(stranger)
+
+	dinosaur, resp, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursPost(ownerCtx).Dinosaur(openapi.Dinosaur{Species: "Stegosaurus"}).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+
+	// rows of another organization don't exist for the caller
+	_, resp, err = client.DefaultAPI.ApiRhTrexAiV1DinosaursIdGet(strangerCtx, *dinosaur.Id).Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+
+	search := fmt.Sprintf("id = '%s'", *dinosaur.Id)
+	list, _, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursGet(strangerCtx).Search(search).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(list.Items).To(BeEmpty())
+
+	restyResp, err := resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", strangerCtx.Value(openapi.ContextAccessToken))).
+		Delete(h.RestURL("/dinosaurs/" + *dinosaur.Id))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusNotFound))
+
+	list, _, err = client.DefaultAPI.ApiRhTrexAiV1DinosaursGet(ownerCtx).Search(search).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(list.Items).To(HaveLen(1))
+
+	// admins work across organizations
+	h.GrantRole(stranger, auth.RoleAdmin)
+	_, resp, err = client.DefaultAPI.ApiRhTrexAiV1DinosaursIdGet(strangerCtx, *dinosaur.Id).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+}
+
+func TestDinosaurBatch(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	jwtToken := ctx.Value(openapi.ContextAccessToken)
+
+	batch := func(method string, body interface{}) (int, handlers.BatchResponse) {
+		var result handlers.BatchResponse
+		restyResp, err := resty.R().
+			SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+			SetBody(body).
+			SetResult(&result).
+			Post(h.RestURL("/dinosaurs:" + method))
+		Expect(err).NotTo(HaveOccurred())
+		return restyResp.StatusCode(), result
+	}
+
+	status, created := batch("batchCreate", map[string]interface{}{
+		"items": []openapi.Dinosaur{{Species: "Triceratops"}, {Species: "Stegosaurus"}},
+	})
+	Expect(status).To(Equal(http.StatusOK))
+	Expect(created.Items).To(HaveLen(2))
+	for _, item := range created.Items {
+		Expect(item.Status).To(Equal(http.StatusCreated))
+		Expect(item.ID).NotTo(BeEmpty())
+	}
+	ids := []string{created.Items[0].ID, created.Items[1].ID}
+
+	// per item, the unknown id fails on its own and the others are patched
+	status, patched := batch("batchPatch", map[string]interface{}{
+		"items": []map[string]interface{}{
+			{"id": ids[0], "patch": map[string]string{"species": "Brachiosaurus"}},
+			{"id": "unknown", "patch": map[string]string{"species": "Brachiosaurus"}},
+			{"id": ids[1], "resource_version": 42, "patch": map[string]string{"species": "Brachiosaurus"}},
+		},
+	})
+	Expect(status).To(Equal(http.StatusOK))
+	Expect(patched.Items[0].Status).To(Equal(http.StatusOK))
+	Expect(patched.Items[1].Status).To(Equal(http.StatusNotFound))
+	Expect(patched.Items[2].Status).To(Equal(http.StatusPreconditionFailed))
+
+	dinosaur, _, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursIdGet(ctx, ids[0]).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(dinosaur.Species).To(Equal("Brachiosaurus"))
+
+	// all or nothing rolls back the whole batch
+	status, _ = batch("batchDelete", map[string]interface{}{"ids": []string{ids[0], "unknown"}, "all_or_nothing": true})
+	Expect(status).To(Equal(http.StatusNotFound))
+	_, _, err = client.DefaultAPI.ApiRhTrexAiV1DinosaursIdGet(ctx, ids[0]).Execute()
+	Expect(err).NotTo(HaveOccurred())
+
+	status, deleted := batch("batchDelete", map[string]interface{}{"ids": ids})
+	Expect(status).To(Equal(http.StatusOK))
+	Expect(deleted.Items[0].Status).To(Equal(http.StatusNoContent))
+	Expect(deleted.Items[1].Status).To(Equal(http.StatusNoContent))
+
+	status, _ = batch("batchDelete", map[string]interface{}{"ids": []string{}})
+	Expect(status).To(Equal(http.StatusBadRequest))
+}
+
+func TestDinosaurIdempotencyKey(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	jwtToken := ctx.Value(openapi.ContextAccessToken)
+
+	post := func(key, species string) *resty.Response {
+		restyResp, err := resty.R().
+			SetHeader("Content-Type", "application/json").
+			SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+			SetHeader(handlers.IdempotencyKeyHeader, key).
+			SetBody(fmt.Sprintf(`{"species": %q}`, species)).
+			Post(h.RestURL("/dinosaurs"))
+		Expect(err).NotTo(HaveOccurred())
+		return restyResp
+	}
+
+	key := api.NewID()
+	species := "Ankylosaurus " + key
+	first := post(key, species)
+	Expect(first.StatusCode()).To(Equal(http.StatusCreated))
+
+	// the retry gets the first response back and doesn't create a second dinosaur
+	retry := post(key, species)
+	Expect(retry.StatusCode()).To(Equal(http.StatusCreated))
+	Expect(retry.Header().Get(handlers.IdempotentReplayedHeader)).To(Equal("true"))
+	Expect(retry.Body()).To(Equal(first.Body()))
+
+	list, _, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursGet(ctx).Search(fmt.Sprintf("species = '%s'", species)).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(list.Items).To(HaveLen(1))
+
+	// the same key with another body is rejected
+	Expect(post(key, "Diplodocus").StatusCode()).To(Equal(http.StatusUnprocessableEntity))
+}
+
+func TestDinosaurAuditEvents(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	jwtToken := ctx.Value(openapi.ContextAccessToken)
+
+	dinosaur, _, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursPost(ctx).Dinosaur(openapi.Dinosaur{Species: "Allosaurus"}).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	_, _, err = client.DefaultAPI.ApiRhTrexAiV1DinosaursIdPatch(ctx, *dinosaur.Id).DinosaurPatchRequest(openapi.DinosaurPatchRequest{Species: openapi.PtrString("Megalosaurus")}).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	restyResp, err := resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		Delete(h.RestURL("/dinosaurs/" + *dinosaur.Id))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusNoContent))
+
+	listAuditEvents := func() (int, map[string]interface{}) {
+		var result map[string]interface{}
+		restyResp, err := resty.R().
+			SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+			SetQueryParam("search", fmt.Sprintf("source = 'Dinosaurs' and source_id = '%s'", *dinosaur.Id)).
+			SetResult(&result).
+			Get(h.RestURL("/audit_events"))
+		Expect(err).NotTo(HaveOccurred())
+		return restyResp.StatusCode(), result
+	}
+
+	// the audit trail is for admins only
+	status, _ := listAuditEvents()
+	Expect(status).To(Equal(http.StatusForbidden))
+
+	h.GrantRole(account, auth.RoleAdmin)
+	status, list := listAuditEvents()
+	Expect(status).To(Equal(http.StatusOK))
+	items := list["items"].([]interface{})
+	Expect(items).To(HaveLen(3))
+
+	var actions []string
+	for _, item := range items {
+		auditEvent := item.(map[string]interface{})
+		Expect(auditEvent["username"]).To(Equal(strings.ToLower(account.Username())))
+		Expect(auditEvent["operation_id"]).NotTo(BeEmpty())
+		actions = append(actions, auditEvent["action"].(string))
+	}
+	Expect(actions).To(ConsistOf("Create", "Update", "Delete"))
+
+	for _, item := range items {
+		auditEvent := item.(map[string]interface{})
+		if auditEvent["action"] == "Update" {
+			Expect(auditEvent["before"].(map[string]interface{})["species"]).To(Equal("Allosaurus"))
+			Expect(auditEvent["after"].(map[string]interface{})["species"]).To(Equal("Megalosaurus"))
+		}
+	}
+}
+
+func TestDinosaurWatch(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+	h.StartControllersServer()
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	jwtToken := ctx.Value(openapi.ContextAccessToken)
+
+	species := fmt.Sprintf("Watchosaurus_%d", time.Now().UnixNano())
+	watch := func(lastEventID string) (*http.Response, *bufio.Reader) {
+		req, err := http.NewRequest(http.MethodGet, h.RestURL("/dinosaurs"), nil)
+		Expect(err).NotTo(HaveOccurred())
+		query := req.URL.Query()
+		query.Set("watch", "true")
+		query.Set("search", fmt.Sprintf("species = '%s'", species))
+		req.URL.RawQuery = query.Encode()
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", jwtToken))
+		req.Header.Set("Last-Event-ID", lastEventID)
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		Expect(resp.Header.Get("Content-Type")).To(Equal("text/event-stream"))
+		return resp, bufio.NewReader(resp.Body)
+	}
+	// readFrame returns the fields of the next frame that isn't a comment
+	readFrame := func(reader *bufio.Reader) map[string]string {
+		frame := map[string]string{}
+		for {
+			line, err := reader.ReadString('\n')
+			Expect(err).NotTo(HaveOccurred())
+			line = strings.TrimSuffix(line, "\n")
+			switch {
+			case strings.HasPrefix(line, ":"):
+			case line == "":
+				if len(frame) > 0 {
+					return frame
+				}
+			default:
+				field, value, _ := strings.Cut(line, ": ")
+				frame[field] = value
+			}
+		}
+	}
+
+	// the watch only sees the dinosaurs matching its search
+	resp, reader := watch("")
+	time.Sleep(100 * time.Millisecond)
+	_, _, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursPost(ctx).Dinosaur(openapi.Dinosaur{Species: "Tyrannosaurus"}).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	first, _, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursPost(ctx).Dinosaur(openapi.Dinosaur{Species: species}).Execute()
+	Expect(err).NotTo(HaveOccurred())
+
+	frame := readFrame(reader)
+	Expect(frame["event"]).To(Equal("Create"))
+	Expect(frame["id"]).NotTo(BeEmpty())
+	var watchEvent struct {
+		EventID    string           `json:"event_id"`
+		ResourceID string           `json:"resource_id"`
+		Object     openapi.Dinosaur `json:"object"`
+	}
+	Expect(json.Unmarshal([]byte(frame["data"]), &watchEvent)).To(Succeed())
+	Expect(watchEvent.EventID).To(Equal(frame["id"]))
+	Expect(watchEvent.ResourceID).To(Equal(*first.Id))
+	Expect(watchEvent.Object.Species).To(Equal(species))
+	Expect(resp.Body.Close()).To(Succeed())
+
+	// changes made while nobody watches are replayed after Last-Event-ID
+	missed, _, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursPost(ctx).Dinosaur(openapi.Dinosaur{Species: species}).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	resp, reader = watch(frame["id"])
+	defer resp.Body.Close()
+	frame = readFrame(reader)
+	Expect(frame["event"]).To(Equal("Create"))
+	Expect(frame["data"]).To(ContainSubstring(*missed.Id))
+}
+
+func TestDinosaurWebhooks(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+	h.StartControllersServer()
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	jwtToken := ctx.Value(openapi.ContextAccessToken)
+
+	received := make(chan *http.Request, 10)
+	bodies := make(chan []byte, 10)
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- r
+		bodies <- body
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	species := fmt.Sprintf("Hookosaurus_%d", time.Now().UnixNano())
+	createWebhook := func(webhook map[string]string) (int, map[string]interface{}) {
+		var result map[string]interface{}
+		restyResp, err := resty.R().
+			SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+			SetBody(webhook).
+			SetResult(&result).
+			Post(h.RestURL("/webhooks"))
+		Expect(err).NotTo(HaveOccurred())
+		return restyResp.StatusCode(), result
+	}
+	webhook := map[string]string{
+		"url":    receiver.URL,
+		"secret": "s3cr3t",
+		"source": "Dinosaurs",
+		"filter": fmt.Sprintf("species = '%s'", species),
+	}
+
+	// webhooks are for admins only
+	status, _ := createWebhook(webhook)
+	Expect(status).To(Equal(http.StatusForbidden))
+
+	h.GrantRole(account, auth.RoleAdmin)
+	status, _ = createWebhook(map[string]string{"url": receiver.URL, "secret": "s3cr3t", "source": "Unicorns"})
+	Expect(status).To(Equal(http.StatusBadRequest))
+	status, _ = createWebhook(map[string]string{"url": receiver.URL, "secret": "s3cr3t", "filter": "species = 'Rex'"})
+	Expect(status).To(Equal(http.StatusBadRequest))
+	status, _ = createWebhook(map[string]string{"url": receiver.URL, "secret": "s3cr3t", "source": "Dinosaurs", "filter": "unknown_column = 'x'"})
+	Expect(status).To(Equal(http.StatusBadRequest))
+
+	status, created := createWebhook(webhook)
+	Expect(status).To(Equal(http.StatusCreated))
+	Expect(created["kind"]).To(Equal("Webhook"))
+	Expect(created).NotTo(HaveKey("secret"))
+	webhookID := created["id"].(string)
+
+	// only the dinosaurs matching the filter are delivered
+	_, _, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursPost(ctx).Dinosaur(openapi.Dinosaur{Species: "Tyrannosaurus"}).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	dinosaur, _, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursPost(ctx).Dinosaur(openapi.Dinosaur{Species: species}).Execute()
+	Expect(err).NotTo(HaveOccurred())
+
+	var request *http.Request
+	Eventually(received, 15*time.Second).Should(Receive(&request))
+	body := <-bodies
+	Expect(request.Header.Get(controllers.WebhookIDHeader)).To(Equal(webhookID))
+	Expect(controllers.VerifyWebhookSignature("s3cr3t", request.Header.Get(controllers.WebhookTimestampHeader), body,
+		request.Header.Get(controllers.WebhookSignatureHeader))).To(BeTrue())
+	var webhookEvent struct {
+		WebhookID  string           `json:"webhook_id"`
+		EventID    string           `json:"event_id"`
+		Type       string           `json:"type"`
+		Source     string           `json:"source"`
+		ResourceID string           `json:"resource_id"`
+		Object     openapi.Dinosaur `json:"object"`
+	}
+	Expect(json.Unmarshal(body, &webhookEvent)).To(Succeed())
+	Expect(webhookEvent.Type).To(Equal("Create"))
+	Expect(webhookEvent.Source).To(Equal("Dinosaurs"))
+	Expect(webhookEvent.ResourceID).To(Equal(*dinosaur.Id))
+	Expect(webhookEvent.Object.Species).To(Equal(species))
+	Expect(request.Header.Get(controllers.WebhookEventIDHeader)).To(Equal(webhookEvent.EventID))
+
+	// every attempt is recorded, once the response is in
+	listDeliveries := func() []interface{} {
+		var deliveries map[string]interface{}
+		restyResp, err := resty.R().
+			SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+			SetQueryParam("search", "status = 'Succeeded'").
+			SetResult(&deliveries).
+			Get(h.RestURL("/webhooks/" + webhookID + "/deliveries"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+		return deliveries["items"].([]interface{})
+	}
+	Eventually(listDeliveries, 5*time.Second).Should(HaveLen(1))
+	delivery := listDeliveries()[0].(map[string]interface{})
+	Expect(delivery["event_id"]).To(Equal(webhookEvent.EventID))
+	Expect(delivery["attempt"]).To(BeEquivalentTo(1))
+	Expect(delivery["status_code"]).To(BeEquivalentTo(http.StatusOK))
+
+	restyResp, err := resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		Delete(h.RestURL("/webhooks/" + webhookID))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusNoContent))
+}
@@ -23,3 +23,41 @@ func migration() *gormigrate.Migration {
 		},
 	}
 }
+
+func resourceVersionMigration() *gormigrate.Migration {
+	type Dinosaur struct {
+		ResourceVersion int64 `gorm:"not null;default:1"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101709000228",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Dinosaur{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&Dinosaur{}, "resource_version")
+		},
+	}
+}
+
+func tenancyMigration() *gormigrate.Migration {
+	type Dinosaur struct {
+		OrganizationID string `gorm:"index"`
+		Owner          string
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101712000228",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Dinosaur{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, column := range []string{"organization_id", "owner"} {
+				if err := tx.Migrator().DropColumn(&Dinosaur{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -2,6 +2,7 @@ package dinosaurs
 
 import (
 	"context"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -36,7 +37,7 @@ func (d *dinosaurDaoMock) Replace(ctx context.Context, dinosaur *Dinosaur) (*Din
 	return nil, errors.NotImplemented("Dinosaur").AsError()
 }
 
-func (d *dinosaurDaoMock) Delete(ctx context.Context, id string) error {
+func (d *dinosaurDaoMock) Delete(ctx context.Context, id string, resourceVersions []int64) error {
 	return errors.NotImplemented("Dinosaur").AsError()
 }
 
@@ -47,3 +48,15 @@ func (d *dinosaurDaoMock) FindByIDs(ctx context.Context, ids []string) (Dinosaur
 func (d *dinosaurDaoMock) All(ctx context.Context) (DinosaurList, error) {
 	return d.dinosaurs, nil
 }
+
+func (d *dinosaurDaoMock) Restore(ctx context.Context, id string) (*Dinosaur, error) {
+	return nil, errors.NotImplemented("Dinosaur").AsError()
+}
+
+func (d *dinosaurDaoMock) Purge(ctx context.Context, id string) error {
+	return errors.NotImplemented("Dinosaur").AsError()
+}
+
+func (d *dinosaurDaoMock) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	return 0, errors.NotImplemented("Dinosaur").AsError()
+}
@@ -2,6 +2,7 @@ package dinosaurs
 
 import (
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/validation"
 	"gorm.io/gorm"
 )
 
@@ -23,9 +24,14 @@ func (l DinosaurList) Index() DinosaurIndex {
 
 func (d *Dinosaur) BeforeCreate(tx *gorm.DB) error {
 	d.ID = api.NewID()
+	d.ResourceVersion = 1
 	return nil
 }
 
 type DinosaurPatchRequest struct {
 	Species *string `json:"species,omitempty"`
 }
+
+// dinosaurRules validate the fields of the dinosaurs sent to the REST and gRPC APIs, read from the constraints
+// of the Dinosaur schema in openapi/openapi.dinosaurs.yaml
+var dinosaurRules = validation.MustSchemaRules("Dinosaur")
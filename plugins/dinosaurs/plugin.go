@@ -11,14 +11,21 @@ import (
 	"github.com/openshift-online/rh-trex-ai/pkg/api/presenters"
 	"github.com/openshift-online/rh-trex-ai/pkg/auth"
 	"github.com/openshift-online/rh-trex-ai/pkg/controllers"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
 	"github.com/openshift-online/rh-trex-ai/pkg/environments"
 	"github.com/openshift-online/rh-trex-ai/pkg/registry"
 	pkgserver "github.com/openshift-online/rh-trex-ai/pkg/server"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+	"github.com/openshift-online/rh-trex-ai/plugins/auditevents"
 	"github.com/openshift-online/rh-trex-ai/plugins/events"
 	"github.com/openshift-online/rh-trex-ai/plugins/generic"
+	"github.com/openshift-online/rh-trex-ai/plugins/webhooks"
 )
 
+// authzResource is the resource the routes and RPCs of this Kind are authorized for
+const authzResource = "dinosaurs"
+
 type ServiceLocator func() DinosaurService
 
 func NewServiceLocator(env *environments.Env) ServiceLocator {
@@ -27,6 +34,8 @@ func NewServiceLocator(env *environments.Env) ServiceLocator {
 			db.NewAdvisoryLockFactory(env.Database.SessionFactory),
 			NewDinosaurDao(&env.Database.SessionFactory),
 			events.Service(&env.Services),
+			auditevents.Service(&env.Services),
+			services.NewRelationshipService(dao.NewRelationshipDao(&env.Database.SessionFactory)),
 		)
 	}
 }
@@ -49,16 +58,29 @@ func init() {
 
 	pkgserver.RegisterRoutes("dinosaurs", func(apiV1Router *mux.Router, services pkgserver.ServicesInterface, authMiddleware auth.JWTMiddleware, authzMiddleware auth.AuthorizationMiddleware) {
 		envServices := services.(*environments.Services)
-		dinosaurHandler := NewDinosaurHandler(Service(envServices), generic.Service(envServices))
+		dinosaurHandler := NewDinosaurHandler(Service(envServices), generic.Service(envServices), pkgserver.EventBrokerFunc(services))
 
 		dinosaursRouter := apiV1Router.PathPrefix("/dinosaurs").Subrouter()
-		dinosaursRouter.HandleFunc("", dinosaurHandler.List).Methods(http.MethodGet)
-		dinosaursRouter.HandleFunc("/{id}", dinosaurHandler.Get).Methods(http.MethodGet)
-		dinosaursRouter.HandleFunc("", dinosaurHandler.Create).Methods(http.MethodPost)
-		dinosaursRouter.HandleFunc("/{id}", dinosaurHandler.Patch).Methods(http.MethodPatch)
-		dinosaursRouter.HandleFunc("/{id}", dinosaurHandler.Delete).Methods(http.MethodDelete)
+		pkgserver.RegisterWatch(dinosaursRouter, authzMiddleware, authzResource, dinosaurHandler.Watch)
+		dinosaursRouter.HandleFunc("", authzMiddleware.Authorize(auth.ActionList, authzResource, dinosaurHandler.List)).Methods(http.MethodGet)
+		dinosaursRouter.HandleFunc("/{id}", authzMiddleware.Authorize(auth.ActionGet, authzResource, dinosaurHandler.Get)).Methods(http.MethodGet)
+		dinosaursRouter.HandleFunc("", authzMiddleware.Authorize(auth.ActionCreate, authzResource, dinosaurHandler.Create)).Methods(http.MethodPost)
+		dinosaursRouter.HandleFunc("/{id}", authzMiddleware.Authorize(auth.ActionUpdate, authzResource, dinosaurHandler.Patch)).Methods(http.MethodPatch)
+		dinosaursRouter.HandleFunc("/{id}", authzMiddleware.Authorize(auth.ActionDelete, authzResource, dinosaurHandler.Delete)).Methods(http.MethodDelete)
+		dinosaursRouter.HandleFunc("/{id}:restore", authzMiddleware.Authorize("restore", authzResource, dinosaurHandler.Restore)).Methods(http.MethodPost)
+		dinosaursRouter.HandleFunc("/{id}:purge", authzMiddleware.Authorize("purge", authzResource, dinosaurHandler.Purge)).Methods(http.MethodPost)
 		dinosaursRouter.Use(authMiddleware.AuthenticateAccountJWT)
-		dinosaursRouter.Use(authzMiddleware.AuthorizeApi)
+		dinosaursRouter.Use(pkgserver.RateLimitMiddleware(environments.Environment().RateLimiter, authzResource))
+		dinosaursRouter.Use(pkgserver.IdempotencyMiddleware(services))
+
+		// custom methods on the collection, e.g. /dinosaurs:batchCreate, don't fit under the /dinosaurs prefix
+		dinosaursBatchRouter := apiV1Router.NewRoute().Subrouter()
+		dinosaursBatchRouter.HandleFunc("/dinosaurs:batchCreate", authzMiddleware.Authorize(auth.ActionCreate, authzResource, dinosaurHandler.BatchCreate)).Methods(http.MethodPost)
+		dinosaursBatchRouter.HandleFunc("/dinosaurs:batchPatch", authzMiddleware.Authorize(auth.ActionUpdate, authzResource, dinosaurHandler.BatchPatch)).Methods(http.MethodPost)
+		dinosaursBatchRouter.HandleFunc("/dinosaurs:batchDelete", authzMiddleware.Authorize(auth.ActionDelete, authzResource, dinosaurHandler.BatchDelete)).Methods(http.MethodPost)
+		dinosaursBatchRouter.Use(authMiddleware.AuthenticateAccountJWT)
+		dinosaursBatchRouter.Use(pkgserver.RateLimitMiddleware(environments.Environment().RateLimiter, authzResource))
+		dinosaursBatchRouter.Use(pkgserver.IdempotencyMiddleware(services))
 	})
 
 	pkgserver.RegisterController("Dinosaurs", func(manager *controllers.KindControllerManager, services pkgserver.ServicesInterface) {
@@ -74,18 +96,37 @@ func init() {
 		})
 	})
 
+	pkgserver.RegisterPurger("Dinosaurs", func(purger *controllers.PurgeController, services pkgserver.ServicesInterface) {
+		dinosaurServices := Service(services.(*environments.Services))
+
+		purger.Add(&controllers.PurgeConfig{
+			Kind:      "Dinosaurs",
+			Retention: controllers.DefaultPurgeRetention,
+			Purge:     dinosaurServices.PurgeDeleted,
+		})
+	})
+
+	webhooks.RegisterKind(&webhooks.KindConfig{
+		Source:         "Dinosaurs",
+		ValidateFilter: validateDinosaurWatchFilter,
+		Match:          presentWatchedDinosaur,
+	})
+
 	pkgserver.RegisterGRPCService("dinosaurs", func(grpcServer *grpc.Server, services pkgserver.ServicesInterface) {
 		envServices := services.(*environments.Services)
 		dinosaurService := Service(envServices)
 		genericService := generic.Service(envServices)
-		brokerFunc := func() *pkgserver.EventBroker {
-			if obj := envServices.GetService("EventBroker"); obj != nil {
-				return obj.(*pkgserver.EventBroker)
-			}
-			return nil
-		}
-		pb.RegisterDinosaurServiceServer(grpcServer, NewDinosaurGRPCHandler(dinosaurService, genericService, brokerFunc))
+		pb.RegisterDinosaurServiceServer(grpcServer, NewDinosaurGRPCHandler(dinosaurService, genericService, pkgserver.EventBrokerFunc(services)))
 	})
+	pkgserver.RegisterGRPCMethodAuthorization(pb.DinosaurService_GetDinosaur_FullMethodName, auth.ActionGet, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.DinosaurService_ListDinosaurs_FullMethodName, auth.ActionList, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.DinosaurService_CreateDinosaur_FullMethodName, auth.ActionCreate, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.DinosaurService_UpdateDinosaur_FullMethodName, auth.ActionUpdate, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.DinosaurService_DeleteDinosaur_FullMethodName, auth.ActionDelete, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.DinosaurService_WatchDinosaurs_FullMethodName, auth.ActionWatch, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.DinosaurService_BatchCreateDinosaurs_FullMethodName, auth.ActionCreate, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.DinosaurService_BatchUpdateDinosaurs_FullMethodName, auth.ActionUpdate, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.DinosaurService_BatchDeleteDinosaurs_FullMethodName, auth.ActionDelete, authzResource)
 
 	presenters.RegisterPath(Dinosaur{}, "dinosaurs")
 	presenters.RegisterPath(&Dinosaur{}, "dinosaurs")
@@ -93,4 +134,6 @@ func init() {
 	presenters.RegisterKind(&Dinosaur{}, "Dinosaur")
 
 	db.RegisterMigration(migration())
+	db.RegisterMigration(resourceVersionMigration())
+	db.RegisterMigration(tenancyMigration())
 }
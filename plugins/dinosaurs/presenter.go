@@ -26,11 +26,13 @@ func ConvertDinosaur(dinosaur openapi.Dinosaur) *Dinosaur {
 func PresentDinosaur(dinosaur *Dinosaur) openapi.Dinosaur {
 	reference := presenters.PresentReference(dinosaur.ID, dinosaur)
 	return openapi.Dinosaur{
-		Id:        reference.Id,
-		Kind:      reference.Kind,
-		Href:      reference.Href,
-		CreatedAt: openapi.PtrTime(dinosaur.CreatedAt),
-		UpdatedAt: openapi.PtrTime(dinosaur.UpdatedAt),
-		Species:   dinosaur.Species,
+		Id:              reference.Id,
+		Kind:            reference.Kind,
+		Href:            reference.Href,
+		CreatedAt:       openapi.PtrTime(dinosaur.CreatedAt),
+		UpdatedAt:       openapi.PtrTime(dinosaur.UpdatedAt),
+		ResourceVersion: openapi.PtrInt64(dinosaur.ResourceVersion),
+		DeletedAt:       presenters.PresentDeletedAt(dinosaur.DeletedAt),
+		Species:         dinosaur.Species,
 	}
 }
@@ -2,6 +2,10 @@ package dinosaurs
 
 import (
 	"context"
+	e "errors"
+	"time"
+
+	"gorm.io/gorm"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
@@ -16,29 +20,44 @@ type DinosaurService interface {
 	Get(ctx context.Context, id string) (*Dinosaur, *errors.ServiceError)
 	Create(ctx context.Context, dinosaur *Dinosaur) (*Dinosaur, *errors.ServiceError)
 	Replace(ctx context.Context, dinosaur *Dinosaur) (*Dinosaur, *errors.ServiceError)
-	Delete(ctx context.Context, id string) *errors.ServiceError
+	// Delete deletes a dinosaur, only at one of resourceVersions unless they are nil, see handlers.IfMatchVersions
+	Delete(ctx context.Context, id string, resourceVersions []int64) *errors.ServiceError
 	All(ctx context.Context) (DinosaurList, *errors.ServiceError)
 
 	FindByIDs(ctx context.Context, ids []string) (DinosaurList, *errors.ServiceError)
 
+	Restore(ctx context.Context, id string) (*Dinosaur, *errors.ServiceError)
+	Purge(ctx context.Context, id string) *errors.ServiceError
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, *errors.ServiceError)
+
+	// BatchCreate creates dinosaurs in place and BatchUpdate loads, updates and replaces the dinosaurs of ids.
+	// Either the whole batch is written or, without allOrNothing, every item that succeeds, see services.RunBatch.
+	BatchCreate(ctx context.Context, dinosaurs DinosaurList, allOrNothing bool) (services.BatchErrors, *errors.ServiceError)
+	BatchUpdate(ctx context.Context, ids []string, update func(i int, dinosaur *Dinosaur) *errors.ServiceError, allOrNothing bool) (DinosaurList, services.BatchErrors, *errors.ServiceError)
+	BatchDelete(ctx context.Context, ids []string, allOrNothing bool) (services.BatchErrors, *errors.ServiceError)
+
 	OnUpsert(ctx context.Context, id string) error
 	OnDelete(ctx context.Context, id string) error
 }
 
-func NewDinosaurService(lockFactory db.LockFactory, dinosaurDao DinosaurDao, events services.EventService) DinosaurService {
+func NewDinosaurService(lockFactory db.LockFactory, dinosaurDao DinosaurDao, events services.EventService, auditEvents services.AuditEventService, relationships services.RelationshipService) DinosaurService {
 	return &sqlDinosaurService{
-		lockFactory: lockFactory,
-		dinosaurDao: dinosaurDao,
-		events:      events,
+		lockFactory:   lockFactory,
+		dinosaurDao:   dinosaurDao,
+		events:        events,
+		auditEvents:   auditEvents,
+		relationships: relationships,
 	}
 }
 
 var _ DinosaurService = &sqlDinosaurService{}
 
 type sqlDinosaurService struct {
-	lockFactory db.LockFactory
-	dinosaurDao DinosaurDao
-	events      services.EventService
+	lockFactory   db.LockFactory
+	dinosaurDao   DinosaurDao
+	events        services.EventService
+	auditEvents   services.AuditEventService
+	relationships services.RelationshipService
 }
 
 func (s *sqlDinosaurService) OnUpsert(ctx context.Context, id string) error {
@@ -83,6 +102,10 @@ func (s *sqlDinosaurService) Create(ctx context.Context, dinosaur *Dinosaur) (*D
 		return nil, services.HandleCreateError("Dinosaur", evErr)
 	}
 
+	if auditErr := s.recordAudit(ctx, api.AuditCreateAction, dinosaur.ID, nil, dinosaur); auditErr != nil {
+		return nil, auditErr
+	}
+
 	return dinosaur, nil
 }
 
@@ -93,6 +116,11 @@ func (s *sqlDinosaurService) Replace(ctx context.Context, dinosaur *Dinosaur) (*
 	}
 	defer s.lockFactory.Unlock(ctx, lockOwnerID)
 
+	before, err := s.dinosaurDao.Get(ctx, dinosaur.ID)
+	if err != nil {
+		return nil, services.HandleGetError("Dinosaur", "id", dinosaur.ID, err)
+	}
+
 	dinosaur, err = s.dinosaurDao.Replace(ctx, dinosaur)
 	if err != nil {
 		return nil, services.HandleUpdateError("Dinosaur", err)
@@ -107,23 +135,40 @@ func (s *sqlDinosaurService) Replace(ctx context.Context, dinosaur *Dinosaur) (*
 		return nil, services.HandleUpdateError("Dinosaur", evErr)
 	}
 
+	if auditErr := s.recordAudit(ctx, api.AuditUpdateAction, dinosaur.ID, before, dinosaur); auditErr != nil {
+		return nil, auditErr
+	}
+
 	return dinosaur, nil
 }
 
-func (s *sqlDinosaurService) Delete(ctx context.Context, id string) *errors.ServiceError {
-	if err := s.dinosaurDao.Delete(ctx, id); err != nil {
-		return services.HandleDeleteError("Dinosaur", errors.GeneralError("Unable to delete dinosaur: %s", err))
+func (s *sqlDinosaurService) Delete(ctx context.Context, id string, resourceVersions []int64) *errors.ServiceError {
+	before, err := s.dinosaurDao.Get(ctx, id)
+	if err != nil {
+		return services.HandleDeleteError("Dinosaur", err)
+	}
+	if err := s.relationships.CheckDelete(ctx, "Dinosaurs", id); err != nil {
+		return err
+	}
+	if err := s.dinosaurDao.Delete(ctx, id, resourceVersions); err != nil {
+		return services.HandleDeleteError("Dinosaur", err)
 	}
 
 	_, evErr := s.events.Create(ctx, &api.Event{
 		Source:    "Dinosaurs",
 		SourceID:  id,
 		EventType: api.DeleteEventType,
+		// the organization of the dinosaur rather than of the caller, who may work across organizations
+		Meta: api.Meta{OrganizationID: before.OrganizationID},
 	})
 	if evErr != nil {
 		return services.HandleDeleteError("Dinosaur", evErr)
 	}
 
+	if auditErr := s.recordAudit(ctx, api.AuditDeleteAction, id, before, nil); auditErr != nil {
+		return auditErr
+	}
+
 	return nil
 }
 
@@ -142,3 +187,155 @@ func (s *sqlDinosaurService) All(ctx context.Context) (DinosaurList, *errors.Ser
 	}
 	return dinosaurs, nil
 }
+
+// Restore undeletes a soft-deleted dinosaur. Controllers and watchers see it as created again.
+func (s *sqlDinosaurService) Restore(ctx context.Context, id string) (*Dinosaur, *errors.ServiceError) {
+	lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, id, dinosaursLockType)
+	if err != nil {
+		return nil, errors.DatabaseAdvisoryLock(err)
+	}
+	defer s.lockFactory.Unlock(ctx, lockOwnerID)
+
+	dinosaur, err := s.dinosaurDao.Restore(ctx, id)
+	if err != nil {
+		return nil, s.handleNotDeleted(ctx, id, err)
+	}
+
+	_, evErr := s.events.Create(ctx, &api.Event{
+		Source:    "Dinosaurs",
+		SourceID:  dinosaur.ID,
+		EventType: api.CreateEventType,
+	})
+	if evErr != nil {
+		return nil, services.HandleUpdateError("Dinosaur", evErr)
+	}
+
+	if auditErr := s.recordAudit(ctx, api.AuditRestoreAction, dinosaur.ID, nil, dinosaur); auditErr != nil {
+		return nil, auditErr
+	}
+
+	return dinosaur, nil
+}
+
+// Purge hard-deletes a soft-deleted dinosaur, it can't be restored afterwards
+func (s *sqlDinosaurService) Purge(ctx context.Context, id string) *errors.ServiceError {
+	if err := s.dinosaurDao.Purge(ctx, id); err != nil {
+		return s.handleNotDeleted(ctx, id, err)
+	}
+	// the state of the dinosaur was recorded when it was deleted
+	return s.recordAudit(ctx, api.AuditPurgeAction, id, nil, nil)
+}
+
+func (s *sqlDinosaurService) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, *errors.ServiceError) {
+	purged, err := services.PurgeInBatches(ctx, func(limit int) (int64, error) {
+		return s.dinosaurDao.PurgeDeleted(ctx, deletedBefore, limit)
+	})
+	if err != nil {
+		return purged, errors.GeneralError("Unable to purge deleted dinosaurs: %s", err)
+	}
+	return purged, nil
+}
+
+func (s *sqlDinosaurService) BatchCreate(ctx context.Context, dinosaurs DinosaurList, allOrNothing bool) (services.BatchErrors, *errors.ServiceError) {
+	itemErrs, svcErr := services.RunBatch(ctx, len(dinosaurs), allOrNothing, func(i int) *errors.ServiceError {
+		if _, err := s.dinosaurDao.Create(ctx, dinosaurs[i]); err != nil {
+			return services.HandleCreateError("Dinosaur", err)
+		}
+		return s.recordAudit(ctx, api.AuditCreateAction, dinosaurs[i].ID, nil, dinosaurs[i])
+	})
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	ids := make([]string, len(dinosaurs))
+	for i, dinosaur := range dinosaurs {
+		ids[i] = dinosaur.ID
+	}
+	if _, evErr := s.events.CreateBatch(ctx, services.BatchEvents("Dinosaurs", api.CreateEventType, ids, itemErrs)); evErr != nil {
+		return nil, services.HandleCreateError("Dinosaur", evErr)
+	}
+	return itemErrs, nil
+}
+
+func (s *sqlDinosaurService) BatchUpdate(ctx context.Context, ids []string, update func(i int, dinosaur *Dinosaur) *errors.ServiceError, allOrNothing bool) (DinosaurList, services.BatchErrors, *errors.ServiceError) {
+	dinosaurs := make(DinosaurList, len(ids))
+	itemErrs, svcErr := services.RunBatch(ctx, len(ids), allOrNothing, func(i int) *errors.ServiceError {
+		lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, ids[i], dinosaursLockType)
+		if err != nil {
+			return errors.DatabaseAdvisoryLock(err)
+		}
+		defer s.lockFactory.Unlock(ctx, lockOwnerID)
+
+		dinosaur, svcErr := s.Get(ctx, ids[i])
+		if svcErr != nil {
+			return svcErr
+		}
+		before := *dinosaur
+		if svcErr := update(i, dinosaur); svcErr != nil {
+			return svcErr
+		}
+		if _, err := s.dinosaurDao.Replace(ctx, dinosaur); err != nil {
+			return services.HandleUpdateError("Dinosaur", err)
+		}
+		dinosaurs[i] = dinosaur
+		return s.recordAudit(ctx, api.AuditUpdateAction, dinosaur.ID, &before, dinosaur)
+	})
+	if svcErr != nil {
+		return nil, nil, svcErr
+	}
+
+	if _, evErr := s.events.CreateBatch(ctx, services.BatchEvents("Dinosaurs", api.UpdateEventType, ids, itemErrs)); evErr != nil {
+		return nil, nil, services.HandleUpdateError("Dinosaur", evErr)
+	}
+	return dinosaurs, itemErrs, nil
+}
+
+func (s *sqlDinosaurService) BatchDelete(ctx context.Context, ids []string, allOrNothing bool) (services.BatchErrors, *errors.ServiceError) {
+	itemErrs, svcErr := services.RunBatch(ctx, len(ids), allOrNothing, func(i int) *errors.ServiceError {
+		before, err := s.dinosaurDao.Get(ctx, ids[i])
+		if err != nil {
+			return services.HandleDeleteError("Dinosaur", err)
+		}
+		if err := s.relationships.CheckDelete(ctx, "Dinosaurs", ids[i]); err != nil {
+			return err
+		}
+		if err := s.dinosaurDao.Delete(ctx, ids[i], nil); err != nil {
+			return services.HandleDeleteError("Dinosaur", err)
+		}
+		return s.recordAudit(ctx, api.AuditDeleteAction, ids[i], before, nil)
+	})
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if _, evErr := s.events.CreateBatch(ctx, services.BatchEvents("Dinosaurs", api.DeleteEventType, ids, itemErrs)); evErr != nil {
+		return nil, services.HandleDeleteError("Dinosaur", evErr)
+	}
+	return itemErrs, nil
+}
+
+// recordAudit appends a change of the dinosaur id to the audit trail, before is nil for creates and after for deletes
+func (s *sqlDinosaurService) recordAudit(ctx context.Context, action api.AuditAction, id string, before, after *Dinosaur) *errors.ServiceError {
+	auditEvent := &api.AuditEvent{Source: "Dinosaurs", SourceID: id, Action: action}
+	var beforeState, afterState interface{}
+	if before != nil {
+		beforeState = before
+		auditEvent.OrganizationID = before.OrganizationID
+	}
+	if after != nil {
+		afterState = after
+		auditEvent.OrganizationID = after.OrganizationID
+	}
+	return s.auditEvents.Record(ctx, auditEvent, beforeState, afterState)
+}
+
+// handleNotDeleted tells a dinosaur that isn't deleted apart from one that doesn't exist at all
+func (s *sqlDinosaurService) handleNotDeleted(ctx context.Context, id string, err error) *errors.ServiceError {
+	if !e.Is(err, gorm.ErrRecordNotFound) {
+		return services.HandleUpdateError("Dinosaur", err)
+	}
+	if _, getErr := s.dinosaurDao.Get(ctx, id); getErr == nil {
+		return errors.Conflict("Dinosaur with id='%s' is not deleted", id)
+	}
+	return services.HandleGetError("Dinosaur", "id", id, err)
+}
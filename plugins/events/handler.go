@@ -0,0 +1,195 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/handlers"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
+
+// deadLetteredSearch restricts event listings to events the controllers gave up on
+const deadLetteredSearch = "dead_lettered_at is not null"
+
+type eventHandler struct {
+	event   services.EventService
+	generic services.GenericService
+}
+
+func NewEventHandler(event services.EventService, generic services.GenericService) *eventHandler {
+	return &eventHandler{
+		event:   event,
+		generic: generic,
+	}
+}
+
+func (h eventHandler) Get(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			event, err := h.event.Get(r.Context(), id)
+			if err != nil {
+				return nil, err
+			}
+			return PresentEvent(event), nil
+		},
+	}
+
+	handlers.HandleGet(w, r, cfg)
+}
+
+// List lists events, optionally narrowed by a search on any event column, e.g.
+// "source = 'Dinosaurs' and reconciled_date is null"
+func (h eventHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			return h.list(r.Context(), services.NewListArguments(r.URL.Query()))
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+// ListDeadLettered lists dead-lettered events, optionally narrowed by a search on any event column
+func (h eventHandler) ListDeadLettered(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := services.NewListArguments(r.URL.Query())
+			if listArgs.Search == "" {
+				listArgs.Search = deadLetteredSearch
+			} else {
+				listArgs.Search = fmt.Sprintf("%s and (%s)", deadLetteredSearch, listArgs.Search)
+			}
+			return h.list(r.Context(), listArgs)
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+func (h eventHandler) list(ctx context.Context, listArgs *services.ListArguments) (interface{}, *errors.ServiceError) {
+	var events []api.Event
+	paging, err := h.generic.List(ctx, listArgs, &events)
+	if err != nil {
+		return nil, err
+	}
+	eventList := EventList{
+		Kind:     "EventList",
+		Page:     int32(paging.Page),
+		Size:     int32(paging.Size),
+		Total:    int32(paging.Total),
+		Continue: paging.Continue,
+		Items:    []Event{},
+	}
+	for i := range events {
+		eventList.Items = append(eventList.Items, PresentEvent(&events[i]))
+	}
+	return eventList, nil
+}
+
+// Redrive resets a dead-lettered event so the controllers process it again
+func (h eventHandler) Redrive(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			event, err := h.event.Redrive(r.Context(), id)
+			if err != nil {
+				return nil, err
+			}
+			return PresentEvent(event), nil
+		},
+		ErrorHandler: handlers.HandleError,
+	}
+
+	handlers.HandleWithoutBody(w, r, cfg, http.StatusOK)
+}
+
+// Reprocess hands an event back to the controllers, whether it is reconciled, failing or dead-lettered, and
+// records the caller as reprocessed_by. Unlike Redrive, which only takes dead-lettered events and fails with a
+// conflict otherwise, it runs the controllers again for changes they already reconciled.
+func (h eventHandler) Reprocess(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			event, err := h.event.Reprocess(r.Context(), id)
+			if err != nil {
+				return nil, err
+			}
+			return PresentEvent(event), nil
+		},
+		ErrorHandler: handlers.HandleError,
+	}
+
+	handlers.HandleWithoutBody(w, r, cfg, http.StatusOK)
+}
+
+// ListKinds lists the backlog of the controllers of every kind with events or paused controllers
+func (h eventHandler) ListKinds(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			stats, err := h.event.Stats(r.Context())
+			if err != nil {
+				return nil, err
+			}
+			kindList := EventKindList{
+				Kind:  "EventKindList",
+				Total: int32(len(stats)),
+				Items: []EventKind{},
+			}
+			for _, kindStats := range stats {
+				kindList.Items = append(kindList.Items, PresentEventKind(kindStats))
+			}
+			return kindList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+func (h eventHandler) GetKind(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			return h.presentKind(h.event.KindStats(r.Context(), mux.Vars(r)["kind"]))
+		},
+	}
+
+	handlers.HandleGet(w, r, cfg)
+}
+
+// ReprocessKind hands every unreconciled event of a kind back to the controllers, recording the caller as
+// reprocessed_by of each
+func (h eventHandler) ReprocessKind(w http.ResponseWriter, r *http.Request) {
+	h.kindAction(w, r, h.event.ReprocessKind)
+}
+
+// PauseKind stops the controllers from processing the events of a kind, ResumeKind starts them again
+func (h eventHandler) PauseKind(w http.ResponseWriter, r *http.Request) {
+	h.kindAction(w, r, h.event.Pause)
+}
+
+func (h eventHandler) ResumeKind(w http.ResponseWriter, r *http.Request) {
+	h.kindAction(w, r, h.event.Resume)
+}
+
+func (h eventHandler) kindAction(w http.ResponseWriter, r *http.Request, action func(ctx context.Context, source string) (*api.EventStats, *errors.ServiceError)) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			return h.presentKind(action(r.Context(), mux.Vars(r)["kind"]))
+		},
+		ErrorHandler: handlers.HandleError,
+	}
+
+	handlers.HandleWithoutBody(w, r, cfg, http.StatusOK)
+}
+
+func (h eventHandler) presentKind(stats *api.EventStats, err *errors.ServiceError) (interface{}, *errors.ServiceError) {
+	if err != nil {
+		return nil, err
+	}
+	return PresentEventKind(stats), nil
+}
@@ -28,3 +28,223 @@ func migration() *gormigrate.Migration {
 		},
 	}
 }
+
+func resourceVersionMigration() *gormigrate.Migration {
+	type Event struct {
+		ResourceVersion int64 `gorm:"not null;default:1"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101709000925",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Event{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&Event{}, "resource_version")
+		},
+	}
+}
+
+func retryMigration() *gormigrate.Migration {
+	type Event struct {
+		Attempts       int `gorm:"not null;default:0"`
+		LastError      string
+		NextAttemptAt  *time.Time `gorm:"null;index"`
+		DeadLetteredAt *time.Time `gorm:"null;index"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101710300925",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Event{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, column := range []string{"attempts", "last_error", "next_attempt_at", "dead_lettered_at"} {
+				if err := tx.Migrator().DropColumn(&Event{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func tenancyMigration() *gormigrate.Migration {
+	type Event struct {
+		OrganizationID string `gorm:"index"`
+		Owner          string
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101712000925",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Event{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, column := range []string{"organization_id", "owner"} {
+				if err := tx.Migrator().DropColumn(&Event{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func changedFieldsMigration() *gormigrate.Migration {
+	type Event struct {
+		ChangedFields string
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101719000925",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Event{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&Event{}, "changed_fields")
+		},
+	}
+}
+
+func traceContextMigration() *gormigrate.Migration {
+	type Event struct {
+		TraceParent string
+		TraceState  string
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101721000925",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Event{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, column := range []string{"trace_parent", "trace_state"} {
+				if err := tx.Migrator().DropColumn(&Event{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func pauseMigration() *gormigrate.Migration {
+	type EventPause struct {
+		Source    string `gorm:"primaryKey"`
+		PausedBy  string
+		CreatedAt time.Time
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101723000925",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&EventPause{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&EventPause{})
+		},
+	}
+}
+
+func archiveMigration() *gormigrate.Migration {
+	type EventArchive struct {
+		ID             string `gorm:"primaryKey"`
+		CreatedAt      time.Time
+		Source         string `gorm:"index"`
+		SourceID       string `gorm:"index"`
+		EventType      string
+		ChangedFields  string
+		OrganizationID string `gorm:"index"`
+		Owner          string
+		Attempts       int
+		ReconciledDate *time.Time
+		ArchivedAt     time.Time `gorm:"index"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101723300925",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&EventArchive{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&EventArchive{})
+		},
+	}
+}
+
+func sequenceMigration() *gormigrate.Migration {
+	type Event struct {
+		Source string `gorm:"index;index:idx_events_source_seq,priority:1"`
+		Seq    int64  `gorm:"autoIncrement;index:idx_events_source_seq,priority:2"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101723400925",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Event{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropIndex(&Event{}, "idx_events_source_seq"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(&Event{}, "seq")
+		},
+	}
+}
+
+func reprocessMigration() *gormigrate.Migration {
+	type Event struct {
+		ReprocessedBy string
+		ReprocessedAt *time.Time
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101723500925",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Event{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, column := range []string{"reprocessed_by", "reprocessed_at"} {
+				if err := tx.Migrator().DropColumn(&Event{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// archiveColumnsMigration adds the columns of the events missing from the event_archives table, so that an
+// archived event keeps all of them
+func archiveColumnsMigration() *gormigrate.Migration {
+	type EventArchive struct {
+		UpdatedAt       time.Time
+		DeletedAt       *time.Time
+		ResourceVersion int64
+		Seq             int64 `gorm:"index"`
+		TraceParent     string
+		TraceState      string
+		LastError       string
+		NextAttemptAt   *time.Time
+		DeadLetteredAt  *time.Time
+		ReprocessedBy   string
+		ReprocessedAt   *time.Time
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101723600925",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&EventArchive{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, column := range []string{"updated_at", "deleted_at", "resource_version", "seq", "trace_parent",
+				"trace_state", "last_error", "next_attempt_at", "dead_lettered_at", "reprocessed_by", "reprocessed_at"} {
+				if err := tx.Migrator().DropColumn(&EventArchive{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
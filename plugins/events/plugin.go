@@ -1,16 +1,25 @@
 package events
 
 import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/api/presenters"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
 	"github.com/openshift-online/rh-trex-ai/pkg/dao"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
 	"github.com/openshift-online/rh-trex-ai/pkg/environments"
 	"github.com/openshift-online/rh-trex-ai/pkg/registry"
+	pkgserver "github.com/openshift-online/rh-trex-ai/pkg/server"
 	"github.com/openshift-online/rh-trex-ai/pkg/services"
+	"github.com/openshift-online/rh-trex-ai/plugins/generic"
 )
 
 func NewServiceLocator(env *environments.Env) services.EventServiceLocator {
 	return func() services.EventService {
-		return services.NewEventService(dao.NewEventDao(&env.Database.SessionFactory))
+		return services.NewEventService(db.NewAdvisoryLockFactory(env.Database.SessionFactory), dao.NewEventDao(&env.Database.SessionFactory, env.Database.EventBus), env.Database.SessionFactory)
 	}
 }
 
@@ -31,5 +40,39 @@ func init() {
 		return NewServiceLocator(env.(*environments.Env))
 	})
 
+	pkgserver.RegisterRoutes("events", func(apiV1Router *mux.Router, services pkgserver.ServicesInterface, authMiddleware auth.JWTMiddleware, authzMiddleware auth.AuthorizationMiddleware) {
+		envServices := services.(*environments.Services)
+		eventHandler := NewEventHandler(Service(envServices), generic.Service(envServices))
+
+		eventsRouter := apiV1Router.PathPrefix("/events").Subrouter()
+		eventsRouter.HandleFunc("", authzMiddleware.Authorize(auth.ActionList, "events", eventHandler.List)).Methods(http.MethodGet)
+		eventsRouter.HandleFunc("/dead_letters", authzMiddleware.Authorize(auth.ActionList, "events", eventHandler.ListDeadLettered)).Methods(http.MethodGet)
+		eventsRouter.HandleFunc("/kinds", authzMiddleware.Authorize(auth.ActionList, "events", eventHandler.ListKinds)).Methods(http.MethodGet)
+		eventsRouter.HandleFunc("/kinds/{kind}", authzMiddleware.Authorize(auth.ActionGet, "events", eventHandler.GetKind)).Methods(http.MethodGet)
+		eventsRouter.HandleFunc("/kinds/{kind}/reprocess", authzMiddleware.Authorize("reprocess", "events", eventHandler.ReprocessKind)).Methods(http.MethodPost)
+		eventsRouter.HandleFunc("/kinds/{kind}/pause", authzMiddleware.Authorize("pause", "events", eventHandler.PauseKind)).Methods(http.MethodPost)
+		eventsRouter.HandleFunc("/kinds/{kind}/resume", authzMiddleware.Authorize("resume", "events", eventHandler.ResumeKind)).Methods(http.MethodPost)
+		eventsRouter.HandleFunc("/{id}", authzMiddleware.Authorize(auth.ActionGet, "events", eventHandler.Get)).Methods(http.MethodGet)
+		eventsRouter.HandleFunc("/{id}/redrive", authzMiddleware.Authorize("redrive", "events", eventHandler.Redrive)).Methods(http.MethodPost)
+		eventsRouter.HandleFunc("/{id}/reprocess", authzMiddleware.Authorize("reprocess", "events", eventHandler.Reprocess)).Methods(http.MethodPost)
+		eventsRouter.Use(authMiddleware.AuthenticateAccountJWT)
+		eventsRouter.Use(pkgserver.RateLimitMiddleware(environments.Environment().RateLimiter, "events"))
+	})
+
+	presenters.RegisterPath(api.Event{}, "events")
+	presenters.RegisterPath(&api.Event{}, "events")
+	presenters.RegisterKind(api.Event{}, "Event")
+	presenters.RegisterKind(&api.Event{}, "Event")
+
 	db.RegisterMigration(migration())
+	db.RegisterMigration(resourceVersionMigration())
+	db.RegisterMigration(retryMigration())
+	db.RegisterMigration(tenancyMigration())
+	db.RegisterMigration(changedFieldsMigration())
+	db.RegisterMigration(traceContextMigration())
+	db.RegisterMigration(pauseMigration())
+	db.RegisterMigration(archiveMigration())
+	db.RegisterMigration(sequenceMigration())
+	db.RegisterMigration(reprocessMigration())
+	db.RegisterMigration(archiveColumnsMigration())
 }
@@ -0,0 +1,104 @@
+package events
+
+import (
+	"time"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/api/presenters"
+	"github.com/openshift-online/rh-trex-ai/pkg/util"
+)
+
+// Event is the REST representation of an api.Event. Events are an operational resource and are
+// not part of the generated openapi client.
+type Event struct {
+	ID             string     `json:"id"`
+	Kind           string     `json:"kind"`
+	Href           string     `json:"href"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	Source         string     `json:"source"`
+	SourceID       string     `json:"source_id"`
+	EventType      string     `json:"event_type"`
+	ReconciledDate *time.Time `json:"reconciled_date,omitempty"`
+	ChangedFields  []string   `json:"changed_fields,omitempty"`
+	Attempts       int        `json:"attempts"`
+	LastError      string     `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	DeadLetteredAt *time.Time `json:"dead_lettered_at,omitempty"`
+	ReprocessedBy  string     `json:"reprocessed_by,omitempty"`
+	ReprocessedAt  *time.Time `json:"reprocessed_at,omitempty"`
+}
+
+type EventList struct {
+	Kind     string  `json:"kind"`
+	Page     int32   `json:"page"`
+	Size     int32   `json:"size"`
+	Total    int32   `json:"total"`
+	Continue string  `json:"continue,omitempty"`
+	Items    []Event `json:"items"`
+}
+
+func PresentEvent(event *api.Event) Event {
+	reference := presenters.PresentReference(event.ID, event)
+	return Event{
+		ID:             util.NilToEmptyString(reference.Id),
+		Kind:           util.NilToEmptyString(reference.Kind),
+		Href:           util.NilToEmptyString(reference.Href),
+		CreatedAt:      event.CreatedAt,
+		UpdatedAt:      event.UpdatedAt,
+		Source:         event.Source,
+		SourceID:       event.SourceID,
+		EventType:      string(event.EventType),
+		ReconciledDate: event.ReconciledDate,
+		ChangedFields:  event.FieldMask(),
+		Attempts:       event.Attempts,
+		LastError:      event.LastError,
+		NextAttemptAt:  event.NextAttemptAt,
+		DeadLetteredAt: event.DeadLetteredAt,
+		ReprocessedBy:  event.ReprocessedBy,
+		ReprocessedAt:  event.ReprocessedAt,
+	}
+}
+
+// EventKind is the REST representation of the backlog of the kind controllers for a source
+type EventKind struct {
+	Kind            string     `json:"kind"`
+	Source          string     `json:"source"`
+	Total           int64      `json:"total"`
+	Pending         int64      `json:"pending"`
+	Retrying        int64      `json:"retrying"`
+	DeadLettered    int64      `json:"dead_lettered"`
+	OldestPendingAt *time.Time `json:"oldest_pending_at,omitempty"`
+	// OldestPendingAge is the age in seconds of the oldest pending event
+	OldestPendingAge float64    `json:"oldest_pending_age_seconds"`
+	Paused           bool       `json:"paused"`
+	PausedBy         string     `json:"paused_by,omitempty"`
+	PausedAt         *time.Time `json:"paused_at,omitempty"`
+}
+
+type EventKindList struct {
+	Kind  string      `json:"kind"`
+	Total int32       `json:"total"`
+	Items []EventKind `json:"items"`
+}
+
+func PresentEventKind(stats *api.EventStats) EventKind {
+	eventKind := EventKind{
+		Kind:            "EventKind",
+		Source:          stats.Source,
+		Total:           stats.Total,
+		Pending:         stats.Pending,
+		Retrying:        stats.Retrying,
+		DeadLettered:    stats.DeadLettered,
+		OldestPendingAt: stats.OldestPendingAt,
+	}
+	if stats.OldestPendingAt != nil {
+		eventKind.OldestPendingAge = time.Since(*stats.OldestPendingAt).Seconds()
+	}
+	if stats.Pause != nil {
+		eventKind.Paused = true
+		eventKind.PausedBy = stats.Pause.PausedBy
+		eventKind.PausedAt = &stats.Pause.CreatedAt
+	}
+	return eventKind
+}
@@ -2,20 +2,36 @@ package fossils
 
 import (
 	"context"
+	"time"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
 )
 
+// FossilDao reads and writes the fossils of the organization of the request in ctx, see dao.TenantScope
 type FossilDao interface {
 	Get(ctx context.Context, id string) (*Fossil, error)
 	Create(ctx context.Context, fossil *Fossil) (*Fossil, error)
 	Replace(ctx context.Context, fossil *Fossil) (*Fossil, error)
-	Delete(ctx context.Context, id string) error
+	// Delete soft-deletes a fossil, only at one of resourceVersions unless they are nil
+	Delete(ctx context.Context, id string, resourceVersions []int64) error
 	FindByIDs(ctx context.Context, ids []string) (FossilList, error)
 	All(ctx context.Context) (FossilList, error)
+	// FindByDinosaurID finds the fossils of a dinosaur
+	FindByDinosaurID(ctx context.Context, dinosaurID string) (FossilList, error)
+	// FindByScientistID finds the fossils of a scientist
+	FindByScientistID(ctx context.Context, scientistID string) (FossilList, error)
+
+	// Restore undeletes a soft-deleted fossil
+	Restore(ctx context.Context, id string) (*Fossil, error)
+	// Purge hard-deletes a soft-deleted fossil
+	Purge(ctx context.Context, id string) error
+	// PurgeDeleted hard-deletes at most limit fossils soft-deleted before the cutoff, the oldest first
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error)
 }
 
 var _ FossilDao = &sqlFossilDao{}
@@ -29,7 +45,7 @@ func NewFossilDao(sessionFactory *db.SessionFactory) FossilDao {
 }
 
 func (d *sqlFossilDao) Get(ctx context.Context, id string) (*Fossil, error) {
-	g2 := (*d.sessionFactory).New(ctx)
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
 	var fossil Fossil
 	if err := g2.Take(&fossil, "id = ?", id).Error; err != nil {
 		return nil, err
@@ -39,6 +55,7 @@ func (d *sqlFossilDao) Get(ctx context.Context, id string) (*Fossil, error) {
 
 func (d *sqlFossilDao) Create(ctx context.Context, fossil *Fossil) (*Fossil, error) {
 	g2 := (*d.sessionFactory).New(ctx)
+	dao.SetTenancy(ctx, &fossil.Meta)
 	if err := g2.Omit(clause.Associations).Create(fossil).Error; err != nil {
 		db.MarkForRollback(ctx, err)
 		return nil, err
@@ -47,17 +64,17 @@ func (d *sqlFossilDao) Create(ctx context.Context, fossil *Fossil) (*Fossil, err
 }
 
 func (d *sqlFossilDao) Replace(ctx context.Context, fossil *Fossil) (*Fossil, error) {
-	g2 := (*d.sessionFactory).New(ctx)
-	if err := g2.Omit(clause.Associations).Save(fossil).Error; err != nil {
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
+	if err := db.SaveVersioned(g2.Omit(clause.Associations), fossil, &fossil.ResourceVersion); err != nil {
 		db.MarkForRollback(ctx, err)
 		return nil, err
 	}
 	return fossil, nil
 }
 
-func (d *sqlFossilDao) Delete(ctx context.Context, id string) error {
-	g2 := (*d.sessionFactory).New(ctx)
-	if err := g2.Omit(clause.Associations).Delete(&Fossil{Meta: api.Meta{ID: id}}).Error; err != nil {
+func (d *sqlFossilDao) Delete(ctx context.Context, id string, resourceVersions []int64) error {
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
+	if err := db.DeleteVersioned(g2.Omit(clause.Associations), &Fossil{Meta: api.Meta{ID: id}}, resourceVersions); err != nil {
 		db.MarkForRollback(ctx, err)
 		return err
 	}
@@ -65,7 +82,7 @@ func (d *sqlFossilDao) Delete(ctx context.Context, id string) error {
 }
 
 func (d *sqlFossilDao) FindByIDs(ctx context.Context, ids []string) (FossilList, error) {
-	g2 := (*d.sessionFactory).New(ctx)
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
 	fossils := FossilList{}
 	if err := g2.Where("id in (?)", ids).Find(&fossils).Error; err != nil {
 		return nil, err
@@ -74,10 +91,66 @@ func (d *sqlFossilDao) FindByIDs(ctx context.Context, ids []string) (FossilList,
 }
 
 func (d *sqlFossilDao) All(ctx context.Context) (FossilList, error) {
-	g2 := (*d.sessionFactory).New(ctx)
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
 	fossils := FossilList{}
 	if err := g2.Find(&fossils).Error; err != nil {
 		return nil, err
 	}
 	return fossils, nil
 }
+
+func (d *sqlFossilDao) FindByDinosaurID(ctx context.Context, dinosaurID string) (FossilList, error) {
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
+	fossils := FossilList{}
+	if err := g2.Where("dinosaur_id = ?", dinosaurID).Find(&fossils).Error; err != nil {
+		return nil, err
+	}
+	return fossils, nil
+}
+
+func (d *sqlFossilDao) FindByScientistID(ctx context.Context, scientistID string) (FossilList, error) {
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
+	fossils := FossilList{}
+	if err := g2.Where("scientist_id = ?", scientistID).Find(&fossils).Error; err != nil {
+		return nil, err
+	}
+	return fossils, nil
+}
+
+func (d *sqlFossilDao) Restore(ctx context.Context, id string) (*Fossil, error) {
+	g2 := (*d.sessionFactory).New(ctx).Unscoped().Scopes(dao.TenantScope(ctx))
+	var fossil Fossil
+	if err := g2.Take(&fossil, "id = ? AND deleted_at IS NOT NULL", id).Error; err != nil {
+		return nil, err
+	}
+	fossil.DeletedAt = gorm.DeletedAt{}
+	if err := db.SaveVersioned(g2.Omit(clause.Associations), &fossil, &fossil.ResourceVersion); err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return &fossil, nil
+}
+
+func (d *sqlFossilDao) Purge(ctx context.Context, id string) error {
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
+	result := g2.Unscoped().Omit(clause.Associations).Where("deleted_at IS NOT NULL").Delete(&Fossil{Meta: api.Meta{ID: id}})
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (d *sqlFossilDao) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
+	batch := g2.Unscoped().Model(&Fossil{}).Select("id").Where("deleted_at < ?", deletedBefore).Order("deleted_at").Limit(limit)
+	result := (*d.sessionFactory).New(ctx).Unscoped().Omit(clause.Associations).Where("id IN (?)", batch).Delete(&Fossil{})
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -3,16 +3,17 @@ package fossils
 import (
 	"context"
 
-	"github.com/golang/glog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
-	"github.com/openshift-online/rh-trex-ai/pkg/api"
 	pb "github.com/openshift-online/rh-trex-ai/pkg/api/grpc/rh_trex/v1"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 	pkgserver "github.com/openshift-online/rh-trex-ai/pkg/server"
 	"github.com/openshift-online/rh-trex-ai/pkg/server/grpcutil"
 	"github.com/openshift-online/rh-trex-ai/pkg/services"
+	"github.com/openshift-online/rh-trex-ai/pkg/validation"
 )
 
 type fossilGRPCHandler struct {
@@ -30,20 +31,68 @@ func (h *fossilGRPCHandler) GetFossil(ctx context.Context, req *pb.GetFossilRequ
 	if err := grpcutil.ValidateRequiredID(req.Id); err != nil {
 		return nil, err
 	}
+	if err := grpcutil.ValidateReadMask(req.ReadMask, &pb.Fossil{}); err != nil {
+		return nil, err
+	}
 
 	fossil, svcErr := h.service.Get(ctx, req.Id)
 	if svcErr != nil {
 		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
 	}
-	return fossilToProto(fossil), nil
+	result := FossilToProto(fossil)
+	grpcutil.ApplyReadMask(result, req.ReadMask)
+	return result, nil
 }
 
 func (h *fossilGRPCHandler) CreateFossil(ctx context.Context, req *pb.CreateFossilRequest) (*pb.Fossil, error) {
-	if err := grpcutil.ValidateStringField("discovery_location", req.DiscoveryLocation, true); err != nil {
+	if err := validateCreateFossilRequest(req); err != nil {
+		return nil, err
+	}
+
+	result, svcErr := h.service.Create(ctx, fossilFromCreateRequest(req))
+	if svcErr != nil {
+		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
+	}
+	return FossilToProto(result), nil
+}
+
+func (h *fossilGRPCHandler) UpdateFossil(ctx context.Context, req *pb.UpdateFossilRequest) (*pb.Fossil, error) {
+	fields, err := validateUpdateFossilRequest(req)
+	if err != nil {
 		return nil, err
 	}
 
-	fossil := &Fossil{
+	fossil, svcErr := h.service.Get(ctx, req.Id)
+	if svcErr != nil {
+		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
+	}
+	if err := grpcutil.ValidateResourceVersion(req.ResourceVersion, fossil.ResourceVersion); err != nil {
+		return nil, err
+	}
+	before := FossilToProto(fossil)
+	if err := grpcutil.ValidateUpdate(fossilRules, req, fields, before); err != nil {
+		return nil, err
+	}
+	applyUpdateFossilRequest(fossil, req, fields)
+	changed := grpcutil.ChangedFields(before, FossilToProto(fossil))
+	// an update that changes nothing doesn't write, bump the resource version or record an event
+	if len(changed) == 0 {
+		return before, nil
+	}
+	result, svcErr := h.service.Replace(services.WithChangedFields(ctx, changed), fossil)
+	if svcErr != nil {
+		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
+	}
+	return FossilToProto(result), nil
+}
+
+// validateCreateFossilRequest checks the fields of a create request against the rules of the fossils
+func validateCreateFossilRequest(req *pb.CreateFossilRequest) error {
+	return grpcutil.ValidateCreate(fossilRules, req)
+}
+
+func fossilFromCreateRequest(req *pb.CreateFossilRequest) *Fossil {
+	return &Fossil{
 		DiscoveryLocation: req.DiscoveryLocation,
 		EstimatedAge: func() *int {
 			if req.EstimatedAge != nil {
@@ -54,55 +103,51 @@ func (h *fossilGRPCHandler) CreateFossil(ctx context.Context, req *pb.CreateFoss
 		}(),
 		FossilType:    req.FossilType,
 		ExcavatorName: req.ExcavatorName,
+		DinosaurID:    req.DinosaurId,
+		ScientistID:   req.ScientistId,
 	}
-	result, svcErr := h.service.Create(ctx, fossil)
-	if svcErr != nil {
-		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
-	}
-	return fossilToProto(result), nil
 }
 
-func (h *fossilGRPCHandler) UpdateFossil(ctx context.Context, req *pb.UpdateFossilRequest) (*pb.Fossil, error) {
+// validateUpdateFossilRequest checks the id, the update mask and the fields an update request writes, and
+// returns those fields. Immutable fields are checked once the fossil is found.
+func validateUpdateFossilRequest(req *pb.UpdateFossilRequest) (grpcutil.FieldSet, error) {
 	if err := grpcutil.ValidateRequiredID(req.Id); err != nil {
 		return nil, err
 	}
-	if req.DiscoveryLocation != nil {
-		if err := grpcutil.ValidateStringField("discovery_location", *req.DiscoveryLocation, false); err != nil {
-			return nil, err
-		}
-	}
-	if req.FossilType != nil {
-		if err := grpcutil.ValidateStringField("fossil_type", *req.FossilType, false); err != nil {
-			return nil, err
-		}
-	}
-	if req.ExcavatorName != nil {
-		if err := grpcutil.ValidateStringField("excavator_name", *req.ExcavatorName, false); err != nil {
-			return nil, err
-		}
+	fields, err := grpcutil.UpdatedFields(req, req.UpdateMask)
+	if err != nil {
+		return nil, err
 	}
+	return fields, grpcutil.ValidateUpdate(fossilRules, req, fields, nil)
+}
 
-	fossil, svcErr := h.service.Get(ctx, req.Id)
-	if svcErr != nil {
-		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
-	}
-	if req.DiscoveryLocation != nil {
-		fossil.DiscoveryLocation = *req.DiscoveryLocation
+// applyUpdateFossilRequest sets fields, the fields the update writes, on fossil from req. Those req leaves
+// unset are cleared.
+func applyUpdateFossilRequest(fossil *Fossil, req *pb.UpdateFossilRequest, fields grpcutil.FieldSet) {
+	if fields["discovery_location"] {
+		fossil.DiscoveryLocation = req.GetDiscoveryLocation()
 	}
-	if req.EstimatedAge != nil {
-		fossil.EstimatedAge = func() *int { v := int(*req.EstimatedAge); return &v }()
+	if fields["estimated_age"] {
+		fossil.EstimatedAge = func() *int {
+			if req.EstimatedAge != nil {
+				v := int(*req.EstimatedAge)
+				return &v
+			}
+			return nil
+		}()
 	}
-	if req.FossilType != nil {
+	if fields["fossil_type"] {
 		fossil.FossilType = req.FossilType
 	}
-	if req.ExcavatorName != nil {
+	if fields["excavator_name"] {
 		fossil.ExcavatorName = req.ExcavatorName
 	}
-	result, svcErr := h.service.Replace(ctx, fossil)
-	if svcErr != nil {
-		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
+	if fields["dinosaur_id"] {
+		fossil.DinosaurID = req.DinosaurId
+	}
+	if fields["scientist_id"] {
+		fossil.ScientistID = req.ScientistId
 	}
-	return fossilToProto(result), nil
 }
 
 func (h *fossilGRPCHandler) DeleteFossil(ctx context.Context, req *pb.DeleteFossilRequest) (*pb.DeleteFossilResponse, error) {
@@ -110,7 +155,7 @@ func (h *fossilGRPCHandler) DeleteFossil(ctx context.Context, req *pb.DeleteFoss
 		return nil, err
 	}
 
-	svcErr := h.service.Delete(ctx, req.Id)
+	svcErr := h.service.Delete(ctx, req.Id, nil)
 	if svcErr != nil {
 		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
 	}
@@ -118,27 +163,36 @@ func (h *fossilGRPCHandler) DeleteFossil(ctx context.Context, req *pb.DeleteFoss
 }
 
 func (h *fossilGRPCHandler) ListFossils(ctx context.Context, req *pb.ListFossilsRequest) (*pb.ListFossilsResponse, error) {
+	if err := grpcutil.ValidateReadMask(req.ReadMask, &pb.Fossil{}); err != nil {
+		return nil, err
+	}
 	page, size := grpcutil.NormalizePagination(req.Page, req.Size)
 
 	listArgs := &services.ListArguments{
-		Page: int(page),
-		Size: int64(size),
+		Page:           int(page),
+		Size:           int64(size),
+		Search:         req.Search,
+		OrderBy:        req.OrderBy,
+		Continue:       req.ContinueToken,
+		SkipCount:      req.SkipCount,
+		IncludeDeleted: req.IncludeDeleted,
 	}
 
 	var fossils []Fossil
-	paging, svcErr := h.generic.List(ctx, "id", listArgs, &fossils)
+	paging, svcErr := h.generic.List(ctx, listArgs, &fossils)
 	if svcErr != nil {
 		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
 	}
 
 	items := make([]*pb.Fossil, len(fossils))
 	for i, d := range fossils {
-		items[i] = fossilToProto(&d)
+		items[i] = FossilToProto(&d)
+		grpcutil.ApplyReadMask(items[i], req.ReadMask)
 	}
 
 	return &pb.ListFossilsResponse{
 		Items:    items,
-		Metadata: &pb.ListMeta{Page: page, Size: size, Total: int32(paging.Total)},
+		Metadata: &pb.ListMeta{Page: page, Size: size, Total: int32(paging.Total), ContinueToken: paging.Continue},
 	}, nil
 }
 
@@ -149,44 +203,130 @@ func (h *fossilGRPCHandler) WatchFossils(req *pb.WatchFossilsRequest, stream grp
 	}
 
 	ctx := stream.Context()
-	sub, err := broker.Subscribe(ctx)
-	if err != nil {
-		return status.Errorf(codes.Unavailable, "failed to subscribe: %v", err)
+	if svcErr := validateFossilWatchFilter(ctx, h.generic, req.Filter); svcErr != nil {
+		return grpcutil.ServiceErrorToGRPC(svcErr)
 	}
-	glog.V(4).Infof("WatchFossils: subscriber %s connected", sub.ID)
+	from := pkgserver.ResumePoint{EventID: req.ResumeFrom, EventSeq: req.EventSeq}
+	logger.V(4).Infof("WatchFossils: watch started, resuming from %+v", from)
 
-	for {
-		select {
-		case <-ctx.Done():
-			glog.V(4).Infof("WatchFossils: subscriber %s disconnected", sub.ID)
-			return nil
-		case evt, ok := <-sub.Events:
-			if !ok {
-				return nil
-			}
+	return broker.ServeWatch(ctx, "Fossils", from, func(evt *pkgserver.BrokerEvent) error {
+		watchEvent, err := h.fossilWatchEvent(ctx, evt, req.Filter)
+		if err != nil || watchEvent == nil {
+			return err
+		}
+		return stream.Send(watchEvent)
+	}, func(resumeFrom string, eventSeq int64) error {
+		logger.V(4).Infof("WatchFossils: resync required, resume from %q", resumeFrom)
+		return stream.Send(&pb.FossilWatchEvent{Type: pb.EventType_EVENT_TYPE_RESYNC_REQUIRED, EventId: resumeFrom, EventSeq: eventSeq})
+	})
+}
 
-			if evt.Source != "Fossils" {
-				continue
-			}
+// fossilWatchEvent returns the watch event of evt, or nil if the fossil doesn't match filter or isn't
+// visible to the caller
+func (h *fossilGRPCHandler) fossilWatchEvent(ctx context.Context, evt *pkgserver.BrokerEvent, filter string) (*pb.FossilWatchEvent, error) {
+	fossil, ok, svcErr := watchedFossil(ctx, h.generic, evt, filter)
+	if svcErr != nil {
+		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
+	}
+	if !ok {
+		return nil, nil
+	}
 
-			watchEvent := &pb.FossilWatchEvent{
-				Type:       grpcutil.APIEventTypeToProto(evt.EventType),
-				ResourceId: evt.SourceID,
-			}
+	watchEvent := &pb.FossilWatchEvent{
+		Type:          grpcutil.APIEventTypeToProto(evt.EventType),
+		ResourceId:    evt.SourceID,
+		EventId:       evt.EventID,
+		EventSeq:      evt.Seq,
+		ChangedFields: evt.ChangedFields,
+	}
+	if fossil != nil {
+		watchEvent.Fossil = FossilToProto(fossil)
+	}
+	return watchEvent, nil
+}
 
-			if evt.EventType != api.DeleteEventType {
-				fossil, svcErr := h.service.Get(ctx, evt.SourceID)
-				if svcErr != nil {
-					glog.Warningf("WatchFossils: failed to load fossil %s: %v", evt.SourceID, svcErr)
-					continue
-				}
-				watchEvent.Fossil = fossilToProto(fossil)
-			}
+func (h *fossilGRPCHandler) BatchCreateFossils(ctx context.Context, req *pb.BatchCreateFossilsRequest) (*pb.FossilBatchResponse, error) {
+	if err := grpcutil.ValidateBatchSize("requests", len(req.Requests)); err != nil {
+		return nil, err
+	}
+	fossils := make(FossilList, len(req.Requests))
+	for i, item := range req.Requests {
+		if err := grpcutil.ValidateBatchItem("requests", i, validateCreateFossilRequest(item)); err != nil {
+			return nil, err
+		}
+		fossils[i] = fossilFromCreateRequest(item)
+	}
 
-			if err := stream.Send(watchEvent); err != nil {
-				glog.V(4).Infof("WatchFossils: send error for subscriber %s: %v", sub.ID, err)
-				return err
-			}
+	itemErrs, svcErr := h.service.BatchCreate(ctx, fossils, req.AllOrNothing)
+	if svcErr != nil {
+		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
+	}
+	results := make([]*pb.FossilBatchResult, len(fossils))
+	for i, fossil := range fossils {
+		if itemErrs[i] != nil {
+			results[i] = &pb.FossilBatchResult{Error: grpcutil.BatchItemErrorToProto(itemErrs[i])}
+			continue
 		}
+		results[i] = &pb.FossilBatchResult{Id: fossil.ID, Fossil: FossilToProto(fossil)}
+	}
+	return &pb.FossilBatchResponse{Results: results}, nil
+}
+
+func (h *fossilGRPCHandler) BatchUpdateFossils(ctx context.Context, req *pb.BatchUpdateFossilsRequest) (*pb.FossilBatchResponse, error) {
+	if err := grpcutil.ValidateBatchSize("requests", len(req.Requests)); err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(req.Requests))
+	fields := make([]grpcutil.FieldSet, len(req.Requests))
+	for i, item := range req.Requests {
+		itemFields, err := validateUpdateFossilRequest(item)
+		if err := grpcutil.ValidateBatchItem("requests", i, err); err != nil {
+			return nil, err
+		}
+		ids[i], fields[i] = item.Id, itemFields
+	}
+
+	fossils, itemErrs, svcErr := h.service.BatchUpdate(ctx, ids, func(i int, fossil *Fossil) *errors.ServiceError {
+		if err := services.ValidateResourceVersion(req.Requests[i].ResourceVersion, fossil.ResourceVersion); err != nil {
+			return err
+		}
+		if err := fossilRules.ValidateUpdate(fields[i].Values(req.Requests[i]), validation.ProtoFieldsOf(FossilToProto(fossil))); err != nil {
+			return err
+		}
+		applyUpdateFossilRequest(fossil, req.Requests[i], fields[i])
+		return nil
+	}, req.AllOrNothing)
+	if svcErr != nil {
+		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
+	}
+	results := make([]*pb.FossilBatchResult, len(ids))
+	for i, id := range ids {
+		if itemErrs[i] != nil {
+			results[i] = &pb.FossilBatchResult{Id: id, Error: grpcutil.BatchItemErrorToProto(itemErrs[i])}
+			continue
+		}
+		results[i] = &pb.FossilBatchResult{Id: id, Fossil: FossilToProto(fossils[i])}
+	}
+	return &pb.FossilBatchResponse{Results: results}, nil
+}
+
+func (h *fossilGRPCHandler) BatchDeleteFossils(ctx context.Context, req *pb.BatchDeleteFossilsRequest) (*pb.FossilBatchResponse, error) {
+	if err := grpcutil.ValidateBatchSize("ids", len(req.Ids)); err != nil {
+		return nil, err
+	}
+	for i, id := range req.Ids {
+		if err := grpcutil.ValidateBatchItem("ids", i, grpcutil.ValidateRequiredID(id)); err != nil {
+			return nil, err
+		}
+	}
+
+	itemErrs, svcErr := h.service.BatchDelete(ctx, req.Ids, req.AllOrNothing)
+	if svcErr != nil {
+		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
+	}
+	results := make([]*pb.FossilBatchResult, len(req.Ids))
+	for i, id := range req.Ids {
+		results[i] = &pb.FossilBatchResult{Id: id, Error: grpcutil.BatchItemErrorToProto(itemErrs[i])}
 	}
+	return &pb.FossilBatchResponse{Results: results}, nil
 }
@@ -4,16 +4,23 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	. "github.com/onsi/gomega"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	pb "github.com/openshift-online/rh-trex-ai/pkg/api/grpc/rh_trex/v1"
 	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/server/grpcutil"
 	"github.com/openshift-online/rh-trex-ai/test"
 )
 
@@ -239,4 +246,126 @@ func TestGRPCFossilErrorHandling(t *testing.T) {
 
 	deleteReq := &pb.DeleteFossilRequest{Id: "nonexistent"}
 	_, err = grpcClient.DeleteFossil(context.Background(), deleteReq)
-}
\ No newline at end of file
+}
+func TestGRPCFossilValidation(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	jwtToken := h.CreateJWTString(account)
+
+	conn, err := grpc.NewClient(
+		h.GRPCAddress(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(&bearerToken{token: jwtToken}),
+	)
+	Expect(err).NotTo(HaveOccurred())
+	defer conn.Close()
+
+	grpcClient := pb.NewFossilServiceClient(conn)
+
+	// the rules are those of the REST API, with the same messages
+	estimatedAge := int32(-1)
+	_, err = grpcClient.CreateFossil(context.Background(), &pb.CreateFossilRequest{EstimatedAge: &estimatedAge})
+	Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+	Expect(status.Convert(err).Message()).To(Equal("discovery_location is required; estimated_age must be at least 0"))
+	violations := grpcutil.FieldViolationsFromGRPC(err)
+	Expect(violations).To(HaveLen(2))
+	Expect(violations[0]).To(Equal(errors.FieldViolation{Field: "discovery_location", Code: "required", Message: "discovery_location is required"}))
+	Expect(violations[1].Code).To(Equal("minimum"))
+
+	created, err := grpcClient.CreateFossil(context.Background(), &pb.CreateFossilRequest{DiscoveryLocation: "Hell Creek"})
+	Expect(err).NotTo(HaveOccurred())
+
+	excavatorName := strings.Repeat("x", 256)
+	_, err = grpcClient.UpdateFossil(context.Background(), &pb.UpdateFossilRequest{Id: created.Metadata.Id, ExcavatorName: &excavatorName})
+	Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+	Expect(status.Convert(err).Message()).To(Equal("excavator_name exceeds maximum length of 255"))
+}
+
+func TestGRPCFossilFieldMasks(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	jwtToken := h.CreateJWTString(account)
+
+	conn, err := grpc.NewClient(
+		h.GRPCAddress(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(&bearerToken{token: jwtToken}),
+	)
+	Expect(err).NotTo(HaveOccurred())
+	defer conn.Close()
+
+	grpcClient := pb.NewFossilServiceClient(conn)
+	ctx := context.Background()
+
+	location := h.NewID()
+	created, err := grpcClient.CreateFossil(ctx, &pb.CreateFossilRequest{
+		DiscoveryLocation: location,
+		EstimatedAge:      proto.Int32(66),
+		ExcavatorName:     proto.String("Sue"),
+	})
+	Expect(err).NotTo(HaveOccurred())
+
+	// the mask names the fields to write: unset ones are cleared, set ones it leaves out are ignored
+	updated, err := grpcClient.UpdateFossil(ctx, &pb.UpdateFossilRequest{
+		Id:            created.Metadata.Id,
+		ExcavatorName: proto.String("Stan"),
+		FossilType:    proto.String("Tooth"),
+		UpdateMask:    &fieldmaskpb.FieldMask{Paths: []string{"estimated_age", "fossil_type"}},
+	})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(updated.EstimatedAge).To(BeNil())
+	Expect(updated.GetFossilType()).To(Equal("Tooth"))
+	Expect(updated.GetExcavatorName()).To(Equal("Sue"))
+
+	_, err = grpcClient.UpdateFossil(ctx, &pb.UpdateFossilRequest{
+		Id:         created.Metadata.Id,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"discovery_location"}},
+	})
+	Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+	Expect(grpcutil.FieldViolationsFromGRPC(err)[0].Code).To(Equal("required"))
+
+	_, err = grpcClient.UpdateFossil(ctx, &pb.UpdateFossilRequest{
+		Id:         created.Metadata.Id,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"colour"}},
+	})
+	Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+	Expect(grpcutil.FieldViolationsFromGRPC(err)[0].Code).To(Equal("unknown"))
+
+	// the read mask projects the fields returned
+	retrieved, err := grpcClient.GetFossil(ctx, &pb.GetFossilRequest{
+		Id:       created.Metadata.Id,
+		ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"fossil_type", "metadata.id"}},
+	})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(retrieved.GetFossilType()).To(Equal("Tooth"))
+	Expect(retrieved.DiscoveryLocation).To(BeEmpty())
+	Expect(retrieved.Metadata.Id).To(Equal(created.Metadata.Id))
+	Expect(retrieved.Metadata.CreatedAt).To(BeNil())
+
+	_, err = grpcClient.GetFossil(ctx, &pb.GetFossilRequest{
+		Id:       created.Metadata.Id,
+		ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"metadata.colour"}},
+	})
+	Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+
+	// lists take the search and order of the REST API
+	location = h.NewID()
+	for _, age := range []int32{100, 150} {
+		_, err = grpcClient.CreateFossil(ctx, &pb.CreateFossilRequest{DiscoveryLocation: location, EstimatedAge: proto.Int32(age)})
+		Expect(err).NotTo(HaveOccurred())
+	}
+	listResp, err := grpcClient.ListFossils(ctx, &pb.ListFossilsRequest{
+		Search:   fmt.Sprintf("discovery_location = '%s'", location),
+		OrderBy:  []string{"estimated_age desc"},
+		ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"estimated_age"}},
+	})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(listResp.Items).To(HaveLen(2))
+	Expect(listResp.Items[0].GetEstimatedAge()).To(Equal(int32(150)))
+	Expect(listResp.Items[0].Metadata).To(BeNil())
+
+	_, err = grpcClient.ListFossils(ctx, &pb.ListFossilsRequest{Search: "discovery_location ="})
+	Expect(status.Code(err)).To(Equal(codes.InvalidArgument))
+}
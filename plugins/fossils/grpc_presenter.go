@@ -2,17 +2,21 @@ package fossils
 
 import (
 	pb "github.com/openshift-online/rh-trex-ai/pkg/api/grpc/rh_trex/v1"
+	"github.com/openshift-online/rh-trex-ai/pkg/server/grpcutil"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func fossilToProto(d *Fossil) *pb.Fossil {
+// FossilToProto converts a Fossil to its protobuf message
+func FossilToProto(d *Fossil) *pb.Fossil {
 	return &pb.Fossil{
 		Metadata: &pb.ObjectReference{
-			Id:        d.ID,
-			CreatedAt: timestamppb.New(d.CreatedAt),
-			UpdatedAt: timestamppb.New(d.UpdatedAt),
-			Kind:      "Fossil",
-			Href:      "/api/rh-trex-ai/v1/fossils/" + d.ID,
+			Id:              d.ID,
+			CreatedAt:       timestamppb.New(d.CreatedAt),
+			UpdatedAt:       timestamppb.New(d.UpdatedAt),
+			Kind:            "Fossil",
+			Href:            "/api/rh-trex-ai/v1/fossils/" + d.ID,
+			ResourceVersion: d.ResourceVersion,
+			DeletedAt:       grpcutil.DeletedAtToProto(d.DeletedAt),
 		},
 		DiscoveryLocation: d.DiscoveryLocation,
 		EstimatedAge: func() *int32 {
@@ -24,5 +28,7 @@ func fossilToProto(d *Fossil) *pb.Fossil {
 		}(),
 		FossilType:    d.FossilType,
 		ExcavatorName: d.ExcavatorName,
+		DinosaurId:    d.DinosaurID,
+		ScientistId:   d.ScientistID,
 	}
 }
@@ -1,28 +1,40 @@
 package fossils
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"reflect"
 
 	"github.com/gorilla/mux"
 
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
 	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
 	"github.com/openshift-online/rh-trex-ai/pkg/api/presenters"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
 	"github.com/openshift-online/rh-trex-ai/pkg/errors"
 	"github.com/openshift-online/rh-trex-ai/pkg/handlers"
+	pkgserver "github.com/openshift-online/rh-trex-ai/pkg/server"
 	"github.com/openshift-online/rh-trex-ai/pkg/services"
+	"github.com/openshift-online/rh-trex-ai/pkg/validation"
+	"github.com/openshift-online/rh-trex-ai/plugins/dinosaurs"
+	"github.com/openshift-online/rh-trex-ai/plugins/scientists"
 )
 
 var _ handlers.RestHandler = fossilHandler{}
 
 type fossilHandler struct {
-	fossil  FossilService
-	generic services.GenericService
+	fossil     FossilService
+	generic    services.GenericService
+	brokerFunc func() *pkgserver.EventBroker
 }
 
-func NewFossilHandler(fossil FossilService, generic services.GenericService) *fossilHandler {
+func NewFossilHandler(fossil FossilService, generic services.GenericService, brokerFunc func() *pkgserver.EventBroker) *fossilHandler {
 	return &fossilHandler{
-		fossil:  fossil,
-		generic: generic,
+		fossil:     fossil,
+		generic:    generic,
+		brokerFunc: brokerFunc,
 	}
 }
 
@@ -32,6 +44,7 @@ func (h fossilHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Body: &fossil,
 		Validators: []handlers.Validate{
 			handlers.ValidateEmpty(&fossil, "Id", "id"),
+			handlers.ValidateCreate(fossilRules, &fossil),
 		},
 		Action: func() (interface{}, *errors.ServiceError) {
 			ctx := r.Context()
@@ -40,6 +53,7 @@ func (h fossilHandler) Create(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				return nil, err
 			}
+			handlers.SetETag(w, fossilModel.ResourceVersion)
 			return PresentFossil(fossilModel), nil
 		},
 		ErrorHandler: handlers.HandleError,
@@ -48,38 +62,41 @@ func (h fossilHandler) Create(w http.ResponseWriter, r *http.Request) {
 	handlers.Handle(w, r, cfg, http.StatusCreated)
 }
 
+// Patch applies a partial fossil, a JSON Merge Patch or a JSON Patch, by the Content-Type of the request
 func (h fossilHandler) Patch(w http.ResponseWriter, r *http.Request) {
-	var patch openapi.FossilPatchRequest
+	var patch json.RawMessage
 
 	cfg := &handlers.HandlerConfig{
-		Body:       &patch,
-		Validators: []handlers.Validate{},
+		Body: &patch,
 		Action: func() (interface{}, *errors.ServiceError) {
 			ctx := r.Context()
+			format, err := handlers.PatchFormatOf(r)
+			if err != nil {
+				return nil, err
+			}
 			id := mux.Vars(r)["id"]
 			found, err := h.fossil.Get(ctx, id)
 			if err != nil {
 				return nil, err
 			}
-
-			if patch.DiscoveryLocation != nil {
-				found.DiscoveryLocation = *patch.DiscoveryLocation
-			}
-			if patch.EstimatedAge != nil {
-				estimatedAgeVal := int(*patch.EstimatedAge)
-				found.EstimatedAge = &estimatedAgeVal
-			}
-			if patch.FossilType != nil {
-				found.FossilType = patch.FossilType
+			if err := handlers.ValidateIfMatch(r, found.ResourceVersion); err != nil {
+				return nil, err
 			}
-			if patch.ExcavatorName != nil {
-				found.ExcavatorName = patch.ExcavatorName
+			patched, changed, err := patchFossil(format, patch, found)
+			if err != nil {
+				return nil, err
 			}
 
-			fossilModel, err := h.fossil.Replace(ctx, found)
+			// a patch that changes nothing doesn't write, bump the resource version or record an event
+			if len(changed) == 0 {
+				handlers.SetETag(w, found.ResourceVersion)
+				return PresentFossil(found), nil
+			}
+			fossilModel, err := h.fossil.Replace(services.WithChangedFields(ctx, changed), patched)
 			if err != nil {
 				return nil, err
 			}
+			handlers.SetETag(w, fossilModel.ResourceVersion)
 			return PresentFossil(fossilModel), nil
 		},
 		ErrorHandler: handlers.HandleError,
@@ -89,13 +106,57 @@ func (h fossilHandler) Patch(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h fossilHandler) List(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, "", nil)
+}
+
+// ListByDinosaur lists the fossils of the dinosaur of the path, /dinosaurs/{id}/fossils
+func (h fossilHandler) ListByDinosaur(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, "dinosaur_id", func(ctx context.Context, id string) *errors.ServiceError {
+		return h.findParent(ctx, "Dinosaur", id, &[]dinosaurs.Dinosaur{})
+	})
+}
+
+// ListByScientist lists the fossils of the scientist of the path, /scientists/{id}/fossils
+func (h fossilHandler) ListByScientist(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, "scientist_id", func(ctx context.Context, id string) *errors.ServiceError {
+		return h.findParent(ctx, "Scientist", id, &[]scientists.Scientist{})
+	})
+}
+
+// findParent fails with a 404 unless the parent of a nested list exists and is visible to the caller.
+// found is a pointer to an empty slice of the parent Kind.
+func (h fossilHandler) findParent(ctx context.Context, kind, id string, found interface{}) *errors.ServiceError {
+	listArgs := &services.ListArguments{Page: 1, Size: 1, Search: fmt.Sprintf("id = '%s'", id), SkipCount: true}
+	if _, err := h.generic.List(ctx, listArgs, found); err != nil {
+		return err
+	}
+	if reflect.ValueOf(found).Elem().Len() == 0 {
+		return errors.NotFound("%s with id='%s' not found", kind, id)
+	}
+	return nil
+}
+
+// list lists the fossils of the request. Nested lists are scoped to the parent of the path, by the
+// foreignKey column, once checkParent found it.
+func (h fossilHandler) list(w http.ResponseWriter, r *http.Request, foreignKey string, checkParent func(ctx context.Context, id string) *errors.ServiceError) {
 	cfg := &handlers.HandlerConfig{
 		Action: func() (interface{}, *errors.ServiceError) {
 			ctx := r.Context()
 
 			listArgs := services.NewListArguments(r.URL.Query())
+			if checkParent != nil {
+				id := mux.Vars(r)["id"]
+				if err := checkParent(ctx, id); err != nil {
+					return nil, err
+				}
+				search := fmt.Sprintf("%s = '%s'", foreignKey, id)
+				if listArgs.Search != "" {
+					search = fmt.Sprintf("%s and (%s)", search, listArgs.Search)
+				}
+				listArgs.Search = search
+			}
 			var fossils []Fossil
-			paging, err := h.generic.List(ctx, "id", listArgs, &fossils)
+			paging, err := h.generic.List(ctx, listArgs, &fossils)
 			if err != nil {
 				return nil, err
 			}
@@ -106,6 +167,9 @@ func (h fossilHandler) List(w http.ResponseWriter, r *http.Request) {
 				Total: int32(paging.Total),
 				Items: []openapi.Fossil{},
 			}
+			if paging.Continue != "" {
+				fossilList.Continue = openapi.PtrString(paging.Continue)
+			}
 
 			for _, fossil := range fossils {
 				converted := PresentFossil(&fossil)
@@ -125,6 +189,91 @@ func (h fossilHandler) List(w http.ResponseWriter, r *http.Request) {
 	handlers.HandleList(w, r, cfg)
 }
 
+// Watch streams the events of the fossils that match the search parameter as Server-Sent Events,
+// resuming after the Last-Event-ID header if set
+func (h fossilHandler) Watch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	broker := h.brokerFunc()
+	if broker == nil {
+		handlers.HandleError(ctx, w, errors.GeneralError("Event broker not available"))
+		return
+	}
+	filter := r.URL.Query().Get("search")
+	if err := validateFossilWatchFilter(ctx, h.generic, filter); err != nil {
+		handlers.HandleError(ctx, w, err)
+		return
+	}
+
+	broker.ServeSSE(w, r, "Fossils", func(ctx context.Context, evt *pkgserver.BrokerEvent) (interface{}, bool, *errors.ServiceError) {
+		fossil, ok, err := watchedFossil(ctx, h.generic, evt, filter)
+		if err != nil || fossil == nil {
+			return nil, ok, err
+		}
+		return PresentFossil(fossil), true, nil
+	})
+}
+
+// validateFossilWatchFilter fails a watch filter that isn't a valid search right away, rather than
+// with the first event
+func validateFossilWatchFilter(ctx context.Context, generic services.GenericService, filter string) *errors.ServiceError {
+	if filter == "" {
+		return nil
+	}
+	var fossils []Fossil
+	_, err := generic.List(ctx, &services.ListArguments{Page: 1, Size: 1, Search: filter, SkipCount: true}, &fossils)
+	return err
+}
+
+// watchedFossil returns the fossil of a watch event, and whether the event is sent at all: events of
+// fossils that don't match filter or aren't visible to the caller are skipped. Deleted fossils are
+// matched on their last state, no fossil is returned for deletes.
+func watchedFossil(ctx context.Context, generic services.GenericService, evt *pkgserver.BrokerEvent, filter string) (*Fossil, bool, *errors.ServiceError) {
+	if filter == "" {
+		return unfilteredWatchedFossil(ctx, generic, evt)
+	}
+	var fossils []Fossil
+	search := fmt.Sprintf("id = '%s' and (%s)", evt.SourceID, filter)
+	listArgs := &services.ListArguments{Page: 1, Size: 1, Search: search, SkipCount: true, IncludeDeleted: true}
+	if _, err := generic.List(ctx, listArgs, &fossils); err != nil || len(fossils) == 0 {
+		return nil, false, err
+	}
+	if evt.EventType == api.DeleteEventType {
+		return nil, true, nil
+	}
+	return &fossils[0], true, nil
+}
+
+// unfilteredWatchedFossil is watchedFossil for watches without a filter. Deletes are sent without reading the
+// fossil, and the fossil of other events is read once for every watcher of the event.
+func unfilteredWatchedFossil(ctx context.Context, generic services.GenericService, evt *pkgserver.BrokerEvent) (*Fossil, bool, *errors.ServiceError) {
+	if evt.EventType == api.DeleteEventType {
+		return nil, evt.VisibleTo(ctx), nil
+	}
+	loaded, err := evt.Resource(ctx, func(ctx context.Context) (interface{}, *errors.ServiceError) {
+		var fossils []Fossil
+		listArgs := &services.ListArguments{Page: 1, Size: 1, Search: fmt.Sprintf("id = '%s'", evt.SourceID), SkipCount: true, IncludeDeleted: true}
+		if _, err := generic.List(ctx, listArgs, &fossils); err != nil || len(fossils) == 0 {
+			return nil, err
+		}
+		return &fossils[0], nil
+	})
+	fossil, _ := loaded.(*Fossil)
+	if err != nil || fossil == nil {
+		// purged since
+		return nil, false, err
+	}
+	return fossil, auth.InTenantScope(ctx, fossil.OrganizationID), nil
+}
+
+// presentWatchedFossil is watchedFossil for consumers of presented fossils, e.g. webhooks
+func presentWatchedFossil(ctx context.Context, generic services.GenericService, evt *pkgserver.BrokerEvent, filter string) (interface{}, bool, *errors.ServiceError) {
+	fossil, matched, err := watchedFossil(ctx, generic, evt, filter)
+	if fossil == nil {
+		return nil, matched, err
+	}
+	return PresentFossil(fossil), matched, err
+}
+
 func (h fossilHandler) Get(w http.ResponseWriter, r *http.Request) {
 	cfg := &handlers.HandlerConfig{
 		Action: func() (interface{}, *errors.ServiceError) {
@@ -134,6 +283,7 @@ func (h fossilHandler) Get(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				return nil, err
 			}
+			handlers.SetETag(w, fossil.ResourceVersion)
 
 			return PresentFossil(fossil), nil
 		},
@@ -147,7 +297,12 @@ func (h fossilHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		Action: func() (interface{}, *errors.ServiceError) {
 			id := mux.Vars(r)["id"]
 			ctx := r.Context()
-			err := h.fossil.Delete(ctx, id)
+			// the If-Match versions are checked by the DELETE itself, not by a read before it
+			resourceVersions, err := handlers.IfMatchVersions(r)
+			if err != nil {
+				return nil, err
+			}
+			err = h.fossil.Delete(ctx, id, resourceVersions)
 			if err != nil {
 				return nil, err
 			}
@@ -156,3 +311,189 @@ func (h fossilHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
 }
+
+// Restore undeletes a soft-deleted fossil
+func (h fossilHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			fossil, err := h.fossil.Restore(r.Context(), id)
+			if err != nil {
+				return nil, err
+			}
+			handlers.SetETag(w, fossil.ResourceVersion)
+			return PresentFossil(fossil), nil
+		},
+	}
+	handlers.HandleWithoutBody(w, r, cfg, http.StatusOK)
+}
+
+// Purge hard-deletes a soft-deleted fossil before its retention has passed
+func (h fossilHandler) Purge(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			if err := h.fossil.Purge(r.Context(), id); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		},
+	}
+	handlers.HandleWithoutBody(w, r, cfg, http.StatusNoContent)
+}
+
+// patchFossil applies patch, the body of a PATCH request in format, to fossil. It returns the patched
+// fossil and the fields the patch changed.
+func patchFossil(format handlers.PatchFormat, patch []byte, fossil *Fossil) (*Fossil, []string, *errors.ServiceError) {
+	var patched openapi.Fossil
+	changed, err := handlers.ApplyPatch(format, patch, fossilRules, PresentFossil(fossil), &patched, openapi.FossilPatchRequest{})
+	if err != nil {
+		return nil, nil, err
+	}
+	result := ConvertFossil(patched)
+	result.Meta = fossil.Meta
+	return result, changed, nil
+}
+
+type fossilBatchCreateRequest struct {
+	Items        []openapi.Fossil `json:"items"`
+	AllOrNothing bool             `json:"all_or_nothing"`
+}
+
+type fossilBatchPatchItem struct {
+	ID              string                     `json:"id"`
+	ResourceVersion *int64                     `json:"resource_version,omitempty"`
+	Patch           openapi.FossilPatchRequest `json:"patch"`
+}
+
+type fossilBatchPatchRequest struct {
+	Items        []fossilBatchPatchItem `json:"items"`
+	AllOrNothing bool                   `json:"all_or_nothing"`
+}
+
+// BatchCreate creates every fossil of the request in one transaction
+func (h fossilHandler) BatchCreate(w http.ResponseWriter, r *http.Request) {
+	var batch fossilBatchCreateRequest
+	cfg := &handlers.HandlerConfig{
+		Body: &batch,
+		Validators: []handlers.Validate{
+			handlers.ValidateBatchSize(&batch, "Items", "items"),
+			func() *errors.ServiceError {
+				for i := range batch.Items {
+					if batch.Items[i].Id != nil {
+						return errors.Validation("items[%d].id must be empty", i)
+					}
+					if err := fossilRules.ValidateCreate(validation.FieldsOf(batch.Items[i])); err != nil {
+						return validation.ItemError("items", i, err)
+					}
+				}
+				return nil
+			},
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			fossils := make(FossilList, len(batch.Items))
+			for i := range batch.Items {
+				fossils[i] = ConvertFossil(batch.Items[i])
+			}
+			itemErrs, err := h.fossil.BatchCreate(ctx, fossils, batch.AllOrNothing)
+			if err != nil {
+				return nil, err
+			}
+			response := handlers.BatchResponse{Kind: "FossilBatchResponse", Items: []handlers.BatchResult{}}
+			for i, fossil := range fossils {
+				if itemErrs[i] != nil {
+					response.Items = append(response.Items, handlers.NewBatchResult(ctx, "", 0, nil, itemErrs[i]))
+					continue
+				}
+				response.Items = append(response.Items, handlers.NewBatchResult(ctx, fossil.ID, http.StatusCreated, PresentFossil(fossil), nil))
+			}
+			return response, nil
+		},
+		ErrorHandler: handlers.HandleError,
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+// BatchPatch patches every fossil of the request in one transaction. An item's resource_version works like If-Match.
+func (h fossilHandler) BatchPatch(w http.ResponseWriter, r *http.Request) {
+	var batch fossilBatchPatchRequest
+	cfg := &handlers.HandlerConfig{
+		Body: &batch,
+		Validators: []handlers.Validate{
+			handlers.ValidateBatchSize(&batch, "Items", "items"),
+			func() *errors.ServiceError {
+				for i := range batch.Items {
+					if err := fossilRules.ValidateUpdate(validation.FieldsOf(batch.Items[i].Patch), nil); err != nil {
+						return validation.ItemError("items", i, err)
+					}
+				}
+				return nil
+			},
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			ids := make([]string, len(batch.Items))
+			for i, item := range batch.Items {
+				ids[i] = item.ID
+			}
+			fossils, itemErrs, err := h.fossil.BatchUpdate(ctx, ids, func(i int, fossil *Fossil) *errors.ServiceError {
+				if err := services.ValidateResourceVersion(batch.Items[i].ResourceVersion, fossil.ResourceVersion); err != nil {
+					return err
+				}
+				patch, err := json.Marshal(batch.Items[i].Patch)
+				if err != nil {
+					return errors.MalformedRequest("Invalid patch: %s", err)
+				}
+				patched, _, svcErr := patchFossil(handlers.PatchFormatJSON, patch, fossil)
+				if svcErr != nil {
+					return svcErr
+				}
+				*fossil = *patched
+				return nil
+			}, batch.AllOrNothing)
+			if err != nil {
+				return nil, err
+			}
+			response := handlers.BatchResponse{Kind: "FossilBatchResponse", Items: []handlers.BatchResult{}}
+			for i, id := range ids {
+				if itemErrs[i] != nil {
+					response.Items = append(response.Items, handlers.NewBatchResult(ctx, id, 0, nil, itemErrs[i]))
+					continue
+				}
+				response.Items = append(response.Items, handlers.NewBatchResult(ctx, id, http.StatusOK, PresentFossil(fossils[i]), nil))
+			}
+			return response, nil
+		},
+		ErrorHandler: handlers.HandleError,
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+// BatchDelete deletes every fossil of the request in one transaction
+func (h fossilHandler) BatchDelete(w http.ResponseWriter, r *http.Request) {
+	var batch handlers.BatchDeleteRequest
+	cfg := &handlers.HandlerConfig{
+		Body: &batch,
+		Validators: []handlers.Validate{
+			handlers.ValidateBatchSize(&batch, "IDs", "ids"),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			itemErrs, err := h.fossil.BatchDelete(ctx, batch.IDs, batch.AllOrNothing)
+			if err != nil {
+				return nil, err
+			}
+			response := handlers.BatchResponse{Kind: "FossilBatchResponse", Items: []handlers.BatchResult{}}
+			for i, id := range batch.IDs {
+				response.Items = append(response.Items, handlers.NewBatchResult(ctx, id, http.StatusNoContent, nil, itemErrs[i]))
+			}
+			return response, nil
+		},
+		ErrorHandler: handlers.HandleError,
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
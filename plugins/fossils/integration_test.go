@@ -4,12 +4,16 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	"gopkg.in/resty.v1"
 
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
 	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
 	"github.com/openshift-online/rh-trex-ai/test"
 )
 
@@ -97,6 +101,77 @@ func TestFossilPatch(t *testing.T) {
 	Expect(restyResp.StatusCode()).To(Equal(http.StatusBadRequest))
 }
 
+func TestFossilPatchFormats(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	jwtToken := ctx.Value(openapi.ContextAccessToken)
+
+	fossil, _, err := client.DefaultAPI.ApiRhTrexAiV1FossilsPost(ctx).Fossil(openapi.Fossil{
+		DiscoveryLocation: "Hell Creek",
+		EstimatedAge:      openapi.PtrInt32(66),
+	}).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	fossilURL := h.RestURL("/fossils/" + *fossil.Id)
+
+	// null leaves a field of a partial fossil as it is, and clears it in a merge patch
+	restyResp, err := resty.R().
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetBody(`{"estimated_age": null}`).
+		Patch(fossilURL)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+	Expect(string(restyResp.Body())).To(ContainSubstring(`"estimated_age":66`))
+
+	restyResp, err = resty.R().
+		SetHeader("Content-Type", "application/merge-patch+json").
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetBody(`{"estimated_age": null, "excavator_name": "Sue"}`).
+		Patch(fossilURL)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+	Expect(string(restyResp.Body())).NotTo(ContainSubstring(`"estimated_age"`))
+
+	restyResp, err = resty.R().
+		SetHeader("Content-Type", "application/json-patch+json").
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetBody(`[{"op": "test", "path": "/excavator_name", "value": "Sue"}, {"op": "replace", "path": "/discovery_location", "value": "Gobi"}]`).
+		Patch(fossilURL)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusOK))
+	Expect(string(restyResp.Body())).To(ContainSubstring(`"discovery_location":"Gobi"`))
+
+	restyResp, err = resty.R().
+		SetHeader("Content-Type", "application/json-patch+json").
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetBody(`[{"op": "test", "path": "/excavator_name", "value": "Stan"}]`).
+		Patch(fossilURL)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusConflict))
+
+	restyResp, err = resty.R().
+		SetHeader("Content-Type", "text/plain").
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetBody(`estimated_age=1`).
+		Patch(fossilURL)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusUnsupportedMediaType))
+
+	// the update events carry the fields each patch changed, none for the patch that changed nothing
+	eventDao := dao.NewEventDao(&h.Env().Database.SessionFactory, h.Env().Database.EventBus)
+	updates, err := eventDao.FindBySourceAndType(ctx, "Fossils", api.UpdateEventType)
+	Expect(err).NotTo(HaveOccurred())
+	var masks [][]string
+	for _, event := range updates {
+		if event.SourceID == *fossil.Id {
+			masks = append(masks, event.FieldMask())
+		}
+	}
+	Expect(masks).To(ConsistOf(BeEmpty(), Equal([]string{"estimated_age", "excavator_name"}), Equal([]string{"discovery_location"})))
+}
+
 func TestFossilPaging(t *testing.T) {
 	h, client := test.RegisterIntegration(t)
 
@@ -137,3 +212,134 @@ func TestFossilListSearch(t *testing.T) {
 	Expect(list.Total).To(Equal(int32(1)))
 	Expect(*list.Items[0].Id).To(Equal(fossils[0].ID))
 }
+
+func TestFossilRelationships(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+	h.StartControllersServer()
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	jwtToken := ctx.Value(openapi.ContextAccessToken)
+
+	dinosaur, _, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursPost(ctx).Dinosaur(openapi.Dinosaur{Species: "Stegosaurus"}).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	scientist, _, err := client.DefaultAPI.ApiRhTrexAiV1ScientistsPost(ctx).Scientist(openapi.Scientist{Name: "Mary Anning", Field: "paleontology"}).Execute()
+	Expect(err).NotTo(HaveOccurred())
+
+	// the parents of a fossil must exist
+	_, resp, err := client.DefaultAPI.ApiRhTrexAiV1FossilsPost(ctx).Fossil(openapi.Fossil{
+		DiscoveryLocation: "Lyme Regis",
+		DinosaurId:        openapi.PtrString(h.NewID()),
+	}).Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+
+	fossil, _, err := client.DefaultAPI.ApiRhTrexAiV1FossilsPost(ctx).Fossil(openapi.Fossil{
+		DiscoveryLocation: "Lyme Regis",
+		DinosaurId:        dinosaur.Id,
+		ScientistId:       scientist.Id,
+	}).Execute()
+	Expect(err).NotTo(HaveOccurred())
+
+	list, _, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursIdFossilsGet(ctx, *dinosaur.Id).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(list.Items).To(HaveLen(1))
+	Expect(*list.Items[0].Id).To(Equal(*fossil.Id))
+	Expect(list.Items[0].Dinosaur).To(BeNil())
+
+	_, resp, err = client.DefaultAPI.ApiRhTrexAiV1DinosaursIdFossilsGet(ctx, h.NewID()).Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+
+	search := fmt.Sprintf("id = '%s'", *fossil.Id)
+	list, _, err = client.DefaultAPI.ApiRhTrexAiV1FossilsGet(ctx).Search(search).Preload("dinosaur").Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(list.Items).To(HaveLen(1))
+	Expect(list.Items[0].Dinosaur).NotTo(BeNil())
+	Expect(list.Items[0].Dinosaur.Species).To(Equal("Stegosaurus"))
+	Expect(list.Items[0].Scientist).To(BeNil())
+
+	_, resp, err = client.DefaultAPI.ApiRhTrexAiV1FossilsGet(ctx).Preload("excavator").Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+
+	// scientists can't be deleted while they have fossils
+	restyResp, err := resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		Delete(h.RestURL("/scientists/" + *scientist.Id))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusConflict))
+
+	// the fossils of a dinosaur go with it
+	restyResp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		Delete(h.RestURL("/dinosaurs/" + *dinosaur.Id))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusNoContent))
+
+	Eventually(func() int {
+		_, resp, _ := client.DefaultAPI.ApiRhTrexAiV1FossilsIdGet(ctx, *fossil.Id).Execute()
+		return resp.StatusCode
+	}, 15*time.Second).Should(Equal(http.StatusNotFound))
+
+	// nor do they come back while their dinosaur is deleted
+	restyResp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		Post(h.RestURL("/fossils/" + *fossil.Id + ":restore"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusBadRequest))
+	_, resp, err = client.DefaultAPI.ApiRhTrexAiV1FossilsIdGet(ctx, *fossil.Id).Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+
+	restyResp, err = resty.R().
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		Delete(h.RestURL("/scientists/" + *scientist.Id))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusNoContent))
+}
+
+func TestFossilValidation(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	// every field that breaks its rules is reported
+	_, resp, err := client.DefaultAPI.ApiRhTrexAiV1FossilsPost(ctx).Fossil(openapi.Fossil{
+		EstimatedAge: openapi.PtrInt32(-1),
+	}).Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+	openapiErr := h.OpenapiError(err)
+	Expect(openapiErr.GetReason()).To(Equal("discovery_location is required; estimated_age must be at least 0"))
+	Expect(openapiErr.FieldViolations).To(HaveLen(2))
+	Expect(openapiErr.FieldViolations[0].GetField()).To(Equal("discovery_location"))
+	Expect(openapiErr.FieldViolations[0].GetCode()).To(Equal("required"))
+	Expect(openapiErr.FieldViolations[1].GetField()).To(Equal("estimated_age"))
+	Expect(openapiErr.FieldViolations[1].GetCode()).To(Equal("minimum"))
+
+	fossil, _, err := client.DefaultAPI.ApiRhTrexAiV1FossilsPost(ctx).Fossil(openapi.Fossil{DiscoveryLocation: "Hell Creek"}).Execute()
+	Expect(err).NotTo(HaveOccurred())
+
+	_, resp, err = client.DefaultAPI.ApiRhTrexAiV1FossilsIdPatch(ctx, *fossil.Id).FossilPatchRequest(openapi.FossilPatchRequest{
+		DiscoveryLocation: openapi.PtrString(""),
+		ExcavatorName:     openapi.PtrString(strings.Repeat("x", 256)),
+	}).Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+	openapiErr = h.OpenapiError(err)
+	Expect(openapiErr.FieldViolations).To(HaveLen(2))
+	Expect(openapiErr.FieldViolations[1].GetCode()).To(Equal("max_length"))
+
+	// the items of a batch are reported by their position
+	jwtToken := ctx.Value(openapi.ContextAccessToken)
+	restyResp, err := resty.R().
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", jwtToken)).
+		SetBody(`{"items": [{"discovery_location": "Gobi"}, {"discovery_location": "Gobi", "estimated_age": -5}]}`).
+		Post(h.RestURL("/fossils:batchCreate"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(restyResp.StatusCode()).To(Equal(http.StatusBadRequest))
+	Expect(string(restyResp.Body())).To(ContainSubstring(`"field":"items[1].estimated_age"`))
+}
@@ -26,3 +26,73 @@ func migration() *gormigrate.Migration {
 		},
 	}
 }
+
+func resourceVersionMigration() *gormigrate.Migration {
+	type Fossil struct {
+		ResourceVersion int64 `gorm:"not null;default:1"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101709001012",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Fossil{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&Fossil{}, "resource_version")
+		},
+	}
+}
+
+func tenancyMigration() *gormigrate.Migration {
+	type Fossil struct {
+		OrganizationID string `gorm:"index"`
+		Owner          string
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101712001012",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Fossil{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, column := range []string{"organization_id", "owner"} {
+				if err := tx.Migrator().DropColumn(&Fossil{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func relationshipMigration() *gormigrate.Migration {
+	type Fossil struct {
+		DinosaurID  *string `gorm:"index"`
+		ScientistID *string `gorm:"index"`
+	}
+	fks := []db.FKMigration{
+		{Model: "fossils", Dest: "dinosaurs", Field: "dinosaur_id", Reference: "dinosaurs(id)", OnDelete: dinosaurRelationship.OnDelete.ReferentialAction()},
+		{Model: "fossils", Dest: "scientists", Field: "scientist_id", Reference: "scientists(id)", OnDelete: scientistRelationship.OnDelete.ReferentialAction()},
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101716001012",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&Fossil{}); err != nil {
+				return err
+			}
+			return db.CreateFK(tx, fks...)
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := db.DropFK(tx, fks...); err != nil {
+				return err
+			}
+			for _, column := range []string{"dinosaur_id", "scientist_id"} {
+				if err := tx.Migrator().DropColumn(&Fossil{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
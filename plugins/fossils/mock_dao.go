@@ -2,6 +2,7 @@ package fossils
 
 import (
 	"context"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -36,7 +37,7 @@ func (d *fossilDaoMock) Replace(ctx context.Context, fossil *Fossil) (*Fossil, e
 	return nil, errors.NotImplemented("Fossil").AsError()
 }
 
-func (d *fossilDaoMock) Delete(ctx context.Context, id string) error {
+func (d *fossilDaoMock) Delete(ctx context.Context, id string, resourceVersions []int64) error {
 	return errors.NotImplemented("Fossil").AsError()
 }
 
@@ -47,3 +48,35 @@ func (d *fossilDaoMock) FindByIDs(ctx context.Context, ids []string) (FossilList
 func (d *fossilDaoMock) All(ctx context.Context) (FossilList, error) {
 	return d.fossils, nil
 }
+
+func (d *fossilDaoMock) FindByDinosaurID(ctx context.Context, dinosaurID string) (FossilList, error) {
+	fossils := FossilList{}
+	for _, fossil := range d.fossils {
+		if fossil.DinosaurID != nil && *fossil.DinosaurID == dinosaurID {
+			fossils = append(fossils, fossil)
+		}
+	}
+	return fossils, nil
+}
+
+func (d *fossilDaoMock) FindByScientistID(ctx context.Context, scientistID string) (FossilList, error) {
+	fossils := FossilList{}
+	for _, fossil := range d.fossils {
+		if fossil.ScientistID != nil && *fossil.ScientistID == scientistID {
+			fossils = append(fossils, fossil)
+		}
+	}
+	return fossils, nil
+}
+
+func (d *fossilDaoMock) Restore(ctx context.Context, id string) (*Fossil, error) {
+	return nil, errors.NotImplemented("Fossil").AsError()
+}
+
+func (d *fossilDaoMock) Purge(ctx context.Context, id string) error {
+	return errors.NotImplemented("Fossil").AsError()
+}
+
+func (d *fossilDaoMock) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	return 0, errors.NotImplemented("Fossil").AsError()
+}
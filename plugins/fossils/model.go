@@ -2,6 +2,9 @@ package fossils
 
 import (
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/validation"
+	"github.com/openshift-online/rh-trex-ai/plugins/dinosaurs"
+	"github.com/openshift-online/rh-trex-ai/plugins/scientists"
 	"gorm.io/gorm"
 )
 
@@ -11,6 +14,12 @@ type Fossil struct {
 	EstimatedAge      *int    `json:"estimated_age"`
 	FossilType        *string `json:"fossil_type"`
 	ExcavatorName     *string `json:"excavator_name"`
+	DinosaurID        *string `json:"dinosaur_id"`
+	ScientistID       *string `json:"scientist_id"`
+
+	// the parents of the foreign keys are only loaded when preloaded
+	Dinosaur  *dinosaurs.Dinosaur   `json:"dinosaur,omitempty"`
+	Scientist *scientists.Scientist `json:"scientist,omitempty"`
 }
 
 type FossilList []*Fossil
@@ -26,6 +35,7 @@ func (l FossilList) Index() FossilIndex {
 
 func (d *Fossil) BeforeCreate(tx *gorm.DB) error {
 	d.ID = api.NewID()
+	d.ResourceVersion = 1
 	return nil
 }
 
@@ -34,4 +44,10 @@ type FossilPatchRequest struct {
 	EstimatedAge      *int    `json:"estimated_age,omitempty"`
 	FossilType        *string `json:"fossil_type,omitempty"`
 	ExcavatorName     *string `json:"excavator_name,omitempty"`
+	DinosaurID        *string `json:"dinosaur_id,omitempty"`
+	ScientistID       *string `json:"scientist_id,omitempty"`
 }
+
+// fossilRules validate the fields of the fossils sent to the REST and gRPC APIs, read from the constraints
+// of the Fossil schema in openapi/openapi.fossils.yaml
+var fossilRules = validation.MustSchemaRules("Fossil")
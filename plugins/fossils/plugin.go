@@ -11,12 +11,31 @@ import (
 	"github.com/openshift-online/rh-trex-ai/pkg/api/presenters"
 	"github.com/openshift-online/rh-trex-ai/pkg/auth"
 	"github.com/openshift-online/rh-trex-ai/pkg/controllers"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
 	"github.com/openshift-online/rh-trex-ai/pkg/environments"
 	"github.com/openshift-online/rh-trex-ai/pkg/registry"
 	pkgserver "github.com/openshift-online/rh-trex-ai/pkg/server"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+	"github.com/openshift-online/rh-trex-ai/plugins/auditevents"
 	"github.com/openshift-online/rh-trex-ai/plugins/events"
 	"github.com/openshift-online/rh-trex-ai/plugins/generic"
+	"github.com/openshift-online/rh-trex-ai/plugins/webhooks"
+)
+
+// authzResource is the resource the routes and RPCs of this Kind are authorized for
+const authzResource = "fossils"
+
+// the relationships of fossils to their parents, see services.Relationship
+var (
+	dinosaurRelationship = &services.Relationship{
+		Parent: "Dinosaurs", ParentTable: "dinosaurs", Child: "Fossils", ChildTable: "fossils",
+		ForeignKey: "dinosaur_id", OnDelete: services.OnDeleteCascade,
+	}
+	scientistRelationship = &services.Relationship{
+		Parent: "Scientists", ParentTable: "scientists", Child: "Fossils", ChildTable: "fossils",
+		ForeignKey: "scientist_id", OnDelete: services.OnDeleteRestrict,
+	}
 )
 
 type ServiceLocator func() FossilService
@@ -27,6 +46,8 @@ func NewServiceLocator(env *environments.Env) ServiceLocator {
 			db.NewAdvisoryLockFactory(env.Database.SessionFactory),
 			NewFossilDao(&env.Database.SessionFactory),
 			events.Service(&env.Services),
+			auditevents.Service(&env.Services),
+			services.NewRelationshipService(dao.NewRelationshipDao(&env.Database.SessionFactory)),
 		)
 	}
 }
@@ -49,16 +70,36 @@ func init() {
 
 	pkgserver.RegisterRoutes("fossils", func(apiV1Router *mux.Router, services pkgserver.ServicesInterface, authMiddleware auth.JWTMiddleware, authzMiddleware auth.AuthorizationMiddleware) {
 		envServices := services.(*environments.Services)
-		fossilHandler := NewFossilHandler(Service(envServices), generic.Service(envServices))
+		fossilHandler := NewFossilHandler(Service(envServices), generic.Service(envServices), pkgserver.EventBrokerFunc(services))
 
 		fossilsRouter := apiV1Router.PathPrefix("/fossils").Subrouter()
-		fossilsRouter.HandleFunc("", fossilHandler.List).Methods(http.MethodGet)
-		fossilsRouter.HandleFunc("/{id}", fossilHandler.Get).Methods(http.MethodGet)
-		fossilsRouter.HandleFunc("", fossilHandler.Create).Methods(http.MethodPost)
-		fossilsRouter.HandleFunc("/{id}", fossilHandler.Patch).Methods(http.MethodPatch)
-		fossilsRouter.HandleFunc("/{id}", fossilHandler.Delete).Methods(http.MethodDelete)
+		pkgserver.RegisterWatch(fossilsRouter, authzMiddleware, authzResource, fossilHandler.Watch)
+		fossilsRouter.HandleFunc("", authzMiddleware.Authorize(auth.ActionList, authzResource, fossilHandler.List)).Methods(http.MethodGet)
+		fossilsRouter.HandleFunc("/{id}", authzMiddleware.Authorize(auth.ActionGet, authzResource, fossilHandler.Get)).Methods(http.MethodGet)
+		fossilsRouter.HandleFunc("", authzMiddleware.Authorize(auth.ActionCreate, authzResource, fossilHandler.Create)).Methods(http.MethodPost)
+		fossilsRouter.HandleFunc("/{id}", authzMiddleware.Authorize(auth.ActionUpdate, authzResource, fossilHandler.Patch)).Methods(http.MethodPatch)
+		fossilsRouter.HandleFunc("/{id}", authzMiddleware.Authorize(auth.ActionDelete, authzResource, fossilHandler.Delete)).Methods(http.MethodDelete)
+		fossilsRouter.HandleFunc("/{id}:restore", authzMiddleware.Authorize("restore", authzResource, fossilHandler.Restore)).Methods(http.MethodPost)
+		fossilsRouter.HandleFunc("/{id}:purge", authzMiddleware.Authorize("purge", authzResource, fossilHandler.Purge)).Methods(http.MethodPost)
 		fossilsRouter.Use(authMiddleware.AuthenticateAccountJWT)
-		fossilsRouter.Use(authzMiddleware.AuthorizeApi)
+		fossilsRouter.Use(pkgserver.RateLimitMiddleware(environments.Environment().RateLimiter, authzResource))
+		fossilsRouter.Use(pkgserver.IdempotencyMiddleware(services))
+
+		// custom methods on the collection, e.g. /fossils:batchCreate, don't fit under the /fossils prefix
+		fossilsBatchRouter := apiV1Router.NewRoute().Subrouter()
+		fossilsBatchRouter.HandleFunc("/fossils:batchCreate", authzMiddleware.Authorize(auth.ActionCreate, authzResource, fossilHandler.BatchCreate)).Methods(http.MethodPost)
+		fossilsBatchRouter.HandleFunc("/fossils:batchPatch", authzMiddleware.Authorize(auth.ActionUpdate, authzResource, fossilHandler.BatchPatch)).Methods(http.MethodPost)
+		fossilsBatchRouter.HandleFunc("/fossils:batchDelete", authzMiddleware.Authorize(auth.ActionDelete, authzResource, fossilHandler.BatchDelete)).Methods(http.MethodPost)
+		fossilsBatchRouter.Use(authMiddleware.AuthenticateAccountJWT)
+		fossilsBatchRouter.Use(pkgserver.RateLimitMiddleware(environments.Environment().RateLimiter, authzResource))
+		fossilsBatchRouter.Use(pkgserver.IdempotencyMiddleware(services))
+
+		// the fossils of a parent, e.g. /dinosaurs/{id}/fossils
+		fossilsNestedRouter := apiV1Router.NewRoute().Subrouter()
+		fossilsNestedRouter.HandleFunc("/dinosaurs/{id}/fossils", authzMiddleware.Authorize(auth.ActionList, authzResource, fossilHandler.ListByDinosaur)).Methods(http.MethodGet)
+		fossilsNestedRouter.HandleFunc("/scientists/{id}/fossils", authzMiddleware.Authorize(auth.ActionList, authzResource, fossilHandler.ListByScientist)).Methods(http.MethodGet)
+		fossilsNestedRouter.Use(authMiddleware.AuthenticateAccountJWT)
+		fossilsNestedRouter.Use(pkgserver.RateLimitMiddleware(environments.Environment().RateLimiter, authzResource))
 	})
 
 	pkgserver.RegisterController("Fossils", func(manager *controllers.KindControllerManager, services pkgserver.ServicesInterface) {
@@ -72,20 +113,45 @@ func init() {
 				api.DeleteEventType: {fossilServices.OnDelete},
 			},
 		})
+		manager.Add(&controllers.ControllerConfig{
+			Source: "Dinosaurs",
+			Handlers: map[api.EventType][]controllers.ControllerHandlerFunc{
+				api.DeleteEventType: {fossilServices.OnDinosaurDelete},
+			},
+		})
+	})
+
+	pkgserver.RegisterPurger("Fossils", func(purger *controllers.PurgeController, services pkgserver.ServicesInterface) {
+		fossilServices := Service(services.(*environments.Services))
+
+		purger.Add(&controllers.PurgeConfig{
+			Kind:      "Fossils",
+			Retention: controllers.DefaultPurgeRetention,
+			Purge:     fossilServices.PurgeDeleted,
+		})
+	})
+
+	webhooks.RegisterKind(&webhooks.KindConfig{
+		Source:         "Fossils",
+		ValidateFilter: validateFossilWatchFilter,
+		Match:          presentWatchedFossil,
 	})
 
 	pkgserver.RegisterGRPCService("fossils", func(grpcServer *grpc.Server, services pkgserver.ServicesInterface) {
 		envServices := services.(*environments.Services)
 		fossilService := Service(envServices)
 		genericService := generic.Service(envServices)
-		brokerFunc := func() *pkgserver.EventBroker {
-			if obj := envServices.GetService("EventBroker"); obj != nil {
-				return obj.(*pkgserver.EventBroker)
-			}
-			return nil
-		}
-		pb.RegisterFossilServiceServer(grpcServer, NewFossilGRPCHandler(fossilService, genericService, brokerFunc))
+		pb.RegisterFossilServiceServer(grpcServer, NewFossilGRPCHandler(fossilService, genericService, pkgserver.EventBrokerFunc(services)))
 	})
+	pkgserver.RegisterGRPCMethodAuthorization(pb.FossilService_GetFossil_FullMethodName, auth.ActionGet, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.FossilService_ListFossils_FullMethodName, auth.ActionList, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.FossilService_CreateFossil_FullMethodName, auth.ActionCreate, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.FossilService_UpdateFossil_FullMethodName, auth.ActionUpdate, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.FossilService_DeleteFossil_FullMethodName, auth.ActionDelete, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.FossilService_WatchFossils_FullMethodName, auth.ActionWatch, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.FossilService_BatchCreateFossils_FullMethodName, auth.ActionCreate, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.FossilService_BatchUpdateFossils_FullMethodName, auth.ActionUpdate, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.FossilService_BatchDeleteFossils_FullMethodName, auth.ActionDelete, authzResource)
 
 	presenters.RegisterPath(Fossil{}, "fossils")
 	presenters.RegisterPath(&Fossil{}, "fossils")
@@ -93,4 +159,10 @@ func init() {
 	presenters.RegisterKind(&Fossil{}, "Fossil")
 
 	db.RegisterMigration(migration())
+	db.RegisterMigration(resourceVersionMigration())
+	db.RegisterMigration(tenancyMigration())
+	db.RegisterMigration(relationshipMigration())
+
+	services.RegisterRelationship(dinosaurRelationship)
+	services.RegisterRelationship(scientistRelationship)
 }
@@ -5,6 +5,8 @@ import (
 	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
 	"github.com/openshift-online/rh-trex-ai/pkg/api/presenters"
 	"github.com/openshift-online/rh-trex-ai/pkg/util"
+	"github.com/openshift-online/rh-trex-ai/plugins/dinosaurs"
+	"github.com/openshift-online/rh-trex-ai/plugins/scientists"
 )
 
 func ConvertFossil(fossil openapi.Fossil) *Fossil {
@@ -19,6 +21,8 @@ func ConvertFossil(fossil openapi.Fossil) *Fossil {
 	}
 	c.FossilType = fossil.FossilType
 	c.ExcavatorName = fossil.ExcavatorName
+	c.DinosaurID = fossil.DinosaurId
+	c.ScientistID = fossil.ScientistId
 
 	if fossil.CreatedAt != nil {
 		c.CreatedAt = *fossil.CreatedAt
@@ -30,12 +34,14 @@ func ConvertFossil(fossil openapi.Fossil) *Fossil {
 
 func PresentFossil(fossil *Fossil) openapi.Fossil {
 	reference := presenters.PresentReference(fossil.ID, fossil)
-	return openapi.Fossil{
+	presented := openapi.Fossil{
 		Id:                reference.Id,
 		Kind:              reference.Kind,
 		Href:              reference.Href,
 		CreatedAt:         openapi.PtrTime(fossil.CreatedAt),
 		UpdatedAt:         openapi.PtrTime(fossil.UpdatedAt),
+		ResourceVersion:   openapi.PtrInt64(fossil.ResourceVersion),
+		DeletedAt:         presenters.PresentDeletedAt(fossil.DeletedAt),
 		DiscoveryLocation: fossil.DiscoveryLocation,
 		EstimatedAge: func() *int32 {
 			if fossil.EstimatedAge != nil {
@@ -45,5 +51,16 @@ func PresentFossil(fossil *Fossil) openapi.Fossil {
 		}(),
 		FossilType:    fossil.FossilType,
 		ExcavatorName: fossil.ExcavatorName,
+		DinosaurId:    fossil.DinosaurID,
+		ScientistId:   fossil.ScientistID,
 	}
+	if fossil.Dinosaur != nil {
+		dinosaur := dinosaurs.PresentDinosaur(fossil.Dinosaur)
+		presented.Dinosaur = &dinosaur
+	}
+	if fossil.Scientist != nil {
+		scientist := scientists.PresentScientist(fossil.Scientist)
+		presented.Scientist = &scientist
+	}
+	return presented
 }
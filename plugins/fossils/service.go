@@ -2,6 +2,10 @@ package fossils
 
 import (
 	"context"
+	e "errors"
+	"time"
+
+	"gorm.io/gorm"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
@@ -16,29 +20,48 @@ type FossilService interface {
 	Get(ctx context.Context, id string) (*Fossil, *errors.ServiceError)
 	Create(ctx context.Context, fossil *Fossil) (*Fossil, *errors.ServiceError)
 	Replace(ctx context.Context, fossil *Fossil) (*Fossil, *errors.ServiceError)
-	Delete(ctx context.Context, id string) *errors.ServiceError
+	// Delete deletes a fossil, only at one of resourceVersions unless they are nil, see handlers.IfMatchVersions
+	Delete(ctx context.Context, id string, resourceVersions []int64) *errors.ServiceError
 	All(ctx context.Context) (FossilList, *errors.ServiceError)
 
 	FindByIDs(ctx context.Context, ids []string) (FossilList, *errors.ServiceError)
+	FindByDinosaurID(ctx context.Context, dinosaurID string) (FossilList, *errors.ServiceError)
+	FindByScientistID(ctx context.Context, scientistID string) (FossilList, *errors.ServiceError)
+
+	Restore(ctx context.Context, id string) (*Fossil, *errors.ServiceError)
+	Purge(ctx context.Context, id string) *errors.ServiceError
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, *errors.ServiceError)
+
+	// BatchCreate creates fossils in place and BatchUpdate loads, updates and replaces the fossils of ids.
+	// Either the whole batch is written or, without allOrNothing, every item that succeeds, see services.RunBatch.
+	BatchCreate(ctx context.Context, fossils FossilList, allOrNothing bool) (services.BatchErrors, *errors.ServiceError)
+	BatchUpdate(ctx context.Context, ids []string, update func(i int, fossil *Fossil) *errors.ServiceError, allOrNothing bool) (FossilList, services.BatchErrors, *errors.ServiceError)
+	BatchDelete(ctx context.Context, ids []string, allOrNothing bool) (services.BatchErrors, *errors.ServiceError)
 
 	OnUpsert(ctx context.Context, id string) error
 	OnDelete(ctx context.Context, id string) error
+	// OnDinosaurDelete deletes the fossils of a deleted dinosaur, see services.OnDeleteCascade
+	OnDinosaurDelete(ctx context.Context, id string) error
 }
 
-func NewFossilService(lockFactory db.LockFactory, fossilDao FossilDao, events services.EventService) FossilService {
+func NewFossilService(lockFactory db.LockFactory, fossilDao FossilDao, events services.EventService, auditEvents services.AuditEventService, relationships services.RelationshipService) FossilService {
 	return &sqlFossilService{
-		lockFactory: lockFactory,
-		fossilDao:   fossilDao,
-		events:      events,
+		lockFactory:   lockFactory,
+		fossilDao:     fossilDao,
+		events:        events,
+		auditEvents:   auditEvents,
+		relationships: relationships,
 	}
 }
 
 var _ FossilService = &sqlFossilService{}
 
 type sqlFossilService struct {
-	lockFactory db.LockFactory
-	fossilDao   FossilDao
-	events      services.EventService
+	lockFactory   db.LockFactory
+	fossilDao     FossilDao
+	events        services.EventService
+	auditEvents   services.AuditEventService
+	relationships services.RelationshipService
 }
 
 func (s *sqlFossilService) OnUpsert(ctx context.Context, id string) error {
@@ -60,6 +83,20 @@ func (s *sqlFossilService) OnDelete(ctx context.Context, id string) error {
 	return nil
 }
 
+func (s *sqlFossilService) OnDinosaurDelete(ctx context.Context, id string) error {
+	fossils, err := s.fossilDao.FindByDinosaurID(ctx, id)
+	if err != nil {
+		return err
+	}
+	for _, fossil := range fossils {
+		// a fossil deleted concurrently is deleted all the same
+		if svcErr := s.Delete(ctx, fossil.ID, nil); svcErr != nil && !svcErr.Is404() {
+			return svcErr.AsError()
+		}
+	}
+	return nil
+}
+
 func (s *sqlFossilService) Get(ctx context.Context, id string) (*Fossil, *errors.ServiceError) {
 	fossil, err := s.fossilDao.Get(ctx, id)
 	if err != nil {
@@ -69,6 +106,9 @@ func (s *sqlFossilService) Get(ctx context.Context, id string) (*Fossil, *errors
 }
 
 func (s *sqlFossilService) Create(ctx context.Context, fossil *Fossil) (*Fossil, *errors.ServiceError) {
+	if err := s.checkParents(ctx, fossil); err != nil {
+		return nil, err
+	}
 	fossil, err := s.fossilDao.Create(ctx, fossil)
 	if err != nil {
 		return nil, services.HandleCreateError("Fossil", err)
@@ -83,6 +123,10 @@ func (s *sqlFossilService) Create(ctx context.Context, fossil *Fossil) (*Fossil,
 		return nil, services.HandleCreateError("Fossil", evErr)
 	}
 
+	if auditErr := s.recordAudit(ctx, api.AuditCreateAction, fossil.ID, nil, fossil); auditErr != nil {
+		return nil, auditErr
+	}
+
 	return fossil, nil
 }
 
@@ -93,6 +137,14 @@ func (s *sqlFossilService) Replace(ctx context.Context, fossil *Fossil) (*Fossil
 	}
 	defer s.lockFactory.Unlock(ctx, lockOwnerID)
 
+	before, err := s.fossilDao.Get(ctx, fossil.ID)
+	if err != nil {
+		return nil, services.HandleGetError("Fossil", "id", fossil.ID, err)
+	}
+	if err := s.checkParents(ctx, fossil); err != nil {
+		return nil, err
+	}
+
 	fossil, err = s.fossilDao.Replace(ctx, fossil)
 	if err != nil {
 		return nil, services.HandleUpdateError("Fossil", err)
@@ -107,23 +159,40 @@ func (s *sqlFossilService) Replace(ctx context.Context, fossil *Fossil) (*Fossil
 		return nil, services.HandleUpdateError("Fossil", evErr)
 	}
 
+	if auditErr := s.recordAudit(ctx, api.AuditUpdateAction, fossil.ID, before, fossil); auditErr != nil {
+		return nil, auditErr
+	}
+
 	return fossil, nil
 }
 
-func (s *sqlFossilService) Delete(ctx context.Context, id string) *errors.ServiceError {
-	if err := s.fossilDao.Delete(ctx, id); err != nil {
-		return services.HandleDeleteError("Fossil", errors.GeneralError("Unable to delete fossil: %s", err))
+func (s *sqlFossilService) Delete(ctx context.Context, id string, resourceVersions []int64) *errors.ServiceError {
+	before, err := s.fossilDao.Get(ctx, id)
+	if err != nil {
+		return services.HandleDeleteError("Fossil", err)
+	}
+	if err := s.relationships.CheckDelete(ctx, "Fossils", id); err != nil {
+		return err
+	}
+	if err := s.fossilDao.Delete(ctx, id, resourceVersions); err != nil {
+		return services.HandleDeleteError("Fossil", err)
 	}
 
 	_, evErr := s.events.Create(ctx, &api.Event{
 		Source:    "Fossils",
 		SourceID:  id,
 		EventType: api.DeleteEventType,
+		// the organization of the fossil rather than of the caller, who may work across organizations
+		Meta: api.Meta{OrganizationID: before.OrganizationID},
 	})
 	if evErr != nil {
 		return services.HandleDeleteError("Fossil", evErr)
 	}
 
+	if auditErr := s.recordAudit(ctx, api.AuditDeleteAction, id, before, nil); auditErr != nil {
+		return auditErr
+	}
+
 	return nil
 }
 
@@ -135,6 +204,22 @@ func (s *sqlFossilService) FindByIDs(ctx context.Context, ids []string) (FossilL
 	return fossils, nil
 }
 
+func (s *sqlFossilService) FindByDinosaurID(ctx context.Context, dinosaurID string) (FossilList, *errors.ServiceError) {
+	fossils, err := s.fossilDao.FindByDinosaurID(ctx, dinosaurID)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to get the fossils of dinosaur '%s': %s", dinosaurID, err)
+	}
+	return fossils, nil
+}
+
+func (s *sqlFossilService) FindByScientistID(ctx context.Context, scientistID string) (FossilList, *errors.ServiceError) {
+	fossils, err := s.fossilDao.FindByScientistID(ctx, scientistID)
+	if err != nil {
+		return nil, errors.GeneralError("Unable to get the fossils of scientist '%s': %s", scientistID, err)
+	}
+	return fossils, nil
+}
+
 func (s *sqlFossilService) All(ctx context.Context) (FossilList, *errors.ServiceError) {
 	fossils, err := s.fossilDao.All(ctx)
 	if err != nil {
@@ -142,3 +227,181 @@ func (s *sqlFossilService) All(ctx context.Context) (FossilList, *errors.Service
 	}
 	return fossils, nil
 }
+
+// Restore undeletes a soft-deleted fossil. Controllers and watchers see it as created again.
+func (s *sqlFossilService) Restore(ctx context.Context, id string) (*Fossil, *errors.ServiceError) {
+	lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, id, fossilsLockType)
+	if err != nil {
+		return nil, errors.DatabaseAdvisoryLock(err)
+	}
+	defer s.lockFactory.Unlock(ctx, lockOwnerID)
+
+	fossil, err := s.fossilDao.Restore(ctx, id)
+	if err != nil {
+		return nil, s.handleNotDeleted(ctx, id, err)
+	}
+	// a fossil can't come back while a parent it refers to is deleted, the parent's purge would take it along
+	if err := s.checkParents(ctx, fossil); err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+
+	_, evErr := s.events.Create(ctx, &api.Event{
+		Source:    "Fossils",
+		SourceID:  fossil.ID,
+		EventType: api.CreateEventType,
+	})
+	if evErr != nil {
+		return nil, services.HandleUpdateError("Fossil", evErr)
+	}
+
+	if auditErr := s.recordAudit(ctx, api.AuditRestoreAction, fossil.ID, nil, fossil); auditErr != nil {
+		return nil, auditErr
+	}
+
+	return fossil, nil
+}
+
+// Purge hard-deletes a soft-deleted fossil, it can't be restored afterwards
+func (s *sqlFossilService) Purge(ctx context.Context, id string) *errors.ServiceError {
+	if err := s.fossilDao.Purge(ctx, id); err != nil {
+		return s.handleNotDeleted(ctx, id, err)
+	}
+	// the state of the fossil was recorded when it was deleted
+	return s.recordAudit(ctx, api.AuditPurgeAction, id, nil, nil)
+}
+
+func (s *sqlFossilService) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, *errors.ServiceError) {
+	purged, err := services.PurgeInBatches(ctx, func(limit int) (int64, error) {
+		return s.fossilDao.PurgeDeleted(ctx, deletedBefore, limit)
+	})
+	if err != nil {
+		return purged, errors.GeneralError("Unable to purge deleted fossils: %s", err)
+	}
+	return purged, nil
+}
+
+func (s *sqlFossilService) BatchCreate(ctx context.Context, fossils FossilList, allOrNothing bool) (services.BatchErrors, *errors.ServiceError) {
+	itemErrs, svcErr := services.RunBatch(ctx, len(fossils), allOrNothing, func(i int) *errors.ServiceError {
+		if err := s.checkParents(ctx, fossils[i]); err != nil {
+			return err
+		}
+		if _, err := s.fossilDao.Create(ctx, fossils[i]); err != nil {
+			return services.HandleCreateError("Fossil", err)
+		}
+		return s.recordAudit(ctx, api.AuditCreateAction, fossils[i].ID, nil, fossils[i])
+	})
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	ids := make([]string, len(fossils))
+	for i, fossil := range fossils {
+		ids[i] = fossil.ID
+	}
+	if _, evErr := s.events.CreateBatch(ctx, services.BatchEvents("Fossils", api.CreateEventType, ids, itemErrs)); evErr != nil {
+		return nil, services.HandleCreateError("Fossil", evErr)
+	}
+	return itemErrs, nil
+}
+
+func (s *sqlFossilService) BatchUpdate(ctx context.Context, ids []string, update func(i int, fossil *Fossil) *errors.ServiceError, allOrNothing bool) (FossilList, services.BatchErrors, *errors.ServiceError) {
+	fossils := make(FossilList, len(ids))
+	itemErrs, svcErr := services.RunBatch(ctx, len(ids), allOrNothing, func(i int) *errors.ServiceError {
+		lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, ids[i], fossilsLockType)
+		if err != nil {
+			return errors.DatabaseAdvisoryLock(err)
+		}
+		defer s.lockFactory.Unlock(ctx, lockOwnerID)
+
+		fossil, svcErr := s.Get(ctx, ids[i])
+		if svcErr != nil {
+			return svcErr
+		}
+		before := *fossil
+		if svcErr := update(i, fossil); svcErr != nil {
+			return svcErr
+		}
+		if svcErr := s.checkParents(ctx, fossil); svcErr != nil {
+			return svcErr
+		}
+		if _, err := s.fossilDao.Replace(ctx, fossil); err != nil {
+			return services.HandleUpdateError("Fossil", err)
+		}
+		fossils[i] = fossil
+		return s.recordAudit(ctx, api.AuditUpdateAction, fossil.ID, &before, fossil)
+	})
+	if svcErr != nil {
+		return nil, nil, svcErr
+	}
+
+	if _, evErr := s.events.CreateBatch(ctx, services.BatchEvents("Fossils", api.UpdateEventType, ids, itemErrs)); evErr != nil {
+		return nil, nil, services.HandleUpdateError("Fossil", evErr)
+	}
+	return fossils, itemErrs, nil
+}
+
+func (s *sqlFossilService) BatchDelete(ctx context.Context, ids []string, allOrNothing bool) (services.BatchErrors, *errors.ServiceError) {
+	itemErrs, svcErr := services.RunBatch(ctx, len(ids), allOrNothing, func(i int) *errors.ServiceError {
+		before, err := s.fossilDao.Get(ctx, ids[i])
+		if err != nil {
+			return services.HandleDeleteError("Fossil", err)
+		}
+		if err := s.relationships.CheckDelete(ctx, "Fossils", ids[i]); err != nil {
+			return err
+		}
+		if err := s.fossilDao.Delete(ctx, ids[i], nil); err != nil {
+			return services.HandleDeleteError("Fossil", err)
+		}
+		return s.recordAudit(ctx, api.AuditDeleteAction, ids[i], before, nil)
+	})
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if _, evErr := s.events.CreateBatch(ctx, services.BatchEvents("Fossils", api.DeleteEventType, ids, itemErrs)); evErr != nil {
+		return nil, services.HandleDeleteError("Fossil", evErr)
+	}
+	return itemErrs, nil
+}
+
+// checkParents fails unless the parents the fossil refers to exist
+func (s *sqlFossilService) checkParents(ctx context.Context, fossil *Fossil) *errors.ServiceError {
+	if fossil.DinosaurID != nil {
+		if err := s.relationships.CheckParent(ctx, "Fossils", "dinosaur_id", *fossil.DinosaurID); err != nil {
+			return err
+		}
+	}
+	if fossil.ScientistID != nil {
+		if err := s.relationships.CheckParent(ctx, "Fossils", "scientist_id", *fossil.ScientistID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordAudit appends a change of the fossil id to the audit trail, before is nil for creates and after for deletes
+func (s *sqlFossilService) recordAudit(ctx context.Context, action api.AuditAction, id string, before, after *Fossil) *errors.ServiceError {
+	auditEvent := &api.AuditEvent{Source: "Fossils", SourceID: id, Action: action}
+	var beforeState, afterState interface{}
+	if before != nil {
+		beforeState = before
+		auditEvent.OrganizationID = before.OrganizationID
+	}
+	if after != nil {
+		afterState = after
+		auditEvent.OrganizationID = after.OrganizationID
+	}
+	return s.auditEvents.Record(ctx, auditEvent, beforeState, afterState)
+}
+
+// handleNotDeleted tells a fossil that isn't deleted apart from one that doesn't exist at all
+func (s *sqlFossilService) handleNotDeleted(ctx context.Context, id string, err error) *errors.ServiceError {
+	if !e.Is(err, gorm.ErrRecordNotFound) {
+		return services.HandleUpdateError("Fossil", err)
+	}
+	if _, getErr := s.fossilDao.Get(ctx, id); getErr == nil {
+		return errors.Conflict("Fossil with id='%s' is not deleted", id)
+	}
+	return services.HandleGetError("Fossil", "id", id, err)
+}
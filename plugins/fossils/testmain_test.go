@@ -6,15 +6,14 @@ import (
 	"runtime"
 	"testing"
 
-	"github.com/golang/glog"
-
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 	"github.com/openshift-online/rh-trex-ai/test"
 )
 
 func TestMain(m *testing.M) {
 	
 	flag.Parse()
-	glog.Infof("Starting fossils integration test using go version %s", runtime.Version())
+	logger.Infof("Starting fossils integration test using go version %s", runtime.Version())
 	helper := test.NewHelper(&testing.T{})
 	exitCode := m.Run()
 	helper.Teardown()
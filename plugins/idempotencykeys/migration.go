@@ -0,0 +1,32 @@
+package idempotencykeys
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func migration() *gormigrate.Migration {
+	type IdempotencyKey struct {
+		ID          string    `gorm:"primaryKey"`
+		CreatedAt   time.Time `gorm:"not null;index"`
+		Scope       string    `gorm:"not null;uniqueIndex:idx_idempotency_keys_scope_key"`
+		Key         string    `gorm:"not null;uniqueIndex:idx_idempotency_keys_scope_key"`
+		RequestHash string    `gorm:"not null"`
+		StatusCode  int       `gorm:"not null"`
+		Header      string
+		Body        []byte
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101713000925",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&IdempotencyKey{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&IdempotencyKey{})
+		},
+	}
+}
@@ -0,0 +1,48 @@
+package idempotencykeys
+
+import (
+	"github.com/openshift-online/rh-trex-ai/pkg/controllers"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/pkg/environments"
+	"github.com/openshift-online/rh-trex-ai/pkg/registry"
+	pkgserver "github.com/openshift-online/rh-trex-ai/pkg/server"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
+
+func NewServiceLocator(env *environments.Env) services.IdempotencyKeyServiceLocator {
+	return func() services.IdempotencyKeyService {
+		return services.NewIdempotencyKeyService(dao.NewIdempotencyKeyDao(&env.Database.SessionFactory), env.Config.Server.IdempotencyKeyTTL)
+	}
+}
+
+// Service helper function to get the idempotency key service from the registry
+func Service(s *environments.Services) services.IdempotencyKeyService {
+	if s == nil {
+		return nil
+	}
+	if obj := s.GetService("IdempotencyKeys"); obj != nil {
+		locator := obj.(services.IdempotencyKeyServiceLocator)
+		return locator()
+	}
+	return nil
+}
+
+func init() {
+	registry.RegisterService("IdempotencyKeys", func(env interface{}) interface{} {
+		return NewServiceLocator(env.(*environments.Env))
+	})
+
+	// expired keys are cleaned up by the purge controller, a key expires once its TTL has passed
+	pkgserver.RegisterPurger("IdempotencyKeys", func(purger *controllers.PurgeController, services pkgserver.ServicesInterface) {
+		idempotencyKeyService := Service(services.(*environments.Services))
+
+		purger.Add(&controllers.PurgeConfig{
+			Kind:      "IdempotencyKeys",
+			Retention: environments.Environment().Config.Server.IdempotencyKeyTTL,
+			Purge:     idempotencyKeyService.PurgeExpired,
+		})
+	})
+
+	db.RegisterMigration(migration())
+}
@@ -0,0 +1,27 @@
+package ratelimits
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+)
+
+func migration() *gormigrate.Migration {
+	type RateLimitBucket struct {
+		Key    string    `gorm:"primaryKey"`
+		Tokens float64   `gorm:"not null"`
+		UsedAt time.Time `gorm:"not null;index"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101722000925",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&RateLimitBucket{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&RateLimitBucket{})
+		},
+	}
+}
@@ -0,0 +1,38 @@
+package ratelimits
+
+import (
+	"context"
+	"time"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/controllers"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/pkg/environments"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/ratelimit"
+	pkgserver "github.com/openshift-online/rh-trex-ai/pkg/server"
+)
+
+func init() {
+	// the buckets of the postgres backend are deleted once they have refilled, see ratelimit.Limiter.Retention
+	pkgserver.RegisterPurger("RateLimitBuckets", func(purger *controllers.PurgeController, services pkgserver.ServicesInterface) {
+		env := environments.Environment()
+		if env.RateLimiter == nil || env.Config.RateLimit.Backend != ratelimit.PostgresBackend {
+			return
+		}
+
+		limiter := env.RateLimiter
+		purger.Add(&controllers.PurgeConfig{
+			Kind:      "RateLimitBuckets",
+			Retention: limiter.Retention(),
+			Purge: func(ctx context.Context, usedBefore time.Time) (int64, *errors.ServiceError) {
+				purged, err := limiter.PurgeIdle(ctx, usedBefore)
+				if err != nil {
+					return 0, errors.GeneralError("Unable to purge rate limit buckets: %s", err)
+				}
+				return purged, nil
+			},
+		})
+	})
+
+	db.RegisterMigration(migration())
+}
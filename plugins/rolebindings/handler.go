@@ -0,0 +1,105 @@
+package rolebindings
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/handlers"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
+
+type roleBindingHandler struct {
+	roleBinding services.RoleBindingService
+	generic     services.GenericService
+}
+
+func NewRoleBindingHandler(roleBinding services.RoleBindingService, generic services.GenericService) *roleBindingHandler {
+	return &roleBindingHandler{
+		roleBinding: roleBinding,
+		generic:     generic,
+	}
+}
+
+func (h roleBindingHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var roleBinding RoleBinding
+	cfg := &handlers.HandlerConfig{
+		Body: &roleBinding,
+		Validators: []handlers.Validate{
+			handlers.ValidateEmpty(&roleBinding, "ID", "id"),
+			handlers.ValidateNotEmpty(&roleBinding, "Username", "username"),
+			handlers.ValidateNotEmpty(&roleBinding, "Role", "role"),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			roleBindingModel, err := h.roleBinding.Create(r.Context(), ConvertRoleBinding(roleBinding))
+			if err != nil {
+				return nil, err
+			}
+			return PresentRoleBinding(roleBindingModel), nil
+		},
+		ErrorHandler: handlers.HandleError,
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusCreated)
+}
+
+func (h roleBindingHandler) Get(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			roleBinding, err := h.roleBinding.Get(r.Context(), id)
+			if err != nil {
+				return nil, err
+			}
+			return PresentRoleBinding(roleBinding), nil
+		},
+	}
+
+	handlers.HandleGet(w, r, cfg)
+}
+
+func (h roleBindingHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := services.NewListArguments(r.URL.Query())
+			var roleBindings []api.RoleBinding
+			paging, err := h.generic.List(r.Context(), listArgs, &roleBindings)
+			if err != nil {
+				return nil, err
+			}
+			roleBindingList := RoleBindingList{
+				Kind:     "RoleBindingList",
+				Page:     int32(paging.Page),
+				Size:     int32(paging.Size),
+				Total:    int32(paging.Total),
+				Continue: paging.Continue,
+				Items:    []RoleBinding{},
+			}
+			for i := range roleBindings {
+				roleBindingList.Items = append(roleBindingList.Items, PresentRoleBinding(&roleBindings[i]))
+			}
+			return roleBindingList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+func (h roleBindingHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			if _, err := h.roleBinding.Get(r.Context(), id); err != nil {
+				return nil, err
+			}
+			if err := h.roleBinding.Delete(r.Context(), id); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		},
+	}
+
+	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
+}
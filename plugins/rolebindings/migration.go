@@ -0,0 +1,49 @@
+package rolebindings
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+)
+
+func migration() *gormigrate.Migration {
+	type RoleBinding struct {
+		db.Model
+		ResourceVersion int64  `gorm:"not null;default:1"`
+		Username        string `gorm:"not null;uniqueIndex:idx_role_bindings_username_role,where:deleted_at IS NULL"`
+		Role            string `gorm:"not null;uniqueIndex:idx_role_bindings_username_role,where:deleted_at IS NULL"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101711000925",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&RoleBinding{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&RoleBinding{})
+		},
+	}
+}
+
+func tenancyMigration() *gormigrate.Migration {
+	type RoleBinding struct {
+		OrganizationID string `gorm:"index"`
+		Owner          string
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101712010925",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&RoleBinding{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, column := range []string{"organization_id", "owner"} {
+				if err := tx.Migrator().DropColumn(&RoleBinding{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
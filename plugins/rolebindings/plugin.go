@@ -0,0 +1,63 @@
+package rolebindings
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/api/presenters"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/pkg/environments"
+	"github.com/openshift-online/rh-trex-ai/pkg/registry"
+	pkgserver "github.com/openshift-online/rh-trex-ai/pkg/server"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+	"github.com/openshift-online/rh-trex-ai/plugins/generic"
+)
+
+func NewServiceLocator(env *environments.Env) services.RoleBindingServiceLocator {
+	return func() services.RoleBindingService {
+		return services.NewRoleBindingService(dao.NewRoleBindingDao(&env.Database.SessionFactory))
+	}
+}
+
+// Service helper function to get the role binding service from the registry
+func Service(s *environments.Services) services.RoleBindingService {
+	if s == nil {
+		return nil
+	}
+	if obj := s.GetService("RoleBindings"); obj != nil {
+		locator := obj.(services.RoleBindingServiceLocator)
+		return locator()
+	}
+	return nil
+}
+
+func init() {
+	registry.RegisterService("RoleBindings", func(env interface{}) interface{} {
+		return NewServiceLocator(env.(*environments.Env))
+	})
+
+	pkgserver.RegisterRoutes("role_bindings", func(apiV1Router *mux.Router, services pkgserver.ServicesInterface, authMiddleware auth.JWTMiddleware, authzMiddleware auth.AuthorizationMiddleware) {
+		envServices := services.(*environments.Services)
+		roleBindingHandler := NewRoleBindingHandler(Service(envServices), generic.Service(envServices))
+
+		roleBindingsRouter := apiV1Router.PathPrefix("/role_bindings").Subrouter()
+		roleBindingsRouter.HandleFunc("", authzMiddleware.Authorize(auth.ActionList, "role_bindings", roleBindingHandler.List)).Methods(http.MethodGet)
+		roleBindingsRouter.HandleFunc("/{id}", authzMiddleware.Authorize(auth.ActionGet, "role_bindings", roleBindingHandler.Get)).Methods(http.MethodGet)
+		roleBindingsRouter.HandleFunc("", authzMiddleware.Authorize(auth.ActionCreate, "role_bindings", roleBindingHandler.Create)).Methods(http.MethodPost)
+		roleBindingsRouter.HandleFunc("/{id}", authzMiddleware.Authorize(auth.ActionDelete, "role_bindings", roleBindingHandler.Delete)).Methods(http.MethodDelete)
+		roleBindingsRouter.Use(authMiddleware.AuthenticateAccountJWT)
+		roleBindingsRouter.Use(pkgserver.RateLimitMiddleware(environments.Environment().RateLimiter, "role_bindings"))
+	})
+
+	presenters.RegisterPath(api.RoleBinding{}, "role_bindings")
+	presenters.RegisterPath(&api.RoleBinding{}, "role_bindings")
+	presenters.RegisterKind(api.RoleBinding{}, "RoleBinding")
+	presenters.RegisterKind(&api.RoleBinding{}, "RoleBinding")
+
+	db.RegisterMigration(migration())
+	db.RegisterMigration(tenancyMigration())
+}
@@ -0,0 +1,48 @@
+package rolebindings
+
+import (
+	"time"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/api/presenters"
+	"github.com/openshift-online/rh-trex-ai/pkg/util"
+)
+
+// RoleBinding is the REST representation of an api.RoleBinding. Role bindings are an operational
+// resource and are not part of the generated openapi client.
+type RoleBinding struct {
+	ID        string     `json:"id,omitempty"`
+	Kind      string     `json:"kind,omitempty"`
+	Href      string     `json:"href,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	Username  string     `json:"username"`
+	Role      string     `json:"role"`
+}
+
+type RoleBindingList struct {
+	Kind     string        `json:"kind"`
+	Page     int32         `json:"page"`
+	Size     int32         `json:"size"`
+	Total    int32         `json:"total"`
+	Continue string        `json:"continue,omitempty"`
+	Items    []RoleBinding `json:"items"`
+}
+
+func ConvertRoleBinding(roleBinding RoleBinding) *api.RoleBinding {
+	return &api.RoleBinding{
+		Username: roleBinding.Username,
+		Role:     roleBinding.Role,
+	}
+}
+
+func PresentRoleBinding(roleBinding *api.RoleBinding) RoleBinding {
+	reference := presenters.PresentReference(roleBinding.ID, roleBinding)
+	return RoleBinding{
+		ID:        util.NilToEmptyString(reference.Id),
+		Kind:      util.NilToEmptyString(reference.Kind),
+		Href:      util.NilToEmptyString(reference.Href),
+		CreatedAt: presenters.PresentTime(roleBinding.CreatedAt),
+		Username:  roleBinding.Username,
+		Role:      roleBinding.Role,
+	}
+}
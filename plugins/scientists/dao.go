@@ -2,20 +2,37 @@ package scientists
 
 import (
 	"context"
+	"time"
 
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
 )
 
+// ScientistDao reads and writes the scientists of the organization of the request in ctx, see dao.TenantScope
 type ScientistDao interface {
 	Get(ctx context.Context, id string) (*Scientist, error)
 	Create(ctx context.Context, scientist *Scientist) (*Scientist, error)
 	Replace(ctx context.Context, scientist *Scientist) (*Scientist, error)
-	Delete(ctx context.Context, id string) error
+	// Delete soft-deletes a scientist, only at one of resourceVersions unless they are nil
+	Delete(ctx context.Context, id string, resourceVersions []int64) error
 	FindByIDs(ctx context.Context, ids []string) (ScientistList, error)
 	All(ctx context.Context) (ScientistList, error)
+
+	// Restore undeletes a soft-deleted scientist
+	Restore(ctx context.Context, id string) (*Scientist, error)
+	// Purge hard-deletes a soft-deleted scientist
+	Purge(ctx context.Context, id string) error
+	// PurgeDeleted hard-deletes at most limit scientists soft-deleted before the cutoff, the oldest first
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error)
+
+	// LinkDinosaur links a scientist to a dinosaur, false if they were linked already
+	LinkDinosaur(ctx context.Context, link *ScientistDinosaur) (bool, error)
+	// UnlinkDinosaur removes the link of a scientist to a dinosaur, gorm.ErrRecordNotFound if there is none
+	UnlinkDinosaur(ctx context.Context, id, dinosaurID string) (*ScientistDinosaur, error)
 }
 
 var _ ScientistDao = &sqlScientistDao{}
@@ -29,7 +46,7 @@ func NewScientistDao(sessionFactory *db.SessionFactory) ScientistDao {
 }
 
 func (d *sqlScientistDao) Get(ctx context.Context, id string) (*Scientist, error) {
-	g2 := (*d.sessionFactory).New(ctx)
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
 	var scientist Scientist
 	if err := g2.Take(&scientist, "id = ?", id).Error; err != nil {
 		return nil, err
@@ -39,6 +56,7 @@ func (d *sqlScientistDao) Get(ctx context.Context, id string) (*Scientist, error
 
 func (d *sqlScientistDao) Create(ctx context.Context, scientist *Scientist) (*Scientist, error) {
 	g2 := (*d.sessionFactory).New(ctx)
+	dao.SetTenancy(ctx, &scientist.Meta)
 	if err := g2.Omit(clause.Associations).Create(scientist).Error; err != nil {
 		db.MarkForRollback(ctx, err)
 		return nil, err
@@ -47,17 +65,17 @@ func (d *sqlScientistDao) Create(ctx context.Context, scientist *Scientist) (*Sc
 }
 
 func (d *sqlScientistDao) Replace(ctx context.Context, scientist *Scientist) (*Scientist, error) {
-	g2 := (*d.sessionFactory).New(ctx)
-	if err := g2.Omit(clause.Associations).Save(scientist).Error; err != nil {
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
+	if err := db.SaveVersioned(g2.Omit(clause.Associations), scientist, &scientist.ResourceVersion); err != nil {
 		db.MarkForRollback(ctx, err)
 		return nil, err
 	}
 	return scientist, nil
 }
 
-func (d *sqlScientistDao) Delete(ctx context.Context, id string) error {
-	g2 := (*d.sessionFactory).New(ctx)
-	if err := g2.Omit(clause.Associations).Delete(&Scientist{Meta: api.Meta{ID: id}}).Error; err != nil {
+func (d *sqlScientistDao) Delete(ctx context.Context, id string, resourceVersions []int64) error {
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
+	if err := db.DeleteVersioned(g2.Omit(clause.Associations), &Scientist{Meta: api.Meta{ID: id}}, resourceVersions); err != nil {
 		db.MarkForRollback(ctx, err)
 		return err
 	}
@@ -65,7 +83,7 @@ func (d *sqlScientistDao) Delete(ctx context.Context, id string) error {
 }
 
 func (d *sqlScientistDao) FindByIDs(ctx context.Context, ids []string) (ScientistList, error) {
-	g2 := (*d.sessionFactory).New(ctx)
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
 	scientists := ScientistList{}
 	if err := g2.Where("id in (?)", ids).Find(&scientists).Error; err != nil {
 		return nil, err
@@ -74,10 +92,71 @@ func (d *sqlScientistDao) FindByIDs(ctx context.Context, ids []string) (Scientis
 }
 
 func (d *sqlScientistDao) All(ctx context.Context) (ScientistList, error) {
-	g2 := (*d.sessionFactory).New(ctx)
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
 	scientists := ScientistList{}
 	if err := g2.Find(&scientists).Error; err != nil {
 		return nil, err
 	}
 	return scientists, nil
 }
+
+func (d *sqlScientistDao) Restore(ctx context.Context, id string) (*Scientist, error) {
+	g2 := (*d.sessionFactory).New(ctx).Unscoped().Scopes(dao.TenantScope(ctx))
+	var scientist Scientist
+	if err := g2.Take(&scientist, "id = ? AND deleted_at IS NOT NULL", id).Error; err != nil {
+		return nil, err
+	}
+	scientist.DeletedAt = gorm.DeletedAt{}
+	if err := db.SaveVersioned(g2.Omit(clause.Associations), &scientist, &scientist.ResourceVersion); err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return &scientist, nil
+}
+
+func (d *sqlScientistDao) Purge(ctx context.Context, id string) error {
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
+	result := g2.Unscoped().Omit(clause.Associations).Where("deleted_at IS NOT NULL").Delete(&Scientist{Meta: api.Meta{ID: id}})
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (d *sqlScientistDao) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	g2 := (*d.sessionFactory).New(ctx).Scopes(dao.TenantScope(ctx))
+	batch := g2.Unscoped().Model(&Scientist{}).Select("id").Where("deleted_at < ?", deletedBefore).Order("deleted_at").Limit(limit)
+	result := (*d.sessionFactory).New(ctx).Unscoped().Omit(clause.Associations).Where("id IN (?)", batch).Delete(&Scientist{})
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+func (d *sqlScientistDao) LinkDinosaur(ctx context.Context, link *ScientistDinosaur) (bool, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	result := g2.Clauses(clause.OnConflict{DoNothing: true}).Create(link)
+	if result.Error != nil {
+		db.MarkForRollback(ctx, result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (d *sqlScientistDao) UnlinkDinosaur(ctx context.Context, id, dinosaurID string) (*ScientistDinosaur, error) {
+	g2 := (*d.sessionFactory).New(ctx)
+	var link ScientistDinosaur
+	if err := g2.Take(&link, "scientist_id = ? AND dinosaur_id = ?", id, dinosaurID).Error; err != nil {
+		return nil, err
+	}
+	if err := g2.Where("scientist_id = ? AND dinosaur_id = ?", id, dinosaurID).Delete(&ScientistDinosaur{}).Error; err != nil {
+		db.MarkForRollback(ctx, err)
+		return nil, err
+	}
+	return &link, nil
+}
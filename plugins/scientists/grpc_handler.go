@@ -2,17 +2,20 @@ package scientists
 
 import (
 	"context"
+	"fmt"
 
-	"github.com/golang/glog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
-	"github.com/openshift-online/rh-trex-ai/pkg/api"
 	pb "github.com/openshift-online/rh-trex-ai/pkg/api/grpc/rh_trex/v1"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 	pkgserver "github.com/openshift-online/rh-trex-ai/pkg/server"
 	"github.com/openshift-online/rh-trex-ai/pkg/server/grpcutil"
 	"github.com/openshift-online/rh-trex-ai/pkg/services"
+	"github.com/openshift-online/rh-trex-ai/pkg/validation"
+	"github.com/openshift-online/rh-trex-ai/plugins/dinosaurs"
 )
 
 type scientistGRPCHandler struct {
@@ -30,63 +33,95 @@ func (h *scientistGRPCHandler) GetScientist(ctx context.Context, req *pb.GetScie
 	if err := grpcutil.ValidateRequiredID(req.Id); err != nil {
 		return nil, err
 	}
+	if err := grpcutil.ValidateReadMask(req.ReadMask, &pb.Scientist{}); err != nil {
+		return nil, err
+	}
 
 	scientist, svcErr := h.service.Get(ctx, req.Id)
 	if svcErr != nil {
 		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
 	}
-	return scientistToProto(scientist), nil
+	result := ScientistToProto(scientist)
+	grpcutil.ApplyReadMask(result, req.ReadMask)
+	return result, nil
 }
 
 func (h *scientistGRPCHandler) CreateScientist(ctx context.Context, req *pb.CreateScientistRequest) (*pb.Scientist, error) {
-	if err := grpcutil.ValidateStringField("name", req.Name, true); err != nil {
-		return nil, err
-	}
-	if err := grpcutil.ValidateStringField("field", req.Field, true); err != nil {
+	if err := validateCreateScientistRequest(req); err != nil {
 		return nil, err
 	}
 
-	scientist := &Scientist{
-		Name:  req.Name,
-		Field: req.Field,
-	}
-	result, svcErr := h.service.Create(ctx, scientist)
+	result, svcErr := h.service.Create(ctx, scientistFromCreateRequest(req))
 	if svcErr != nil {
 		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
 	}
-	return scientistToProto(result), nil
+	return ScientistToProto(result), nil
 }
 
 func (h *scientistGRPCHandler) UpdateScientist(ctx context.Context, req *pb.UpdateScientistRequest) (*pb.Scientist, error) {
-	if err := grpcutil.ValidateRequiredID(req.Id); err != nil {
+	fields, err := validateUpdateScientistRequest(req)
+	if err != nil {
 		return nil, err
 	}
-	if req.Name != nil {
-		if err := grpcutil.ValidateStringField("name", *req.Name, false); err != nil {
-			return nil, err
-		}
-	}
-	if req.Field != nil {
-		if err := grpcutil.ValidateStringField("field", *req.Field, false); err != nil {
-			return nil, err
-		}
-	}
 
 	scientist, svcErr := h.service.Get(ctx, req.Id)
 	if svcErr != nil {
 		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
 	}
-	if req.Name != nil {
-		scientist.Name = *req.Name
+	if err := grpcutil.ValidateResourceVersion(req.ResourceVersion, scientist.ResourceVersion); err != nil {
+		return nil, err
 	}
-	if req.Field != nil {
-		scientist.Field = *req.Field
+	before := ScientistToProto(scientist)
+	if err := grpcutil.ValidateUpdate(scientistRules, req, fields, before); err != nil {
+		return nil, err
 	}
-	result, svcErr := h.service.Replace(ctx, scientist)
+	applyUpdateScientistRequest(scientist, req, fields)
+	changed := grpcutil.ChangedFields(before, ScientistToProto(scientist))
+	// an update that changes nothing doesn't write, bump the resource version or record an event
+	if len(changed) == 0 {
+		return before, nil
+	}
+	result, svcErr := h.service.Replace(services.WithChangedFields(ctx, changed), scientist)
 	if svcErr != nil {
 		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
 	}
-	return scientistToProto(result), nil
+	return ScientistToProto(result), nil
+}
+
+// validateCreateScientistRequest checks the fields of a create request against the rules of the scientists
+func validateCreateScientistRequest(req *pb.CreateScientistRequest) error {
+	return grpcutil.ValidateCreate(scientistRules, req)
+}
+
+func scientistFromCreateRequest(req *pb.CreateScientistRequest) *Scientist {
+	return &Scientist{
+		Name:  req.Name,
+		Field: req.Field,
+	}
+}
+
+// validateUpdateScientistRequest checks the id, the update mask and the fields an update request writes, and
+// returns those fields. Immutable fields are checked once the scientist is found.
+func validateUpdateScientistRequest(req *pb.UpdateScientistRequest) (grpcutil.FieldSet, error) {
+	if err := grpcutil.ValidateRequiredID(req.Id); err != nil {
+		return nil, err
+	}
+	fields, err := grpcutil.UpdatedFields(req, req.UpdateMask)
+	if err != nil {
+		return nil, err
+	}
+	return fields, grpcutil.ValidateUpdate(scientistRules, req, fields, nil)
+}
+
+// applyUpdateScientistRequest sets fields, the fields the update writes, on scientist from req. Those req
+// leaves unset are cleared.
+func applyUpdateScientistRequest(scientist *Scientist, req *pb.UpdateScientistRequest, fields grpcutil.FieldSet) {
+	if fields["name"] {
+		scientist.Name = req.GetName()
+	}
+	if fields["field"] {
+		scientist.Field = req.GetField()
+	}
 }
 
 func (h *scientistGRPCHandler) DeleteScientist(ctx context.Context, req *pb.DeleteScientistRequest) (*pb.DeleteScientistResponse, error) {
@@ -94,7 +129,7 @@ func (h *scientistGRPCHandler) DeleteScientist(ctx context.Context, req *pb.Dele
 		return nil, err
 	}
 
-	svcErr := h.service.Delete(ctx, req.Id)
+	svcErr := h.service.Delete(ctx, req.Id, nil)
 	if svcErr != nil {
 		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
 	}
@@ -102,27 +137,36 @@ func (h *scientistGRPCHandler) DeleteScientist(ctx context.Context, req *pb.Dele
 }
 
 func (h *scientistGRPCHandler) ListScientists(ctx context.Context, req *pb.ListScientistsRequest) (*pb.ListScientistsResponse, error) {
+	if err := grpcutil.ValidateReadMask(req.ReadMask, &pb.Scientist{}); err != nil {
+		return nil, err
+	}
 	page, size := grpcutil.NormalizePagination(req.Page, req.Size)
 
 	listArgs := &services.ListArguments{
-		Page: int(page),
-		Size: int64(size),
+		Page:           int(page),
+		Size:           int64(size),
+		Search:         req.Search,
+		OrderBy:        req.OrderBy,
+		Continue:       req.ContinueToken,
+		SkipCount:      req.SkipCount,
+		IncludeDeleted: req.IncludeDeleted,
 	}
 
 	var scientists []Scientist
-	paging, svcErr := h.generic.List(ctx, "id", listArgs, &scientists)
+	paging, svcErr := h.generic.List(ctx, listArgs, &scientists)
 	if svcErr != nil {
 		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
 	}
 
 	items := make([]*pb.Scientist, len(scientists))
 	for i, d := range scientists {
-		items[i] = scientistToProto(&d)
+		items[i] = ScientistToProto(&d)
+		grpcutil.ApplyReadMask(items[i], req.ReadMask)
 	}
 
 	return &pb.ListScientistsResponse{
 		Items:    items,
-		Metadata: &pb.ListMeta{Page: page, Size: size, Total: int32(paging.Total)},
+		Metadata: &pb.ListMeta{Page: page, Size: size, Total: int32(paging.Total), ContinueToken: paging.Continue},
 	}, nil
 }
 
@@ -133,44 +177,204 @@ func (h *scientistGRPCHandler) WatchScientists(req *pb.WatchScientistsRequest, s
 	}
 
 	ctx := stream.Context()
-	sub, err := broker.Subscribe(ctx)
-	if err != nil {
-		return status.Errorf(codes.Unavailable, "failed to subscribe: %v", err)
-	}
-	glog.V(4).Infof("WatchScientists: subscriber %s connected", sub.ID)
-
-	for {
-		select {
-		case <-ctx.Done():
-			glog.V(4).Infof("WatchScientists: subscriber %s disconnected", sub.ID)
-			return nil
-		case evt, ok := <-sub.Events:
-			if !ok {
-				return nil
-			}
-
-			if evt.Source != "Scientists" {
-				continue
-			}
-
-			watchEvent := &pb.ScientistWatchEvent{
-				Type:       grpcutil.APIEventTypeToProto(evt.EventType),
-				ResourceId: evt.SourceID,
-			}
-
-			if evt.EventType != api.DeleteEventType {
-				scientist, svcErr := h.service.Get(ctx, evt.SourceID)
-				if svcErr != nil {
-					glog.Warningf("WatchScientists: failed to load scientist %s: %v", evt.SourceID, svcErr)
-					continue
-				}
-				watchEvent.Scientist = scientistToProto(scientist)
-			}
-
-			if err := stream.Send(watchEvent); err != nil {
-				glog.V(4).Infof("WatchScientists: send error for subscriber %s: %v", sub.ID, err)
-				return err
-			}
+	if svcErr := validateScientistWatchFilter(ctx, h.generic, req.Filter); svcErr != nil {
+		return grpcutil.ServiceErrorToGRPC(svcErr)
+	}
+	from := pkgserver.ResumePoint{EventID: req.ResumeFrom, EventSeq: req.EventSeq}
+	logger.V(4).Infof("WatchScientists: watch started, resuming from %+v", from)
+
+	return broker.ServeWatch(ctx, "Scientists", from, func(evt *pkgserver.BrokerEvent) error {
+		watchEvent, err := h.scientistWatchEvent(ctx, evt, req.Filter)
+		if err != nil || watchEvent == nil {
+			return err
+		}
+		return stream.Send(watchEvent)
+	}, func(resumeFrom string, eventSeq int64) error {
+		logger.V(4).Infof("WatchScientists: resync required, resume from %q", resumeFrom)
+		return stream.Send(&pb.ScientistWatchEvent{Type: pb.EventType_EVENT_TYPE_RESYNC_REQUIRED, EventId: resumeFrom, EventSeq: eventSeq})
+	})
+}
+
+// scientistWatchEvent returns the watch event of evt, or nil if the scientist doesn't match filter or isn't
+// visible to the caller
+func (h *scientistGRPCHandler) scientistWatchEvent(ctx context.Context, evt *pkgserver.BrokerEvent, filter string) (*pb.ScientistWatchEvent, error) {
+	scientist, ok, svcErr := watchedScientist(ctx, h.generic, evt, filter)
+	if svcErr != nil {
+		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	watchEvent := &pb.ScientistWatchEvent{
+		Type:          grpcutil.APIEventTypeToProto(evt.EventType),
+		ResourceId:    evt.SourceID,
+		EventId:       evt.EventID,
+		EventSeq:      evt.Seq,
+		ChangedFields: evt.ChangedFields,
+	}
+	if scientist != nil {
+		watchEvent.Scientist = ScientistToProto(scientist)
+	}
+	return watchEvent, nil
+}
+
+func (h *scientistGRPCHandler) BatchCreateScientists(ctx context.Context, req *pb.BatchCreateScientistsRequest) (*pb.ScientistBatchResponse, error) {
+	if err := grpcutil.ValidateBatchSize("requests", len(req.Requests)); err != nil {
+		return nil, err
+	}
+	scientists := make(ScientistList, len(req.Requests))
+	for i, item := range req.Requests {
+		if err := grpcutil.ValidateBatchItem("requests", i, validateCreateScientistRequest(item)); err != nil {
+			return nil, err
+		}
+		scientists[i] = scientistFromCreateRequest(item)
+	}
+
+	itemErrs, svcErr := h.service.BatchCreate(ctx, scientists, req.AllOrNothing)
+	if svcErr != nil {
+		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
+	}
+	results := make([]*pb.ScientistBatchResult, len(scientists))
+	for i, scientist := range scientists {
+		if itemErrs[i] != nil {
+			results[i] = &pb.ScientistBatchResult{Error: grpcutil.BatchItemErrorToProto(itemErrs[i])}
+			continue
+		}
+		results[i] = &pb.ScientistBatchResult{Id: scientist.ID, Scientist: ScientistToProto(scientist)}
+	}
+	return &pb.ScientistBatchResponse{Results: results}, nil
+}
+
+func (h *scientistGRPCHandler) BatchUpdateScientists(ctx context.Context, req *pb.BatchUpdateScientistsRequest) (*pb.ScientistBatchResponse, error) {
+	if err := grpcutil.ValidateBatchSize("requests", len(req.Requests)); err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(req.Requests))
+	fields := make([]grpcutil.FieldSet, len(req.Requests))
+	for i, item := range req.Requests {
+		itemFields, err := validateUpdateScientistRequest(item)
+		if err := grpcutil.ValidateBatchItem("requests", i, err); err != nil {
+			return nil, err
+		}
+		ids[i], fields[i] = item.Id, itemFields
+	}
+
+	scientists, itemErrs, svcErr := h.service.BatchUpdate(ctx, ids, func(i int, scientist *Scientist) *errors.ServiceError {
+		if err := services.ValidateResourceVersion(req.Requests[i].ResourceVersion, scientist.ResourceVersion); err != nil {
+			return err
+		}
+		if err := scientistRules.ValidateUpdate(fields[i].Values(req.Requests[i]), validation.ProtoFieldsOf(ScientistToProto(scientist))); err != nil {
+			return err
 		}
+		applyUpdateScientistRequest(scientist, req.Requests[i], fields[i])
+		return nil
+	}, req.AllOrNothing)
+	if svcErr != nil {
+		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
+	}
+	results := make([]*pb.ScientistBatchResult, len(ids))
+	for i, id := range ids {
+		if itemErrs[i] != nil {
+			results[i] = &pb.ScientistBatchResult{Id: id, Error: grpcutil.BatchItemErrorToProto(itemErrs[i])}
+			continue
+		}
+		results[i] = &pb.ScientistBatchResult{Id: id, Scientist: ScientistToProto(scientists[i])}
+	}
+	return &pb.ScientistBatchResponse{Results: results}, nil
+}
+
+func (h *scientistGRPCHandler) BatchDeleteScientists(ctx context.Context, req *pb.BatchDeleteScientistsRequest) (*pb.ScientistBatchResponse, error) {
+	if err := grpcutil.ValidateBatchSize("ids", len(req.Ids)); err != nil {
+		return nil, err
 	}
+	for i, id := range req.Ids {
+		if err := grpcutil.ValidateBatchItem("ids", i, grpcutil.ValidateRequiredID(id)); err != nil {
+			return nil, err
+		}
+	}
+
+	itemErrs, svcErr := h.service.BatchDelete(ctx, req.Ids, req.AllOrNothing)
+	if svcErr != nil {
+		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
+	}
+	results := make([]*pb.ScientistBatchResult, len(req.Ids))
+	for i, id := range req.Ids {
+		results[i] = &pb.ScientistBatchResult{Id: id, Error: grpcutil.BatchItemErrorToProto(itemErrs[i])}
+	}
+	return &pb.ScientistBatchResponse{Results: results}, nil
+}
+
+// validateLinkRequest checks the ids of the scientist and the linked resource of a link or unlink request
+func validateLinkRequest(id, linkedID, linkedKey string) error {
+	if err := grpcutil.ValidateRequiredID(id); err != nil {
+		return err
+	}
+	return grpcutil.ValidateStringField(linkedKey, linkedID, true)
+}
+
+func (h *scientistGRPCHandler) LinkScientistDinosaur(ctx context.Context, req *pb.LinkScientistDinosaurRequest) (*pb.LinkScientistDinosaurResponse, error) {
+	if err := validateLinkRequest(req.Id, req.DinosaurId, "dinosaur_id"); err != nil {
+		return nil, err
+	}
+
+	if svcErr := h.service.LinkDinosaur(ctx, req.Id, req.DinosaurId); svcErr != nil {
+		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
+	}
+	return &pb.LinkScientistDinosaurResponse{}, nil
+}
+
+func (h *scientistGRPCHandler) UnlinkScientistDinosaur(ctx context.Context, req *pb.UnlinkScientistDinosaurRequest) (*pb.UnlinkScientistDinosaurResponse, error) {
+	if err := validateLinkRequest(req.Id, req.DinosaurId, "dinosaur_id"); err != nil {
+		return nil, err
+	}
+
+	if svcErr := h.service.UnlinkDinosaur(ctx, req.Id, req.DinosaurId); svcErr != nil {
+		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
+	}
+	return &pb.UnlinkScientistDinosaurResponse{}, nil
+}
+
+func (h *scientistGRPCHandler) ListScientistDinosaurs(ctx context.Context, req *pb.ListScientistDinosaursRequest) (*pb.ListDinosaursResponse, error) {
+	if err := grpcutil.ValidateRequiredID(req.Id); err != nil {
+		return nil, err
+	}
+	if err := grpcutil.ValidateReadMask(req.ReadMask, &pb.Dinosaur{}); err != nil {
+		return nil, err
+	}
+	if _, svcErr := h.service.Get(ctx, req.Id); svcErr != nil {
+		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
+	}
+	page, size := grpcutil.NormalizePagination(req.Page, req.Size)
+
+	search := fmt.Sprintf("scientists.id = '%s'", req.Id)
+	if req.Search != "" {
+		search = fmt.Sprintf("%s and (%s)", search, req.Search)
+	}
+	listArgs := &services.ListArguments{
+		Page:           int(page),
+		Size:           int64(size),
+		Search:         search,
+		OrderBy:        req.OrderBy,
+		Continue:       req.ContinueToken,
+		SkipCount:      req.SkipCount,
+		IncludeDeleted: req.IncludeDeleted,
+	}
+
+	var linked []dinosaurs.Dinosaur
+	paging, svcErr := h.generic.List(ctx, listArgs, &linked)
+	if svcErr != nil {
+		return nil, grpcutil.ServiceErrorToGRPC(svcErr)
+	}
+
+	items := make([]*pb.Dinosaur, len(linked))
+	for i, d := range linked {
+		items[i] = dinosaurs.DinosaurToProto(&d)
+		grpcutil.ApplyReadMask(items[i], req.ReadMask)
+	}
+
+	return &pb.ListDinosaursResponse{
+		Items:    items,
+		Metadata: &pb.ListMeta{Page: page, Size: size, Total: int32(paging.Total), ContinueToken: paging.Continue},
+	}, nil
 }
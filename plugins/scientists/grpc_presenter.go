@@ -2,17 +2,21 @@ package scientists
 
 import (
 	pb "github.com/openshift-online/rh-trex-ai/pkg/api/grpc/rh_trex/v1"
+	"github.com/openshift-online/rh-trex-ai/pkg/server/grpcutil"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func scientistToProto(d *Scientist) *pb.Scientist {
+// ScientistToProto converts a Scientist to its protobuf message
+func ScientistToProto(d *Scientist) *pb.Scientist {
 	return &pb.Scientist{
 		Metadata: &pb.ObjectReference{
-			Id:        d.ID,
-			CreatedAt: timestamppb.New(d.CreatedAt),
-			UpdatedAt: timestamppb.New(d.UpdatedAt),
-			Kind:      "Scientist",
-			Href:      "/api/rh-trex-ai/v1/scientists/" + d.ID,
+			Id:              d.ID,
+			CreatedAt:       timestamppb.New(d.CreatedAt),
+			UpdatedAt:       timestamppb.New(d.UpdatedAt),
+			Kind:            "Scientist",
+			Href:            "/api/rh-trex-ai/v1/scientists/" + d.ID,
+			ResourceVersion: d.ResourceVersion,
+			DeletedAt:       grpcutil.DeletedAtToProto(d.DeletedAt),
 		},
 		Name:  d.Name,
 		Field: d.Field,
@@ -1,28 +1,39 @@
 package scientists
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"reflect"
 
 	"github.com/gorilla/mux"
 
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
 	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
 	"github.com/openshift-online/rh-trex-ai/pkg/api/presenters"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
 	"github.com/openshift-online/rh-trex-ai/pkg/errors"
 	"github.com/openshift-online/rh-trex-ai/pkg/handlers"
+	pkgserver "github.com/openshift-online/rh-trex-ai/pkg/server"
 	"github.com/openshift-online/rh-trex-ai/pkg/services"
+	"github.com/openshift-online/rh-trex-ai/pkg/validation"
+	"github.com/openshift-online/rh-trex-ai/plugins/dinosaurs"
 )
 
 var _ handlers.RestHandler = scientistHandler{}
 
 type scientistHandler struct {
-	scientist ScientistService
-	generic   services.GenericService
+	scientist  ScientistService
+	generic    services.GenericService
+	brokerFunc func() *pkgserver.EventBroker
 }
 
-func NewScientistHandler(scientist ScientistService, generic services.GenericService) *scientistHandler {
+func NewScientistHandler(scientist ScientistService, generic services.GenericService, brokerFunc func() *pkgserver.EventBroker) *scientistHandler {
 	return &scientistHandler{
-		scientist: scientist,
-		generic:   generic,
+		scientist:  scientist,
+		generic:    generic,
+		brokerFunc: brokerFunc,
 	}
 }
 
@@ -32,6 +43,7 @@ func (h scientistHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Body: &scientist,
 		Validators: []handlers.Validate{
 			handlers.ValidateEmpty(&scientist, "Id", "id"),
+			handlers.ValidateCreate(scientistRules, &scientist),
 		},
 		Action: func() (interface{}, *errors.ServiceError) {
 			ctx := r.Context()
@@ -40,6 +52,7 @@ func (h scientistHandler) Create(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				return nil, err
 			}
+			handlers.SetETag(w, scientistModel.ResourceVersion)
 			return PresentScientist(scientistModel), nil
 		},
 		ErrorHandler: handlers.HandleError,
@@ -48,31 +61,41 @@ func (h scientistHandler) Create(w http.ResponseWriter, r *http.Request) {
 	handlers.Handle(w, r, cfg, http.StatusCreated)
 }
 
+// Patch applies a partial scientist, a JSON Merge Patch or a JSON Patch, by the Content-Type of the request
 func (h scientistHandler) Patch(w http.ResponseWriter, r *http.Request) {
-	var patch openapi.ScientistPatchRequest
+	var patch json.RawMessage
 
 	cfg := &handlers.HandlerConfig{
-		Body:       &patch,
-		Validators: []handlers.Validate{},
+		Body: &patch,
 		Action: func() (interface{}, *errors.ServiceError) {
 			ctx := r.Context()
+			format, err := handlers.PatchFormatOf(r)
+			if err != nil {
+				return nil, err
+			}
 			id := mux.Vars(r)["id"]
 			found, err := h.scientist.Get(ctx, id)
 			if err != nil {
 				return nil, err
 			}
-
-			if patch.Name != nil {
-				found.Name = *patch.Name
+			if err := handlers.ValidateIfMatch(r, found.ResourceVersion); err != nil {
+				return nil, err
 			}
-			if patch.Field != nil {
-				found.Field = *patch.Field
+			patched, changed, err := patchScientist(format, patch, found)
+			if err != nil {
+				return nil, err
 			}
 
-			scientistModel, err := h.scientist.Replace(ctx, found)
+			// a patch that changes nothing doesn't write, bump the resource version or record an event
+			if len(changed) == 0 {
+				handlers.SetETag(w, found.ResourceVersion)
+				return PresentScientist(found), nil
+			}
+			scientistModel, err := h.scientist.Replace(services.WithChangedFields(ctx, changed), patched)
 			if err != nil {
 				return nil, err
 			}
+			handlers.SetETag(w, scientistModel.ResourceVersion)
 			return PresentScientist(scientistModel), nil
 		},
 		ErrorHandler: handlers.HandleError,
@@ -82,13 +105,126 @@ func (h scientistHandler) Patch(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h scientistHandler) List(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, "", nil)
+}
+
+// ListByDinosaur lists the scientists linked to the dinosaur of the path, /dinosaurs/{id}/scientists
+func (h scientistHandler) ListByDinosaur(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, "dinosaurs.id", func(ctx context.Context, id string) *errors.ServiceError {
+		return h.findParent(ctx, "Dinosaur", id, &[]dinosaurs.Dinosaur{})
+	})
+}
+
+// ListDinosaurs lists the dinosaurs linked to the scientist of the path, /scientists/{id}/dinosaurs
+func (h scientistHandler) ListDinosaurs(w http.ResponseWriter, r *http.Request) {
 	cfg := &handlers.HandlerConfig{
 		Action: func() (interface{}, *errors.ServiceError) {
 			ctx := r.Context()
+			id := mux.Vars(r)["id"]
+			if _, err := h.scientist.Get(ctx, id); err != nil {
+				return nil, err
+			}
 
 			listArgs := services.NewListArguments(r.URL.Query())
+			search := fmt.Sprintf("scientists.id = '%s'", id)
+			if listArgs.Search != "" {
+				search = fmt.Sprintf("%s and (%s)", search, listArgs.Search)
+			}
+			listArgs.Search = search
+			var linked []dinosaurs.Dinosaur
+			paging, err := h.generic.List(ctx, listArgs, &linked)
+			if err != nil {
+				return nil, err
+			}
+			dinosaurList := openapi.DinosaurList{
+				Kind:  "DinosaurList",
+				Page:  int32(paging.Page),
+				Size:  int32(paging.Size),
+				Total: int32(paging.Total),
+				Items: []openapi.Dinosaur{},
+			}
+			if paging.Continue != "" {
+				dinosaurList.Continue = openapi.PtrString(paging.Continue)
+			}
+			for _, dinosaur := range linked {
+				dinosaurList.Items = append(dinosaurList.Items, dinosaurs.PresentDinosaur(&dinosaur))
+			}
+			if listArgs.Fields != nil {
+				filteredItems, err := presenters.SliceFilter(listArgs.Fields, dinosaurList.Items)
+				if err != nil {
+					return nil, err
+				}
+				return filteredItems, nil
+			}
+			return dinosaurList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+// LinkDinosaur links the scientist and the dinosaur of the path, /scientists/{id}/dinosaurs/{dinosaur_id}
+func (h scientistHandler) LinkDinosaur(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			vars := mux.Vars(r)
+			if err := h.scientist.LinkDinosaur(r.Context(), vars["id"], vars["dinosaur_id"]); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		},
+	}
+	handlers.HandleWithoutBody(w, r, cfg, http.StatusNoContent)
+}
+
+// UnlinkDinosaur removes the link of the scientist and the dinosaur of the path
+func (h scientistHandler) UnlinkDinosaur(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			vars := mux.Vars(r)
+			if err := h.scientist.UnlinkDinosaur(r.Context(), vars["id"], vars["dinosaur_id"]); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		},
+	}
+	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
+}
+
+// findParent fails with a 404 unless the parent of a nested list exists and is visible to the caller.
+// found is a pointer to an empty slice of the parent Kind.
+func (h scientistHandler) findParent(ctx context.Context, kind, id string, found interface{}) *errors.ServiceError {
+	listArgs := &services.ListArguments{Page: 1, Size: 1, Search: fmt.Sprintf("id = '%s'", id), SkipCount: true}
+	if _, err := h.generic.List(ctx, listArgs, found); err != nil {
+		return err
+	}
+	if reflect.ValueOf(found).Elem().Len() == 0 {
+		return errors.NotFound("%s with id='%s' not found", kind, id)
+	}
+	return nil
+}
+
+// list lists the scientists of the request. Nested lists are scoped to the parent of the path, by the
+// foreignKey column, once checkParent found it.
+func (h scientistHandler) list(w http.ResponseWriter, r *http.Request, foreignKey string, checkParent func(ctx context.Context, id string) *errors.ServiceError) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+
+			listArgs := services.NewListArguments(r.URL.Query())
+			if checkParent != nil {
+				id := mux.Vars(r)["id"]
+				if err := checkParent(ctx, id); err != nil {
+					return nil, err
+				}
+				search := fmt.Sprintf("%s = '%s'", foreignKey, id)
+				if listArgs.Search != "" {
+					search = fmt.Sprintf("%s and (%s)", search, listArgs.Search)
+				}
+				listArgs.Search = search
+			}
 			var scientists []Scientist
-			paging, err := h.generic.List(ctx, "id", listArgs, &scientists)
+			paging, err := h.generic.List(ctx, listArgs, &scientists)
 			if err != nil {
 				return nil, err
 			}
@@ -99,6 +235,9 @@ func (h scientistHandler) List(w http.ResponseWriter, r *http.Request) {
 				Total: int32(paging.Total),
 				Items: []openapi.Scientist{},
 			}
+			if paging.Continue != "" {
+				scientistList.Continue = openapi.PtrString(paging.Continue)
+			}
 
 			for _, scientist := range scientists {
 				converted := PresentScientist(&scientist)
@@ -118,6 +257,91 @@ func (h scientistHandler) List(w http.ResponseWriter, r *http.Request) {
 	handlers.HandleList(w, r, cfg)
 }
 
+// Watch streams the events of the scientists that match the search parameter as Server-Sent Events,
+// resuming after the Last-Event-ID header if set
+func (h scientistHandler) Watch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	broker := h.brokerFunc()
+	if broker == nil {
+		handlers.HandleError(ctx, w, errors.GeneralError("Event broker not available"))
+		return
+	}
+	filter := r.URL.Query().Get("search")
+	if err := validateScientistWatchFilter(ctx, h.generic, filter); err != nil {
+		handlers.HandleError(ctx, w, err)
+		return
+	}
+
+	broker.ServeSSE(w, r, "Scientists", func(ctx context.Context, evt *pkgserver.BrokerEvent) (interface{}, bool, *errors.ServiceError) {
+		scientist, ok, err := watchedScientist(ctx, h.generic, evt, filter)
+		if err != nil || scientist == nil {
+			return nil, ok, err
+		}
+		return PresentScientist(scientist), true, nil
+	})
+}
+
+// validateScientistWatchFilter fails a watch filter that isn't a valid search right away, rather than
+// with the first event
+func validateScientistWatchFilter(ctx context.Context, generic services.GenericService, filter string) *errors.ServiceError {
+	if filter == "" {
+		return nil
+	}
+	var scientists []Scientist
+	_, err := generic.List(ctx, &services.ListArguments{Page: 1, Size: 1, Search: filter, SkipCount: true}, &scientists)
+	return err
+}
+
+// watchedScientist returns the scientist of a watch event, and whether the event is sent at all: events of
+// scientists that don't match filter or aren't visible to the caller are skipped. Deleted scientists are
+// matched on their last state, no scientist is returned for deletes.
+func watchedScientist(ctx context.Context, generic services.GenericService, evt *pkgserver.BrokerEvent, filter string) (*Scientist, bool, *errors.ServiceError) {
+	if filter == "" {
+		return unfilteredWatchedScientist(ctx, generic, evt)
+	}
+	var scientists []Scientist
+	search := fmt.Sprintf("id = '%s' and (%s)", evt.SourceID, filter)
+	listArgs := &services.ListArguments{Page: 1, Size: 1, Search: search, SkipCount: true, IncludeDeleted: true}
+	if _, err := generic.List(ctx, listArgs, &scientists); err != nil || len(scientists) == 0 {
+		return nil, false, err
+	}
+	if evt.EventType == api.DeleteEventType {
+		return nil, true, nil
+	}
+	return &scientists[0], true, nil
+}
+
+// unfilteredWatchedScientist is watchedScientist for watches without a filter. Deletes are sent without reading the
+// scientist, and the scientist of other events is read once for every watcher of the event.
+func unfilteredWatchedScientist(ctx context.Context, generic services.GenericService, evt *pkgserver.BrokerEvent) (*Scientist, bool, *errors.ServiceError) {
+	if evt.EventType == api.DeleteEventType {
+		return nil, evt.VisibleTo(ctx), nil
+	}
+	loaded, err := evt.Resource(ctx, func(ctx context.Context) (interface{}, *errors.ServiceError) {
+		var scientists []Scientist
+		listArgs := &services.ListArguments{Page: 1, Size: 1, Search: fmt.Sprintf("id = '%s'", evt.SourceID), SkipCount: true, IncludeDeleted: true}
+		if _, err := generic.List(ctx, listArgs, &scientists); err != nil || len(scientists) == 0 {
+			return nil, err
+		}
+		return &scientists[0], nil
+	})
+	scientist, _ := loaded.(*Scientist)
+	if err != nil || scientist == nil {
+		// purged since
+		return nil, false, err
+	}
+	return scientist, auth.InTenantScope(ctx, scientist.OrganizationID), nil
+}
+
+// presentWatchedScientist is watchedScientist for consumers of presented scientists, e.g. webhooks
+func presentWatchedScientist(ctx context.Context, generic services.GenericService, evt *pkgserver.BrokerEvent, filter string) (interface{}, bool, *errors.ServiceError) {
+	scientist, matched, err := watchedScientist(ctx, generic, evt, filter)
+	if scientist == nil {
+		return nil, matched, err
+	}
+	return PresentScientist(scientist), matched, err
+}
+
 func (h scientistHandler) Get(w http.ResponseWriter, r *http.Request) {
 	cfg := &handlers.HandlerConfig{
 		Action: func() (interface{}, *errors.ServiceError) {
@@ -127,6 +351,7 @@ func (h scientistHandler) Get(w http.ResponseWriter, r *http.Request) {
 			if err != nil {
 				return nil, err
 			}
+			handlers.SetETag(w, scientist.ResourceVersion)
 
 			return PresentScientist(scientist), nil
 		},
@@ -140,7 +365,12 @@ func (h scientistHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		Action: func() (interface{}, *errors.ServiceError) {
 			id := mux.Vars(r)["id"]
 			ctx := r.Context()
-			err := h.scientist.Delete(ctx, id)
+			// the If-Match versions are checked by the DELETE itself, not by a read before it
+			resourceVersions, err := handlers.IfMatchVersions(r)
+			if err != nil {
+				return nil, err
+			}
+			err = h.scientist.Delete(ctx, id, resourceVersions)
 			if err != nil {
 				return nil, err
 			}
@@ -149,3 +379,189 @@ func (h scientistHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
 }
+
+// Restore undeletes a soft-deleted scientist
+func (h scientistHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			scientist, err := h.scientist.Restore(r.Context(), id)
+			if err != nil {
+				return nil, err
+			}
+			handlers.SetETag(w, scientist.ResourceVersion)
+			return PresentScientist(scientist), nil
+		},
+	}
+	handlers.HandleWithoutBody(w, r, cfg, http.StatusOK)
+}
+
+// Purge hard-deletes a soft-deleted scientist before its retention has passed
+func (h scientistHandler) Purge(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			if err := h.scientist.Purge(r.Context(), id); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		},
+	}
+	handlers.HandleWithoutBody(w, r, cfg, http.StatusNoContent)
+}
+
+// patchScientist applies patch, the body of a PATCH request in format, to scientist. It returns the patched
+// scientist and the fields the patch changed.
+func patchScientist(format handlers.PatchFormat, patch []byte, scientist *Scientist) (*Scientist, []string, *errors.ServiceError) {
+	var patched openapi.Scientist
+	changed, err := handlers.ApplyPatch(format, patch, scientistRules, PresentScientist(scientist), &patched, openapi.ScientistPatchRequest{})
+	if err != nil {
+		return nil, nil, err
+	}
+	result := ConvertScientist(patched)
+	result.Meta = scientist.Meta
+	return result, changed, nil
+}
+
+type scientistBatchCreateRequest struct {
+	Items        []openapi.Scientist `json:"items"`
+	AllOrNothing bool                `json:"all_or_nothing"`
+}
+
+type scientistBatchPatchItem struct {
+	ID              string                        `json:"id"`
+	ResourceVersion *int64                        `json:"resource_version,omitempty"`
+	Patch           openapi.ScientistPatchRequest `json:"patch"`
+}
+
+type scientistBatchPatchRequest struct {
+	Items        []scientistBatchPatchItem `json:"items"`
+	AllOrNothing bool                      `json:"all_or_nothing"`
+}
+
+// BatchCreate creates every scientist of the request in one transaction
+func (h scientistHandler) BatchCreate(w http.ResponseWriter, r *http.Request) {
+	var batch scientistBatchCreateRequest
+	cfg := &handlers.HandlerConfig{
+		Body: &batch,
+		Validators: []handlers.Validate{
+			handlers.ValidateBatchSize(&batch, "Items", "items"),
+			func() *errors.ServiceError {
+				for i := range batch.Items {
+					if batch.Items[i].Id != nil {
+						return errors.Validation("items[%d].id must be empty", i)
+					}
+					if err := scientistRules.ValidateCreate(validation.FieldsOf(batch.Items[i])); err != nil {
+						return validation.ItemError("items", i, err)
+					}
+				}
+				return nil
+			},
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			scientists := make(ScientistList, len(batch.Items))
+			for i := range batch.Items {
+				scientists[i] = ConvertScientist(batch.Items[i])
+			}
+			itemErrs, err := h.scientist.BatchCreate(ctx, scientists, batch.AllOrNothing)
+			if err != nil {
+				return nil, err
+			}
+			response := handlers.BatchResponse{Kind: "ScientistBatchResponse", Items: []handlers.BatchResult{}}
+			for i, scientist := range scientists {
+				if itemErrs[i] != nil {
+					response.Items = append(response.Items, handlers.NewBatchResult(ctx, "", 0, nil, itemErrs[i]))
+					continue
+				}
+				response.Items = append(response.Items, handlers.NewBatchResult(ctx, scientist.ID, http.StatusCreated, PresentScientist(scientist), nil))
+			}
+			return response, nil
+		},
+		ErrorHandler: handlers.HandleError,
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+// BatchPatch patches every scientist of the request in one transaction. An item's resource_version works like If-Match.
+func (h scientistHandler) BatchPatch(w http.ResponseWriter, r *http.Request) {
+	var batch scientistBatchPatchRequest
+	cfg := &handlers.HandlerConfig{
+		Body: &batch,
+		Validators: []handlers.Validate{
+			handlers.ValidateBatchSize(&batch, "Items", "items"),
+			func() *errors.ServiceError {
+				for i := range batch.Items {
+					if err := scientistRules.ValidateUpdate(validation.FieldsOf(batch.Items[i].Patch), nil); err != nil {
+						return validation.ItemError("items", i, err)
+					}
+				}
+				return nil
+			},
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			ids := make([]string, len(batch.Items))
+			for i, item := range batch.Items {
+				ids[i] = item.ID
+			}
+			scientists, itemErrs, err := h.scientist.BatchUpdate(ctx, ids, func(i int, scientist *Scientist) *errors.ServiceError {
+				if err := services.ValidateResourceVersion(batch.Items[i].ResourceVersion, scientist.ResourceVersion); err != nil {
+					return err
+				}
+				patch, err := json.Marshal(batch.Items[i].Patch)
+				if err != nil {
+					return errors.MalformedRequest("Invalid patch: %s", err)
+				}
+				patched, _, svcErr := patchScientist(handlers.PatchFormatJSON, patch, scientist)
+				if svcErr != nil {
+					return svcErr
+				}
+				*scientist = *patched
+				return nil
+			}, batch.AllOrNothing)
+			if err != nil {
+				return nil, err
+			}
+			response := handlers.BatchResponse{Kind: "ScientistBatchResponse", Items: []handlers.BatchResult{}}
+			for i, id := range ids {
+				if itemErrs[i] != nil {
+					response.Items = append(response.Items, handlers.NewBatchResult(ctx, id, 0, nil, itemErrs[i]))
+					continue
+				}
+				response.Items = append(response.Items, handlers.NewBatchResult(ctx, id, http.StatusOK, PresentScientist(scientists[i]), nil))
+			}
+			return response, nil
+		},
+		ErrorHandler: handlers.HandleError,
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+// BatchDelete deletes every scientist of the request in one transaction
+func (h scientistHandler) BatchDelete(w http.ResponseWriter, r *http.Request) {
+	var batch handlers.BatchDeleteRequest
+	cfg := &handlers.HandlerConfig{
+		Body: &batch,
+		Validators: []handlers.Validate{
+			handlers.ValidateBatchSize(&batch, "IDs", "ids"),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			itemErrs, err := h.scientist.BatchDelete(ctx, batch.IDs, batch.AllOrNothing)
+			if err != nil {
+				return nil, err
+			}
+			response := handlers.BatchResponse{Kind: "ScientistBatchResponse", Items: []handlers.BatchResult{}}
+			for i, id := range batch.IDs {
+				response.Items = append(response.Items, handlers.NewBatchResult(ctx, id, http.StatusNoContent, nil, itemErrs[i]))
+			}
+			return response, nil
+		},
+		ErrorHandler: handlers.HandleError,
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
@@ -135,3 +135,61 @@ func TestScientistListSearch(t *testing.T) {
 	Expect(list.Total).To(Equal(int32(1)))
 	Expect(*list.Items[0].Id).To(Equal(scientists[0].ID))
 }
+
+func TestScientistDinosaurLinks(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+
+	scientist, _, err := client.DefaultAPI.ApiRhTrexAiV1ScientistsPost(ctx).Scientist(openapi.Scientist{Name: "Barnum Brown", Field: "paleontology"}).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	dinosaur, _, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursPost(ctx).Dinosaur(openapi.Dinosaur{Species: "Tyrannosaurus"}).Execute()
+	Expect(err).NotTo(HaveOccurred())
+
+	resp, err := client.DefaultAPI.ApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdPut(ctx, *scientist.Id, h.NewID()).Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+
+	// linking twice is a no-op
+	for i := 0; i < 2; i++ {
+		resp, err = client.DefaultAPI.ApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdPut(ctx, *scientist.Id, *dinosaur.Id).Execute()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+	}
+
+	dinosaurs, _, err := client.DefaultAPI.ApiRhTrexAiV1ScientistsIdDinosaursGet(ctx, *scientist.Id).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(dinosaurs.Items).To(HaveLen(1))
+	Expect(*dinosaurs.Items[0].Id).To(Equal(*dinosaur.Id))
+
+	scientists, _, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursIdScientistsGet(ctx, *dinosaur.Id).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(scientists.Items).To(HaveLen(1))
+	Expect(*scientists.Items[0].Id).To(Equal(*scientist.Id))
+
+	// the search of either Kind reaches across the join table
+	search := fmt.Sprintf("dinosaurs.species = 'Tyrannosaurus' and id = '%s'", *scientist.Id)
+	scientists, _, err = client.DefaultAPI.ApiRhTrexAiV1ScientistsGet(ctx).Search(search).Preload("dinosaurs").Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(scientists.Items).To(HaveLen(1))
+	Expect(scientists.Items[0].Dinosaurs).To(HaveLen(1))
+	Expect(scientists.Items[0].Dinosaurs[0].Species).To(Equal("Tyrannosaurus"))
+
+	search = fmt.Sprintf("scientists.name = 'Barnum Brown' and id = '%s'", *dinosaur.Id)
+	dinosaursList, _, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursGet(ctx).Search(search).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(dinosaursList.Items).To(HaveLen(1))
+
+	resp, err = client.DefaultAPI.ApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdDelete(ctx, *scientist.Id, *dinosaur.Id).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusNoContent))
+
+	dinosaurs, _, err = client.DefaultAPI.ApiRhTrexAiV1ScientistsIdDinosaursGet(ctx, *scientist.Id).Execute()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(dinosaurs.Items).To(BeEmpty())
+
+	resp, err = client.DefaultAPI.ApiRhTrexAiV1ScientistsIdDinosaursDinosaurIdDelete(ctx, *scientist.Id, *dinosaur.Id).Execute()
+	Expect(err).To(HaveOccurred())
+	Expect(resp.StatusCode).To(Equal(http.StatusNotFound))
+}
@@ -1,6 +1,8 @@
 package scientists
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 
 	"github.com/go-gormigrate/gormigrate/v2"
@@ -24,3 +26,66 @@ func migration() *gormigrate.Migration {
 		},
 	}
 }
+
+func resourceVersionMigration() *gormigrate.Migration {
+	type Scientist struct {
+		ResourceVersion int64 `gorm:"not null;default:1"`
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101709005426",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Scientist{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&Scientist{}, "resource_version")
+		},
+	}
+}
+
+func tenancyMigration() *gormigrate.Migration {
+	type Scientist struct {
+		OrganizationID string `gorm:"index"`
+		Owner          string
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101712005426",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Scientist{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, column := range []string{"organization_id", "owner"} {
+				if err := tx.Migrator().DropColumn(&Scientist{}, column); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+func linkMigration() *gormigrate.Migration {
+	type ScientistDinosaur struct {
+		ScientistID string `gorm:"primaryKey"`
+		DinosaurID  string `gorm:"primaryKey;index"`
+		CreatedAt   time.Time
+	}
+	fks := []db.FKMigration{
+		{Model: "scientist_dinosaurs", Dest: "scientists", Field: "scientist_id", Reference: "scientists(id)", OnDelete: "CASCADE"},
+		{Model: "scientist_dinosaurs", Dest: "dinosaurs", Field: "dinosaur_id", Reference: "dinosaurs(id)", OnDelete: "CASCADE"},
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101718005426",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.AutoMigrate(&ScientistDinosaur{}); err != nil {
+				return err
+			}
+			return db.CreateFK(tx, fks...)
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&ScientistDinosaur{})
+		},
+	}
+}
@@ -2,6 +2,7 @@ package scientists
 
 import (
 	"context"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -36,7 +37,7 @@ func (d *scientistDaoMock) Replace(ctx context.Context, scientist *Scientist) (*
 	return nil, errors.NotImplemented("Scientist").AsError()
 }
 
-func (d *scientistDaoMock) Delete(ctx context.Context, id string) error {
+func (d *scientistDaoMock) Delete(ctx context.Context, id string, resourceVersions []int64) error {
 	return errors.NotImplemented("Scientist").AsError()
 }
 
@@ -47,3 +48,23 @@ func (d *scientistDaoMock) FindByIDs(ctx context.Context, ids []string) (Scienti
 func (d *scientistDaoMock) All(ctx context.Context) (ScientistList, error) {
 	return d.scientists, nil
 }
+
+func (d *scientistDaoMock) Restore(ctx context.Context, id string) (*Scientist, error) {
+	return nil, errors.NotImplemented("Scientist").AsError()
+}
+
+func (d *scientistDaoMock) Purge(ctx context.Context, id string) error {
+	return errors.NotImplemented("Scientist").AsError()
+}
+
+func (d *scientistDaoMock) PurgeDeleted(ctx context.Context, deletedBefore time.Time, limit int) (int64, error) {
+	return 0, errors.NotImplemented("Scientist").AsError()
+}
+
+func (d *scientistDaoMock) LinkDinosaur(ctx context.Context, link *ScientistDinosaur) (bool, error) {
+	return false, errors.NotImplemented("Scientist").AsError()
+}
+
+func (d *scientistDaoMock) UnlinkDinosaur(ctx context.Context, id, dinosaurID string) (*ScientistDinosaur, error) {
+	return nil, errors.NotImplemented("Scientist").AsError()
+}
@@ -1,7 +1,11 @@
 package scientists
 
 import (
+	"time"
+
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/validation"
+	"github.com/openshift-online/rh-trex-ai/plugins/dinosaurs"
 	"gorm.io/gorm"
 )
 
@@ -9,6 +13,9 @@ type Scientist struct {
 	api.Meta
 	Name  string `json:"name"`
 	Field string `json:"field"`
+
+	// the linked resources of the many-to-many relationships are only loaded when preloaded
+	Dinosaurs []dinosaurs.Dinosaur `json:"dinosaurs,omitempty" gorm:"many2many:scientist_dinosaurs"`
 }
 
 type ScientistList []*Scientist
@@ -24,6 +31,7 @@ func (l ScientistList) Index() ScientistIndex {
 
 func (d *Scientist) BeforeCreate(tx *gorm.DB) error {
 	d.ID = api.NewID()
+	d.ResourceVersion = 1
 	return nil
 }
 
@@ -31,3 +39,14 @@ type ScientistPatchRequest struct {
 	Name  *string `json:"name,omitempty"`
 	Field *string `json:"field,omitempty"`
 }
+
+// scientistRules validate the fields of the scientists sent to the REST and gRPC APIs, read from the constraints
+// of the Scientist schema in openapi/openapi.scientists.yaml
+var scientistRules = validation.MustSchemaRules("Scientist")
+
+// ScientistDinosaur links a scientist to a dinosaur, a row of the scientist_dinosaurs join table
+type ScientistDinosaur struct {
+	ScientistID string `json:"scientist_id" gorm:"primaryKey"`
+	DinosaurID  string `json:"dinosaur_id" gorm:"primaryKey"`
+	CreatedAt   time.Time
+}
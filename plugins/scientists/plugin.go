@@ -11,14 +11,27 @@ import (
 	"github.com/openshift-online/rh-trex-ai/pkg/api/presenters"
 	"github.com/openshift-online/rh-trex-ai/pkg/auth"
 	"github.com/openshift-online/rh-trex-ai/pkg/controllers"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
 	"github.com/openshift-online/rh-trex-ai/pkg/environments"
 	"github.com/openshift-online/rh-trex-ai/pkg/registry"
 	pkgserver "github.com/openshift-online/rh-trex-ai/pkg/server"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+	"github.com/openshift-online/rh-trex-ai/plugins/auditevents"
 	"github.com/openshift-online/rh-trex-ai/plugins/events"
 	"github.com/openshift-online/rh-trex-ai/plugins/generic"
+	"github.com/openshift-online/rh-trex-ai/plugins/webhooks"
 )
 
+// authzResource is the resource the routes and RPCs of this Kind are authorized for
+const authzResource = "scientists"
+
+// dinosaurLink is the many-to-many relationship of scientists to dinosaurs, see services.Link
+var dinosaurLink = &services.Link{
+	Owner: "Scientists", OwnerTable: "scientists", Linked: "Dinosaurs", LinkedTable: "dinosaurs",
+	JoinTable: "scientist_dinosaurs", OwnerKey: "scientist_id", LinkedKey: "dinosaur_id",
+}
+
 type ServiceLocator func() ScientistService
 
 func NewServiceLocator(env *environments.Env) ServiceLocator {
@@ -27,6 +40,8 @@ func NewServiceLocator(env *environments.Env) ServiceLocator {
 			db.NewAdvisoryLockFactory(env.Database.SessionFactory),
 			NewScientistDao(&env.Database.SessionFactory),
 			events.Service(&env.Services),
+			auditevents.Service(&env.Services),
+			services.NewRelationshipService(dao.NewRelationshipDao(&env.Database.SessionFactory)),
 		)
 	}
 }
@@ -49,16 +64,38 @@ func init() {
 
 	pkgserver.RegisterRoutes("scientists", func(apiV1Router *mux.Router, services pkgserver.ServicesInterface, authMiddleware auth.JWTMiddleware, authzMiddleware auth.AuthorizationMiddleware) {
 		envServices := services.(*environments.Services)
-		scientistHandler := NewScientistHandler(Service(envServices), generic.Service(envServices))
+		scientistHandler := NewScientistHandler(Service(envServices), generic.Service(envServices), pkgserver.EventBrokerFunc(services))
 
 		scientistsRouter := apiV1Router.PathPrefix("/scientists").Subrouter()
-		scientistsRouter.HandleFunc("", scientistHandler.List).Methods(http.MethodGet)
-		scientistsRouter.HandleFunc("/{id}", scientistHandler.Get).Methods(http.MethodGet)
-		scientistsRouter.HandleFunc("", scientistHandler.Create).Methods(http.MethodPost)
-		scientistsRouter.HandleFunc("/{id}", scientistHandler.Patch).Methods(http.MethodPatch)
-		scientistsRouter.HandleFunc("/{id}", scientistHandler.Delete).Methods(http.MethodDelete)
+		pkgserver.RegisterWatch(scientistsRouter, authzMiddleware, authzResource, scientistHandler.Watch)
+		scientistsRouter.HandleFunc("", authzMiddleware.Authorize(auth.ActionList, authzResource, scientistHandler.List)).Methods(http.MethodGet)
+		scientistsRouter.HandleFunc("/{id}", authzMiddleware.Authorize(auth.ActionGet, authzResource, scientistHandler.Get)).Methods(http.MethodGet)
+		scientistsRouter.HandleFunc("", authzMiddleware.Authorize(auth.ActionCreate, authzResource, scientistHandler.Create)).Methods(http.MethodPost)
+		scientistsRouter.HandleFunc("/{id}", authzMiddleware.Authorize(auth.ActionUpdate, authzResource, scientistHandler.Patch)).Methods(http.MethodPatch)
+		scientistsRouter.HandleFunc("/{id}", authzMiddleware.Authorize(auth.ActionDelete, authzResource, scientistHandler.Delete)).Methods(http.MethodDelete)
+		scientistsRouter.HandleFunc("/{id}:restore", authzMiddleware.Authorize("restore", authzResource, scientistHandler.Restore)).Methods(http.MethodPost)
+		scientistsRouter.HandleFunc("/{id}:purge", authzMiddleware.Authorize("purge", authzResource, scientistHandler.Purge)).Methods(http.MethodPost)
+		scientistsRouter.HandleFunc("/{id}/dinosaurs", authzMiddleware.Authorize(auth.ActionList, "dinosaurs", scientistHandler.ListDinosaurs)).Methods(http.MethodGet)
+		scientistsRouter.HandleFunc("/{id}/dinosaurs/{dinosaur_id}", authzMiddleware.Authorize(auth.ActionUpdate, authzResource, scientistHandler.LinkDinosaur)).Methods(http.MethodPut)
+		scientistsRouter.HandleFunc("/{id}/dinosaurs/{dinosaur_id}", authzMiddleware.Authorize(auth.ActionUpdate, authzResource, scientistHandler.UnlinkDinosaur)).Methods(http.MethodDelete)
 		scientistsRouter.Use(authMiddleware.AuthenticateAccountJWT)
-		scientistsRouter.Use(authzMiddleware.AuthorizeApi)
+		scientistsRouter.Use(pkgserver.RateLimitMiddleware(environments.Environment().RateLimiter, authzResource))
+		scientistsRouter.Use(pkgserver.IdempotencyMiddleware(services))
+
+		// custom methods on the collection, e.g. /scientists:batchCreate, don't fit under the /scientists prefix
+		scientistsBatchRouter := apiV1Router.NewRoute().Subrouter()
+		scientistsBatchRouter.HandleFunc("/scientists:batchCreate", authzMiddleware.Authorize(auth.ActionCreate, authzResource, scientistHandler.BatchCreate)).Methods(http.MethodPost)
+		scientistsBatchRouter.HandleFunc("/scientists:batchPatch", authzMiddleware.Authorize(auth.ActionUpdate, authzResource, scientistHandler.BatchPatch)).Methods(http.MethodPost)
+		scientistsBatchRouter.HandleFunc("/scientists:batchDelete", authzMiddleware.Authorize(auth.ActionDelete, authzResource, scientistHandler.BatchDelete)).Methods(http.MethodPost)
+		scientistsBatchRouter.Use(authMiddleware.AuthenticateAccountJWT)
+		scientistsBatchRouter.Use(pkgserver.RateLimitMiddleware(environments.Environment().RateLimiter, authzResource))
+		scientistsBatchRouter.Use(pkgserver.IdempotencyMiddleware(services))
+
+		// the scientists linked to another Kind are listed under it, e.g. /dinosaurs/{id}/scientists
+		scientistsNestedRouter := apiV1Router.NewRoute().Subrouter()
+		scientistsNestedRouter.HandleFunc("/dinosaurs/{id}/scientists", authzMiddleware.Authorize(auth.ActionList, authzResource, scientistHandler.ListByDinosaur)).Methods(http.MethodGet)
+		scientistsNestedRouter.Use(authMiddleware.AuthenticateAccountJWT)
+		scientistsNestedRouter.Use(pkgserver.RateLimitMiddleware(environments.Environment().RateLimiter, authzResource))
 	})
 
 	pkgserver.RegisterController("Scientists", func(manager *controllers.KindControllerManager, services pkgserver.ServicesInterface) {
@@ -74,18 +111,40 @@ func init() {
 		})
 	})
 
+	pkgserver.RegisterPurger("Scientists", func(purger *controllers.PurgeController, services pkgserver.ServicesInterface) {
+		scientistServices := Service(services.(*environments.Services))
+
+		purger.Add(&controllers.PurgeConfig{
+			Kind:      "Scientists",
+			Retention: controllers.DefaultPurgeRetention,
+			Purge:     scientistServices.PurgeDeleted,
+		})
+	})
+
+	webhooks.RegisterKind(&webhooks.KindConfig{
+		Source:         "Scientists",
+		ValidateFilter: validateScientistWatchFilter,
+		Match:          presentWatchedScientist,
+	})
+
 	pkgserver.RegisterGRPCService("scientists", func(grpcServer *grpc.Server, services pkgserver.ServicesInterface) {
 		envServices := services.(*environments.Services)
 		scientistService := Service(envServices)
 		genericService := generic.Service(envServices)
-		brokerFunc := func() *pkgserver.EventBroker {
-			if obj := envServices.GetService("EventBroker"); obj != nil {
-				return obj.(*pkgserver.EventBroker)
-			}
-			return nil
-		}
-		pb.RegisterScientistServiceServer(grpcServer, NewScientistGRPCHandler(scientistService, genericService, brokerFunc))
+		pb.RegisterScientistServiceServer(grpcServer, NewScientistGRPCHandler(scientistService, genericService, pkgserver.EventBrokerFunc(services)))
 	})
+	pkgserver.RegisterGRPCMethodAuthorization(pb.ScientistService_GetScientist_FullMethodName, auth.ActionGet, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.ScientistService_ListScientists_FullMethodName, auth.ActionList, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.ScientistService_CreateScientist_FullMethodName, auth.ActionCreate, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.ScientistService_UpdateScientist_FullMethodName, auth.ActionUpdate, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.ScientistService_DeleteScientist_FullMethodName, auth.ActionDelete, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.ScientistService_WatchScientists_FullMethodName, auth.ActionWatch, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.ScientistService_BatchCreateScientists_FullMethodName, auth.ActionCreate, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.ScientistService_BatchUpdateScientists_FullMethodName, auth.ActionUpdate, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.ScientistService_BatchDeleteScientists_FullMethodName, auth.ActionDelete, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.ScientistService_LinkScientistDinosaur_FullMethodName, auth.ActionUpdate, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.ScientistService_UnlinkScientistDinosaur_FullMethodName, auth.ActionUpdate, authzResource)
+	pkgserver.RegisterGRPCMethodAuthorization(pb.ScientistService_ListScientistDinosaurs_FullMethodName, auth.ActionList, "dinosaurs")
 
 	presenters.RegisterPath(Scientist{}, "scientists")
 	presenters.RegisterPath(&Scientist{}, "scientists")
@@ -93,4 +152,9 @@ func init() {
 	presenters.RegisterKind(&Scientist{}, "Scientist")
 
 	db.RegisterMigration(migration())
+	db.RegisterMigration(resourceVersionMigration())
+	db.RegisterMigration(tenancyMigration())
+	db.RegisterMigration(linkMigration())
+
+	services.RegisterLink(dinosaurLink)
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
 	"github.com/openshift-online/rh-trex-ai/pkg/api/presenters"
 	"github.com/openshift-online/rh-trex-ai/pkg/util"
+	"github.com/openshift-online/rh-trex-ai/plugins/dinosaurs"
 )
 
 func ConvertScientist(scientist openapi.Scientist) *Scientist {
@@ -26,13 +27,19 @@ func ConvertScientist(scientist openapi.Scientist) *Scientist {
 
 func PresentScientist(scientist *Scientist) openapi.Scientist {
 	reference := presenters.PresentReference(scientist.ID, scientist)
-	return openapi.Scientist{
-		Id:        reference.Id,
-		Kind:      reference.Kind,
-		Href:      reference.Href,
-		CreatedAt: openapi.PtrTime(scientist.CreatedAt),
-		UpdatedAt: openapi.PtrTime(scientist.UpdatedAt),
-		Name:      scientist.Name,
-		Field:     scientist.Field,
+	presented := openapi.Scientist{
+		Id:              reference.Id,
+		Kind:            reference.Kind,
+		Href:            reference.Href,
+		CreatedAt:       openapi.PtrTime(scientist.CreatedAt),
+		UpdatedAt:       openapi.PtrTime(scientist.UpdatedAt),
+		ResourceVersion: openapi.PtrInt64(scientist.ResourceVersion),
+		DeletedAt:       presenters.PresentDeletedAt(scientist.DeletedAt),
+		Name:            scientist.Name,
+		Field:           scientist.Field,
 	}
+	for _, dinosaur := range scientist.Dinosaurs {
+		presented.Dinosaurs = append(presented.Dinosaurs, dinosaurs.PresentDinosaur(&dinosaur))
+	}
+	return presented
 }
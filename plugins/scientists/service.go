@@ -2,6 +2,10 @@ package scientists
 
 import (
 	"context"
+	e "errors"
+	"time"
+
+	"gorm.io/gorm"
 
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
 	"github.com/openshift-online/rh-trex-ai/pkg/db"
@@ -16,29 +20,49 @@ type ScientistService interface {
 	Get(ctx context.Context, id string) (*Scientist, *errors.ServiceError)
 	Create(ctx context.Context, scientist *Scientist) (*Scientist, *errors.ServiceError)
 	Replace(ctx context.Context, scientist *Scientist) (*Scientist, *errors.ServiceError)
-	Delete(ctx context.Context, id string) *errors.ServiceError
+	// Delete deletes a scientist, only at one of resourceVersions unless they are nil, see handlers.IfMatchVersions
+	Delete(ctx context.Context, id string, resourceVersions []int64) *errors.ServiceError
 	All(ctx context.Context) (ScientistList, *errors.ServiceError)
 
 	FindByIDs(ctx context.Context, ids []string) (ScientistList, *errors.ServiceError)
 
+	Restore(ctx context.Context, id string) (*Scientist, *errors.ServiceError)
+	Purge(ctx context.Context, id string) *errors.ServiceError
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, *errors.ServiceError)
+
+	// BatchCreate creates scientists in place and BatchUpdate loads, updates and replaces the scientists of ids.
+	// Either the whole batch is written or, without allOrNothing, every item that succeeds, see services.RunBatch.
+	BatchCreate(ctx context.Context, scientists ScientistList, allOrNothing bool) (services.BatchErrors, *errors.ServiceError)
+	BatchUpdate(ctx context.Context, ids []string, update func(i int, scientist *Scientist) *errors.ServiceError, allOrNothing bool) (ScientistList, services.BatchErrors, *errors.ServiceError)
+	BatchDelete(ctx context.Context, ids []string, allOrNothing bool) (services.BatchErrors, *errors.ServiceError)
+
 	OnUpsert(ctx context.Context, id string) error
 	OnDelete(ctx context.Context, id string) error
+
+	// LinkDinosaur links the scientist id to a dinosaur, linking them again changes nothing.
+	// UnlinkDinosaur removes the link, see services.Link.
+	LinkDinosaur(ctx context.Context, id, dinosaurID string) *errors.ServiceError
+	UnlinkDinosaur(ctx context.Context, id, dinosaurID string) *errors.ServiceError
 }
 
-func NewScientistService(lockFactory db.LockFactory, scientistDao ScientistDao, events services.EventService) ScientistService {
+func NewScientistService(lockFactory db.LockFactory, scientistDao ScientistDao, events services.EventService, auditEvents services.AuditEventService, relationships services.RelationshipService) ScientistService {
 	return &sqlScientistService{
-		lockFactory:  lockFactory,
-		scientistDao: scientistDao,
-		events:       events,
+		lockFactory:   lockFactory,
+		scientistDao:  scientistDao,
+		events:        events,
+		auditEvents:   auditEvents,
+		relationships: relationships,
 	}
 }
 
 var _ ScientistService = &sqlScientistService{}
 
 type sqlScientistService struct {
-	lockFactory  db.LockFactory
-	scientistDao ScientistDao
-	events       services.EventService
+	lockFactory   db.LockFactory
+	scientistDao  ScientistDao
+	events        services.EventService
+	auditEvents   services.AuditEventService
+	relationships services.RelationshipService
 }
 
 func (s *sqlScientistService) OnUpsert(ctx context.Context, id string) error {
@@ -83,6 +107,10 @@ func (s *sqlScientistService) Create(ctx context.Context, scientist *Scientist)
 		return nil, services.HandleCreateError("Scientist", evErr)
 	}
 
+	if auditErr := s.recordAudit(ctx, api.AuditCreateAction, scientist.ID, nil, scientist); auditErr != nil {
+		return nil, auditErr
+	}
+
 	return scientist, nil
 }
 
@@ -93,6 +121,11 @@ func (s *sqlScientistService) Replace(ctx context.Context, scientist *Scientist)
 	}
 	defer s.lockFactory.Unlock(ctx, lockOwnerID)
 
+	before, err := s.scientistDao.Get(ctx, scientist.ID)
+	if err != nil {
+		return nil, services.HandleGetError("Scientist", "id", scientist.ID, err)
+	}
+
 	scientist, err = s.scientistDao.Replace(ctx, scientist)
 	if err != nil {
 		return nil, services.HandleUpdateError("Scientist", err)
@@ -107,23 +140,40 @@ func (s *sqlScientistService) Replace(ctx context.Context, scientist *Scientist)
 		return nil, services.HandleUpdateError("Scientist", evErr)
 	}
 
+	if auditErr := s.recordAudit(ctx, api.AuditUpdateAction, scientist.ID, before, scientist); auditErr != nil {
+		return nil, auditErr
+	}
+
 	return scientist, nil
 }
 
-func (s *sqlScientistService) Delete(ctx context.Context, id string) *errors.ServiceError {
-	if err := s.scientistDao.Delete(ctx, id); err != nil {
-		return services.HandleDeleteError("Scientist", errors.GeneralError("Unable to delete scientist: %s", err))
+func (s *sqlScientistService) Delete(ctx context.Context, id string, resourceVersions []int64) *errors.ServiceError {
+	before, err := s.scientistDao.Get(ctx, id)
+	if err != nil {
+		return services.HandleDeleteError("Scientist", err)
+	}
+	if err := s.relationships.CheckDelete(ctx, "Scientists", id); err != nil {
+		return err
+	}
+	if err := s.scientistDao.Delete(ctx, id, resourceVersions); err != nil {
+		return services.HandleDeleteError("Scientist", err)
 	}
 
 	_, evErr := s.events.Create(ctx, &api.Event{
 		Source:    "Scientists",
 		SourceID:  id,
 		EventType: api.DeleteEventType,
+		// the organization of the scientist rather than of the caller, who may work across organizations
+		Meta: api.Meta{OrganizationID: before.OrganizationID},
 	})
 	if evErr != nil {
 		return services.HandleDeleteError("Scientist", evErr)
 	}
 
+	if auditErr := s.recordAudit(ctx, api.AuditDeleteAction, id, before, nil); auditErr != nil {
+		return auditErr
+	}
+
 	return nil
 }
 
@@ -142,3 +192,195 @@ func (s *sqlScientistService) All(ctx context.Context) (ScientistList, *errors.S
 	}
 	return scientists, nil
 }
+
+// Restore undeletes a soft-deleted scientist. Controllers and watchers see it as created again.
+func (s *sqlScientistService) Restore(ctx context.Context, id string) (*Scientist, *errors.ServiceError) {
+	lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, id, scientistsLockType)
+	if err != nil {
+		return nil, errors.DatabaseAdvisoryLock(err)
+	}
+	defer s.lockFactory.Unlock(ctx, lockOwnerID)
+
+	scientist, err := s.scientistDao.Restore(ctx, id)
+	if err != nil {
+		return nil, s.handleNotDeleted(ctx, id, err)
+	}
+
+	_, evErr := s.events.Create(ctx, &api.Event{
+		Source:    "Scientists",
+		SourceID:  scientist.ID,
+		EventType: api.CreateEventType,
+	})
+	if evErr != nil {
+		return nil, services.HandleUpdateError("Scientist", evErr)
+	}
+
+	if auditErr := s.recordAudit(ctx, api.AuditRestoreAction, scientist.ID, nil, scientist); auditErr != nil {
+		return nil, auditErr
+	}
+
+	return scientist, nil
+}
+
+// Purge hard-deletes a soft-deleted scientist, it can't be restored afterwards
+func (s *sqlScientistService) Purge(ctx context.Context, id string) *errors.ServiceError {
+	if err := s.scientistDao.Purge(ctx, id); err != nil {
+		return s.handleNotDeleted(ctx, id, err)
+	}
+	// the state of the scientist was recorded when it was deleted
+	return s.recordAudit(ctx, api.AuditPurgeAction, id, nil, nil)
+}
+
+func (s *sqlScientistService) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, *errors.ServiceError) {
+	purged, err := services.PurgeInBatches(ctx, func(limit int) (int64, error) {
+		return s.scientistDao.PurgeDeleted(ctx, deletedBefore, limit)
+	})
+	if err != nil {
+		return purged, errors.GeneralError("Unable to purge deleted scientists: %s", err)
+	}
+	return purged, nil
+}
+
+func (s *sqlScientistService) BatchCreate(ctx context.Context, scientists ScientistList, allOrNothing bool) (services.BatchErrors, *errors.ServiceError) {
+	itemErrs, svcErr := services.RunBatch(ctx, len(scientists), allOrNothing, func(i int) *errors.ServiceError {
+		if _, err := s.scientistDao.Create(ctx, scientists[i]); err != nil {
+			return services.HandleCreateError("Scientist", err)
+		}
+		return s.recordAudit(ctx, api.AuditCreateAction, scientists[i].ID, nil, scientists[i])
+	})
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	ids := make([]string, len(scientists))
+	for i, scientist := range scientists {
+		ids[i] = scientist.ID
+	}
+	if _, evErr := s.events.CreateBatch(ctx, services.BatchEvents("Scientists", api.CreateEventType, ids, itemErrs)); evErr != nil {
+		return nil, services.HandleCreateError("Scientist", evErr)
+	}
+	return itemErrs, nil
+}
+
+func (s *sqlScientistService) BatchUpdate(ctx context.Context, ids []string, update func(i int, scientist *Scientist) *errors.ServiceError, allOrNothing bool) (ScientistList, services.BatchErrors, *errors.ServiceError) {
+	scientists := make(ScientistList, len(ids))
+	itemErrs, svcErr := services.RunBatch(ctx, len(ids), allOrNothing, func(i int) *errors.ServiceError {
+		lockOwnerID, err := s.lockFactory.NewAdvisoryLock(ctx, ids[i], scientistsLockType)
+		if err != nil {
+			return errors.DatabaseAdvisoryLock(err)
+		}
+		defer s.lockFactory.Unlock(ctx, lockOwnerID)
+
+		scientist, svcErr := s.Get(ctx, ids[i])
+		if svcErr != nil {
+			return svcErr
+		}
+		before := *scientist
+		if svcErr := update(i, scientist); svcErr != nil {
+			return svcErr
+		}
+		if _, err := s.scientistDao.Replace(ctx, scientist); err != nil {
+			return services.HandleUpdateError("Scientist", err)
+		}
+		scientists[i] = scientist
+		return s.recordAudit(ctx, api.AuditUpdateAction, scientist.ID, &before, scientist)
+	})
+	if svcErr != nil {
+		return nil, nil, svcErr
+	}
+
+	if _, evErr := s.events.CreateBatch(ctx, services.BatchEvents("Scientists", api.UpdateEventType, ids, itemErrs)); evErr != nil {
+		return nil, nil, services.HandleUpdateError("Scientist", evErr)
+	}
+	return scientists, itemErrs, nil
+}
+
+func (s *sqlScientistService) BatchDelete(ctx context.Context, ids []string, allOrNothing bool) (services.BatchErrors, *errors.ServiceError) {
+	itemErrs, svcErr := services.RunBatch(ctx, len(ids), allOrNothing, func(i int) *errors.ServiceError {
+		before, err := s.scientistDao.Get(ctx, ids[i])
+		if err != nil {
+			return services.HandleDeleteError("Scientist", err)
+		}
+		if err := s.relationships.CheckDelete(ctx, "Scientists", ids[i]); err != nil {
+			return err
+		}
+		if err := s.scientistDao.Delete(ctx, ids[i], nil); err != nil {
+			return services.HandleDeleteError("Scientist", err)
+		}
+		return s.recordAudit(ctx, api.AuditDeleteAction, ids[i], before, nil)
+	})
+	if svcErr != nil {
+		return nil, svcErr
+	}
+
+	if _, evErr := s.events.CreateBatch(ctx, services.BatchEvents("Scientists", api.DeleteEventType, ids, itemErrs)); evErr != nil {
+		return nil, services.HandleDeleteError("Scientist", evErr)
+	}
+	return itemErrs, nil
+}
+
+func (s *sqlScientistService) LinkDinosaur(ctx context.Context, id, dinosaurID string) *errors.ServiceError {
+	scientist, err := s.scientistDao.Get(ctx, id)
+	if err != nil {
+		return services.HandleGetError("Scientist", "id", id, err)
+	}
+	if err := s.relationships.CheckLinked(ctx, "Scientists", "Dinosaurs", dinosaurID); err != nil {
+		return err
+	}
+	link := &ScientistDinosaur{ScientistID: id, DinosaurID: dinosaurID}
+	linked, err := s.scientistDao.LinkDinosaur(ctx, link)
+	if err != nil {
+		return services.HandleUpdateError("Scientist", err)
+	}
+	if !linked {
+		return nil
+	}
+	return s.recordLinkAudit(ctx, api.AuditLinkAction, scientist, nil, link)
+}
+
+func (s *sqlScientistService) UnlinkDinosaur(ctx context.Context, id, dinosaurID string) *errors.ServiceError {
+	scientist, err := s.scientistDao.Get(ctx, id)
+	if err != nil {
+		return services.HandleGetError("Scientist", "id", id, err)
+	}
+	link, err := s.scientistDao.UnlinkDinosaur(ctx, id, dinosaurID)
+	if err != nil {
+		if e.Is(err, gorm.ErrRecordNotFound) {
+			return errors.NotFound("Scientist with id='%s' is not linked to Dinosaur with id='%s'", id, dinosaurID)
+		}
+		return services.HandleUpdateError("Scientist", err)
+	}
+	return s.recordLinkAudit(ctx, api.AuditUnlinkAction, scientist, link, nil)
+}
+
+// recordAudit appends a change of the scientist id to the audit trail, before is nil for creates and after for deletes
+func (s *sqlScientistService) recordAudit(ctx context.Context, action api.AuditAction, id string, before, after *Scientist) *errors.ServiceError {
+	auditEvent := &api.AuditEvent{Source: "Scientists", SourceID: id, Action: action}
+	var beforeState, afterState interface{}
+	if before != nil {
+		beforeState = before
+		auditEvent.OrganizationID = before.OrganizationID
+	}
+	if after != nil {
+		afterState = after
+		auditEvent.OrganizationID = after.OrganizationID
+	}
+	return s.auditEvents.Record(ctx, auditEvent, beforeState, afterState)
+}
+
+// recordLinkAudit appends a link or unlink of the scientist to the audit trail, the state is the row of the join table
+func (s *sqlScientistService) recordLinkAudit(ctx context.Context, action api.AuditAction, scientist *Scientist, before, after interface{}) *errors.ServiceError {
+	auditEvent := &api.AuditEvent{Source: "Scientists", SourceID: scientist.ID, Action: action, OrganizationID: scientist.OrganizationID}
+	return s.auditEvents.Record(ctx, auditEvent, before, after)
+}
+
+// handleNotDeleted tells a scientist that isn't deleted apart from one that doesn't exist at all
+func (s *sqlScientistService) handleNotDeleted(ctx context.Context, id string, err error) *errors.ServiceError {
+	if !e.Is(err, gorm.ErrRecordNotFound) {
+		return services.HandleUpdateError("Scientist", err)
+	}
+	if _, getErr := s.scientistDao.Get(ctx, id); getErr == nil {
+		return errors.Conflict("Scientist with id='%s' is not deleted", id)
+	}
+	return services.HandleGetError("Scientist", "id", id, err)
+}
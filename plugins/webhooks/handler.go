@@ -0,0 +1,200 @@
+package webhooks
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	"github.com/openshift-online/rh-trex-ai/pkg/handlers"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
+
+type webhookHandler struct {
+	webhook services.WebhookService
+	generic services.GenericService
+}
+
+func NewWebhookHandler(webhook services.WebhookService, generic services.GenericService) *webhookHandler {
+	return &webhookHandler{
+		webhook: webhook,
+		generic: generic,
+	}
+}
+
+func (h webhookHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var webhook Webhook
+	cfg := &handlers.HandlerConfig{
+		Body: &webhook,
+		Validators: []handlers.Validate{
+			handlers.ValidateEmpty(&webhook, "ID", "id"),
+			handlers.ValidateNotEmpty(&webhook, "URL", "url"),
+			handlers.ValidateNotEmpty(&webhook, "Secret", "secret"),
+		},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			webhookModel := ConvertWebhook(webhook)
+			if err := h.validate(r, webhookModel); err != nil {
+				return nil, err
+			}
+			webhookModel, err := h.webhook.Create(ctx, webhookModel)
+			if err != nil {
+				return nil, err
+			}
+			return PresentWebhook(webhookModel), nil
+		},
+		ErrorHandler: handlers.HandleError,
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusCreated)
+}
+
+func (h webhookHandler) Patch(w http.ResponseWriter, r *http.Request) {
+	var patch WebhookPatchRequest
+	cfg := &handlers.HandlerConfig{
+		Body:       &patch,
+		Validators: []handlers.Validate{},
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			id := mux.Vars(r)["id"]
+			found, err := h.webhook.Get(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if err := handlers.ValidateIfMatch(r, found.ResourceVersion); err != nil {
+				return nil, err
+			}
+
+			applyWebhookPatch(found, patch)
+			if err := h.validate(r, found); err != nil {
+				return nil, err
+			}
+
+			webhookModel, err := h.webhook.Replace(ctx, found)
+			if err != nil {
+				return nil, err
+			}
+			handlers.SetETag(w, webhookModel.ResourceVersion)
+			return PresentWebhook(webhookModel), nil
+		},
+		ErrorHandler: handlers.HandleError,
+	}
+
+	handlers.Handle(w, r, cfg, http.StatusOK)
+}
+
+// validate checks the fields of webhook that the validators of the body can't
+func (h webhookHandler) validate(r *http.Request, webhook *api.Webhook) *errors.ServiceError {
+	endpoint, err := url.Parse(webhook.URL)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return errors.Validation("url must be an absolute http or https URL")
+	}
+	if webhook.Secret == "" {
+		return errors.Validation("secret is required")
+	}
+	return validateWebhookSubscription(r.Context(), h.generic, webhook)
+}
+
+func (h webhookHandler) Get(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			webhook, err := h.webhook.Get(r.Context(), id)
+			if err != nil {
+				return nil, err
+			}
+			handlers.SetETag(w, webhook.ResourceVersion)
+			return PresentWebhook(webhook), nil
+		},
+	}
+
+	handlers.HandleGet(w, r, cfg)
+}
+
+func (h webhookHandler) List(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			listArgs := services.NewListArguments(r.URL.Query())
+			var webhooks []api.Webhook
+			paging, err := h.generic.List(r.Context(), listArgs, &webhooks)
+			if err != nil {
+				return nil, err
+			}
+			webhookList := WebhookList{
+				Kind:     "WebhookList",
+				Page:     int32(paging.Page),
+				Size:     int32(paging.Size),
+				Total:    int32(paging.Total),
+				Continue: paging.Continue,
+				Items:    []Webhook{},
+			}
+			for i := range webhooks {
+				webhookList.Items = append(webhookList.Items, PresentWebhook(&webhooks[i]))
+			}
+			return webhookList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
+
+func (h webhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			id := mux.Vars(r)["id"]
+			if _, err := h.webhook.Get(r.Context(), id); err != nil {
+				return nil, err
+			}
+			if err := h.webhook.Delete(r.Context(), id); err != nil {
+				return nil, err
+			}
+			return nil, nil
+		},
+	}
+
+	handlers.HandleDelete(w, r, cfg, http.StatusNoContent)
+}
+
+// ListDeliveries lists the delivery attempts of a webhook, narrowed by a search on any delivery column,
+// e.g. "status = 'DeadLettered'" or "event_id = '...'"
+func (h webhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	cfg := &handlers.HandlerConfig{
+		Action: func() (interface{}, *errors.ServiceError) {
+			ctx := r.Context()
+			id := mux.Vars(r)["id"]
+			webhook, err := h.webhook.Get(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+
+			listArgs := services.NewListArguments(r.URL.Query())
+			search := fmt.Sprintf("webhook_id = '%s'", webhook.ID)
+			if listArgs.Search != "" {
+				search = fmt.Sprintf("%s and (%s)", search, listArgs.Search)
+			}
+			listArgs.Search = search
+			var deliveries []api.WebhookDelivery
+			paging, err := h.generic.List(ctx, listArgs, &deliveries)
+			if err != nil {
+				return nil, err
+			}
+			deliveryList := WebhookDeliveryList{
+				Kind:     "WebhookDeliveryList",
+				Page:     int32(paging.Page),
+				Size:     int32(paging.Size),
+				Total:    int32(paging.Total),
+				Continue: paging.Continue,
+				Items:    []WebhookDelivery{},
+			}
+			for i := range deliveries {
+				deliveryList.Items = append(deliveryList.Items, PresentWebhookDelivery(&deliveries[i]))
+			}
+			return deliveryList, nil
+		},
+	}
+
+	handlers.HandleList(w, r, cfg)
+}
@@ -0,0 +1,110 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/controllers"
+	"github.com/openshift-online/rh-trex-ai/pkg/errors"
+	pkgserver "github.com/openshift-online/rh-trex-ai/pkg/server"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+)
+
+// MatchFunc returns the resource of an event as it is presented to clients, and whether the event is
+// delivered to a webhook with filter. It is called in the organization of the webhook, so the resources
+// of other organizations never match. No resource is returned for deletes.
+type MatchFunc func(ctx context.Context, generic services.GenericService, evt *pkgserver.BrokerEvent, filter string) (interface{}, bool, *errors.ServiceError)
+
+// KindConfig lets webhooks subscribe to the events of a Kind
+type KindConfig struct {
+	// Source is the Source of the events of the Kind, e.g. Dinosaurs
+	Source string
+	// ValidateFilter rejects the filters that aren't a valid search of the Kind
+	ValidateFilter func(ctx context.Context, generic services.GenericService, filter string) *errors.ServiceError
+	Match          MatchFunc
+}
+
+var kinds = map[string]*KindConfig{}
+
+// RegisterKind lets webhooks subscribe to the events of a Kind. Kinds register in their plugin's init.
+func RegisterKind(config *KindConfig) {
+	kinds[config.Source] = config
+}
+
+func registeredSources() []string {
+	sources := make([]string, 0, len(kinds))
+	for source := range kinds {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+// validateWebhookSubscription checks the source and the filter of a webhook
+func validateWebhookSubscription(ctx context.Context, generic services.GenericService, webhook *api.Webhook) *errors.ServiceError {
+	if webhook.Source == "" {
+		if webhook.Filter != "" {
+			return errors.Validation("filter requires a source")
+		}
+		return nil
+	}
+	kind, found := kinds[webhook.Source]
+	if !found {
+		return errors.Validation("source must be one of %v", registeredSources())
+	}
+	if webhook.Filter == "" {
+		return nil
+	}
+	return kind.ValidateFilter(ctx, generic, webhook.Filter)
+}
+
+// enqueueDeliveries is the webhooks controller. It queues a delivery of the handled event for every webhook
+// subscribed to its Kind that the resource of the event matches.
+func enqueueDeliveries(webhookService services.WebhookService, generic services.GenericService) controllers.ControllerHandlerFunc {
+	return func(ctx context.Context, id string) error {
+		event, ok := controllers.EventFromContext(ctx)
+		if !ok {
+			return fmt.Errorf("no event handled for %s", id)
+		}
+		kind, found := kinds[event.Source]
+		if !found {
+			return nil
+		}
+		webhooks, svcErr := webhookService.FindBySource(ctx, event.Source)
+		if svcErr != nil {
+			return svcErr
+		}
+
+		evt := &pkgserver.BrokerEvent{
+			EventID:        event.ID,
+			Source:         event.Source,
+			SourceID:       event.SourceID,
+			EventType:      event.EventType,
+			ChangedFields:  event.FieldMask(),
+			OrganizationID: event.OrganizationID,
+			Seq:            event.Seq,
+		}
+		for _, webhook := range webhooks {
+			webhookCtx := auth.SetTenantContext(ctx, auth.Tenant{OrganizationID: webhook.OrganizationID})
+			object, matched, svcErr := kind.Match(webhookCtx, generic, evt, webhook.Filter)
+			if svcErr != nil {
+				return svcErr
+			}
+			if !matched {
+				continue
+			}
+			payload, err := json.Marshal(PresentWebhookEvent(webhook, event, object))
+			if err != nil {
+				return err
+			}
+			if svcErr := webhookService.Enqueue(ctx, webhook, event, payload); svcErr != nil {
+				return svcErr
+			}
+		}
+		return nil
+	}
+}
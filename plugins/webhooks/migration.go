@@ -0,0 +1,47 @@
+package webhooks
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+)
+
+func migration() *gormigrate.Migration {
+	type Webhook struct {
+		db.Model
+		URL    string `gorm:"not null"`
+		Secret string `gorm:"not null"`
+		Source string `gorm:"index"`
+		Filter string
+	}
+	type WebhookDelivery struct {
+		ID             string    `gorm:"primaryKey"`
+		CreatedAt      time.Time `gorm:"not null;index"`
+		WebhookID      string    `gorm:"not null;uniqueIndex:idx_webhook_deliveries_attempt"`
+		OrganizationID string    `gorm:"index"`
+		EventID        string    `gorm:"not null;uniqueIndex:idx_webhook_deliveries_attempt"`
+		Source         string    `gorm:"not null"`
+		SourceID       string    `gorm:"not null"`
+		EventType      string    `gorm:"not null"`
+		Attempt        int       `gorm:"not null;uniqueIndex:idx_webhook_deliveries_attempt"`
+		Payload        string
+		Status         string    `gorm:"not null;index:idx_webhook_deliveries_due"`
+		ScheduledAt    time.Time `gorm:"not null;index:idx_webhook_deliveries_due"`
+		AttemptedAt    *time.Time
+		StatusCode     int
+		Error          string
+	}
+
+	return &gormigrate.Migration{
+		ID: "2026101715000925",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&Webhook{}, &WebhookDelivery{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&WebhookDelivery{}, &Webhook{})
+		},
+	}
+}
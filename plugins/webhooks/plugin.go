@@ -0,0 +1,100 @@
+package webhooks
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/api/presenters"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/controllers"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/pkg/environments"
+	"github.com/openshift-online/rh-trex-ai/pkg/registry"
+	pkgserver "github.com/openshift-online/rh-trex-ai/pkg/server"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+	"github.com/openshift-online/rh-trex-ai/plugins/generic"
+)
+
+// deliveryRetention is how long the delivery attempts of webhooks are kept
+const deliveryRetention = 7 * 24 * time.Hour
+
+func NewServiceLocator(env *environments.Env) services.WebhookServiceLocator {
+	return func() services.WebhookService {
+		return services.NewWebhookService(dao.NewWebhookDao(&env.Database.SessionFactory))
+	}
+}
+
+// Service helper function to get the webhook service from the registry
+func Service(s *environments.Services) services.WebhookService {
+	if s == nil {
+		return nil
+	}
+	if obj := s.GetService("Webhooks"); obj != nil {
+		locator := obj.(services.WebhookServiceLocator)
+		return locator()
+	}
+	return nil
+}
+
+func init() {
+	registry.RegisterService("Webhooks", func(env interface{}) interface{} {
+		return NewServiceLocator(env.(*environments.Env))
+	})
+
+	pkgserver.RegisterRoutes("webhooks", func(apiV1Router *mux.Router, services pkgserver.ServicesInterface, authMiddleware auth.JWTMiddleware, authzMiddleware auth.AuthorizationMiddleware) {
+		envServices := services.(*environments.Services)
+		webhookHandler := NewWebhookHandler(Service(envServices), generic.Service(envServices))
+
+		webhooksRouter := apiV1Router.PathPrefix("/webhooks").Subrouter()
+		webhooksRouter.HandleFunc("", authzMiddleware.Authorize(auth.ActionList, "webhooks", webhookHandler.List)).Methods(http.MethodGet)
+		webhooksRouter.HandleFunc("/{id}", authzMiddleware.Authorize(auth.ActionGet, "webhooks", webhookHandler.Get)).Methods(http.MethodGet)
+		webhooksRouter.HandleFunc("", authzMiddleware.Authorize(auth.ActionCreate, "webhooks", webhookHandler.Create)).Methods(http.MethodPost)
+		webhooksRouter.HandleFunc("/{id}", authzMiddleware.Authorize(auth.ActionUpdate, "webhooks", webhookHandler.Patch)).Methods(http.MethodPatch)
+		webhooksRouter.HandleFunc("/{id}", authzMiddleware.Authorize(auth.ActionDelete, "webhooks", webhookHandler.Delete)).Methods(http.MethodDelete)
+		webhooksRouter.HandleFunc("/{id}/deliveries", authzMiddleware.Authorize(auth.ActionList, "webhooks", webhookHandler.ListDeliveries)).Methods(http.MethodGet)
+		webhooksRouter.Use(authMiddleware.AuthenticateAccountJWT)
+		webhooksRouter.Use(pkgserver.RateLimitMiddleware(environments.Environment().RateLimiter, "webhooks"))
+	})
+
+	// the webhooks controller queues the deliveries of the events of every Kind, the WebhookController
+	// of the controllers server sends them
+	pkgserver.RegisterController("Webhooks", func(manager *controllers.KindControllerManager, services pkgserver.ServicesInterface) {
+		envServices := services.(*environments.Services)
+		enqueue := enqueueDeliveries(Service(envServices), generic.Service(envServices))
+
+		manager.Add(&controllers.ControllerConfig{
+			Source: controllers.AnySource,
+			Handlers: map[api.EventType][]controllers.ControllerHandlerFunc{
+				api.CreateEventType: {enqueue},
+				api.UpdateEventType: {enqueue},
+				api.DeleteEventType: {enqueue},
+			},
+		})
+	})
+
+	pkgserver.RegisterPurger("WebhookDeliveries", func(purger *controllers.PurgeController, services pkgserver.ServicesInterface) {
+		webhookService := Service(services.(*environments.Services))
+
+		purger.Add(&controllers.PurgeConfig{
+			Kind:      "WebhookDeliveries",
+			Retention: deliveryRetention,
+			Purge:     webhookService.PurgeDeliveries,
+		})
+	})
+
+	// the secret is write-only, it must not be guessed with searches
+	services.SearchDisallowedFields["Webhook"] = map[string]string{"secret": "secret"}
+
+	presenters.RegisterPath(api.Webhook{}, "webhooks")
+	presenters.RegisterPath(&api.Webhook{}, "webhooks")
+	presenters.RegisterKind(api.Webhook{}, "Webhook")
+	presenters.RegisterKind(&api.Webhook{}, "Webhook")
+	presenters.RegisterKind(api.WebhookDelivery{}, "WebhookDelivery")
+	presenters.RegisterKind(&api.WebhookDelivery{}, "WebhookDelivery")
+
+	db.RegisterMigration(migration())
+}
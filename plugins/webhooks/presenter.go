@@ -0,0 +1,156 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/api/presenters"
+	"github.com/openshift-online/rh-trex-ai/pkg/util"
+)
+
+// Webhook is the REST representation of an api.Webhook. Webhooks are an operational resource and are
+// not part of the generated openapi client. The secret is write-only, it is never presented.
+type Webhook struct {
+	ID        string     `json:"id,omitempty"`
+	Kind      string     `json:"kind,omitempty"`
+	Href      string     `json:"href,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	URL       string     `json:"url"`
+	Secret    string     `json:"secret,omitempty"`
+	Source    string     `json:"source,omitempty"`
+	Filter    string     `json:"filter,omitempty"`
+}
+
+// WebhookPatchRequest changes the fields it sets
+type WebhookPatchRequest struct {
+	URL    *string `json:"url,omitempty"`
+	Secret *string `json:"secret,omitempty"`
+	Source *string `json:"source,omitempty"`
+	Filter *string `json:"filter,omitempty"`
+}
+
+type WebhookList struct {
+	Kind     string    `json:"kind"`
+	Page     int32     `json:"page"`
+	Size     int32     `json:"size"`
+	Total    int32     `json:"total"`
+	Continue string    `json:"continue,omitempty"`
+	Items    []Webhook `json:"items"`
+}
+
+// WebhookDelivery is the REST representation of an api.WebhookDelivery, an attempt to deliver an event
+type WebhookDelivery struct {
+	ID          string          `json:"id"`
+	Kind        string          `json:"kind"`
+	CreatedAt   time.Time       `json:"created_at"`
+	WebhookID   string          `json:"webhook_id"`
+	EventID     string          `json:"event_id"`
+	Source      string          `json:"source"`
+	SourceID    string          `json:"source_id"`
+	EventType   string          `json:"event_type"`
+	Attempt     int             `json:"attempt"`
+	Status      string          `json:"status"`
+	ScheduledAt time.Time       `json:"scheduled_at"`
+	AttemptedAt *time.Time      `json:"attempted_at,omitempty"`
+	StatusCode  int             `json:"status_code,omitempty"`
+	Error       string          `json:"error,omitempty"`
+	Payload     json.RawMessage `json:"payload,omitempty"`
+}
+
+type WebhookDeliveryList struct {
+	Kind     string            `json:"kind"`
+	Page     int32             `json:"page"`
+	Size     int32             `json:"size"`
+	Total    int32             `json:"total"`
+	Continue string            `json:"continue,omitempty"`
+	Items    []WebhookDelivery `json:"items"`
+}
+
+// WebhookEvent is the body POSTed to a webhook for an event
+type WebhookEvent struct {
+	WebhookID  string        `json:"webhook_id"`
+	EventID    string        `json:"event_id"`
+	Type       api.EventType `json:"type"`
+	Source     string        `json:"source"`
+	ResourceID string        `json:"resource_id"`
+	// ChangedFields is the field mask of an update, it is omitted when the update isn't known field by field
+	ChangedFields []string `json:"changed_fields,omitempty"`
+	// Object is the resource as it is presented by its API, it is omitted for deletes
+	Object interface{} `json:"object,omitempty"`
+}
+
+func ConvertWebhook(webhook Webhook) *api.Webhook {
+	return &api.Webhook{
+		URL:    webhook.URL,
+		Secret: webhook.Secret,
+		Source: webhook.Source,
+		Filter: webhook.Filter,
+	}
+}
+
+func PresentWebhook(webhook *api.Webhook) Webhook {
+	reference := presenters.PresentReference(webhook.ID, webhook)
+	return Webhook{
+		ID:        util.NilToEmptyString(reference.Id),
+		Kind:      util.NilToEmptyString(reference.Kind),
+		Href:      util.NilToEmptyString(reference.Href),
+		CreatedAt: presenters.PresentTime(webhook.CreatedAt),
+		UpdatedAt: presenters.PresentTime(webhook.UpdatedAt),
+		URL:       webhook.URL,
+		Source:    webhook.Source,
+		Filter:    webhook.Filter,
+	}
+}
+
+func applyWebhookPatch(webhook *api.Webhook, patch WebhookPatchRequest) {
+	if patch.URL != nil {
+		webhook.URL = *patch.URL
+	}
+	if patch.Secret != nil {
+		webhook.Secret = *patch.Secret
+	}
+	if patch.Source != nil {
+		webhook.Source = *patch.Source
+	}
+	if patch.Filter != nil {
+		webhook.Filter = *patch.Filter
+	}
+}
+
+func PresentWebhookDelivery(delivery *api.WebhookDelivery) WebhookDelivery {
+	var payload json.RawMessage
+	if delivery.Payload != "" {
+		payload = json.RawMessage(delivery.Payload)
+	}
+	return WebhookDelivery{
+		ID:          delivery.ID,
+		Kind:        util.NilToEmptyString(presenters.ObjectKind(delivery)),
+		CreatedAt:   delivery.CreatedAt,
+		WebhookID:   delivery.WebhookID,
+		EventID:     delivery.EventID,
+		Source:      delivery.Source,
+		SourceID:    delivery.SourceID,
+		EventType:   string(delivery.EventType),
+		Attempt:     delivery.Attempt,
+		Status:      string(delivery.Status),
+		ScheduledAt: delivery.ScheduledAt,
+		AttemptedAt: delivery.AttemptedAt,
+		StatusCode:  delivery.StatusCode,
+		Error:       delivery.Error,
+		Payload:     payload,
+	}
+}
+
+func PresentWebhookEvent(webhook *api.Webhook, event *api.Event, object interface{}) WebhookEvent {
+	return WebhookEvent{
+		WebhookID:     webhook.ID,
+		EventID:       event.ID,
+		Type:          event.EventType,
+		Source:        event.Source,
+		ResourceID:    event.SourceID,
+		ChangedFields: event.FieldMask(),
+		Object:        object,
+	}
+}
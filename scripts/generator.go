@@ -10,6 +10,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"text/template"
 	"time"
@@ -46,14 +48,13 @@ var (
 )
 
 func init() {
-	_ = flag.Set("logtostderr", "true")
 	flags := pflag.CommandLine
 	flags.AddGoFlagSet(flag.CommandLine)
 
 	flags.StringVar(&kind, "kind", kind, "the name of the kind.  e.g Account or User")
 	flags.StringVar(&repo, "repo", repo, "the name of the repo.  e.g github.com/yourproject")
 	flags.StringVar(&project, "project", project, "the name of the project.  e.g rh-trex")
-	flags.StringVar(&fields, "fields", fields, "comma-separated list of custom fields in format name:type (e.g. 'name:string,age:int,active:bool')")
+	flags.StringVar(&fields, "fields", fields, "comma-separated list of custom fields in format name:type (e.g. 'name:string,age:int,active:bool'), foreign keys as parent_id:fk[:cascade|restrict], many-to-many links as other_kind:m2m. Fields take constraints after their type, e.g. 'name:string:required:max=64:pattern=^[A-Z]', see scripts/generator.md")
 	flags.StringVar(&plural, "plural", plural, "the plural form of the kind. If not provided, uses irregular plurals map or adds 's'")
 	flags.StringVar(&library, "library", library, "the module path of the rh-trex-ai library (e.g. github.com/openshift-online/rh-trex-ai)")
 }
@@ -75,7 +76,11 @@ func pluralize(word string) string {
 	if plural != "" {
 		return plural
 	}
-	
+	return pluralizeWord(word)
+}
+
+// pluralizeWord is pluralize without the --plural override, for the names of other Kinds
+func pluralizeWord(word string) string {
 	wordLower := strings.ToLower(word)
 	
 	// Check for irregular plurals by examining word endings  
@@ -120,7 +125,7 @@ func main() {
 	pflag.Parse()
 
 	// Parse custom fields
-	parsedFields, err := parseFields(fields)
+	parsedFields, foreignKeys, links, err := parseFields(kind, fields)
 	if err != nil {
 		panic(fmt.Sprintf("Error parsing fields: %v", err))
 	}
@@ -176,6 +181,9 @@ func main() {
 			KindLowerSingular:   kindLowerCamel,
 			KindSnakeCasePlural: kindPluralSnake,
 			Fields:              parsedFields,
+			ForeignKeys:         foreignKeys,
+			Links:               links,
+			RelatedPlugins:      relatedPlugins(foreignKeys, links),
 		}
 
 		now := time.Now()
@@ -311,44 +319,148 @@ func toCamelCase(s string) string {
 	return strings.ToLower(string(pascal[0])) + pascal[1:]
 }
 
-func parseFields(fieldsStr string) ([]Field, error) {
+// parseFields parses the --fields flag into the columns of the Kind, its foreign keys and its links
+func parseFields(kind, fieldsStr string) ([]Field, []ForeignKey, []Link, error) {
 	if fieldsStr == "" {
-		return []Field{}, nil
+		return []Field{}, nil, nil, nil
 	}
 
 	var fields []Field
+	var foreignKeys []ForeignKey
+	var links []Link
 	fieldPairs := strings.Split(fieldsStr, ",")
 	for _, pair := range fieldPairs {
 		parts := strings.Split(strings.TrimSpace(pair), ":")
-		if len(parts) < 2 || len(parts) > 3 {
-			return nil, fmt.Errorf("invalid field format: %s (expected name:type or name:type:required)", pair)
+		if len(parts) < 2 {
+			return nil, nil, nil, fmt.Errorf("invalid field format: %s (expected name:type, name:type:required, parent_id:fk:cascade or other_kind:m2m)", pair)
 		}
 
 		name := strings.TrimSpace(parts[0])
 		fieldType := strings.TrimSpace(parts[1])
+
+		if fieldType == "fk" {
+			if len(parts) > 3 {
+				return nil, nil, nil, fmt.Errorf("invalid foreign key: %s (expected parent_id:fk[:cascade|restrict])", pair)
+			}
+			onDelete := "restrict"
+			if len(parts) == 3 {
+				onDelete = strings.TrimSpace(parts[2])
+			}
+			foreignKey, err := mapForeignKey(name, onDelete)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			foreignKeys = append(foreignKeys, foreignKey)
+			continue
+		}
+
+		if fieldType == "m2m" {
+			if len(parts) > 2 {
+				return nil, nil, nil, fmt.Errorf("invalid link: %s (expected other_kind:m2m)", pair)
+			}
+			links = append(links, mapLink(kind, name))
+			continue
+		}
+
 		nullable := true // Default to nullable
 
 		// Check for :required or :optional suffix
-		if len(parts) == 3 {
-			modifier := strings.TrimSpace(parts[2])
+		var constraints []string
+		for _, part := range parts[2:] {
+			modifier := strings.TrimSpace(part)
 			if modifier == "required" {
 				nullable = false
 			} else if modifier == "optional" {
 				nullable = true
 			} else {
-				return nil, fmt.Errorf("invalid field modifier: %s (expected 'required' or 'optional')", modifier)
+				constraints = append(constraints, modifier)
 			}
 		}
 
 		field, err := mapFieldType(name, fieldType, nullable)
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, err
+		}
+		if err := field.addConstraints(constraints); err != nil {
+			return nil, nil, nil, err
 		}
 
 		fields = append(fields, field)
 	}
 
-	return fields, nil
+	return fields, foreignKeys, links, nil
+}
+
+// mapForeignKey maps a parent_id:fk field to the relationship of the Kind to its parent Kind. The column
+// is always nullable, a child can be created before it is attached to a parent.
+func mapForeignKey(name, onDelete string) (ForeignKey, error) {
+	if !strings.HasSuffix(name, "_id") {
+		return ForeignKey{}, fmt.Errorf("invalid foreign key: %s (expected <parent>_id, e.g. dinosaur_id)", name)
+	}
+	if onDelete != "cascade" && onDelete != "restrict" {
+		return ForeignKey{}, fmt.Errorf("invalid foreign key modifier: %s (expected 'cascade' or 'restrict')", onDelete)
+	}
+
+	parentKind := toPascalCase(strings.TrimSuffix(name, "_id"))
+	parentLowerSingular := strings.ToLower(string(parentKind[0])) + parentKind[1:]
+	field, err := mapFieldType(name, "string", true)
+	if err != nil {
+		return ForeignKey{}, err
+	}
+	field.Name = parentKind + "ID"
+	field.NameCamelCase = parentLowerSingular + "ID"
+
+	return ForeignKey{
+		Field:                   field,
+		ClientName:              toPascalCase(name),
+		ParentKind:              parentKind,
+		ParentKindPlural:        pluralizeWord(parentKind),
+		ParentKindLowerSingular: parentLowerSingular,
+		ParentKindLowerPlural:   pluralizeWord(parentLowerSingular),
+		ParentTable:             toSnakeCase(pluralizeWord(parentKind)),
+		OnDelete:                onDelete,
+		OnDeleteConst:           "services.OnDelete" + toPascalCase(onDelete),
+	}, nil
+}
+
+// mapLink maps an other_kind:m2m field to the many-to-many relationship of the Kind to the other Kind. The
+// Kind owns the join table, e.g. scientist_dinosaurs for Scientist and dinosaur:m2m, and the routes of the links.
+func mapLink(kind, name string) Link {
+	linkedKind := toPascalCase(name)
+	linkedLowerSingular := strings.ToLower(string(linkedKind[0])) + linkedKind[1:]
+	linkedTable := toSnakeCase(pluralizeWord(linkedKind))
+	return Link{
+		LinkedKind:              linkedKind,
+		LinkedKindPlural:        pluralizeWord(linkedKind),
+		LinkedKindLowerSingular: linkedLowerSingular,
+		LinkedKindLowerPlural:   pluralizeWord(linkedLowerSingular),
+		LinkedTable:             linkedTable,
+		JoinKind:                kind + linkedKind,
+		JoinTable:               toSnakeCase(kind) + "_" + linkedTable,
+		OwnerKey:                toSnakeCase(kind) + "_id",
+		LinkedKey:               toSnakeCase(linkedKind) + "_id",
+		LinkedKeyName:           linkedKind + "ID",
+		LinkedKeyCamelCase:      linkedLowerSingular + "ID",
+	}
+}
+
+// relatedPlugins lists the plugins of the parents and the linked Kinds once each, for the imports
+func relatedPlugins(foreignKeys []ForeignKey, links []Link) []string {
+	var plugins []string
+	seen := map[string]bool{}
+	add := func(plugin string) {
+		if !seen[plugin] {
+			seen[plugin] = true
+			plugins = append(plugins, plugin)
+		}
+	}
+	for _, foreignKey := range foreignKeys {
+		add(foreignKey.ParentKindLowerPlural)
+	}
+	for _, link := range links {
+		add(link.LinkedKindLowerPlural)
+	}
+	return plugins
 }
 
 func mapFieldType(name, fieldType string, nullable bool) (Field, error) {
@@ -435,6 +547,123 @@ type Field struct {
 	Nullable           bool
 	PointerType        string
 	NeedsIntConversion bool
+
+	// the constraints of the field, see validation.Rule
+	Immutable bool
+	MinLength int
+	MaxLength int
+	Minimum   string
+	Maximum   string
+	Pattern   string
+	Enum      []string
+}
+
+// addConstraints parses the constraint modifiers of a field: immutable, min=N and max=N, the length of a
+// string or the range of a number, pattern=RE and enum=a|b|c. Strings are at most 255 characters long unless
+// max says otherwise.
+func (f *Field) addConstraints(modifiers []string) error {
+	if f.Type == "string" {
+		f.MaxLength = 255
+	}
+	for _, modifier := range modifiers {
+		key, value, _ := strings.Cut(modifier, "=")
+		switch {
+		case key == "immutable" && value == "":
+			f.Immutable = true
+		case (key == "min" || key == "max") && f.Type == "string":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return fmt.Errorf("invalid field constraint: %s of %s (expected a length)", modifier, f.NameSnakeCase)
+			}
+			if key == "min" {
+				f.MinLength = n
+			} else {
+				f.MaxLength = n
+			}
+		case (key == "min" || key == "max") && f.OpenAPIType != "boolean" && f.Type != "time":
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return fmt.Errorf("invalid field constraint: %s of %s (expected a number)", modifier, f.NameSnakeCase)
+			}
+			if key == "min" {
+				f.Minimum = value
+			} else {
+				f.Maximum = value
+			}
+		case key == "pattern" && f.Type == "string":
+			if _, err := regexp.Compile(value); err != nil {
+				return fmt.Errorf("invalid field constraint: %s of %s: %v", modifier, f.NameSnakeCase, err)
+			}
+			f.Pattern = value
+		case key == "enum" && f.Type == "string" && value != "":
+			f.Enum = strings.Split(value, "|")
+		default:
+			return fmt.Errorf("invalid field modifier: %s of %s %s (expected required, optional, immutable, min=, max=, pattern= or enum=)", modifier, f.Type, f.NameSnakeCase)
+		}
+	}
+	return nil
+}
+
+// OpenAPIConstraints are the keywords of the field's schema for its constraints
+func (f Field) OpenAPIConstraints() []string {
+	var keywords []string
+	if f.MinLength > 0 {
+		keywords = append(keywords, fmt.Sprintf("minLength: %d", f.MinLength))
+	}
+	if f.MaxLength > 0 {
+		keywords = append(keywords, fmt.Sprintf("maxLength: %d", f.MaxLength))
+	}
+	if f.Pattern != "" {
+		keywords = append(keywords, fmt.Sprintf("pattern: '%s'", strings.ReplaceAll(f.Pattern, "'", "''")))
+	}
+	if f.Minimum != "" {
+		keywords = append(keywords, "minimum: "+f.Minimum)
+	}
+	if f.Maximum != "" {
+		keywords = append(keywords, "maximum: "+f.Maximum)
+	}
+	if len(f.Enum) > 0 {
+		values := make([]string, len(f.Enum))
+		for i, value := range f.Enum {
+			values[i] = fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", "''"))
+		}
+		keywords = append(keywords, fmt.Sprintf("enum: [%s]", strings.Join(values, ", ")))
+	}
+	if f.Immutable {
+		keywords = append(keywords, "x-immutable: true")
+	}
+	return keywords
+}
+
+// ForeignKey is a <parent>_id column of a Kind that refers to its parent Kind, see services.Relationship
+type ForeignKey struct {
+	Field
+	// ClientName is the name of the field in the openapi client and the protos, e.g. DinosaurId
+	ClientName              string
+	ParentKind              string
+	ParentKindPlural        string
+	ParentKindLowerSingular string
+	ParentKindLowerPlural   string
+	ParentTable             string
+	// OnDelete is cascade or restrict, OnDeleteConst the matching services.OnDeleteAction
+	OnDelete      string
+	OnDeleteConst string
+}
+
+// Link is a many-to-many relationship of a Kind to another Kind through a join table, see services.Link
+type Link struct {
+	LinkedKind              string
+	LinkedKindPlural        string
+	LinkedKindLowerSingular string
+	LinkedKindLowerPlural   string
+	LinkedTable             string
+	// JoinKind is the model of the rows of JoinTable, e.g. ScientistDinosaur of scientist_dinosaurs
+	JoinKind  string
+	JoinTable string
+	// OwnerKey and LinkedKey are the columns of the join table, e.g. scientist_id and dinosaur_id
+	OwnerKey           string
+	LinkedKey          string
+	LinkedKeyName      string
+	LinkedKeyCamelCase string
 }
 
 type myWriter struct {
@@ -451,6 +680,10 @@ type myWriter struct {
 	KindSnakeCasePlural string
 	ID                  string
 	Fields              []Field
+	ForeignKeys         []ForeignKey
+	Links               []Link
+	// RelatedPlugins are the plugins of the parents and the linked Kinds, e.g. dinosaurs
+	RelatedPlugins []string
 }
 
 func modifyOpenapi(mainPath string, kindPath string) {
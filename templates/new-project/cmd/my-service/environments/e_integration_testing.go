@@ -45,13 +45,13 @@ func (e *IntegrationTestingEnvImpl) OverrideClients(c *pkgenv.Clients) error {
 
 func (e *IntegrationTestingEnvImpl) Flags() map[string]string {
 	return map[string]string{
-		"v":                    "0",
-		"logtostderr":          "true",
+		"log-level":            "0",
 		"api-base-url":         "https://api.integration.openshift.com",
 		"enable-https":         "false",
 		"enable-metrics-https": "false",
 		"enable-authz":         "true",
 		"debug":                "false",
 		"enable-mock":          "true",
+		"tracing-exporter":     "memory",
 	}
 }
@@ -1,14 +1,18 @@
 package main
 
 import (
-	"github.com/golang/glog"
-
 	localapi "github.com/example/my-service/pkg/api"
 	pkgcmd "github.com/openshift-online/rh-trex-ai/pkg/cmd"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 
 	_ "github.com/example/my-service/cmd/my-service/environments"
+	_ "github.com/openshift-online/rh-trex-ai/plugins/auditevents"
 	_ "github.com/openshift-online/rh-trex-ai/plugins/events"
 	_ "github.com/openshift-online/rh-trex-ai/plugins/generic"
+	_ "github.com/openshift-online/rh-trex-ai/plugins/idempotencykeys"
+	_ "github.com/openshift-online/rh-trex-ai/plugins/ratelimits"
+	_ "github.com/openshift-online/rh-trex-ai/plugins/rolebindings"
+	_ "github.com/openshift-online/rh-trex-ai/plugins/webhooks"
 )
 
 func main() {
@@ -19,6 +23,6 @@ func main() {
 	)
 
 	if err := rootCmd.Execute(); err != nil {
-		glog.Fatalf("error running command: %v", err)
+		logger.Fatalf("error running command: %v", err)
 	}
 }
\ No newline at end of file
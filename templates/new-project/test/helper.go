@@ -9,7 +9,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/golang/glog"
 	"github.com/spf13/pflag"
 
 	amv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
@@ -17,6 +16,7 @@ import (
 	"github.com/example/my-service/cmd/my-service/environments"
 	localapi "github.com/example/my-service/pkg/api"
 	"github.com/example/my-service/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 	pkgserver "github.com/openshift-online/rh-trex-ai/pkg/server"
 	"github.com/openshift-online/rh-trex-ai/pkg/testutil"
 )
@@ -42,17 +42,17 @@ func NewHelper(t *testing.T) *Helper {
 		env := environments.Environment()
 		err := env.AddFlags(pflag.CommandLine)
 		if err != nil {
-			glog.Fatalf("Unable to add environment flags: %s", err.Error())
+			logger.Fatalf("Unable to add environment flags: %s", err.Error())
 		}
 		if logLevel := os.Getenv("LOGLEVEL"); logLevel != "" {
-			glog.Infof("Using custom loglevel: %s", logLevel)
-			pflag.CommandLine.Set("-v", logLevel)
+			logger.Infof("Using custom loglevel: %s", logLevel)
+			pflag.CommandLine.Set("log-level", logLevel)
 		}
 		pflag.Parse()
 
 		err = env.Initialize()
 		if err != nil {
-			glog.Fatalf("Unable to initialize testing environment: %s", err.Error())
+			logger.Fatalf("Unable to initialize testing environment: %s", err.Error())
 		}
 
 		base := testutil.NewBaseHelper(
@@ -100,17 +100,17 @@ func (helper *Helper) Teardown() {
 func (helper *Helper) startAPIServer() {
 	specData, err := localapi.GetOpenAPISpec()
 	if err != nil {
-		glog.Fatalf("Unable to load OpenAPI spec: %s", err)
+		logger.Fatalf("Unable to load OpenAPI spec: %s", err)
 	}
 	helper.APIServer = pkgserver.NewDefaultAPIServer(environments.Environment(), specData)
 	listener, err := helper.APIServer.Listen()
 	if err != nil {
-		glog.Fatalf("Unable to start Test API server: %s", err)
+		logger.Fatalf("Unable to start Test API server: %s", err)
 	}
 	go func() {
-		glog.V(10).Info("Test API server started")
+		logger.V(10).Info("Test API server started")
 		helper.APIServer.Serve(listener)
-		glog.V(10).Info("Test API server stopped")
+		logger.V(10).Info("Test API server stopped")
 	}()
 }
 
@@ -124,37 +124,37 @@ func (helper *Helper) stopAPIServer() error {
 func (helper *Helper) startMetricsServer() {
 	helper.MetricsServer = pkgserver.NewDefaultMetricsServer(environments.Environment())
 	go func() {
-		glog.V(10).Info("Test Metrics server started")
+		logger.V(10).Info("Test Metrics server started")
 		helper.MetricsServer.Start()
-		glog.V(10).Info("Test Metrics server stopped")
+		logger.V(10).Info("Test Metrics server stopped")
 	}()
 }
 
 func (helper *Helper) stopMetricsServer() {
 	if err := helper.MetricsServer.Stop(); err != nil {
-		glog.Fatalf("Unable to stop metrics server: %s", err.Error())
+		logger.Fatalf("Unable to stop metrics server: %s", err.Error())
 	}
 }
 
 func (helper *Helper) startHealthCheckServer() {
 	helper.HealthCheckServer = pkgserver.NewDefaultHealthCheckServer(environments.Environment())
 	go func() {
-		glog.V(10).Info("Test health check server started")
+		logger.V(10).Info("Test health check server started")
 		helper.HealthCheckServer.Start()
-		glog.V(10).Info("Test health check server stopped")
+		logger.V(10).Info("Test health check server stopped")
 	}()
 }
 
 func (helper *Helper) RestartServer() {
 	helper.stopAPIServer()
 	helper.startAPIServer()
-	glog.V(10).Info("Test API server restarted")
+	logger.V(10).Info("Test API server restarted")
 }
 
 func (helper *Helper) RestartMetricsServer() {
 	helper.stopMetricsServer()
 	helper.startMetricsServer()
-	glog.V(10).Info("Test metrics server restarted")
+	logger.V(10).Info("Test metrics server restarted")
 }
 
 func (helper *Helper) NewApiClient() *openapi.APIClient {
@@ -5,11 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
-	"github.com/golang/glog"
 	"github.com/spf13/pflag"
 
 	amv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
@@ -18,8 +18,17 @@ import (
 	"github.com/openshift-online/rh-trex-ai/pkg/api"
 	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
 	"github.com/openshift-online/rh-trex-ai/pkg/config"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+	"github.com/openshift-online/rh-trex-ai/pkg/logger"
 	pkgserver "github.com/openshift-online/rh-trex-ai/pkg/server"
 	"github.com/openshift-online/rh-trex-ai/pkg/testutil"
+
+	// role bindings, idempotency keys, the audit trail and webhooks are part of every server the helper starts
+	_ "github.com/openshift-online/rh-trex-ai/plugins/auditevents"
+	_ "github.com/openshift-online/rh-trex-ai/plugins/idempotencykeys"
+	_ "github.com/openshift-online/rh-trex-ai/plugins/ratelimits"
+	_ "github.com/openshift-online/rh-trex-ai/plugins/rolebindings"
+	_ "github.com/openshift-online/rh-trex-ai/plugins/webhooks"
 )
 
 const (
@@ -55,17 +64,17 @@ func NewHelper(t *testing.T) *Helper {
 		env.Name = "integration_testing"
 		err := env.AddFlags(pflag.CommandLine)
 		if err != nil {
-			glog.Fatalf("Unable to add environment flags: %s", err.Error())
+			logger.Fatalf("Unable to add environment flags: %s", err.Error())
 		}
 		if logLevel := os.Getenv("LOGLEVEL"); logLevel != "" {
-			glog.Infof("Using custom loglevel: %s", logLevel)
-			pflag.CommandLine.Set("-v", logLevel)
+			logger.Infof("Using custom loglevel: %s", logLevel)
+			pflag.CommandLine.Set("log-level", logLevel)
 		}
 		pflag.Parse()
 
 		err = env.Initialize()
 		if err != nil {
-			glog.Fatalf("Unable to initialize testing environment: %s", err.Error())
+			logger.Fatalf("Unable to initialize testing environment: %s", err.Error())
 		}
 
 		base := testutil.NewBaseHelper(
@@ -117,17 +126,17 @@ func (helper *Helper) Teardown() {
 func (helper *Helper) startAPIServer() {
 	specData, err := api.GetOpenAPISpec()
 	if err != nil {
-		glog.Fatalf("Unable to load OpenAPI spec: %s", err)
+		logger.Fatalf("Unable to load OpenAPI spec: %s", err)
 	}
 	helper.APIServer = pkgserver.NewDefaultAPIServer(environments.Environment(), specData)
 	listener, err := helper.APIServer.Listen()
 	if err != nil {
-		glog.Fatalf("Unable to start Test API server: %s", err)
+		logger.Fatalf("Unable to start Test API server: %s", err)
 	}
 	go func() {
-		glog.V(10).Info("Test API server started")
+		logger.V(10).Info("Test API server started")
 		helper.APIServer.Serve(listener)
-		glog.V(10).Info("Test API server stopped")
+		logger.V(10).Info("Test API server stopped")
 	}()
 }
 
@@ -141,24 +150,24 @@ func (helper *Helper) stopAPIServer() error {
 func (helper *Helper) startMetricsServer() {
 	helper.MetricsServer = pkgserver.NewDefaultMetricsServer(environments.Environment())
 	go func() {
-		glog.V(10).Info("Test Metrics server started")
+		logger.V(10).Info("Test Metrics server started")
 		helper.MetricsServer.Start()
-		glog.V(10).Info("Test Metrics server stopped")
+		logger.V(10).Info("Test Metrics server stopped")
 	}()
 }
 
 func (helper *Helper) stopMetricsServer() {
 	if err := helper.MetricsServer.Stop(); err != nil {
-		glog.Fatalf("Unable to stop metrics server: %s", err.Error())
+		logger.Fatalf("Unable to stop metrics server: %s", err.Error())
 	}
 }
 
 func (helper *Helper) startHealthCheckServer() {
 	helper.HealthCheckServer = pkgserver.NewDefaultHealthCheckServer(environments.Environment())
 	go func() {
-		glog.V(10).Info("Test health check server started")
+		logger.V(10).Info("Test health check server started")
 		helper.HealthCheckServer.Start()
-		glog.V(10).Info("Test health check server stopped")
+		logger.V(10).Info("Test health check server stopped")
 	}()
 }
 
@@ -184,12 +193,12 @@ func (helper *Helper) startGRPCServer() {
 	helper.GRPCServer = pkgserver.NewDefaultGRPCServer(env)
 	listener, err := helper.GRPCServer.Listen()
 	if err != nil {
-		glog.Fatalf("Unable to start Test gRPC server: %s", err)
+		logger.Fatalf("Unable to start Test gRPC server: %s", err)
 	}
 	go func() {
-		glog.V(10).Info("Test gRPC server started")
+		logger.V(10).Info("Test gRPC server started")
 		helper.GRPCServer.Serve(listener)
-		glog.V(10).Info("Test gRPC server stopped")
+		logger.V(10).Info("Test gRPC server stopped")
 	}()
 }
 
@@ -209,17 +218,17 @@ func (helper *Helper) GRPCAddress() string {
 func (helper *Helper) RestartServer() {
 	helper.stopAPIServer()
 	helper.startAPIServer()
-	glog.V(10).Info("Test API server restarted")
+	logger.V(10).Info("Test API server restarted")
 }
 
 func (helper *Helper) RestartMetricsServer() {
 	helper.stopMetricsServer()
 	helper.startMetricsServer()
-	glog.V(10).Info("Test metrics server restarted")
+	logger.V(10).Info("Test metrics server restarted")
 }
 
 func (helper *Helper) Reset() {
-	glog.Infof("Reseting testing environment")
+	logger.Infof("Reseting testing environment")
 	env := environments.Environment()
 	env.Config = config.NewApplicationConfig()
 
@@ -229,7 +238,7 @@ func (helper *Helper) Reset() {
 
 	err := env.Initialize()
 	if err != nil {
-		glog.Fatalf("Unable to reset testing environment: %s", err.Error())
+		logger.Fatalf("Unable to reset testing environment: %s", err.Error())
 	}
 	helper.AppConfig = env.Config
 	helper.RestartServer()
@@ -246,6 +255,18 @@ func (helper *Helper) NewAuthenticatedContext(account *amv1.Account) context.Con
 	return context.WithValue(context.Background(), openapi.ContextAccessToken, tokenString)
 }
 
+// GrantRole binds a role of the authorization policy to the account
+func (helper *Helper) GrantRole(account *amv1.Account, role string) {
+	roleBindingDao := dao.NewRoleBindingDao(&helper.DBFactory)
+	_, err := roleBindingDao.Create(context.Background(), &api.RoleBinding{
+		Username: strings.ToLower(account.Username()),
+		Role:     role,
+	})
+	if err != nil {
+		helper.T.Errorf("Unable to grant role %s: %s", role, err)
+	}
+}
+
 func (helper *Helper) OpenapiError(err error) openapi.Error {
 	generic := err.(openapi.GenericOpenAPIError)
 	var exErr openapi.Error
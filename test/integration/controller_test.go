@@ -23,7 +23,7 @@ func TestControllerRacing(t *testing.T) {
 
 	account := h.NewRandAccount()
 	authCtx := h.NewAuthenticatedContext(account)
-	dao := dao.NewEventDao(&h.Env().Database.SessionFactory)
+	dao := dao.NewEventDao(&h.Env().Database.SessionFactory, h.Env().Database.EventBus)
 
 	// The handler filters the events by source id/type/reconciled, and only record
 	// the event with create type. Due to the event lock, each create event
@@ -61,7 +61,7 @@ func TestControllerRacing(t *testing.T) {
 					db.NewAdvisoryLockFactory(h.Env().Database.SessionFactory),
 					events.Service(&h.Env().Services),
 				),
-				SessionFactory: h.Env().Database.SessionFactory,
+				EventBus: h.Env().Database.EventBus,
 			}
 
 			s.KindControllerManager.Add(&controllers.ControllerConfig{
@@ -0,0 +1,92 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/auth"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+	"github.com/openshift-online/rh-trex-ai/test"
+)
+
+func TestEventAdmin(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	ctx := context.Background()
+	eventDao := dao.NewEventDao(&h.Env().Database.SessionFactory, h.Env().Database.EventBus)
+	eventService := services.NewEventService(db.NewAdvisoryLockFactory(h.Env().Database.SessionFactory), eventDao, h.Env().Database.SessionFactory)
+
+	// a source of its own keeps the events of other tests out of the stats
+	source := "Admin" + h.NewID()
+	now := time.Now()
+	for _, event := range []*api.Event{
+		{Source: source, SourceID: "a", EventType: api.CreateEventType},
+		{Source: source, SourceID: "b", EventType: api.UpdateEventType, Attempts: 2, NextAttemptAt: &now},
+		{Source: source, SourceID: "c", EventType: api.DeleteEventType, Attempts: 10, DeadLetteredAt: &now},
+		{Source: source, SourceID: "d", EventType: api.CreateEventType, ReconciledDate: &now},
+	} {
+		_, err := eventDao.Create(ctx, event)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	stats, svcErr := eventService.KindStats(ctx, source)
+	Expect(svcErr).To(BeNil())
+	Expect(stats.Total).To(Equal(int64(4)))
+	Expect(stats.Pending).To(Equal(int64(2)))
+	Expect(stats.Retrying).To(Equal(int64(1)))
+	Expect(stats.DeadLettered).To(Equal(int64(1)))
+	Expect(stats.OldestPendingAt).NotTo(BeNil())
+	Expect(stats.Pause).To(BeNil())
+
+	// the sync controller leaves the events of a paused source alone
+	stats, svcErr = eventService.Pause(ctx, source)
+	Expect(svcErr).To(BeNil())
+	Expect(stats.Pause).NotTo(BeNil())
+	unreconciled, svcErr := eventService.FindUnreconciled(ctx, 0)
+	Expect(svcErr).To(BeNil())
+	for _, event := range unreconciled {
+		Expect(event.Source).NotTo(Equal(source))
+	}
+
+	stats, svcErr = eventService.Resume(ctx, source)
+	Expect(svcErr).To(BeNil())
+	Expect(stats.Pause).To(BeNil())
+	unreconciled, svcErr = eventService.FindUnreconciled(ctx, 0)
+	Expect(svcErr).To(BeNil())
+	found := 0
+	for _, event := range unreconciled {
+		if event.Source == source {
+			found++
+		}
+	}
+	Expect(found).To(Equal(2))
+
+	stats, svcErr = eventService.ReprocessKind(auth.SetUsernameContext(ctx, "alice"), source)
+	Expect(svcErr).To(BeNil())
+	Expect(stats.Pending).To(Equal(int64(3)))
+	Expect(stats.Retrying).To(Equal(int64(0)))
+	Expect(stats.DeadLettered).To(Equal(int64(0)))
+
+	// only the unreconciled events are requeued and record who reprocessed them
+	events, err := eventDao.FindBySourceAfter(ctx, source, 0, 10)
+	Expect(err).NotTo(HaveOccurred())
+	for _, event := range events {
+		if event.SourceID == "d" {
+			Expect(event.ReconciledDate).NotTo(BeNil())
+			Expect(event.ReprocessedBy).To(BeEmpty())
+		} else {
+			Expect(event.ReprocessedBy).To(Equal("alice"))
+			Expect(event.ReprocessedAt).NotTo(BeNil())
+		}
+	}
+
+	all, svcErr := eventService.Stats(ctx)
+	Expect(svcErr).To(BeNil())
+	Expect(all).To(ContainElement(HaveField("Source", source)))
+}
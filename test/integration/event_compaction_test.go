@@ -0,0 +1,67 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/dao"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/pkg/services"
+	"github.com/openshift-online/rh-trex-ai/test"
+)
+
+func TestEventCompaction(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	ctx := context.Background()
+	eventDao := dao.NewEventDao(&h.Env().Database.SessionFactory, h.Env().Database.EventBus)
+	eventService := services.NewEventService(db.NewAdvisoryLockFactory(h.Env().Database.SessionFactory), eventDao, h.Env().Database.SessionFactory)
+
+	source := "Compaction" + h.NewID()
+	yearAgo := time.Now().Add(-365 * 24 * time.Hour)
+	created := map[string]*api.Event{}
+	for _, named := range []struct {
+		name  string
+		event *api.Event
+	}{
+		{"archived", &api.Event{Source: source, SourceID: "a", EventType: api.UpdateEventType, ReconciledDate: &yearAgo,
+			TraceParent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", LastError: "timeout", Attempts: 1}},
+		{"first", &api.Event{Source: source, SourceID: "b", EventType: api.UpdateEventType, ChangedFields: "species"}},
+		{"second", &api.Event{Source: source, SourceID: "b", EventType: api.UpdateEventType, ChangedFields: "name"}},
+	} {
+		// the updates are collapsed in creation order
+		time.Sleep(10 * time.Millisecond)
+		event, err := eventDao.Create(ctx, named.event)
+		Expect(err).NotTo(HaveOccurred())
+		created[named.name] = event
+	}
+
+	removed, svcErr := eventService.CollapseUpdates(ctx)
+	Expect(svcErr).To(BeNil())
+	Expect(removed).To(BeNumerically(">=", 1))
+	_, svcErr = eventService.Get(ctx, created["first"].ID)
+	Expect(svcErr).NotTo(BeNil())
+	Expect(svcErr.Is404()).To(BeTrue())
+	second, svcErr := eventService.Get(ctx, created["second"].ID)
+	Expect(svcErr).To(BeNil())
+	Expect(second.FieldMask()).To(ConsistOf("species", "name"))
+
+	removed, svcErr = eventService.PurgeReconciled(ctx, yearAgo.Add(time.Hour), true)
+	Expect(svcErr).To(BeNil())
+	Expect(removed).To(BeNumerically(">=", 1))
+	_, svcErr = eventService.Get(ctx, created["archived"].ID)
+	Expect(svcErr.Is404()).To(BeTrue())
+
+	// the archived event keeps every column
+	archived := &api.Event{}
+	err := h.DBFactory.New(ctx).Table("event_archives").Where("id = ?", created["archived"].ID).Take(archived).Error
+	Expect(err).NotTo(HaveOccurred())
+	Expect(archived.Seq).To(Equal(created["archived"].Seq))
+	Expect(archived.TraceParent).To(Equal(created["archived"].TraceParent))
+	Expect(archived.LastError).To(Equal("timeout"))
+	Expect(archived.UpdatedAt).To(BeTemporally("~", created["archived"].UpdatedAt, time.Millisecond))
+}
@@ -0,0 +1,52 @@
+package integration
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/ratelimit"
+	"github.com/openshift-online/rh-trex-ai/test"
+)
+
+func TestPostgresRateLimitStore(t *testing.T) {
+	h, _ := test.RegisterIntegration(t)
+
+	// two replicas take their tokens from the same buckets
+	replicas := []ratelimit.Store{
+		ratelimit.NewPostgresStore(h.Env().Database.SessionFactory),
+		ratelimit.NewPostgresStore(h.Env().Database.SessionFactory),
+	}
+	limit := ratelimit.Limit{Rate: 0.001, Burst: 10}
+	key := "default|user:" + h.NewID()
+
+	var wg sync.WaitGroup
+	results := make([]ratelimit.Result, 15)
+	errs := make([]error, len(results))
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = replicas[i%2].Take(context.Background(), key, limit)
+		}(i)
+	}
+	wg.Wait()
+	allowed := 0
+	for i, result := range results {
+		Expect(errs[i]).NotTo(HaveOccurred())
+		if result.Allowed {
+			allowed++
+		}
+	}
+	Expect(allowed).To(Equal(limit.Burst))
+
+	purged, err := replicas[0].PurgeIdle(context.Background(), time.Now().Add(time.Minute))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(purged).To(BeNumerically(">=", 1))
+	result, err := replicas[1].Take(context.Background(), key, limit)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(result.Allowed).To(BeTrue())
+}
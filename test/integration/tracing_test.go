@@ -0,0 +1,83 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/openshift-online/rh-trex-ai/pkg/api"
+	"github.com/openshift-online/rh-trex-ai/pkg/api/openapi"
+	"github.com/openshift-online/rh-trex-ai/pkg/controllers"
+	"github.com/openshift-online/rh-trex-ai/pkg/db"
+	"github.com/openshift-online/rh-trex-ai/plugins/events"
+	"github.com/openshift-online/rh-trex-ai/test"
+)
+
+func TestTracing(t *testing.T) {
+	h, client := test.RegisterIntegration(t)
+
+	account := h.NewRandAccount()
+	ctx := h.NewAuthenticatedContext(account)
+	tracing := h.Env().Tracing
+	tracing.Reset()
+
+	spanNamed := func(name string, match func(tracetest.SpanStub) bool) func() *tracetest.SpanStub {
+		return func() *tracetest.SpanStub {
+			for _, span := range tracing.Spans() {
+				if span.Name == name && match(span) {
+					return &span
+				}
+			}
+			return nil
+		}
+	}
+
+	dinosaur, _, err := client.DefaultAPI.ApiRhTrexAiV1DinosaursPost(ctx).Dinosaur(openapi.Dinosaur{Species: "traced"}).Execute()
+	Expect(err).NotTo(HaveOccurred())
+
+	// the span of the request ends once the response is written
+	var request *tracetest.SpanStub
+	Eventually(func() *tracetest.SpanStub {
+		request = spanNamed("POST /api/rh-trex-ai/v1/dinosaurs", func(tracetest.SpanStub) bool { return true })()
+		return request
+	}, 5*time.Second, 50*time.Millisecond).ShouldNot(BeNil())
+	traceID := request.SpanContext.TraceID()
+
+	insert := spanNamed("gorm.create", func(span tracetest.SpanStub) bool {
+		return span.SpanContext.TraceID() == traceID && span.Parent.SpanID() == request.SpanContext.SpanID()
+	})()
+	Expect(insert).NotTo(BeNil(), "the queries of the request are children of its span")
+	Expect(insert.Attributes).To(ContainElement(attribute.String("db.system.name", "postgresql")))
+
+	// the event carries the trace context of the request, the controller handling it links back to it
+	createEvents, svcErr := events.Service(&h.Env().Services).FindBySourceAndType(ctx, "Dinosaurs", api.CreateEventType)
+	Expect(svcErr).To(BeNil())
+	var event *api.Event
+	for _, e := range createEvents {
+		if e.SourceID == *dinosaur.Id {
+			event = e
+		}
+	}
+	Expect(event).NotTo(BeNil())
+	Expect(event.TraceParent).To(ContainSubstring(traceID.String()))
+
+	manager := controllers.NewKindControllerManager(
+		db.NewAdvisoryLockFactory(h.Env().Database.SessionFactory),
+		events.Service(&h.Env().Services),
+	)
+	manager.Handle(event.ID)
+
+	handle := spanNamed("KindControllerManager.Handle", func(span tracetest.SpanStub) bool {
+		return len(span.Links) > 0 && span.Links[0].SpanContext.TraceID() == traceID
+	})()
+	Expect(handle).NotTo(BeNil())
+	Expect(handle.SpanContext.TraceID()).NotTo(Equal(traceID))
+	lock := spanNamed("advisory_lock "+string(db.Events), func(span tracetest.SpanStub) bool {
+		return span.Parent.SpanID() == handle.SpanContext.SpanID()
+	})()
+	Expect(lock).NotTo(BeNil())
+	Expect(lock.Attributes).To(ContainElement(attribute.Bool("lock.acquired", true)))
+}